@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// dpu-agent is the DPU-side agent programming the VF representors for the split-forwarder running on the host
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+)
+
+func main() {
+	listenOn := flag.String("listen-on", "tcp://:5005", "DPU agent gRPC service URL")
+	uplink := flag.String("uplink", "p0", "DPU eswitch uplink net interface, only its VF representors are programmed")
+	certFile := flag.String("tls-cert", "", "DPU agent TLS certificate file")
+	keyFile := flag.String("tls-key", "", "DPU agent TLS private key file")
+	caFile := flag.String("tls-ca", "", "CA certificate file verifying the host forwarder client certificates")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	u, err := url.Parse(*listenOn)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid listen-on URL: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	if err := run(ctx, u, *uplink, *certFile, *keyFile, *caFile); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, listenOn *url.URL, uplink, certFile, keyFile, caFile string) error {
+	var tlsConfig *tls.Config
+	if certFile != "" || keyFile != "" || caFile != "" {
+		var err error
+		if tlsConfig, err = dpu.LoadServerTLSConfig(certFile, keyFile, caFile); err != nil {
+			return err
+		}
+	}
+
+	programmer, err := dpu.NewNetlinkProgrammer(nil, uplink)
+	if err != nil {
+		return err
+	}
+
+	return <-dpu.ListenAndServe(ctx, listenOn, dpu.NewServer(programmer), tlsConfig)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// sriovctl is the node operator debug CLI talking to the forwarder SR-IOV admin service
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovctl"
+)
+
+func main() {
+	socketPath := flag.String("socket", "/var/lib/networkservicemesh/sriov-admin.sock", "admin service unix socket path")
+	timeout := flag.Duration("timeout", 10*time.Second, "admin service call timeout")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <command> [args]\n", os.Args[0])
+		flag.PrintDefaults()
+		sriovctl.Usage(flag.CommandLine.Output())
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*socketPath, *timeout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(socketPath string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, cc, err := sriovadmin.Dial(ctx, socketPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cc.Close() }()
+
+	return sriovctl.Run(ctx, client, os.Stdout, flag.Args())
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpu
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+)
+
+// Client is the DPU agent gRPC service client, it is a Programmer delegating the calls to the agent
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient returns a new Client using the cc
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{
+		cc: cc,
+	}
+}
+
+// Dial returns a new Client connected to the DPU agent served on the connectTo URL with mTLS using the tlsConfig. Only
+// the unix connectTo URL can be dialed without TLS with the nil tlsConfig. The returned connection should be closed by
+// the caller.
+func Dial(ctx context.Context, connectTo *url.URL, tlsConfig *tls.Config, dialOptions ...grpc.DialOption) (*Client, *grpc.ClientConn, error) {
+	if err := checkTLSConfig(connectTo, tlsConfig); err != nil {
+		return nil, nil, err
+	}
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(connectTo),
+		append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, dialOptions...)...,
+	)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to dial DPU agent: %s", connectTo)
+	}
+	return NewClient(cc), cc, nil
+}
+
+// Configure configures the DPU-side representor for the connection
+func (c *Client) Configure(ctx context.Context, cfg *RepresentorConfig) error {
+	return c.invoke(ctx, ConfigureMethod, cfg)
+}
+
+// Release releases the DPU-side representor configured for the connection
+func (c *Client) Release(ctx context.Context, cfg *RepresentorConfig) error {
+	return c.invoke(ctx, ReleaseMethod, cfg)
+}
+
+func (c *Client) invoke(ctx context.Context, method string, cfg *RepresentorConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal representor config")
+	}
+	if err := c.cc.Invoke(ctx, method, wrapperspb.Bytes(data), new(emptypb.Empty)); err != nil {
+		return errors.Wrapf(err, "failed to call %s", method)
+	}
+	return nil
+}
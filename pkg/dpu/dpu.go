@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dpu provides the split-forwarder support for the DPU (SmartNIC) hosted physical functions: the resource pool
+// and the token logic run in the host forwarder, while the DPU-side representors are programmed by the agent running
+// on the DPU cores, called over gRPC
+package dpu
+
+import (
+	"context"
+)
+
+// RepresentorConfig is the DPU-side representor configuration requested for the host VF connection
+type RepresentorConfig struct {
+	// ConnectionID is the host connection ID the VF is selected for
+	ConnectionID string `json:"connectionID"`
+	// VFPCIAddress is the host VF PCI address
+	VFPCIAddress string `json:"vfPCIAddress"`
+	// Representor is the DPU-side VF representor net interface name
+	Representor string `json:"representor"`
+	// MTU is the connection MTU, 0 leaves the representor MTU unchanged
+	MTU uint32 `json:"mtu,omitempty"`
+}
+
+// Programmer programs the DPU eswitch for the host VF connections
+type Programmer interface {
+	// Configure configures the representor for the connection, it is called again with the same ConnectionID on the
+	// connection refresh
+	Configure(ctx context.Context, cfg *RepresentorConfig) error
+	// Release restores the representor configured for the connection
+	Release(ctx context.Context, cfg *RepresentorConfig) error
+}
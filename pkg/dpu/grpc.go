@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpu
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+)
+
+// Full gRPC method names of the DPU agent calls
+const (
+	ConfigureMethod = "/sriov.dpu.AgentService/Configure"
+	ReleaseMethod   = "/sriov.dpu.AgentService/Release"
+)
+
+const unixScheme = "unix"
+
+// RegisterAgentServer registers the DPU agent gRPC service. The service is registered without the generated proto
+// code, both Configure and Release take google.protobuf.BytesValue with the JSON encoded RepresentorConfig and return
+// google.protobuf.Empty.
+func RegisterAgentServer(s grpc.ServiceRegistrar, server *Server) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "sriov.dpu.AgentService",
+		HandlerType: (*Programmer)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Configure",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					cfg, err := decodeRepresentorConfig(dec)
+					if err != nil {
+						return nil, err
+					}
+					if err := srv.(Programmer).Configure(ctx, cfg); err != nil {
+						return nil, err
+					}
+					return new(emptypb.Empty), nil
+				},
+			},
+			{
+				MethodName: "Release",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					cfg, err := decodeRepresentorConfig(dec)
+					if err != nil {
+						return nil, err
+					}
+					if err := srv.(Programmer).Release(ctx, cfg); err != nil {
+						return nil, err
+					}
+					return new(emptypb.Empty), nil
+				},
+			},
+		},
+	}, server)
+}
+
+func decodeRepresentorConfig(dec func(interface{}) error) (*RepresentorConfig, error) {
+	data := new(wrapperspb.BytesValue)
+	if err := dec(data); err != nil {
+		return nil, err
+	}
+	cfg := new(RepresentorConfig)
+	if err := json.Unmarshal(data.GetValue(), cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal representor config")
+	}
+	return cfg, nil
+}
+
+// ListenAndServe serves the DPU agent gRPC service on the listenOn URL until the ctx is done. The service is served
+// with mTLS using the tlsConfig, which must require and verify the client certificates. Only the unix listenOn URL
+// can be served without TLS with the nil tlsConfig.
+func ListenAndServe(ctx context.Context, listenOn *url.URL, server *Server, tlsConfig *tls.Config, serverOptions ...grpc.ServerOption) <-chan error {
+	if err := checkTLSConfig(listenOn, tlsConfig); err != nil {
+		return errorCh(err)
+	}
+	if tlsConfig != nil {
+		if tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+			return errorCh(errors.Errorf("DPU agent TLS config must require and verify the client certificates: %s", listenOn))
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(serverOptions...)
+	RegisterAgentServer(grpcServer, server)
+
+	return grpcutils.ListenAndServe(ctx, listenOn, grpcServer)
+}
+
+func checkTLSConfig(u *url.URL, tlsConfig *tls.Config) error {
+	if tlsConfig == nil && u.Scheme != unixScheme {
+		return errors.Errorf("TLS config is required for the DPU agent URL: %s", u)
+	}
+	return nil
+}
+
+func errorCh(err error) <-chan error {
+	errCh := make(chan error, 1)
+	errCh <- err
+	close(errCh)
+	return errCh
+}
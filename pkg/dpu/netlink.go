@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dpu
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// Netlink is a netlink.Handle interface
+type Netlink interface {
+	LinkByName(name string) (netlink.Link, error)
+	LinkSetMTU(link netlink.Link, mtu int) error
+	LinkSetUp(link netlink.Link) error
+	LinkSetDown(link netlink.Link) error
+}
+
+// DefaultNetDir is a default sysfs net interfaces directory
+const DefaultNetDir = "/sys/class/net"
+
+var vfRepresentorPortName = regexp.MustCompile(`^(c\d+)?pf\d+vf\d+$`)
+
+// NetlinkProgrammer is a Programmer setting the representor MTU and the link state in the DPU eswitch with netlink,
+// released representors are restored to the original MTU and set down. Only the VF representor ports of the uplink
+// eswitch can be programmed.
+type NetlinkProgrammer struct {
+	netlink      Netlink
+	netDir       string
+	switchID     string
+	originalMTUs map[string]int // originalMTUs[representor] -> MTU
+	lock         sync.Mutex
+}
+
+// NetlinkOption is an option pattern for NewNetlinkProgrammer
+type NetlinkOption func(p *NetlinkProgrammer)
+
+// WithNetDir sets the sysfs net interfaces directory
+func WithNetDir(netDir string) NetlinkOption {
+	return func(p *NetlinkProgrammer) {
+		p.netDir = netDir
+	}
+}
+
+// NewNetlinkProgrammer returns a new NetlinkProgrammer using the nl for the VF representors of the uplink eswitch,
+// netlink default handle is used if nl is nil
+func NewNetlinkProgrammer(nl Netlink, uplink string, options ...NetlinkOption) (*NetlinkProgrammer, error) {
+	if nl == nil {
+		nl = new(netlink.Handle)
+	}
+	p := &NetlinkProgrammer{
+		netlink:      nl,
+		netDir:       DefaultNetDir,
+		originalMTUs: map[string]int{},
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+
+	switchID, err := readNetAttr(p.netDir, uplink, "phys_switch_id")
+	if err != nil || switchID == "" {
+		return nil, errors.Errorf("uplink is not in switchdev mode: %s", uplink)
+	}
+	p.switchID = switchID
+
+	return p, nil
+}
+
+// Configure sets the representor MTU and sets it up
+func (p *NetlinkProgrammer) Configure(_ context.Context, cfg *RepresentorConfig) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := p.checkRepresentor(cfg.Representor); err != nil {
+		return err
+	}
+
+	link, err := p.netlink.LinkByName(cfg.Representor)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find representor net interface: %s", cfg.Representor)
+	}
+
+	if cfg.MTU != 0 && link.Attrs().MTU != int(cfg.MTU) {
+		if _, ok := p.originalMTUs[cfg.Representor]; !ok {
+			p.originalMTUs[cfg.Representor] = link.Attrs().MTU
+		}
+		if err := p.netlink.LinkSetMTU(link, int(cfg.MTU)); err != nil {
+			return errors.Wrapf(err, "failed to set MTU %d for the representor: %s", cfg.MTU, cfg.Representor)
+		}
+	}
+
+	if err := p.netlink.LinkSetUp(link); err != nil {
+		return errors.Wrapf(err, "failed to set up the representor: %s", cfg.Representor)
+	}
+	return nil
+}
+
+// Release restores the representor original MTU and sets it down
+func (p *NetlinkProgrammer) Release(_ context.Context, cfg *RepresentorConfig) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := p.checkRepresentor(cfg.Representor); err != nil {
+		return err
+	}
+
+	link, err := p.netlink.LinkByName(cfg.Representor)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find representor net interface: %s", cfg.Representor)
+	}
+
+	if err := p.netlink.LinkSetDown(link); err != nil {
+		return errors.Wrapf(err, "failed to set down the representor: %s", cfg.Representor)
+	}
+
+	if mtu, ok := p.originalMTUs[cfg.Representor]; ok {
+		if err := p.netlink.LinkSetMTU(link, mtu); err != nil {
+			return errors.Wrapf(err, "failed to restore MTU %d for the representor: %s", mtu, cfg.Representor)
+		}
+		delete(p.originalMTUs, cfg.Representor)
+	}
+	return nil
+}
+
+func (p *NetlinkProgrammer) checkRepresentor(name string) error {
+	if name != filepath.Base(name) {
+		return errors.Errorf("invalid representor net interface name: %s", name)
+	}
+	if switchID, err := readNetAttr(p.netDir, name, "phys_switch_id"); err != nil || switchID != p.switchID {
+		return errors.Errorf("net interface is not a port of the DPU eswitch: %s", name)
+	}
+	if portName, err := readNetAttr(p.netDir, name, "phys_port_name"); err != nil || !vfRepresentorPortName.MatchString(portName) {
+		return errors.Errorf("net interface is not a VF representor: %s", name)
+	}
+	return nil
+}
+
+func readNetAttr(netDir, ifName, attr string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(netDir, ifName, attr)))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dpu_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+)
+
+type netlinkStub struct {
+	mtus map[string]int
+	up   map[string]bool
+}
+
+func (n *netlinkStub) LinkByName(name string) (netlink.Link, error) {
+	return &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: name, MTU: n.mtus[name]}}, nil
+}
+
+func (n *netlinkStub) LinkSetMTU(link netlink.Link, mtu int) error {
+	n.mtus[link.Attrs().Name] = mtu
+	return nil
+}
+
+func (n *netlinkStub) LinkSetUp(link netlink.Link) error {
+	n.up[link.Attrs().Name] = true
+	return nil
+}
+
+func (n *netlinkStub) LinkSetDown(link netlink.Link) error {
+	n.up[link.Attrs().Name] = false
+	return nil
+}
+
+func writeNetAttrs(t *testing.T, netDir, ifName, switchID, portName string) {
+	dir := filepath.Join(netDir, ifName)
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "phys_switch_id"), []byte(switchID+"\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "phys_port_name"), []byte(portName+"\n"), 0o600))
+}
+
+func TestNetlinkProgrammer_Representors(t *testing.T) {
+	netDir := t.TempDir()
+	writeNetAttrs(t, netDir, "p0", "0011", "p0")
+	writeNetAttrs(t, netDir, "pf0vf0", "0011", "pf0vf0")
+	writeNetAttrs(t, netDir, "pf0hpf", "0011", "pf0hpf")
+	writeNetAttrs(t, netDir, "other0", "0022", "pf0vf0")
+	require.NoError(t, os.MkdirAll(filepath.Join(netDir, "eth0"), 0o750))
+
+	_, err := dpu.NewNetlinkProgrammer(&netlinkStub{}, "eth0", dpu.WithNetDir(netDir))
+	require.Error(t, err)
+
+	nl := &netlinkStub{mtus: map[string]int{"pf0vf0": 1500}, up: map[string]bool{}}
+	programmer, err := dpu.NewNetlinkProgrammer(nl, "p0", dpu.WithNetDir(netDir))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	cfg := &dpu.RepresentorConfig{ConnectionID: "conn-1", Representor: "pf0vf0", MTU: 9000}
+	require.NoError(t, programmer.Configure(ctx, cfg))
+	require.Equal(t, 9000, nl.mtus["pf0vf0"])
+	require.True(t, nl.up["pf0vf0"])
+
+	require.NoError(t, programmer.Release(ctx, cfg))
+	require.Equal(t, 1500, nl.mtus["pf0vf0"])
+	require.False(t, nl.up["pf0vf0"])
+
+	// only the VF representors of the uplink eswitch are programmed
+	for _, name := range []string{"p0", "pf0hpf", "other0", "eth0", "lo", "../p0/../pf0vf0"} {
+		err := programmer.Configure(ctx, &dpu.RepresentorConfig{ConnectionID: "conn-2", Representor: name, MTU: 9000})
+		require.Error(t, err, name)
+		require.Error(t, programmer.Release(ctx, &dpu.RepresentorConfig{ConnectionID: "conn-2", Representor: name}), name)
+	}
+	require.Equal(t, map[string]int{"pf0vf0": 1500}, nl.mtus)
+	require.Equal(t, map[string]bool{"pf0vf0": false}, nl.up)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpu
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+// Server is the DPU agent serving the host forwarder representor programming calls with the programmer
+type Server struct {
+	programmer Programmer
+	configs    map[string]*RepresentorConfig // configs[connectionID] -> *RepresentorConfig
+	lock       sync.Mutex
+}
+
+// NewServer returns a new DPU agent Server programming the representors with the programmer
+func NewServer(programmer Programmer) *Server {
+	return &Server{
+		programmer: programmer,
+		configs:    map[string]*RepresentorConfig{},
+	}
+}
+
+// Configure configures the representor for the connection. If the connection has been configured with another
+// representor, the previous one is released first.
+func (s *Server) Configure(ctx context.Context, cfg *RepresentorConfig) error {
+	if cfg.ConnectionID == "" || cfg.Representor == "" {
+		return errors.Errorf("connection ID and representor are required: %+v", cfg)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if prev, ok := s.configs[cfg.ConnectionID]; ok && prev.Representor != cfg.Representor {
+		if err := s.programmer.Release(ctx, prev); err != nil {
+			return errors.Wrapf(err, "failed to release previous representor: %s", prev.Representor)
+		}
+		delete(s.configs, cfg.ConnectionID)
+	}
+
+	if err := s.programmer.Configure(ctx, cfg); err != nil {
+		return errors.Wrapf(err, "failed to configure representor: %s", cfg.Representor)
+	}
+	s.configs[cfg.ConnectionID] = cfg
+
+	log.FromContext(ctx).WithField("dpuServer", "Configure").
+		Infof("representor %s configured for the VF %s", cfg.Representor, cfg.VFPCIAddress)
+
+	return nil
+}
+
+// Release releases the representor configured for the connection, does nothing if there is no such connection
+func (s *Server) Release(ctx context.Context, cfg *RepresentorConfig) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	prev, ok := s.configs[cfg.ConnectionID]
+	if !ok {
+		return nil
+	}
+
+	if err := s.programmer.Release(ctx, prev); err != nil {
+		return errors.Wrapf(err, "failed to release representor: %s", prev.Representor)
+	}
+	delete(s.configs, cfg.ConnectionID)
+
+	log.FromContext(ctx).WithField("dpuServer", "Release").
+		Infof("representor %s released for the VF %s", prev.Representor, prev.VFPCIAddress)
+
+	return nil
+}
+
+// Configured returns the representors configured for the connections
+func (s *Server) Configured() map[string]*RepresentorConfig {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	configs := make(map[string]*RepresentorConfig, len(s.configs))
+	for connID, cfg := range s.configs {
+		configs[connID] = cfg
+	}
+	return configs
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpu_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+)
+
+type programmerStub struct {
+	configured map[string]*dpu.RepresentorConfig
+	failOn     string
+	lock       sync.Mutex
+}
+
+func (p *programmerStub) Configure(_ context.Context, cfg *dpu.RepresentorConfig) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if cfg.Representor == p.failOn {
+		return errors.New("representor not found")
+	}
+	p.configured[cfg.Representor] = cfg
+	return nil
+}
+
+func (p *programmerStub) Release(_ context.Context, cfg *dpu.RepresentorConfig) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.configured, cfg.Representor)
+	return nil
+}
+
+func TestAgent(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	programmer := &programmerStub{
+		configured: map[string]*dpu.RepresentorConfig{},
+		failOn:     "pf0vf9",
+	}
+	server := dpu.NewServer(programmer)
+
+	agentURL := &url.URL{Scheme: "unix", Path: filepath.Join(t.TempDir(), "dpu.sock")}
+	errCh := dpu.ListenAndServe(ctx, agentURL, server, nil)
+
+	client, cc, err := dpu.Dial(ctx, agentURL, nil)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	cfg := &dpu.RepresentorConfig{
+		ConnectionID: "conn-1",
+		VFPCIAddress: "0000:01:00.1",
+		Representor:  "pf0vf0",
+		MTU:          9000,
+	}
+	require.NoError(t, client.Configure(ctx, cfg))
+	require.Equal(t, map[string]*dpu.RepresentorConfig{"pf0vf0": cfg}, programmer.configured)
+	require.Equal(t, map[string]*dpu.RepresentorConfig{"conn-1": cfg}, server.Configured())
+
+	// the connection moved to another VF
+	movedCfg := &dpu.RepresentorConfig{
+		ConnectionID: "conn-1",
+		VFPCIAddress: "0000:01:00.2",
+		Representor:  "pf0vf1",
+	}
+	require.NoError(t, client.Configure(ctx, movedCfg))
+	require.Equal(t, map[string]*dpu.RepresentorConfig{"pf0vf1": movedCfg}, programmer.configured)
+
+	err = client.Configure(ctx, &dpu.RepresentorConfig{ConnectionID: "conn-2", Representor: "pf0vf9"})
+	require.ErrorContains(t, err, "representor not found")
+	require.Len(t, server.Configured(), 1)
+
+	require.NoError(t, client.Release(ctx, &dpu.RepresentorConfig{ConnectionID: "conn-1"}))
+	require.Empty(t, programmer.configured)
+	require.Empty(t, server.Configured())
+
+	// release of the unknown connection is a no-op
+	require.NoError(t, client.Release(ctx, &dpu.RepresentorConfig{ConnectionID: "conn-1"}))
+
+	_ = cc.Close()
+	cancel()
+	<-errCh
+}
+
+func TestAgent_TLS(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	writeCertificates(t, dir)
+
+	programmer := &programmerStub{configured: map[string]*dpu.RepresentorConfig{}}
+	agentURL := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+
+	// plaintext TCP is refused on both sides
+	require.Error(t, <-dpu.ListenAndServe(ctx, agentURL, dpu.NewServer(programmer), nil))
+	_, _, err := dpu.Dial(ctx, agentURL, nil)
+	require.Error(t, err)
+
+	// TLS without the client certificates verification is refused
+	tlsConfig, err := dpu.LoadClientTLSConfig(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.pem"))
+	require.NoError(t, err)
+	require.Error(t, <-dpu.ListenAndServe(ctx, agentURL, dpu.NewServer(programmer), tlsConfig))
+
+	serverTLSConfig, err := dpu.LoadServerTLSConfig(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.pem"))
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	agentURL.Host = listener.Addr().String()
+	_ = listener.Close()
+
+	errCh := dpu.ListenAndServe(ctx, agentURL, dpu.NewServer(programmer), serverTLSConfig)
+
+	clientTLSConfig, err := dpu.LoadClientTLSConfig(filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key"), filepath.Join(dir, "ca.pem"))
+	require.NoError(t, err)
+	clientTLSConfig.ServerName = "localhost"
+
+	client, cc, err := dpu.Dial(ctx, agentURL, clientTLSConfig)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	cfg := &dpu.RepresentorConfig{ConnectionID: "conn-1", Representor: "pf0vf0"}
+	require.NoError(t, client.Configure(ctx, cfg))
+	require.NoError(t, client.Release(ctx, cfg))
+
+	// the client without the certificate is refused
+	noCertTLSConfig := clientTLSConfig.Clone()
+	noCertTLSConfig.Certificates = nil
+
+	noCertClient, noCertCC, err := dpu.Dial(ctx, agentURL, noCertTLSConfig)
+	require.NoError(t, err)
+	defer func() { _ = noCertCC.Close() }()
+
+	callCtx, callCancel := context.WithTimeout(ctx, time.Second)
+	defer callCancel()
+	require.Error(t, noCertClient.Configure(callCtx, cfg))
+	require.Empty(t, programmer.configured)
+
+	_ = noCertCC.Close()
+	_ = cc.Close()
+	cancel()
+	<-errCh
+}
+
+func writeCertificates(t *testing.T, dir string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "dpu-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", caDER)
+
+	for i, name := range []string{"server", "client"} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		writePEM(t, filepath.Join(dir, name+".pem"), "CERTIFICATE", der)
+
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		writePEM(t, filepath.Join(dir, name+".key"), "PRIVATE KEY", keyDER)
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package appliance
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	clientSegmentName = "appliance-client"
+	serverSegmentName = "sriov-appliance"
+)
+
+type leaseExpiryServer struct {
+	leaseTTL time.Duration
+}
+
+// newLeaseExpiryServer returns a server setting the connection path expiring in leaseTTL on each Request, so the
+// timeout chain element closes the connections not refreshed by the appliance in time. The appliance clients don't
+// manage the path, so it is always replaced keeping the connection ID.
+func newLeaseExpiryServer(leaseTTL time.Duration) networkservice.NetworkServiceServer {
+	return &leaseExpiryServer{
+		leaseTTL: leaseTTL,
+	}
+}
+
+func (s *leaseExpiryServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	if conn == nil {
+		conn = new(networkservice.Connection)
+		request.Connection = conn
+	}
+	conn.Path = &networkservice.Path{
+		Index: 1,
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Name:    clientSegmentName,
+				Id:      conn.GetId(),
+				Expires: timestamppb.New(clock.FromContext(ctx).Now().Add(s.leaseTTL)),
+			},
+			{
+				Name: serverSegmentName,
+				Id:   conn.GetId(),
+			},
+		},
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *leaseExpiryServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package appliance
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+)
+
+// TokenNameLabel is a request label selecting the SR-IOV resource token name (e.g. "service.domain/capability") to
+// lease the token for the connection, it is ignored on refresh if the mechanism has the token ID already leased for the
+// connection
+const TokenNameLabel = "sriovTokenName"
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	AllocateFree(name string) (string, error)
+	SetCorrelationID(id, correlationID string) error
+	Free(id string) error
+}
+
+type tokenLeaseServer struct {
+	tokenPool TokenPool
+	leases    genericsync.Map[string, string]
+}
+
+// newTokenLeaseServer returns a server leasing the SR-IOV resource tokens to the connections, so there is no need in
+// the Device Plugin allocating them
+func newTokenLeaseServer(tokenPool TokenPool) networkservice.NetworkServiceServer {
+	return &tokenLeaseServer{
+		tokenPool: tokenPool,
+	}
+}
+
+func (s *tokenLeaseServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	if conn.GetMechanism() == nil {
+		return nil, errors.New("no mechanism provided")
+	}
+	if conn.GetMechanism().GetParameters() == nil {
+		conn.GetMechanism().Parameters = map[string]string{}
+	}
+	params := conn.GetMechanism().GetParameters()
+
+	// only the token leased for the connection is accepted, the client provided ones can be owned by other connections
+	tokenID, leased := s.leases.Load(conn.GetId())
+	if params[common.DeviceTokenIDKey] != tokenID {
+		delete(params, common.DeviceTokenIDKey)
+	}
+	delete(params, resourcepool.TokenIDsKey)
+	if !leased {
+		tokenName, ok := conn.GetLabels()[TokenNameLabel]
+		if !ok {
+			return nil, errors.Errorf("neither token ID nor %s label provided", TokenNameLabel)
+		}
+
+		var err error
+		if tokenID, err = s.tokenPool.AllocateFree(tokenName); err != nil {
+			return nil, err
+		}
+		_ = s.tokenPool.SetCorrelationID(tokenID, conn.GetId())
+	}
+	params[common.DeviceTokenIDKey] = tokenID
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !leased {
+			_ = s.tokenPool.Free(tokenID)
+		}
+		return nil, err
+	}
+
+	s.leases.Store(conn.GetId(), tokenID)
+
+	return conn, nil
+}
+
+func (s *tokenLeaseServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if tokenID, ok := s.leases.LoadAndDelete(conn.GetId()); ok {
+		if freeErr := s.tokenPool.Free(tokenID); freeErr != nil {
+			log.FromContext(ctx).WithField("tokenLeaseServer", "Close").
+				Warnf("failed to free token %s: %s", tokenID, freeErr.Error())
+		}
+	}
+
+	return rv, err
+}
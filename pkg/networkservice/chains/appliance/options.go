@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package appliance
+
+import (
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+)
+
+const defaultLeaseTTL = 10 * time.Minute
+
+type serverOptions struct {
+	leaseTTL            time.Duration
+	resourcePoolOptions []resourcepool.Option
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *serverOptions)
+
+// WithResourcePoolOptions sets additional options for the resourcepool chain elements
+func WithResourcePoolOptions(resourcePoolOptions ...resourcepool.Option) Option {
+	return func(o *serverOptions) {
+		o.resourcePoolOptions = append(o.resourcePoolOptions, resourcePoolOptions...)
+	}
+}
+
+// WithLeaseTTL sets the duration the connection VF is leased for by each Request, the connection is closed if it is not
+// refreshed in time. Default is 10 minutes.
+func WithLeaseTTL(leaseTTL time.Duration) Option {
+	return func(o *serverOptions) {
+		o.leaseTTL = leaseTTL
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package appliance provides the SR-IOV VF leasing server driven directly by a local gRPC API, for the bare-metal
+// appliances using the SR-IOV resources without the NSM control plane
+package appliance
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/timeout"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+// NewServer returns a chain leasing VFs to the connections, it doesn't configure the VF datapath: the VF PCI address
+// and IOMMU group are returned in the connection mechanism parameters for the appliance to use the VF itself. The
+// connections not refreshed with Request within the lease TTL (see WithLeaseTTL) are closed, freeing their VFs.
+//   - ctx - the server lifetime context, the connections expiration stops when it is done
+//   - pciPool - provides PCI functions
+//   - resourcePool - provides SR-IOV resources
+//   - tokenPool - provides SR-IOV resource tokens for the connections requested with TokenNameLabel
+//   - sriovConfig - SR-IOV PCI functions config
+func NewServer(
+	ctx context.Context,
+	pciPool resourcepool.PCIPool,
+	resourcePool resourcepool.ResourcePool,
+	tokenPool TokenPool,
+	sriovConfig *config.Config,
+	options ...Option,
+) networkservice.NetworkServiceServer {
+	o := &serverOptions{
+		leaseTTL: defaultLeaseTTL,
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	resourceLock := new(sync.Mutex)
+	return chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		newLeaseExpiryServer(o.leaseTTL),
+		metadata.NewServer(),
+		timeout.NewServer(ctx),
+		cleanup.NewServer(),
+		newTokenLeaseServer(tokenPool),
+		mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+			kernel.MECHANISM: resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, sriovConfig,
+				o.resourcePoolOptions...),
+			vfio.MECHANISM: resourcepool.NewServer(sriov.VFIOPCIDriver, resourceLock, pciPool, resourcePool, sriovConfig,
+				o.resourcePoolOptions...),
+		}),
+	)
+}
+
+// ListenAndServe serves the server on the unix socket, closes the gRPC server when ctx is done. Returns a chan receiving
+// the serve error.
+func ListenAndServe(ctx context.Context, socketPath string, server networkservice.NetworkServiceServer) <-chan error {
+	grpcServer := grpc.NewServer()
+	networkservice.RegisterNetworkServiceServer(grpcServer, server)
+
+	return grpcutils.ListenAndServe(ctx, &url.URL{Scheme: "unix", Path: socketPath}, grpcServer)
+}
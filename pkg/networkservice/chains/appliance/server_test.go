@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package appliance_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clock"
+	"github.com/ljkiraly/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/chains/appliance"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+)
+
+const (
+	configFileName = "config.yml"
+	tokenName      = "service.domain.1/10G"
+	vfPCIAddr      = "0000:01:00.1"
+)
+
+func TestApplianceServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := config.ReadConfig(ctx, configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	server := appliance.NewServer(ctx, pciPool, resource.NewPool(tokenPool, cfg), tokenPool, cfg)
+
+	socketPath := filepath.Join(t.TempDir(), "appliance.sock")
+	serveCtx, cancelServe := context.WithCancel(ctx)
+	errCh := appliance.ListenAndServe(serveCtx, socketPath, server)
+	defer func() {
+		cancelServe()
+		for range errCh {
+		}
+	}()
+
+	cc, err := grpc.DialContext(ctx, "unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := networkservice.NewNetworkServiceClient(cc)
+	request := func(id string, labels map[string]string) (*networkservice.Connection, error) {
+		return client.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:     id,
+				Labels: labels,
+				Mechanism: &networkservice.Mechanism{
+					Type:       vfio.MECHANISM,
+					Parameters: map[string]string{},
+				},
+			},
+		})
+	}
+
+	_, err = request("id-0", nil)
+	require.Error(t, err)
+
+	conn, err := request("id-1", map[string]string{appliance.TokenNameLabel: tokenName})
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+	require.Equal(t, uint(1), vfio.ToMechanism(conn.GetMechanism()).GetIommuGroup())
+
+	// the only token is leased
+	_, err = request("id-2", map[string]string{appliance.TokenNameLabel: tokenName})
+	require.Error(t, err)
+
+	// the token leased for the other connection is not accepted
+	_, err = client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id-2",
+			Labels: map[string]string{appliance.TokenNameLabel: tokenName},
+			Mechanism: &networkservice.Mechanism{
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: conn.GetMechanism().GetParameters()[common.DeviceTokenIDKey],
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+
+	// refresh
+	conn, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+
+	_, err = request("id-2", map[string]string{appliance.TokenNameLabel: tokenName})
+	require.NoError(t, err)
+}
+
+func TestApplianceServer_Expire(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	cfg, err := config.ReadConfig(ctx, configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	server := appliance.NewServer(ctx, pciPool, resource.NewPool(tokenPool, cfg), tokenPool, cfg,
+		appliance.WithLeaseTTL(time.Minute))
+
+	request := func(id string) (*networkservice.Connection, error) {
+		return server.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id:     id,
+				Labels: map[string]string{appliance.TokenNameLabel: tokenName},
+				Mechanism: &networkservice.Mechanism{
+					Type:       vfio.MECHANISM,
+					Parameters: map[string]string{},
+				},
+			},
+		})
+	}
+
+	conn, err := request("id-1")
+	require.NoError(t, err)
+	require.Equal(t, "id-1", conn.GetId())
+
+	// refresh extends the lease
+	clockMock.Add(time.Minute / 2)
+	_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+
+	clockMock.Add(time.Minute / 2)
+	_, err = request("id-2")
+	require.Error(t, err)
+
+	// the expired connection is closed freeing the token and the VF
+	clockMock.Add(time.Minute / 2)
+	require.Eventually(t, func() bool {
+		_, err = request("id-2")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
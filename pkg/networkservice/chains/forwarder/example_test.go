@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xconnectns_test
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ljkiraly/sdk/pkg/tools/token"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+	xconnectns "github.com/ljkiraly/sdk-sriov/pkg/networkservice/chains/forwarder"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/noop"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vlan"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/standby"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tokenaccess"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/vfhealth"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	vdpadev "github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/diagnostics"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/health"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/xdp"
+)
+
+// ExampleNewServer is a sample caller using all the forwarder options, it compiles on all the platforms: on the
+// unsupported ones the options are ignored and the forwarder fails all the requests
+func ExampleNewServer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		tokenGenerator    token.GeneratorFunc
+		pciPool           resourcepool.PCIPool
+		resourcePool      resourcepool.ResourcePool
+		router            *resourcepool.Router
+		sriovConfig       *config.Config
+		vlanPool          vlan.VLANPool
+		dpuAgents         map[string]dpu.Programmer
+		clientURL         *url.URL
+		healthMonitor     vfhealth.HealthMonitor
+		standbyLease      standby.Lease
+		registerer        prometheus.Registerer
+		tokenOwners       tokenaccess.TokenOwners
+		auditor           *audit.Auditor
+		bus               *eventbus.Bus
+		evaluator         *alerting.Evaluator
+		healthChecker     *health.Checker
+		exhaustionTracker *exhaustion.Tracker
+		diag              *diagnostics.Diagnostics
+		noopStore         *noop.Store
+	)
+
+	_ = xconnectns.NewServer(ctx, "forwarder", tokenGenerator,
+		xconnectns.WithAuthorizeServer(nil),
+		xconnectns.WithAuthorizeMonitorConnectionServer(nil),
+		xconnectns.WithPools(pciPool, resourcePool),
+		xconnectns.WithPoolRouter(router),
+		xconnectns.WithRegisteredPools(ctx, "forwarder"),
+		xconnectns.WithResourcePoolOptions(),
+		xconnectns.WithShardedResourceLock(),
+		xconnectns.WithSelectionHintsOptions(),
+		xconnectns.WithSRIOVConfig(sriovConfig),
+		xconnectns.WithVLANPool(vlanPool),
+		xconnectns.WithMechanisms(),
+		xconnectns.WithVFIODirs("/dev/vfio", "/sys/fs/cgroup/devices"),
+		xconnectns.WithVFIOServerOptions(),
+		xconnectns.WithVFIOInit(),
+		xconnectns.WithWarmVFIO(),
+		xconnectns.WithVDPA(vdpadev.NewManager()),
+		xconnectns.WithDPUAgents(dpuAgents),
+		xconnectns.WithAFXDP(xdp.NewPreparer()),
+		xconnectns.WithTCOffload(tcflower.NewManager()),
+		xconnectns.WithPTP(),
+		xconnectns.WithClientURL(clientURL),
+		xconnectns.WithClientURLs(clientURL),
+		xconnectns.WithDialTimeout(time.Second),
+		xconnectns.WithConnectDialTimeout(time.Second),
+		xconnectns.WithRegistryDialTimeout(time.Second),
+		xconnectns.WithConnectRetry(time.Second, time.Second),
+		xconnectns.WithRegistryRetry(time.Second, time.Second),
+		xconnectns.WithDialOptions(),
+		xconnectns.WithDryRun(),
+		xconnectns.WithGracefulShutdown(),
+		xconnectns.WithHealthMonitor(healthMonitor),
+		xconnectns.WithIRQAffinity(),
+		xconnectns.WithBonding(),
+		xconnectns.WithDriverOverride(),
+		xconnectns.WithMechanismMigration(),
+		xconnectns.WithWarmStandby(standbyLease, "/var/lib/forwarder/standby"),
+		xconnectns.WithIntrospection("/run/forwarder/introspect.sock"),
+		xconnectns.WithPoolsMetrics(registerer),
+		xconnectns.WithVFStatsMetrics(),
+		xconnectns.WithStageMetrics(),
+		xconnectns.WithHardwareLogLevels("pci=debug,resourcepool=warn"),
+		xconnectns.WithAuditor(auditor),
+		xconnectns.WithEventBus(bus),
+		xconnectns.WithAlerting(evaluator),
+		xconnectns.WithHealthChecker(healthChecker),
+		xconnectns.WithExhaustionTracker(exhaustionTracker),
+		xconnectns.WithAdmin("/run/forwarder/admin.sock"),
+		xconnectns.WithDiagnostics(diag),
+		xconnectns.WithKubeletCheckpoint("/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint"),
+		xconnectns.WithTokenAccessControl(tokenOwners),
+		xconnectns.WithAdmissionFuncs(),
+		xconnectns.WithNoopStore(noopStore),
+		xconnectns.WithAdditionalServerFunctionality(),
+		xconnectns.WithAdditionalClientFunctionality(),
+	)
+
+	_ = xconnectns.NewLegacyServer(ctx, "forwarder", nil, nil, tokenGenerator, pciPool, resourcePool, sriovConfig,
+		"/dev/vfio", "/sys/fs/cgroup/devices", clientURL, time.Second)
+
+	_ = xconnectns.MetricsMetadata()
+	_ = xconnectns.MetricsMetadataHandler()
+}
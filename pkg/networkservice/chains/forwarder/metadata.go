@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xconnectns
+
+import (
+	"net/http"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metrics"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metricsmeta"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfstats"
+)
+
+// MetricsMetadata returns the descriptions of all the metrics the forwarder may expose
+func MetricsMetadata() []*metricsmeta.Metric {
+	var metadata []*metricsmeta.Metric
+	metadata = append(metadata, metrics.Metadata()...)
+	metadata = append(metadata, vfstats.Metadata()...)
+	metadata = append(metadata, stages.Metadata()...)
+	metadata = append(metadata, vfio.Metadata()...)
+	return metadata
+}
+
+// MetricsMetadataHandler returns the HTTP handler serving MetricsMetadata, meant to be served on
+// metricsmeta.MetadataPath next to the metrics endpoint
+func MetricsMetadataHandler() http.Handler {
+	return metricsmeta.Handler(MetricsMetadata()...)
+}
@@ -0,0 +1,630 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package xconnectns
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/authorize"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/retry"
+	registryretry "github.com/ljkiraly/sdk/pkg/registry/common/retry"
+	authmonitor "github.com/ljkiraly/sdk/pkg/tools/monitorconnection/authorize"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/admission"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/drain"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/irqaffinity"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/noop"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vlan"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/ptp"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/selectionhints"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/standby"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tokenaccess"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/vfhealth"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	vdpadev "github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/diagnostics"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/health"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfioinit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfstats"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/xdp"
+)
+
+const (
+	defaultVFIODir       = "/dev/vfio"
+	defaultCgroupBaseDir = "/sys/fs/cgroup/devices"
+)
+
+type serverOptions struct {
+	authorizeServer                  networkservice.NetworkServiceServer
+	authorizeMonitorConnectionServer networkservice.MonitorConnectionServer
+	pciPool                          resourcepool.PCIPool
+	resourcePool                     resourcepool.ResourcePool
+	registeredPools                  string
+	registeredPoolsCtx               context.Context
+	resourcePoolOptions              []resourcepool.Option
+	selectionHintsOptions            []selectionhints.Option
+	sriovConfig                      *config.Config
+	shardedLock                      *resourcepool.ShardedLock
+	vlanPool                         vlan.VLANPool
+	mechanismTypes                   []string
+	vfioDir                          string
+	cgroupBaseDir                    string
+	vfioServerOptions                []vfio.ServerOption
+	vfioInit                         bool
+	vfioInitOptions                  []vfioinit.Option
+	warmVFIO                         bool
+	vdpaManager                      *vdpadev.Manager
+	vdpaServerOptions                []vdpa.ServerOption
+	dpuAgents                        map[string]dpu.Programmer
+	xdpPreparer                      *xdp.Preparer
+	tcManager                        *tcflower.Manager
+	ptp                              bool
+	ptpServerOptions                 []ptp.ServerOption
+	clientURLs                       []*url.URL
+	connectDialTimeout               time.Duration
+	connectRetry                     bool
+	connectRetryOptions              []retry.Option
+	registryDialTimeout              time.Duration
+	registryRetryOptions             []registryretry.Option
+	dialOptions                      []grpc.DialOption
+	dryRun                           bool
+	drainOptions                     []drain.Option
+	gracefulShutdown                 bool
+	healthMonitor                    vfhealth.HealthMonitor
+	tokenOwners                      tokenaccess.TokenOwners
+	admissionFuncs                   []admission.Func
+	noopStore                        *noop.Store
+	irqAffinity                      bool
+	bonding                          bool
+	driverOverride                   bool
+	mechanismMigration               bool
+	standbyLease                     standby.Lease
+	standbyStatePath                 string
+	standbyOptions                   []standby.Option
+	introspectSocketPath             string
+	adminSocketPath                  string
+	adminOptions                     []sriovadmin.Option
+	kubeletCheckpointPath            string
+	metricsRegisterer                prometheus.Registerer
+	vfStatsMetrics                   bool
+	stageMetrics                     bool
+	hardwareLogLevels                string
+	vfStatsOptions                   []vfstats.Option
+	healthChecker                    *health.Checker
+	alertingEvaluator                *alerting.Evaluator
+	exhaustionTracker                *exhaustion.Tracker
+	auditor                          *audit.Auditor
+	eventBus                         *eventbus.Bus
+	diagnostics                      *diagnostics.Diagnostics
+	irqAffinityOptions               []irqaffinity.Option
+	additionalServerFunctionality    []networkservice.NetworkServiceServer
+	additionalClientFunctionality    []networkservice.NetworkServiceClient
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *serverOptions)
+
+// WithAuthorizeServer sets authorization server chain element
+func WithAuthorizeServer(authorizeServer networkservice.NetworkServiceServer) Option {
+	if authorizeServer == nil {
+		panic("authorizeServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeServer = authorizeServer
+	}
+}
+
+// WithAuthorizeMonitorConnectionServer sets authorization MonitorConnectionServer chain element
+func WithAuthorizeMonitorConnectionServer(authorizeMonitorConnectionServer networkservice.MonitorConnectionServer) Option {
+	if authorizeMonitorConnectionServer == nil {
+		panic("authorizeMonitorConnectionServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeMonitorConnectionServer = authorizeMonitorConnectionServer
+	}
+}
+
+// WithPools sets pools providing PCI functions and SR-IOV resources
+func WithPools(pciPool resourcepool.PCIPool, resourcePool resourcepool.ResourcePool) Option {
+	return func(o *serverOptions) {
+		o.pciPool = pciPool
+		o.resourcePool = resourcePool
+	}
+}
+
+// WithPoolRouter sets PCI pool, resource pool and SR-IOV config routing the requests between several pool sets, e.g.
+// one per NUMA node or NIC vendor. It can be used instead of WithPools and WithSRIOVConfig.
+func WithPoolRouter(router *resourcepool.Router) Option {
+	return func(o *serverOptions) {
+		o.pciPool = router
+		o.resourcePool = router
+		o.sriovConfig = router.Config()
+	}
+}
+
+// WithRegisteredPools sets pools created with the pools implementation registered by the name with
+// resourcepool.RegisterPools. Pools are created for the SR-IOV config set with WithSRIOVConfig, NewServer panics if they
+// can't be created. It can be used instead of WithPools.
+func WithRegisteredPools(ctx context.Context, name string) Option {
+	return func(o *serverOptions) {
+		o.registeredPools = name
+		o.registeredPoolsCtx = ctx
+	}
+}
+
+// WithResourcePoolOptions sets additional options for the resourcepool chain elements
+func WithResourcePoolOptions(resourcePoolOptions ...resourcepool.Option) Option {
+	return func(o *serverOptions) {
+		o.resourcePoolOptions = append(o.resourcePoolOptions, resourcePoolOptions...)
+	}
+}
+
+// WithShardedResourceLock makes the Forwarder hold the shared resource lock only for the VF selection, VF driver binding
+// is serialized per PF and VF assignment is serialized per token instead. It increases the Request throughput on the
+// nodes with many PFs.
+func WithShardedResourceLock() Option {
+	return func(o *serverOptions) {
+		o.shardedLock = resourcepool.NewShardedLock()
+		o.resourcePoolOptions = append(o.resourcePoolOptions,
+			resourcepool.WithShardedLock(o.shardedLock),
+			resourcepool.WithTokenLock(resourcepool.NewShardedLock()),
+		)
+	}
+}
+
+// WithSelectionHintsOptions sets options for the chain element mapping NSE registry labels and request labels to the VF
+// selection hints
+func WithSelectionHintsOptions(selectionHintsOptions ...selectionhints.Option) Option {
+	return func(o *serverOptions) {
+		o.selectionHintsOptions = append(o.selectionHintsOptions, selectionHintsOptions...)
+	}
+}
+
+// WithSRIOVConfig sets SR-IOV PCI functions config
+func WithSRIOVConfig(sriovConfig *config.Config) Option {
+	return func(o *serverOptions) {
+		o.sriovConfig = sriovConfig
+	}
+}
+
+// WithVLANPool enables the remote VLAN mechanism for the cross-node SR-IOV connections, VLAN IDs are allocated from
+// the vlanPool
+func WithVLANPool(vlanPool vlan.VLANPool) Option {
+	return func(o *serverOptions) {
+		o.vlanPool = vlanPool
+	}
+}
+
+// WithMechanisms sets mechanism types supported by the Forwarder in the priority order, e.g. kernel.MECHANISM only to
+// force kernel-only operation or to disable VFIO on the nodes without IOMMU. By default all the mechanisms are supported.
+func WithMechanisms(mechanismTypes ...string) Option {
+	return func(o *serverOptions) {
+		o.mechanismTypes = mechanismTypes
+	}
+}
+
+// WithVFIODirs sets host /dev/vfio and /sys/fs/cgroup/devices directories mount locations
+func WithVFIODirs(vfioDir, cgroupBaseDir string) Option {
+	return func(o *serverOptions) {
+		o.vfioDir = vfioDir
+		o.cgroupBaseDir = cgroupBaseDir
+	}
+}
+
+// WithVFIOServerOptions sets options for the VFIO mechanism server, e.g. vfio.WithNoIOMMUMode for the nodes without a
+// functional IOMMU or vfio.WithFDPassing for the clients without the device cgroup access
+func WithVFIOServerOptions(vfioServerOptions ...vfio.ServerOption) Option {
+	return func(o *serverOptions) {
+		o.vfioServerOptions = append(o.vfioServerOptions, vfioServerOptions...)
+	}
+}
+
+// WithVFIOInit makes the forwarder ensure the VFIO kernel modules are loaded with the required parameters at startup,
+// if it fails the VFIO mechanism requests are rejected with the vfioinit.Init error
+func WithVFIOInit(vfioInitOptions ...vfioinit.Option) Option {
+	return func(o *serverOptions) {
+		o.vfioInit = true
+		o.vfioInitOptions = append(o.vfioInitOptions, vfioInitOptions...)
+	}
+}
+
+// WithWarmVFIO makes the forwarder pre-bind the warm IOMMU groups configured with config.Config.WarmVFIO to the vfio-pci
+// driver at startup and re-warm them on the VFs free, it is applied only if the resource pool is a
+// resourcepool.WarmResourcePool and the VFIO kernel modules are initialized
+func WithWarmVFIO() Option {
+	return func(o *serverOptions) {
+		o.warmVFIO = true
+	}
+}
+
+// WithVDPA enables the vhost-vdpa mechanism: the vDPA devices are created by the manager on the selected kernel driver
+// VFs and the client is granted access to the vhost-vdpa char device
+func WithVDPA(manager *vdpadev.Manager, vdpaServerOptions ...vdpa.ServerOption) Option {
+	return func(o *serverOptions) {
+		o.vdpaManager = manager
+		o.vdpaServerOptions = append(o.vdpaServerOptions, vdpaServerOptions...)
+	}
+}
+
+// WithDPUAgents enables the split-forwarder mode for the DPU hosted physical functions: the VFs are still selected by
+// the forwarder, but their DPU-side representors are programmed by the DPU agents, agents[DPU name], e.g. dpu.Client
+func WithDPUAgents(agents map[string]dpu.Programmer) Option {
+	return func(o *serverOptions) {
+		o.dpuAgents = agents
+	}
+}
+
+// WithAFXDP enables the AF_XDP-ready VF driver mode for the kernel mechanism connections labeled with afxdp.Label:
+// the VF stays bound to the kernel driver, the preparer attaches the XDP program to it and the XSKMAP fd is passed to
+// the client
+func WithAFXDP(preparer *xdp.Preparer) Option {
+	return func(o *serverOptions) {
+		o.xdpPreparer = preparer
+	}
+}
+
+// WithTCOffload enables the TC flower hardware offload for the switchdev mode PFs: the cross-connects between the VFs
+// of the same PF are redirected between the VF representors in the NIC eswitch, and the VLAN remote mechanism tags the
+// VF traffic with the TC flower rules on the PF uplink instead of the VF VLAN
+func WithTCOffload(manager *tcflower.Manager) Option {
+	return func(o *serverOptions) {
+		o.tcManager = manager
+	}
+}
+
+// WithPTP enables granting the clients access to the PTP hardware clock of the PF the connection VF is on, for the
+// kernel and VFIO mechanism connections labeled with ptp.Label and the PFs with config.PTPCapability
+func WithPTP(ptpServerOptions ...ptp.ServerOption) Option {
+	return func(o *serverOptions) {
+		o.ptp = true
+		o.ptpServerOptions = append(o.ptpServerOptions, ptpServerOptions...)
+	}
+}
+
+// WithClientURL sets URL for the talking to the NSMgr
+func WithClientURL(clientURL *url.URL) Option {
+	return func(o *serverOptions) {
+		o.clientURLs = []*url.URL{clientURL}
+	}
+}
+
+// WithClientURLs sets URLs for the talking to the NSMgr, registry clients fail over to the next URL if the current one
+// is unavailable, e.g. during the local NSMgr upgrade
+func WithClientURLs(clientURLs ...*url.URL) Option {
+	return func(o *serverOptions) {
+		o.clientURLs = clientURLs
+	}
+}
+
+// WithDialTimeout sets dial timeout for both the registry and the connect NSMgr connections
+func WithDialTimeout(dialTimeout time.Duration) Option {
+	return func(o *serverOptions) {
+		o.connectDialTimeout = dialTimeout
+		o.registryDialTimeout = dialTimeout
+	}
+}
+
+// WithConnectDialTimeout sets dial timeout for the connect NSMgr connections
+func WithConnectDialTimeout(dialTimeout time.Duration) Option {
+	return func(o *serverOptions) {
+		o.connectDialTimeout = dialTimeout
+	}
+}
+
+// WithRegistryDialTimeout sets dial timeout for the registry NSMgr connections
+func WithRegistryDialTimeout(dialTimeout time.Duration) Option {
+	return func(o *serverOptions) {
+		o.registryDialTimeout = dialTimeout
+	}
+}
+
+// WithConnectRetry enables retrying the failed connect client requests and closes each tryTimeout with the given
+// interval until the incoming request context is done. By default the connect client doesn't retry.
+func WithConnectRetry(tryTimeout, interval time.Duration) Option {
+	return func(o *serverOptions) {
+		o.connectRetry = true
+		o.connectRetryOptions = []retry.Option{retry.WithTryTimeout(tryTimeout), retry.WithInterval(interval)}
+	}
+}
+
+// WithRegistryRetry sets timeout and interval for retrying the failed registry client operations
+func WithRegistryRetry(tryTimeout, interval time.Duration) Option {
+	return func(o *serverOptions) {
+		o.registryRetryOptions = []registryretry.Option{
+			registryretry.WithTryTimeout(tryTimeout),
+			registryretry.WithInterval(interval),
+		}
+	}
+}
+
+// WithDialOptions sets dial options for dialing the NSMgr
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *serverOptions) {
+		o.dialOptions = dialOptions
+	}
+}
+
+// WithDryRun enables the dry-run mode: PCI functions are faked from the SR-IOV config (unless PCI pool is provided
+// with WithPools) and no host datapath is configured, so the full chain including token handling can be exercised
+// without the SR-IOV hardware. NOTE: the remote VLAN mechanism VFs are not tagged in the dry-run mode.
+func WithDryRun() Option {
+	return func(o *serverOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithGracefulShutdown enables the graceful shutdown on the Forwarder ctx cancellation: new Requests are rejected, all
+// the connections are closed freeing VFs, VFs are rebound to the kernel driver and then drainOptions after drain
+// functions are called (e.g. persisting the final pool state)
+func WithGracefulShutdown(drainOptions ...drain.Option) Option {
+	return func(o *serverOptions) {
+		o.gracefulShutdown = true
+		o.drainOptions = append(o.drainOptions, drainOptions...)
+	}
+}
+
+// WithHealthMonitor sets PF/VF health monitor (e.g. hotplug.Monitor), the connections using VFs of the PF with the
+// link down or using the disappeared VFs are closed on its events so the clients can heal
+func WithHealthMonitor(healthMonitor vfhealth.HealthMonitor) Option {
+	return func(o *serverOptions) {
+		o.healthMonitor = healthMonitor
+	}
+}
+
+// WithIRQAffinity enables pinning the kernel mechanism VF IRQs to the CPUs local to the VF NUMA node and the client
+// cpuset
+func WithIRQAffinity(irqAffinityOptions ...irqaffinity.Option) Option {
+	return func(o *serverOptions) {
+		o.irqAffinity = true
+		o.irqAffinityOptions = append(o.irqAffinityOptions, irqAffinityOptions...)
+	}
+}
+
+// WithBonding enables the kernel mechanism bonded pairs: requests with the bond.Label get the second VF from a
+// different PF moved into the client netns along with the bond configuration context. Resource pool should implement
+// bond.ResourcePool.
+func WithBonding() Option {
+	return func(o *serverOptions) {
+		o.bonding = true
+	}
+}
+
+// WithDriverOverride enables forcing the VF driver with the resourcepool.DriverLabel request label, e.g. to leave the
+// kernel mechanism VF bound to the vfio-pci driver for AF_XDP-like setups. The mechanism datapath is not configured for
+// the VFs bound to the driver other than the mechanism implied one.
+func WithDriverOverride() Option {
+	return func(o *serverOptions) {
+		o.driverOverride = true
+		o.resourcePoolOptions = append(o.resourcePoolOptions, resourcepool.WithDriverOverride())
+	}
+}
+
+// WithMechanismMigration enables the connection mechanism change between the kernel, VFIO and RDMA mechanisms keeping
+// the same VF: the VF is rebound to the new mechanism driver in place instead of closing the old mechanism and selecting
+// a VF again. Resource pool should implement resourcepool.RebindingResourcePool, the mechanism change closes the old
+// mechanism first otherwise.
+func WithMechanismMigration() Option {
+	return func(o *serverOptions) {
+		o.mechanismMigration = true
+	}
+}
+
+// WithWarmStandby enables the warm-standby mode: the Forwarder serves only while holding the lease, persisting the
+// resource pool state into the statePath file. The second Forwarder instance started with the same lease and statePath
+// loads the persisted state read-only and takes over when the lease lapses. Resource pool should implement
+// standby.StatefulPool.
+func WithWarmStandby(lease standby.Lease, statePath string, standbyOptions ...standby.Option) Option {
+	return func(o *serverOptions) {
+		o.standbyLease = lease
+		o.standbyStatePath = statePath
+		o.standbyOptions = append(o.standbyOptions, standbyOptions...)
+	}
+}
+
+// WithIntrospection enables serving the active connections token ID, VF PCI address, driver and mechanism on the
+// introspect gRPC unix socket
+func WithIntrospection(socketPath string) Option {
+	return func(o *serverOptions) {
+		o.introspectSocketPath = socketPath
+	}
+}
+
+// WithPoolsMetrics enables the SR-IOV pools Prometheus metrics registered on the registerer: resource pool VF counts
+// if it is a resource.Pool and PCI pool driver binding latency and failures. NewServer panics if the metrics can't be
+// registered.
+func WithPoolsMetrics(registerer prometheus.Registerer) Option {
+	return func(o *serverOptions) {
+		o.metricsRegisterer = registerer
+	}
+}
+
+// WithVFStatsMetrics enables the per-connection VF traffic counters Prometheus metrics, they are registered on the
+// WithPoolsMetrics registerer
+func WithVFStatsMetrics(vfStatsOptions ...vfstats.Option) Option {
+	return func(o *serverOptions) {
+		o.vfStatsMetrics = true
+		o.vfStatsOptions = vfStatsOptions
+	}
+}
+
+// WithStageMetrics enables the Request stages duration Prometheus histograms: token lookup, VF selection, driver bind,
+// cgroup grant and namespace injection, they are registered on the WithPoolsMetrics registerer
+func WithStageMetrics() Option {
+	return func(o *serverOptions) {
+		o.stageMetrics = true
+	}
+}
+
+// WithHardwareLogLevels sets the hardware operations log levels per subsystem from the comma separated subsystem=level
+// list, e.g. "pci=debug,resourcepool=warn", see hwlog.SetLevels
+func WithHardwareLogLevels(spec string) Option {
+	return func(o *serverOptions) {
+		o.hardwareLogLevels = spec
+	}
+}
+
+// WithAuditor sets the auditor recording the hardware state changes caused by the connections: VF assignments, driver
+// bindings, VF releases and VF VLAN tagging
+func WithAuditor(auditor *audit.Auditor) Option {
+	return func(o *serverOptions) {
+		o.auditor = auditor
+		o.resourcePoolOptions = append(o.resourcePoolOptions, resourcepool.WithAuditor(auditor))
+	}
+}
+
+// WithEventBus sets the event bus shared by the pools and the health monitor, the published events are counted by the
+// WithPoolsMetrics metrics
+func WithEventBus(bus *eventbus.Bus) Option {
+	return func(o *serverOptions) {
+		o.eventBus = bus
+	}
+}
+
+// WithAlerting sets the alerting thresholds evaluator to record the PCI pool driver bind failures
+func WithAlerting(evaluator *alerting.Evaluator) Option {
+	return func(o *serverOptions) {
+		o.alertingEvaluator = evaluator
+	}
+}
+
+// WithHealthChecker sets the health checker to check the resource pool readiness once it is built, the resource pool
+// should be a resource.Pool. The forwarder sends the health.ForwarderReconciler heartbeat every second, register it with
+// health.WithReconciler to check the forwarder liveness
+func WithHealthChecker(healthChecker *health.Checker) Option {
+	return func(o *serverOptions) {
+		o.healthChecker = healthChecker
+	}
+}
+
+// WithExhaustionTracker enables attributing the resource pool exhaustion to the connections and client identities holding
+// the VFs: the tracker is shared by all the resourcepool chain elements and the attribution is reported by the pools
+// metrics and the admin service if they are enabled
+func WithExhaustionTracker(exhaustionTracker *exhaustion.Tracker) Option {
+	return func(o *serverOptions) {
+		o.exhaustionTracker = exhaustionTracker
+		o.resourcePoolOptions = append(o.resourcePoolOptions, resourcepool.WithExhaustionTracker(exhaustionTracker))
+	}
+}
+
+// WithAdmin enables serving the SR-IOV admin gRPC service on the unix socket: the resource pool status if it is a
+// resource.Pool and the active connections are reported, adminOptions can add the token pool and the event log
+func WithAdmin(socketPath string, adminOptions ...sriovadmin.Option) Option {
+	return func(o *serverOptions) {
+		o.adminSocketPath = socketPath
+		o.adminOptions = adminOptions
+	}
+}
+
+// WithDiagnostics sets the diagnostics to bundle the forwarder state reported by the admin service, adminOptions of
+// WithAdmin are applied to it as well
+func WithDiagnostics(diagnostics *diagnostics.Diagnostics) Option {
+	return func(o *serverOptions) {
+		o.diagnostics = diagnostics
+	}
+}
+
+// WithKubeletCheckpoint sets the kubelet device plugin checkpoint file path, e.g. checkpoint.DefaultPath, the admin
+// service and the diagnostics report the pod containers the tokens are assigned to and refuse force-freeing them
+func WithKubeletCheckpoint(path string) Option {
+	return func(o *serverOptions) {
+		o.kubeletCheckpointPath = path
+	}
+}
+
+// WithTokenAccessControl enables rejecting the requests presenting device tokens owned by another client identity
+func WithTokenAccessControl(tokenOwners tokenaccess.TokenOwners) Option {
+	return func(o *serverOptions) {
+		o.tokenOwners = tokenOwners
+	}
+}
+
+// WithAdmissionFuncs sets admission checks evaluated for the new connection Requests before the SR-IOV resources
+// selection, e.g. to veto SR-IOV consumption for the quota, maintenance window or tenant policy reasons
+func WithAdmissionFuncs(admissionFuncs ...admission.Func) Option {
+	return func(o *serverOptions) {
+		o.admissionFuncs = append(o.admissionFuncs, admissionFuncs...)
+	}
+}
+
+// WithNoopStore sets the store for the connections established with the NOOP mechanism (e.g. for monitoring only), so
+// they can be inspected despite using no SR-IOV resources
+func WithNoopStore(noopStore *noop.Store) Option {
+	return func(o *serverOptions) {
+		o.noopStore = noopStore
+	}
+}
+
+// WithAdditionalServerFunctionality sets additional server chain elements inserted after the mechanism specific
+// chain elements and right before the connect chain element
+func WithAdditionalServerFunctionality(additionalFunctionality ...networkservice.NetworkServiceServer) Option {
+	return func(o *serverOptions) {
+		o.additionalServerFunctionality = append(o.additionalServerFunctionality, additionalFunctionality...)
+	}
+}
+
+// WithAdditionalClientFunctionality sets additional client chain elements inserted at the end of the NSMgr client
+// additional functionality, right before the dial and the connect to the NSMgr
+func WithAdditionalClientFunctionality(additionalFunctionality ...networkservice.NetworkServiceClient) Option {
+	return func(o *serverOptions) {
+		o.additionalClientFunctionality = append(o.additionalClientFunctionality, additionalFunctionality...)
+	}
+}
+
+func newServerOptions(options ...Option) *serverOptions {
+	o := &serverOptions{
+		authorizeServer:                  authorize.NewServer(authorize.Any()),
+		authorizeMonitorConnectionServer: authmonitor.NewMonitorConnectionServer(authmonitor.Any()),
+		sriovConfig:                      &config.Config{},
+		vfioDir:                          defaultVFIODir,
+		cgroupBaseDir:                    defaultCgroupBaseDir,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	if o.registeredPools != "" {
+		var err error
+		if o.pciPool, o.resourcePool, err = resourcepool.NewPools(o.registeredPoolsCtx, o.registeredPools, o.sriovConfig); err != nil {
+			panic(err.Error())
+		}
+	}
+	if o.dryRun && o.pciPool == nil {
+		// PFs are generated from the config, so there can be no error
+		o.pciPool, _ = pci.NewTestPool(sriovtest.NewPhysicalFunctions(o.sriovConfig), o.sriovConfig)
+	}
+	return o
+}
@@ -32,10 +32,6 @@ import (
 
 	"google.golang.org/grpc"
 
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
-	noopmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/noop"
-	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel"
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/ethernetcontext"
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/inject"
@@ -48,117 +44,616 @@ import (
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms/recvfd"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanismtranslation"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/null"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/retry"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/roundrobin"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/switchcase"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	registryclient "github.com/ljkiraly/sdk/pkg/registry/chains/client"
+	registryrecvfd "github.com/ljkiraly/sdk/pkg/registry/common/recvfd"
+	registryretry "github.com/ljkiraly/sdk/pkg/registry/common/retry"
+	registrysendfd "github.com/ljkiraly/sdk/pkg/registry/common/sendfd"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
 	"github.com/ljkiraly/sdk/pkg/tools/token"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	noopmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/noop"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/api/pkg/api/registry"
 
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/admission"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/afxdp"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/bond"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/dpuoffload"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/drain"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/extravf"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/irqaffinity"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/macsec"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanismpriority"
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/noop"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/rdma"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vdpa"
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vlan"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/ptp"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/qos"
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resetmechanism"
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/selectionhints"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/stagetiming"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/standby"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/stats"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tcoffload"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tokenaccess"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/vfhealth"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/vfmtu"
+	"github.com/ljkiraly/sdk-sriov/pkg/registry/common/clienturls"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
-
-	registryclient "github.com/ljkiraly/sdk/pkg/registry/chains/client"
-	registryrecvfd "github.com/ljkiraly/sdk/pkg/registry/common/recvfd"
-	registrysendfd "github.com/ljkiraly/sdk/pkg/registry/common/sendfd"
+	vdpadev "github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/health"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metrics"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfioinit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfstats"
 )
 
+const heartbeatInterval = time.Second
+
 type sriovServer struct {
 	endpoint.Endpoint
 }
 
 // NewServer - returns an Endpoint implementing the SR-IOV Forwarder networks service
 //   - name - name of the Forwarder
-//   - authzServer - policy for allowing or rejecting requests
 //   - tokenGenerator - token.GeneratorFunc - generates tokens for use in Path
-//   - pciPool - provides PCI functions
-//   - resourcePool - provides SR-IOV resources
-//   - sriovConfig - SR-IOV PCI functions config
-//   - vfioDir - host /dev/vfio directory mount location
-//   - cgroupBaseDir - host /sys/fs/cgroup/devices directory mount location
-//   - clientUrl - *url.URL for the talking to the NSMgr
-//   - ...clientDialOptions - dialOptions for dialing the NSMgr
-func NewServer(
-	ctx context.Context,
-	name string,
-	authzServer networkservice.NetworkServiceServer,
-	authzMonitorConnectionServer networkservice.MonitorConnectionServer,
-	tokenGenerator token.GeneratorFunc,
-	pciPool resourcepool.PCIPool,
-	resourcePool resourcepool.ResourcePool,
-	sriovConfig *config.Config,
-	vfioDir, cgroupBaseDir string,
-	clientURL *url.URL,
-	dialTimeout time.Duration,
-	clientDialOptions ...grpc.DialOption,
-) endpoint.Endpoint {
-	nseClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx,
-		registryclient.WithClientURL(clientURL),
-		registryclient.WithNSEAdditionalFunctionality(
-			registryrecvfd.NewNetworkServiceEndpointRegistryClient(),
-			registrysendfd.NewNetworkServiceEndpointRegistryClient(),
-		),
-		registryclient.WithDialOptions(clientDialOptions...),
-	)
-	nsClient := registryclient.NewNetworkServiceRegistryClient(ctx,
-		registryclient.WithClientURL(clientURL),
-		registryclient.WithDialOptions(clientDialOptions...))
+//   - options - options for the Forwarder, WithPools, WithPoolRouter or WithRegisteredPools is required
+func NewServer(ctx context.Context, name string, tokenGenerator token.GeneratorFunc, options ...Option) endpoint.Endpoint {
+	o := newServerOptions(options...)
+
+	if o.hardwareLogLevels != "" {
+		if err := hwlog.SetLevels(o.hardwareLogLevels); err != nil {
+			log.FromContext(ctx).WithField("sriovServer", "hwlog").Errorf("invalid hardware log levels: %s", err.Error())
+		}
+	}
+
+	nsClient, nseClient := newRegistryClients(ctx, o)
 
 	rv := new(sriovServer)
 
 	resourceLock := &sync.Mutex{}
-	additionalFunctionality := []networkservice.NetworkServiceServer{
+	if o.metricsRegisterer != nil {
+		registerPoolsMetrics(o, resourceLock)
+	}
+	if o.alertingEvaluator != nil && o.pciPool != nil {
+		o.pciPool = o.alertingEvaluator.PCIPool(o.pciPool)
+	}
+	if o.healthChecker != nil {
+		if resourcePool, ok := o.resourcePool.(health.ResourcePool); ok {
+			o.healthChecker.SetResourcePool(resourcePool, resourceLock)
+		} else {
+			log.FromContext(ctx).WithField("sriovServer", "health").Error("resource pool readiness can't be checked")
+		}
+		go sendHeartbeats(ctx, o.healthChecker, resourceLock)
+	}
+
+	var additionalFunctionality []networkservice.NetworkServiceServer
+	if o.stageMetrics && o.metricsRegisterer != nil {
+		additionalFunctionality = append(additionalFunctionality, newStageTimingServer(o))
+	}
+	if o.standbyLease != nil {
+		additionalFunctionality = append(additionalFunctionality, newStandbyServer(ctx, o, resourceLock))
+	}
+	if o.gracefulShutdown {
+		var drainOptions []drain.Option
+		if o.standbyLease == nil {
+			// in the warm-standby mode VFs are left bound for the instance taking over
+			drainOptions = append(drainOptions, drain.WithAfterDrain(func(drainCtx context.Context) {
+				rebindKernelDrivers(drainCtx, o, resourceLock)
+			}))
+		}
+		additionalFunctionality = append(additionalFunctionality, drain.NewServer(ctx, append(drainOptions, o.drainOptions...)...))
+	}
+	additionalFunctionality = append(additionalFunctionality,
 		recvfd.NewServer(),
 		discover.NewServer(nsClient, nseClient),
 		roundrobin.NewServer(),
+		selectionhints.NewServer(o.selectionHintsOptions...),
+	)
+	if len(o.mechanismTypes) != 0 {
+		additionalFunctionality = append(additionalFunctionality, mechanismpriority.NewServer(o.mechanismTypes...))
+	}
+	if o.tokenOwners != nil {
+		additionalFunctionality = append(additionalFunctionality, tokenaccess.NewServer(o.tokenOwners))
+	}
+	if len(o.admissionFuncs) != 0 {
+		additionalFunctionality = append(additionalFunctionality, admission.NewServer(o.admissionFuncs...))
+	}
+	additionalFunctionality = append(additionalFunctionality,
+		cleanup.NewServer(),
 		resetmechanism.NewServer(
-			mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
-				kernel.MECHANISM: chain.NewNetworkServiceServer(
-					resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, sriovConfig),
-				),
-				vfiomech.MECHANISM: chain.NewNetworkServiceServer(
-					resourcepool.NewServer(sriov.VFIOPCIDriver, resourceLock, pciPool, resourcePool, sriovConfig),
-					vfio.NewServer(vfioDir, cgroupBaseDir),
-				),
-				noopmech.MECHANISM: null.NewServer(),
-			}),
+			mechanisms.NewServer(newMechanismServers(ctx, o, resourceLock)),
+			newResetMechanismOptions(o)...,
 		),
 		switchcase.NewServer(
 			&switchcase.ServerCase{
 				Condition: func(_ context.Context, conn *networkservice.Connection) bool {
 					return conn.GetMechanism().GetType() != noopmech.MECHANISM
 				},
-				Server: chain.NewNetworkServiceServer(
+				Server: newDatapathServer(o,
 					ethernetcontext.NewVFServer(),
-					inject.NewServer(),
+					newInjectServer(o),
 					connectioncontextkernel.NewServer(),
+					macsec.NewServer(),
 				),
 			},
 		),
-		connect.NewServer(
-			client.NewClient(
-				ctx,
-				client.WithName(name),
-				client.WithAdditionalFunctionality(
-					mechanismtranslation.NewClient(),
-					noop.NewClient(),
-					filtermechanisms.NewClient(),
-				),
-				client.WithDialTimeout(dialTimeout),
-				client.WithDialOptions(clientDialOptions...),
-				client.WithoutRefresh(),
-			),
-		),
+	)
+	if o.healthMonitor != nil {
+		additionalFunctionality = append(additionalFunctionality, vfhealth.NewServer(ctx, o.healthMonitor, o.sriovConfig))
 	}
+	if o.introspectSocketPath != "" || o.adminSocketPath != "" || o.diagnostics != nil || o.vfStatsMetrics {
+		additionalFunctionality = append(additionalFunctionality, newIntrospectServer(ctx, o, resourceLock))
+	}
+	additionalFunctionality = append(additionalFunctionality, o.additionalServerFunctionality...)
+	additionalFunctionality = append(additionalFunctionality,
+		connect.NewServer(newConnectClient(ctx, name, o, resourceLock)),
+	)
 
 	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator,
 		endpoint.WithName(name),
-		endpoint.WithAuthorizeServer(authzServer),
-		endpoint.WithAuthorizeMonitorConnectionServer(authzMonitorConnectionServer),
+		endpoint.WithAuthorizeServer(o.authorizeServer),
+		endpoint.WithAuthorizeMonitorConnectionServer(o.authorizeMonitorConnectionServer),
 		endpoint.WithAdditionalFunctionality(additionalFunctionality...),
 	)
 
 	return rv
 }
+
+// sendHeartbeats sends the forwarder heartbeat to the health checker every heartbeatInterval under the resource lock, so
+// the forwarder stuck on the resource lock fails the liveness
+func sendHeartbeats(ctx context.Context, healthChecker *health.Checker, resourceLock sync.Locker) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		resourceLock.Lock()
+		healthChecker.Heartbeat(health.ForwarderReconciler)
+		resourceLock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// registerPoolsMetrics registers the pools metrics and replaces the PCI pool with the one recording them
+func registerPoolsMetrics(o *serverOptions, resourceLock sync.Locker) {
+	var metricsOptions []metrics.Option
+	if resourcePool, ok := o.resourcePool.(metrics.ResourcePool); ok {
+		metricsOptions = append(metricsOptions, metrics.WithResourcePool(resourcePool, resourceLock))
+	}
+	if o.exhaustionTracker != nil {
+		metricsOptions = append(metricsOptions, metrics.WithExhaustionTracker(o.exhaustionTracker))
+	}
+	if o.eventBus != nil {
+		metricsOptions = append(metricsOptions, metrics.WithEventBus(o.eventBus))
+	}
+	poolsMetrics := metrics.New(metricsOptions...)
+	if o.pciPool != nil {
+		o.pciPool = poolsMetrics.PCIPool(o.pciPool)
+	}
+	if err := poolsMetrics.Register(o.metricsRegisterer); err != nil {
+		panic(err.Error())
+	}
+}
+
+// newStageTimingServer registers the Request stages histograms and returns stage timing chain element recording into them
+func newStageTimingServer(o *serverOptions) networkservice.NetworkServiceServer {
+	stageHistograms := stages.NewHistograms()
+	if err := stageHistograms.Register(o.metricsRegisterer); err != nil {
+		panic(err.Error())
+	}
+	return stagetiming.NewServer(stageHistograms)
+}
+
+// newInjectServer returns inject chain element, timed as the namespace injection stage if the stage metrics are enabled
+func newInjectServer(o *serverOptions) networkservice.NetworkServiceServer {
+	if !o.stageMetrics {
+		return inject.NewServer()
+	}
+	return stagetiming.NewStageServer(stages.NamespaceInjection, inject.NewServer())
+}
+
+// newStandbyServer returns standby chain element, or null server if the resource pool state can't be persisted
+func newStandbyServer(ctx context.Context, o *serverOptions, resourceLock sync.Locker) networkservice.NetworkServiceServer {
+	statefulPool, ok := o.resourcePool.(standby.StatefulPool)
+	if !ok {
+		log.FromContext(ctx).WithField("sriovServer", "standby").Error("resource pool state can't be persisted, warm-standby mode is disabled")
+		return null.NewServer()
+	}
+	return standby.NewServer(ctx, o.standbyLease, o.standbyStatePath, resourceLock, statefulPool, o.standbyOptions...)
+}
+
+// newIntrospectServer returns introspect chain element with the store served on the introspect socket and reported by
+// the admin service and the diagnostics, the VF traffic counters are collected for the stored connections
+func newIntrospectServer(ctx context.Context, o *serverOptions, resourceLock sync.Locker) networkservice.NetworkServiceServer {
+	store := introspect.NewStore()
+
+	if o.introspectSocketPath != "" {
+		logServeErrors(ctx, "introspect", o.introspectSocketPath,
+			introspect.ListenAndServe(ctx, o.introspectSocketPath, store))
+	}
+	if o.vfStatsMetrics && o.metricsRegisterer != nil {
+		collector := vfstats.NewCollector(o.sriovConfig, o.pciPool, store, o.vfStatsOptions...)
+		if err := o.metricsRegisterer.Register(collector); err != nil {
+			panic(err.Error())
+		}
+	}
+	if o.adminSocketPath != "" || o.diagnostics != nil {
+		adminOptions := []sriovadmin.Option{sriovadmin.WithConnections(store)}
+		if resourcePool, ok := o.resourcePool.(sriovadmin.ResourcePool); ok {
+			adminOptions = append(adminOptions, sriovadmin.WithResourcePool(resourcePool, resourceLock))
+		}
+		if o.exhaustionTracker != nil {
+			adminOptions = append(adminOptions, sriovadmin.WithExhaustionTracker(o.exhaustionTracker))
+		}
+		if o.sriovConfig != nil {
+			adminOptions = append(adminOptions, sriovadmin.WithConfig(o.sriovConfig))
+		}
+		if o.kubeletCheckpointPath != "" {
+			adminOptions = append(adminOptions, sriovadmin.WithCheckpoint(o.kubeletCheckpointPath))
+		}
+		adminServer := sriovadmin.NewServer(append(adminOptions, o.adminOptions...)...)
+		if o.adminSocketPath != "" {
+			logServeErrors(ctx, "admin", o.adminSocketPath,
+				sriovadmin.ListenAndServe(ctx, o.adminSocketPath, adminServer))
+		}
+		if o.diagnostics != nil {
+			o.diagnostics.SetState(adminServer)
+		}
+	}
+
+	return introspect.NewServer(store)
+}
+
+func logServeErrors(ctx context.Context, service, socketPath string, errCh <-chan error) {
+	go func() {
+		for err := range errCh {
+			log.FromContext(ctx).WithField("sriovServer", service).Errorf("failed to serve %s: %s", socketPath, err.Error())
+		}
+	}()
+}
+
+func newRegistryClients(ctx context.Context, o *serverOptions) (registry.NetworkServiceRegistryClient, registry.NetworkServiceEndpointRegistryClient) {
+	registryOptions := []registryclient.Option{
+		registryclient.WithDialOptions(o.dialOptions...),
+	}
+	if o.registryDialTimeout != 0 {
+		registryOptions = append(registryOptions, registryclient.WithDialTimeout(o.registryDialTimeout))
+	}
+
+	nseClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx, append([]registryclient.Option{
+		registryclient.WithNSEClientURLResolver(clienturls.NewNetworkServiceEndpointRegistryClient(o.clientURLs...)),
+		registryclient.WithNSEAdditionalFunctionality(
+			registryrecvfd.NewNetworkServiceEndpointRegistryClient(),
+			registrysendfd.NewNetworkServiceEndpointRegistryClient(),
+		),
+		registryclient.WithNSERetryClient(registryretry.NewNetworkServiceEndpointRegistryClient(ctx, o.registryRetryOptions...)),
+	}, registryOptions...)...)
+	nsClient := registryclient.NewNetworkServiceRegistryClient(ctx, append([]registryclient.Option{
+		registryclient.WithNSClientURLResolver(clienturls.NewNetworkServiceRegistryClient(o.clientURLs...)),
+		registryclient.WithNSRetryClient(registryretry.NewNetworkServiceRegistryClient(ctx, o.registryRetryOptions...)),
+	}, registryOptions...)...)
+
+	return nsClient, nseClient
+}
+
+func newConnectClient(ctx context.Context, name string, o *serverOptions, resourceLock sync.Locker) networkservice.NetworkServiceClient {
+	connectClient := client.NewClient(
+		ctx,
+		client.WithName(name),
+		client.WithAdditionalFunctionality(newAdditionalClientFunctionality(o, resourceLock)...),
+		client.WithDialTimeout(o.connectDialTimeout),
+		client.WithDialOptions(o.dialOptions...),
+		client.WithoutRefresh(),
+	)
+	if o.connectRetry {
+		connectClient = retry.NewClient(connectClient, o.connectRetryOptions...)
+	}
+	return connectClient
+}
+
+// newResetMechanismOptions enables the VF mechanisms migration if it is requested and supported by the resource pool
+func newResetMechanismOptions(o *serverOptions) []resetmechanism.Option {
+	if _, ok := o.resourcePool.(resourcepool.RebindingResourcePool); !ok || !o.mechanismMigration {
+		return nil
+	}
+	return []resetmechanism.Option{
+		resetmechanism.WithMigration(canMigrate),
+	}
+}
+
+// canMigrate returns true if the connection can be migrated between the VF mechanisms keeping the same VF
+func canMigrate(ctx context.Context, oldMech, newMech *networkservice.Mechanism) bool {
+	for _, mech := range []*networkservice.Mechanism{oldMech, newMech} {
+		switch mech.GetType() {
+		case kernel.MECHANISM, vfiomech.MECHANISM, rdma.MECHANISM:
+		default:
+			return false
+		}
+	}
+	return resourcepool.CanMigrate(ctx, oldMech, newMech)
+}
+
+func newMechanismServers(ctx context.Context, o *serverOptions, resourceLock sync.Locker) map[string]networkservice.NetworkServiceServer {
+	vfioInitErr := initVFIO(ctx, o)
+	if vfioInitErr == nil && o.warmVFIO && !o.dryRun {
+		o.resourcePoolOptions = append(o.resourcePoolOptions, warmUp(ctx, o, resourceLock)...)
+	}
+
+	kernelDatapathServers := []networkservice.NetworkServiceServer{
+		vfmtu.NewServer(),
+		stats.NewServer(),
+		qos.NewServer(o.sriovConfig),
+	}
+	if o.ptp {
+		kernelDatapathServers = append(kernelDatapathServers, ptp.NewServer(o.sriovConfig, o.cgroupBaseDir, o.ptpServerOptions...))
+	}
+	if o.irqAffinity {
+		kernelDatapathServers = append(kernelDatapathServers, irqaffinity.NewServer(o.irqAffinityOptions...))
+	}
+	if len(o.dpuAgents) > 0 {
+		kernelDatapathServers = append(kernelDatapathServers, dpuoffload.NewServer(o.sriovConfig, o.dpuAgents))
+	}
+	if o.xdpPreparer != nil {
+		kernelDatapathServers = append(kernelDatapathServers, afxdp.NewServer(o.xdpPreparer))
+	}
+
+	kernelServers := []networkservice.NetworkServiceServer{
+		resourcepool.NewServer(sriov.KernelDriver, resourceLock, o.pciPool, o.resourcePool, o.sriovConfig,
+			o.resourcePoolOptions...),
+	}
+	if !o.dryRun {
+		kernelServers = append(kernelServers, extravf.NewServer(o.pciPool))
+	}
+	if bondPool, ok := o.resourcePool.(bond.ResourcePool); ok && o.bonding && !o.dryRun {
+		var bondOptions []bond.Option
+		if o.shardedLock != nil {
+			bondOptions = append(bondOptions, bond.WithShardedLock(o.shardedLock))
+		}
+		kernelServers = append(kernelServers, bond.NewServer(resourceLock, o.pciPool, bondPool, o.sriovConfig, bondOptions...))
+	}
+
+	mechanismServers := map[string]networkservice.NetworkServiceServer{
+		kernel.MECHANISM: chain.NewNetworkServiceServer(
+			append(kernelServers, newDatapathServer(o, kernelDatapathServers...))...,
+		),
+		vfiomech.MECHANISM: newVFIOServer(o, resourceLock, vfioInitErr),
+		rdma.MECHANISM: chain.NewNetworkServiceServer(
+			resourcepool.NewServer(sriov.KernelDriver, resourceLock, o.pciPool, o.resourcePool, o.sriovConfig,
+				o.resourcePoolOptions...),
+			newDatapathServer(o, rdma.NewServer(), stats.NewServer()),
+		),
+		noopmech.MECHANISM: null.NewServer(),
+	}
+	if o.noopStore != nil {
+		mechanismServers[noopmech.MECHANISM] = noop.NewServer(o.noopStore)
+	}
+	if o.vlanPool != nil {
+		mechanismServers[vlanmech.MECHANISM] = vlan.NewServer(o.vlanPool)
+	}
+	if o.vdpaManager != nil {
+		vdpaPool := vdpadev.NewPool(o.resourcePool, o.vdpaManager)
+		mechanismServers[vdpa.MECHANISM] = chain.NewNetworkServiceServer(
+			resourcepool.NewServer(sriov.VhostVDPADriver, resourceLock, o.pciPool, vdpaPool, o.sriovConfig,
+				o.resourcePoolOptions...),
+			newDatapathServer(o, vdpa.NewServer(vdpaPool, o.cgroupBaseDir, o.vdpaServerOptions...)),
+		)
+	}
+	if len(o.mechanismTypes) == 0 {
+		return mechanismServers
+	}
+
+	enabledMechanismServers := map[string]networkservice.NetworkServiceServer{}
+	for _, mechanismType := range o.mechanismTypes {
+		if mechanismServer, ok := mechanismServers[mechanismType]; ok {
+			enabledMechanismServers[mechanismType] = mechanismServer
+		}
+	}
+	return enabledMechanismServers
+}
+
+// initVFIO ensures the VFIO kernel modules are initialized if it is requested
+func initVFIO(ctx context.Context, o *serverOptions) error {
+	if !o.vfioInit || o.dryRun {
+		return nil
+	}
+	if err := vfioinit.Init(ctx, o.vfioInitOptions...); err != nil {
+		log.FromContext(ctx).WithField("sriovServer", "vfioinit").Errorf("VFIO mechanism is disabled: %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// warmUp pre-binds the warm IOMMU groups to the vfio-pci driver and returns the resourcepool options re-warming them on
+// the VFs free, or no options if the resource pool doesn't support the warm IOMMU groups
+func warmUp(ctx context.Context, o *serverOptions, resourceLock sync.Locker) []resourcepool.Option {
+	logger := log.FromContext(ctx).WithField("sriovServer", "warmVFIO")
+
+	warmPool, ok := o.resourcePool.(resourcepool.WarmResourcePool)
+	if !ok {
+		logger.Error("resource pool doesn't support the warm IOMMU groups")
+		return nil
+	}
+
+	resourceLock.Lock()
+	err := resourcepool.WarmUp(ctx, warmPool, o.pciPool, o.sriovConfig.WarmVFIO)
+	resourceLock.Unlock()
+	if err != nil {
+		logger.Errorf("failed to warm up IOMMU groups: %s", err.Error())
+	}
+
+	return []resourcepool.Option{resourcepool.WithWarmVFIO(o.sriovConfig.WarmVFIO)}
+}
+
+// newVFIOServer returns VFIO mechanism server, or error server if the VFIO kernel modules can't be initialized
+func newVFIOServer(o *serverOptions, resourceLock sync.Locker, vfioInitErr error) networkservice.NetworkServiceServer {
+	if vfioInitErr != nil {
+		return injecterror.NewServer(injecterror.WithError(vfioInitErr), injecterror.WithCloseErrorTimes())
+	}
+	vfioDatapathServers := []networkservice.NetworkServiceServer{
+		vfio.NewServer(o.vfioDir, o.cgroupBaseDir, o.vfioServerOptions...),
+		qos.NewServer(o.sriovConfig),
+	}
+	if o.ptp {
+		vfioDatapathServers = append(vfioDatapathServers, ptp.NewServer(o.sriovConfig, o.cgroupBaseDir, o.ptpServerOptions...))
+	}
+	if len(o.dpuAgents) > 0 {
+		vfioDatapathServers = append(vfioDatapathServers, dpuoffload.NewServer(o.sriovConfig, o.dpuAgents))
+	}
+	return chain.NewNetworkServiceServer(
+		resourcepool.NewServer(sriov.VFIOPCIDriver, resourceLock, o.pciPool, o.resourcePool, o.sriovConfig,
+			o.resourcePoolOptions...),
+		newDatapathServer(o, vfioDatapathServers...),
+		vfio.NewConnectionContextServer(),
+	)
+}
+
+func newAdditionalClientFunctionality(o *serverOptions, resourceLock sync.Locker) []networkservice.NetworkServiceClient {
+	additionalFunctionality := []networkservice.NetworkServiceClient{
+		mechanismtranslation.NewClient(),
+		noop.NewClient(),
+	}
+	if o.tcManager != nil && !o.dryRun {
+		additionalFunctionality = append(additionalFunctionality, tcoffload.NewClient(o.tcManager))
+	}
+	if o.vlanPool != nil && !o.dryRun {
+		var vlanOptions []vlan.Option
+		if o.auditor != nil {
+			vlanOptions = append(vlanOptions, vlan.WithAuditor(o.auditor))
+		}
+		if o.tcManager != nil {
+			vlanOptions = append(vlanOptions, vlan.WithVFTagger(tcoffload.NewVLANTagger(o.tcManager, sriovvfconfig.NewNetlink())))
+		}
+		additionalFunctionality = append(additionalFunctionality, vlan.NewClient(vlanOptions...))
+	}
+	additionalFunctionality = append(additionalFunctionality, filtermechanisms.NewClient())
+	if o.vlanPool != nil {
+		additionalFunctionality = append(additionalFunctionality,
+			resourcepool.NewClient(sriov.KernelDriver, resourceLock, o.pciPool, o.resourcePool, o.sriovConfig,
+				o.resourcePoolOptions...),
+		)
+	}
+	return append(additionalFunctionality, o.additionalClientFunctionality...)
+}
+
+// newDatapathServer returns chain element configuring the host datapath, or null server in the dry-run mode
+func newDatapathServer(o *serverOptions, servers ...networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+	if o.dryRun {
+		return null.NewServer()
+	}
+	if !o.driverOverride {
+		return chain.NewNetworkServiceServer(servers...)
+	}
+	return switchcase.NewServer(
+		&switchcase.ServerCase{
+			Condition: func(_ context.Context, conn *networkservice.Connection) bool {
+				return !isDriverOverridden(conn)
+			},
+			Server: chain.NewNetworkServiceServer(servers...),
+		},
+	)
+}
+
+// isDriverOverridden returns if the connection VF is forced with resourcepool.DriverLabel to the driver other than the
+// mechanism implied one, so there is no mechanism datapath to configure
+func isDriverOverridden(conn *networkservice.Connection) bool {
+	driverType, ok, _ := resourcepool.DriverFromLabels(conn.GetLabels())
+	if !ok {
+		return false
+	}
+	if conn.GetMechanism().GetType() == vfiomech.MECHANISM {
+		return driverType != sriov.VFIOPCIDriver
+	}
+	return driverType != sriov.KernelDriver
+}
+
+// rebindKernelDrivers rebinds all the configured VFs to the kernel driver, so no VF is left bound to vfio-pci driver
+func rebindKernelDrivers(ctx context.Context, o *serverOptions, resourceLock sync.Locker) {
+	logger := log.FromContext(ctx).WithField("sriovServer", "rebindKernelDrivers")
+
+	resourceLock.Lock()
+	defer resourceLock.Unlock()
+
+	for pfPCIAddr, pfCfg := range o.sriovConfig.PhysicalFunctions {
+		if o.shardedLock != nil {
+			o.shardedLock.Lock(pfPCIAddr)
+		}
+		for _, vfCfg := range pfCfg.VirtualFunctions {
+			if err := func() error {
+				vf, err := o.pciPool.GetPCIFunction(vfCfg.Address)
+				if err != nil {
+					return err
+				}
+				iommuGroup, err := vf.GetIOMMUGroup()
+				if err != nil {
+					return err
+				}
+				return o.pciPool.BindDriver(ctx, iommuGroup, sriov.KernelDriver)
+			}(); err != nil {
+				logger.Warnf("failed to rebind VF %s to the kernel driver: %s", vfCfg.Address, err.Error())
+			}
+		}
+		if o.shardedLock != nil {
+			o.shardedLock.Unlock(pfPCIAddr)
+		}
+	}
+}
+
+// NewLegacyServer - returns an Endpoint implementing the SR-IOV Forwarder networks service
+//   - name - name of the Forwarder
+//   - authzServer - policy for allowing or rejecting requests
+//   - tokenGenerator - token.GeneratorFunc - generates tokens for use in Path
+//   - pciPool - provides PCI functions
+//   - resourcePool - provides SR-IOV resources
+//   - sriovConfig - SR-IOV PCI functions config
+//   - vfioDir - host /dev/vfio directory mount location
+//   - cgroupBaseDir - host /sys/fs/cgroup/devices directory mount location
+//   - clientUrl - *url.URL for the talking to the NSMgr
+//   - ...clientDialOptions - dialOptions for dialing the NSMgr
+//
+// Deprecated: use NewServer with options instead
+func NewLegacyServer(
+	ctx context.Context,
+	name string,
+	authzServer networkservice.NetworkServiceServer,
+	authzMonitorConnectionServer networkservice.MonitorConnectionServer,
+	tokenGenerator token.GeneratorFunc,
+	pciPool resourcepool.PCIPool,
+	resourcePool resourcepool.ResourcePool,
+	sriovConfig *config.Config,
+	vfioDir, cgroupBaseDir string,
+	clientURL *url.URL,
+	dialTimeout time.Duration,
+	clientDialOptions ...grpc.DialOption,
+) endpoint.Endpoint {
+	return NewServer(ctx, name, tokenGenerator,
+		WithAuthorizeServer(authzServer),
+		WithAuthorizeMonitorConnectionServer(authzMonitorConnectionServer),
+		WithPools(pciPool, resourcePool),
+		WithSRIOVConfig(sriovConfig),
+		WithVFIODirs(vfioDir, cgroupBaseDir),
+		WithClientURL(clientURL),
+		WithDialTimeout(dialTimeout),
+		WithDialOptions(clientDialOptions...),
+	)
+}
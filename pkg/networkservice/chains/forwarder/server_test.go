@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package xconnectns_test
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	xconnectns "github.com/ljkiraly/sdk-sriov/pkg/networkservice/chains/forwarder"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+)
+
+const (
+	configFileName = "config.yml"
+	tokenName      = "service.domain.1/10G"
+	vfPCIAddr      = "0000:01:00.1"
+)
+
+func generateToken(_ credentials.AuthInfo) (string, time.Time, error) {
+	expires := time.Now().Add(time.Hour)
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "spiffe://example.org/forwarder",
+		ExpiresAt: jwt.NewNumericDate(expires),
+	}).SignedString([]byte("secret"))
+	return signed, expires, err
+}
+
+type resettingPCIPool struct {
+	*pci.Pool
+	resets chan string
+}
+
+func (p *resettingPCIPool) ResetFunction(_ context.Context, pciAddr string, _ sriov.DriverType) error {
+	p.resets <- pciAddr
+	return nil
+}
+
+func serveEndpoint(ctx context.Context, t *testing.T) *url.URL {
+	endpointURL := &url.URL{Scheme: "unix", Path: filepath.Join(t.TempDir(), "endpoint.sock")}
+
+	server := grpc.NewServer()
+	networkservice.RegisterNetworkServiceServer(server, checkrequest.NewServer(t, func(*testing.T, *networkservice.NetworkServiceRequest) {}))
+	errCh := grpcutils.ListenAndServe(ctx, endpointURL, server)
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	default:
+	}
+
+	return endpointURL
+}
+
+func TestForwarder_VFReset_PoolsMetricsAlerting(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := config.ReadConfig(ctx, configFileName)
+	require.NoError(t, err)
+
+	testPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+	require.NoError(t, err)
+	pciPool := &resettingPCIPool{Pool: testPool, resets: make(chan string, 1)}
+
+	tokenPool := token.NewPool(cfg)
+	tokenID, err := tokenPool.AllocateFree(tokenName)
+	require.NoError(t, err)
+
+	forwarder := xconnectns.NewServer(ctx, "forwarder", generateToken,
+		xconnectns.WithPools(pciPool, resource.NewPool(tokenPool, cfg)),
+		xconnectns.WithSRIOVConfig(cfg),
+		xconnectns.WithResourcePoolOptions(resourcepool.WithVFReset(sriov.KernelDriver)),
+		xconnectns.WithPoolsMetrics(prometheus.NewRegistry()),
+		xconnectns.WithAlerting(alerting.NewEvaluator(&alerting.Config{})),
+		xconnectns.WithDryRun(),
+		xconnectns.WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+
+	clientToken, expires, err := generateToken(nil)
+	require.NoError(t, err)
+
+	requestCtx := clienturlctx.WithClientURL(ctx, serveEndpoint(ctx, t))
+	conn, err := forwarder.Request(requestCtx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{
+					Name:    "nsc",
+					Id:      "id",
+					Token:   clientToken,
+					Expires: timestamppb.New(expires),
+				}},
+			},
+			Mechanism: &networkservice.Mechanism{
+				Cls:  "LOCAL",
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+
+	// the VF is reset through the metrics and alerting recording PCI pools
+	_, err = forwarder.Close(requestCtx, conn)
+	require.NoError(t, err)
+	select {
+	case pciAddr := <-pciPool.resets:
+		require.Equal(t, vfPCIAddr, pciAddr)
+	case <-ctx.Done():
+		require.FailNow(t, "VF is not reset")
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+// Package xconnectns provides an Endpoint implementing the SR-IOV Forwarder networks service
+package xconnectns
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/chains/endpoint"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/tools/token"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/admission"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/drain"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/irqaffinity"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/noop"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vlan"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/ptp"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/selectionhints"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/standby"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tokenaccess"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/vfhealth"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	vdpadev "github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/diagnostics"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/health"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfioinit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfstats"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/xdp"
+)
+
+type serverOptions struct{}
+
+// Option is an option pattern for NewServer
+type Option func(o *serverOptions)
+
+// NewServer returns an Endpoint failing all the requests with sriov.UnsupportedError, the SR-IOV Forwarder is
+// supported on Linux only
+func NewServer(ctx context.Context, name string, tokenGenerator token.GeneratorFunc, _ ...Option) endpoint.Endpoint {
+	return endpoint.NewServer(ctx, tokenGenerator,
+		endpoint.WithName(name),
+		endpoint.WithAdditionalFunctionality(
+			injecterror.NewServer(
+				injecterror.WithError(sriov.NewUnsupportedError("SR-IOV Forwarder")),
+				injecterror.WithCloseErrorTimes()),
+		),
+	)
+}
+
+// NewLegacyServer returns an Endpoint failing all the requests with sriov.UnsupportedError, the SR-IOV Forwarder is
+// supported on Linux only
+//
+// Deprecated: use NewServer with options instead
+func NewLegacyServer(
+	ctx context.Context,
+	name string,
+	_ networkservice.NetworkServiceServer,
+	_ networkservice.MonitorConnectionServer,
+	tokenGenerator token.GeneratorFunc,
+	_ resourcepool.PCIPool,
+	_ resourcepool.ResourcePool,
+	_ *config.Config,
+	_, _ string,
+	_ *url.URL,
+	_ time.Duration,
+	_ ...grpc.DialOption,
+) endpoint.Endpoint {
+	return NewServer(ctx, name, tokenGenerator)
+}
+
+// WithAuthorizeServer does nothing on the unsupported platforms
+func WithAuthorizeServer(networkservice.NetworkServiceServer) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAuthorizeMonitorConnectionServer does nothing on the unsupported platforms
+func WithAuthorizeMonitorConnectionServer(networkservice.MonitorConnectionServer) Option {
+	return func(*serverOptions) {}
+}
+
+// WithPools does nothing on the unsupported platforms
+func WithPools(resourcepool.PCIPool, resourcepool.ResourcePool) Option {
+	return func(*serverOptions) {}
+}
+
+// WithPoolRouter does nothing on the unsupported platforms
+func WithPoolRouter(*resourcepool.Router) Option {
+	return func(*serverOptions) {}
+}
+
+// WithRegisteredPools does nothing on the unsupported platforms
+func WithRegisteredPools(context.Context, string) Option {
+	return func(*serverOptions) {}
+}
+
+// WithResourcePoolOptions does nothing on the unsupported platforms
+func WithResourcePoolOptions(...resourcepool.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithShardedResourceLock does nothing on the unsupported platforms
+func WithShardedResourceLock() Option {
+	return func(*serverOptions) {}
+}
+
+// WithSelectionHintsOptions does nothing on the unsupported platforms
+func WithSelectionHintsOptions(...selectionhints.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithSRIOVConfig does nothing on the unsupported platforms
+func WithSRIOVConfig(*config.Config) Option {
+	return func(*serverOptions) {}
+}
+
+// WithVLANPool does nothing on the unsupported platforms
+func WithVLANPool(vlan.VLANPool) Option {
+	return func(*serverOptions) {}
+}
+
+// WithMechanisms does nothing on the unsupported platforms
+func WithMechanisms(...string) Option {
+	return func(*serverOptions) {}
+}
+
+// WithVFIODirs does nothing on the unsupported platforms
+func WithVFIODirs(_, _ string) Option {
+	return func(*serverOptions) {}
+}
+
+// WithVFIOServerOptions does nothing on the unsupported platforms
+func WithVFIOServerOptions(...vfio.ServerOption) Option {
+	return func(*serverOptions) {}
+}
+
+// WithVFIOInit does nothing on the unsupported platforms
+func WithVFIOInit(...vfioinit.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithWarmVFIO does nothing on the unsupported platforms
+func WithWarmVFIO() Option {
+	return func(*serverOptions) {}
+}
+
+// WithVDPA does nothing on the unsupported platforms
+func WithVDPA(*vdpadev.Manager, ...vdpa.ServerOption) Option {
+	return func(*serverOptions) {}
+}
+
+// WithDPUAgents does nothing on the unsupported platforms
+func WithDPUAgents(map[string]dpu.Programmer) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAFXDP does nothing on the unsupported platforms
+func WithAFXDP(*xdp.Preparer) Option {
+	return func(*serverOptions) {}
+}
+
+// WithTCOffload does nothing on the unsupported platforms
+func WithTCOffload(*tcflower.Manager) Option {
+	return func(*serverOptions) {}
+}
+
+// WithPTP does nothing on the unsupported platforms
+func WithPTP(...ptp.ServerOption) Option {
+	return func(*serverOptions) {}
+}
+
+// WithClientURL does nothing on the unsupported platforms
+func WithClientURL(*url.URL) Option {
+	return func(*serverOptions) {}
+}
+
+// WithClientURLs does nothing on the unsupported platforms
+func WithClientURLs(...*url.URL) Option {
+	return func(*serverOptions) {}
+}
+
+// WithDialTimeout does nothing on the unsupported platforms
+func WithDialTimeout(time.Duration) Option {
+	return func(*serverOptions) {}
+}
+
+// WithConnectDialTimeout does nothing on the unsupported platforms
+func WithConnectDialTimeout(time.Duration) Option {
+	return func(*serverOptions) {}
+}
+
+// WithRegistryDialTimeout does nothing on the unsupported platforms
+func WithRegistryDialTimeout(time.Duration) Option {
+	return func(*serverOptions) {}
+}
+
+// WithConnectRetry does nothing on the unsupported platforms
+func WithConnectRetry(_, _ time.Duration) Option {
+	return func(*serverOptions) {}
+}
+
+// WithRegistryRetry does nothing on the unsupported platforms
+func WithRegistryRetry(_, _ time.Duration) Option {
+	return func(*serverOptions) {}
+}
+
+// WithDialOptions does nothing on the unsupported platforms
+func WithDialOptions(...grpc.DialOption) Option {
+	return func(*serverOptions) {}
+}
+
+// WithDryRun does nothing on the unsupported platforms
+func WithDryRun() Option {
+	return func(*serverOptions) {}
+}
+
+// WithGracefulShutdown does nothing on the unsupported platforms
+func WithGracefulShutdown(...drain.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithHealthMonitor does nothing on the unsupported platforms
+func WithHealthMonitor(vfhealth.HealthMonitor) Option {
+	return func(*serverOptions) {}
+}
+
+// WithIRQAffinity does nothing on the unsupported platforms
+func WithIRQAffinity(...irqaffinity.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithBonding does nothing on the unsupported platforms
+func WithBonding() Option {
+	return func(*serverOptions) {}
+}
+
+// WithDriverOverride does nothing on the unsupported platforms
+func WithDriverOverride() Option {
+	return func(*serverOptions) {}
+}
+
+// WithMechanismMigration does nothing on the unsupported platforms
+func WithMechanismMigration() Option {
+	return func(*serverOptions) {}
+}
+
+// WithWarmStandby does nothing on the unsupported platforms
+func WithWarmStandby(standby.Lease, string, ...standby.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithIntrospection does nothing on the unsupported platforms
+func WithIntrospection(string) Option {
+	return func(*serverOptions) {}
+}
+
+// WithPoolsMetrics does nothing on the unsupported platforms
+func WithPoolsMetrics(prometheus.Registerer) Option {
+	return func(*serverOptions) {}
+}
+
+// WithVFStatsMetrics does nothing on the unsupported platforms
+func WithVFStatsMetrics(...vfstats.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithStageMetrics does nothing on the unsupported platforms
+func WithStageMetrics() Option {
+	return func(*serverOptions) {}
+}
+
+// WithHardwareLogLevels does nothing on the unsupported platforms
+func WithHardwareLogLevels(string) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAuditor does nothing on the unsupported platforms
+func WithAuditor(*audit.Auditor) Option {
+	return func(*serverOptions) {}
+}
+
+// WithEventBus does nothing on the unsupported platforms
+func WithEventBus(*eventbus.Bus) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAlerting does nothing on the unsupported platforms
+func WithAlerting(*alerting.Evaluator) Option {
+	return func(*serverOptions) {}
+}
+
+// WithHealthChecker does nothing on the unsupported platforms
+func WithHealthChecker(*health.Checker) Option {
+	return func(*serverOptions) {}
+}
+
+// WithExhaustionTracker does nothing on the unsupported platforms
+func WithExhaustionTracker(*exhaustion.Tracker) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAdmin does nothing on the unsupported platforms
+func WithAdmin(string, ...sriovadmin.Option) Option {
+	return func(*serverOptions) {}
+}
+
+// WithDiagnostics does nothing on the unsupported platforms
+func WithDiagnostics(*diagnostics.Diagnostics) Option {
+	return func(*serverOptions) {}
+}
+
+// WithKubeletCheckpoint does nothing on the unsupported platforms
+func WithKubeletCheckpoint(string) Option {
+	return func(*serverOptions) {}
+}
+
+// WithTokenAccessControl does nothing on the unsupported platforms
+func WithTokenAccessControl(tokenaccess.TokenOwners) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAdmissionFuncs does nothing on the unsupported platforms
+func WithAdmissionFuncs(...admission.Func) Option {
+	return func(*serverOptions) {}
+}
+
+// WithNoopStore does nothing on the unsupported platforms
+func WithNoopStore(*noop.Store) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAdditionalServerFunctionality does nothing on the unsupported platforms
+func WithAdditionalServerFunctionality(...networkservice.NetworkServiceServer) Option {
+	return func(*serverOptions) {}
+}
+
+// WithAdditionalClientFunctionality does nothing on the unsupported platforms
+func WithAdditionalClientFunctionality(...networkservice.NetworkServiceClient) Option {
+	return func(*serverOptions) {}
+}
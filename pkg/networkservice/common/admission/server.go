@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission provides chain element vetoing the new connections before the SR-IOV resources selection
+package admission
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Func is an admission check (e.g. quota, maintenance window, tenant policy), the Request is rejected if it returns an
+// error
+type Func func(ctx context.Context, request *networkservice.NetworkServiceRequest) error
+
+type admittedKey struct{}
+
+type admissionServer struct {
+	admissionFuncs []Func
+}
+
+// NewServer returns a new admission server chain element evaluating admissionFuncs in order for the new connection
+// Requests, the refresh Requests of the already admitted connections are not evaluated
+func NewServer(admissionFuncs ...Func) networkservice.NetworkServiceServer {
+	return &admissionServer{
+		admissionFuncs: admissionFuncs,
+	}
+}
+
+func (s *admissionServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if _, ok := metadata.Map(ctx, false).Load(admittedKey{}); ok {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	for _, admissionFunc := range s.admissionFuncs {
+		if err := admissionFunc(ctx, request); err != nil {
+			return nil, errors.Wrap(err, "request is not admitted")
+		}
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Map(ctx, false).Store(admittedKey{}, struct{}{})
+
+	return conn, nil
+}
+
+func (s *admissionServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	metadata.Map(ctx, false).Delete(admittedKey{})
+	return next.Server(ctx).Close(ctx, conn)
+}
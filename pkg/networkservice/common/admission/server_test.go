@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/admission"
+)
+
+func TestAdmissionServer(t *testing.T) {
+	inMaintenance := true
+	calls := 0
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		admission.NewServer(func(_ context.Context, _ *networkservice.NetworkServiceRequest) error {
+			calls++
+			if inMaintenance {
+				return errors.New("maintenance window")
+			}
+			return nil
+		}),
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	}
+
+	_, err := server.Request(context.Background(), request)
+	require.Error(t, err)
+
+	inMaintenance = false
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	// Refresh of the admitted connection is not evaluated.
+	inMaintenance = true
+	_, err = server.Request(context.Background(), request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), request)
+	require.Error(t, err)
+	require.Equal(t, 3, calls)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package afxdp
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type afxdpClient struct {
+	files map[string]*os.File // files[connID] -> XSKMAP file
+	lock  sync.Mutex
+}
+
+// NewClient returns a new AF_XDP client chain element receiving the XSKMAP fd passed by the server, the mechanism
+// XSKMapFDURLKey parameter is replaced with the received file URL. The file is closed on Close.
+func NewClient() networkservice.NetworkServiceClient {
+	return &afxdpClient{
+		files: map[string]*os.File{},
+	}
+}
+
+func (c *afxdpClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	rpcCredentials := grpcfd.PerRPCCredentials(grpcfd.PerRPCCredentialsFromCallOptions(opts...))
+	opts = append(opts, grpc.PerRPCCredentials(rpcCredentials))
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	inodeURL := conn.GetMechanism().GetParameters()[XSKMapFDURLKey]
+	if inodeURL == "" {
+		return conn, nil
+	}
+
+	recv, _ := grpcfd.FromPerRPCCredentials(rpcCredentials)
+	file, err := recvFile(ctx, recv, inodeURL)
+	if err != nil {
+		_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+		return nil, err
+	}
+
+	c.lock.Lock()
+	if prev, ok := c.files[conn.GetId()]; ok {
+		_ = prev.Close()
+	}
+	c.files[conn.GetId()] = file
+	c.lock.Unlock()
+
+	conn.GetMechanism().GetParameters()[XSKMapFDURLKey] = (&url.URL{Scheme: "file", Path: file.Name()}).String()
+
+	return conn, nil
+}
+
+func (c *afxdpClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.lock.Lock()
+	if file, ok := c.files[conn.GetId()]; ok {
+		_ = file.Close()
+		delete(c.files, conn.GetId())
+	}
+	c.lock.Unlock()
+
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func recvFile(ctx context.Context, recv grpcfd.FDRecver, inodeURL string) (*os.File, error) {
+	if recv == nil {
+		return nil, errors.New("not able to receive XSKMAP fd over the connection: no grpcfd receiver")
+	}
+	fileCh, err := recv.RecvFileByURL(inodeURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive the file: %s", inodeURL)
+	}
+	select {
+	case file, ok := <-fileCh:
+		if !ok {
+			return nil, errors.Errorf("failed to receive the file: %s", inodeURL)
+		}
+		return file, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "failed to receive the file: %s", inodeURL)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package afxdp provides chain elements for the AF_XDP-ready VF driver mode: the VF stays bound to the kernel driver,
+// the forwarder attaches the XDP program to it and passes the XSKMAP fd to the client with grpcfd, so the client can
+// run the AF_XDP sockets on the VF without the privileged pod
+package afxdp
+
+const (
+	// Label is a connection label requesting the AF_XDP-ready VF, should be set to "true"
+	Label = "sriovAFXDP"
+	// XSKMapFDURLKey is a mechanism parameter key for the XSKMAP fd URL: inode://${dev}/${ino} sent with grpcfd by the
+	// server, file:///proc/self/fd/${fd} received by the client
+	XSKMapFDURLKey = "afxdpXSKMapFDURL"
+	// QueueCountKey is a mechanism parameter key for the VF RX queues count, the XSKMAP is keyed by the RX queue index
+	QueueCountKey = "afxdpQueueCount"
+)
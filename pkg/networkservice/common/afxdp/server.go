@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package afxdp
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/xdp"
+)
+
+// Preparer is a xdp.Preparer interface
+type Preparer interface {
+	Prepare(ifName string) (*xdp.Prepared, error)
+	Release(ifName string) error
+}
+
+type preparedKey struct{}
+
+type afxdpServer struct {
+	preparer Preparer
+}
+
+// NewServer returns a new AF_XDP server chain element. For the connections labeled with Label it prepares the kernel
+// driver VF selected by the previous chain elements with the preparer and passes the XSKMAP fd to the client, the VF
+// is released on Close. It should be placed before the VF is moved into the client net namespace.
+func NewServer(preparer Preparer) networkservice.NetworkServiceServer {
+	return &afxdpServer{
+		preparer: preparer,
+	}
+}
+
+func (s *afxdpServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if request.GetConnection().GetLabels()[Label] != "true" {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	vfConfig, ok := vfconfig.Load(ctx, false)
+	if !ok || vfConfig.VFInterfaceName == "" {
+		return nil, errors.New("AF_XDP-ready VF is requested, but no kernel driver VF is selected")
+	}
+
+	sender, ok := grpcfd.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("not able to pass XSKMAP fd over the connection: no grpcfd sender")
+	}
+
+	_, isEstablished := metadata.Map(ctx, false).Load(preparedKey{})
+
+	prepared, err := s.preparer.Prepare(vfConfig.VFInterfaceName)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Map(ctx, false).Store(preparedKey{}, prepared.InterfaceName)
+
+	inodeURL, err := grpcfd.FileToURL(prepared.XSKMap)
+	if err == nil {
+		select {
+		case err = <-sender.SendFile(prepared.XSKMap):
+		default:
+		}
+	}
+	if err != nil {
+		if !isEstablished {
+			s.release(ctx)
+		}
+		return nil, errors.Wrapf(err, "failed to send XSKMAP fd for the VF net interface: %s", prepared.InterfaceName)
+	}
+
+	mech := request.GetConnection().GetMechanism()
+	if mech.GetParameters() == nil {
+		mech.Parameters = map[string]string{}
+	}
+	mech.GetParameters()[XSKMapFDURLKey] = inodeURL.String()
+	mech.GetParameters()[QueueCountKey] = strconv.Itoa(prepared.QueueCount)
+
+	var registered bool
+	if !isEstablished {
+		registered = cleanup.Register(ctx, s, func(ctx context.Context) {
+			s.release(ctx)
+		})
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !isEstablished && !registered {
+			s.release(ctx)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *afxdpServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	// VF should be moved back into the forwarder netns at this point
+	s.release(ctx)
+	cleanup.Unregister(ctx, s)
+
+	return rv, err
+}
+
+func (s *afxdpServer) release(ctx context.Context) {
+	rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(preparedKey{})
+	if !ok {
+		return
+	}
+	ifName := rawValue.(string)
+
+	if err := s.preparer.Release(ifName); err != nil {
+		log.FromContext(ctx).WithField("afxdpServer", "release").
+			Errorf("failed to release AF_XDP-ready VF net interface %s: %s", ifName, err.Error())
+	}
+}
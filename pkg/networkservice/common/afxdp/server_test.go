@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package afxdp_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/afxdp"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/xdp"
+)
+
+const (
+	ifName     = "ens1f0v1"
+	xskMapData = "xsks_map"
+)
+
+type preparerStub struct {
+	dir      string
+	prepared map[string]*xdp.Prepared
+	lock     sync.Mutex
+}
+
+func (p *preparerStub) Prepare(ifName string) (*xdp.Prepared, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if prepared, ok := p.prepared[ifName]; ok {
+		return prepared, nil
+	}
+
+	xskMapPath := filepath.Join(p.dir, xskMapData)
+	if err := os.WriteFile(xskMapPath, []byte(xskMapData), 0o600); err != nil {
+		return nil, err
+	}
+	xskMap, err := os.Open(filepath.Clean(xskMapPath))
+	if err != nil {
+		return nil, err
+	}
+
+	p.prepared[ifName] = &xdp.Prepared{
+		InterfaceName: ifName,
+		QueueCount:    4,
+		XSKMap:        xskMap,
+	}
+	return p.prepared[ifName], nil
+}
+
+func (p *preparerStub) Release(ifName string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if prepared, ok := p.prepared[ifName]; ok {
+		_ = prepared.XSKMap.Close()
+		delete(p.prepared, ifName)
+	}
+	return nil
+}
+
+// preparedInterfaces returns the prepared net interfaces, the stub is called by the server in the gRPC goroutines
+func (p *preparerStub) preparedInterfaces() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var ifNames []string
+	for ifName := range p.prepared {
+		ifNames = append(ifNames, ifName)
+	}
+	return ifNames
+}
+
+type vfConfigStub struct{}
+
+func (vfConfigStub) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	vfconfig.Store(ctx, false, &vfconfig.VFConfig{VFInterfaceName: ifName})
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (vfConfigStub) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func testServer(ctx context.Context, t *testing.T, preparer afxdp.Preparer) *grpc.ClientConn {
+	socketURL := &url.URL{
+		Scheme: "unix",
+		Path:   filepath.Join(t.TempDir(), "server.socket"),
+	}
+
+	server := grpc.NewServer(grpc.Creds(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	networkservice.RegisterNetworkServiceServer(server, chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vfConfigStub{},
+		afxdp.NewServer(preparer),
+	))
+	_ = grpcutils.ListenAndServe(ctx, socketURL, server)
+
+	<-time.After(1 * time.Millisecond) // wait for the server to start
+
+	cc, err := grpc.DialContext(ctx, socketURL.String(),
+		grpc.WithTransportCredentials(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+
+	return cc
+}
+
+func testRequest(labels map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "conn-1",
+			Mechanism: &networkservice.Mechanism{
+				Type:       kernel.MECHANISM,
+				Parameters: map[string]string{},
+			},
+			Labels: labels,
+		},
+	}
+}
+
+func TestAFXDP_FDPassing(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	preparer := &preparerStub{dir: t.TempDir(), prepared: map[string]*xdp.Prepared{}}
+	client := chain.NewNetworkServiceClient(
+		afxdp.NewClient(),
+		networkservice.NewNetworkServiceClient(testServer(ctx, t, preparer)),
+	)
+
+	conn, err := client.Request(ctx, testRequest(map[string]string{afxdp.Label: "true"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{ifName}, preparer.preparedInterfaces())
+	require.Equal(t, "4", conn.GetMechanism().GetParameters()[afxdp.QueueCountKey])
+
+	fileURL, err := url.Parse(conn.GetMechanism().GetParameters()[afxdp.XSKMapFDURLKey])
+	require.NoError(t, err)
+	require.Equal(t, "file", fileURL.Scheme)
+
+	content, err := os.ReadFile(fileURL.Path)
+	require.NoError(t, err)
+	require.Equal(t, xskMapData, string(content))
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Empty(t, preparer.preparedInterfaces())
+}
+
+func TestAFXDP_NotRequested(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	preparer := &preparerStub{dir: t.TempDir(), prepared: map[string]*xdp.Prepared{}}
+	client := chain.NewNetworkServiceClient(
+		afxdp.NewClient(),
+		networkservice.NewNetworkServiceClient(testServer(ctx, t, preparer)),
+	)
+
+	conn, err := client.Request(ctx, testRequest(nil))
+	require.NoError(t, err)
+	require.Empty(t, preparer.preparedInterfaces())
+	require.Empty(t, conn.GetMechanism().GetParameters()[afxdp.XSKMapFDURLKey])
+}
+
+func TestAFXDPServer_Request_NoSender(t *testing.T) {
+	preparer := &preparerStub{dir: t.TempDir(), prepared: map[string]*xdp.Prepared{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vfConfigStub{},
+		afxdp.NewServer(preparer),
+	)
+
+	_, err := server.Request(context.Background(), testRequest(map[string]string{afxdp.Label: "true"}))
+	require.ErrorContains(t, err, "no grpcfd sender")
+	require.Empty(t, preparer.preparedInterfaces())
+}
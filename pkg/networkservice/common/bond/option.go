@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bond
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+)
+
+// Option is an option for NewServer
+type Option func(s *bondServer)
+
+// WithShardedLock sets the lock serializing the VF driver binding per PF, so the resource lock is held only for the VF
+// selection and the bond VFs of the different PFs are moved to the clients concurrently
+func WithShardedLock(shardedLock *resourcepool.ShardedLock) Option {
+	return func(s *bondServer) {
+		s.shardedLock = shardedLock
+	}
+}
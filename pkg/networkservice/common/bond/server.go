@@ -0,0 +1,300 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package bond provides chain element assigning the second VF from a different PF for the bonded pair connections
+package bond
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+const (
+	// Label is a request label enabling the bonded pair, its value is the bond mode (e.g. "active-backup")
+	Label = "bond"
+	// DeviceTokenIDKey is a kernel mechanism parameter key for the second VF device token ID
+	DeviceTokenIDKey = "bondDeviceTokenID"
+	// PCIAddressKey is a kernel mechanism parameter key for the second VF PCI address
+	PCIAddressKey = "bondPCIAddress"
+	// ModeKey is a connection extra context key for the bond mode
+	ModeKey = "bondMode"
+	// SlavesKey is a connection extra context key for the comma separated bond slaves interface names in the client
+	// netns
+	SlavesKey = "bondSlaves"
+)
+
+// ResourcePool is a resource.Pool interface
+type ResourcePool interface {
+	resourcepool.ExcludingResourcePool
+	Free(vfPCIAddr string) error
+}
+
+type secondaryVFKey struct{}
+
+type secondaryVF struct {
+	pciAddr  string
+	ifName   string
+	netNSURL string
+}
+
+type bondServer struct {
+	resourceLock sync.Locker
+	shardedLock  *resourcepool.ShardedLock
+	pciPool      resourcepool.PCIPool
+	resourcePool ResourcePool
+	pfPCIAddrs   map[string]string // pfPCIAddrs[vfPCIAddr] -> pfPCIAddr
+	pfNetworks   map[string]string // pfNetworks[pfPCIAddr] -> physical network
+}
+
+// NewServer returns a new bond server chain element. For the kernel mechanism requests with the bond Label it selects
+// the second VF with the DeviceTokenIDKey token on a PF different from the one of the VF selected by the previous chain
+// elements and on the same physical network, moves it into the client netns and writes the bond context (ModeKey, SlavesKey) for the bond to be
+// configured in the client netns.
+func NewServer(
+	resourceLock sync.Locker,
+	pciPool resourcepool.PCIPool,
+	resourcePool ResourcePool,
+	cfg *config.Config,
+	options ...Option,
+) networkservice.NetworkServiceServer {
+	s := &bondServer{
+		resourceLock: resourceLock,
+		pciPool:      pciPool,
+		resourcePool: resourcePool,
+		pfPCIAddrs:   map[string]string{},
+		pfNetworks:   map[string]string{},
+	}
+	for pfPCIAddr, pfCfg := range cfg.PhysicalFunctions {
+		s.pfNetworks[pfPCIAddr] = pfCfg.PhysicalNetwork
+		for _, vfCfg := range pfCfg.VirtualFunctions {
+			s.pfPCIAddrs[vfCfg.Address] = pfPCIAddr
+		}
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *bondServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mech := kernel.ToMechanism(request.GetConnection().GetMechanism())
+	bondMode, isBond := request.GetConnection().GetLabels()[Label]
+	if mech == nil || !isBond {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if rawValue, ok := metadata.Map(ctx, false).Load(secondaryVFKey{}); ok {
+		setBondContext(request.GetConnection(), bondMode, mech, rawValue.(*secondaryVF))
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	vf, err := s.assignSecondaryVF(ctx, mech)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Map(ctx, false).Store(secondaryVFKey{}, vf)
+	setBondContext(request.GetConnection(), bondMode, mech, vf)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		metadata.Map(ctx, false).Delete(secondaryVFKey{})
+		s.releaseSecondaryVF(ctx, vf)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *bondServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(secondaryVFKey{}); ok {
+		s.releaseSecondaryVF(ctx, rawValue.(*secondaryVF))
+	}
+
+	return rv, err
+}
+
+// excludedPFs returns the primary PF and the PFs on the physical networks other than the primary PF one, so the bond
+// doesn't cross the physical isolation groups
+func (s *bondServer) excludedPFs(primaryPFPCIAddr string) []string {
+	excludedPFs := []string{primaryPFPCIAddr}
+	physicalNetwork := s.pfNetworks[primaryPFPCIAddr]
+	if physicalNetwork == "" {
+		return excludedPFs
+	}
+	for pfPCIAddr, pfNetwork := range s.pfNetworks {
+		if pfPCIAddr != primaryPFPCIAddr && pfNetwork != physicalNetwork {
+			excludedPFs = append(excludedPFs, pfPCIAddr)
+		}
+	}
+	return excludedPFs
+}
+
+func (s *bondServer) assignSecondaryVF(ctx context.Context, mech *kernel.Mechanism) (*secondaryVF, error) {
+	tokenID := mech.GetParameters()[DeviceTokenIDKey]
+	if tokenID == "" {
+		return nil, errors.New("expected bond device token ID set")
+	}
+	pfPCIAddr, ok := s.pfPCIAddrs[mech.GetParameters()[common.PCIAddressKey]]
+	if !ok {
+		return nil, errors.New("no primary VF selected")
+	}
+	if mech.GetNetNSURL() == "" {
+		return nil, errors.New("expected client netns URL set")
+	}
+
+	s.resourceLock.Lock()
+	vfPCIAddr, err := s.resourcePool.SelectExcludingPFs(tokenID, sriov.KernelDriver, s.excludedPFs(pfPCIAddr))
+	if err != nil {
+		s.resourceLock.Unlock()
+		return nil, errors.Wrapf(err, "failed to select bond VF on a PF other than: %s", pfPCIAddr)
+	}
+	log.FromContext(ctx).WithField("bondServer", "assignSecondaryVF").Infof("selected bond VF: %s", vfPCIAddr)
+
+	unlock := s.resourceLock.Unlock
+	if s.shardedLock != nil {
+		// VF is already selected, so only the operations on the same PF should be serialized
+		unlock()
+		vfPFPCIAddr := s.pfPCIAddrs[vfPCIAddr]
+		s.shardedLock.Lock(vfPFPCIAddr)
+		unlock = func() { s.shardedLock.Unlock(vfPFPCIAddr) }
+	}
+
+	vf := &secondaryVF{
+		pciAddr:  vfPCIAddr,
+		netNSURL: mech.GetNetNSURL(),
+	}
+	err = s.moveToClient(ctx, vf)
+	unlock()
+	if err != nil {
+		s.resourceLock.Lock()
+		_ = s.resourcePool.Free(vfPCIAddr)
+		s.resourceLock.Unlock()
+		return nil, err
+	}
+
+	return vf, nil
+}
+
+func (s *bondServer) moveToClient(ctx context.Context, vf *secondaryVF) error {
+	pciFunction, err := s.pciPool.GetPCIFunction(vf.pciAddr)
+	if err != nil {
+		return err
+	}
+	iommuGroup, err := pciFunction.GetIOMMUGroup()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VF IOMMU group: %s", vf.pciAddr)
+	}
+	if err = s.pciPool.BindDriver(ctx, iommuGroup, sriov.KernelDriver); err != nil {
+		return err
+	}
+	if vf.ifName, err = pciFunction.GetNetInterfaceName(); err != nil {
+		return errors.Wrapf(err, "failed to get VF net interface name: %s", vf.pciAddr)
+	}
+
+	link, err := netlink.LinkByName(vf.ifName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find VF net interface: %s", vf.ifName)
+	}
+
+	clientNetNS, err := nshandle.FromURL(vf.netNSURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get client netns: %s", vf.netNSURL)
+	}
+	defer func() { _ = clientNetNS.Close() }()
+
+	if err := netlink.LinkSetNsFd(link, int(clientNetNS)); err != nil {
+		return errors.Wrapf(err, "failed to move VF net interface into the client netns: %s", vf.ifName)
+	}
+	return nil
+}
+
+// releaseSecondaryVF moves the VF net interface back into the forwarder netns and frees the VF. If client netns is
+// already gone, kernel moves the VF net interface back into the init netns itself.
+func (s *bondServer) releaseSecondaryVF(ctx context.Context, vf *secondaryVF) {
+	logger := log.FromContext(ctx).WithField("bondServer", "releaseSecondaryVF")
+
+	if err := moveBack(vf); err != nil {
+		logger.Warnf("failed to move bond VF net interface back: %s", err.Error())
+	}
+
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	if err := s.resourcePool.Free(vf.pciAddr); err != nil {
+		logger.Warnf("failed to free bond VF %s: %s", vf.pciAddr, err.Error())
+	}
+}
+
+func moveBack(vf *secondaryVF) error {
+	clientNetNS, err := nshandle.FromURL(vf.netNSURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get client netns: %s", vf.netNSURL)
+	}
+	defer func() { _ = clientNetNS.Close() }()
+
+	handle, err := netlink.NewHandleAt(clientNetNS)
+	if err != nil {
+		return errors.Wrap(err, "failed to create netlink handle in the client netns")
+	}
+	defer handle.Close()
+
+	link, err := handle.LinkByName(vf.ifName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find VF net interface in the client netns: %s", vf.ifName)
+	}
+
+	currentNetNS, err := nshandle.Current()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = currentNetNS.Close() }()
+
+	return handle.LinkSetNsFd(link, int(currentNetNS))
+}
+
+func setBondContext(conn *networkservice.Connection, bondMode string, mech *kernel.Mechanism, vf *secondaryVF) {
+	mech.GetParameters()[PCIAddressKey] = vf.pciAddr
+
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = map[string]string{}
+	}
+	conn.GetContext().GetExtraContext()[ModeKey] = bondMode
+	conn.GetContext().GetExtraContext()[SlavesKey] = mech.GetInterfaceName() + "," + vf.ifName
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bond_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/bond"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+)
+
+const (
+	pfPCIAddr     = "0000:01:00.0"
+	vfPCIAddr     = "0000:01:00.1"
+	bondPFPCIAddr = "0000:02:00.0"
+	bondVFPCIAddr = "0000:02:00.1"
+)
+
+func bondRequest(labels, parameters map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id",
+			Labels: labels,
+			Mechanism: &networkservice.Mechanism{
+				Cls:        "LOCAL",
+				Type:       kernel.MECHANISM,
+				Parameters: parameters,
+			},
+		},
+	}
+}
+
+func TestBondServer_Request(t *testing.T) {
+	cfg := &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			pfPCIAddr: {
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: vfPCIAddr},
+				},
+			},
+		},
+	}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		bond.NewServer(new(sync.Mutex), nil, nil, cfg),
+	)
+
+	conn, err := server.Request(context.Background(), bondRequest(nil, map[string]string{
+		common.PCIAddressKey: vfPCIAddr,
+	}))
+	require.NoError(t, err)
+	require.Empty(t, conn.GetContext().GetExtraContext())
+
+	_, err = server.Request(context.Background(), bondRequest(map[string]string{bond.Label: "active-backup"}, map[string]string{
+		common.PCIAddressKey: vfPCIAddr,
+	}))
+	require.Error(t, err)
+
+	_, err = server.Request(context.Background(), bondRequest(map[string]string{bond.Label: "active-backup"}, map[string]string{
+		bond.DeviceTokenIDKey: "token-2",
+	}))
+	require.Error(t, err)
+}
+
+func TestBondServer_Request_ShardedLock(t *testing.T) {
+	// The test thread netns is changed, so the thread is terminated with the test goroutine instead of being reused
+	runtime.LockOSThread()
+
+	unshareNetNS(t)
+	forwarderNetNS, err := nshandle.Current()
+	require.NoError(t, err)
+	defer func() { _ = forwarderNetNS.Close() }()
+
+	unshareNetNS(t)
+	clientNetNS, err := nshandle.Current()
+	require.NoError(t, err)
+	defer func() { _ = clientNetNS.Close() }()
+
+	require.NoError(t, unix.Setns(int(forwarderNetNS), unix.CLONE_NEWNET))
+
+	cfg := &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			pfPCIAddr: {
+				VFKernelDriver: "vf-driver",
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: vfPCIAddr, IOMMUGroup: 1},
+				},
+			},
+			bondPFPCIAddr: {
+				VFKernelDriver: "vf-driver",
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: bondVFPCIAddr, IOMMUGroup: 2},
+				},
+			},
+		},
+	}
+	pfs := sriovtest.NewPhysicalFunctions(cfg)
+	bondIfName := pfs[bondPFPCIAddr].Vfs[0].IfName
+	require.NoError(t, netlink.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: bondIfName},
+		PeerName:  "bond-peer",
+	}))
+
+	testPool, err := pci.NewTestPool(pfs, cfg)
+	require.NoError(t, err)
+
+	resourceLock := new(resourceLockStub)
+	resourcePool := &resourcePoolStub{vfPCIAddr: bondVFPCIAddr}
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		bond.NewServer(resourceLock, &lockCheckingPCIPool{Pool: testPool, t: t, resourceLock: resourceLock}, resourcePool, cfg,
+			bond.WithShardedLock(resourcepool.NewShardedLock())),
+	)
+
+	request := bondRequest(map[string]string{bond.Label: "active-backup"}, map[string]string{
+		common.PCIAddressKey:    vfPCIAddr,
+		bond.DeviceTokenIDKey:   "token-2",
+		kernel.NetNSURL:         fmt.Sprintf("file:///proc/self/fd/%d", int(clientNetNS)),
+		kernel.InterfaceNameKey: "nsm-1",
+	})
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, []string{pfPCIAddr}, resourcePool.excludedPFs)
+	require.Equal(t, bondVFPCIAddr, conn.GetMechanism().GetParameters()[bond.PCIAddressKey])
+	require.Equal(t, map[string]string{
+		bond.ModeKey:   "active-backup",
+		bond.SlavesKey: "nsm-1," + bondIfName,
+	}, conn.GetContext().GetExtraContext())
+	require.Equal(t, "vf-driver", pfs[bondPFPCIAddr].Vfs[0].Driver)
+
+	clientHandle, err := netlink.NewHandleAt(clientNetNS)
+	require.NoError(t, err)
+	defer clientHandle.Close()
+
+	_, err = clientHandle.LinkByName(bondIfName)
+	require.NoError(t, err)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, []string{bondVFPCIAddr}, resourcePool.freed)
+
+	_, err = clientHandle.LinkByName(bondIfName)
+	require.Error(t, err)
+	_, err = netlink.LinkByName(bondIfName)
+	require.NoError(t, err)
+}
+
+// unshareNetNS moves the current thread into a new netns, the test is skipped if it is not permitted
+func unshareNetNS(t *testing.T) {
+	if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+		t.Skipf("failed to create netns: %s", err.Error())
+	}
+}
+
+type resourceLockStub struct {
+	sync.Mutex
+	locked bool
+}
+
+func (l *resourceLockStub) Lock() {
+	l.Mutex.Lock()
+	l.locked = true
+}
+
+func (l *resourceLockStub) Unlock() {
+	l.locked = false
+	l.Mutex.Unlock()
+}
+
+type lockCheckingPCIPool struct {
+	*pci.Pool
+	t            *testing.T
+	resourceLock *resourceLockStub
+}
+
+func (p *lockCheckingPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	require.False(p.t, p.resourceLock.locked, "driver is bound under the resource lock")
+	return p.Pool.BindDriver(ctx, iommuGroup, driverType)
+}
+
+type resourcePoolStub struct {
+	vfPCIAddr   string
+	excludedPFs []string
+	freed       []string
+}
+
+func (rp *resourcePoolStub) SelectExcludingPFs(_ string, _ sriov.DriverType, excludedPFs []string) (string, error) {
+	rp.excludedPFs = excludedPFs
+	return rp.vfPCIAddr, nil
+}
+
+func (rp *resourcePoolStub) Free(vfPCIAddr string) error {
+	rp.freed = append(rp.freed, vfPCIAddr)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cleanup provides a chain element unwinding the per connection undo actions registered by the following chain
+// elements on Close or on the Request failure
+package cleanup
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+)
+
+type registryKey struct{}
+
+// registry is a set of the undo actions keyed by the chain elements, actions are unwound in the reverse registration
+// order
+type registry struct {
+	lock    sync.Mutex
+	keys    []interface{}
+	actions map[interface{}]func(ctx context.Context)
+}
+
+func newRegistry() *registry {
+	return &registry{
+		actions: map[interface{}]func(ctx context.Context){},
+	}
+}
+
+func (r *registry) register(key interface{}, undo func(ctx context.Context)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.actions[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.actions[key] = undo
+}
+
+func (r *registry) unregister(key interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.actions[key]; !ok {
+		return
+	}
+	delete(r.actions, key)
+	for i := range r.keys {
+		if r.keys[i] == key {
+			r.keys = append(r.keys[:i], r.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (r *registry) len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.keys)
+}
+
+// unwind runs and removes the actions registered after the first from ones in the reverse order
+func (r *registry) unwind(ctx context.Context, from int) {
+	r.lock.Lock()
+	var actions []func(ctx context.Context)
+	for i := len(r.keys) - 1; i >= from; i-- {
+		actions = append(actions, r.actions[r.keys[i]])
+		delete(r.actions, r.keys[i])
+	}
+	r.keys = r.keys[:from]
+	r.lock.Unlock()
+
+	for _, undo := range actions {
+		undo(ctx)
+	}
+}
+
+// Register registers the undo action for the chain element key in the connection registry, it replaces the action
+// already registered for the key on refresh. The key should be unique for the chain element, e.g. the chain element
+// itself. Returns false if there is no cleanup chain element before, so the chain element should clean up itself on
+// the Request failure.
+func Register(ctx context.Context, key interface{}, undo func(ctx context.Context)) bool {
+	r, ok := ctx.Value(registryKey{}).(*registry)
+	if !ok {
+		return false
+	}
+	r.register(key, undo)
+	return true
+}
+
+// Unregister removes the undo action for the chain element key from the connection registry, it should be called by
+// the chain element cleaning up itself on Close
+func Unregister(ctx context.Context, key interface{}) {
+	if r, ok := ctx.Value(registryKey{}).(*registry); ok {
+		r.unregister(key)
+	}
+}
+
+func withRegistry(ctx context.Context, r *registry) context.Context {
+	return context.WithValue(ctx, registryKey{}, r)
+}
+
+func loadRegistry(ctx context.Context) (*registry, bool) {
+	rawValue, ok := metadata.Map(ctx, false).Load(registryKey{})
+	if !ok {
+		return nil, false
+	}
+	r, ok := rawValue.(*registry)
+	return r, ok
+}
+
+func storeRegistry(ctx context.Context, r *registry) {
+	metadata.Map(ctx, false).Store(registryKey{}, r)
+}
+
+func deleteRegistry(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(registryKey{})
+}
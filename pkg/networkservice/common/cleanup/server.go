@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type cleanupServer struct{}
+
+// NewServer returns a new cleanup server chain element, it should be placed after the metadata chain element and before
+// the chain elements registering the undo actions:
+//   - on Close the undo actions left by the following chain elements are unwound after they are closed
+//   - on the Request failure the undo actions registered by this Request are unwound, so the established connection
+//     resources are kept on the failed refresh
+func NewServer() networkservice.NetworkServiceServer {
+	return new(cleanupServer)
+}
+
+func (s *cleanupServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	r, isEstablished := loadRegistry(ctx)
+	if !isEstablished {
+		r = newRegistry()
+		storeRegistry(ctx, r)
+	}
+	checkpoint := r.len()
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(withRegistry(ctx, r), request)
+	if err != nil {
+		unwindCtx, cancelUnwind := postponeCtxFunc()
+		defer cancelUnwind()
+
+		r.unwind(unwindCtx, checkpoint)
+		if !isEstablished {
+			deleteRegistry(ctx)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *cleanupServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	r, ok := loadRegistry(ctx)
+	if !ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+
+	rv, err := next.Server(ctx).Close(withRegistry(ctx, r), conn)
+
+	r.unwind(ctx, 0)
+	deleteRegistry(ctx)
+
+	return rv, err
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+)
+
+const failLabel = "fail"
+
+type undoServer struct {
+	name     string
+	undone   *[]string
+	selfUndo bool
+}
+
+func (s *undoServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	cleanup.Register(ctx, s, func(context.Context) {
+		*s.undone = append(*s.undone, s.name)
+	})
+	if _, ok := request.GetConnection().GetLabels()[failLabel+"-"+s.name]; ok {
+		return nil, errors.New("failed")
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *undoServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if s.selfUndo {
+		*s.undone = append(*s.undone, s.name+"-self")
+		cleanup.Unregister(ctx, s)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestCleanupServer_RequestFailure(t *testing.T) {
+	var undone []string
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		cleanup.NewServer(),
+		&undoServer{name: "a", undone: &undone},
+		&undoServer{name: "b", undone: &undone},
+		&undoServer{name: "c", undone: &undone},
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id",
+			Labels: map[string]string{failLabel + "-c": ""},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"c", "b", "a"}, undone)
+}
+
+func TestCleanupServer_RefreshFailure(t *testing.T) {
+	var undone []string
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		cleanup.NewServer(),
+		&undoServer{name: "a", undone: &undone},
+		&undoServer{name: "b", undone: &undone, selfUndo: true},
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, undone)
+
+	// established connection undo actions are kept on the failed refresh
+	refreshConn := conn.Clone()
+	refreshConn.Labels = map[string]string{failLabel + "-b": ""}
+	_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: refreshConn})
+	require.Error(t, err)
+	require.Empty(t, undone)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b-self", "a"}, undone)
+}
+
+func TestCleanupServer_NoRegistry(t *testing.T) {
+	require.False(t, cleanup.Register(context.Background(), "key", func(context.Context) {}))
+	cleanup.Unregister(context.Background(), "key")
+}
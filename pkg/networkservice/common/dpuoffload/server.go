@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dpuoffload provides chain element delegating the DPU-side representor programming for the VFs of the DPU
+// hosted physical functions to the DPU agents
+package dpuoffload
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+type representorKey struct{}
+
+type representor struct {
+	dpuName string
+	cfg     *dpu.RepresentorConfig
+}
+
+type dpuOffloadServer struct {
+	config *config.Config
+	agents map[string]dpu.Programmer
+}
+
+// NewServer returns a new DPU offload server chain element. For the VF selected by the previous chain elements on the
+// DPU hosted physical function it requests the DPU agent from agents (agents[DPU name]) to configure the VF
+// representor, the representor is released on Close. VFs of the host physical functions are passed through.
+func NewServer(cfg *config.Config, agents map[string]dpu.Programmer) networkservice.NetworkServiceServer {
+	return &dpuOffloadServer{
+		config: cfg,
+		agents: agents,
+	}
+}
+
+func (s *dpuOffloadServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	vfPCIAddr := request.GetConnection().GetMechanism().GetParameters()[common.PCIAddressKey]
+	if vfPCIAddr == "" {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	dpuName, representorName, err := s.config.Representor(vfPCIAddr)
+	if err != nil {
+		// VF is not DPU hosted
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	agent, ok := s.agents[dpuName]
+	if !ok {
+		return nil, errors.Errorf("no agent configured for the DPU: %s", dpuName)
+	}
+
+	_, isEstablished := metadata.Map(ctx, false).Load(representorKey{})
+
+	r := &representor{
+		dpuName: dpuName,
+		cfg: &dpu.RepresentorConfig{
+			ConnectionID: request.GetConnection().GetId(),
+			VFPCIAddress: vfPCIAddr,
+			Representor:  representorName,
+			MTU:          request.GetConnection().GetContext().GetMTU(),
+		},
+	}
+	if err := agent.Configure(ctx, r.cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to configure representor on the DPU: %s", dpuName)
+	}
+	metadata.Map(ctx, false).Store(representorKey{}, r)
+
+	var registered bool
+	if !isEstablished {
+		registered = cleanup.Register(ctx, s, func(ctx context.Context) {
+			s.release(ctx)
+		})
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !isEstablished && !registered {
+			s.release(ctx)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *dpuOffloadServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	s.release(ctx)
+	cleanup.Unregister(ctx, s)
+
+	return rv, err
+}
+
+func (s *dpuOffloadServer) release(ctx context.Context) {
+	rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(representorKey{})
+	if !ok {
+		return
+	}
+	r := rawValue.(*representor)
+
+	if err := s.agents[r.dpuName].Release(ctx, r.cfg); err != nil {
+		log.FromContext(ctx).WithField("dpuOffloadServer", "release").
+			Errorf("failed to release representor %s on the DPU %s: %s", r.cfg.Representor, r.dpuName, err.Error())
+	}
+}
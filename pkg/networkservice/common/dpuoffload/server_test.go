@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpuoffload_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/dpu"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/dpuoffload"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+const (
+	dpuName     = "dpu-1"
+	hostedVF    = "0000:01:00.1"
+	hostVF      = "0000:02:00.1"
+	representor = "pf0vf0"
+)
+
+type agentStub struct {
+	configured map[string]*dpu.RepresentorConfig
+}
+
+func (a *agentStub) Configure(_ context.Context, cfg *dpu.RepresentorConfig) error {
+	a.configured[cfg.ConnectionID] = cfg
+	return nil
+}
+
+func (a *agentStub) Release(_ context.Context, cfg *dpu.RepresentorConfig) error {
+	delete(a.configured, cfg.ConnectionID)
+	return nil
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			"0000:01:00.0": {
+				DPU: &config.DPU{Name: dpuName, PFRepresentor: "pf0hpf"},
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: hostedVF, Representor: representor},
+				},
+			},
+			"0000:02:00.0": {
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: hostVF},
+				},
+			},
+		},
+	}
+}
+
+func testRequest(vfPCIAddr string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "conn-1",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.PCIAddressKey: vfPCIAddr,
+				},
+			},
+			Context: &networkservice.ConnectionContext{
+				MTU: 9000,
+			},
+		},
+	}
+}
+
+func TestDPUOffloadServer_DPUHosted(t *testing.T) {
+	agent := &agentStub{configured: map[string]*dpu.RepresentorConfig{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		dpuoffload.NewServer(testConfig(), map[string]dpu.Programmer{dpuName: agent}),
+	)
+
+	conn, err := server.Request(context.Background(), testRequest(hostedVF))
+	require.NoError(t, err)
+	require.Equal(t, map[string]*dpu.RepresentorConfig{
+		"conn-1": {
+			ConnectionID: "conn-1",
+			VFPCIAddress: hostedVF,
+			Representor:  representor,
+			MTU:          9000,
+		},
+	}, agent.configured)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Empty(t, agent.configured)
+}
+
+func TestDPUOffloadServer_HostVF(t *testing.T) {
+	agent := &agentStub{configured: map[string]*dpu.RepresentorConfig{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		dpuoffload.NewServer(testConfig(), map[string]dpu.Programmer{dpuName: agent}),
+	)
+
+	_, err := server.Request(context.Background(), testRequest(hostVF))
+	require.NoError(t, err)
+	require.Empty(t, agent.configured)
+}
+
+func TestDPUOffloadServer_RequestFailed(t *testing.T) {
+	agent := &agentStub{configured: map[string]*dpu.RepresentorConfig{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		dpuoffload.NewServer(testConfig(), map[string]dpu.Programmer{dpuName: agent}),
+		injecterror.NewServer(injecterror.WithError(errors.New("error"))),
+	)
+
+	_, err := server.Request(context.Background(), testRequest(hostedVF))
+	require.Error(t, err)
+	require.Empty(t, agent.configured)
+}
+
+func TestDPUOffloadServer_NoAgent(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		dpuoffload.NewServer(testConfig(), map[string]dpu.Programmer{}),
+	)
+
+	_, err := server.Request(context.Background(), testRequest(hostedVF))
+	require.ErrorContains(t, err, "no agent configured for the DPU: "+dpuName)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain
+
+import (
+	"context"
+	"time"
+)
+
+// Option is an option for NewServer
+type Option func(s *drainServer)
+
+// WithTimeout sets timeout for closing the connections and running after drain functions, default is 15s
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *drainServer) {
+		s.timeout = timeout
+	}
+}
+
+// WithAfterDrain adds function called after all the connections have been closed (or the timeout has expired)
+func WithAfterDrain(afterDrain func(ctx context.Context)) Option {
+	return func(s *drainServer) {
+		s.afterDrain = append(s.afterDrain, afterDrain)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drain provides chain element closing all the connections on the shutdown
+package drain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const defaultTimeout = 15 * time.Second
+
+type drainServer struct {
+	timeout      time.Duration
+	afterDrain   []func(ctx context.Context)
+	isDraining   atomic.Bool
+	lock         sync.RWMutex
+	eventFactory genericsync.Map[string, begin.EventFactory]
+}
+
+// NewServer returns a new drain server chain element. On ctx cancellation it stops accepting new Requests, closes all
+// existing connections with the full chain and runs after drain functions. It should be placed after begin.
+func NewServer(ctx context.Context, options ...Option) networkservice.NetworkServiceServer {
+	s := &drainServer{
+		timeout: defaultTimeout,
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.drain(log.FromContext(ctx).WithField("drainServer", "drain"))
+	}()
+
+	return s
+}
+
+func (s *drainServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.isDraining.Load() {
+		return nil, errors.New("server is shutting down, no new requests are accepted")
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.eventFactory.Store(conn.GetId(), begin.FromContext(ctx))
+
+	return conn, nil
+}
+
+func (s *drainServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.eventFactory.Delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *drainServer) drain(logger log.Logger) {
+	// Wait for the Requests in progress
+	s.lock.Lock()
+	s.isDraining.Store(true)
+	s.lock.Unlock()
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	closeChs := map[string]<-chan error{}
+	s.eventFactory.Range(func(connID string, eventFactory begin.EventFactory) bool {
+		closeChs[connID] = eventFactory.Close(begin.CancelContext(timeoutCtx))
+		return true
+	})
+
+	logger.Infof("closing %d connections", len(closeChs))
+	for connID, closeCh := range closeChs {
+		select {
+		case err := <-closeCh:
+			if err != nil {
+				logger.Warnf("failed to close connection %s: %s", connID, err.Error())
+			}
+		case <-timeoutCtx.Done():
+			logger.Warnf("timeout waiting for the connection %s close", connID)
+		}
+	}
+
+	for _, afterDrain := range s.afterDrain {
+		afterDrain(timeoutCtx)
+	}
+	logger.Info("drained")
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package extravf provides chain element moving the extra VFs of the multi-token kernel connections into the client
+// netns
+package extravf
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+)
+
+// InterfacesKey is a connection extra context key for the comma separated extra VFs net interface names in the client
+// netns, in the resourcepool.PCIAddressesKey order
+const InterfacesKey = "sriovExtraInterfaces"
+
+type extraVFsKey struct{}
+
+type extraVF struct {
+	pciAddr string
+	ifName  string
+}
+
+type extraVFServer struct {
+	pciPool resourcepool.PCIPool
+}
+
+// NewServer returns a new extra VF server chain element. For the kernel mechanism connections with the VFs selected for
+// several tokens (see resourcepool.TokenIDsKey) it moves the net interfaces of all the VFs except the first one into
+// the client netns and reports them in the InterfacesKey extra context. The first VF is configured by the following
+// chain elements. The VF net interfaces are moved back on Close.
+func NewServer(pciPool resourcepool.PCIPool) networkservice.NetworkServiceServer {
+	return &extraVFServer{
+		pciPool: pciPool,
+	}
+}
+
+func (s *extraVFServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mech := kernel.ToMechanism(request.GetConnection().GetMechanism())
+	if mech == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if rawValue, ok := metadata.Map(ctx, false).Load(extraVFsKey{}); ok {
+		setInterfaces(request.GetConnection(), rawValue.([]*extraVF))
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	pciAddrs := resourcepool.PCIAddresses(mech.Mechanism)
+	if len(pciAddrs) < 2 {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	vfs, err := s.moveToClient(ctx, mech.GetNetNSURL(), pciAddrs[1:])
+	if err != nil {
+		return nil, err
+	}
+	metadata.Map(ctx, false).Store(extraVFsKey{}, vfs)
+	setInterfaces(request.GetConnection(), vfs)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		metadata.Map(ctx, false).Delete(extraVFsKey{})
+		moveBack(ctx, mech.GetNetNSURL(), vfs)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *extraVFServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(extraVFsKey{}); ok {
+		moveBack(ctx, kernel.ToMechanism(conn.GetMechanism()).GetNetNSURL(), rawValue.([]*extraVF))
+	}
+
+	return rv, err
+}
+
+func (s *extraVFServer) moveToClient(ctx context.Context, netNSURL string, pciAddrs []string) ([]*extraVF, error) {
+	if netNSURL == "" {
+		return nil, errors.New("expected client netns URL set")
+	}
+
+	clientNetNS, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get client netns: %s", netNSURL)
+	}
+	defer func() { _ = clientNetNS.Close() }()
+
+	var vfs []*extraVF
+	for _, pciAddr := range pciAddrs {
+		vf := &extraVF{pciAddr: pciAddr}
+		if err := s.getIfName(vf); err != nil {
+			moveBack(ctx, netNSURL, vfs)
+			return nil, err
+		}
+
+		link, err := netlink.LinkByName(vf.ifName)
+		if err != nil {
+			moveBack(ctx, netNSURL, vfs)
+			return nil, errors.Wrapf(err, "failed to find VF net interface: %s", vf.ifName)
+		}
+		if err := netlink.LinkSetNsFd(link, int(clientNetNS)); err != nil {
+			moveBack(ctx, netNSURL, vfs)
+			return nil, errors.Wrapf(err, "failed to move VF net interface into the client netns: %s", vf.ifName)
+		}
+		vfs = append(vfs, vf)
+	}
+	return vfs, nil
+}
+
+func (s *extraVFServer) getIfName(vf *extraVF) error {
+	pciFunction, err := s.pciPool.GetPCIFunction(vf.pciAddr)
+	if err != nil {
+		return err
+	}
+	if vf.ifName, err = pciFunction.GetNetInterfaceName(); err != nil {
+		return errors.Wrapf(err, "failed to get VF net interface name: %s", vf.pciAddr)
+	}
+	return nil
+}
+
+// moveBack moves the VF net interfaces back into the forwarder netns. If client netns is already gone, kernel moves the
+// VF net interfaces back into the init netns itself.
+func moveBack(ctx context.Context, netNSURL string, vfs []*extraVF) {
+	if len(vfs) == 0 {
+		return
+	}
+	logger := log.FromContext(ctx).WithField("extraVFServer", "moveBack")
+
+	clientNetNS, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		logger.Warnf("failed to get client netns %s: %s", netNSURL, err.Error())
+		return
+	}
+	defer func() { _ = clientNetNS.Close() }()
+
+	handle, err := netlink.NewHandleAt(clientNetNS)
+	if err != nil {
+		logger.Warnf("failed to create netlink handle in the client netns: %s", err.Error())
+		return
+	}
+	defer handle.Close()
+
+	currentNetNS, err := nshandle.Current()
+	if err != nil {
+		logger.Warnf("failed to get current netns: %s", err.Error())
+		return
+	}
+	defer func() { _ = currentNetNS.Close() }()
+
+	for _, vf := range vfs {
+		link, err := handle.LinkByName(vf.ifName)
+		if err == nil {
+			err = handle.LinkSetNsFd(link, int(currentNetNS))
+		}
+		if err != nil {
+			logger.Warnf("failed to move VF %s net interface back: %s", vf.pciAddr, err.Error())
+		}
+	}
+}
+
+func setInterfaces(conn *networkservice.Connection, vfs []*extraVF) {
+	var ifNames []string
+	for _, vf := range vfs {
+		ifNames = append(ifNames, vf.ifName)
+	}
+
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = map[string]string{}
+	}
+	conn.GetContext().GetExtraContext()[InterfacesKey] = strings.Join(ifNames, ",")
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package extravf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/extravf"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+)
+
+func kernelRequest(parameters map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Cls:        "LOCAL",
+				Type:       kernel.MECHANISM,
+				Parameters: parameters,
+			},
+		},
+	}
+}
+
+func TestExtraVFServer_Request(t *testing.T) {
+	pciPool, err := pci.NewTestPool(nil, &config.Config{})
+	require.NoError(t, err)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		extravf.NewServer(pciPool),
+	)
+
+	// single VF connections are passed through
+	conn, err := server.Request(context.Background(), kernelRequest(map[string]string{
+		common.PCIAddressKey: "0000:01:00.1",
+	}))
+	require.NoError(t, err)
+	require.Empty(t, conn.GetContext().GetExtraContext()[extravf.InterfacesKey])
+
+	// extra VFs can't be moved without the client netns
+	_, err = server.Request(context.Background(), kernelRequest(map[string]string{
+		common.PCIAddressKey:         "0000:01:00.1",
+		resourcepool.PCIAddressesKey: "0000:01:00.1,0000:02:00.1",
+	}))
+	require.ErrorContains(t, err, "expected client netns URL set")
+}
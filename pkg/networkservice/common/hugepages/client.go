@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugepages
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+type hugepagesClient struct {
+	value string
+}
+
+// NewClient returns a new hugepages client chain element setting the hugepages needed by the client into the VFIO
+// mechanism preferences parameters
+func NewClient(hugepages ...*Hugepages) networkservice.NetworkServiceClient {
+	return &hugepagesClient{
+		value: Format(hugepages),
+	}
+}
+
+func (c *hugepagesClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if c.value != "" {
+		for _, preference := range request.GetMechanismPreferences() {
+			if mech := vfio.ToMechanism(preference); mech != nil {
+				mech.GetParameters()[HugepagesKey] = c.value
+			}
+		}
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *hugepagesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hugepages provides chain elements coordinating the hugepages needed by the VFIO (DPDK) clients: the client
+// records the requested hugepages in the VFIO mechanism parameters, the server validates the node hugepages
+// availability for them
+package hugepages
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// HugepagesKey is a VFIO mechanism parameter key for the requested hugepages: comma separated "${size}:${count}"
+	// items, e.g. "2048kB:512,1048576kB:2"
+	HugepagesKey = "hugepages"
+
+	sizeSuffix = "kB"
+)
+
+// Hugepages is a number of the hugepages of the size
+type Hugepages struct {
+	SizeKB uint64
+	Count  uint64
+}
+
+// Format formats the hugepages into the HugepagesKey parameter value
+func Format(hugepages []*Hugepages) string {
+	var items []string
+	for _, h := range hugepages {
+		items = append(items, strconv.FormatUint(h.SizeKB, 10)+sizeSuffix+":"+strconv.FormatUint(h.Count, 10))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// Parse parses the HugepagesKey parameter value
+func Parse(value string) ([]*Hugepages, error) {
+	var hugepages []*Hugepages
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		split := strings.Split(item, ":")
+		if len(split) != 2 || !strings.HasSuffix(split[0], sizeSuffix) {
+			return nil, errors.Errorf("invalid hugepages: %s", item)
+		}
+		sizeKB, err := strconv.ParseUint(strings.TrimSuffix(split[0], sizeSuffix), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid hugepages size: %s", item)
+		}
+		count, err := strconv.ParseUint(split[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid hugepages count: %s", item)
+		}
+		hugepages = append(hugepages, &Hugepages{SizeKB: sizeKB, Count: count})
+	}
+	return hugepages, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugepages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+const (
+	defaultHugepagesPath = "/sys/kernel/mm/hugepages"
+
+	freeHugepagesFile = "free_hugepages"
+)
+
+type hugepagesServer struct {
+	hugepagesPath string
+}
+
+// NewServer returns a new hugepages server chain element. For the VFIO mechanism connections with HugepagesKey
+// parameter set it validates the node has enough free hugepages of the requested sizes and fails the Request if it
+// doesn't, so the client learns it can't map memory for the device before the device is allocated.
+// NOTE: free hugepages are not reserved, so the concurrent clients may still race for them.
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &hugepagesServer{
+		hugepagesPath: defaultHugepagesPath,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *hugepagesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if mech := vfio.ToMechanism(request.GetConnection().GetMechanism()); mech != nil {
+		if value, ok := mech.GetParameters()[HugepagesKey]; ok {
+			if err := s.validate(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *hugepagesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *hugepagesServer) validate(value string) error {
+	hugepages, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	for _, h := range hugepages {
+		free, err := s.freeHugepages(h.SizeKB)
+		if err != nil {
+			return err
+		}
+		if free < h.Count {
+			return errors.Errorf("not enough free %dkB hugepages: requested %d, free %d", h.SizeKB, h.Count, free)
+		}
+	}
+	return nil
+}
+
+func (s *hugepagesServer) freeHugepages(sizeKB uint64) (uint64, error) {
+	path := filepath.Join(s.hugepagesPath, "hugepages-"+strconv.FormatUint(sizeKB, 10)+sizeSuffix, freeHugepagesFile)
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, errors.Errorf("%dkB hugepages are not supported by the node", sizeKB)
+		}
+		return 0, errors.Wrapf(err, "failed to read free hugepages: %s", path)
+	}
+	free, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid free hugepages: %s", path)
+	}
+	return free, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugepages_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/hugepages"
+)
+
+func vfioRequest(hugepagesValue string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					hugepages.HugepagesKey: hugepagesValue,
+				},
+			},
+		},
+	}
+}
+
+func TestHugepagesClient(t *testing.T) {
+	client := chain.NewNetworkServiceClient(
+		hugepages.NewClient(
+			&hugepages.Hugepages{SizeKB: 2048, Count: 512},
+			&hugepages.Hugepages{SizeKB: 1048576, Count: 2},
+		),
+		checkrequest.NewClient(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.Equal(t, "1048576kB:2,2048kB:512", request.GetMechanismPreferences()[0].GetParameters()[hugepages.HugepagesKey])
+			require.Empty(t, request.GetMechanismPreferences()[1].GetParameters()[hugepages.HugepagesKey])
+		}),
+	)
+
+	_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			vfio.New(""),
+			{Cls: cls.LOCAL, Type: "KERNEL", Parameters: map[string]string{}},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestHugepagesServer(t *testing.T) {
+	hugepagesPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(hugepagesPath, "hugepages-2048kB"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(hugepagesPath, "hugepages-2048kB", "free_hugepages"), []byte("512\n"), 0o600))
+
+	server := hugepages.NewServer(hugepages.WithHugepagesPath(hugepagesPath))
+
+	_, err := server.Request(context.Background(), vfioRequest("2048kB:512"))
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), vfioRequest("2048kB:513"))
+	require.ErrorContains(t, err, "not enough free 2048kB hugepages")
+
+	_, err = server.Request(context.Background(), vfioRequest("1048576kB:1"))
+	require.ErrorContains(t, err, "not supported")
+
+	_, err = server.Request(context.Background(), vfioRequest("2048:1"))
+	require.ErrorContains(t, err, "invalid hugepages")
+
+	_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+)
+
+// ListConnectionsMethod is a gRPC method returning the JSON list of the ConnectionInfo as google.protobuf.BytesValue
+const ListConnectionsMethod = "/sriov.introspect.IntrospectService/ListConnections"
+
+type introspectService interface {
+	listConnections() []*ConnectionInfo
+}
+
+// RegisterIntrospectServer registers ListConnectionsMethod handler serving the store connections
+func RegisterIntrospectServer(s grpc.ServiceRegistrar, store *Store) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "sriov.introspect.IntrospectService",
+		HandlerType: (*introspectService)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "ListConnections",
+				Handler: func(srv interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					if err := dec(new(emptypb.Empty)); err != nil {
+						return nil, err
+					}
+					data, err := json.Marshal(srv.(introspectService).listConnections())
+					if err != nil {
+						return nil, errors.Wrap(err, "failed to marshal connections")
+					}
+					return wrapperspb.Bytes(data), nil
+				},
+			},
+		},
+	}, store)
+}
+
+func (s *Store) listConnections() []*ConnectionInfo {
+	return s.List()
+}
+
+// ListenAndServe serves the store connections on the unix socket, closes the server when ctx is done. Returns a chan
+// receiving the serve error.
+func ListenAndServe(ctx context.Context, socketPath string, store *Store) <-chan error {
+	server := grpc.NewServer()
+	RegisterIntrospectServer(server, store)
+
+	return grpcutils.ListenAndServe(ctx, &url.URL{Scheme: "unix", Path: socketPath}, server)
+}
+
+// ListConnections calls ListConnectionsMethod with cc
+func ListConnections(ctx context.Context, cc grpc.ClientConnInterface) ([]*ConnectionInfo, error) {
+	resp := new(wrapperspb.BytesValue)
+	if err := cc.Invoke(ctx, ListConnectionsMethod, new(emptypb.Empty), resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", ListConnectionsMethod)
+	}
+
+	var infos []*ConnectionInfo
+	if err := json.Unmarshal(resp.GetValue(), &infos); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal connections")
+	}
+	return infos, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type introspectServer struct {
+	store *Store
+}
+
+// NewServer returns a new introspect server chain element storing the established connections token ID, VF PCI
+// address, driver and mechanism into the store, begin chain element is required before it to close the connections
+// through the chain, see Store.CloseTokenConnections
+func NewServer(store *Store) networkservice.NetworkServiceServer {
+	return &introspectServer{
+		store: store,
+	}
+}
+
+func (s *introspectServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store.store(ctx, conn)
+
+	return conn, nil
+}
+
+func (s *introspectServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.store.delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+func TestIntrospectServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	store := introspect.NewStore()
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		introspect.NewServer(store),
+	)
+
+	socketPath := filepath.Join(t.TempDir(), "introspect.sock")
+	errCh := introspect.ListenAndServe(ctx, socketPath, store)
+
+	cc, err := grpc.DialContext(ctx, "unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	conn, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: "token-1",
+					common.PCIAddressKey:    "0000:01:00.1",
+					// The VF is recorded as bound to vfio-pci even though the mechanism implies the kernel driver
+					resourcepool.VFPCIAddressKey: "0000:01:00.1",
+					resourcepool.BoundDriverKey:  string(sriov.VFIOPCIDriver),
+				},
+			},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	infos, err := introspect.ListConnections(ctx, cc)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, "id", infos[0].ID)
+	require.Equal(t, "nsc", infos[0].Client)
+	require.Equal(t, kernel.MECHANISM, infos[0].Mechanism)
+	require.Equal(t, "token-1", infos[0].TokenID)
+	require.Equal(t, "0000:01:00.1", infos[0].VFPCIAddress)
+	require.Equal(t, sriov.VFIOPCIDriver, infos[0].Driver)
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+
+	infos, err = introspect.ListConnections(ctx, cc)
+	require.NoError(t, err)
+	require.Empty(t, infos)
+
+	_ = cc.Close()
+	cancel()
+	<-errCh
+}
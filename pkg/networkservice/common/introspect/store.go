@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package introspect provides chain element storing the active connections SR-IOV state and gRPC server exporting it
+// for the debugging purposes
+package introspect
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// ConnectionInfo is an SR-IOV state of the active connection
+type ConnectionInfo struct {
+	ID             string            `json:"id"`
+	NetworkService string            `json:"networkService"`
+	Client         string            `json:"client,omitempty"`
+	Mechanism      string            `json:"mechanism"`
+	TokenID        string            `json:"tokenID,omitempty"`
+	VFPCIAddress   string            `json:"vfPCIAddress,omitempty"`
+	Driver         sriov.DriverType  `json:"driver,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+// Store is a store of the active connections SR-IOV state
+type Store struct {
+	connections genericsync.Map[string, *ConnectionInfo]
+	closers     genericsync.Map[string, *connectionCloser]
+}
+
+// connectionCloser closes the connection through its chain
+type connectionCloser struct {
+	tokenIDs     []string
+	eventFactory begin.EventFactory
+}
+
+// NewStore returns a new Store
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Get returns the connection info for the connection ID
+func (s *Store) Get(connID string) (*ConnectionInfo, bool) {
+	return s.connections.Load(connID)
+}
+
+// List returns all the stored connection infos sorted by the connection ID
+func (s *Store) List() []*ConnectionInfo {
+	infos := []*ConnectionInfo{}
+	s.connections.Range(func(_ string, info *ConnectionInfo) bool {
+		infos = append(infos, info)
+		return true
+	})
+	sort.Slice(infos, func(i, k int) bool {
+		return infos[i].ID < infos[k].ID
+	})
+	return infos
+}
+
+// CloseTokenConnections closes the connections holding the tokenID through their chains, so the chain elements free
+// the connection resources the same way as on the client Close. Returns error if any of the connections fails to close.
+func (s *Store) CloseTokenConnections(tokenID string) error {
+	var connIDs []string
+	s.closers.Range(func(connID string, closer *connectionCloser) bool {
+		for _, id := range closer.tokenIDs {
+			if id == tokenID {
+				connIDs = append(connIDs, connID)
+				break
+			}
+		}
+		return true
+	})
+	sort.Strings(connIDs)
+
+	for _, connID := range connIDs {
+		closer, ok := s.closers.Load(connID)
+		if !ok {
+			continue
+		}
+		if err := <-closer.eventFactory.Close(); err != nil {
+			return errors.Wrapf(err, "failed to close the connection %s holding the token %s", connID, tokenID)
+		}
+	}
+	return nil
+}
+
+func (s *Store) store(ctx context.Context, conn *networkservice.Connection) {
+	labels := map[string]string{}
+	for k, v := range conn.GetLabels() {
+		labels[k] = v
+	}
+
+	info := &ConnectionInfo{
+		ID:             conn.GetId(),
+		NetworkService: conn.GetNetworkService(),
+		Mechanism:      conn.GetMechanism().GetType(),
+		TokenID:        conn.GetMechanism().GetParameters()[common.DeviceTokenIDKey],
+		VFPCIAddress:   conn.GetMechanism().GetParameters()[common.PCIAddressKey],
+		Labels:         labels,
+		UpdatedAt:      time.Now(),
+	}
+	if segments := conn.GetPath().GetPathSegments(); len(segments) > 0 {
+		info.Client = segments[0].GetName()
+	}
+	if assignment, ok := resourcepool.LoadAssignment(conn); ok {
+		if info.VFPCIAddress == "" {
+			info.VFPCIAddress = assignment.VFPCIAddress
+		}
+		info.Driver = assignment.DriverType
+	}
+
+	s.connections.Store(conn.GetId(), info)
+	s.closers.Store(conn.GetId(), &connectionCloser{
+		tokenIDs:     resourcepool.TokenIDs(conn.GetMechanism()),
+		eventFactory: begin.FromContext(ctx),
+	})
+}
+
+func (s *Store) delete(connID string) {
+	s.connections.Delete(connID)
+	s.closers.Delete(connID)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package irqaffinity
+
+// Option is an option for NewServer
+type Option func(s *irqAffinityServer)
+
+// WithPCIDevicesPath sets PCI devices sysfs path, default is /sys/bus/pci/devices
+func WithPCIDevicesPath(pciDevicesPath string) Option {
+	return func(s *irqAffinityServer) {
+		s.pciDevicesPath = pciDevicesPath
+	}
+}
+
+// WithNodesPath sets NUMA nodes sysfs path, default is /sys/devices/system/node
+func WithNodesPath(nodesPath string) Option {
+	return func(s *irqAffinityServer) {
+		s.nodesPath = nodesPath
+	}
+}
+
+// WithProcIRQPath sets IRQs procfs path, default is /proc/irq
+func WithProcIRQPath(procIRQPath string) Option {
+	return func(s *irqAffinityServer) {
+		s.procIRQPath = procIRQPath
+	}
+}
+
+// WithCPUSetBaseDir sets host cpuset cgroup directory mount location, default is /sys/fs/cgroup/cpuset
+func WithCPUSetBaseDir(cpusetBaseDir string) Option {
+	return func(s *irqAffinityServer) {
+		s.cpusetBaseDir = cpusetBaseDir
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package irqaffinity provides chain element pinning the selected VF interrupts to the CPUs local to the client
+package irqaffinity
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/numa"
+)
+
+const (
+	// CgroupDirKey is a client on host cgroup directory mechanism parameter key, same as for the VFIO mechanism
+	CgroupDirKey = vfio.CgroupDirKey
+
+	defaultPCIDevicesPath = "/sys/bus/pci/devices"
+	defaultNodesPath      = numa.DefaultNodesPath
+	defaultProcIRQPath    = "/proc/irq"
+	defaultCPUSetBaseDir  = numa.DefaultCPUSetBaseDir
+
+	msiIRQsDir       = "msi_irqs"
+	numaNodeFile     = "numa_node"
+	cpuListFile      = "cpulist"
+	affinityListFile = "smp_affinity_list"
+)
+
+type originalAffinityKey struct{}
+
+type irqAffinityServer struct {
+	pciDevicesPath string
+	nodesPath      string
+	procIRQPath    string
+	cpusetBaseDir  string
+}
+
+// NewServer returns a new IRQ affinity server chain element. For the kernel mechanism connections it sets the selected
+// VF IRQs affinity to the CPUs on the VF NUMA node, narrowed to the client cpuset if the client cgroup directory is
+// provided with CgroupDirKey mechanism parameter. Affinity is restored on Close. Failures are logged and don't fail the
+// Request.
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &irqAffinityServer{
+		pciDevicesPath: defaultPCIDevicesPath,
+		nodesPath:      defaultNodesPath,
+		procIRQPath:    defaultProcIRQPath,
+		cpusetBaseDir:  defaultCPUSetBaseDir,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *irqAffinityServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("irqAffinityServer", "Request")
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := metadata.Map(ctx, false).Load(originalAffinityKey{}); ok {
+		return conn, nil
+	}
+
+	mech := kernel.ToMechanism(conn.GetMechanism())
+	pciAddr := conn.GetMechanism().GetParameters()[common.PCIAddressKey]
+	if mech == nil || pciAddr == "" {
+		return conn, nil
+	}
+
+	cpus, err := s.localCPUs(pciAddr, conn.GetMechanism().GetParameters()[CgroupDirKey])
+	if err != nil {
+		logger.Warnf("failed to get local CPUs for the VF %s: %s", pciAddr, err.Error())
+		return conn, nil
+	}
+	if len(cpus) == 0 {
+		return conn, nil
+	}
+
+	originalAffinity, err := s.setAffinity(pciAddr, numa.FormatCPUList(cpus))
+	if len(originalAffinity) != 0 {
+		metadata.Map(ctx, false).Store(originalAffinityKey{}, originalAffinity)
+	}
+	if err != nil {
+		logger.Warnf("failed to set VF %s IRQs affinity: %s", pciAddr, err.Error())
+	}
+
+	return conn, nil
+}
+
+func (s *irqAffinityServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	logger := log.FromContext(ctx).WithField("irqAffinityServer", "Close")
+
+	if rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(originalAffinityKey{}); ok {
+		for irq, affinity := range rawValue.(map[string]string) {
+			if err := os.WriteFile(filepath.Join(s.procIRQPath, irq, affinityListFile), []byte(affinity), 0); err != nil {
+				logger.Warnf("failed to restore IRQ %s affinity: %s", irq, err.Error())
+			}
+		}
+	}
+
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *irqAffinityServer) localCPUs(pciAddr, cgroupDir string) ([]int, error) {
+	numaNode, err := readString(filepath.Join(s.pciDevicesPath, pciAddr, numaNodeFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var cpus []int
+	if nodeID, err := strconv.Atoi(numaNode); err == nil && nodeID >= 0 {
+		cpuList, err := readString(filepath.Join(s.nodesPath, "node"+numaNode, cpuListFile))
+		if err != nil {
+			return nil, err
+		}
+		if cpus, err = numa.ParseCPUList(cpuList); err != nil {
+			return nil, err
+		}
+	}
+
+	if cgroupDir == "" {
+		return cpus, nil
+	}
+
+	clientCPUs, err := numa.ReadCPUSet(s.cpusetBaseDir, cgroupDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(cpus) == 0 {
+		return clientCPUs, nil
+	}
+	if localClientCPUs := numa.IntersectCPUs(cpus, clientCPUs); len(localClientCPUs) != 0 {
+		return localClientCPUs, nil
+	}
+	// Client has no CPUs on the VF NUMA node, pinning to the client CPUs is better than to the remote node ones
+	return clientCPUs, nil
+}
+
+// setAffinity sets the VF IRQs affinity and returns the original affinity for the IRQs changed
+func (s *irqAffinityServer) setAffinity(pciAddr, cpuList string) (map[string]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.pciDevicesPath, pciAddr, msiIRQsDir))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read VF MSI IRQs: %s", pciAddr)
+	}
+
+	originalAffinity := map[string]string{}
+	for _, entry := range entries {
+		affinityPath := filepath.Join(s.procIRQPath, entry.Name(), affinityListFile)
+		affinity, err := readString(affinityPath)
+		if err != nil {
+			return originalAffinity, err
+		}
+		if err := os.WriteFile(affinityPath, []byte(cpuList), 0); err != nil {
+			return originalAffinity, errors.Wrapf(err, "failed to set IRQ %s affinity", entry.Name())
+		}
+		originalAffinity[entry.Name()] = affinity
+	}
+
+	return originalAffinity, nil
+}
+
+func readString(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read file: %s", path)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
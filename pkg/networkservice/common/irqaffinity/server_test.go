@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package irqaffinity_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/irqaffinity"
+)
+
+const (
+	vfPCIAddr = "0000:01:00.1"
+	cgroupDir = "kubepods/pod-1"
+)
+
+var irqs = []string{"100", "101"}
+
+func writeFile(t *testing.T, path, data string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestIRQAffinityServer(t *testing.T) {
+	tmpDir := t.TempDir()
+	pciDevicesPath := filepath.Join(tmpDir, "pci")
+	nodesPath := filepath.Join(tmpDir, "node")
+	procIRQPath := filepath.Join(tmpDir, "irq")
+	cpusetBaseDir := filepath.Join(tmpDir, "cpuset")
+
+	writeFile(t, filepath.Join(pciDevicesPath, vfPCIAddr, "numa_node"), "1\n")
+	writeFile(t, filepath.Join(nodesPath, "node1", "cpulist"), "8-15\n")
+	writeFile(t, filepath.Join(cpusetBaseDir, cgroupDir, "cpuset.effective_cpus"), "2-3,10-11\n")
+	for _, irq := range irqs {
+		writeFile(t, filepath.Join(pciDevicesPath, vfPCIAddr, "msi_irqs", irq), "msix")
+		writeFile(t, filepath.Join(procIRQPath, irq, "smp_affinity_list"), "0-31\n")
+	}
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		irqaffinity.NewServer(
+			irqaffinity.WithPCIDevicesPath(pciDevicesPath),
+			irqaffinity.WithNodesPath(nodesPath),
+			irqaffinity.WithProcIRQPath(procIRQPath),
+			irqaffinity.WithCPUSetBaseDir(cpusetBaseDir),
+		),
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.PCIAddressKey:     vfPCIAddr,
+					irqaffinity.CgroupDirKey: cgroupDir,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, irq := range irqs {
+		require.Equal(t, "10,11", readFile(t, filepath.Join(procIRQPath, irq, "smp_affinity_list")))
+	}
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	for _, irq := range irqs {
+		require.Equal(t, "0-31", readFile(t, filepath.Join(procIRQPath, irq, "smp_affinity_list")))
+	}
+}
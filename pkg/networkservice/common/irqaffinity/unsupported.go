@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+// Package irqaffinity provides chain element pinning the selected VF interrupts to the CPUs local to the client
+package irqaffinity
+
+import (
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+type irqAffinityServer struct {
+	pciDevicesPath string
+	nodesPath      string
+	procIRQPath    string
+	cpusetBaseDir  string
+}
+
+// NewServer returns a server chain element failing all the requests with sriov.UnsupportedError, IRQ affinity is
+// supported on Linux only
+func NewServer(_ ...Option) networkservice.NetworkServiceServer {
+	return injecterror.NewServer(
+		injecterror.WithError(sriov.NewUnsupportedError("IRQ affinity server")),
+		injecterror.WithCloseErrorTimes())
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec
+
+// Connection extra context keys carrying the MACsec configuration, keys and key IDs are hex encoded. The keys are
+// deleted from the connection extra context by the server once the MACsec net interface is created.
+const (
+	// TxKeyKey is the transmit SA key
+	TxKeyKey = "sriovMACsecTxKey"
+	// TxKeyIDKey is the transmit SA key ID
+	TxKeyIDKey = "sriovMACsecTxKeyID"
+	// RxKeyKey is the peer receive SA key
+	RxKeyKey = "sriovMACsecRxKey"
+	// RxKeyIDKey is the peer receive SA key ID
+	RxKeyIDKey = "sriovMACsecRxKeyID"
+	// RxSCIKey is the peer secure channel identifier
+	RxSCIKey = "sriovMACsecRxSCI"
+	// CipherKey is the cipher suite: "gcm-aes-128" (default) or "gcm-aes-256"
+	CipherKey = "sriovMACsecCipher"
+	// OffloadKey is the offload mode: "mac" (default) or "phy"
+	OffloadKey = "sriovMACsecOffload"
+	// InterfaceNameKey is the MACsec net interface name set by the server, "ms-" prefixed kernel mechanism interface
+	// name is used if it is not requested
+	InterfaceNameKey = "sriovMACsecInterfaceName"
+)
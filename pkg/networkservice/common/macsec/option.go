@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec
+
+import (
+	macsecdev "github.com/ljkiraly/sdk-sriov/pkg/tools/macsec"
+)
+
+// Option is an option pattern for NewServer
+type Option func(s *macsecServer)
+
+// WithManager sets MACsec net interfaces manager, macsec package functions are used by default
+func WithManager(manager Manager) Option {
+	return func(s *macsecServer) {
+		s.manager = manager
+	}
+}
+
+type netlinkManager struct{}
+
+func (netlinkManager) Create(cfg *macsecdev.Config) error {
+	return macsecdev.Create(cfg)
+}
+
+func (netlinkManager) Delete(name string) error {
+	return macsecdev.Delete(name)
+}
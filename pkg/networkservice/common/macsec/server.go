@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package macsec provides chain element programming the hardware offloaded MACsec on the VF with the keys carried in
+// the connection context
+package macsec
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	macsecdev "github.com/ljkiraly/sdk-sriov/pkg/tools/macsec"
+)
+
+const maxInterfaceNameLen = 15
+
+// Manager is a MACsec net interfaces manager
+type Manager interface {
+	Create(cfg *macsecdev.Config) error
+	Delete(name string) error
+}
+
+type macsecInterface struct {
+	name     string
+	netNSURL string
+}
+
+type macsecInterfaceKey struct{}
+
+type macsecServer struct {
+	manager Manager
+}
+
+// NewServer returns a new MACsec server chain element. For the kernel mechanism connections with the MACsec keys in
+// the connection extra context it creates the hardware offloaded MACsec net interface on the VF in the client netns,
+// so it should be placed after inject. The MACsec net interface is deleted on Close.
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &macsecServer{
+		manager: netlinkManager{},
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *macsecServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	mech := kernel.ToMechanism(conn.GetMechanism())
+	extraContext := conn.GetContext().GetExtraContext()
+	_, isEstablished := metadata.Map(ctx, false).Load(macsecInterfaceKey{})
+	if mech == nil || !isEstablished && extraContext[TxKeyKey] == "" {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if !isEstablished {
+		cfg, err := parseConfig(extraContext, mech.GetInterfaceName())
+		deleteKeys(extraContext)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.runInClientNetNS(mech.GetNetNSURL(), func() error {
+			return s.manager.Create(cfg)
+		}); err != nil {
+			return nil, err
+		}
+		extraContext[InterfaceNameKey] = cfg.Name
+		metadata.Map(ctx, false).Store(macsecInterfaceKey{}, &macsecInterface{
+			name:     cfg.Name,
+			netNSURL: mech.GetNetNSURL(),
+		})
+	}
+	deleteKeys(extraContext)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !isEstablished {
+			s.delete(ctx)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// deleteKeys deletes the MACsec SA keys from the connection extra context, so they are not returned to the client,
+// passed to the next hops or logged
+func deleteKeys(extraContext map[string]string) {
+	delete(extraContext, TxKeyKey)
+	delete(extraContext, RxKeyKey)
+}
+
+func (s *macsecServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.delete(ctx)
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *macsecServer) delete(ctx context.Context) {
+	rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(macsecInterfaceKey{})
+	if !ok {
+		return
+	}
+	iface := rawValue.(*macsecInterface)
+
+	if err := s.runInClientNetNS(iface.netNSURL, func() error {
+		return s.manager.Delete(iface.name)
+	}); err != nil {
+		log.FromContext(ctx).WithField("macsecServer", "delete").
+			Warnf("failed to delete MACsec net interface %s: %s", iface.name, err.Error())
+	}
+}
+
+func (s *macsecServer) runInClientNetNS(netNSURL string, runner func() error) error {
+	clientNetNS, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get client netns: %s", netNSURL)
+	}
+	defer func() { _ = clientNetNS.Close() }()
+
+	currentNetNS, err := nshandle.Current()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = currentNetNS.Close() }()
+
+	return nshandle.RunIn(currentNetNS, clientNetNS, runner)
+}
+
+func parseConfig(extraContext map[string]string, parent string) (*macsecdev.Config, error) {
+	cfg := &macsecdev.Config{
+		Name:    extraContext[InterfaceNameKey],
+		Parent:  parent,
+		Offload: macsecdev.OffloadMAC,
+		TxSA:    new(macsecdev.SA),
+		RxSA:    new(macsecdev.SA),
+	}
+	if cfg.Name == "" {
+		cfg.Name = "ms-" + parent
+		if len(cfg.Name) > maxInterfaceNameLen {
+			cfg.Name = cfg.Name[:maxInterfaceNameLen]
+		}
+	}
+
+	switch extraContext[CipherKey] {
+	case "", "gcm-aes-128":
+		cfg.Cipher = macsecdev.CipherGCMAES128
+	case "gcm-aes-256":
+		cfg.Cipher = macsecdev.CipherGCMAES256
+	default:
+		return nil, errors.Errorf("unsupported MACsec cipher: %s", extraContext[CipherKey])
+	}
+
+	switch extraContext[OffloadKey] {
+	case "", "mac":
+	case "phy":
+		cfg.Offload = macsecdev.OffloadPHY
+	default:
+		return nil, errors.Errorf("unsupported MACsec offload mode: %s", extraContext[OffloadKey])
+	}
+
+	for key, value := range map[string]*[]byte{
+		TxKeyKey:   &cfg.TxSA.Key,
+		TxKeyIDKey: &cfg.TxSA.KeyID,
+		RxKeyKey:   &cfg.RxSA.Key,
+		RxKeyIDKey: &cfg.RxSA.KeyID,
+		RxSCIKey:   &cfg.RxSCI,
+	} {
+		var err error
+		if *value, err = hex.DecodeString(extraContext[key]); err != nil {
+			return nil, errors.Wrapf(err, "invalid MACsec connection context value: %s", key)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid MACsec connection context")
+	}
+	return cfg, nil
+}
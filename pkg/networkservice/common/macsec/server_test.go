@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec_test
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/macsec"
+	macsecdev "github.com/ljkiraly/sdk-sriov/pkg/tools/macsec"
+)
+
+const (
+	ifName   = "nsm-1"
+	netNSURL = "file:///proc/self/ns/net"
+)
+
+type fakeManager struct {
+	interfaces map[string]*macsecdev.Config
+}
+
+func (m *fakeManager) Create(cfg *macsecdev.Config) error {
+	if _, ok := m.interfaces[cfg.Name]; ok {
+		return errors.Errorf("already exists: %s", cfg.Name)
+	}
+	m.interfaces[cfg.Name] = cfg
+	return nil
+}
+
+func (m *fakeManager) Delete(name string) error {
+	delete(m.interfaces, name)
+	return nil
+}
+
+func testRequest(extraContext map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					kernel.InterfaceNameKey: ifName,
+					kernel.NetNSURL:         netNSURL,
+				},
+			},
+			Context: &networkservice.ConnectionContext{
+				ExtraContext: extraContext,
+			},
+		},
+	}
+}
+
+func testExtraContext() map[string]string {
+	return map[string]string{
+		macsec.TxKeyKey:   strings.Repeat("01", 16),
+		macsec.TxKeyIDKey: strings.Repeat("02", macsecdev.KeyIDLen),
+		macsec.RxKeyKey:   strings.Repeat("03", 16),
+		macsec.RxKeyIDKey: strings.Repeat("04", macsecdev.KeyIDLen),
+		macsec.RxSCIKey:   "0200000000010001",
+	}
+}
+
+func TestMACsecServer_Request(t *testing.T) {
+	manager := &fakeManager{interfaces: map[string]*macsecdev.Config{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		macsec.NewServer(macsec.WithManager(manager)),
+	)
+
+	conn, err := server.Request(context.Background(), testRequest(testExtraContext()))
+	require.NoError(t, err)
+	require.Equal(t, "ms-"+ifName, conn.GetContext().GetExtraContext()[macsec.InterfaceNameKey])
+	require.NotContains(t, conn.GetContext().GetExtraContext(), macsec.TxKeyKey)
+	require.NotContains(t, conn.GetContext().GetExtraContext(), macsec.RxKeyKey)
+
+	cfg := manager.interfaces["ms-"+ifName]
+	require.NotNil(t, cfg)
+	require.Equal(t, ifName, cfg.Parent)
+	require.Equal(t, macsecdev.OffloadMAC, cfg.Offload)
+	require.Equal(t, macsecdev.CipherGCMAES128, cfg.Cipher)
+	require.Equal(t, "0200000000010001", hex.EncodeToString(cfg.RxSCI))
+
+	// Refresh doesn't recreate the MACsec net interface
+	_, err = server.Request(context.Background(), testRequest(conn.GetContext().GetExtraContext()))
+	require.NoError(t, err)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Empty(t, manager.interfaces)
+}
+
+func TestMACsecServer_Request_Invalid(t *testing.T) {
+	manager := &fakeManager{interfaces: map[string]*macsecdev.Config{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		macsec.NewServer(macsec.WithManager(manager)),
+	)
+
+	extraContext := testExtraContext()
+	extraContext[macsec.RxKeyKey] = "01"
+	_, err := server.Request(context.Background(), testRequest(extraContext))
+	require.ErrorContains(t, err, "invalid receive SA key length")
+
+	extraContext = testExtraContext()
+	extraContext[macsec.OffloadKey] = "sw"
+	_, err = server.Request(context.Background(), testRequest(extraContext))
+	require.ErrorContains(t, err, "unsupported MACsec offload mode")
+
+	require.Empty(t, manager.interfaces)
+}
+
+func TestMACsecServer_Request_Failed(t *testing.T) {
+	manager := &fakeManager{interfaces: map[string]*macsecdev.Config{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		macsec.NewServer(macsec.WithManager(manager)),
+		injecterror.NewServer(),
+	)
+
+	_, err := server.Request(context.Background(), testRequest(testExtraContext()))
+	require.Error(t, err)
+	require.Empty(t, manager.interfaces)
+}
+
+func TestMACsecServer_Request_NoKeys(t *testing.T) {
+	manager := &fakeManager{interfaces: map[string]*macsecdev.Config{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		macsec.NewServer(macsec.WithManager(manager)),
+	)
+
+	_, err := server.Request(context.Background(), testRequest(nil))
+	require.NoError(t, err)
+	require.Empty(t, manager.interfaces)
+}
+
+func TestMACsecServer_Request_RefreshFailed(t *testing.T) {
+	manager := &fakeManager{interfaces: map[string]*macsecdev.Config{}}
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		macsec.NewServer(macsec.WithManager(manager)),
+		injecterror.NewServer(injecterror.WithRequestErrorTimes(1), injecterror.WithCloseErrorTimes()),
+	)
+
+	conn, err := server.Request(context.Background(), testRequest(testExtraContext()))
+	require.NoError(t, err)
+
+	// failed refresh keeps the MACsec net interface of the established connection
+	_, err = server.Request(context.Background(), testRequest(conn.GetContext().GetExtraContext()))
+	require.Error(t, err)
+	require.NotEmpty(t, manager.interfaces)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Empty(t, manager.interfaces)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mechanismpriority provides chain element ordering and limiting the request mechanism preferences
+package mechanismpriority
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type mechanismPriorityServer struct {
+	priorities map[string]int
+}
+
+// NewServer returns a new mechanism priority server chain element. It orders the request mechanism preferences by the
+// mechanismTypes order and drops the mechanism preferences not listed in mechanismTypes.
+func NewServer(mechanismTypes ...string) networkservice.NetworkServiceServer {
+	s := &mechanismPriorityServer{
+		priorities: map[string]int{},
+	}
+	for _, mechanismType := range mechanismTypes {
+		if _, ok := s.priorities[mechanismType]; !ok {
+			s.priorities[mechanismType] = len(s.priorities)
+		}
+	}
+	return s
+}
+
+func (s *mechanismPriorityServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	buckets := make([][]*networkservice.Mechanism, len(s.priorities))
+	for _, mech := range request.GetMechanismPreferences() {
+		if priority, ok := s.priorities[mech.GetType()]; ok {
+			buckets[priority] = append(buckets[priority], mech)
+		}
+	}
+
+	var mechanismPreferences []*networkservice.Mechanism
+	for _, bucket := range buckets {
+		mechanismPreferences = append(mechanismPreferences, bucket...)
+	}
+	request.MechanismPreferences = mechanismPreferences
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *mechanismPriorityServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
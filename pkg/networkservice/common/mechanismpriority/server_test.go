@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechanismpriority_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/noop"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanismpriority"
+)
+
+func TestMechanismPriorityServer_Request(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		mechanismpriority.NewServer(kernel.MECHANISM, noop.MECHANISM, kernel.MECHANISM),
+		checkrequest.NewServer(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			var mechanismTypes []string
+			for _, mech := range request.GetMechanismPreferences() {
+				mechanismTypes = append(mechanismTypes, mech.GetType()+"-"+mech.GetCls())
+			}
+			require.Equal(t, []string{
+				kernel.MECHANISM + "-1",
+				kernel.MECHANISM + "-2",
+				noop.MECHANISM + "-1",
+			}, mechanismTypes)
+		}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Type: noop.MECHANISM, Cls: "1"},
+			{Type: vfio.MECHANISM, Cls: "1"},
+			{Type: kernel.MECHANISM, Cls: "1"},
+			{Type: kernel.MECHANISM, Cls: "2"},
+		},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noop
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/noop"
+)
+
+type noopServer struct {
+	store *Store
+}
+
+// NewServer returns a NOOP server chain element storing the NOOP mechanism connections labels and path into the store,
+// so the connections created without SR-IOV resources (e.g. for monitoring) can be inspected
+func NewServer(store *Store) networkservice.NetworkServiceServer {
+	return &noopServer{
+		store: store,
+	}
+}
+
+func (s *noopServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if conn.GetMechanism().GetType() == noop.MECHANISM {
+		s.store.store(conn)
+	}
+
+	return conn, nil
+}
+
+func (s *noopServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.store.delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noop_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	noopmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/noop"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/noop"
+)
+
+func request(connID, mechanismType string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             connID,
+			NetworkService: "ns",
+			Labels: map[string]string{
+				"app": "monitoring",
+			},
+			Mechanism: &networkservice.Mechanism{
+				Type: mechanismType,
+			},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc"},
+					{Name: "forwarder"},
+				},
+			},
+		},
+	}
+}
+
+func TestNoopServer(t *testing.T) {
+	store := noop.NewStore()
+	server := noop.NewServer(store)
+
+	conn, err := server.Request(context.Background(), request("id-1", noopmech.MECHANISM))
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), request("id-2", kernel.MECHANISM))
+	require.NoError(t, err)
+
+	infos := store.List()
+	require.Len(t, infos, 1)
+	require.Equal(t, "id-1", infos[0].ID)
+	require.Equal(t, "ns", infos[0].NetworkService)
+	require.Equal(t, map[string]string{"app": "monitoring"}, infos[0].Labels)
+	require.Len(t, infos[0].Path.GetPathSegments(), 2)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	_, ok := store.Get("id-1")
+	require.False(t, ok)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noop
+
+import (
+	"sort"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// ConnectionInfo is a minimal state of the connection established with the NOOP mechanism
+type ConnectionInfo struct {
+	ID             string
+	NetworkService string
+	Labels         map[string]string
+	Path           *networkservice.Path
+	UpdatedAt      time.Time
+}
+
+// Store is a store of the connections established with the NOOP mechanism
+type Store struct {
+	connections genericsync.Map[string, *ConnectionInfo]
+}
+
+// NewStore returns a new Store
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Get returns the connection info for the connection ID
+func (s *Store) Get(connID string) (*ConnectionInfo, bool) {
+	return s.connections.Load(connID)
+}
+
+// List returns all the stored connection infos sorted by the connection ID
+func (s *Store) List() []*ConnectionInfo {
+	var infos []*ConnectionInfo
+	s.connections.Range(func(_ string, info *ConnectionInfo) bool {
+		infos = append(infos, info)
+		return true
+	})
+	sort.Slice(infos, func(i, k int) bool {
+		return infos[i].ID < infos[k].ID
+	})
+	return infos
+}
+
+func (s *Store) store(conn *networkservice.Connection) {
+	labels := map[string]string{}
+	for k, v := range conn.GetLabels() {
+		labels[k] = v
+	}
+	s.connections.Store(conn.GetId(), &ConnectionInfo{
+		ID:             conn.GetId(),
+		NetworkService: conn.GetNetworkService(),
+		Labels:         labels,
+		Path:           conn.GetPath().Clone(),
+		UpdatedAt:      time.Now(),
+	})
+}
+
+func (s *Store) delete(connID string) {
+	s.connections.Delete(connID)
+}
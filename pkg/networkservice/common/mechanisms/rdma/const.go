@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rdma provides the RDMA mechanism and the server chain element moving VF RDMA device into the client netns
+package rdma
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+)
+
+const (
+	// MECHANISM string
+	MECHANISM = "RDMA"
+
+	// NetNSURL - client NetNS URL, it should be a file:// URL of the netns file shared with grpcfd
+	NetNSURL = common.InodeURL
+	// IBDeviceKey - RDMA (ib) device name moved into the client NetNS
+	IBDeviceKey = "ibDevice"
+
+	// NetnsModeExclusive - RDMA subsystem netns mode required for moving RDMA devices between netns
+	NetnsModeExclusive = "exclusive"
+)
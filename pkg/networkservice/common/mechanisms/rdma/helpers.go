@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rdma
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+)
+
+// Mechanism is an RDMA mechanism helper
+type Mechanism struct {
+	*networkservice.Mechanism
+}
+
+// ToMechanism converts unified mechanism to helper
+func ToMechanism(m *networkservice.Mechanism) *Mechanism {
+	if m.GetType() == MECHANISM {
+		if m.Parameters == nil {
+			m.Parameters = map[string]string{}
+		}
+		return &Mechanism{
+			m,
+		}
+	}
+	return nil
+}
+
+// New returns an RDMA mechanism for the given client netns URL
+func New(netNSURL string) *networkservice.Mechanism {
+	return &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: MECHANISM,
+		Parameters: map[string]string{
+			NetNSURL: netNSURL,
+		},
+	}
+}
+
+// GetNetNSURL returns the client NetNS URL
+func (m *Mechanism) GetNetNSURL() string {
+	return m.GetParameters()[NetNSURL]
+}
+
+// GetPCIAddress returns the selected VF PCI address
+func (m *Mechanism) GetPCIAddress() string {
+	return m.GetParameters()[common.PCIAddressKey]
+}
+
+// GetIBDevice returns the RDMA (ib) device name
+func (m *Mechanism) GetIBDevice() string {
+	return m.GetParameters()[IBDeviceKey]
+}
+
+// SetIBDevice sets the RDMA (ib) device name
+func (m *Mechanism) SetIBDevice(ibDevice string) {
+	m.GetParameters()[IBDeviceKey] = ibDevice
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package rdma
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	defaultPCIDevicesPath = "/sys/bus/pci/devices"
+	infinibandDir         = "infiniband"
+)
+
+type movedDeviceKey struct{}
+
+type movedDevice struct {
+	ibDevice string
+	netNSURL string
+}
+
+type rdmaServer struct {
+	pciDevicesPath string
+	lock           sync.Mutex
+}
+
+// NewServer returns a new RDMA server chain element moving the selected VF RDMA device into the client netns. VF
+// should be selected by the previous chain elements, RDMA subsystem is switched to the exclusive netns mode if needed.
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &rdmaServer{
+		pciDevicesPath: defaultPCIDevicesPath,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *rdmaServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mech := ToMechanism(request.GetConnection().GetMechanism())
+	if mech == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if moved, ok := metadata.Map(ctx, false).Load(movedDeviceKey{}); ok {
+		mech.SetIBDevice(moved.(*movedDevice).ibDevice)
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if mech.GetNetNSURL() == "" {
+		return nil, errors.New("expected client netns URL set")
+	}
+
+	ibDevice, err := s.getIBDevice(mech.GetPCIAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.moveToClient(ibDevice, mech.GetNetNSURL()); err != nil {
+		return nil, err
+	}
+	moved := &movedDevice{
+		ibDevice: ibDevice,
+		netNSURL: mech.GetNetNSURL(),
+	}
+	metadata.Map(ctx, false).Store(movedDeviceKey{}, moved)
+	mech.SetIBDevice(ibDevice)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		metadata.Map(ctx, false).Delete(movedDeviceKey{})
+		s.moveBack(ctx, moved)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *rdmaServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if moved, ok := metadata.Map(ctx, false).LoadAndDelete(movedDeviceKey{}); ok {
+		s.moveBack(ctx, moved.(*movedDevice))
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *rdmaServer) getIBDevice(pciAddr string) (string, error) {
+	if pciAddr == "" {
+		return "", errors.New("no VF selected")
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.pciDevicesPath, pciAddr, infinibandDir))
+	if err != nil || len(entries) == 0 {
+		return "", errors.Errorf("VF is not RDMA capable: %s", pciAddr)
+	}
+	return entries[0].Name(), nil
+}
+
+func (s *rdmaServer) moveToClient(ibDevice, netNSURL string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mode, err := netlink.RdmaSystemGetNetnsMode()
+	if err != nil {
+		return errors.Wrap(err, "failed to get RDMA netns mode")
+	}
+	if mode != NetnsModeExclusive {
+		if err = netlink.RdmaSystemSetNetnsMode(NetnsModeExclusive); err != nil {
+			return errors.Wrapf(err, "failed to set RDMA netns mode: %s", NetnsModeExclusive)
+		}
+	}
+
+	link, err := netlink.RdmaLinkByName(ibDevice)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find RDMA device: %s", ibDevice)
+	}
+
+	clientNetNS, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get client netns: %s", netNSURL)
+	}
+	defer func() { _ = clientNetNS.Close() }()
+
+	if err := netlink.RdmaLinkSetNsFd(link, uint32(clientNetNS)); err != nil {
+		return errors.Wrapf(err, "failed to move RDMA device into the client netns: %s", ibDevice)
+	}
+	return nil
+}
+
+// moveBack moves RDMA device back into the forwarder netns. If client netns is already gone, kernel moves the RDMA
+// device back into the init netns itself.
+func (s *rdmaServer) moveBack(ctx context.Context, moved *movedDevice) {
+	logger := log.FromContext(ctx).WithField("rdmaServer", "moveBack")
+
+	if err := func() error {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		clientNetNS, err := nshandle.FromURL(moved.netNSURL)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get client netns: %s", moved.netNSURL)
+		}
+		defer func() { _ = clientNetNS.Close() }()
+
+		handle, err := netlink.NewHandleAt(clientNetNS)
+		if err != nil {
+			return errors.Wrap(err, "failed to create netlink handle in the client netns")
+		}
+		defer handle.Close()
+
+		link, err := handle.RdmaLinkByName(moved.ibDevice)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find RDMA device in the client netns: %s", moved.ibDevice)
+		}
+
+		currentNetNS, err := nshandle.Current()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = currentNetNS.Close() }()
+
+		return handle.RdmaLinkSetNsFd(link, uint32(currentNetNS))
+	}(); err != nil {
+		logger.Warnf("failed to move RDMA device back: %s", err.Error())
+	}
+}
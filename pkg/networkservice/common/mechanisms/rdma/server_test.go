@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package rdma_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/rdma"
+)
+
+const (
+	vfPCIAddr = "0000:01:00.1"
+	netNSURL  = "file:///proc/1/fd/10"
+)
+
+func TestRDMAServer_NotRDMAMechanism(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		rdma.NewServer(rdma.WithPCIDevicesPath(t.TempDir())),
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        "id",
+			Mechanism: kernel.New(netNSURL),
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, conn.GetMechanism().GetParameters()[rdma.IBDeviceKey])
+}
+
+func TestRDMAServer_NotRDMACapableVF(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		rdma.NewServer(rdma.WithPCIDevicesPath(t.TempDir())),
+	)
+
+	mech := rdma.New(netNSURL)
+	mech.GetParameters()[common.PCIAddressKey] = vfPCIAddr
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        "id",
+			Mechanism: mech,
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "VF is not RDMA capable")
+}
+
+func TestRDMAServer_NoNetNSURL(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		rdma.NewServer(rdma.WithPCIDevicesPath(t.TempDir())),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        "id",
+			Mechanism: rdma.New(""),
+		},
+	})
+	require.Error(t, err)
+}
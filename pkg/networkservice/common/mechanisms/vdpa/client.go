@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vdpa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
+const mknodPerm = 0o666
+
+type vdpaClient struct {
+	devDir          string
+	cgroupDir       string
+	cgroupResolvers []cgroup.PathResolver
+	devices         map[string]string // devices[connID] -> created device file path
+	lock            sync.Mutex
+}
+
+// NewClient returns a new vhost-vdpa client chain element creating the granted vhost-vdpa char device in the client
+// dev directory, the device is not created if it is granted with CDI
+func NewClient(options ...Option) networkservice.NetworkServiceClient {
+	c := &vdpaClient{
+		devDir:  defaultDevDir,
+		devices: map[string]string{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.cgroupDir == "" {
+		var err error
+		if c.cgroupDir, err = cgroup.DirPath(c.cgroupResolvers...); err != nil {
+			return injecterror.NewClient(injecterror.WithError(err))
+		}
+	}
+
+	return c
+}
+
+func (c *vdpaClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	var hasMechanism bool
+	for _, preference := range request.MechanismPreferences {
+		if mech := ToMechanism(preference); mech != nil {
+			hasMechanism = true
+			mech.SetCgroupDir(c.cgroupDir)
+		}
+	}
+	if !hasMechanism {
+		request.MechanismPreferences = append(request.MechanismPreferences, New(c.cgroupDir))
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if mech := ToMechanism(conn.GetMechanism()); mech != nil && mech.GetCDIDevice() == "" {
+		devicePath := filepath.Join(c.devDir, filepath.Base(mech.GetDevicePath()))
+		if err := unix.Mknod(
+			devicePath,
+			unix.S_IFCHR|mknodPerm,
+			int(unix.Mkdev(mech.GetDeviceMajor(), mech.GetDeviceMinor())),
+		); err != nil && !os.IsExist(err) {
+			_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+			return nil, errors.Wrapf(err, "failed to mknod device: %v", devicePath)
+		}
+
+		c.lock.Lock()
+		c.devices[conn.GetId()] = devicePath
+		c.lock.Unlock()
+	}
+
+	return conn, nil
+}
+
+func (c *vdpaClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.lock.Lock()
+	if devicePath, ok := c.devices[conn.GetId()]; ok {
+		_ = os.Remove(devicePath)
+		delete(c.devices, conn.GetId())
+	}
+	c.lock.Unlock()
+
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
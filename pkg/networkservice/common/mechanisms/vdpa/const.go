@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vdpa provides the vhost-vdpa mechanism and the client, server chain elements granting the client access to
+// the vhost-vdpa char device created on the selected VF
+package vdpa
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+)
+
+const (
+	// MECHANISM string
+	MECHANISM = "VDPA"
+
+	// CgroupDirKey - client cgroup directory the vhost-vdpa char device access is granted to
+	CgroupDirKey = "cgroupDir"
+	// DevicePathKey - vhost-vdpa char device path, e.g. /dev/vhost-vdpa-0
+	DevicePathKey = "vhostVdpaDevice"
+	// DeviceMajorKey - vhost-vdpa char device major number
+	DeviceMajorKey = "deviceMajor"
+	// DeviceMinorKey - vhost-vdpa char device minor number
+	DeviceMinorKey = "deviceMinor"
+	// DeviceNameKey - vDPA device name
+	DeviceNameKey = "vdpaDevice"
+	// VirtioFeaturesKey - vDPA device virtio features bitmask as a hex number, e.g. 0x300000000
+	VirtioFeaturesKey = "virtioFeatures"
+	// CDIDeviceKey - qualified CDI device name of the vhost-vdpa char device, set if the access is granted with CDI
+	CDIDeviceKey = "cdiDevice"
+	// PCIAddressKey - VF PCI address
+	PCIAddressKey = common.PCIAddressKey
+)
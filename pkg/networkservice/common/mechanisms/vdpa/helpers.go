@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vdpa
+
+import (
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+)
+
+// Mechanism is a vhost-vdpa mechanism helper
+type Mechanism struct {
+	*networkservice.Mechanism
+}
+
+// ToMechanism converts unified mechanism to helper
+func ToMechanism(m *networkservice.Mechanism) *Mechanism {
+	if m.GetType() == MECHANISM {
+		if m.Parameters == nil {
+			m.Parameters = map[string]string{}
+		}
+		return &Mechanism{
+			m,
+		}
+	}
+	return nil
+}
+
+// New returns a vhost-vdpa mechanism for the given client cgroup directory
+func New(cgroupDir string) *networkservice.Mechanism {
+	return &networkservice.Mechanism{
+		Cls:  cls.LOCAL,
+		Type: MECHANISM,
+		Parameters: map[string]string{
+			CgroupDirKey: cgroupDir,
+		},
+	}
+}
+
+// GetCgroupDir returns the client cgroup directory
+func (m *Mechanism) GetCgroupDir() string {
+	return m.GetParameters()[CgroupDirKey]
+}
+
+// SetCgroupDir sets the client cgroup directory
+func (m *Mechanism) SetCgroupDir(cgroupDir string) {
+	m.GetParameters()[CgroupDirKey] = cgroupDir
+}
+
+// GetPCIAddress returns the selected VF PCI address
+func (m *Mechanism) GetPCIAddress() string {
+	return m.GetParameters()[PCIAddressKey]
+}
+
+// GetDevicePath returns the vhost-vdpa char device path
+func (m *Mechanism) GetDevicePath() string {
+	return m.GetParameters()[DevicePathKey]
+}
+
+// GetDeviceMajor returns the vhost-vdpa char device major number
+func (m *Mechanism) GetDeviceMajor() uint32 {
+	return atou(m.GetParameters()[DeviceMajorKey])
+}
+
+// GetDeviceMinor returns the vhost-vdpa char device minor number
+func (m *Mechanism) GetDeviceMinor() uint32 {
+	return atou(m.GetParameters()[DeviceMinorKey])
+}
+
+// GetVirtioFeatures returns the vDPA device virtio features bitmask
+func (m *Mechanism) GetVirtioFeatures() uint64 {
+	features, _ := strconv.ParseUint(m.GetParameters()[VirtioFeaturesKey], 0, 64)
+	return features
+}
+
+// GetCDIDevice returns the qualified CDI device name of the vhost-vdpa char device
+func (m *Mechanism) GetCDIDevice() string {
+	return m.GetParameters()[CDIDeviceKey]
+}
+
+func atou(s string) uint32 {
+	value, _ := strconv.ParseUint(s, 10, 32)
+	return uint32(value)
+}
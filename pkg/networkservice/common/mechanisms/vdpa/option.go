@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vdpa
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
+// Option is an option for NewClient
+type Option func(c *vdpaClient)
+
+// WithDevDir sets vdpaClient directory the vhost-vdpa char devices are created in, /dev by default
+func WithDevDir(devDir string) Option {
+	return func(c *vdpaClient) {
+		c.devDir = devDir
+	}
+}
+
+// WithCgroupDir sets vdpaClient cgroupDir
+func WithCgroupDir(cgroupDir string) Option {
+	return func(c *vdpaClient) {
+		c.cgroupDir = cgroupDir
+	}
+}
+
+// WithCgroupPathResolvers sets vdpaClient resolvers used to find out cgroupDir if it is not set, the cgroup package
+// default resolvers are used by default
+func WithCgroupPathResolvers(resolvers ...cgroup.PathResolver) Option {
+	return func(c *vdpaClient) {
+		c.cgroupResolvers = resolvers
+	}
+}
+
+// DevicePool is a vdpa.Pool interface
+type DevicePool interface {
+	CreateDevice(vfPCIAddr string) (*vdpa.Device, error)
+	DeleteDevice(vfPCIAddr string) error
+}
+
+// ServerOption is an option for NewServer
+type ServerOption func(s *vdpaServer)
+
+// WithHostDevDir sets the host directory the vhost-vdpa char devices are looked up in, /dev by default
+func WithHostDevDir(devDir string) ServerOption {
+	return func(s *vdpaServer) {
+		s.devDir = devDir
+	}
+}
+
+// WithCDI grants the client access to the vhost-vdpa char device with the CDI spec written per connection instead of
+// the cgroup device rules, the qualified CDI device name is set as CDIDeviceKey mechanism parameter
+func WithCDI(generator *cdi.Generator) ServerOption {
+	return func(s *vdpaServer) {
+		s.cdi = generator
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vdpa
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+)
+
+const defaultDevDir = "/dev"
+
+// Netlink is a netlink.Handle interface
+type Netlink interface {
+	VDPAGetDevConfigByName(name string) (*netlink.VDPADevConfig, error)
+}
+
+// WithNetlink sets the netlink handle used to get the vDPA devices virtio features, netlink default handle is used by
+// default
+func WithNetlink(nl Netlink) ServerOption {
+	return func(s *vdpaServer) {
+		s.netlink = nl
+	}
+}
+
+type grant struct {
+	cgroupDirPattern string
+	major, minor     uint32
+}
+
+type vdpaServer struct {
+	devicePool    DevicePool
+	cgroupBaseDir string
+	devDir        string
+	cdi           *cdi.Generator
+	netlink       Netlink
+	grants        map[string]*grant
+	lock          sync.Mutex
+}
+
+// NewServer returns a new vhost-vdpa server chain element creating the vDPA device with the devicePool on the VF
+// selected and bound to the kernel driver by the previous chain elements and granting the client access to its
+// vhost-vdpa char device, the device is deleted on Close
+func NewServer(devicePool DevicePool, cgroupBaseDir string, options ...ServerOption) networkservice.NetworkServiceServer {
+	s := &vdpaServer{
+		devicePool:    devicePool,
+		cgroupBaseDir: cgroupBaseDir,
+		devDir:        defaultDevDir,
+		netlink:       new(netlink.Handle),
+		grants:        map[string]*grant{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *vdpaServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	var registered bool
+	if mech := ToMechanism(request.GetConnection().GetMechanism()); mech != nil {
+		if err := s.grant(ctx, request.GetConnection().GetId(), mech); err != nil {
+			return nil, err
+		}
+
+		grantedConn := request.GetConnection()
+		registered = cleanup.Register(ctx, s, func(ctx context.Context) {
+			s.close(ctx, grantedConn)
+		})
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !registered {
+			s.close(ctx, request.GetConnection())
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *vdpaServer) grant(ctx context.Context, connID string, mech *Mechanism) error {
+	defer stages.Observe(ctx, stages.CgroupGrant, time.Now())
+
+	device, err := s.devicePool.CreateDevice(mech.GetPCIAddress())
+	if err != nil {
+		return errors.Wrapf(err, "failed to create vDPA device on the VF: %s", mech.GetPCIAddress())
+	}
+	if device.DevicePath == "" {
+		return errors.Errorf("no vhost-vdpa device created on the VF: %s", mech.GetPCIAddress())
+	}
+
+	hostDevicePath := filepath.Join(s.devDir, filepath.Base(device.DevicePath))
+	info := new(unix.Stat_t)
+	if err := unix.Stat(hostDevicePath, info); err != nil {
+		return errors.Wrapf(err, "failed to check %s file status", hostDevicePath)
+	}
+	major, minor := unix.Major(info.Rdev), unix.Minor(info.Rdev)
+
+	devConfig, err := s.netlink.VDPAGetDevConfigByName(device.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get vDPA device config: %s", device.Name)
+	}
+
+	if s.cdi != nil {
+		names, err := s.cdi.WriteSpec(ctx, connID, s.cdi.CharDevice(connID, device.DevicePath))
+		if err != nil {
+			return err
+		}
+		mech.GetParameters()[CDIDeviceKey] = names[0]
+	} else if err := s.allowDevice(connID, mech, major, minor); err != nil {
+		return err
+	}
+
+	mech.GetParameters()[DeviceNameKey] = device.Name
+	mech.GetParameters()[DevicePathKey] = device.DevicePath
+	mech.GetParameters()[DeviceMajorKey] = strconv.FormatUint(uint64(major), 10)
+	mech.GetParameters()[DeviceMinorKey] = strconv.FormatUint(uint64(minor), 10)
+	mech.GetParameters()[VirtioFeaturesKey] = fmt.Sprintf("%#x", devConfig.Features)
+
+	return nil
+}
+
+func (s *vdpaServer) allowDevice(connID string, mech *Mechanism, major, minor uint32) error {
+	if mech.GetCgroupDir() == "" {
+		return errors.New("expected client cgroup directory set")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.grants[connID]; ok {
+		return nil
+	}
+
+	g := &grant{
+		cgroupDirPattern: filepath.Join(s.cgroupBaseDir, mech.GetCgroupDir()),
+		major:            major,
+		minor:            minor,
+	}
+	cgroups, err := cgroup.NewDeviceControllers(g.cgroupDirPattern)
+	if err != nil || len(cgroups) == 0 {
+		return errors.Errorf("no cgroupDir found: %s", g.cgroupDirPattern)
+	}
+	for _, cg := range cgroups {
+		if err := cg.AllowRule(cgroup.NewRule(cgroup.CharDevice, major, minor, 'r', 'w', 'm')); err != nil {
+			return errors.Wrapf(err, "failed to allow vhost-vdpa device for the cgroup: %s", cg.Dir())
+		}
+	}
+	s.grants[connID] = g
+
+	return nil
+}
+
+func (s *vdpaServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.close(ctx, conn)
+	cleanup.Unregister(ctx, s)
+
+	if _, err := next.Server(ctx).Close(ctx, conn); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *vdpaServer) close(ctx context.Context, conn *networkservice.Connection) {
+	logger := log.FromContext(ctx).WithField("vdpaServer", "close")
+
+	s.revoke(logger, conn)
+
+	if mech := ToMechanism(conn.GetMechanism()); mech != nil {
+		if err := s.devicePool.DeleteDevice(mech.GetPCIAddress()); err != nil {
+			logger.Errorf("failed to delete vDPA device on the VF %s: %s", mech.GetPCIAddress(), err.Error())
+		}
+	}
+}
+
+func (s *vdpaServer) revoke(logger log.Logger, conn *networkservice.Connection) {
+	if s.cdi != nil {
+		if err := s.cdi.RemoveSpec(conn.GetId()); err != nil {
+			logger.Errorf("failed to remove CDI spec: %s", err.Error())
+		}
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	g, ok := s.grants[conn.GetId()]
+	if !ok {
+		return
+	}
+	delete(s.grants, conn.GetId())
+
+	cgroups, err := cgroup.NewDeviceControllers(g.cgroupDirPattern)
+	if err != nil {
+		logger.Errorf("no cgroupDir found: %s", g.cgroupDirPattern)
+		return
+	}
+	for _, cg := range cgroups {
+		if err := cg.DenyRule(cgroup.NewRule(cgroup.CharDevice, g.major, g.minor, 'r', 'w')); err != nil {
+			logger.Errorf("failed to deny vhost-vdpa device for the cgroup %s: %s", cg.Dir(), err.Error())
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vdpa_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+
+	vdpamech "github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+)
+
+const (
+	vfPCIAddr  = "0000:01:00.1"
+	deviceName = "vdpa-0000-01-00-1"
+	devicePath = "/dev/vhost-vdpa-0"
+	cgroupDir  = "pod-1/container-1"
+)
+
+type devicePoolStub struct {
+	devices map[string]*vdpa.Device
+	created map[string]bool
+}
+
+func (p *devicePoolStub) CreateDevice(vfPCIAddr string) (*vdpa.Device, error) {
+	device, ok := p.devices[vfPCIAddr]
+	if !ok {
+		return nil, errors.Errorf("VF is not selected for the vDPA driver: %s", vfPCIAddr)
+	}
+	p.created[vfPCIAddr] = true
+	return device, nil
+}
+
+func (p *devicePoolStub) DeleteDevice(vfPCIAddr string) error {
+	delete(p.created, vfPCIAddr)
+	return nil
+}
+
+type netlinkStub struct{}
+
+func (netlinkStub) VDPAGetDevConfigByName(name string) (*netlink.VDPADevConfig, error) {
+	if name != deviceName {
+		return nil, errors.Errorf("no such device: %s", name)
+	}
+	return &netlink.VDPADevConfig{Features: 0x300000000}, nil
+}
+
+func testDirs(t *testing.T) (devDir, cgroupBaseDir string) {
+	devDir = t.TempDir()
+	cgroupBaseDir = t.TempDir()
+
+	// /dev/null is 1:3
+	require.NoError(t, os.Symlink("/dev/null", filepath.Join(devDir, filepath.Base(devicePath))))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(cgroupBaseDir, cgroupDir), 0o750))
+	for _, name := range []string{"devices.list", "devices.allow", "devices.deny"} {
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupBaseDir, cgroupDir, name), nil, 0o600))
+	}
+
+	return devDir, cgroupBaseDir
+}
+
+func readCgroupFile(t *testing.T, cgroupBaseDir, name string) string {
+	data, err := os.ReadFile(filepath.Join(cgroupBaseDir, cgroupDir, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func testRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "conn-1",
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: vdpamech.MECHANISM,
+				Parameters: map[string]string{
+					vdpamech.CgroupDirKey:  cgroupDir,
+					vdpamech.PCIAddressKey: vfPCIAddr,
+				},
+			},
+		},
+	}
+}
+
+func testDevicePool() *devicePoolStub {
+	return &devicePoolStub{
+		devices: map[string]*vdpa.Device{
+			vfPCIAddr: {
+				Name:         deviceName,
+				VFPCIAddress: vfPCIAddr,
+				Driver:       sriov.VhostVDPADriver,
+				DevicePath:   devicePath,
+			},
+		},
+		created: map[string]bool{},
+	}
+}
+
+func TestVDPAServer_Request_Cgroup(t *testing.T) {
+	devDir, cgroupBaseDir := testDirs(t)
+
+	devicePool := testDevicePool()
+	server := vdpamech.NewServer(devicePool, cgroupBaseDir,
+		vdpamech.WithHostDevDir(devDir),
+		vdpamech.WithNetlink(netlinkStub{}))
+
+	conn, err := server.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+	require.True(t, devicePool.created[vfPCIAddr])
+
+	mech := vdpamech.ToMechanism(conn.GetMechanism())
+	require.NotNil(t, mech)
+	require.Equal(t, devicePath, mech.GetDevicePath())
+	require.Equal(t, uint32(1), mech.GetDeviceMajor())
+	require.Equal(t, uint32(3), mech.GetDeviceMinor())
+	require.Equal(t, uint64(0x300000000), mech.GetVirtioFeatures())
+	require.Equal(t, deviceName, conn.GetMechanism().GetParameters()[vdpamech.DeviceNameKey])
+	require.Empty(t, mech.GetCDIDevice())
+	require.Equal(t, "c 1:3 rwm\n", readCgroupFile(t, cgroupBaseDir, "devices.allow"))
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, "c 1:3 rw\n", readCgroupFile(t, cgroupBaseDir, "devices.deny"))
+	require.Empty(t, devicePool.created)
+}
+
+func TestVDPAServer_Request_CDI(t *testing.T) {
+	devDir, cgroupBaseDir := testDirs(t)
+	specDir := filepath.Join(t.TempDir(), "cdi")
+
+	server := vdpamech.NewServer(testDevicePool(), cgroupBaseDir,
+		vdpamech.WithHostDevDir(devDir),
+		vdpamech.WithNetlink(netlinkStub{}),
+		vdpamech.WithCDI(cdi.NewGenerator(cdi.WithSpecDir(specDir))))
+
+	conn, err := server.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+
+	require.Equal(t, cdi.DefaultKind+"=conn-1", vdpamech.ToMechanism(conn.GetMechanism()).GetCDIDevice())
+	require.Empty(t, readCgroupFile(t, cgroupBaseDir, "devices.allow"))
+
+	specs, err := filepath.Glob(filepath.Join(specDir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	specs, err = filepath.Glob(filepath.Join(specDir, "*.json"))
+	require.NoError(t, err)
+	require.Empty(t, specs)
+}
+
+func TestVDPAServer_Request_NoDevice(t *testing.T) {
+	devDir, cgroupBaseDir := testDirs(t)
+
+	server := vdpamech.NewServer(&devicePoolStub{created: map[string]bool{}}, cgroupBaseDir,
+		vdpamech.WithHostDevDir(devDir),
+		vdpamech.WithNetlink(netlinkStub{}))
+
+	_, err := server.Request(context.Background(), testRequest())
+	require.ErrorContains(t, err, "failed to create vDPA device on the VF: "+vfPCIAddr)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/identity"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/yamlhelper"
+)
+
+// GroupACL decides if the client identity (e.g. client spiffe ID) may receive access to the IOMMU group
+type GroupACL func(identity, iommuGroup string) (bool, error)
+
+// GroupACLPolicy is a GroupACL policy file content: IOMMU group -> allowed client identities. IOMMU groups missing in
+// the policy are allowed for all the clients.
+type GroupACLPolicy struct {
+	Groups map[string][]string `yaml:"groups"`
+}
+
+// Allows returns if the client identity may receive access to the IOMMU group
+func (p *GroupACLPolicy) Allows(identity, iommuGroup string) bool {
+	identities, ok := p.Groups[iommuGroup]
+	if !ok {
+		return true
+	}
+	for _, item := range identities {
+		if item == identity {
+			return true
+		}
+	}
+	return false
+}
+
+// NewGroupACLFromFile returns a GroupACL reading the GroupACLPolicy from the policyFile on each check, so the policy
+// updates are applied to the following requests without the server restart
+func NewGroupACLFromFile(policyFile string) GroupACL {
+	return func(identity, iommuGroup string) (bool, error) {
+		policy := new(GroupACLPolicy)
+		if err := yamlhelper.UnmarshalFile(policyFile, policy); err != nil {
+			return false, errors.Wrapf(err, "failed to read IOMMU group ACL policy: %s", policyFile)
+		}
+		return policy.Allows(identity, iommuGroup), nil
+	}
+}
+
+// checkGroupACL returns an error if the client identity taken from the path is not allowed to access the IOMMU group
+func checkGroupACL(ctx context.Context, acl GroupACL, path *networkservice.Path, iommuGroup string) error {
+	if acl == nil {
+		return nil
+	}
+
+	clientID := identity.FromPath(ctx, path)
+	allowed, err := acl(clientID, iommuGroup)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return newDeviceAccessError(ErrAccessDenied,
+			errors.Errorf("client %s is not allowed to access IOMMU group: %s", clientID, iommuGroup))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+)
+
+const (
+	tenantID = "spiffe://example.org/ns/tenant/pod/nsc-1"
+	otherID  = "spiffe://example.org/ns/other/pod/nsc-2"
+
+	aclPolicy = `---
+groups:
+  "1":
+    - spiffe://example.org/ns/tenant/pod/nsc-1
+`
+)
+
+func aclRequest(t *testing.T, connID, spiffeID string) *networkservice.NetworkServiceRequest {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+		SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	request := grantsRequest(connID)
+	request.GetConnection().Path = &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{
+			{Name: "nsc", Token: token},
+		},
+	}
+	return request
+}
+
+func TestVFIOServer_Request_GroupACLFile(t *testing.T) {
+	vfioDir, cgroupBaseDir := testGrantsDirs(t)
+
+	policyFile := filepath.Join(t.TempDir(), "acl.yaml")
+	server := vfio.NewServer(vfioDir, cgroupBaseDir, vfio.WithGroupACL(vfio.NewGroupACLFromFile(policyFile)))
+
+	_, err := server.Request(context.Background(), aclRequest(t, "conn-1", tenantID))
+	require.ErrorContains(t, err, "failed to read IOMMU group ACL policy")
+
+	require.NoError(t, os.WriteFile(policyFile, []byte(aclPolicy), 0o600))
+
+	_, err = server.Request(context.Background(), aclRequest(t, "conn-1", otherID))
+	require.ErrorIs(t, err, vfio.ErrAccessDenied)
+	require.ErrorContains(t, err, "is not allowed to access IOMMU group: "+iommuGroupString)
+	require.Empty(t, readCgroupFile(t, cgroupBaseDir, "devices.allow"))
+
+	_, err = server.Request(context.Background(), aclRequest(t, "conn-1", tenantID))
+	require.NoError(t, err)
+	require.NotEmpty(t, readCgroupFile(t, cgroupBaseDir, "devices.allow"))
+}
+
+func TestGroupACLPolicy_Allows(t *testing.T) {
+	policy := &vfio.GroupACLPolicy{
+		Groups: map[string][]string{
+			"1": {tenantID},
+		},
+	}
+
+	require.True(t, policy.Allows(tenantID, "1"))
+	require.False(t, policy.Allows(otherID, "1"))
+	require.False(t, policy.Allows("", "1"))
+	require.True(t, policy.Allows(otherID, "2"))
+}
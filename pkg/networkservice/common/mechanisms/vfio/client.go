@@ -16,33 +16,43 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !windows
-// +build !windows
+//go:build linux
+// +build linux
 
 package vfio
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/edwarnicke/grpcfd"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
 	"github.com/pkg/errors"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
 )
 
 type vfioClient struct {
-	vfioDir   string
-	cgroupDir string
+	vfioDir         string
+	cgroupDir       string
+	cgroupResolvers []cgroup.PathResolver
+	noIOMMU         bool
+	verifyDevices   bool
+	lock            sync.Mutex
+	files           map[string]*deviceFiles
 }
 
 const (
@@ -54,6 +64,7 @@ const (
 func NewClient(options ...Option) networkservice.NetworkServiceClient {
 	c := &vfioClient{
 		vfioDir: "/dev/vfio",
+		files:   map[string]*deviceFiles{},
 	}
 
 	for _, option := range options {
@@ -62,7 +73,7 @@ func NewClient(options ...Option) networkservice.NetworkServiceClient {
 
 	if c.cgroupDir == "" {
 		var err error
-		if c.cgroupDir, err = cgroup.DirPath(); err != nil {
+		if c.cgroupDir, err = cgroup.DirPath(c.cgroupResolvers...); err != nil {
 			return injecterror.NewClient(injecterror.WithError(err))
 		}
 	}
@@ -84,12 +95,29 @@ func (c *vfioClient) Request(ctx context.Context, request *networkservice.Networ
 		request.MechanismPreferences = append(request.MechanismPreferences, vfio.New(c.cgroupDir))
 	}
 
+	rpcCredentials := grpcfd.PerRPCCredentials(grpcfd.PerRPCCredentialsFromCallOptions(opts...))
+	opts = append(opts, grpc.PerRPCCredentials(rpcCredentials))
+
 	conn, err := next.Client(ctx).Request(ctx, request, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	if mech := vfio.ToMechanism(conn.GetMechanism()); mech != nil {
+		if mech.GetParameters()[NoIOMMUKey] == "true" && !c.noIOMMU {
+			_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+			return nil, errors.New("VFIO device in the unsafe no-IOMMU mode is not allowed")
+		}
+
+		if mech.GetParameters()[GroupFDURLKey] != "" {
+			recv, _ := grpcfd.FromPerRPCCredentials(rpcCredentials)
+			if err := c.recvFiles(ctx, conn.GetId(), mech, recv); err != nil {
+				_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+				return nil, err
+			}
+			return conn, nil
+		}
+
 		if err := os.Mkdir(c.vfioDir, mkdirPerm); err != nil && !os.IsExist(err) {
 			logger.Error("failed to create vfio directory")
 			return nil, errors.Wrapf(err, "failed to create vfio directory %s", c.vfioDir)
@@ -105,6 +133,9 @@ func (c *vfioClient) Request(ctx context.Context, request *networkservice.Networ
 		}
 
 		igid := mech.GetParameters()[vfio.IommuGroupKey]
+		if mech.GetParameters()[NoIOMMUKey] == "true" {
+			igid = noIOMMUGroupPrefix + igid
+		}
 		if err := unix.Mknod(
 			filepath.Join(c.vfioDir, igid),
 			unix.S_IFCHR|mknodPerm,
@@ -113,11 +144,63 @@ func (c *vfioClient) Request(ctx context.Context, request *networkservice.Networ
 			logger.Errorf("failed to mknod device: %v", vfioDevice)
 			return nil, errors.Wrapf(err, "failed to mknod device: %v", vfioDevice)
 		}
+
+		if err := c.mknodExtraGroups(mech); err != nil {
+			logger.Errorf("failed to mknod extra IOMMU group devices: %s", err.Error())
+			return nil, err
+		}
+
+		if c.verifyDevices {
+			if err := verifyDevices(c.vfioDir, igid); err != nil {
+				_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+				return nil, err
+			}
+		}
 	}
 
 	return conn, nil
 }
 
+// mknodExtraGroups creates the devices for the extra IOMMU groups granted to the client cgroup, see ExtraDeviceNumbersKey
+func (c *vfioClient) mknodExtraGroups(mech *vfio.Mechanism) error {
+	extraDeviceNumbers := mech.GetParameters()[ExtraDeviceNumbersKey]
+	if extraDeviceNumbers == "" {
+		return nil
+	}
+
+	iommuGroups := resourcepool.IOMMUGroups(mech.Mechanism)
+	deviceNumbers := strings.Split(extraDeviceNumbers, ",")
+	if len(iommuGroups) != len(deviceNumbers)+1 {
+		return errors.Errorf("extra IOMMU groups %v don't match the device numbers: %s", iommuGroups, extraDeviceNumbers)
+	}
+
+	for i, deviceNumber := range deviceNumbers {
+		var major, minor uint32
+		if _, err := fmt.Sscanf(deviceNumber, "%d:%d", &major, &minor); err != nil {
+			return errors.Wrapf(err, "invalid device number: %s", deviceNumber)
+		}
+		igid := iommuGroups[i+1]
+		if mech.GetParameters()[NoIOMMUKey] == "true" {
+			igid = noIOMMUGroupPrefix + igid
+		}
+		if err := unix.Mknod(
+			filepath.Join(c.vfioDir, igid),
+			unix.S_IFCHR|mknodPerm,
+			int(unix.Mkdev(major, minor)),
+		); err != nil && !os.IsExist(err) {
+			return errors.Wrapf(err, "failed to mknod device: %v", igid)
+		}
+	}
+	return nil
+}
+
 func (c *vfioClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.lock.Lock()
+	if files, ok := c.files[conn.GetId()]; ok {
+		files.close()
+		delete(c.files, conn.GetId())
+	}
+	c.lock.Unlock()
+
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
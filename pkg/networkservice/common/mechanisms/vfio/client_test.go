@@ -14,8 +14,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !windows && perm
-// +build !windows,perm
+//go:build linux && perm
+// +build linux,perm
 
 package vfio_test
 
@@ -33,12 +33,12 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
 )
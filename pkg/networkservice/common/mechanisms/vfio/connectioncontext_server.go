@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+type connectionContextServer struct{}
+
+// NewConnectionContextServer returns a new VFIO connection context server chain element. It copies the resulting
+// connection ethernet and IP context (MAC address, VLAN tag, IP addresses) into the VFIO mechanism parameters, so the
+// DPDK clients can configure VF without any out-of-band coordination.
+func NewConnectionContextServer() networkservice.NetworkServiceServer {
+	return &connectionContextServer{}
+}
+
+func (s *connectionContextServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if mech := vfio.ToMechanism(conn.GetMechanism()); mech != nil {
+		params := mech.GetParameters()
+		setParameter(params, MACAddressKey, conn.GetContext().GetEthernetContext().GetSrcMac())
+		setParameter(params, VLANTagKey, "")
+		if vlanTag := conn.GetContext().GetEthernetContext().GetVlanTag(); vlanTag != 0 {
+			setParameter(params, VLANTagKey, strconv.FormatInt(int64(vlanTag), 10))
+		}
+		setParameter(params, IPAddressesKey, strings.Join(conn.GetContext().GetIpContext().GetSrcIpAddrs(), ","))
+		setParameter(params, DstIPAddressesKey, strings.Join(conn.GetContext().GetIpContext().GetDstIpAddrs(), ","))
+	}
+
+	return conn, nil
+}
+
+func (s *connectionContextServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func setParameter(params map[string]string, key, value string) {
+	if value == "" {
+		delete(params, key)
+		return
+	}
+	params[key] = value
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+)
+
+func TestConnectionContextServer_Request(t *testing.T) {
+	server := vfio.NewConnectionContextServer()
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: vfiomech.MECHANISM,
+				Parameters: map[string]string{
+					vfio.VLANTagKey: "100",
+				},
+			},
+			Context: &networkservice.ConnectionContext{
+				EthernetContext: &networkservice.EthernetContext{
+					SrcMac: "0a:55:44:33:22:11",
+				},
+				IpContext: &networkservice.IPContext{
+					SrcIpAddrs: []string{"10.0.0.1/32", "fe80::1/128"},
+					DstIpAddrs: []string{"10.0.0.2/32"},
+				},
+			},
+		},
+	}
+
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		vfio.MACAddressKey:     "0a:55:44:33:22:11",
+		vfio.IPAddressesKey:    "10.0.0.1/32,fe80::1/128",
+		vfio.DstIPAddressesKey: "10.0.0.2/32",
+	}, conn.GetMechanism().GetParameters())
+
+	conn.GetContext().GetEthernetContext().VlanTag = 100
+	conn, err = server.Request(context.Background(), request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, "100", conn.GetMechanism().GetParameters()[vfio.VLANTagKey])
+}
@@ -14,11 +14,43 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !windows
-// +build !windows
-
 package vfio
 
 const (
-	vfioDevice = "vfio"
+	// MACAddressKey is a VF MAC address mechanism parameter key
+	MACAddressKey = "macAddress"
+	// VLANTagKey is a VF VLAN tag mechanism parameter key
+	VLANTagKey = "vlanTag"
+	// IPAddressesKey is a comma separated VF IP addresses (CIDR) mechanism parameter key
+	IPAddressesKey = "ipAddresses"
+	// DstIPAddressesKey is a comma separated peer IP addresses (CIDR) mechanism parameter key
+	DstIPAddressesKey = "dstIPAddresses"
+
+	// NoIOMMUKey is a mechanism parameter key set to "true" if the VFIO device is in the unsafe no-IOMMU mode
+	NoIOMMUKey = "noIOMMU"
+	// ContainerFDURLKey is a mechanism parameter key for the /dev/vfio/vfio container fd URL: inode://${dev}/${ino} sent
+	// with grpcfd by the server, file:///proc/self/fd/${fd} received by the client
+	ContainerFDURLKey = "vfioContainerFDURL"
+	// GroupFDURLKey is a mechanism parameter key for the /dev/vfio/${IOMMU group} fd URL: inode://${dev}/${ino} sent
+	// with grpcfd by the server, file:///proc/self/fd/${fd} received by the client
+	GroupFDURLKey = "vfioGroupFDURL"
+	// ExtraDeviceNumbersKey is a mechanism parameter key for the comma separated ${major}:${minor} device numbers of the
+	// extra IOMMU groups (resourcepool.IOMMUGroupsKey except the first one) granted to the client cgroup
+	ExtraDeviceNumbersKey = "vfioExtraDeviceNumbers"
+	// ExtraGroupFDURLsKey is a mechanism parameter key for the comma separated fd URLs of the extra IOMMU groups
+	// (resourcepool.IOMMUGroupsKey except the first one), see GroupFDURLKey
+	ExtraGroupFDURLsKey = "vfioExtraGroupFDURLs"
+	// MdevUUIDKey is a mechanism parameter key for the mediated device UUID, if set the server resolves the IOMMU group
+	// of the mediated device created on the allocated VF and sets it as the mechanism IOMMU group
+	MdevUUIDKey = "mdevUUID"
+	// UnsafeLabel is a connection label set to "true" for the VFIO connections in the unsafe no-IOMMU mode
+	UnsafeLabel = "vfioUnsafe"
+	// OrphanedGrantsReason is the reason of the event recorded for the orphaned connection grants denied by the
+	// reconciliation
+	OrphanedGrantsReason = "OrphanedGrantsDenied"
+
+	vfioDevice         = "vfio"
+	noIOMMUGroupPrefix = "noiommu-"
+
+	defaultNoIOMMUParameterPath = "/sys/module/vfio/parameters/enable_unsafe_noiommu_mode"
 )
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The server device access errors, the returned errors match them with errors.Is
+var (
+	// ErrNoIOMMUGroup is an error for the requested IOMMU group device missing on the node
+	ErrNoIOMMUGroup = errors.New("no IOMMU group device")
+	// ErrNoCgroup is an error for the client cgroup directory not set or not found
+	ErrNoCgroup = errors.New("no client cgroup directory")
+	// ErrCgroupWrite is an error for the device cgroup rule failed to be written
+	ErrCgroupWrite = errors.New("failed to write device cgroup rule")
+	// ErrAccessDenied is an error for the IOMMU group access denied to the client by the GroupACL
+	ErrAccessDenied = errors.New("IOMMU group access denied")
+)
+
+// deviceAccessError is an error matching its kind with errors.Is and keeping the cause message
+type deviceAccessError struct {
+	kind  error
+	cause error
+}
+
+func newDeviceAccessError(kind, cause error) error {
+	return &deviceAccessError{
+		kind:  kind,
+		cause: cause,
+	}
+}
+
+func (e *deviceAccessError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *deviceAccessError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *deviceAccessError) Unwrap() error {
+	return e.cause
+}
+
+// failureReason returns the metrics reason label value for the error
+func failureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrNoIOMMUGroup):
+		return "no_iommu_group"
+	case errors.Is(err, ErrNoCgroup):
+		return "no_cgroup"
+	case errors.Is(err, ErrCgroupWrite):
+		return "cgroup_write"
+	case errors.Is(err, ErrAccessDenied):
+		return "access_denied"
+	default:
+		return "other"
+	}
+}
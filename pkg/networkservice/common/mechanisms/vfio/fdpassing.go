@@ -0,0 +1,218 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+type deviceFiles struct {
+	container   *os.File
+	group       *os.File
+	extraGroups []*os.File
+}
+
+func (f *deviceFiles) close() {
+	for _, file := range append([]*os.File{f.container, f.group}, f.extraGroups...) {
+		if file != nil {
+			_ = file.Close()
+		}
+	}
+}
+
+func (s *vfioServer) passFDs(ctx context.Context, connID string, mech *vfio.Mechanism, igid string, extraGroups []string) error {
+	sender, ok := grpcfd.FromContext(ctx)
+	if !ok {
+		return errors.New("not able to pass VFIO fds over the connection: no grpcfd sender")
+	}
+
+	files, err := s.openFiles(connID, igid, extraGroups)
+	if err != nil {
+		return err
+	}
+
+	for key, file := range map[string]*os.File{
+		ContainerFDURLKey: files.container,
+		GroupFDURLKey:     files.group,
+	} {
+		inodeURL, err := sendFile(sender, file)
+		if err != nil {
+			s.closeFiles(connID)
+			return err
+		}
+		mech.GetParameters()[key] = inodeURL
+	}
+
+	var extraInodeURLs []string
+	for _, file := range files.extraGroups {
+		inodeURL, err := sendFile(sender, file)
+		if err != nil {
+			s.closeFiles(connID)
+			return err
+		}
+		extraInodeURLs = append(extraInodeURLs, inodeURL)
+	}
+	if extraInodeURLs != nil {
+		mech.GetParameters()[ExtraGroupFDURLsKey] = strings.Join(extraInodeURLs, ",")
+	}
+
+	return nil
+}
+
+func sendFile(sender grpcfd.FDSender, file *os.File) (string, error) {
+	inodeURL, err := grpcfd.FileToURL(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get inode URL for the file: %s", file.Name())
+	}
+	select {
+	case err := <-sender.SendFile(file):
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to send the file: %s", file.Name())
+		}
+	default:
+	}
+	return inodeURL.String(), nil
+}
+
+func (s *vfioServer) openFiles(connID, igid string, extraGroups []string) (*deviceFiles, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if files, ok := s.files[connID]; ok {
+		return files, nil
+	}
+
+	files := new(deviceFiles)
+	var err error
+	if files.container, err = os.OpenFile(filepath.Join(s.vfioDir, vfioDevice), os.O_RDWR, 0); err != nil {
+		return nil, errors.Wrapf(err, "failed to open the device: %s", vfioDevice)
+	}
+	for _, group := range append([]string{igid}, extraGroups...) {
+		file, err := os.OpenFile(filepath.Join(s.vfioDir, group), os.O_RDWR, 0)
+		if err != nil {
+			files.close()
+			err = errors.Wrapf(err, "failed to open the device: %s", group)
+			if os.IsNotExist(errors.Cause(err)) {
+				return nil, newDeviceAccessError(ErrNoIOMMUGroup, err)
+			}
+			return nil, err
+		}
+		if files.group == nil {
+			files.group = file
+		} else {
+			files.extraGroups = append(files.extraGroups, file)
+		}
+	}
+	s.files[connID] = files
+
+	return files, nil
+}
+
+func (s *vfioServer) closeFiles(connID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if files, ok := s.files[connID]; ok {
+		files.close()
+		delete(s.files, connID)
+	}
+}
+
+func (c *vfioClient) recvFiles(ctx context.Context, connID string, mech *vfio.Mechanism, recv grpcfd.FDRecver) error {
+	if recv == nil {
+		return errors.New("not able to receive VFIO fds over the connection: no grpcfd receiver")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if files, ok := c.files[connID]; ok {
+		files.close()
+		delete(c.files, connID)
+	}
+
+	files := new(deviceFiles)
+	var err error
+	if files.container, err = recvFile(ctx, recv, mech.GetParameters()[ContainerFDURLKey]); err != nil {
+		return err
+	}
+	if files.group, err = recvFile(ctx, recv, mech.GetParameters()[GroupFDURLKey]); err != nil {
+		files.close()
+		return err
+	}
+	if extraInodeURLs := mech.GetParameters()[ExtraGroupFDURLsKey]; extraInodeURLs != "" {
+		for _, inodeURL := range strings.Split(extraInodeURLs, ",") {
+			file, err := recvFile(ctx, recv, inodeURL)
+			if err != nil {
+				files.close()
+				return err
+			}
+			files.extraGroups = append(files.extraGroups, file)
+		}
+	}
+	if c.verifyDevices {
+		if err := verifyFiles(files); err != nil {
+			files.close()
+			return err
+		}
+	}
+	c.files[connID] = files
+
+	mech.GetParameters()[ContainerFDURLKey] = fileURL(files.container)
+	mech.GetParameters()[GroupFDURLKey] = fileURL(files.group)
+	if files.extraGroups != nil {
+		var extraFileURLs []string
+		for _, file := range files.extraGroups {
+			extraFileURLs = append(extraFileURLs, fileURL(file))
+		}
+		mech.GetParameters()[ExtraGroupFDURLsKey] = strings.Join(extraFileURLs, ",")
+	}
+
+	return nil
+}
+
+func recvFile(ctx context.Context, recv grpcfd.FDRecver, inodeURL string) (*os.File, error) {
+	fileCh, err := recv.RecvFileByURL(inodeURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive the file: %s", inodeURL)
+	}
+	select {
+	case file, ok := <-fileCh:
+		if !ok {
+			return nil, errors.Errorf("failed to receive the file: %s", inodeURL)
+		}
+		return file, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "failed to receive the file: %s", inodeURL)
+	}
+}
+
+func fileURL(file *os.File) string {
+	return (&url.URL{Scheme: "file", Path: file.Name()}).String()
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+)
+
+func TestVFIOServer_Request_FDPassingNoSender(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, vfioDevice), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, iommuGroupString), nil, 0o600))
+
+	server := vfio.NewServer(tmpDir, tmpDir, vfio.WithFDPassing())
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: vfiomech.MECHANISM,
+				Parameters: map[string]string{
+					vfiomech.IommuGroupKey: iommuGroupString,
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
+func testFDPassingServer(ctx context.Context, t *testing.T, serverDir string, extraGroups ...string) *grpc.ClientConn {
+	for _, device := range append([]string{vfioDevice, iommuGroupString}, extraGroups...) {
+		require.NoError(t, os.WriteFile(filepath.Join(serverDir, device), []byte(device), 0o600))
+	}
+
+	socketURL := &url.URL{
+		Scheme: "unix",
+		Path:   filepath.Join(serverDir, "server.socket"),
+	}
+
+	server := grpc.NewServer(grpc.Creds(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	networkservice.RegisterNetworkServiceServer(server, mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+		vfiomech.MECHANISM: chain.NewNetworkServiceServer(
+			&iommuGroupStub{extraGroups: extraGroups},
+			vfio.NewServer(serverDir, serverDir, vfio.WithFDPassing()),
+		),
+	}))
+	_ = grpcutils.ListenAndServe(ctx, socketURL, server)
+
+	<-time.After(1 * time.Millisecond) // wait for the server to start
+
+	cc, err := grpc.DialContext(ctx, socketURL.String(),
+		grpc.WithTransportCredentials(grpcfd.TransportCredentials(insecure.NewCredentials())))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+
+	return cc
+}
+
+func TestVFIOClient_Request_FDPassing(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clientDir := t.TempDir()
+	cc := testFDPassingServer(ctx, t, t.TempDir())
+
+	client := chain.NewNetworkServiceClient(
+		vfio.NewClient(vfio.WithVFIODir(clientDir), vfio.WithCgroupDir("cgroup_dir")),
+		networkservice.NewNetworkServiceClient(cc),
+	)
+
+	conn, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{},
+	})
+	require.NoError(t, err)
+
+	for key, data := range map[string]string{
+		vfio.ContainerFDURLKey: vfioDevice,
+		vfio.GroupFDURLKey:     iommuGroupString,
+	} {
+		fileURL, err := url.Parse(conn.GetMechanism().GetParameters()[key])
+		require.NoError(t, err)
+		require.Equal(t, "file", fileURL.Scheme)
+
+		content, err := os.ReadFile(fileURL.Path)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(content), data))
+	}
+
+	_, err = os.Stat(filepath.Join(clientDir, vfioDevice))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestVFIOClient_Request_FDPassingExtraGroups(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cc := testFDPassingServer(ctx, t, t.TempDir(), "4", "5")
+
+	client := chain.NewNetworkServiceClient(
+		vfio.NewClient(vfio.WithVFIODir(t.TempDir()), vfio.WithCgroupDir("cgroup_dir")),
+		networkservice.NewNetworkServiceClient(cc),
+	)
+
+	conn, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{},
+	})
+	require.NoError(t, err)
+
+	extraFileURLs := strings.Split(conn.GetMechanism().GetParameters()[vfio.ExtraGroupFDURLsKey], ",")
+	require.Len(t, extraFileURLs, 2)
+	for i, data := range []string{"4", "5"} {
+		fileURL, err := url.Parse(extraFileURLs[i])
+		require.NoError(t, err)
+		require.Equal(t, "file", fileURL.Scheme)
+
+		content, err := os.ReadFile(fileURL.Path)
+		require.NoError(t, err)
+		require.Equal(t, data, string(content))
+	}
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestVFIOClient_Request_FDPassingVerification(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cc := testFDPassingServer(ctx, t, t.TempDir())
+
+	client := chain.NewNetworkServiceClient(
+		vfio.NewClient(vfio.WithVFIODir(t.TempDir()), vfio.WithCgroupDir("cgroup_dir"), vfio.WithDeviceVerification()),
+		networkservice.NewNetworkServiceClient(cc),
+	)
+
+	// the server passes the regular files instead of the VFIO devices
+	_, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{},
+	})
+	require.ErrorContains(t, err, "VFIO container is not accessible")
+}
+
+type iommuGroupStub struct {
+	extraGroups []string
+}
+
+func (s *iommuGroupStub) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if mech := vfiomech.ToMechanism(request.GetConnection().GetMechanism()); mech != nil {
+		mech.GetParameters()[vfiomech.IommuGroupKey] = iommuGroupString
+		if s.extraGroups != nil {
+			mech.GetParameters()[resourcepool.IOMMUGroupsKey] = strings.Join(append([]string{iommuGroupString}, s.extraGroups...), ",")
+		}
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *iommuGroupStub) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
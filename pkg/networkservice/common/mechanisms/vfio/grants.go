@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const defaultGrantsReconcileDelay = 10 * time.Minute
+
+// grant is a device cgroup rule written by the server for the connection
+type grant struct {
+	CgroupDirPattern string `json:"cgroupDirPattern"`
+	Major            uint32 `json:"major"`
+	Minor            uint32 `json:"minor"`
+}
+
+func containsGrant(grants []*grant, g *grant) bool {
+	for _, item := range grants {
+		if *item == *g {
+			return true
+		}
+	}
+	return false
+}
+
+// setGrants allows the new connection grants and denies the old connection grants not present in grants
+// NOTE: s.lock should be held
+func (s *vfioServer) setGrants(logger log.Logger, connID string, grants []*grant) error {
+	oldGrants := s.grants[connID]
+
+	var allowed []*grant
+	for _, g := range grants {
+		if !containsGrant(oldGrants, g) {
+			if err := s.deviceAllow(g.CgroupDirPattern, g.Major, g.Minor); err != nil {
+				for _, a := range allowed {
+					_ = s.deviceDeny(a.CgroupDirPattern, a.Major, a.Minor)
+				}
+				return err
+			}
+			allowed = append(allowed, g)
+		}
+	}
+	for _, g := range oldGrants {
+		if !containsGrant(grants, g) {
+			if err := s.deviceDeny(g.CgroupDirPattern, g.Major, g.Minor); err != nil {
+				logger.Warnf("failed to deny device for the client: %d:%d", g.Major, g.Minor)
+			}
+		}
+	}
+
+	s.grants[connID] = grants
+	delete(s.restoredGrants, connID)
+	s.saveGrants(logger)
+
+	return nil
+}
+
+// clearGrants denies all the connection grants
+// NOTE: s.lock should be held
+func (s *vfioServer) clearGrants(logger log.Logger, connID string) {
+	grants, ok := s.grants[connID]
+	if !ok {
+		return
+	}
+
+	for _, g := range grants {
+		if err := s.deviceDeny(g.CgroupDirPattern, g.Major, g.Minor); err != nil {
+			logger.Warnf("failed to deny device for the client: %d:%d", g.Major, g.Minor)
+		}
+	}
+
+	delete(s.grants, connID)
+	delete(s.restoredGrants, connID)
+	s.saveGrants(logger)
+}
+
+// restoreGrants restores the grants written by the previous server instance and schedules the reconciliation of
+// them: grants of the connections not requested again until the reconciliation are considered orphaned and denied
+func (s *vfioServer) restoreGrants() {
+	logger := log.L().WithField("vfioServer", "restoreGrants")
+
+	data, err := os.ReadFile(filepath.Clean(s.grantsFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Errorf("failed to read grants file: %s", err.Error())
+		}
+		return
+	}
+
+	var grants map[string][]*grant
+	if err := json.Unmarshal(data, &grants); err != nil {
+		logger.Errorf("failed to unmarshal grants file %s: %s", s.grantsFile, err.Error())
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for connID, connGrants := range grants {
+		var restored []*grant
+		for _, g := range connGrants {
+			// the rule is already written, allowing it again restores the device counters
+			if err := s.deviceAllow(g.CgroupDirPattern, g.Major, g.Minor); err != nil {
+				logger.Warnf("dropping grant %s %d:%d of the connection %s: %s",
+					g.CgroupDirPattern, g.Major, g.Minor, connID, err.Error())
+				continue
+			}
+			restored = append(restored, g)
+		}
+		if len(restored) != 0 {
+			s.grants[connID] = restored
+			s.restoredGrants[connID] = struct{}{}
+		}
+	}
+	s.saveGrants(logger)
+
+	time.AfterFunc(s.reconcileDelay, s.reconcileGrants)
+}
+
+// reconcileGrants denies the restored grants of the connections not requested again since the restore
+func (s *vfioServer) reconcileGrants() {
+	logger := log.L().WithField("vfioServer", "reconcileGrants")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for connID := range s.restoredGrants {
+		logger.Infof("denying orphaned grants of the connection: %s", connID)
+		s.clearGrants(logger, connID)
+		if s.eventRecorder != nil {
+			s.eventRecorder.Normalf(OrphanedGrantsReason, "denied orphaned VFIO device grants of the connection: %s", connID)
+		}
+	}
+}
+
+// saveGrants atomically writes the grants into the grants file, if it is set
+// NOTE: s.lock should be held
+func (s *vfioServer) saveGrants(logger log.Logger) {
+	if s.grantsFile == "" {
+		return
+	}
+
+	if err := writeGrantsFile(s.grantsFile, s.grants); err != nil {
+		logger.Errorf("failed to save grants: %s", err.Error())
+	}
+}
+
+func writeGrantsFile(path string, grants map[string][]*grant) error {
+	data, err := json.Marshal(grants)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal grants")
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary grants file for: %s", path)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write temporary grants file: %s", tmpFile.Name())
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close temporary grants file: %s", tmpFile.Name())
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to replace grants file: %s", path)
+	}
+	return nil
+}
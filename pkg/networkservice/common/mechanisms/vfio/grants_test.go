@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+)
+
+const grantsCgroupDir = "cgroup"
+
+func testGrantsDirs(t *testing.T) (vfioDir, cgroupBaseDir string) {
+	vfioDir = filepath.Join(t.TempDir(), "vfio")
+	cgroupBaseDir = t.TempDir()
+
+	// /dev/null is 1:3, /dev/zero is 1:5
+	require.NoError(t, os.MkdirAll(vfioDir, 0o750))
+	require.NoError(t, os.Symlink("/dev/null", filepath.Join(vfioDir, vfioDevice)))
+	require.NoError(t, os.Symlink("/dev/zero", filepath.Join(vfioDir, iommuGroupString)))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(cgroupBaseDir, grantsCgroupDir), 0o750))
+	for _, name := range []string{"devices.list", "devices.allow", "devices.deny"} {
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupBaseDir, grantsCgroupDir, name), nil, 0o600))
+	}
+
+	return vfioDir, cgroupBaseDir
+}
+
+func readCgroupFile(t *testing.T, cgroupBaseDir, name string) string {
+	data, err := os.ReadFile(filepath.Join(cgroupBaseDir, grantsCgroupDir, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func grantsRequest(connID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: vfiomech.MECHANISM,
+				Parameters: map[string]string{
+					vfiomech.CgroupDirKey:  grantsCgroupDir,
+					vfiomech.IommuGroupKey: iommuGroupString,
+				},
+			},
+		},
+	}
+}
+
+func TestVFIOServer_Grants_Refresh(t *testing.T) {
+	vfioDir, cgroupBaseDir := testGrantsDirs(t)
+	grantsFile := filepath.Join(t.TempDir(), "grants.json")
+
+	server := vfio.NewServer(vfioDir, cgroupBaseDir, vfio.WithGrantsFile(grantsFile))
+
+	var conn *networkservice.Connection
+	for i := 0; i < 3; i++ {
+		var err error
+		conn, err = server.Request(context.Background(), grantsRequest("conn-1"))
+		require.NoError(t, err)
+	}
+	require.Contains(t, readCgroupFile(t, cgroupBaseDir, "devices.allow"), "c 1:5 ")
+
+	data, err := os.ReadFile(grantsFile)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "conn-1")
+
+	// refreshes are not counted, so the single Close denies the devices
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.NotEmpty(t, readCgroupFile(t, cgroupBaseDir, "devices.deny"))
+
+	data, err = os.ReadFile(grantsFile)
+	require.NoError(t, err)
+	require.Equal(t, "{}", string(data))
+}
+
+func TestVFIOServer_Grants_Reconcile(t *testing.T) {
+	vfioDir, cgroupBaseDir := testGrantsDirs(t)
+	grantsFile := filepath.Join(t.TempDir(), "grants.json")
+
+	crashed := vfio.NewServer(vfioDir, cgroupBaseDir, vfio.WithGrantsFile(grantsFile))
+	for _, connID := range []string{"conn-1", "conn-2"} {
+		_, err := crashed.Request(context.Background(), grantsRequest(connID))
+		require.NoError(t, err)
+	}
+
+	eventRecorder := &eventRecorderStub{
+		events: make(chan string, 1),
+	}
+	server := vfio.NewServer(vfioDir, cgroupBaseDir,
+		vfio.WithGrantsFile(grantsFile),
+		vfio.WithGrantsReconcileDelay(100*time.Millisecond),
+		vfio.WithEventRecorder(eventRecorder))
+
+	// conn-1 is requested again, conn-2 is orphaned
+	conn, err := server.Request(context.Background(), grantsRequest("conn-1"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(grantsFile)
+		require.NoError(t, err)
+		return !strings.Contains(string(data), "conn-2")
+	}, time.Second, 10*time.Millisecond)
+	// conn-1 still holds the devices
+	require.Empty(t, readCgroupFile(t, cgroupBaseDir, "devices.deny"))
+	require.Contains(t, <-eventRecorder.events, "conn-2")
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.NotEmpty(t, readCgroupFile(t, cgroupBaseDir, "devices.deny"))
+}
+
+type eventRecorderStub struct {
+	events chan string
+}
+
+func (r *eventRecorderStub) Normalf(_, format string, args ...interface{}) {
+	r.events <- fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+const (
+	mdevIOMMUGroup = "iommu_group"
+
+	defaultMdevDevicesPath = "/sys/bus/mdev/devices"
+)
+
+// resolveMdevIOMMUGroup sets the mechanism IOMMU group to the mediated device IOMMU group if the mechanism refers to a
+// mediated device with MdevUUIDKey, the mediated device should be created on the VF allocated for the connection
+func resolveMdevIOMMUGroup(devicesPath string, mech *vfio.Mechanism) error {
+	mdevUUID, ok := mech.GetParameters()[MdevUUIDKey]
+	if !ok {
+		return nil
+	}
+
+	if _, err := uuid.Parse(mdevUUID); err != nil {
+		return errors.Wrapf(err, "invalid mediated device UUID: %s", mdevUUID)
+	}
+
+	devicePath := filepath.Join(devicesPath, mdevUUID)
+	if _, err := os.Lstat(devicePath); err != nil {
+		return errors.Wrapf(err, "mediated device not found: %s", mdevUUID)
+	}
+
+	realDevicePath, err := filepath.EvalSymlinks(devicePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get parent device for the mediated device: %s", mdevUUID)
+	}
+	vfPCIAddr := mech.GetParameters()[common.PCIAddressKey]
+	if parent := filepath.Base(filepath.Dir(realDevicePath)); vfPCIAddr == "" || parent != vfPCIAddr {
+		return errors.Errorf("mediated device %s is not created on the allocated VF: %s", mdevUUID, vfPCIAddr)
+	}
+
+	groupPath := filepath.Join(devicePath, mdevIOMMUGroup)
+
+	realPath, err := filepath.EvalSymlinks(groupPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get IOMMU group for the mediated device: %s", mdevUUID)
+	}
+	mech.GetParameters()[vfio.IommuGroupKey] = filepath.Base(realPath)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+)
+
+const (
+	mdevIOMMUGroupString = "7"
+	mdevVFPCIAddr        = "0000:01:00.1"
+)
+
+func TestVFIOServer_Request_Mdev(t *testing.T) {
+	tmpDir := t.TempDir()
+	devicesPath := filepath.Join(tmpDir, "devices")
+	mdevUUID := uuid.NewString()
+	foreignMdevUUID := uuid.NewString()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "iommu_groups", mdevIOMMUGroupString), 0o750))
+	require.NoError(t, os.MkdirAll(devicesPath, 0o750))
+	for parent, mdevUUID := range map[string]string{
+		mdevVFPCIAddr:  mdevUUID,
+		"0000:01:00.2": foreignMdevUUID,
+	} {
+		mdevPath := filepath.Join(tmpDir, "pci", parent, mdevUUID)
+		require.NoError(t, os.MkdirAll(mdevPath, 0o750))
+		require.NoError(t, os.Symlink(
+			filepath.Join("..", "..", "..", "iommu_groups", mdevIOMMUGroupString),
+			filepath.Join(mdevPath, "iommu_group")))
+		require.NoError(t, os.Symlink(mdevPath, filepath.Join(devicesPath, mdevUUID)))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, vfioDevice), nil, 0o600))
+
+	server := vfio.NewServer(tmpDir, tmpDir, vfio.WithMdevDevicesPath(devicesPath))
+
+	request := func(mdevUUID string) error {
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Mechanism: &networkservice.Mechanism{
+					Cls:  cls.LOCAL,
+					Type: vfiomech.MECHANISM,
+					Parameters: map[string]string{
+						vfiomech.CgroupDirKey:  "*",
+						common.PCIAddressKey:   mdevVFPCIAddr,
+						vfiomech.IommuGroupKey: "1",
+						vfio.MdevUUIDKey:       mdevUUID,
+					},
+				},
+			},
+		})
+		return err
+	}
+
+	// the mediated device IOMMU group device is used
+	require.ErrorContains(t, request(mdevUUID), filepath.Join(tmpDir, mdevIOMMUGroupString)+" file status")
+
+	// the mediated devices created on the other devices are rejected
+	require.ErrorContains(t, request(foreignMdevUUID), "is not created on the allocated VF")
+
+	require.ErrorContains(t, request(uuid.NewString()), "mediated device not found")
+	require.ErrorContains(t, request("../"+mdevUUID), "invalid mediated device UUID")
+}
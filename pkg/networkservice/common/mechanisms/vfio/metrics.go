@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ljkiraly/sdk/pkg/tools/opentelemetry"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metricsmeta"
+)
+
+// Metrics names as exported by the OpenTelemetry Prometheus exporter
+const (
+	GroupsGrantedMetric   = "vfio_groups_granted_total"
+	GrantFailuresMetric   = "vfio_grant_failures_total"
+	CleanupDurationMetric = "vfio_cleanup_duration_seconds"
+
+	cgroupMode    = "cgroup"
+	fdPassingMode = "fd_passing"
+
+	modeAttribute   = "mode"
+	reasonAttribute = "reason"
+)
+
+var (
+	modeLabel = &metricsmeta.Label{Name: modeAttribute, Help: "client device access mode: cgroup or fd_passing"}
+
+	groupsGrantedMeta = &metricsmeta.Metric{
+		Name:   GroupsGrantedMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the IOMMU groups access granted to the clients",
+		Labels: []*metricsmeta.Label{modeLabel},
+	}
+	grantFailuresMeta = &metricsmeta.Metric{
+		Name: GrantFailuresMetric,
+		Type: metricsmeta.Counter,
+		Help: "Number of the IOMMU groups access failed to be granted to the clients by reason",
+		Labels: []*metricsmeta.Label{
+			modeLabel,
+			{Name: reasonAttribute, Help: "grant failure reason"},
+		},
+	}
+	cleanupDurationMeta = &metricsmeta.Metric{
+		Name:   CleanupDurationMetric,
+		Type:   metricsmeta.Histogram,
+		Help:   "Duration of the client device access cleanup",
+		Unit:   "seconds",
+		Labels: []*metricsmeta.Label{modeLabel},
+	}
+)
+
+// Metadata returns the descriptions of the server metrics
+func Metadata() []*metricsmeta.Metric {
+	return []*metricsmeta.Metric{
+		groupsGrantedMeta,
+		grantFailuresMeta,
+		cleanupDurationMeta,
+	}
+}
+
+// serverMetrics are the server device access metrics, all the methods are no-op if the metrics are disabled
+type serverMetrics struct {
+	groupsGranted   metric.Int64Counter
+	grantFailures   metric.Int64Counter
+	cleanupDuration metric.Float64Histogram
+}
+
+func newServerMetrics(meter metric.Meter) *serverMetrics {
+	if meter == nil {
+		if !opentelemetry.IsEnabled() {
+			return nil
+		}
+		meter = otel.Meter("")
+	}
+
+	m := new(serverMetrics)
+	var err error
+	if m.groupsGranted, err = meter.Int64Counter(groupsGrantedMeta.InstrumentName(),
+		metric.WithDescription(groupsGrantedMeta.Help)); err != nil {
+		return nil
+	}
+	if m.grantFailures, err = meter.Int64Counter(grantFailuresMeta.InstrumentName(),
+		metric.WithDescription(grantFailuresMeta.Help)); err != nil {
+		return nil
+	}
+	if m.cleanupDuration, err = meter.Float64Histogram(cleanupDurationMeta.InstrumentName(),
+		metric.WithDescription(cleanupDurationMeta.Help), metric.WithUnit("s")); err != nil {
+		return nil
+	}
+	return m
+}
+
+func (m *serverMetrics) granted(ctx context.Context, mode string) {
+	if m == nil {
+		return
+	}
+	m.groupsGranted.Add(ctx, 1, metric.WithAttributes(attribute.String(modeAttribute, mode)))
+}
+
+func (m *serverMetrics) failed(ctx context.Context, mode string, err error) {
+	if m == nil {
+		return
+	}
+	m.grantFailures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(modeAttribute, mode),
+		attribute.String(reasonAttribute, failureReason(err))))
+}
+
+func (m *serverMetrics) cleanedUp(ctx context.Context, mode string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.cleanupDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.String(modeAttribute, mode)))
+}
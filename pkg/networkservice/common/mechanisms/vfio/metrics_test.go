@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+)
+
+// collectMetrics returns the collected metrics by the names they are exported with
+func collectMetrics(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	names := map[string]string{}
+	for _, meta := range vfio.Metadata() {
+		names[meta.InstrumentName()] = meta.Name
+	}
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			name, ok := names[m.Name]
+			require.True(t, ok, m.Name)
+			metrics[name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func sumValue(t *testing.T, data metricdata.Aggregation, reason string) int64 {
+	sum, ok := data.(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	var value int64
+	for _, point := range sum.DataPoints {
+		if r, ok := point.Attributes.Value("reason"); ok && r.AsString() != reason {
+			continue
+		}
+		value += point.Value
+	}
+	return value
+}
+
+func TestVFIOServer_Metrics(t *testing.T) {
+	vfioDir, cgroupBaseDir := testGrantsDirs(t)
+
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("")
+
+	server := vfio.NewServer(vfioDir, cgroupBaseDir, vfio.WithMeter(meter))
+
+	conn, err := server.Request(context.Background(), grantsRequest("conn-1"))
+	require.NoError(t, err)
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	request := grantsRequest("conn-2")
+	request.GetConnection().GetMechanism().GetParameters()["iommuGroup"] = "2"
+	_, err = server.Request(context.Background(), request)
+	require.ErrorIs(t, err, vfio.ErrNoIOMMUGroup)
+
+	require.NoError(t, os.Remove(filepath.Join(cgroupBaseDir, grantsCgroupDir, "devices.allow")))
+	require.NoError(t, os.Mkdir(filepath.Join(cgroupBaseDir, grantsCgroupDir, "devices.allow"), 0o750))
+	_, err = server.Request(context.Background(), grantsRequest("conn-3"))
+	require.ErrorIs(t, err, vfio.ErrCgroupWrite)
+
+	metrics := collectMetrics(t, reader)
+	require.Equal(t, int64(1), sumValue(t, metrics[vfio.GroupsGrantedMetric], ""))
+	require.Equal(t, int64(1), sumValue(t, metrics[vfio.GrantFailuresMetric], "no_iommu_group"))
+	require.Equal(t, int64(1), sumValue(t, metrics[vfio.GrantFailuresMetric], "cgroup_write"))
+
+	histogram, ok := metrics[vfio.CleanupDurationMetric].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.NotEmpty(t, histogram.DataPoints)
+}
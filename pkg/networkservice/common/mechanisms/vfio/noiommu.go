@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+// enableNoIOMMUMode enables the vfio unsafe no-IOMMU mode with the module parameter if it is not enabled yet
+func enableNoIOMMUMode(parameterPath string) error {
+	if enabled, err := isNoIOMMUModeEnabled(parameterPath); err != nil || enabled {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Clean(parameterPath), []byte("Y"), 0o600); err != nil {
+		return errors.Wrapf(err, "failed to enable vfio no-IOMMU mode: %s", parameterPath)
+	}
+
+	switch enabled, err := isNoIOMMUModeEnabled(parameterPath); {
+	case err != nil:
+		return err
+	case !enabled:
+		return errors.Errorf("vfio no-IOMMU mode is not enabled: %s", parameterPath)
+	}
+	return nil
+}
+
+func isNoIOMMUModeEnabled(parameterPath string) (bool, error) {
+	data, err := os.ReadFile(filepath.Clean(parameterPath))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read vfio no-IOMMU mode parameter: %s", parameterPath)
+	}
+	switch strings.TrimSpace(string(data)) {
+	case "Y", "1":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func setNoIOMMU(conn *networkservice.Connection) {
+	vfio.ToMechanism(conn.GetMechanism()).GetParameters()[NoIOMMUKey] = "true"
+
+	if conn.GetLabels() == nil {
+		conn.Labels = map[string]string{}
+	}
+	conn.GetLabels()[UnsafeLabel] = "true"
+}
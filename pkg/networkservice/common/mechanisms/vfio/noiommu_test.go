@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vfio"
+)
+
+func TestVFIOServer_Request_NoIOMMU(t *testing.T) {
+	tmpDir := t.TempDir()
+	parameterPath := filepath.Join(tmpDir, "enable_unsafe_noiommu_mode")
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, vfioDevice), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, iommuGroupString), nil, 0o600))
+
+	server := vfio.NewServer(tmpDir, tmpDir,
+		vfio.WithNoIOMMUMode(),
+		vfio.WithNoIOMMUParameterPath(parameterPath))
+
+	request := func() error {
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Mechanism: &networkservice.Mechanism{
+					Cls:  cls.LOCAL,
+					Type: vfiomech.MECHANISM,
+					Parameters: map[string]string{
+						vfiomech.CgroupDirKey:  "*",
+						vfiomech.IommuGroupKey: iommuGroupString,
+					},
+				},
+			},
+		})
+		return err
+	}
+
+	// vfio module is not loaded
+	require.Error(t, request())
+
+	// no-IOMMU mode gets enabled, the noiommu- prefixed IOMMU group device is used
+	require.NoError(t, os.WriteFile(parameterPath, []byte("N\n"), 0o600))
+	require.ErrorContains(t, request(), "noiommu-"+iommuGroupString)
+
+	data, err := os.ReadFile(parameterPath)
+	require.NoError(t, err)
+	require.Equal(t, "Y", string(data))
+}
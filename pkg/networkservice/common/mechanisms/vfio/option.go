@@ -14,11 +14,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !windows
-// +build !windows
-
 package vfio
 
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
 // Option is an option for NewClient
 type Option func(c *vfioClient)
 
@@ -35,3 +40,107 @@ func WithCgroupDir(cgroupDir string) Option {
 		c.cgroupDir = cgroupDir
 	}
 }
+
+// WithCgroupPathResolvers sets vfioClient resolvers used to find out cgroupDir if it is not set, the cgroup package
+// default resolvers are used by default
+func WithCgroupPathResolvers(resolvers ...cgroup.PathResolver) Option {
+	return func(c *vfioClient) {
+		c.cgroupResolvers = resolvers
+	}
+}
+
+// WithNoIOMMU allows vfioClient to accept the VFIO devices in the unsafe no-IOMMU mode
+func WithNoIOMMU() Option {
+	return func(c *vfioClient) {
+		c.noIOMMU = true
+	}
+}
+
+// WithDeviceVerification makes vfioClient verify the received VFIO devices are accessible from the client mount and
+// cgroup context: the container API version is checked and the group is checked to be viable, so the Request fails
+// early instead of the VFIO device user failing later
+func WithDeviceVerification() Option {
+	return func(c *vfioClient) {
+		c.verifyDevices = true
+	}
+}
+
+// ServerOption is an option for NewServer
+type ServerOption func(s *vfioServer)
+
+// WithNoIOMMUMode enables the vfio-pci unsafe no-IOMMU mode for the nodes without a functional IOMMU: the mode
+// parameter is enabled if needed, the noiommu- prefixed IOMMU group devices are used and the connections are labeled
+// with UnsafeLabel
+func WithNoIOMMUMode() ServerOption {
+	return func(s *vfioServer) {
+		s.noIOMMU = true
+	}
+}
+
+// WithFDPassing makes the server open the VFIO container and IOMMU group devices itself and pass the open fds to the
+// client with grpcfd instead of allowing the devices in the client cgroup, so no device cgroup edits are needed.
+// NOTE: the fds are the open devices, so every hop between the server and the client should pass them as is, the
+// sendfd/recvfd chain elements re-opening the received files by path can't be used for them.
+func WithFDPassing() ServerOption {
+	return func(s *vfioServer) {
+		s.fdPassing = true
+	}
+}
+
+// WithMdevDevicesPath sets the mediated devices sysfs directory, "/sys/bus/mdev/devices" by default
+func WithMdevDevicesPath(devicesPath string) ServerOption {
+	return func(s *vfioServer) {
+		s.mdevDevicesPath = devicesPath
+	}
+}
+
+// WithGroupACL restricts the IOMMU groups access to the client identities (taken from the first path segment token
+// subject) allowed by the groupACL, see NewGroupACLFromFile for the policy file based GroupACL
+func WithGroupACL(groupACL GroupACL) ServerOption {
+	return func(s *vfioServer) {
+		s.groupACL = groupACL
+	}
+}
+
+// WithMeter sets the meter for the server metrics, by default the global meter is used if the opentelemetry is enabled
+func WithMeter(meter metric.Meter) ServerOption {
+	return func(s *vfioServer) {
+		s.meter = meter
+	}
+}
+
+// WithGrantsFile makes the server persist the device cgroup rules written for the connections into the grantsFile, so
+// the rules of the connections lost in the forwarder restart are denied by the restarted server after the
+// reconciliation delay unless the connections are requested again
+func WithGrantsFile(grantsFile string) ServerOption {
+	return func(s *vfioServer) {
+		s.grantsFile = grantsFile
+	}
+}
+
+// WithGrantsReconcileDelay sets how long the restored device cgroup rules wait to be requested again before they are
+// considered orphaned, 10 minutes by default
+func WithGrantsReconcileDelay(reconcileDelay time.Duration) ServerOption {
+	return func(s *vfioServer) {
+		s.reconcileDelay = reconcileDelay
+	}
+}
+
+// EventRecorder records the grants reconciliation corrections, e.g. as the forwarder pod Kubernetes Events
+type EventRecorder interface {
+	Normalf(reason, format string, args ...interface{})
+}
+
+// WithEventRecorder sets the recorder for the orphaned grants denied by the reconciliation
+func WithEventRecorder(eventRecorder EventRecorder) ServerOption {
+	return func(s *vfioServer) {
+		s.eventRecorder = eventRecorder
+	}
+}
+
+// WithNoIOMMUParameterPath sets the vfio module enable_unsafe_noiommu_mode parameter path
+func WithNoIOMMUParameterPath(parameterPath string) ServerOption {
+	return func(s *vfioServer) {
+		s.noIOMMUParameterPath = parameterPath
+	}
+}
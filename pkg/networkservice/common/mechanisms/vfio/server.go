@@ -16,8 +16,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !windows
-// +build !windows
+//go:build linux
+// +build linux
 
 // Package vfio provides server, vfioClient chain elements for the VFIO mechanism connection
 package vfio
@@ -26,93 +26,214 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
 	"golang.org/x/sys/unix"
 
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
 )
 
 type vfioServer struct {
-	vfioDir        string
-	cgroupBaseDir  string
-	deviceCounters map[string]int
-	lock           sync.Mutex
+	vfioDir              string
+	cgroupBaseDir        string
+	noIOMMU              bool
+	noIOMMUParameterPath string
+	fdPassing            bool
+	mdevDevicesPath      string
+	groupACL             GroupACL
+	meter                metric.Meter
+	metrics              *serverMetrics
+	files                map[string]*deviceFiles
+	grantsFile           string
+	reconcileDelay       time.Duration
+	eventRecorder        EventRecorder
+	grants               map[string][]*grant
+	restoredGrants       map[string]struct{}
+	deviceCounters       map[string]int
+	lock                 sync.Mutex
 }
 
 // NewServer returns a new VFIO server chain element
-func NewServer(vfioDir, cgroupBaseDir string) networkservice.NetworkServiceServer {
-	return &vfioServer{
-		vfioDir:        vfioDir,
-		cgroupBaseDir:  cgroupBaseDir,
-		deviceCounters: map[string]int{},
+func NewServer(vfioDir, cgroupBaseDir string, options ...ServerOption) networkservice.NetworkServiceServer {
+	s := &vfioServer{
+		vfioDir:              vfioDir,
+		cgroupBaseDir:        cgroupBaseDir,
+		noIOMMUParameterPath: defaultNoIOMMUParameterPath,
+		mdevDevicesPath:      defaultMdevDevicesPath,
+		files:                map[string]*deviceFiles{},
+		reconcileDelay:       defaultGrantsReconcileDelay,
+		grants:               map[string][]*grant{},
+		restoredGrants:       map[string]struct{}{},
+		deviceCounters:       map[string]int{},
+	}
+	for _, option := range options {
+		option(s)
 	}
+	s.metrics = newServerMetrics(s.meter)
+	if s.grantsFile != "" {
+		s.restoreGrants()
+	}
+	return s
 }
 
 func (s *vfioServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	logger := log.FromContext(ctx).WithField("vfioServer", "Request")
 
+	var registered bool
 	if mech := vfio.ToMechanism(request.GetConnection().GetMechanism()); mech != nil {
-		if mech.GetCgroupDir() == "" {
-			return nil, errors.New("expected client cgroup directory set")
-		}
-
-		vfioMajor, vfioMinor, err := s.getDeviceNumbers(filepath.Join(s.vfioDir, vfioDevice))
-		if err != nil {
-			logger.Errorf("failed to get device numbers for the device: %v", vfioDevice)
+		if err := s.grant(ctx, logger, request.GetConnection(), mech); err != nil {
+			s.metrics.failed(ctx, s.mode(), err)
 			return nil, err
 		}
+		s.metrics.granted(ctx, s.mode())
 
-		igid := mech.GetParameters()[vfio.IommuGroupKey]
-		deviceMajor, deviceMinor, err := s.getDeviceNumbers(filepath.Join(s.vfioDir, igid))
-		if err != nil {
-			logger.Errorf("failed to get device numbers for the device: %v", igid)
-			return nil, err
-		}
-
-		cgroupDirPattern := filepath.Join(s.cgroupBaseDir, mech.GetCgroupDir())
-
-		if err := func() error {
-			s.lock.Lock()
-			defer s.lock.Unlock()
-
-			if err := s.deviceAllow(cgroupDirPattern, vfioMajor, vfioMinor); err != nil {
-				logger.Errorf("failed to allow device for the client: %v", vfioDevice)
-				return err
-			}
-			mech.SetVfioMajor(vfioMajor)
-			mech.SetVfioMinor(vfioMinor)
-
-			if err := s.deviceAllow(cgroupDirPattern, deviceMajor, deviceMinor); err != nil {
-				logger.Errorf("failed to allow device for the client: %v", igid)
-				_ = s.deviceDeny(cgroupDirPattern, vfioMajor, vfioMinor)
-				return err
-			}
-			mech.SetDeviceMajor(deviceMajor)
-			mech.SetDeviceMinor(deviceMinor)
-
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
+		grantedConn := request.GetConnection()
+		registered = cleanup.Register(ctx, s, func(ctx context.Context) {
+			s.close(ctx, grantedConn)
+		})
 	}
 
 	conn, err := next.Server(ctx).Request(ctx, request)
 	if err != nil {
-		s.close(ctx, request.GetConnection())
+		if !registered {
+			s.close(ctx, request.GetConnection())
+		}
 		return nil, err
 	}
 
 	return conn, nil
 }
 
+func (s *vfioServer) grant(ctx context.Context, logger log.Logger, conn *networkservice.Connection, mech *vfio.Mechanism) (err error) {
+	ctx, span := tracing.Start(ctx, "vfio/grant", tracing.VFIOModeKey.String(s.mode()),
+		tracing.PCIAddressKey.String(mech.GetParameters()[common.PCIAddressKey]))
+	defer func() { tracing.End(span, err) }()
+	defer stages.Observe(ctx, stages.CgroupGrant, time.Now())
+
+	if err := resolveMdevIOMMUGroup(s.mdevDevicesPath, mech); err != nil {
+		return err
+	}
+
+	igid := mech.GetParameters()[vfio.IommuGroupKey]
+	span.SetAttributes(tracing.IOMMUGroupKey.String(igid))
+	if err := checkGroupACL(ctx, s.groupACL, conn.GetPath(), igid); err != nil {
+		return err
+	}
+	extraGroups := extraIOMMUGroups(mech)
+	for _, extraGroup := range extraGroups {
+		if err := checkGroupACL(ctx, s.groupACL, conn.GetPath(), extraGroup); err != nil {
+			return err
+		}
+	}
+	if s.noIOMMU {
+		if err := enableNoIOMMUMode(s.noIOMMUParameterPath); err != nil {
+			return err
+		}
+		igid = noIOMMUGroupPrefix + igid
+		for i := range extraGroups {
+			extraGroups[i] = noIOMMUGroupPrefix + extraGroups[i]
+		}
+	}
+
+	if s.fdPassing {
+		err = s.passFDs(ctx, conn.GetId(), mech, igid, extraGroups)
+	} else {
+		err = s.allowDevices(logger, conn.GetId(), mech, igid, extraGroups)
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.noIOMMU {
+		setNoIOMMU(conn)
+	}
+	return nil
+}
+
+// extraIOMMUGroups returns the IOMMU groups of the VFs selected for the connection tokens other than the first one, see
+// resourcepool.TokenIDsKey
+func extraIOMMUGroups(mech *vfio.Mechanism) []string {
+	iommuGroups := resourcepool.IOMMUGroups(mech.Mechanism)
+	if len(iommuGroups) < 2 {
+		return nil
+	}
+	return iommuGroups[1:]
+}
+
+func (s *vfioServer) mode() string {
+	if s.fdPassing {
+		return fdPassingMode
+	}
+	return cgroupMode
+}
+
+func (s *vfioServer) allowDevices(logger log.Logger, connID string, mech *vfio.Mechanism, igid string, extraGroups []string) error {
+	if mech.GetCgroupDir() == "" {
+		return newDeviceAccessError(ErrNoCgroup, errors.New("expected client cgroup directory set"))
+	}
+
+	vfioMajor, vfioMinor, err := s.getDeviceNumbers(filepath.Join(s.vfioDir, vfioDevice))
+	if err != nil {
+		logger.Errorf("failed to get device numbers for the device: %v", vfioDevice)
+		return err
+	}
+
+	deviceMajor, deviceMinor, err := s.getDeviceNumbers(filepath.Join(s.vfioDir, igid))
+	if err != nil {
+		logger.Errorf("failed to get device numbers for the device: %v", igid)
+		return newDeviceAccessError(ErrNoIOMMUGroup, err)
+	}
+
+	cgroupDirPattern := filepath.Join(s.cgroupBaseDir, mech.GetCgroupDir())
+	grants := []*grant{
+		{CgroupDirPattern: cgroupDirPattern, Major: vfioMajor, Minor: vfioMinor},
+		{CgroupDirPattern: cgroupDirPattern, Major: deviceMajor, Minor: deviceMinor},
+	}
+
+	var extraDeviceNumbers []string
+	for _, extraGroup := range extraGroups {
+		extraMajor, extraMinor, err := s.getDeviceNumbers(filepath.Join(s.vfioDir, extraGroup))
+		if err != nil {
+			logger.Errorf("failed to get device numbers for the device: %v", extraGroup)
+			return newDeviceAccessError(ErrNoIOMMUGroup, err)
+		}
+		grants = append(grants, &grant{CgroupDirPattern: cgroupDirPattern, Major: extraMajor, Minor: extraMinor})
+		extraDeviceNumbers = append(extraDeviceNumbers, fmt.Sprintf("%d:%d", extraMajor, extraMinor))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.setGrants(logger, connID, grants); err != nil {
+		logger.Errorf("failed to allow devices for the client: %v, %v", vfioDevice, igid)
+		return err
+	}
+	mech.SetVfioMajor(vfioMajor)
+	mech.SetVfioMinor(vfioMinor)
+	mech.SetDeviceMajor(deviceMajor)
+	mech.SetDeviceMinor(deviceMinor)
+	if extraDeviceNumbers != nil {
+		mech.GetParameters()[ExtraDeviceNumbersKey] = strings.Join(extraDeviceNumbers, ",")
+	}
+
+	return nil
+}
+
 func (s *vfioServer) getDeviceNumbers(deviceFile string) (major, minor uint32, err error) {
 	info := new(unix.Stat_t)
 	if err := unix.Stat(deviceFile, info); err != nil {
@@ -122,28 +243,29 @@ func (s *vfioServer) getDeviceNumbers(deviceFile string) (major, minor uint32, e
 }
 
 func (s *vfioServer) deviceAllow(cgroupDirPattern string, major, minor uint32) error {
-	cgroups, err := cgroup.NewCgroups(cgroupDirPattern)
+	cgroups, err := cgroup.NewDeviceControllers(cgroupDirPattern)
 	if err != nil || len(cgroups) == 0 {
-		return errors.Wrapf(err, "no cgroupDir found: %s", cgroupDirPattern)
+		return newDeviceAccessError(ErrNoCgroup, errors.Errorf("no cgroupDir found: %s", cgroupDirPattern))
 	}
 
+	rule := cgroup.NewRule(cgroup.CharDevice, major, minor, 'r', 'w', 'm')
 	for _, cg := range cgroups {
-		isWider, err := cg.IsWiderThan(major, minor)
+		_, isWider, err := cg.CompareRule(rule)
 		if err != nil {
-			return err
+			return newDeviceAccessError(ErrCgroupWrite, err)
 		}
 		if isWider {
 			continue
 		}
 
-		key := deviceKey(cg.Path, major, minor)
+		key := deviceKey(cg.Dir(), major, minor)
 		if counter, ok := s.deviceCounters[key]; ok && counter > 0 {
 			s.deviceCounters[key] = counter + 1
-			return nil
+			continue
 		}
 
-		if err := cg.Allow(major, minor); err != nil {
-			return err
+		if err := cg.AllowRule(rule); err != nil {
+			return newDeviceAccessError(ErrCgroupWrite, err)
 		}
 
 		s.deviceCounters[key] = 1
@@ -154,6 +276,7 @@ func (s *vfioServer) deviceAllow(cgroupDirPattern string, major, minor uint32) e
 
 func (s *vfioServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	s.close(ctx, conn)
+	cleanup.Unregister(ctx, s)
 
 	if _, err := next.Server(ctx).Close(ctx, conn); err != nil {
 		return nil, err
@@ -164,38 +287,31 @@ func (s *vfioServer) Close(ctx context.Context, conn *networkservice.Connection)
 func (s *vfioServer) close(ctx context.Context, conn *networkservice.Connection) {
 	logger := log.FromContext(ctx).WithField("vfioServer", "close")
 
-	if mech := vfio.ToMechanism(conn.GetMechanism()); mech != nil {
-		cgroupDirPattern := filepath.Join(s.cgroupBaseDir, mech.GetCgroupDir())
+	ctx, span := tracing.Start(ctx, "vfio/revoke", tracing.VFIOModeKey.String(s.mode()),
+		tracing.IOMMUGroupKey.String(conn.GetMechanism().GetParameters()[vfio.IommuGroupKey]))
+	defer span.End()
 
-		s.lock.Lock()
-		defer s.lock.Unlock()
-
-		vfioMajor := mech.GetVfioMajor()
-		vfioMinor := mech.GetVfioMinor()
-		if !(vfioMajor == 0 && vfioMinor == 0) {
-			if err := s.deviceDeny(cgroupDirPattern, vfioMajor, vfioMinor); err != nil {
-				logger.Warnf("failed to deny device for the client: %v", vfioDevice)
-			}
-		}
+	defer s.metrics.cleanedUp(ctx, s.mode(), time.Now())
 
-		deviceMajor := mech.GetDeviceMajor()
-		deviceMinor := mech.GetDeviceMinor()
-		if !(deviceMajor == 0 && deviceMinor == 0) {
-			if err := s.deviceDeny(cgroupDirPattern, deviceMajor, deviceMinor); err != nil {
-				logger.Warnf("failed to deny device for the client: %v", mech.GetIommuGroup())
-			}
-		}
+	if s.fdPassing {
+		s.closeFiles(conn.GetId())
+		return
 	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.clearGrants(logger, conn.GetId())
 }
 
 func (s *vfioServer) deviceDeny(cgroupDirPattern string, major, minor uint32) error {
-	cgroups, err := cgroup.NewCgroups(cgroupDirPattern)
+	cgroups, err := cgroup.NewDeviceControllers(cgroupDirPattern)
 	if err != nil || len(cgroups) == 0 {
 		return errors.Wrapf(err, "no cgroupDir found: %s", cgroupDirPattern)
 	}
 
 	for _, cg := range cgroups {
-		isWider, err := cg.IsWiderThan(major, minor)
+		_, isWider, err := cg.CompareRule(cgroup.NewRule(cgroup.CharDevice, major, minor, 'r', 'w', 'm'))
 		if err != nil {
 			return err
 		}
@@ -203,13 +319,14 @@ func (s *vfioServer) deviceDeny(cgroupDirPattern string, major, minor uint32) er
 			continue
 		}
 
-		key := deviceKey(cg.Path, major, minor)
+		key := deviceKey(cg.Dir(), major, minor)
 		s.deviceCounters[key]--
 		if s.deviceCounters[key] > 0 {
-			return nil
+			continue
 		}
+		delete(s.deviceCounters, key)
 
-		if err := cg.Deny(major, minor); err != nil {
+		if err := cg.DenyRule(cgroup.NewRule(cgroup.CharDevice, major, minor, 'r', 'w')); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfio
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// verifyFiles checks the container and group files are the accessible VFIO devices
+func verifyFiles(files *deviceFiles) error {
+	if err := verifyContainer(files.container); err != nil {
+		return errors.Wrap(err, "VFIO container is not accessible")
+	}
+	for _, group := range append([]*os.File{files.group}, files.extraGroups...) {
+		if err := verifyGroup(group); err != nil {
+			return errors.Wrap(err, "VFIO group is not accessible")
+		}
+	}
+	return nil
+}
+
+// verifyDevices checks the container and group devices created in the vfioDir are accessible from the client mount
+// and cgroup context
+func verifyDevices(vfioDir, igid string) error {
+	files := new(deviceFiles)
+	defer files.close()
+
+	var err error
+	if files.container, err = os.OpenFile(filepath.Join(vfioDir, vfioDevice), os.O_RDWR, 0); err != nil {
+		return errors.Wrapf(err, "VFIO container is not accessible: %s", vfioDevice)
+	}
+	if files.group, err = os.OpenFile(filepath.Join(vfioDir, igid), os.O_RDWR, 0); err != nil {
+		return errors.Wrapf(err, "VFIO group is not accessible: %s", igid)
+	}
+	return verifyFiles(files)
+}
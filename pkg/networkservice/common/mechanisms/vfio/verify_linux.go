@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfio
+
+import (
+	"os"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// linux/vfio.h
+const (
+	vfioAPIVersion       = 0
+	vfioGetAPIVersion    = 0x3b64 // _IO(VFIO_TYPE, VFIO_BASE + 0)
+	vfioGroupGetStatus   = 0x3b67 // _IO(VFIO_TYPE, VFIO_BASE + 3)
+	vfioGroupFlagsViable = 1 << 0
+)
+
+type vfioGroupStatus struct {
+	argsz uint32
+	flags uint32
+}
+
+// verifyContainer checks the file is a VFIO container supporting the VFIO API version
+func verifyContainer(file *os.File) error {
+	version, err := unix.IoctlRetInt(int(file.Fd()), vfioGetAPIVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VFIO API version: %s", file.Name())
+	}
+	if version != vfioAPIVersion {
+		return errors.Errorf("unsupported VFIO API version %d: %s", version, file.Name())
+	}
+	return nil
+}
+
+// verifyGroup checks the file is a viable VFIO group: all the group devices are bound to the VFIO drivers
+func verifyGroup(file *os.File) error {
+	status := &vfioGroupStatus{argsz: uint32(unsafe.Sizeof(vfioGroupStatus{}))}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, file.Fd(), vfioGroupGetStatus, uintptr(unsafe.Pointer(status))); errno != 0 {
+		return errors.Wrapf(errno, "failed to get VFIO group status: %s", file.Name())
+	}
+	if status.flags&vfioGroupFlagsViable == 0 {
+		return errors.Errorf("VFIO group is not viable: %s", file.Name())
+	}
+	return nil
+}
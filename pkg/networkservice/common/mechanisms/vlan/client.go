@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+type vlanClient struct {
+	auditor Auditor
+	tagger  VFTagger
+}
+
+// NewClient returns a new VLAN client chain element. It requests the remote VLAN mechanism and tags the selected VF
+// with the VLAN ID:
+//   - allocated by the remote forwarder - for the VF selected for the client by the server chain
+//   - allocated by the VLAN server - for the VF selected for the endpoint by the following client chain elements
+func NewClient(options ...Option) networkservice.NetworkServiceClient {
+	c := &vlanClient{
+		tagger: &vfConfigTagger{configurator: sriovvfconfig.NewNetlink()},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func (c *vlanClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	request.MechanismPreferences = append(request.MechanismPreferences,
+		&networkservice.Mechanism{
+			Cls:  cls.REMOTE,
+			Type: vlanmech.MECHANISM,
+		},
+	)
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	vlanID, ok := loadVLANID(ctx)
+	if mech := vlanmech.ToMechanism(conn.GetMechanism()); mech != nil {
+		vlanID, ok = mech.GetVlanID(), true
+	}
+	vfConfig, vfExists := loadVFConfig(ctx)
+	if !ok || !vfExists {
+		return conn, nil
+	}
+
+	if err := c.tagger.TagVF(vfConfig, vlanID); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := next.Client(ctx).Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+	_, tagged := metadata.Map(ctx, true).Load(taggedVFKey{})
+	metadata.Map(ctx, true).Store(taggedVFKey{}, vfConfig)
+	if !tagged {
+		c.audit(ctx, conn, audit.VLANSet, vfConfig, vlanID)
+	}
+
+	return conn, nil
+}
+
+func (c *vlanClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	logger := log.FromContext(ctx).WithField("vlanClient", "Close")
+
+	if rawValue, ok := metadata.Map(ctx, true).LoadAndDelete(taggedVFKey{}); ok {
+		vfConfig := rawValue.(*vfconfig.VFConfig)
+		if err := c.tagger.TagVF(vfConfig, 0); err != nil {
+			logger.Warnf("failed to untag VF: %s", err.Error())
+		} else {
+			c.audit(ctx, conn, audit.VLANCleared, vfConfig, 0)
+		}
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *vlanClient) audit(ctx context.Context, conn *networkservice.Connection, action audit.Action, vfConfig *vfconfig.VFConfig, vlanID uint32) {
+	if c.auditor == nil {
+		return
+	}
+	c.auditor.Record(ctx, conn, &audit.Record{
+		Action: action,
+		Device: fmt.Sprintf("%s vf %d", vfConfig.PFInterfaceName, vfConfig.VFNum),
+		VLANID: vlanID,
+	})
+}
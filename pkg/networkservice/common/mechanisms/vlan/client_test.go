@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vlan"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+func TestVLANClient_Request(t *testing.T) {
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		vlan.NewClient(),
+		checkrequest.NewClient(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.Len(t, request.GetMechanismPreferences(), 1)
+			require.Equal(t, vlanmech.MECHANISM, request.GetMechanismPreferences()[0].GetType())
+			require.Equal(t, cls.REMOTE, request.GetMechanismPreferences()[0].GetCls())
+		}),
+	)
+
+	conn, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
+
+func TestVLANClient_Request_TagVF(t *testing.T) {
+	fake := sriovvfconfig.NewFake(2, "eth0")
+
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		vlan.NewClient(vlan.WithVFConfigurator(fake)),
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			vfconfig.Store(ctx, true, &vfconfig.VFConfig{
+				PFInterfaceName: "eth0",
+				VFNum:           1,
+			})
+		}),
+	)
+
+	conn, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Cls:        cls.REMOTE,
+				Type:       vlanmech.MECHANISM,
+				Parameters: map[string]string{vlanmech.ID: "100"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	vf, err := fake.VF("eth0", 1)
+	require.NoError(t, err)
+	require.Equal(t, 100, vf.VLAN)
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	vf, err = fake.VF("eth0", 1)
+	require.NoError(t, err)
+	require.Zero(t, vf.VLAN)
+}
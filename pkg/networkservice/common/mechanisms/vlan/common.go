@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vlan provides chain elements for the VLAN remote mechanism stitching SR-IOV connections between forwarders on
+// different nodes by the VF VLAN tagging on the PF uplinks
+package vlan
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+type vlanIDKey struct{}
+
+type taggedVFKey struct{}
+
+func storeVLANID(ctx context.Context, vlanID uint32) {
+	metadata.Map(ctx, false).Store(vlanIDKey{}, vlanID)
+}
+
+func loadVLANID(ctx context.Context) (uint32, bool) {
+	rawValue, ok := metadata.Map(ctx, false).Load(vlanIDKey{})
+	if !ok {
+		return 0, false
+	}
+	vlanID, ok := rawValue.(uint32)
+	return vlanID, ok
+}
+
+func deleteVLANID(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(vlanIDKey{})
+}
+
+// loadVFConfig returns the VF selected for the endpoint by the client chain or the VF selected for the client by the
+// server chain
+func loadVFConfig(ctx context.Context) (*vfconfig.VFConfig, bool) {
+	if vfConfig, ok := vfconfig.Load(ctx, true); ok {
+		return vfConfig, true
+	}
+	return vfconfig.Load(ctx, false)
+}
+
+// vfConfigTagger is a VFTagger setting the VF VLAN on the PF net interface
+type vfConfigTagger struct {
+	configurator sriovvfconfig.Configurator
+}
+
+func (t *vfConfigTagger) TagVF(vfConfig *vfconfig.VFConfig, vlanID uint32) error {
+	return t.configurator.SetVLAN(vfConfig.PFInterfaceName, vfConfig.VFNum, int(vlanID), 0)
+}
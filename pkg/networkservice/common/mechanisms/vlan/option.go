@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// Auditor is an audit.Auditor interface
+type Auditor interface {
+	Record(ctx context.Context, conn *networkservice.Connection, record *audit.Record)
+}
+
+// VFTagger tags the VF traffic with the VLAN ID, vlanID == 0 removes the tagging
+type VFTagger interface {
+	TagVF(vfConfig *vfconfig.VFConfig, vlanID uint32) error
+}
+
+// Option is an option for the VLAN client
+type Option func(c *vlanClient)
+
+// WithAuditor sets the auditor recording the VF VLAN tagging and untagging into the audit trail
+func WithAuditor(auditor Auditor) Option {
+	return func(c *vlanClient) {
+		c.auditor = auditor
+	}
+}
+
+// WithVFTagger sets the VF tagger, the VF VLAN is set with netlink by default
+func WithVFTagger(tagger VFTagger) Option {
+	return func(c *vlanClient) {
+		c.tagger = tagger
+	}
+}
+
+// WithVFConfigurator sets the VF configurator setting the VF VLAN for the default VF tagger, netlink by default
+func WithVFConfigurator(configurator sriovvfconfig.Configurator) Option {
+	return func(c *vlanClient) {
+		c.tagger = &vfConfigTagger{configurator: configurator}
+	}
+}
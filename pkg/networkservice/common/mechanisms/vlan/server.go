@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+)
+
+type vlanServer struct {
+	vlanPool VLANPool
+}
+
+// NewServer returns a new VLAN server chain element allocating VLAN IDs for the remote VLAN mechanism connections
+func NewServer(vlanPool VLANPool) networkservice.NetworkServiceServer {
+	return &vlanServer{
+		vlanPool: vlanPool,
+	}
+}
+
+func (s *vlanServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mech := vlanmech.ToMechanism(request.GetConnection().GetMechanism())
+	if mech == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	_, isEstablished := loadVLANID(ctx)
+
+	connID := request.GetConnection().GetId()
+	vlanID, err := s.vlanPool.Allocate(connID)
+	if err != nil {
+		return nil, err
+	}
+	mech.SetVlanID(vlanID)
+	storeVLANID(ctx, vlanID)
+
+	var registered bool
+	if !isEstablished {
+		registered = cleanup.Register(ctx, s, func(ctx context.Context) {
+			deleteVLANID(ctx)
+			s.vlanPool.Free(connID)
+		})
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !isEstablished && !registered {
+		deleteVLANID(ctx)
+		s.vlanPool.Free(connID)
+	}
+
+	return conn, err
+}
+
+func (s *vlanServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if _, ok := loadVLANID(ctx); ok {
+		deleteVLANID(ctx)
+		s.vlanPool.Free(conn.GetId())
+	}
+	cleanup.Unregister(ctx, s)
+
+	return rv, err
+}
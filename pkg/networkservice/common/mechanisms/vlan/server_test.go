@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/mechanisms/vlan"
+	vlanpool "github.com/ljkiraly/sdk-sriov/pkg/sriov/vlan"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.REMOTE,
+				Type: vlanmech.MECHANISM,
+			},
+		},
+	}
+}
+
+func TestVLANServer_Request(t *testing.T) {
+	pool, err := vlanpool.NewPool(100, 100)
+	require.NoError(t, err)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vlan.NewServer(pool),
+	)
+
+	conn, err := server.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanmech.ToMechanism(conn.GetMechanism()).GetVlanID())
+
+	request := newRequest()
+	request.GetConnection().Id = "id-2"
+	_, err = server.Request(context.Background(), request)
+	require.Error(t, err)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), request)
+	require.NoError(t, err)
+}
+
+func TestVLANServer_RequestFailed(t *testing.T) {
+	pool, err := vlanpool.NewPool(100, 100)
+	require.NoError(t, err)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vlan.NewServer(pool),
+		injecterror.NewServer(injecterror.WithError(errors.New("error"))),
+	)
+
+	_, err = server.Request(context.Background(), newRequest())
+	require.Error(t, err)
+
+	_, err = pool.Allocate("id-2")
+	require.NoError(t, err)
+}
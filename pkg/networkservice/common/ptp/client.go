@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ptp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+	ptpdev "github.com/ljkiraly/sdk-sriov/pkg/tools/ptp"
+)
+
+const mknodPerm = 0o666
+
+type ptpClient struct {
+	devDir          string
+	cgroupDir       string
+	cgroupResolvers []cgroup.PathResolver
+	devices         map[string]string // devices[connID] -> created device file path
+	lock            sync.Mutex
+}
+
+// NewClient returns a new PTP client chain element requesting the PF PHC for the connection and creating the granted
+// PHC char device in the client dev directory, the device is not created if it is granted with CDI
+func NewClient(options ...Option) networkservice.NetworkServiceClient {
+	c := &ptpClient{
+		devDir:  ptpdev.DefaultDevDir,
+		devices: map[string]string{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.cgroupDir == "" {
+		var err error
+		if c.cgroupDir, err = cgroup.DirPath(c.cgroupResolvers...); err != nil {
+			return injecterror.NewClient(injecterror.WithError(err))
+		}
+	}
+
+	return c
+}
+
+func (c *ptpClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if request.GetConnection().GetLabels() == nil {
+		request.GetConnection().Labels = map[string]string{}
+	}
+	request.GetConnection().GetLabels()[Label] = "true"
+
+	for _, preference := range request.GetMechanismPreferences() {
+		if preference.GetParameters() == nil {
+			preference.Parameters = map[string]string{}
+		}
+		preference.GetParameters()[CgroupDirKey] = c.cgroupDir
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	params := conn.GetMechanism().GetParameters()
+	if params[DevicePathKey] == "" || params[CDIDeviceKey] != "" {
+		return conn, nil
+	}
+
+	major, majorErr := strconv.ParseUint(params[DeviceMajorKey], 10, 32)
+	minor, minorErr := strconv.ParseUint(params[DeviceMinorKey], 10, 32)
+	if majorErr != nil || minorErr != nil {
+		_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+		return nil, errors.Errorf("invalid PHC device numbers: %s:%s", params[DeviceMajorKey], params[DeviceMinorKey])
+	}
+
+	devicePath := filepath.Join(c.devDir, filepath.Base(params[DevicePathKey]))
+	if err := unix.Mknod(
+		devicePath,
+		unix.S_IFCHR|mknodPerm,
+		int(unix.Mkdev(uint32(major), uint32(minor))),
+	); err != nil && !os.IsExist(err) {
+		_, _ = next.Client(ctx).Close(ctx, conn, opts...)
+		return nil, errors.Wrapf(err, "failed to mknod device: %v", devicePath)
+	}
+
+	c.lock.Lock()
+	c.devices[conn.GetId()] = devicePath
+	c.lock.Unlock()
+
+	return conn, nil
+}
+
+func (c *ptpClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.lock.Lock()
+	if devicePath, ok := c.devices[conn.GetId()]; ok {
+		_ = os.Remove(devicePath)
+		delete(c.devices, conn.GetId())
+	}
+	c.lock.Unlock()
+
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
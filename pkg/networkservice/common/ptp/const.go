@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptp
+
+const (
+	// Label is a connection label requesting the PF PTP hardware clock (PHC), should be "true"
+	Label = "sriovPTP"
+
+	// CgroupDirKey - client cgroup directory the PHC char device access is granted to, same as for the VFIO mechanism
+	CgroupDirKey = "cgroupDir"
+	// DevicePathKey - PHC char device path, e.g. /dev/ptp0
+	DevicePathKey = "sriovPHCDevice"
+	// DeviceMajorKey - PHC char device major number
+	DeviceMajorKey = "sriovPHCMajor"
+	// DeviceMinorKey - PHC char device minor number
+	DeviceMinorKey = "sriovPHCMinor"
+	// CDIDeviceKey - qualified CDI device name of the PHC char device, set if the access is granted with CDI
+	CDIDeviceKey = "sriovPHCCDIDevice"
+)
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptp
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
+// Option is an option for NewClient
+type Option func(c *ptpClient)
+
+// WithDevDir sets ptpClient directory the PHC char devices are created in, /dev by default
+func WithDevDir(devDir string) Option {
+	return func(c *ptpClient) {
+		c.devDir = devDir
+	}
+}
+
+// WithCgroupDir sets ptpClient cgroupDir
+func WithCgroupDir(cgroupDir string) Option {
+	return func(c *ptpClient) {
+		c.cgroupDir = cgroupDir
+	}
+}
+
+// WithCgroupPathResolvers sets ptpClient resolvers used to find out cgroupDir if it is not set, the cgroup package
+// default resolvers are used by default
+func WithCgroupPathResolvers(resolvers ...cgroup.PathResolver) Option {
+	return func(c *ptpClient) {
+		c.cgroupResolvers = resolvers
+	}
+}
+
+// ServerOption is an option for NewServer
+type ServerOption func(s *ptpServer)
+
+// WithHostDevDir sets the host directory the PHC char devices are looked up in, /dev by default
+func WithHostDevDir(devDir string) ServerOption {
+	return func(s *ptpServer) {
+		s.devDir = devDir
+	}
+}
+
+// WithDevicesPath sets the PCI devices sysfs path the PF PHCs are discovered in, ptp.DefaultDevicesPath by default
+func WithDevicesPath(devicesPath string) ServerOption {
+	return func(s *ptpServer) {
+		s.devicesPath = devicesPath
+	}
+}
+
+// WithCDI grants the client access to the PHC char device with the CDI spec written per connection instead of the
+// cgroup device rules, the qualified CDI device name is set as CDIDeviceKey mechanism parameter
+func WithCDI(generator *cdi.Generator) ServerOption {
+	return func(s *ptpServer) {
+		s.cdi = generator
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package ptp provides chain elements granting the client access to the PTP hardware clock (PHC) of the PF the
+// connection VF is on
+package ptp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+	ptpdev "github.com/ljkiraly/sdk-sriov/pkg/tools/ptp"
+)
+
+const cdiSpecPrefix = "ptp-"
+
+type grant struct {
+	cgroupDirPattern string
+	major, minor     uint32
+}
+
+func (g *grant) key() string {
+	return fmt.Sprintf("%s %d:%d", g.cgroupDirPattern, g.major, g.minor)
+}
+
+type ptpServer struct {
+	config        *config.Config
+	cgroupBaseDir string
+	devicesPath   string
+	devDir        string
+	cdi           *cdi.Generator
+	grants        map[string]*grant
+	counters      map[string]int
+	lock          sync.Mutex
+}
+
+// NewServer returns a new PTP server chain element. For the connections labeled with Label it grants the client access
+// to the PHC char device of the PF the VF selected by the previous chain elements is on, the PF should have
+// config.PTPCapability. The PHC is shared by all the PF VFs, so the grant is revoked on Close only when no other
+// connection of the same client uses it.
+func NewServer(cfg *config.Config, cgroupBaseDir string, options ...ServerOption) networkservice.NetworkServiceServer {
+	s := &ptpServer{
+		config:        cfg,
+		cgroupBaseDir: cgroupBaseDir,
+		devicesPath:   ptpdev.DefaultDevicesPath,
+		devDir:        ptpdev.DefaultDevDir,
+		grants:        map[string]*grant{},
+		counters:      map[string]int{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *ptpServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if request.GetConnection().GetLabels()[Label] != "true" {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	if err := s.grant(ctx, request.GetConnection()); err != nil {
+		return nil, err
+	}
+
+	grantedConn := request.GetConnection()
+	registered := cleanup.Register(ctx, s, func(ctx context.Context) {
+		s.close(ctx, grantedConn)
+	})
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !registered {
+			s.close(ctx, request.GetConnection())
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *ptpServer) grant(ctx context.Context, conn *networkservice.Connection) error {
+	assignment, ok := resourcepool.LoadAssignment(conn)
+	if !ok {
+		return errors.New("no VF assigned for the PTP hardware clock")
+	}
+	if pfCfg, ok := s.config.PhysicalFunctions[assignment.PFPCIAddress]; !ok || !pfCfg.HasPTP() {
+		return errors.Errorf("PTP is not enabled on the PF: %s", assignment.PFPCIAddress)
+	}
+
+	name, err := ptpdev.PHC(s.devicesPath, assignment.PFPCIAddress)
+	if err != nil {
+		return err
+	}
+
+	hostDevicePath := filepath.Join(s.devDir, name)
+	info := new(unix.Stat_t)
+	if err := unix.Stat(hostDevicePath, info); err != nil {
+		return errors.Wrapf(err, "failed to check %s file status", hostDevicePath)
+	}
+	major, minor := unix.Major(info.Rdev), unix.Minor(info.Rdev)
+
+	params := conn.GetMechanism().GetParameters()
+	devicePath := filepath.Join(ptpdev.DefaultDevDir, name)
+	if s.cdi != nil {
+		names, err := s.cdi.WriteSpec(ctx, cdiSpecPrefix+conn.GetId(), s.cdi.CharDevice(cdiSpecPrefix+conn.GetId(), devicePath))
+		if err != nil {
+			return err
+		}
+		params[CDIDeviceKey] = names[0]
+	} else if err := s.allowDevice(conn.GetId(), params[CgroupDirKey], major, minor); err != nil {
+		return err
+	}
+
+	params[DevicePathKey] = devicePath
+	params[DeviceMajorKey] = strconv.FormatUint(uint64(major), 10)
+	params[DeviceMinorKey] = strconv.FormatUint(uint64(minor), 10)
+
+	return nil
+}
+
+func (s *ptpServer) allowDevice(connID, cgroupDir string, major, minor uint32) error {
+	if cgroupDir == "" {
+		return errors.New("expected client cgroup directory set")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.grants[connID]; ok {
+		return nil
+	}
+
+	g := &grant{
+		cgroupDirPattern: filepath.Join(s.cgroupBaseDir, cgroupDir),
+		major:            major,
+		minor:            minor,
+	}
+	if s.counters[g.key()] == 0 {
+		cgroups, err := cgroup.NewDeviceControllers(g.cgroupDirPattern)
+		if err != nil || len(cgroups) == 0 {
+			return errors.Errorf("no cgroupDir found: %s", g.cgroupDirPattern)
+		}
+		for _, cg := range cgroups {
+			if err := cg.AllowRule(cgroup.NewRule(cgroup.CharDevice, major, minor, 'r', 'w', 'm')); err != nil {
+				return errors.Wrapf(err, "failed to allow PHC device for the cgroup: %s", cg.Dir())
+			}
+		}
+	}
+	s.counters[g.key()]++
+	s.grants[connID] = g
+
+	return nil
+}
+
+func (s *ptpServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.close(ctx, conn)
+	cleanup.Unregister(ctx, s)
+
+	if _, err := next.Server(ctx).Close(ctx, conn); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *ptpServer) close(ctx context.Context, conn *networkservice.Connection) {
+	logger := log.FromContext(ctx).WithField("ptpServer", "close")
+
+	if s.cdi != nil {
+		if err := s.cdi.RemoveSpec(cdiSpecPrefix + conn.GetId()); err != nil {
+			logger.Errorf("failed to remove CDI spec: %s", err.Error())
+		}
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	g, ok := s.grants[conn.GetId()]
+	if !ok {
+		return
+	}
+	delete(s.grants, conn.GetId())
+
+	if s.counters[g.key()]--; s.counters[g.key()] > 0 {
+		return
+	}
+	delete(s.counters, g.key())
+
+	cgroups, err := cgroup.NewDeviceControllers(g.cgroupDirPattern)
+	if err != nil {
+		logger.Errorf("no cgroupDir found: %s", g.cgroupDirPattern)
+		return
+	}
+	for _, cg := range cgroups {
+		if err := cg.DenyRule(cgroup.NewRule(cgroup.CharDevice, g.major, g.minor, 'r', 'w')); err != nil {
+			logger.Errorf("failed to deny PHC device for the cgroup %s: %s", cg.Dir(), err.Error())
+		}
+	}
+}
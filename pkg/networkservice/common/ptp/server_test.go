@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ptp_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/ptp"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+)
+
+const (
+	pfPCIAddr = "0000:01:00.0"
+	vfPCIAddr = "0000:01:00.1"
+	cgroupDir = "pod-1/container-1"
+)
+
+func testDirs(t *testing.T) (devicesPath, devDir, cgroupBaseDir string) {
+	devicesPath = t.TempDir()
+	devDir = t.TempDir()
+	cgroupBaseDir = t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(devicesPath, pfPCIAddr, "ptp", "ptp0"), 0o750))
+
+	// /dev/null is 1:3
+	require.NoError(t, os.Symlink("/dev/null", filepath.Join(devDir, "ptp0")))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(cgroupBaseDir, cgroupDir), 0o750))
+	for _, name := range []string{"devices.list", "devices.allow", "devices.deny"} {
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupBaseDir, cgroupDir, name), nil, 0o600))
+	}
+
+	return devicesPath, devDir, cgroupBaseDir
+}
+
+func readCgroupFile(t *testing.T, cgroupBaseDir, name string) string {
+	data, err := os.ReadFile(filepath.Join(cgroupBaseDir, cgroupDir, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func testConfig(capabilities ...string) *config.Config {
+	return &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			pfPCIAddr: {Capabilities: capabilities},
+		},
+	}
+}
+
+func testRequest(connID string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     connID,
+			Labels: map[string]string{ptp.Label: "true"},
+			Mechanism: &networkservice.Mechanism{
+				Cls:  cls.LOCAL,
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					ptp.CgroupDirKey:             cgroupDir,
+					resourcepool.VFPCIAddressKey: vfPCIAddr,
+					resourcepool.PFPCIAddressKey: pfPCIAddr,
+				},
+			},
+		},
+	}
+}
+
+func TestPTPServer_Request_Cgroup(t *testing.T) {
+	devicesPath, devDir, cgroupBaseDir := testDirs(t)
+
+	server := ptp.NewServer(testConfig("10G", config.PTPCapability), cgroupBaseDir,
+		ptp.WithDevicesPath(devicesPath),
+		ptp.WithHostDevDir(devDir))
+
+	conn1, err := server.Request(context.Background(), testRequest("conn-1"))
+	require.NoError(t, err)
+
+	params := conn1.GetMechanism().GetParameters()
+	require.Equal(t, "/dev/ptp0", params[ptp.DevicePathKey])
+	require.Equal(t, "1", params[ptp.DeviceMajorKey])
+	require.Equal(t, "3", params[ptp.DeviceMinorKey])
+	require.Empty(t, params[ptp.CDIDeviceKey])
+	require.Equal(t, "c 1:3 rwm\n", readCgroupFile(t, cgroupBaseDir, "devices.allow"))
+
+	// The PHC is shared by the client connections
+	conn2, err := server.Request(context.Background(), testRequest("conn-2"))
+	require.NoError(t, err)
+
+	_, err = server.Close(context.Background(), conn1)
+	require.NoError(t, err)
+	require.Empty(t, readCgroupFile(t, cgroupBaseDir, "devices.deny"))
+
+	_, err = server.Close(context.Background(), conn2)
+	require.NoError(t, err)
+	require.Equal(t, "c 1:3 rw\n", readCgroupFile(t, cgroupBaseDir, "devices.deny"))
+}
+
+func TestPTPServer_Request_CDI(t *testing.T) {
+	devicesPath, devDir, cgroupBaseDir := testDirs(t)
+	specDir := filepath.Join(t.TempDir(), "cdi")
+
+	server := ptp.NewServer(testConfig(config.PTPCapability), cgroupBaseDir,
+		ptp.WithDevicesPath(devicesPath),
+		ptp.WithHostDevDir(devDir),
+		ptp.WithCDI(cdi.NewGenerator(cdi.WithSpecDir(specDir))))
+
+	conn, err := server.Request(context.Background(), testRequest("conn-1"))
+	require.NoError(t, err)
+
+	require.Equal(t, cdi.DefaultKind+"=ptp-conn-1", conn.GetMechanism().GetParameters()[ptp.CDIDeviceKey])
+	require.Empty(t, readCgroupFile(t, cgroupBaseDir, "devices.allow"))
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	specs, err := filepath.Glob(filepath.Join(specDir, "*.json"))
+	require.NoError(t, err)
+	require.Empty(t, specs)
+}
+
+func TestPTPServer_Request_NoPTP(t *testing.T) {
+	devicesPath, devDir, cgroupBaseDir := testDirs(t)
+
+	server := ptp.NewServer(testConfig("10G"), cgroupBaseDir,
+		ptp.WithDevicesPath(devicesPath),
+		ptp.WithHostDevDir(devDir))
+
+	_, err := server.Request(context.Background(), testRequest("conn-1"))
+	require.ErrorContains(t, err, "PTP is not enabled on the PF: "+pfPCIAddr)
+
+	// Not requested
+	request := testRequest("conn-2")
+	request.GetConnection().Labels = nil
+
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Empty(t, conn.GetMechanism().GetParameters()[ptp.DevicePathKey])
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package ptp
+
+import (
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
+type ptpClient struct {
+	devDir          string
+	cgroupDir       string
+	cgroupResolvers []cgroup.PathResolver
+}
+
+type ptpServer struct {
+	devicesPath string
+	devDir      string
+	cdi         *cdi.Generator
+}
+
+// NewClient returns a client chain element failing all the requests with sriov.UnsupportedError, PTP is supported on
+// Linux only
+func NewClient(_ ...Option) networkservice.NetworkServiceClient {
+	return injecterror.NewClient(
+		injecterror.WithError(sriov.NewUnsupportedError("PTP client")),
+		injecterror.WithCloseErrorTimes())
+}
+
+// NewServer returns a server chain element failing all the requests with sriov.UnsupportedError, PTP is supported on
+// Linux only
+func NewServer(_ *config.Config, _ string, _ ...ServerOption) networkservice.NetworkServiceServer {
+	return injecterror.NewServer(
+		injecterror.WithError(sriov.NewUnsupportedError("PTP server")),
+		injecterror.WithCloseErrorTimes())
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package qos
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/dcb"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// Option is an option pattern for NewServer
+type Option func(s *qosServer)
+
+// WithNetlink sets Netlink implementation, dcb package functions are used by default
+func WithNetlink(nl Netlink) Option {
+	return func(s *qosServer) {
+		s.netlink = nl
+	}
+}
+
+// WithVFConfigurator sets the VF configurator setting the VF priority for the default Netlink, netlink by default
+func WithVFConfigurator(configurator sriovvfconfig.Configurator) Option {
+	return func(s *qosServer) {
+		s.netlink = netlinkFuncs{configurator: configurator}
+	}
+}
+
+type netlinkFuncs struct {
+	configurator sriovvfconfig.Configurator
+}
+
+func (netlinkFuncs) ReadIEEE(ifName string) (*dcb.IEEE, error) {
+	return dcb.ReadIEEE(ifName)
+}
+
+func (nl netlinkFuncs) SetVFPriority(pfName string, vfNum int, priority uint8) error {
+	return dcb.SetVFPriority(nl.configurator, pfName, vfNum, priority)
+}
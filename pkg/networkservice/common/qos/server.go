@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package qos provides chain element mapping the connection QoS class to the VF traffic class on the PF
+package qos
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/dcb"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// Label is a connection label with the requested QoS class name
+const Label = "sriovQoS"
+
+// Netlink is the PF DCB configuration reading and the VF priority setting interface
+type Netlink interface {
+	ReadIEEE(ifName string) (*dcb.IEEE, error)
+	SetVFPriority(pfName string, vfNum int, priority uint8) error
+}
+
+type prioritizedVFKey struct{}
+
+type qosServer struct {
+	config  *config.Config
+	netlink Netlink
+}
+
+// NewServer returns a new QoS server chain element. For the connections labeled with Label it checks that the QoS
+// class is configured on the PF of the VF selected by the previous chain elements and that the PF DCB configuration
+// maps the class priority to the configured traffic class, then sets the class priority for the VF traffic. The
+// priority is set after the following chain elements have tagged the VF with the VLAN and is reset on Close.
+func NewServer(cfg *config.Config, options ...Option) networkservice.NetworkServiceServer {
+	s := &qosServer{
+		config:  cfg,
+		netlink: netlinkFuncs{configurator: sriovvfconfig.NewNetlink()},
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *qosServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	className := request.GetConnection().GetLabels()[Label]
+	if className == "" {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	vfConfig, class, err := s.validate(ctx, request.GetConnection(), className)
+	if err != nil {
+		return nil, err
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.netlink.SetVFPriority(vfConfig.PFInterfaceName, vfConfig.VFNum, class.Priority); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := next.Server(ctx).Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+	metadata.Map(ctx, false).Store(prioritizedVFKey{}, vfConfig)
+
+	return conn, nil
+}
+
+func (s *qosServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(prioritizedVFKey{}); ok {
+		vfConfig := rawValue.(*vfconfig.VFConfig)
+		if err := s.netlink.SetVFPriority(vfConfig.PFInterfaceName, vfConfig.VFNum, 0); err != nil {
+			log.FromContext(ctx).WithField("qosServer", "Close").
+				Warnf("failed to reset VF priority: %s", err.Error())
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// validate returns the VF and the QoS class configuration if the class can be set for the VF
+func (s *qosServer) validate(ctx context.Context, conn *networkservice.Connection, className string) (*vfconfig.VFConfig, *config.QoSClass, error) {
+	vfConfig, ok := vfconfig.Load(ctx, false)
+	if !ok {
+		return nil, nil, errors.Errorf("no VF selected for the QoS class: %s", className)
+	}
+	assignment, ok := resourcepool.LoadAssignment(conn)
+	if !ok {
+		return nil, nil, errors.Errorf("no VF assigned for the QoS class: %s", className)
+	}
+
+	var class *config.QoSClass
+	if pfCfg, ok := s.config.PhysicalFunctions[assignment.PFPCIAddress]; ok && pfCfg.QoS != nil {
+		class = pfCfg.QoS.Classes[className]
+	}
+	if class == nil {
+		return nil, nil, errors.Errorf("QoS class %s is not configured on the PF: %s", className, assignment.PFPCIAddress)
+	}
+
+	ieee, err := s.netlink.ReadIEEE(vfConfig.PFInterfaceName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ieee.Validate(class.Priority, class.Lossless); err != nil {
+		return nil, nil, errors.Wrapf(err, "QoS class %s is not configured on the PF DCB: %s", className, vfConfig.PFInterfaceName)
+	}
+
+	return vfConfig, class, nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package qos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/qos"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/dcb"
+)
+
+const (
+	pfPCIAddr = "0000:01:00.0"
+	vfPCIAddr = "0000:01:00.1"
+	pfName    = "eth0"
+)
+
+type fakeNetlink struct {
+	ieee       *dcb.IEEE
+	priorities map[int]uint8
+}
+
+func (nl *fakeNetlink) ReadIEEE(_ string) (*dcb.IEEE, error) {
+	return nl.ieee, nil
+}
+
+func (nl *fakeNetlink) SetVFPriority(_ string, vfNum int, priority uint8) error {
+	nl.priorities[vfNum] = priority
+	return nil
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			pfPCIAddr: {
+				QoS: &config.QoS{
+					Classes: map[string]*config.QoSClass{
+						"gold":    {Priority: 3, Lossless: true},
+						"invalid": {Priority: 5},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testNetlink() *fakeNetlink {
+	return &fakeNetlink{
+		ieee: &dcb.IEEE{
+			PrioTC:      [dcb.NumTCs]uint8{0, 0, 0, 1, 0, 2},
+			TCBandwidth: [dcb.NumTCs]uint8{50, 50},
+			TSA:         [dcb.NumTCs]uint8{dcb.TSAETS, dcb.TSAETS, dcb.TSAETS},
+			PFCEnabled:  1 << 3,
+		},
+		priorities: map[int]uint8{},
+	}
+}
+
+func testServer(nl qos.Netlink) networkservice.NetworkServiceServer {
+	return chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkcontext.NewServer(nil, func(_ *testing.T, ctx context.Context) {
+			vfconfig.Store(ctx, false, &vfconfig.VFConfig{PFInterfaceName: pfName, VFNum: 1})
+		}),
+		qos.NewServer(testConfig(), qos.WithNetlink(nl)),
+	)
+}
+
+func testRequest(className string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id",
+			Labels: map[string]string{qos.Label: className},
+			Mechanism: &networkservice.Mechanism{
+				Parameters: map[string]string{
+					resourcepool.VFPCIAddressKey: vfPCIAddr,
+					resourcepool.PFPCIAddressKey: pfPCIAddr,
+				},
+			},
+		},
+	}
+}
+
+func TestQoSServer_Request(t *testing.T) {
+	nl := testNetlink()
+	server := testServer(nl)
+
+	conn, err := server.Request(context.Background(), testRequest("gold"))
+	require.NoError(t, err)
+	require.Equal(t, uint8(3), nl.priorities[1])
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, uint8(0), nl.priorities[1])
+}
+
+func TestQoSServer_Request_Invalid(t *testing.T) {
+	nl := testNetlink()
+	server := testServer(nl)
+
+	_, err := server.Request(context.Background(), testRequest("bronze"))
+	require.ErrorContains(t, err, "QoS class bronze is not configured on the PF")
+
+	_, err = server.Request(context.Background(), testRequest("invalid"))
+	require.ErrorContains(t, err, "no ETS bandwidth")
+
+	nl.ieee.PFCEnabled = 0
+	_, err = server.Request(context.Background(), testRequest("gold"))
+	require.ErrorContains(t, err, "PFC is not enabled")
+
+	require.Empty(t, nl.priorities)
+}
+
+func TestQoSServer_Request_NoLabel(t *testing.T) {
+	nl := testNetlink()
+	server := testServer(nl)
+
+	_, err := server.Request(context.Background(), testRequest(""))
+	require.NoError(t, err)
+	require.Empty(t, nl.priorities)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resetmechanism
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type migratedFromKey struct{}
+
+type migratingKey struct{}
+
+// MigratedFrom returns the old mechanism the connection is migrated from on the migrating Request
+func MigratedFrom(ctx context.Context) (*networkservice.Mechanism, bool) {
+	mech, ok := ctx.Value(migratedFromKey{}).(*networkservice.Mechanism)
+	return mech, ok
+}
+
+// IsMigrating returns true on the old mechanism Close after the connection has been migrated to the new mechanism
+func IsMigrating(ctx context.Context) bool {
+	_, ok := ctx.Value(migratingKey{}).(struct{})
+	return ok
+}
+
+func withMigratedFrom(ctx context.Context, mech *networkservice.Mechanism) context.Context {
+	return context.WithValue(ctx, migratedFromKey{}, mech)
+}
+
+func withMigrating(ctx context.Context) context.Context {
+	return context.WithValue(ctx, migratingKey{}, struct{}{})
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resetmechanism
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Option is an option for NewServer
+type Option func(s *resetMechanismServer)
+
+// MigrateFunc returns if the connection can be migrated from the old mechanism to the new one in place
+type MigrateFunc func(ctx context.Context, oldMech, newMech *networkservice.Mechanism) bool
+
+// WithMigration makes the server migrate the connections for which canMigrate returns true instead of resetting them:
+// the wrapped server is requested with the new mechanism first and closed with the old mechanism only after that, both
+// with the migration context (see MigratedFrom, IsMigrating). The wrapped server elements should be migration aware:
+// keep the resources shared with the new mechanism (e.g. the same VF rebound in place) on the migrating Close.
+func WithMigration(canMigrate MigrateFunc) Option {
+	return func(s *resetMechanismServer) {
+		s.canMigrate = canMigrate
+	}
+}
@@ -22,23 +22,33 @@ import (
 
 	"github.com/edwarnicke/genericsync"
 	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
 )
 
 type resetMechanismServer struct {
 	wrappedServer networkservice.NetworkServiceServer
 	mechanisms    *genericsync.Map[string, *networkservice.Mechanism]
+	canMigrate    MigrateFunc
 }
 
 // NewServer returns a new reset mechanism server chain element
-func NewServer(wrappedServer networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
-	return &resetMechanismServer{
+func NewServer(wrappedServer networkservice.NetworkServiceServer, options ...Option) networkservice.NetworkServiceServer {
+	s := &resetMechanismServer{
 		wrappedServer: wrappedServer,
 		mechanisms:    &genericsync.Map[string, *networkservice.Mechanism]{},
 	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
 }
 
 func (s *resetMechanismServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -51,23 +61,73 @@ func (s *resetMechanismServer) Request(ctx context.Context, request *networkserv
 			return next.Server(ctx).Request(ctx, request)
 		}
 
-		// requested mechanism has been changed, we need to reset the connection for the wrapped server
-		conn := request.GetConnection().Clone()
-		conn.Mechanism = storedMech
+		if s.canMigrate != nil && s.canMigrate(ctx, storedMech, mech) {
+			return s.migrate(ctx, request, storedMech)
+		}
 
-		closeServer := next.NewNetworkServiceServer(s.wrappedServer, &tailServer{})
-		if _, err := closeServer.Close(ctx, conn); err != nil {
+		// requested mechanism has been changed, we need to reset the connection for the wrapped server
+		if err := s.reset(ctx, request.GetConnection(), storedMech); err != nil {
 			return nil, err
 		}
 	}
 
 	conn, err := s.wrappedServer.Request(ctx, request)
 	if mech := conn.GetMechanism(); err == nil && mech != nil {
-		s.mechanisms.LoadOrStore(connID, mech.Clone())
+		s.mechanisms.Store(connID, mech.Clone())
 	}
 	return conn, err
 }
 
+// reset closes the connection stored mechanism resources for the wrapped server
+func (s *resetMechanismServer) reset(ctx context.Context, conn *networkservice.Connection, storedMech *networkservice.Mechanism) (err error) {
+	ctx, span := tracing.Start(ctx, "resetmechanism/reset", mechanismAttributes(storedMech, conn.GetMechanism())...)
+	defer func() { tracing.End(span, err) }()
+
+	conn = conn.Clone()
+	conn.Mechanism = storedMech
+
+	closeServer := next.NewNetworkServiceServer(s.wrappedServer, &tailServer{})
+	_, err = closeServer.Close(ctx, conn)
+	return err
+}
+
+// migrate requests the wrapped server with the new mechanism and only then closes the old mechanism resources not
+// shared with the new one, so the datapath is not torn down between them
+func (s *resetMechanismServer) migrate(ctx context.Context, request *networkservice.NetworkServiceRequest, storedMech *networkservice.Mechanism) (conn *networkservice.Connection, err error) {
+	logger := log.FromContext(ctx).WithField("resetMechanismServer", "migrate")
+
+	ctx, span := tracing.Start(ctx, "resetmechanism/migrate",
+		mechanismAttributes(storedMech, request.GetConnection().GetMechanism())...)
+	defer func() { tracing.End(span, err) }()
+
+	conn, err = s.wrappedServer.Request(withMigratedFrom(ctx, storedMech), request)
+	if err != nil {
+		return nil, err
+	}
+	if mech := conn.GetMechanism(); mech != nil {
+		s.mechanisms.Store(conn.GetId(), mech.Clone())
+	}
+
+	oldConn := conn.Clone()
+	oldConn.Mechanism = storedMech
+
+	closeServer := next.NewNetworkServiceServer(s.wrappedServer, &tailServer{})
+	if _, err := closeServer.Close(withMigrating(ctx), oldConn); err != nil {
+		logger.Warnf("failed to close the old mechanism %s: %s", storedMech.GetType(), err.Error())
+	}
+
+	return conn, nil
+}
+
+// mechanismAttributes returns the span attributes for the stored mechanism being replaced with the requested one
+func mechanismAttributes(storedMech, mech *networkservice.Mechanism) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		tracing.MechanismKey.String(mech.GetType()),
+		tracing.PreviousMechanismKey.String(storedMech.GetType()),
+		tracing.PCIAddressKey.String(storedMech.GetParameters()[common.PCIAddressKey]),
+	}
+}
+
 func (s *resetMechanismServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	s.mechanisms.Delete(conn.GetId())
 
@@ -147,6 +147,88 @@ func TestResetMechanismServer_Request_Change(t *testing.T) {
 	mockElement.mock.AssertNumberOfCalls(t, "Close", 1)
 }
 
+func TestResetMechanismServer_Request_Migration(t *testing.T) {
+	mechElement := newMechChainElement()
+	mockElement := newMockChainElement()
+
+	var migratedFrom []string
+	var migratingCloses int
+	server := chain.NewNetworkServiceServer(
+		resetmechanism.NewServer(
+			chain.NewNetworkServiceServer(
+				&migrationChainElement{
+					onRequest: func(ctx context.Context) {
+						if mech, ok := resetmechanism.MigratedFrom(ctx); ok {
+							migratedFrom = append(migratedFrom, mech.GetType())
+						}
+					},
+					onClose: func(ctx context.Context) {
+						if resetmechanism.IsMigrating(ctx) {
+							migratingCloses++
+						}
+					},
+				},
+				mechElement,
+			),
+			resetmechanism.WithMigration(func(_ context.Context, oldMech, newMech *networkservice.Mechanism) bool {
+				return oldMech.GetType() == mech1 && newMech.GetType() == mech2
+			}),
+		),
+		mockElement,
+	)
+
+	// 1. Request with mech1 mechanism
+
+	_, err := server.Request(context.TODO(), testRequest(mech1))
+	require.NoError(t, err)
+
+	// 2. Request with mech2 mechanism migrates the connection: the old mechanism is closed after the new one request
+
+	request := testRequest(mech2)
+	request.Connection.Mechanism = request.GetMechanismPreferences()[0]
+
+	conn, err := server.Request(context.TODO(), request)
+	require.NoError(t, err)
+	require.Equal(t, mech2, conn.Mechanism.Type)
+
+	require.Equal(t, []string{mech1}, migratedFrom)
+	require.Equal(t, 1, migratingCloses)
+	require.False(t, mechElement.mechs[mech1])
+	require.True(t, mechElement.mechs[mech2])
+
+	mechElement.mock.AssertNumberOfCalls(t, "Request", 2)
+	mechElement.mock.AssertNumberOfCalls(t, "Close", 1)
+	mockElement.mock.AssertNumberOfCalls(t, "Request", 2)
+	mockElement.mock.AssertNumberOfCalls(t, "Close", 0)
+
+	// 3. Request with mech1 mechanism resets the connection
+
+	conn, err = server.Request(context.TODO(), testRequest(mech1))
+	require.NoError(t, err)
+	require.Equal(t, mech1, conn.Mechanism.Type)
+
+	require.Equal(t, []string{mech1}, migratedFrom)
+	require.Equal(t, 1, migratingCloses)
+
+	mechElement.mock.AssertNumberOfCalls(t, "Request", 3)
+	mechElement.mock.AssertNumberOfCalls(t, "Close", 2)
+}
+
+type migrationChainElement struct {
+	onRequest func(ctx context.Context)
+	onClose   func(ctx context.Context)
+}
+
+func (m *migrationChainElement) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	m.onRequest(ctx)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (m *migrationChainElement) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	m.onClose(ctx)
+	return next.Server(ctx).Close(ctx, conn)
+}
+
 type mechChainElement struct {
 	mock  mock.Mock
 	mechs map[string]bool
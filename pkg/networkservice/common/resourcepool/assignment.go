@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepool
+
+import (
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+const (
+	// VFPCIAddressKey is a mechanism parameter key for the assigned VF PCI address
+	VFPCIAddressKey = "sriovVFPCIAddress"
+	// PFPCIAddressKey is a mechanism parameter key for the assigned VF parent PF PCI address
+	PFPCIAddressKey = "sriovPFPCIAddress"
+	// NUMANodeKey is a mechanism parameter key for the assigned VF parent PF NUMA node, -1 if unknown
+	NUMANodeKey = "sriovNUMANode"
+	// BoundDriverKey is a mechanism parameter key for the driver type the assigned VF is bound to
+	BoundDriverKey = "sriovBoundDriver"
+)
+
+// Assignment describes the hardware backing the connection
+type Assignment struct {
+	VFPCIAddress string
+	PFPCIAddress string
+	NUMANode     int
+	DriverType   sriov.DriverType
+}
+
+// LoadAssignment returns the Assignment stored in the connection mechanism parameters by the resourcepool chain
+// element, ok is false if there is no assignment
+func LoadAssignment(conn *networkservice.Connection) (assignment *Assignment, ok bool) {
+	params := conn.GetMechanism().GetParameters()
+	if _, ok = params[VFPCIAddressKey]; !ok {
+		return nil, false
+	}
+
+	numaNode, err := strconv.Atoi(params[NUMANodeKey])
+	if err != nil {
+		numaNode = -1
+	}
+
+	return &Assignment{
+		VFPCIAddress: params[VFPCIAddressKey],
+		PFPCIAddress: params[PFPCIAddressKey],
+		NUMANode:     numaNode,
+		DriverType:   sriov.DriverType(params[BoundDriverKey]),
+	}, true
+}
+
+func storeAssignment(conn *networkservice.Connection, assignment *Assignment) {
+	params := conn.GetMechanism().GetParameters()
+	params[VFPCIAddressKey] = assignment.VFPCIAddress
+	params[PFPCIAddressKey] = assignment.PFPCIAddress
+	params[NUMANodeKey] = strconv.Itoa(assignment.NUMANode)
+	params[BoundDriverKey] = string(assignment.DriverType)
+}
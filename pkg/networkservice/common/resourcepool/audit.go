@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+)
+
+// Auditor is an audit.Auditor interface
+type Auditor interface {
+	Record(ctx context.Context, conn *networkservice.Connection, record *audit.Record)
+}
+
+// auditAssignment records the driver binding and the assignment of each VF assigned to the connection
+func (s *resourcePoolConfig) auditAssignment(ctx context.Context, conn *networkservice.Connection, driverType sriov.DriverType) {
+	if s.auditor == nil {
+		return
+	}
+
+	tokenIDs := TokenIDs(conn.GetMechanism())
+	for i, vfPCIAddr := range PCIAddresses(conn.GetMechanism()) {
+		var tokenID string
+		if i < len(tokenIDs) {
+			tokenID = tokenIDs[i]
+		}
+		s.auditor.Record(ctx, conn, &audit.Record{
+			Action:     audit.DriverBound,
+			TokenID:    tokenID,
+			PCIAddress: vfPCIAddr,
+			Driver:     driverType,
+		})
+		s.auditor.Record(ctx, conn, &audit.Record{
+			Action:     audit.VFAssigned,
+			TokenID:    tokenID,
+			PCIAddress: vfPCIAddr,
+			Driver:     driverType,
+		})
+	}
+}
+
+// auditFree records the VFs freed by the connection
+func (s *resourcePoolConfig) auditFree(ctx context.Context, conn *networkservice.Connection, freedVFs []*audit.Record) {
+	if s.auditor == nil {
+		return
+	}
+
+	for _, record := range freedVFs {
+		s.auditor.Record(ctx, conn, record)
+	}
+}
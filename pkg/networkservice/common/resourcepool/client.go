@@ -36,11 +36,11 @@ import (
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
-	"github.com/ljkiraly/sdk/pkg/tools/log"
 	"github.com/ljkiraly/sdk/pkg/tools/postpone"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
@@ -55,15 +55,11 @@ func NewClient(
 	pciPool PCIPool,
 	resourcePool ResourcePool,
 	cfg *config.Config,
+	options ...Option,
 ) networkservice.NetworkServiceClient {
-	return &resourcePoolClient{resourcePool: &resourcePoolConfig{
-		driverType:   driverType,
-		resourceLock: resourceLock,
-		pciPool:      pciPool,
-		resourcePool: resourcePool,
-		config:       cfg,
-		selectedVFs:  map[string]string{},
-	}}
+	return &resourcePoolClient{
+		resourcePool: newResourcePoolConfig(driverType, resourceLock, pciPool, resourcePool, cfg, options...),
+	}
 }
 
 func (i *resourcePoolClient) Request(
@@ -71,7 +67,7 @@ func (i *resourcePoolClient) Request(
 	request *networkservice.NetworkServiceRequest,
 	opts ...grpc.CallOption,
 ) (*networkservice.Connection, error) {
-	logger := log.FromContext(ctx).WithField("resourcePoolClient", "Request")
+	logger := hwlog.FromContext(ctx, hwlog.ResourcePoolSubsystem).WithField("resourcePoolClient", "Request")
 
 	mechParams := request.GetConnection().GetMechanism().GetParameters()
 	oldPCIAddress := mechParams[common.PCIAddressKey]
@@ -86,6 +82,10 @@ func (i *resourcePoolClient) Request(
 		return conn, err
 	}
 
+	if err := setPrimaryTokenID(conn); err != nil {
+		logger.Infof("invalid token IDs for the connection: %s", err.Error())
+		return conn, nil
+	}
 	tokenID, ok := conn.GetMechanism().GetParameters()[common.DeviceTokenIDKey]
 	if !ok {
 		logger.Infof("no token ID present for the connection: %v", conn)
@@ -96,7 +96,18 @@ func (i *resourcePoolClient) Request(
 		return conn, nil
 	}
 
-	err = assignVF(ctx, logger, conn, tokenID, i.resourcePool, metadata.IsClient(i))
+	opCtx := hwlog.WithOperation(ctx, conn.GetId(), tokenID, "")
+	logger = hwlog.FromContext(opCtx, hwlog.ResourcePoolSubsystem).WithField("resourcePoolClient", "Request")
+
+	var vfReused bool
+	err = i.resourcePool.verifyToken(tokenID)
+	if err == nil {
+		// refresh Request can carry the already assigned VF, so it should be only validated
+		vfReused, err = reuseVF(opCtx, logger, conn, tokenID, i.resourcePool, metadata.IsClient(i))
+	}
+	if err == nil && !vfReused {
+		err = assignVF(opCtx, logger, conn, tokenID, i.resourcePool, metadata.IsClient(i))
+	}
 	if err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
@@ -119,7 +130,8 @@ func (i *resourcePoolClient) Request(
 	request.Connection = conn.Clone()
 	if conn, err = next.Client(ctx).Request(ctx, request); err != nil {
 		// Perform local cleanup in case of second Request failed
-		_ = i.resourcePool.close(request.Connection)
+		vfconfig.Delete(ctx, metadata.IsClient(i))
+		_ = i.resourcePool.close(ctx, request.Connection)
 	}
 
 	return conn, err
@@ -127,7 +139,9 @@ func (i *resourcePoolClient) Request(
 
 func (i *resourcePoolClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	rv, err := next.Client(ctx).Close(ctx, conn, opts...)
-	closeErr := i.resourcePool.close(conn)
+
+	vfconfig.Delete(ctx, metadata.IsClient(i))
+	closeErr := i.resourcePool.close(ctx, conn)
 
 	if err != nil && closeErr != nil {
 		return nil, errors.Wrapf(err, "failed to free VF: %v", closeErr)
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/yamlhelper"
+)
+
+func TestResourcePoolClient_Request(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+	resourcePool.mock.On("Free", pfs[pf2PciAddr].Vfs[1].Addr).
+		Return(nil)
+
+	resourcePoolClient := resourcepool.NewClient(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf)
+	counter := new(count.Client)
+	client := chain.NewNetworkServiceClient(metadata.NewClient(), resourcePoolClient, counter)
+
+	// 1. Request, assigned VF is communicated to the endpoint with the second Request
+
+	conn, err := client.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, pfs[pf2PciAddr].Vfs[1].Addr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+	require.Equal(t, vf2KernelDriver, pfs[pf2PciAddr].Vfs[1].Driver)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+	require.Equal(t, 2, counter.Requests())
+
+	// 2. Refresh
+
+	conn, err = client.Request(context.TODO(), &networkservice.NetworkServiceRequest{Connection: conn.Clone()})
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+	require.Equal(t, 3, counter.Requests())
+
+	// 3. Restore with the lost metadata, assigned VF is reused
+
+	restoredClient := chain.NewNetworkServiceClient(metadata.NewClient(), resourcePoolClient, counter)
+
+	conn, err = restoredClient.Request(context.TODO(), &networkservice.NetworkServiceRequest{Connection: conn.Clone()})
+	require.NoError(t, err)
+	require.Equal(t, pfs[pf2PciAddr].Vfs[1].Addr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+	require.Equal(t, 4, counter.Requests())
+
+	// 4. Close
+
+	_, err = restoredClient.Close(context.TODO(), conn)
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Free", 1)
+	require.Equal(t, 1, counter.Closes())
+}
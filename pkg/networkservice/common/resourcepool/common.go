@@ -23,7 +23,9 @@ package resourcepool
 
 import (
 	"context"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -32,114 +34,599 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
 	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
 )
 
-// PCIPool is a pci.Pool interface
-type PCIPool interface {
-	GetPCIFunction(pciAddr string) (sriov.PCIFunction, error)
-	BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error
+// defaultUnhealthyVFTimeout is the default duration for the VF with the failed driver binding to be excluded from the
+// selection
+const defaultUnhealthyVFTimeout = time.Minute
+
+// EventRecorder records the significant resource pool events, e.g. as the forwarder pod Kubernetes Events
+type EventRecorder interface {
+	Warningf(reason, format string, args ...interface{})
 }
 
-// ResourcePool is a resource.Pool interface
-type ResourcePool interface {
-	Select(tokenID string, driverType sriov.DriverType) (string, error)
-	Free(vfPCIAddr string) error
+// Event reasons
+const (
+	// BindFailedReason is the reason of the VF driver binding failure event
+	BindFailedReason = "VFBindFailed"
+	// PoolExhaustedReason is the reason of the no free VF event
+	PoolExhaustedReason = "PoolExhausted"
+	// ResetFailedReason is the reason of the returned VF reset failure event
+	ResetFailedReason = "VFResetFailed"
+)
+
+// TokenVerifier is a tokens.Signer interface
+type TokenVerifier interface {
+	Verify(tokenID string) error
 }
 
 type resourcePoolConfig struct {
-	driverType   sriov.DriverType
-	resourceLock sync.Locker
-	pciPool      PCIPool
-	resourcePool ResourcePool
-	config       *config.Config
-	selectedVFs  map[string]string
+	driverType        sriov.DriverType
+	resourceLock      sync.Locker
+	shardedLock       *ShardedLock
+	tokenLock         *ShardedLock
+	quota             *clientQuota
+	pciPool           PCIPool
+	resourcePool      ResourcePool
+	config            *config.Config
+	tokenVerifier     TokenVerifier
+	driverOverride    bool
+	unhealthyTimeout  time.Duration
+	resetDriverTypes  map[sriov.DriverType]struct{}
+	eventRecorder     EventRecorder
+	exhaustionTracker ExhaustionTracker
+	auditor           Auditor
+	warmVFIO          map[string]uint
+	selectedVFs       map[string]string
+	selectedTokens    map[string]string
+}
+
+func newResourcePoolConfig(
+	driverType sriov.DriverType,
+	resourceLock sync.Locker,
+	pciPool PCIPool,
+	resourcePool ResourcePool,
+	cfg *config.Config,
+	options ...Option,
+) *resourcePoolConfig {
+	c := &resourcePoolConfig{
+		driverType:       driverType,
+		resourceLock:     resourceLock,
+		pciPool:          pciPool,
+		resourcePool:     resourcePool,
+		config:           cfg,
+		unhealthyTimeout: defaultUnhealthyVFTimeout,
+		selectedVFs:      map[string]string{},
+		selectedTokens:   map[string]string{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func (s *resourcePoolConfig) verifyToken(tokenID string) error {
+	if s.tokenVerifier == nil {
+		return nil
+	}
+	return s.tokenVerifier.Verify(tokenID)
 }
 
-func (s *resourcePoolConfig) selectVF(connID string, vfConfig *vfconfig.VFConfig, tokenID string) (vf sriov.PCIFunction, err error) {
-	vfPCIAddr, err := s.resourcePool.Select(tokenID, s.driverType)
+// selectVF selects VF for the connection, it touches only the resource pool state, so it should be called under the
+// resource lock
+func (s *resourcePoolConfig) selectVF(
+	ctx context.Context,
+	connID string,
+	tokenID string,
+	driverType sriov.DriverType,
+	hints *sriov.SelectionHints,
+) (vfPCIAddr, pfPCIAddr string, err error) {
+	// the resource lock can be waited for too long, so there can be no time left for the driver binding
+	if err = ctx.Err(); err != nil {
+		return "", "", errors.Wrapf(err, "no time left to select VF for: %v", driverType)
+	}
+
+	if hintedPool, ok := s.resourcePool.(HintedResourcePool); ok && !hints.IsEmpty() {
+		vfPCIAddr, err = hintedPool.SelectWithHints(tokenID, driverType, hints)
+	} else if !ok && hints != nil && hints.PhysicalNetwork != "" {
+		err = errors.Errorf("resource pool doesn't support physical network isolation: %s", hints.PhysicalNetwork)
+	} else {
+		vfPCIAddr, err = s.resourcePool.Select(tokenID, driverType)
+	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to select VF for: %v", s.driverType)
+		return "", "", errors.Wrapf(err, "failed to select VF for: %v", driverType)
 	}
 	s.selectedVFs[connID] = vfPCIAddr
+	s.selectedTokens[connID] = tokenID
+
+	if pfPCIAddr, _, err = s.lookupVF(vfPCIAddr); err != nil {
+		return "", "", err
+	}
+	return vfPCIAddr, pfPCIAddr, nil
+}
 
+// lookupVF returns the VF parent PF PCI address and the VF number from the config
+func (s *resourcePoolConfig) lookupVF(vfPCIAddr string) (pfPCIAddr string, vfNum int, err error) {
 	for pfPCIAddr, pfCfg := range s.config.PhysicalFunctions {
 		for i, vfCfg := range pfCfg.VirtualFunctions {
-			if vfCfg.Address != vfPCIAddr {
-				continue
+			if vfCfg.Address == vfPCIAddr {
+				return pfPCIAddr, i, nil
 			}
+		}
+	}
+	return "", 0, errors.Errorf("no VF with selected PCI address exists: %v", vfPCIAddr)
+}
 
-			pf, err := s.pciPool.GetPCIFunction(pfPCIAddr)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to get PF: %v", pfPCIAddr)
-			}
-			vfConfig.PFInterfaceName, err = pf.GetNetInterfaceName()
-			if err != nil {
-				return nil, errors.Errorf("failed to get PF net interface name: %v", pfPCIAddr)
-			}
+// vfConfig fills the vfConfig for the selected VF and returns the VF with its PF PCI address
+func (s *resourcePoolConfig) vfConfig(vfPCIAddr string, vfConfig *vfconfig.VFConfig) (sriov.PCIFunction, string, error) {
+	pfPCIAddr, vfNum, err := s.lookupVF(vfPCIAddr)
+	if err != nil {
+		return nil, "", err
+	}
 
-			vf, err := s.pciPool.GetPCIFunction(vfPCIAddr)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to get VF: %v", vfPCIAddr)
-			}
+	pf, err := s.pciPool.GetPCIFunction(pfPCIAddr)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get PF: %v", pfPCIAddr)
+	}
+	vfConfig.PFInterfaceName, err = pf.GetNetInterfaceName()
+	if err != nil {
+		return nil, "", errors.Errorf("failed to get PF net interface name: %v", pfPCIAddr)
+	}
+
+	vf, err := s.pciPool.GetPCIFunction(vfPCIAddr)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get VF: %v", vfPCIAddr)
+	}
+
+	vfConfig.VFNum = vfNum
+
+	return vf, pfPCIAddr, nil
+}
+
+func (s *resourcePoolConfig) close(ctx context.Context, conn *networkservice.Connection) error {
+	// the VF reset can take long, so the VFs are reset before the resource lock is taken, they can't be selected by
+	// the other connections until they are freed
+	resetErrs := s.resetVFs(ctx, conn)
 
-			vfConfig.VFNum = i
+	var freedVFs []*audit.Record
+	// the audit sinks can be slow, so the freed VFs are recorded after the resource lock is released
+	defer func() { s.auditFree(ctx, conn, freedVFs) }()
 
-			return vf, err
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	if s.quota != nil {
+		s.quota.release(conn.GetId())
+	}
+	if s.exhaustionTracker != nil {
+		s.exhaustionTracker.Released(conn.GetId())
+	}
+
+	s.restoreSelections(conn)
+	if _, ok := s.selectedVFs[conn.GetId()]; !ok {
+		return nil
+	}
+
+	var err error
+	for i := 0; ; i++ {
+		selectionID := conn.GetId()
+		if i > 0 {
+			selectionID = extraSelectionID(conn.GetId(), i)
+		}
+		vfPCIAddr, ok := s.selectedVFs[selectionID]
+		if !ok {
+			break
+		}
+		tokenID := s.selectedTokens[selectionID]
+		logger := hwlog.FromContext(hwlog.WithOperation(ctx, conn.GetId(), tokenID, vfPCIAddr),
+			hwlog.ResourcePoolSubsystem)
+		delete(s.selectedVFs, selectionID)
+		delete(s.selectedTokens, selectionID)
+
+		if resetErr, ok := resetErrs[vfPCIAddr]; ok {
+			s.recordWarning(ResetFailedReason, "failed to reset VF %s: %s", vfPCIAddr, resetErr.Error())
+			if unhealthyPool, ok := s.resourcePool.(UnhealthyResourcePool); ok {
+				if err := unhealthyPool.MarkUnhealthy(vfPCIAddr, s.unhealthyTimeout); err != nil {
+					logger.Errorf("failed to mark VF %v unhealthy: %s", vfPCIAddr, err.Error())
+				}
+			}
+		}
+
+		freeErr := hwlog.Operation(logger, "free VF", func() error {
+			return s.resourcePool.Free(vfPCIAddr)
+		})
+		if freeErr == nil {
+			freedVFs = append(freedVFs, &audit.Record{
+				Action:     audit.VFFreed,
+				TokenID:    tokenID,
+				PCIAddress: vfPCIAddr,
+			})
+		} else if err == nil {
+			err = freeErr
 		}
 	}
+	if len(freedVFs) > 0 {
+		s.rewarm(ctx)
+	}
+	return err
+}
 
-	return nil, errors.Errorf("no VF with selected PCI address exists: %v", s.selectedVFs[connID])
+// rewarm reserves and pre-binds the warm IOMMU groups missing after the VFs free. It should be called under the resource
+// lock.
+func (s *resourcePoolConfig) rewarm(ctx context.Context) {
+	warmPool, ok := s.resourcePool.(WarmResourcePool)
+	if !ok || len(s.warmVFIO) == 0 {
+		return
+	}
+	if err := WarmUp(ctx, warmPool, s.pciPool, s.warmVFIO); err != nil {
+		hwlog.FromContext(ctx, hwlog.ResourcePoolSubsystem).Warnf("failed to re-warm IOMMU groups: %s", err.Error())
+	}
 }
 
-func (s *resourcePoolConfig) close(conn *networkservice.Connection) error {
-	vfPCIAddr, ok := s.selectedVFs[conn.GetId()]
+// restoreSelections restores the connection VF selections unknown to the chain element from the connection mechanism
+// if the stateful resource pool still has the same VFs selected for the connection tokens and no other connection has
+// them selected, so the connections established by another forwarder instance can be refreshed and closed. It should
+// be called under the resource lock.
+func (s *resourcePoolConfig) restoreSelections(conn *networkservice.Connection) {
+	if _, ok := s.selectedVFs[conn.GetId()]; ok {
+		return
+	}
+	statefulPool, ok := s.resourcePool.(StatefulResourcePool)
 	if !ok {
+		return
+	}
+
+	tokenIDs, pciAddrs := TokenIDs(conn.GetMechanism()), PCIAddresses(conn.GetMechanism())
+	if len(tokenIDs) == 0 || len(tokenIDs) != len(pciAddrs) {
+		return
+	}
+
+	assignedVFs := map[string]string{} // assignedVFs[tokenID] -> vfPCIAddr
+	for _, a := range statefulPool.State().GetAssignments() {
+		assignedVFs[a.TokenID] = a.VFPCIAddress
+	}
+	selectedVFs := map[string]struct{}{}
+	for _, vfPCIAddr := range s.selectedVFs {
+		selectedVFs[vfPCIAddr] = struct{}{}
+	}
+	for i, tokenID := range tokenIDs {
+		if _, ok := selectedVFs[pciAddrs[i]]; ok || assignedVFs[tokenID] != pciAddrs[i] {
+			return
+		}
+	}
+
+	for i, tokenID := range tokenIDs {
+		selectionID := conn.GetId()
+		if i > 0 {
+			selectionID = extraSelectionID(conn.GetId(), i)
+		}
+		s.selectedVFs[selectionID] = pciAddrs[i]
+		s.selectedTokens[selectionID] = tokenID
+	}
+}
+
+// resetVFs resets the connection VFs if they are used with the driver type the reset is enabled for, returns the
+// reset errors: resetErrs[vfPCIAddr] -> error
+func (s *resourcePoolConfig) resetVFs(ctx context.Context, conn *networkservice.Connection) (resetErrs map[string]error) {
+	resetter, ok := s.pciPool.(VFResetter)
+	if !ok || len(s.resetDriverTypes) == 0 {
+		return nil
+	}
+
+	driverType := s.driverType
+	if assignment, ok := LoadAssignment(conn); ok && assignment.DriverType != "" {
+		driverType = assignment.DriverType
+	}
+	if _, ok := s.resetDriverTypes[driverType]; !ok {
 		return nil
 	}
-	delete(s.selectedVFs, conn.GetId())
 
 	s.resourceLock.Lock()
-	defer s.resourceLock.Unlock()
+	s.restoreSelections(conn)
+	selectedVFs := map[string]string{} // selectedVFs[vfPCIAddr] -> tokenID
+	for i := 0; ; i++ {
+		selectionID := conn.GetId()
+		if i > 0 {
+			selectionID = extraSelectionID(conn.GetId(), i)
+		}
+		vfPCIAddr, ok := s.selectedVFs[selectionID]
+		if !ok {
+			break
+		}
+		selectedVFs[vfPCIAddr] = s.selectedTokens[selectionID]
+	}
+	s.resourceLock.Unlock()
 
-	return s.resourcePool.Free(vfPCIAddr)
+	resetErrs = map[string]error{}
+	for vfPCIAddr, tokenID := range selectedVFs {
+		logger := hwlog.FromContext(hwlog.WithOperation(ctx, conn.GetId(), tokenID, vfPCIAddr),
+			hwlog.ResourcePoolSubsystem)
+		if err := hwlog.Operation(logger, "reset VF", func() error {
+			return resetter.ResetFunction(ctx, vfPCIAddr, driverType)
+		}); err != nil {
+			resetErrs[vfPCIAddr] = err
+		}
+	}
+	return resetErrs
 }
 
-func assignVF(ctx context.Context, logger log.Logger, conn *networkservice.Connection, tokenID string, resourcePool *resourcePoolConfig, isClient bool) error {
-	resourcePool.resourceLock.Lock()
-	defer resourcePool.resourceLock.Unlock()
+func assignVF(ctx context.Context, logger log.Logger, conn *networkservice.Connection, tokenID string, resourcePool *resourcePoolConfig, isClient bool) (err error) {
+	driverType, err := resourcePool.requestDriverType(conn)
+	if err != nil {
+		return err
+	}
 
-	vfConfig := &vfconfig.VFConfig{}
+	ctx, span := tracing.Start(ctx, "resourcepool/assignVF",
+		tracing.TokenIDKey.String(tokenID), tracing.DriverKey.String(string(driverType)))
+	defer func() {
+		resourcePool.trackAssignment(ctx, conn, driverType, err)
+		if err == nil {
+			resourcePool.auditAssignment(ctx, conn, driverType)
+		}
+		tracing.End(span, err)
+	}()
 
-	logger.Infof("trying to select VF for %v", resourcePool.driverType)
-	vf, err := resourcePool.selectVF(conn.GetId(), vfConfig, tokenID)
+	if err = resourcePool.reserveQuota(ctx, conn); err != nil {
+		return err
+	}
+
+	bound, err := resourcePool.bindVF(ctx, logger, conn.GetId(), tokenID, driverType, LoadSelectionHints(ctx, isClient))
 	if err != nil {
 		return err
 	}
+
+	if err := resourcePool.setVFParameters(ctx, conn, bound.vf, bound.pfPCIAddr, bound.iommuGroup, driverType, bound.vfConfig, isClient); err != nil {
+		return err
+	}
+
+	return assignExtraVFs(ctx, logger, conn, resourcePool, driverType, isClient)
+}
+
+// boundVF is a VF selected and bound to the driver
+type boundVF struct {
+	vf         sriov.PCIFunction
+	pfPCIAddr  string
+	iommuGroup uint
+	vfConfig   *vfconfig.VFConfig
+}
+
+// bindVF selects VF for the selectionID and binds it to the driverType driver. If the driver binding fails and the
+// resource pool supports it, the VF is marked unhealthy and the next one is tried until there are no more free VFs.
+func (s *resourcePoolConfig) bindVF(
+	ctx context.Context,
+	logger log.Logger,
+	selectionID, tokenID string,
+	driverType sriov.DriverType,
+	hints *sriov.SelectionHints,
+) (*boundVF, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	if s.tokenLock != nil {
+		// only the requests for the same token are serialized for the whole VF assignment
+		s.tokenLock.Lock(tokenID)
+		defer s.tokenLock.Unlock(tokenID)
+	}
+
+	for {
+		bound, failedVF, err := s.trySelectAndBindVF(ctx, postponeCtxFunc, logger, selectionID, tokenID, driverType, hints)
+		if failedVF == "" || !s.markUnhealthy(logger, selectionID, failedVF) {
+			return bound, err
+		}
+		logger.Warnf("failed to bind VF %v, trying another one: %s", failedVF, err.Error())
+	}
+}
+
+// trySelectAndBindVF selects VF for the selectionID and binds it to the driverType driver, returns the selected VF PCI
+// address as failedVF if the driver binding has failed not because of ctx
+func (s *resourcePoolConfig) trySelectAndBindVF(
+	ctx context.Context,
+	postponeCtxFunc func() (context.Context, context.CancelFunc),
+	logger log.Logger,
+	selectionID, tokenID string,
+	driverType sriov.DriverType,
+	hints *sriov.SelectionHints,
+) (bound *boundVF, failedVF string, err error) {
+	vfConfig := &vfconfig.VFConfig{}
+
+	_, selectSpan := tracing.Start(ctx, "resourcepool/selectVF",
+		tracing.TokenIDKey.String(tokenID), tracing.DriverKey.String(string(driverType)))
+	var vfPCIAddr, pfPCIAddr string
+	s.resourceLock.Lock()
+	logger.Infof("trying to select VF for %v", driverType)
+	err = hwlog.Operation(logger, "select VF", func() (err error) {
+		defer stages.Observe(ctx, stages.VFSelection, time.Now())
+		vfPCIAddr, pfPCIAddr, err = s.selectVF(ctx, selectionID, tokenID, driverType, hints)
+		return err
+	})
+	selectSpan.SetAttributes(tracing.PCIAddressKey.String(vfPCIAddr))
+	tracing.End(selectSpan, err)
+	unlock := s.resourceLock.Unlock
+	if err == nil && s.shardedLock != nil {
+		// VF is already selected, so only the operations on the same PF should be serialized
+		unlock()
+		s.shardedLock.Lock(pfPCIAddr)
+		unlock = func() { s.shardedLock.Unlock(pfPCIAddr) }
+	}
+	defer unlock()
+
+	if err != nil {
+		if errors.Is(err, resource.ErrNoFreeVF) {
+			s.recordWarning(PoolExhaustedReason, "no free VF for the token %s and the %v driver", tokenID, driverType)
+		}
+		return nil, "", err
+	}
+
+	logger = logger.WithField(hwlog.PCIAddressField, vfPCIAddr)
+
+	vf, _, err := s.vfConfig(vfPCIAddr, vfConfig)
+	if err != nil {
+		return nil, "", err
+	}
 	logger.Infof("selected VF: %+v", vf)
 
 	iommuGroup, err := vf.GetIOMMUGroup()
 	if err != nil {
-		return errors.Wrapf(err, "failed to get VF IOMMU group: %v", vf.GetPCIAddress())
+		return nil, "", errors.Wrapf(err, "failed to get VF IOMMU group: %v", vf.GetPCIAddress())
 	}
 
-	if err = resourcePool.pciPool.BindDriver(ctx, iommuGroup, resourcePool.driverType); err != nil {
-		return err
+	bindCtx, bindSpan := tracing.Start(ctx, "resourcepool/bindDriver", tracing.PCIAddressKey.String(vfPCIAddr),
+		tracing.IOMMUGroupKey.Int64(int64(iommuGroup)), tracing.DriverKey.String(string(driverType)))
+	err = hwlog.Operation(logger, "bind driver", func() error {
+		defer stages.Observe(ctx, stages.DriverBind, time.Now())
+		return s.pciPool.BindDriver(hwlog.WithOperation(bindCtx, "", "", vfPCIAddr), iommuGroup, pciDriverType(driverType))
+	})
+	tracing.End(bindSpan, err)
+	if err != nil {
+		if ctx.Err() != nil {
+			rollbackBindDriver(postponeCtxFunc, logger, iommuGroup, s)
+			return nil, "", err
+		}
+		s.recordWarning(BindFailedReason, "failed to bind VF %s to the %v driver: %s", vfPCIAddr, driverType, err.Error())
+		return nil, vfPCIAddr, err
 	}
 
-	switch resourcePool.driverType {
+	return &boundVF{
+		vf:         vf,
+		pfPCIAddr:  pfPCIAddr,
+		iommuGroup: iommuGroup,
+		vfConfig:   vfConfig,
+	}, "", nil
+}
+
+func (s *resourcePoolConfig) recordWarning(reason, format string, args ...interface{}) {
+	if s.eventRecorder != nil {
+		s.eventRecorder.Warningf(reason, format, args...)
+	}
+}
+
+// markUnhealthy marks the VF with the failed driver binding unhealthy and frees it, returns false if the resource pool
+// doesn't support it
+func (s *resourcePoolConfig) markUnhealthy(logger log.Logger, selectionID, vfPCIAddr string) bool {
+	unhealthyPool, ok := s.resourcePool.(UnhealthyResourcePool)
+	if !ok {
+		return false
+	}
+
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	if err := unhealthyPool.MarkUnhealthy(vfPCIAddr, s.unhealthyTimeout); err != nil {
+		logger.Errorf("failed to mark VF %v unhealthy: %s", vfPCIAddr, err.Error())
+		return false
+	}
+	if err := s.resourcePool.Free(vfPCIAddr); err != nil {
+		logger.Errorf("failed to free unhealthy VF %v: %s", vfPCIAddr, err.Error())
+		return false
+	}
+	delete(s.selectedVFs, selectionID)
+	delete(s.selectedTokens, selectionID)
+
+	return true
+}
+
+// rollbackBindDriver rebinds the IOMMU group left partially bound by the cancelled driver binding to the kernel driver
+func rollbackBindDriver(
+	postponeCtxFunc func() (context.Context, context.CancelFunc),
+	logger log.Logger,
+	iommuGroup uint,
+	resourcePool *resourcePoolConfig,
+) {
+	rollbackCtx, cancelRollback := postponeCtxFunc()
+	defer cancelRollback()
+
+	logger.Warnf("driver binding is cancelled, rolling back IOMMU group: %v", iommuGroup)
+	if err := resourcePool.pciPool.BindDriver(rollbackCtx, iommuGroup, sriov.KernelDriver); err != nil {
+		logger.Errorf("failed to roll back IOMMU group %v to the kernel driver: %s", iommuGroup, err.Error())
+	}
+}
+
+// reuseVF restores the VF config for the refresh Request carrying the PCI address already assigned to the connection
+// with the same token, so neither the VF selection nor the driver binding are done again. Returns false if the
+// connection has no such assignment.
+func reuseVF(ctx context.Context, logger log.Logger, conn *networkservice.Connection, tokenID string, resourcePool *resourcePoolConfig, isClient bool) (reused bool, err error) {
+	vfPCIAddr := conn.GetMechanism().GetParameters()[common.PCIAddressKey]
+	if vfPCIAddr == "" {
+		return false, nil
+	}
+
+	driverType, err := resourcePool.requestDriverType(conn)
+	if err != nil {
+		return false, err
+	}
+
+	if resourcePool.tokenLock != nil {
+		resourcePool.tokenLock.Lock(tokenID)
+		defer resourcePool.tokenLock.Unlock(tokenID)
+	}
+
+	resourcePool.resourceLock.Lock()
+	resourcePool.restoreSelections(conn)
+	selected, ok := resourcePool.selectedVFs[conn.GetId()]
+	reusable := ok && selected == vfPCIAddr && resourcePool.selectedTokens[conn.GetId()] == tokenID &&
+		resourcePool.extraVFsReusable(conn)
+	resourcePool.resourceLock.Unlock()
+
+	if !reusable {
+		return false, nil
+	}
+
+	_, span := tracing.Start(ctx, "resourcepool/reuseVF", tracing.TokenIDKey.String(tokenID),
+		tracing.PCIAddressKey.String(vfPCIAddr), tracing.DriverKey.String(string(driverType)))
+	defer func() { tracing.End(span, err) }()
+
+	vfConfig := &vfconfig.VFConfig{}
+	vf, pfPCIAddr, err := resourcePool.vfConfig(vfPCIAddr, vfConfig)
+	if err != nil {
+		return false, err
+	}
+	logger.Infof("reusing assigned VF: %+v", vf)
+
+	iommuGroup, err := vf.GetIOMMUGroup()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get VF IOMMU group: %v", vf.GetPCIAddress())
+	}
+
+	return true, resourcePool.setVFParameters(ctx, conn, vf, pfPCIAddr, iommuGroup, driverType, vfConfig, isClient)
+}
+
+func (s *resourcePoolConfig) setVFParameters(
+	ctx context.Context,
+	conn *networkservice.Connection,
+	vf sriov.PCIFunction,
+	pfPCIAddr string,
+	iommuGroup uint,
+	driverType sriov.DriverType,
+	vfConfig *vfconfig.VFConfig,
+	isClient bool,
+) (err error) {
+	switch driverType {
 	case sriov.KernelDriver:
 		vfConfig.VFInterfaceName, err = vf.GetNetInterfaceName()
 		if err != nil {
 			return errors.Wrapf(err, "failed to get VF net interface name: %v", vf.GetPCIAddress())
 		}
 	case sriov.VFIOPCIDriver:
-		vfio.ToMechanism(conn.GetMechanism()).SetIommuGroup(iommuGroup)
+		// the mechanism can be other than VFIO if the driver is overridden with DriverLabel
+		conn.GetMechanism().GetParameters()[vfio.IommuGroupKey] = strconv.FormatUint(uint64(iommuGroup), 10)
 	}
 	conn.GetMechanism().GetParameters()[common.PCIAddressKey] = vf.GetPCIAddress()
 
+	storeAssignment(conn, &Assignment{
+		VFPCIAddress: vf.GetPCIAddress(),
+		PFPCIAddress: pfPCIAddr,
+		NUMANode:     s.config.PhysicalFunctions[pfPCIAddr].GetNUMANode(),
+		DriverType:   driverType,
+	})
+
 	vfconfig.Store(ctx, isClient, vfConfig)
 
 	return nil
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// DriverLabel is a request label forcing the VF driver type, e.g. "vfio" to leave the kernel mechanism VF bound to the
+// vfio-pci driver for AF_XDP-like setups
+const DriverLabel = "sriovDriver"
+
+var driverLabelValues = map[string]sriov.DriverType{
+	"kernel":                    sriov.KernelDriver,
+	"vfio":                      sriov.VFIOPCIDriver,
+	string(sriov.VFIOPCIDriver): sriov.VFIOPCIDriver,
+}
+
+// DriverFromLabels returns the driver type forced by the DriverLabel, ok is false if the label is not set
+func DriverFromLabels(labels map[string]string) (driverType sriov.DriverType, ok bool, err error) {
+	value, ok := labels[DriverLabel]
+	if !ok {
+		return "", false, nil
+	}
+	if driverType, ok = driverLabelValues[value]; !ok {
+		return "", false, errors.Errorf("unsupported %s label value: %s", DriverLabel, value)
+	}
+	return driverType, true, nil
+}
+
+func (s *resourcePoolConfig) requestDriverType(conn *networkservice.Connection) (sriov.DriverType, error) {
+	if !s.driverOverride {
+		return s.driverType, nil
+	}
+	driverType, ok, err := DriverFromLabels(conn.GetLabels())
+	if err != nil || !ok {
+		return s.driverType, err
+	}
+	return driverType, nil
+}
+
+// pciDriverType returns the driver type the VF PCI function is bound to for the driverType: the vDPA devices are
+// created on the VFs bound to the kernel driver
+func pciDriverType(driverType sriov.DriverType) sriov.DriverType {
+	if driverType.IsVDPA() {
+		return sriov.KernelDriver
+	}
+	return driverType
+}
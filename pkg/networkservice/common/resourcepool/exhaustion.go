@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/identity"
+)
+
+// ExhaustionTracker is an exhaustion.Tracker interface, it can be shared by several resource pool chain elements to
+// attribute the exhaustion to all of their connections
+type ExhaustionTracker interface {
+	Assigned(consumer *exhaustion.Consumer)
+	Released(connID string)
+	Exhausted(requester *exhaustion.Consumer, driverType sriov.DriverType)
+}
+
+// trackAssignment reports the VFs assigned to the connection to the exhaustion tracker, or the exhaustion if the
+// assignment has failed for the lack of free VFs
+func (s *resourcePoolConfig) trackAssignment(ctx context.Context, conn *networkservice.Connection, driverType sriov.DriverType, err error) {
+	if s.exhaustionTracker == nil {
+		return
+	}
+
+	consumer := &exhaustion.Consumer{
+		ConnectionID: conn.GetId(),
+		Identity:     identity.FromPath(ctx, conn.GetPath()),
+		TokenIDs:     TokenIDs(conn.GetMechanism()),
+	}
+	switch {
+	case err == nil:
+		consumer.VFPCIAddresses = PCIAddresses(conn.GetMechanism())
+		s.exhaustionTracker.Assigned(consumer)
+	case errors.Is(err, resource.ErrNoFreeVF):
+		s.exhaustionTracker.Exhausted(consumer, driverType)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+type selectionHintsKey struct{}
+
+// StoreSelectionHints sets the VF selection hints stored in per Connection.Id metadata
+func StoreSelectionHints(ctx context.Context, isClient bool, hints *sriov.SelectionHints) {
+	metadata.Map(ctx, isClient).Store(selectionHintsKey{}, hints)
+}
+
+// LoadSelectionHints returns the VF selection hints stored in per Connection.Id metadata, or nil if no value is present
+func LoadSelectionHints(ctx context.Context, isClient bool) *sriov.SelectionHints {
+	if rawValue, ok := metadata.Map(ctx, isClient).Load(selectionHintsKey{}); ok {
+		return rawValue.(*sriov.SelectionHints)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resetmechanism"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
+)
+
+type migratedVFKey struct{}
+
+// migratedVF is the VF taken over by the new mechanism chain element on the mechanism migration, it is stored in the
+// connection metadata so the old mechanism chain element doesn't free it on close
+type migratedVF struct {
+	vfPCIAddr  string
+	tokenID    string
+	iommuGroup uint
+	driverType sriov.DriverType
+	vfConfig   *vfconfig.VFConfig
+}
+
+// CanMigrate is a resetmechanism.MigrateFunc returning true if the connection VF assigned for the oldMech can be
+// rebound in place for the newMech: both mechanisms should have the same single token and the VF should be assigned
+func CanMigrate(_ context.Context, oldMech, newMech *networkservice.Mechanism) bool {
+	oldTokenIDs, newTokenIDs := TokenIDs(oldMech), TokenIDs(newMech)
+	if len(oldTokenIDs) != 1 || len(newTokenIDs) != 1 || oldTokenIDs[0] != newTokenIDs[0] {
+		return false
+	}
+	return oldMech.GetParameters()[common.PCIAddressKey] != ""
+}
+
+// migrateVF takes over the VF assigned to the connection for the resetmechanism.MigratedFrom mechanism and rebinds it
+// to the requested driver, so the VF is neither freed nor selected again. Returns error if the VF can't be migrated,
+// the old mechanism VF is left untouched in such case.
+func migrateVF(
+	ctx context.Context,
+	postponeCtxFunc func() (context.Context, context.CancelFunc),
+	logger log.Logger,
+	conn *networkservice.Connection,
+	tokenID string,
+	resourcePool *resourcePoolConfig,
+	isClient bool,
+) (err error) {
+	oldMech, _ := resetmechanism.MigratedFrom(ctx)
+	rebindingPool, ok := resourcePool.resourcePool.(RebindingResourcePool)
+	if !ok || !CanMigrate(ctx, oldMech, conn.GetMechanism()) {
+		return errors.Errorf("VF can't be migrated from the %s mechanism", oldMech.GetType())
+	}
+	vfPCIAddr := oldMech.GetParameters()[common.PCIAddressKey]
+
+	driverType, err := resourcePool.requestDriverType(conn)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := tracing.Start(ctx, "resourcepool/migrateVF", tracing.TokenIDKey.String(tokenID),
+		tracing.PCIAddressKey.String(vfPCIAddr), tracing.DriverKey.String(string(driverType)))
+	defer func() {
+		resourcePool.trackAssignment(ctx, conn, driverType, err)
+		if err == nil {
+			resourcePool.auditAssignment(ctx, conn, driverType)
+		}
+		tracing.End(span, err)
+	}()
+
+	logger = logger.WithField(hwlog.PCIAddressField, vfPCIAddr)
+
+	vfConfig := &vfconfig.VFConfig{}
+	vf, pfPCIAddr, err := resourcePool.vfConfig(vfPCIAddr, vfConfig)
+	if err != nil {
+		return err
+	}
+	iommuGroup, err := vf.GetIOMMUGroup()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VF IOMMU group: %v", vf.GetPCIAddress())
+	}
+
+	if resourcePool.tokenLock != nil {
+		resourcePool.tokenLock.Lock(tokenID)
+		defer resourcePool.tokenLock.Unlock(tokenID)
+	}
+
+	if err = resourcePool.reserveQuota(ctx, conn); err != nil {
+		return err
+	}
+
+	migrated := &migratedVF{
+		vfPCIAddr:  vfPCIAddr,
+		tokenID:    tokenID,
+		iommuGroup: iommuGroup,
+	}
+	migrated.vfConfig, _ = vfconfig.Load(ctx, isClient)
+
+	resourcePool.resourceLock.Lock()
+	logger.Infof("migrating VF from the %v mechanism to the %v driver", oldMech.GetType(), driverType)
+	migrated.driverType, err = rebindingPool.Rebind(vfPCIAddr, tokenID, driverType)
+	if err == nil {
+		resourcePool.selectedVFs[conn.GetId()] = vfPCIAddr
+		resourcePool.selectedTokens[conn.GetId()] = tokenID
+	} else if resourcePool.quota != nil {
+		resourcePool.quota.release(conn.GetId())
+	}
+	resourcePool.resourceLock.Unlock()
+	if err != nil {
+		return errors.Wrapf(err, "failed to migrate VF: %v", vfPCIAddr)
+	}
+	metadata.Map(ctx, isClient).Store(migratedVFKey{}, migrated)
+
+	if err = resourcePool.rebindMigratedVF(ctx, logger, pfPCIAddr, iommuGroup, driverType); err == nil {
+		err = resourcePool.setVFParameters(ctx, conn, vf, pfPCIAddr, iommuGroup, driverType, vfConfig, isClient)
+	}
+	if err != nil {
+		resourcePool.revertMigration(ctx, postponeCtxFunc, logger, conn, isClient)
+		return err
+	}
+	return nil
+}
+
+// rebindMigratedVF binds the migrated VF IOMMU group to the driverType driver
+func (s *resourcePoolConfig) rebindMigratedVF(
+	ctx context.Context,
+	logger log.Logger,
+	pfPCIAddr string,
+	iommuGroup uint,
+	driverType sriov.DriverType,
+) error {
+	if s.shardedLock != nil {
+		s.shardedLock.Lock(pfPCIAddr)
+		defer s.shardedLock.Unlock(pfPCIAddr)
+	} else {
+		s.resourceLock.Lock()
+		defer s.resourceLock.Unlock()
+	}
+
+	return hwlog.Operation(logger, "bind driver", func() error {
+		defer stages.Observe(ctx, stages.DriverBind, time.Now())
+		return s.pciPool.BindDriver(ctx, iommuGroup, pciDriverType(driverType))
+	})
+}
+
+// revertMigration returns the VF taken over by migrateVF to the old mechanism chain element: the VF is rebound to the
+// previous driver and the old mechanism VF config is restored
+func (s *resourcePoolConfig) revertMigration(
+	ctx context.Context,
+	postponeCtxFunc func() (context.Context, context.CancelFunc),
+	logger log.Logger,
+	conn *networkservice.Connection,
+	isClient bool,
+) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(migratedVFKey{})
+	if !ok {
+		return
+	}
+	migrated := rawValue.(*migratedVF)
+
+	s.resourceLock.Lock()
+	delete(s.selectedVFs, conn.GetId())
+	delete(s.selectedTokens, conn.GetId())
+	if s.quota != nil {
+		s.quota.release(conn.GetId())
+	}
+	_, err := s.resourcePool.(RebindingResourcePool).Rebind(migrated.vfPCIAddr, migrated.tokenID, migrated.driverType)
+	s.resourceLock.Unlock()
+	if err != nil {
+		logger.Errorf("failed to revert VF %v migration: %s", migrated.vfPCIAddr, err.Error())
+	}
+
+	rollbackCtx, cancelRollback := postponeCtxFunc()
+	defer cancelRollback()
+
+	logger.Warnf("VF migration has failed, rolling back IOMMU group %v to the %v driver", migrated.iommuGroup, migrated.driverType)
+	if err := s.pciPool.BindDriver(rollbackCtx, migrated.iommuGroup, pciDriverType(migrated.driverType)); err != nil {
+		logger.Errorf("failed to roll back IOMMU group %v to the %v driver: %s", migrated.iommuGroup,
+			migrated.driverType, err.Error())
+	}
+
+	if migrated.vfConfig != nil {
+		vfconfig.Store(ctx, isClient, migrated.vfConfig)
+	}
+}
+
+// closeMigrated drops the connection VF taken over by the new mechanism chain element on the mechanism migration
+// without freeing it, returns false if the connection VF is not migrated
+func (s *resourcePoolConfig) closeMigrated(ctx context.Context, conn *networkservice.Connection, isClient bool) bool {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(migratedVFKey{})
+	if !ok {
+		return false
+	}
+	migrated := rawValue.(*migratedVF)
+
+	pciAddrs := PCIAddresses(conn.GetMechanism())
+	if len(pciAddrs) != 1 || pciAddrs[0] != migrated.vfPCIAddr {
+		return false
+	}
+	metadata.Map(ctx, isClient).Delete(migratedVFKey{})
+
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	delete(s.selectedVFs, conn.GetId())
+	delete(s.selectedTokens, conn.GetId())
+	if s.quota != nil {
+		s.quota.release(conn.GetId())
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+// setPrimaryTokenID sets common.DeviceTokenIDKey to the first TokenIDsKey token ID if it is not set, the already set
+// one should be the same as the first TokenIDsKey token ID
+func setPrimaryTokenID(conn *networkservice.Connection) error {
+	params := conn.GetMechanism().GetParameters()
+	tokenIDs := TokenIDs(conn.GetMechanism())
+	if len(tokenIDs) == 0 || params == nil {
+		return nil
+	}
+	if tokenID, ok := params[common.DeviceTokenIDKey]; ok && tokenID != tokenIDs[0] {
+		return errors.Errorf("device token ID %s differs from the first token ID: %s", tokenID, tokenIDs[0])
+	}
+	params[common.DeviceTokenIDKey] = tokenIDs[0]
+	return nil
+}
+
+func extraSelectionID(connID string, i int) string {
+	return connID + "/" + strconv.Itoa(i)
+}
+
+// assignExtraVFs selects and binds VFs for all the TokenIDsKey tokens except the first one
+func assignExtraVFs(
+	ctx context.Context,
+	logger log.Logger,
+	conn *networkservice.Connection,
+	resourcePool *resourcePoolConfig,
+	driverType sriov.DriverType,
+	isClient bool,
+) error {
+	tokenIDs := TokenIDs(conn.GetMechanism())
+	if len(tokenIDs) < 2 {
+		return nil
+	}
+
+	params := conn.GetMechanism().GetParameters()
+	pciAddrs := []string{params[common.PCIAddressKey]}
+	var iommuGroups []string
+	if driverType == sriov.VFIOPCIDriver {
+		iommuGroups = append(iommuGroups, params[vfio.IommuGroupKey])
+	}
+
+	for i, tokenID := range tokenIDs[1:] {
+		if !tokens.IsTokenID(tokenID) {
+			return errors.Errorf("no SR-IOV token ID provided, got: %s", tokenID)
+		}
+		if err := resourcePool.verifyToken(tokenID); err != nil {
+			return err
+		}
+
+		bound, err := resourcePool.bindVF(ctx, logger, extraSelectionID(conn.GetId(), i+1), tokenID, driverType,
+			LoadSelectionHints(ctx, isClient))
+		if err != nil {
+			return err
+		}
+
+		pciAddrs = append(pciAddrs, bound.vf.GetPCIAddress())
+		if driverType == sriov.VFIOPCIDriver {
+			iommuGroups = append(iommuGroups, strconv.FormatUint(uint64(bound.iommuGroup), 10))
+		}
+	}
+
+	params[PCIAddressesKey] = strings.Join(pciAddrs, listSeparator)
+	if iommuGroups != nil {
+		params[IOMMUGroupsKey] = strings.Join(iommuGroups, listSeparator)
+	}
+
+	return nil
+}
+
+// extraVFsReusable returns if the VFs listed in the mechanism are still selected for the connection TokenIDsKey tokens,
+// it should be called under the resource lock
+func (s *resourcePoolConfig) extraVFsReusable(conn *networkservice.Connection) bool {
+	tokenIDs := TokenIDs(conn.GetMechanism())
+	pciAddrs := PCIAddresses(conn.GetMechanism())
+	if len(pciAddrs) != len(tokenIDs) {
+		return false
+	}
+
+	for i := 1; i < len(tokenIDs); i++ {
+		selectionID := extraSelectionID(conn.GetId(), i)
+		if s.selectedVFs[selectionID] != pciAddrs[i] || s.selectedTokens[selectionID] != tokenIDs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// Option is an option for the resource pool chain elements
+type Option func(c *resourcePoolConfig)
+
+// WithTokenVerifier sets verifier for the token IDs, requests with not verified token IDs are rejected
+func WithTokenVerifier(tokenVerifier TokenVerifier) Option {
+	return func(c *resourcePoolConfig) {
+		c.tokenVerifier = tokenVerifier
+	}
+}
+
+// WithShardedLock sets the lock serializing the VF driver binding per PF, so the resource lock is held only for the VF
+// selection and the VFs of the different PFs are bound concurrently
+func WithShardedLock(shardedLock *ShardedLock) Option {
+	return func(c *resourcePoolConfig) {
+		c.shardedLock = shardedLock
+	}
+}
+
+// WithTokenLock sets the lock serializing the VF assignment per token ID, so the requests for the different tokens are
+// serialized only by the resource lock held for the VF selection
+func WithTokenLock(tokenLock *ShardedLock) Option {
+	return func(c *resourcePoolConfig) {
+		c.tokenLock = tokenLock
+	}
+}
+
+// WithDriverOverride enables forcing the VF driver type with the request DriverLabel, overriding the chain element one
+func WithDriverOverride() Option {
+	return func(c *resourcePoolConfig) {
+		c.driverOverride = true
+	}
+}
+
+// WithUnhealthyVFTimeout sets the duration for the VF with the failed driver binding to be excluded from the selection,
+// it is applied only if the resource pool is an UnhealthyResourcePool. Default is 1 minute.
+func WithUnhealthyVFTimeout(timeout time.Duration) Option {
+	return func(c *resourcePoolConfig) {
+		c.unhealthyTimeout = timeout
+	}
+}
+
+// WithVFReset makes the resource pool chain elements trigger the function level reset of the VFs returned by the
+// connections using the driverTypes drivers (VFIO by default), e.g. to recover the stuck rings left by a crashed DPDK
+// application. The VF failed to reset or not sane after the reset is excluded from the selection for the unhealthy VF
+// timeout. It is applied only if the PCI pool is a VFResetter.
+func WithVFReset(driverTypes ...sriov.DriverType) Option {
+	if len(driverTypes) == 0 {
+		driverTypes = []sriov.DriverType{sriov.VFIOPCIDriver}
+	}
+	return func(c *resourcePoolConfig) {
+		c.resetDriverTypes = map[sriov.DriverType]struct{}{}
+		for _, driverType := range driverTypes {
+			c.resetDriverTypes[driverType] = struct{}{}
+		}
+	}
+}
+
+// WithEventRecorder sets the recorder for the VF driver binding failure and the resource pool exhaustion events
+func WithEventRecorder(eventRecorder EventRecorder) Option {
+	return func(c *resourcePoolConfig) {
+		c.eventRecorder = eventRecorder
+	}
+}
+
+// WithExhaustionTracker sets the tracker attributing the resource pool exhaustion to the connections and client identities
+// holding the VFs
+func WithExhaustionTracker(exhaustionTracker ExhaustionTracker) Option {
+	return func(c *resourcePoolConfig) {
+		c.exhaustionTracker = exhaustionTracker
+	}
+}
+
+// WithAuditor sets the auditor recording the VF assignments, driver bindings and VF releases into the audit trail
+func WithAuditor(auditor Auditor) Option {
+	return func(c *resourcePoolConfig) {
+		c.auditor = auditor
+	}
+}
+
+// WithClientQuota limits the number of VFs concurrently assigned to the same client identity (the first path segment
+// token subject) to maxVFs, requests exceeding it fail with ErrQuotaExceeded. The quota is shared by all the resourcepool
+// chain elements created with the same option, so they should share the same resource lock. It is independent of the
+// node-level Device Plugin accounting.
+func WithClientQuota(maxVFs uint) Option {
+	quota := newClientQuota(maxVFs)
+	return func(c *resourcePoolConfig) {
+		c.quota = quota
+	}
+}
+
+// WithWarmVFIO makes the resource pool chain elements re-warm the IOMMU groups on the VFs free for the capability ->
+// count warmVFIO config (see WarmUp), so the warm groups taken by the non-VFIO selections are replaced. It is applied
+// only if the resource pool is a WarmResourcePool.
+func WithWarmVFIO(warmVFIO map[string]uint) Option {
+	return func(c *resourcePoolConfig) {
+		c.warmVFIO = warmVFIO
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepool
+
+import (
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+)
+
+const (
+	// TokenIDsKey is a mechanism parameter key for the comma separated token IDs to select one VF per token for the
+	// same connection, the first token ID is used as the common.DeviceTokenIDKey one. Mechanism chain elements configure
+	// only the first VF, the other ones are granted to the client by the VFIO mechanism and moved into the client netns
+	// by the kernel mechanism.
+	TokenIDsKey = "sriovTokenIDs"
+	// PCIAddressesKey is a mechanism parameter key for the comma separated PCI addresses of the VFs selected for the
+	// TokenIDsKey tokens, in the same order
+	PCIAddressesKey = "sriovPCIAddresses"
+	// IOMMUGroupsKey is a mechanism parameter key for the comma separated IOMMU groups of the VFs selected for the
+	// TokenIDsKey tokens, in the same order, it is set only for the VFs bound to the vfio-pci driver
+	IOMMUGroupsKey = "sriovIOMMUGroups"
+
+	listSeparator = ","
+)
+
+// TokenIDs returns the token IDs requested for the connection, the common.DeviceTokenIDKey one if TokenIDsKey is not set
+func TokenIDs(mechanism *networkservice.Mechanism) []string {
+	params := mechanism.GetParameters()
+	if value, ok := params[TokenIDsKey]; ok && value != "" {
+		return strings.Split(value, listSeparator)
+	}
+	if tokenID, ok := params[common.DeviceTokenIDKey]; ok {
+		return []string{tokenID}
+	}
+	return nil
+}
+
+// PCIAddresses returns the PCI addresses of the VFs selected for the connection in the TokenIDs order
+func PCIAddresses(mechanism *networkservice.Mechanism) []string {
+	params := mechanism.GetParameters()
+	if value, ok := params[PCIAddressesKey]; ok && value != "" {
+		return strings.Split(value, listSeparator)
+	}
+	if pciAddr, ok := params[common.PCIAddressKey]; ok {
+		return []string{pciAddr}
+	}
+	return nil
+}
+
+// IOMMUGroups returns the IOMMU groups of the vfio-pci VFs selected for the connection in the TokenIDs order
+func IOMMUGroups(mechanism *networkservice.Mechanism) []string {
+	params := mechanism.GetParameters()
+	if value, ok := params[IOMMUGroupsKey]; ok && value != "" {
+		return strings.Split(value, listSeparator)
+	}
+	if iommuGroup, ok := params[vfio.IommuGroupKey]; ok {
+		return []string{iommuGroup}
+	}
+	return nil
+}
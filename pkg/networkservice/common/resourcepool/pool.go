@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepool
+
+import (
+	"context"
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+// PCIPool is a pci.Pool interface, it provides the configured PCI functions and binds their drivers.
+// Implementations should be safe for the concurrent use, resourcepool chain elements don't hold the resource lock
+// during the driver binding if the sharded lock is used.
+type PCIPool interface {
+	// GetPCIFunction returns the PCI function with the pciAddr, error if there is no such configured function
+	GetPCIFunction(pciAddr string) (sriov.PCIFunction, error)
+	// BindDriver binds all the PCI functions of the iommuGroup to the driverType driver and waits until they get bound,
+	// returns error if ctx is done before that
+	BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error
+}
+
+// ResourcePool is a resource.Pool interface, it tracks the VFs selected by the tokens.
+// Methods are called under the resource lock.
+type ResourcePool interface {
+	// Select selects a free VF for the driverType driver matching the tokenID and returns its PCI address, returns
+	// error if there is no such free VF
+	Select(tokenID string, driverType sriov.DriverType) (string, error)
+	// Free frees the selected VF, returns error if the VF doesn't exist or is not selected
+	Free(vfPCIAddr string) error
+}
+
+// ExcludingResourcePool is a ResourcePool supporting VF selection on the PFs other than the excluded ones
+type ExcludingResourcePool interface {
+	SelectExcludingPFs(tokenID string, driverType sriov.DriverType, excludedPFs []string) (string, error)
+}
+
+// HintedResourcePool is a ResourcePool supporting VF selection hints
+type HintedResourcePool interface {
+	SelectWithHints(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints) (string, error)
+}
+
+// UnhealthyResourcePool is a ResourcePool supporting the temporary VF exclusion from the selection, so the failed VF
+// driver binding is retried with another VF
+type UnhealthyResourcePool interface {
+	MarkUnhealthy(vfPCIAddr string, duration time.Duration) error
+}
+
+// RebindingResourcePool is a ResourcePool supporting the driver change of the selected VF in place, so the connection
+// mechanism migration (see resetmechanism.WithMigration) keeps the same VF instead of freeing and selecting it again
+type RebindingResourcePool interface {
+	// Rebind changes the driver type of the VF selected for the tokenID and returns the previous one, returns error if
+	// the VF is not selected for the tokenID or its IOMMU group can't be rebound
+	Rebind(vfPCIAddr, tokenID string, driverType sriov.DriverType) (sriov.DriverType, error)
+}
+
+// WarmResourcePool is a ResourcePool supporting the warm VFIO IOMMU groups kept bound to the vfio-pci driver
+type WarmResourcePool interface {
+	ReserveWarmGroups(warmVFIO map[string]uint) []uint
+}
+
+// StatefulResourcePool is a ResourcePool providing the current VF assignments, so the connections established by
+// another forwarder instance (e.g. before the warm-standby takeover restoring its state) are recognized
+type StatefulResourcePool interface {
+	State() *resource.State
+}
+
+// VFResetter is a pci.Pool interface, it resets the VFs returned by the connections before they are selected again
+type VFResetter interface {
+	// ResetFunction triggers the function level reset of the PCI function bound to the driverType driver and returns
+	// error if the function is not sane after the reset
+	ResetFunction(ctx context.Context, pciAddr string, driverType sriov.DriverType) error
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/identity"
+)
+
+// ErrQuotaExceeded is returned when the client identity already has the max number of VFs assigned
+var ErrQuotaExceeded = errors.New("VF quota exceeded")
+
+// clientQuota tracks the number of VFs assigned to the client identities, it should be used under the resource lock
+type clientQuota struct {
+	maxVFs     uint
+	assigned   map[string]uint
+	identities map[string]string
+	counts     map[string]uint
+}
+
+func newClientQuota(maxVFs uint) *clientQuota {
+	return &clientQuota{
+		maxVFs:     maxVFs,
+		assigned:   map[string]uint{},
+		identities: map[string]string{},
+		counts:     map[string]uint{},
+	}
+}
+
+// reserve reserves count VFs for the connection client identity, replacing the connection previous reservation
+func (q *clientQuota) reserve(identity, connID string, count uint) error {
+	q.release(connID)
+
+	if q.assigned[identity]+count > q.maxVFs {
+		return errors.Wrapf(ErrQuotaExceeded, "client %s has %d of %d VFs assigned, requested: %d",
+			identity, q.assigned[identity], q.maxVFs, count)
+	}
+	q.assigned[identity] += count
+	q.identities[connID] = identity
+	q.counts[connID] = count
+	return nil
+}
+
+// release releases the VFs reserved for the connection
+func (q *clientQuota) release(connID string) {
+	identity, ok := q.identities[connID]
+	if !ok {
+		return
+	}
+	q.assigned[identity] -= q.counts[connID]
+	if q.assigned[identity] == 0 {
+		delete(q.assigned, identity)
+	}
+	delete(q.identities, connID)
+	delete(q.counts, connID)
+}
+
+func (s *resourcePoolConfig) reserveQuota(ctx context.Context, conn *networkservice.Connection) error {
+	if s.quota == nil {
+		return nil
+	}
+
+	count := uint(len(TokenIDs(conn.GetMechanism())))
+	if count == 0 {
+		count = 1
+	}
+
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	return s.quota.reserve(identity.FromPath(ctx, conn.GetPath()), conn.GetId(), count)
+}
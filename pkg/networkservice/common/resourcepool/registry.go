@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+// PoolsFactory creates PCIPool and ResourcePool for the SR-IOV config
+type PoolsFactory func(ctx context.Context, cfg *config.Config) (PCIPool, ResourcePool, error)
+
+var (
+	poolsFactoriesMutex sync.RWMutex
+	poolsFactories      = map[string]PoolsFactory{}
+)
+
+// RegisterPools makes the pools implementation available by the name, e.g. for the mocked, vDPA-backed or remote
+// pools selected by the Forwarder configuration. It is intended to be called from the implementation package init
+// function and panics if the name is already registered or the factory is nil.
+func RegisterPools(name string, factory PoolsFactory) {
+	if factory == nil {
+		panic("pools factory cannot be nil")
+	}
+
+	poolsFactoriesMutex.Lock()
+	defer poolsFactoriesMutex.Unlock()
+
+	if _, ok := poolsFactories[name]; ok {
+		panic("pools are already registered: " + name)
+	}
+	poolsFactories[name] = factory
+}
+
+// RegisteredPools returns sorted names of the registered pools implementations
+func RegisteredPools() []string {
+	poolsFactoriesMutex.RLock()
+	defer poolsFactoriesMutex.RUnlock()
+
+	names := make([]string, 0, len(poolsFactories))
+	for name := range poolsFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewPools creates PCIPool and ResourcePool with the pools implementation registered by the name
+func NewPools(ctx context.Context, name string, cfg *config.Config) (PCIPool, ResourcePool, error) {
+	poolsFactoriesMutex.RLock()
+	factory, ok := poolsFactories[name]
+	poolsFactoriesMutex.RUnlock()
+
+	if !ok {
+		return nil, nil, errors.Errorf("no pools are registered: %s", name)
+	}
+
+	pciPool, resourcePool, err := factory(ctx, cfg)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create pools: %s", name)
+	}
+	return pciPool, resourcePool, nil
+}
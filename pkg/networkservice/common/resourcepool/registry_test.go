@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+)
+
+func TestRegisterPools(t *testing.T) {
+	resourcePool := new(resourcePoolMock)
+
+	// the registry is global, so the names are unique for the repeated test runs
+	mockName, failingName := "test-mock-"+uuid.NewString(), "test-failing-"+uuid.NewString()
+
+	resourcepool.RegisterPools(mockName, func(_ context.Context, cfg *config.Config) (resourcepool.PCIPool, resourcepool.ResourcePool, error) {
+		pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+		return pciPool, resourcePool, err
+	})
+	resourcepool.RegisterPools(failingName, func(_ context.Context, _ *config.Config) (resourcepool.PCIPool, resourcepool.ResourcePool, error) {
+		return nil, nil, errors.New("no remote pools")
+	})
+
+	require.Subset(t, resourcepool.RegisteredPools(), []string{failingName, mockName})
+	require.Panics(t, func() {
+		resourcepool.RegisterPools(mockName, func(_ context.Context, _ *config.Config) (resourcepool.PCIPool, resourcepool.ResourcePool, error) {
+			return nil, nil, nil
+		})
+	})
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, rp, err := resourcepool.NewPools(context.TODO(), mockName, conf)
+	require.NoError(t, err)
+	require.Equal(t, resourcePool, rp)
+
+	_, err = pciPool.GetPCIFunction(pf2PciAddr)
+	require.NoError(t, err)
+
+	_, _, err = resourcepool.NewPools(context.TODO(), failingName, conf)
+	require.ErrorContains(t, err, "failed to create pools: "+failingName)
+
+	_, _, err = resourcepool.NewPools(context.TODO(), "unknown", conf)
+	require.Error(t, err)
+}
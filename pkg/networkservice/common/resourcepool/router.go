@@ -0,0 +1,339 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepool
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+// TokenFinder is a token.Pool interface
+type TokenFinder interface {
+	Find(id string) (string, error)
+}
+
+// PoolSet is a set of SR-IOV config, PCI pool and resource pool managing a part of the host hardware, e.g. one NUMA
+// node or one NIC vendor
+type PoolSet struct {
+	// TokenNamePrefix is a prefix of the token names served by the pool set, empty prefix matches all token names
+	TokenNamePrefix string
+	Config          *config.Config
+	PCIPool         PCIPool
+	ResourcePool    ResourcePool
+}
+
+type restoringResourcePool interface {
+	Restore(state *resource.State) error
+}
+
+type statsResourcePool interface {
+	Stats() *resource.Stats
+}
+
+type drainingResourcePool interface {
+	DrainPF(pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error)
+}
+
+// Router is a PCIPool and ResourcePool routing the calls between the pool sets: VF selection is routed by the token
+// name prefix (the longest one wins), all other calls are routed by the PCI address or IOMMU group. The optional pool
+// interfaces are forwarded to the pool sets supporting them, calls routed to the pool set not supporting the interface
+// fail.
+type Router struct {
+	tokenFinder       TokenFinder
+	poolSets          []*PoolSet
+	poolSetsByPCIAddr map[string]*PoolSet
+	poolSetsByIOMMU   map[uint]*PoolSet
+	config            *config.Config
+}
+
+// NewRouter returns a new Router for the pool sets, pool sets configs should not intersect
+func NewRouter(tokenFinder TokenFinder, poolSets ...*PoolSet) (*Router, error) {
+	r := &Router{
+		tokenFinder:       tokenFinder,
+		poolSets:          poolSets,
+		poolSetsByPCIAddr: map[string]*PoolSet{},
+		poolSetsByIOMMU:   map[uint]*PoolSet{},
+		config: &config.Config{
+			PhysicalFunctions: map[string]*config.PhysicalFunction{},
+			MaxTokens:         map[string]uint{},
+			WarmVFIO:          map[string]uint{},
+		},
+	}
+
+	for _, poolSet := range poolSets {
+		for pfPCIAddr, pfCfg := range poolSet.Config.PhysicalFunctions {
+			if _, ok := r.poolSetsByPCIAddr[pfPCIAddr]; ok {
+				return nil, errors.Errorf("PF is configured in more than one pool set: %s", pfPCIAddr)
+			}
+			r.poolSetsByPCIAddr[pfPCIAddr] = poolSet
+			r.config.PhysicalFunctions[pfPCIAddr] = pfCfg
+
+			for _, vfCfg := range pfCfg.VirtualFunctions {
+				if other, ok := r.poolSetsByIOMMU[vfCfg.IOMMUGroup]; ok && other != poolSet {
+					return nil, errors.Errorf("IOMMU group is configured in more than one pool set: %d", vfCfg.IOMMUGroup)
+				}
+				r.poolSetsByPCIAddr[vfCfg.Address] = poolSet
+				r.poolSetsByIOMMU[vfCfg.IOMMUGroup] = poolSet
+			}
+		}
+		for tokenName, limit := range poolSet.Config.MaxTokens {
+			r.config.MaxTokens[tokenName] = limit
+		}
+		for capability, count := range poolSet.Config.WarmVFIO {
+			r.config.WarmVFIO[capability] = count
+		}
+	}
+
+	return r, nil
+}
+
+// Config returns the merged config of all the pool sets
+func (r *Router) Config() *config.Config {
+	return r.config
+}
+
+// GetPCIFunction returns PCI function for the given PCI address from the pool set managing it
+func (r *Router) GetPCIFunction(pciAddr string) (sriov.PCIFunction, error) {
+	poolSet, ok := r.poolSetsByPCIAddr[pciAddr]
+	if !ok {
+		return nil, errors.Errorf("PCI function doesn't exist: %v", pciAddr)
+	}
+	return poolSet.PCIPool.GetPCIFunction(pciAddr)
+}
+
+// BindDriver binds selected IOMMU group to the given driver type with the pool set managing it
+func (r *Router) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	poolSet, ok := r.poolSetsByIOMMU[iommuGroup]
+	if !ok {
+		return errors.Errorf("IOMMU group doesn't exist: %v", iommuGroup)
+	}
+	return poolSet.PCIPool.BindDriver(ctx, iommuGroup, driverType)
+}
+
+// Select selects a virtual function for the given driver type from the pool set matching the token name
+func (r *Router) Select(tokenID string, driverType sriov.DriverType) (string, error) {
+	return r.SelectWithHints(tokenID, driverType, nil)
+}
+
+// SelectWithHints selects a virtual function for the given driver type from the pool set matching the token name
+// preferring the ones matching the hints, if the pool set resource pool supports them
+func (r *Router) SelectWithHints(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints) (string, error) {
+	poolSet, err := r.findPoolSet(tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	hintedPool, ok := poolSet.ResourcePool.(HintedResourcePool)
+	switch {
+	case ok && !hints.IsEmpty():
+		return hintedPool.SelectWithHints(tokenID, driverType, hints)
+	case !ok && hints != nil && hints.PhysicalNetwork != "":
+		return "", errors.Errorf("pool set resource pool doesn't support physical network isolation: %s",
+			poolSet.TokenNamePrefix)
+	}
+	return poolSet.ResourcePool.Select(tokenID, driverType)
+}
+
+// SelectExcludingPFs selects a virtual function for the given driver type from the pool set matching the token name on
+// the PF not listed in excludedPFs, if the pool set resource pool supports it
+func (r *Router) SelectExcludingPFs(tokenID string, driverType sriov.DriverType, excludedPFs []string) (string, error) {
+	poolSet, err := r.findPoolSet(tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	excludingPool, ok := poolSet.ResourcePool.(ExcludingResourcePool)
+	if !ok {
+		return "", errors.Errorf("pool set resource pool doesn't support PF exclusion: %s", poolSet.TokenNamePrefix)
+	}
+	return excludingPool.SelectExcludingPFs(tokenID, driverType, excludedPFs)
+}
+
+func (r *Router) findPoolSet(tokenID string) (*PoolSet, error) {
+	tokenName, err := r.tokenFinder.Find(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	var poolSet *PoolSet
+	for _, ps := range r.poolSets {
+		if strings.HasPrefix(tokenName, ps.TokenNamePrefix) &&
+			(poolSet == nil || len(ps.TokenNamePrefix) > len(poolSet.TokenNamePrefix)) {
+			poolSet = ps
+		}
+	}
+	if poolSet == nil {
+		return nil, errors.Errorf("no pool set found for the token name: %s", tokenName)
+	}
+	return poolSet, nil
+}
+
+// Free marks the virtual function "free" in the pool set managing it
+func (r *Router) Free(vfPCIAddr string) error {
+	poolSet, ok := r.poolSetsByPCIAddr[vfPCIAddr]
+	if !ok {
+		return errors.Errorf("VF doesn't exist: %v", vfPCIAddr)
+	}
+	return poolSet.ResourcePool.Free(vfPCIAddr)
+}
+
+// Rebind changes the virtual function driver type in the pool set managing it, see RebindingResourcePool
+func (r *Router) Rebind(vfPCIAddr, tokenID string, driverType sriov.DriverType) (sriov.DriverType, error) {
+	poolSet, ok := r.poolSetsByPCIAddr[vfPCIAddr]
+	if !ok {
+		return sriov.NoDriver, errors.Errorf("VF doesn't exist: %v", vfPCIAddr)
+	}
+	rebindingPool, ok := poolSet.ResourcePool.(RebindingResourcePool)
+	if !ok {
+		return sriov.NoDriver, errors.Errorf("resource pool doesn't support VF rebinding: %v", vfPCIAddr)
+	}
+	return rebindingPool.Rebind(vfPCIAddr, tokenID, driverType)
+}
+
+// ReserveWarmGroups reserves the warm IOMMU groups in all the pool sets supporting them, the counts are applied per
+// pool set, see WarmResourcePool
+func (r *Router) ReserveWarmGroups(warmVFIO map[string]uint) []uint {
+	var reserved []uint
+	for _, poolSet := range r.poolSets {
+		if warmPool, ok := poolSet.ResourcePool.(WarmResourcePool); ok {
+			reserved = append(reserved, warmPool.ReserveWarmGroups(warmVFIO)...)
+		}
+	}
+	return reserved
+}
+
+// ResetFunction resets the PCI function with the pool set managing it, see VFResetter
+func (r *Router) ResetFunction(ctx context.Context, pciAddr string, driverType sriov.DriverType) error {
+	poolSet, ok := r.poolSetsByPCIAddr[pciAddr]
+	if !ok {
+		return errors.Errorf("PCI function doesn't exist: %v", pciAddr)
+	}
+	resetter, ok := poolSet.PCIPool.(VFResetter)
+	if !ok {
+		return errors.Errorf("PCI pool doesn't support VF reset: %v", pciAddr)
+	}
+	return resetter.ResetFunction(ctx, pciAddr, driverType)
+}
+
+// MarkUnhealthy excludes the virtual function from the selection in the pool set managing it, see
+// UnhealthyResourcePool
+func (r *Router) MarkUnhealthy(vfPCIAddr string, duration time.Duration) error {
+	poolSet, ok := r.poolSetsByPCIAddr[vfPCIAddr]
+	if !ok {
+		return errors.Errorf("VF doesn't exist: %v", vfPCIAddr)
+	}
+	unhealthyPool, ok := poolSet.ResourcePool.(UnhealthyResourcePool)
+	if !ok {
+		return errors.Errorf("resource pool doesn't support unhealthy VFs: %v", vfPCIAddr)
+	}
+	return unhealthyPool.MarkUnhealthy(vfPCIAddr, duration)
+}
+
+// DrainPF drains the physical function in the pool set managing it, see resource.Pool.DrainPF
+func (r *Router) DrainPF(pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error) {
+	poolSet, ok := r.poolSetsByPCIAddr[pfPCIAddr]
+	if !ok {
+		return nil, errors.Errorf("PF doesn't exist: %v", pfPCIAddr)
+	}
+	drainingPool, ok := poolSet.ResourcePool.(drainingResourcePool)
+	if !ok {
+		return nil, errors.Errorf("resource pool doesn't support PF draining: %v", pfPCIAddr)
+	}
+	return drainingPool.DrainPF(pfPCIAddr, duration)
+}
+
+// State returns the VF assignments of all the pool sets supporting them sorted by the VF PCI address, see
+// StatefulResourcePool
+func (r *Router) State() *resource.State {
+	state := &resource.State{
+		Assignments: []*resource.Assignment{},
+	}
+	for _, poolSet := range r.poolSets {
+		if statefulPool, ok := poolSet.ResourcePool.(StatefulResourcePool); ok {
+			state.Assignments = append(state.Assignments, statefulPool.State().GetAssignments()...)
+		}
+	}
+	sort.Slice(state.Assignments, func(i, k int) bool {
+		return state.Assignments[i].VFPCIAddress < state.Assignments[k].VFPCIAddress
+	})
+	return state
+}
+
+// Restore restores the state assignments in the pool sets managing the VFs, see resource.Pool.Restore. Every pool set
+// supporting it is restored, so the VFs missing from the state are freed. All the pool sets are tried, the first
+// failure is returned.
+func (r *Router) Restore(state *resource.State) (err error) {
+	states := map[*PoolSet]*resource.State{}
+	for _, a := range state.GetAssignments() {
+		poolSet, ok := r.poolSetsByPCIAddr[a.VFPCIAddress]
+		if !ok {
+			if err == nil {
+				err = errors.Errorf("VF doesn't exist: %v", a.VFPCIAddress)
+			}
+			continue
+		}
+		if states[poolSet] == nil {
+			states[poolSet] = new(resource.State)
+		}
+		states[poolSet].Assignments = append(states[poolSet].Assignments, a)
+	}
+
+	for _, poolSet := range r.poolSets {
+		restoringPool, ok := poolSet.ResourcePool.(restoringResourcePool)
+		if !ok {
+			if states[poolSet] != nil && err == nil {
+				err = errors.Errorf("resource pool doesn't support state restore: %s", poolSet.TokenNamePrefix)
+			}
+			continue
+		}
+		poolSetState := states[poolSet]
+		if poolSetState == nil {
+			poolSetState = new(resource.State)
+		}
+		if restoreErr := restoringPool.Restore(poolSetState); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}
+	return err
+}
+
+// Stats returns the VF counts of all the pool sets supporting them, the failed VF selections are summed up
+func (r *Router) Stats() *resource.Stats {
+	stats := &resource.Stats{
+		PhysicalFunctions: map[string]*resource.PFStats{},
+	}
+	for _, poolSet := range r.poolSets {
+		statsPool, ok := poolSet.ResourcePool.(statsResourcePool)
+		if !ok {
+			continue
+		}
+		poolSetStats := statsPool.Stats()
+		for pfPCIAddr, pfStats := range poolSetStats.PhysicalFunctions {
+			stats.PhysicalFunctions[pfPCIAddr] = pfStats
+		}
+		stats.SelectFailures += poolSetStats.SelectFailures
+	}
+	return stats
+}
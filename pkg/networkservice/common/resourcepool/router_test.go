@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+const (
+	vendorATokenID = "token-a"
+	vendorBTokenID = "token-b"
+)
+
+type tokenFinder map[string]string
+
+func (f tokenFinder) Find(id string) (string, error) {
+	return f[id], nil
+}
+
+func newTestPoolSet(prefix, pfPCIAddr, vfPCIAddr string, iommuGroup uint) (*resourcepool.PoolSet, *resourcePoolMock) {
+	resourcePool := new(resourcePoolMock)
+	return &resourcepool.PoolSet{
+		TokenNamePrefix: prefix,
+		Config: &config.Config{
+			PhysicalFunctions: map[string]*config.PhysicalFunction{
+				pfPCIAddr: {
+					VirtualFunctions: []*config.VirtualFunction{
+						{Address: vfPCIAddr, IOMMUGroup: iommuGroup},
+					},
+				},
+			},
+		},
+		ResourcePool: resourcePool,
+	}, resourcePool
+}
+
+func TestRouter(t *testing.T) {
+	poolSetA, resourcePoolA := newTestPoolSet("service.domain.a/", "0000:00:01.0", "0000:00:01.1", 1)
+	poolSetB, resourcePoolB := newTestPoolSet("", "0000:00:02.0", "0000:00:02.1", 2)
+
+	router, err := resourcepool.NewRouter(tokenFinder{
+		vendorATokenID: "service.domain.a/10G",
+		vendorBTokenID: "service.domain.b/10G",
+	}, poolSetA, poolSetB)
+	require.NoError(t, err)
+	require.Len(t, router.Config().PhysicalFunctions, 2)
+
+	resourcePoolA.mock.On("Select", vendorATokenID, sriov.KernelDriver).Return("0000:00:01.1", nil)
+	resourcePoolB.mock.On("Select", vendorBTokenID, sriov.KernelDriver).Return("0000:00:02.1", nil)
+
+	vfPCIAddr, err := router.Select(vendorATokenID, sriov.KernelDriver)
+	require.NoError(t, err)
+	require.Equal(t, "0000:00:01.1", vfPCIAddr)
+
+	vfPCIAddr, err = router.Select(vendorBTokenID, sriov.KernelDriver)
+	require.NoError(t, err)
+	require.Equal(t, "0000:00:02.1", vfPCIAddr)
+
+	resourcePoolA.mock.On("Free", "0000:00:01.1").Return(nil)
+	require.NoError(t, router.Free("0000:00:01.1"))
+	resourcePoolA.mock.AssertNumberOfCalls(t, "Free", 1)
+	resourcePoolB.mock.AssertNumberOfCalls(t, "Free", 0)
+
+	require.Error(t, router.Free("0000:00:03.1"))
+}
+
+func TestRouter_Intersection(t *testing.T) {
+	poolSetA, _ := newTestPoolSet("a", "0000:00:01.0", "0000:00:01.1", 1)
+	poolSetB, _ := newTestPoolSet("b", "0000:00:01.0", "0000:00:01.1", 1)
+
+	_, err := resourcepool.NewRouter(tokenFinder{}, poolSetA, poolSetB)
+	require.Error(t, err)
+}
+
+func TestRouter_OptionalInterfaces(t *testing.T) {
+	poolSetA, _ := newTestPoolSet("service.domain.a/", "0000:00:01.0", "0000:00:01.1", 1)
+	resourcePoolA := new(routedResourcePoolMock)
+	poolSetA.ResourcePool = resourcePoolA
+	pciPoolA := new(resettingPCIPoolStub)
+	poolSetA.PCIPool = pciPoolA
+	poolSetB, _ := newTestPoolSet("", "0000:00:02.0", "0000:00:02.1", 2)
+
+	router, err := resourcepool.NewRouter(tokenFinder{}, poolSetA, poolSetB)
+	require.NoError(t, err)
+
+	require.NoError(t, router.ResetFunction(context.Background(), "0000:00:01.1", sriov.VFIOPCIDriver))
+	require.Equal(t, []string{"0000:00:01.1"}, pciPoolA.resets)
+	require.Error(t, router.ResetFunction(context.Background(), "0000:00:02.1", sriov.VFIOPCIDriver))
+
+	resourcePoolA.mock.On("MarkUnhealthy", "0000:00:01.1", time.Minute).Return(nil)
+	require.NoError(t, router.MarkUnhealthy("0000:00:01.1", time.Minute))
+	require.Error(t, router.MarkUnhealthy("0000:00:02.1", time.Minute))
+
+	assignment := &resource.Assignment{VFPCIAddress: "0000:00:01.1", TokenID: vendorATokenID, DriverType: sriov.KernelDriver}
+	resourcePoolA.mock.On("DrainPF", "0000:00:01.0", time.Minute).Return([]*resource.Assignment{assignment}, nil)
+	assignments, err := router.DrainPF("0000:00:01.0", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []*resource.Assignment{assignment}, assignments)
+	_, err = router.DrainPF("0000:00:02.0", time.Minute)
+	require.Error(t, err)
+
+	resourcePoolA.state = &resource.State{Assignments: []*resource.Assignment{assignment}}
+	require.Equal(t, resourcePoolA.state, router.State())
+
+	resourcePoolA.stats = &resource.Stats{
+		PhysicalFunctions: map[string]*resource.PFStats{"0000:00:01.0": {VFs: 1}},
+		SelectFailures:    2,
+	}
+	require.Equal(t, resourcePoolA.stats, router.Stats())
+
+	// The pool set without the assignments is restored with the empty state
+	resourcePoolA.mock.On("Restore", new(resource.State)).Return(nil).Once()
+	require.NoError(t, router.Restore(new(resource.State)))
+
+	resourcePoolA.mock.On("Restore", &resource.State{Assignments: []*resource.Assignment{assignment}}).Return(nil).Once()
+	require.NoError(t, router.Restore(&resource.State{Assignments: []*resource.Assignment{assignment}}))
+	resourcePoolA.mock.AssertNumberOfCalls(t, "Restore", 2)
+
+	// The pool set not supporting the restore can't restore its assignments
+	resourcePoolA.mock.On("Restore", new(resource.State)).Return(nil).Once()
+	require.Error(t, router.Restore(&resource.State{Assignments: []*resource.Assignment{
+		{VFPCIAddress: "0000:00:02.1", TokenID: vendorBTokenID, DriverType: sriov.KernelDriver},
+	}}))
+}
+
+type routedResourcePoolMock struct {
+	resourcePoolMock
+
+	state *resource.State
+	stats *resource.Stats
+}
+
+func (rp *routedResourcePoolMock) MarkUnhealthy(vfPCIAddr string, duration time.Duration) error {
+	return rp.mock.Called(vfPCIAddr, duration).Error(0)
+}
+
+func (rp *routedResourcePoolMock) DrainPF(pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error) {
+	rv := rp.mock.Called(pfPCIAddr, duration)
+	return rv.Get(0).([]*resource.Assignment), rv.Error(1)
+}
+
+func (rp *routedResourcePoolMock) State() *resource.State {
+	return rp.state
+}
+
+func (rp *routedResourcePoolMock) Restore(state *resource.State) error {
+	return rp.mock.Called(state).Error(0)
+}
+
+func (rp *routedResourcePoolMock) Stats() *resource.Stats {
+	return rp.stats
+}
+
+type resettingPCIPoolStub struct {
+	resourcepool.PCIPool
+
+	resets []string
+}
+
+func (p *resettingPCIPoolStub) ResetFunction(_ context.Context, pciAddr string, _ sriov.DriverType) error {
+	p.resets = append(p.resets, pciAddr)
+	return nil
+}
@@ -25,6 +25,7 @@ package resourcepool
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
@@ -34,10 +35,14 @@ import (
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
-	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
 
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resetmechanism"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
@@ -52,42 +57,75 @@ func NewServer(
 	pciPool PCIPool,
 	resourcePool ResourcePool,
 	cfg *config.Config,
+	options ...Option,
 ) networkservice.NetworkServiceServer {
-	return &resourcePoolServer{resourcePool: &resourcePoolConfig{
-		driverType:   driverType,
-		resourceLock: resourceLock,
-		pciPool:      pciPool,
-		resourcePool: resourcePool,
-		config:       cfg,
-		selectedVFs:  map[string]string{},
-	}}
+	return &resourcePoolServer{
+		resourcePool: newResourcePoolConfig(driverType, resourceLock, pciPool, resourcePool, cfg, options...),
+	}
 }
 
 func (s *resourcePoolServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	logger := log.FromContext(ctx).WithField("resourcePoolServer", "Request")
 	conn := request.GetConnection()
-	tokenID, ok := conn.GetMechanism().GetParameters()[common.DeviceTokenIDKey]
-	if !ok {
-		return nil, errors.New("no token ID provided")
-	}
-	if !tokens.IsTokenID(tokenID) {
-		return nil, errors.Errorf("no SR-IOV token ID provided, got: %s", tokenID)
+	tokenID, err := lookupTokenID(ctx, conn, s.resourcePool)
+	if err != nil {
+		return nil, err
 	}
 
-	_, vfExists := vfconfig.Load(ctx, metadata.IsClient(s))
+	opCtx := hwlog.WithOperation(ctx, conn.GetId(), tokenID, "")
+	logger := hwlog.FromContext(opCtx, hwlog.ResourcePoolSubsystem).WithField("resourcePoolServer", "Request")
 
-	if !vfExists {
-		err := assignVF(ctx, logger, conn, tokenID, s.resourcePool, metadata.IsClient(s))
-		if err != nil {
-			_ = s.resourcePool.close(conn)
+	_, vfExists := vfconfig.Load(ctx, metadata.IsClient(s))
+	_, vfMigrated := resetmechanism.MigratedFrom(ctx)
+
+	var vfReused bool
+	var postponeCtxFunc func() (context.Context, context.CancelFunc)
+	switch {
+	case vfMigrated:
+		// the VF config is stored by the old mechanism chain element, the VF is taken over from it
+		vfExists = false
+		postponeCtxFunc = postpone.ContextWithValues(ctx)
+		if err = migrateVF(opCtx, postponeCtxFunc, logger, conn, tokenID, s.resourcePool, metadata.IsClient(s)); err != nil {
 			return nil, err
 		}
+	case !vfExists:
+		// refresh Request can carry the already assigned VF, so it should be only validated
+		if vfReused, err = reuseVF(opCtx, logger, conn, tokenID, s.resourcePool, metadata.IsClient(s)); err != nil {
+			return nil, err
+		}
+	}
+
+	var registered bool
+	if !vfExists && !vfReused {
+		if !vfMigrated {
+			err = assignVF(opCtx, logger, conn, tokenID, s.resourcePool, metadata.IsClient(s))
+			if err != nil {
+				_ = s.resourcePool.close(ctx, conn)
+				return nil, err
+			}
+		}
+		assignedConn := conn
+		registered = cleanup.Register(ctx, s, func(ctx context.Context) {
+			vfconfig.Delete(ctx, metadata.IsClient(s))
+			_ = s.resourcePool.close(ctx, assignedConn)
+		})
 	}
 
-	conn, err := next.Server(ctx).Request(ctx, request)
+	conn, err = next.Server(ctx).Request(ctx, request)
+	if err != nil && vfMigrated {
+		// the old mechanism is kept on the failed migration, so the VF should be returned to it
+		cleanup.Unregister(ctx, s)
+		s.resourcePool.revertMigration(ctx, postponeCtxFunc, logger, request.GetConnection(), metadata.IsClient(s))
+		return nil, err
+	}
 	if err != nil && !vfExists {
+		if registered {
+			return nil, err
+		}
 		vfconfig.Delete(ctx, metadata.IsClient(s))
-		if closeErr := s.resourcePool.close(conn); closeErr != nil {
+		if vfReused {
+			return nil, err
+		}
+		if closeErr := s.resourcePool.close(ctx, request.GetConnection()); closeErr != nil {
 			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
 		}
 		return nil, err
@@ -96,11 +134,36 @@ func (s *resourcePoolServer) Request(ctx context.Context, request *networkservic
 	return conn, err
 }
 
+// lookupTokenID returns the verified SR-IOV token ID requested for the connection
+func lookupTokenID(ctx context.Context, conn *networkservice.Connection, resourcePool *resourcePoolConfig) (string, error) {
+	defer stages.Observe(ctx, stages.TokenLookup, time.Now())
+
+	if err := setPrimaryTokenID(conn); err != nil {
+		return "", err
+	}
+	tokenID, ok := conn.GetMechanism().GetParameters()[common.DeviceTokenIDKey]
+	if !ok {
+		return "", errors.New("no token ID provided")
+	}
+	if !tokens.IsTokenID(tokenID) {
+		return "", errors.Errorf("no SR-IOV token ID provided, got: %s", tokenID)
+	}
+	if err := resourcePool.verifyToken(tokenID); err != nil {
+		return "", err
+	}
+	return tokenID, nil
+}
+
 func (s *resourcePoolServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	_, err := next.Server(ctx).Close(ctx, conn)
 
-	vfconfig.Delete(ctx, metadata.IsClient(s))
-	closeErr := s.resourcePool.close(conn)
+	var closeErr error
+	// the VF and its config are taken over by the new mechanism chain element on the mechanism migration
+	if !resetmechanism.IsMigrating(ctx) || !s.resourcePool.closeMigrated(ctx, conn, metadata.IsClient(s)) {
+		vfconfig.Delete(ctx, metadata.IsClient(s))
+		closeErr = s.resourcePool.close(ctx, conn)
+	}
+	cleanup.Unregister(ctx, s)
 
 	if err != nil && closeErr != nil {
 		return nil, errors.Wrapf(err, "failed to free VF: %v", closeErr)
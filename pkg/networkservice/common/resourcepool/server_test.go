@@ -23,27 +23,45 @@ package resourcepool_test
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
 	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/mechanisms"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
 	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
 
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/cleanup"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resetmechanism"
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/yamlhelper"
 )
 
@@ -162,6 +180,15 @@ func TestResourcePoolServer_Request(t *testing.T) {
 			resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
 			sample.test(t, pfs, resourceServerChainElem.getVFConfig(), conn)
 
+			assignment, ok := resourcepool.LoadAssignment(conn)
+			require.True(t, ok)
+			require.Equal(t, &resourcepool.Assignment{
+				VFPCIAddress: pfs[pf2PciAddr].Vfs[1].Addr,
+				PFPCIAddress: pf2PciAddr,
+				NUMANode:     -1,
+				DriverType:   sample.driverType,
+			}, assignment)
+
 			// 2. Close
 
 			resourcePool.mock.On("Free", pfs[pf2PciAddr].Vfs[1].Addr).
@@ -175,6 +202,1089 @@ func TestResourcePoolServer_Request(t *testing.T) {
 	}
 }
 
+func TestResourcePoolServer_Request_TokenVerifier(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	signer := tokens.NewSigner([]byte("key"))
+	signedTokenID := signer.Sign(tokenID)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", signedTokenID, sriov.KernelDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithTokenVerifier(signer)),
+	)
+
+	request := func(id, tokenID string) error {
+		_, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Mechanism: &networkservice.Mechanism{
+					Type: kernel.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+		return err
+	}
+
+	require.Error(t, request("id-1", tokenID))
+	require.Error(t, request("id-2", tokens.NewSigner([]byte("other")).Sign(tokenID)))
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 0)
+
+	require.NoError(t, request("id-3", signedTokenID))
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+}
+
+func TestResourcePoolServer_Request_ShardedLock(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+
+	resourceLock := new(sync.Mutex)
+	shardedLock := resourcepool.NewShardedLock()
+	resourceServerChainElem := newVFResourceServer()
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, conf,
+			resourcepool.WithShardedLock(shardedLock)),
+		resourceServerChainElem,
+	)
+
+	conn, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	samples[0].test(t, pfs, resourceServerChainElem.getVFConfig(), conn)
+
+	require.True(t, resourceLock.TryLock())
+	resourceLock.Unlock()
+
+	locked := make(chan struct{})
+	go func() {
+		shardedLock.Lock(pf2PciAddr)
+		defer shardedLock.Unlock(pf2PciAddr)
+		close(locked)
+	}()
+	require.Eventually(t, func() bool {
+		select {
+		case <-locked:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestResourcePoolServer_Request_DriverOverride(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithDriverOverride()),
+	)
+
+	request := func(id, driver string) (*networkservice.Connection, error) {
+		return server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Labels: map[string]string{
+					resourcepool.DriverLabel: driver,
+				},
+				Mechanism: &networkservice.Mechanism{
+					Type: kernel.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+	}
+
+	_, err = request("id-1", "unknown")
+	require.Error(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 0)
+
+	conn, err := request("id-2", "vfio")
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+
+	require.Equal(t, string(sriov.VFIOPCIDriver), pfs[pf2PciAddr].Vfs[1].Driver)
+	require.Equal(t, strconv.FormatUint(uint64(pfs[pf2PciAddr].Vfs[1].IOMMUGroup), 10),
+		conn.GetMechanism().GetParameters()[vfio.IommuGroupKey])
+}
+
+func TestResourcePoolServer_Request_Refresh(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+
+	resourcePoolServer := resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), pciPool, resourcePool, conf)
+
+	// metadata is lost between the requests, so the refresh can only rely on the mechanism parameters
+	request := func(conn *networkservice.Connection) (*networkservice.Connection, *vfconfig.VFConfig, error) {
+		resourceServerChainElem := newVFResourceServer()
+		conn, err := chain.NewNetworkServiceServer(
+			metadata.NewServer(),
+			resourcePoolServer,
+			resourceServerChainElem,
+		).Request(context.TODO(), &networkservice.NetworkServiceRequest{Connection: conn})
+		return conn, resourceServerChainElem.getVFConfig(), err
+	}
+
+	conn, _, err := request(&networkservice.Connection{
+		Id: "id",
+		Mechanism: &networkservice.Mechanism{
+			Type: vfio.MECHANISM,
+			Parameters: map[string]string{
+				common.DeviceTokenIDKey: tokenID,
+			},
+		},
+	})
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+	require.Equal(t, pfs[pf2PciAddr].Vfs[1].Addr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+
+	// driver is not bound again on refresh
+	pfs[pf2PciAddr].Vfs[1].Driver = vf2KernelDriver
+
+	conn, vfConfig, err := request(conn.Clone())
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+	require.Equal(t, vf2KernelDriver, pfs[pf2PciAddr].Vfs[1].Driver)
+	require.Equal(t, &vfconfig.VFConfig{
+		PFInterfaceName: pfs[pf2PciAddr].IfName,
+		VFNum:           1,
+	}, vfConfig)
+	require.Equal(t, pfs[pf2PciAddr].Vfs[1].IOMMUGroup, vfio.ToMechanism(conn.GetMechanism()).GetIommuGroup())
+
+	// other connection with the same PCI address is not refreshed
+	other := conn.Clone()
+	other.Id = "other-id"
+	_, _, err = request(other)
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 2)
+}
+
+func TestResourcePoolServer_Request_CancelledBindDriver(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+	resourcePool.mock.On("Free", pfs[pf2PciAddr].Vfs[1].Addr).
+		Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), &cancellingPCIPool{
+			Pool:   pciPool,
+			cancel: cancel,
+		}, resourcePool, conf),
+	)
+
+	_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	resourcePool.mock.AssertNumberOfCalls(t, "Free", 1)
+	require.Equal(t, vf2KernelDriver, pfs[pf2PciAddr].Vfs[0].Driver)
+	require.Equal(t, vf2KernelDriver, pfs[pf2PciAddr].Vfs[1].Driver)
+
+	_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+}
+
+func TestResourcePoolServer_Request_BindDriverRetry(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	failedVFPCIAddr := pfs[pf2PciAddr].Vfs[1].Addr
+	vfPCIAddr := pfs["0000:00:01.0"].Vfs[0].Addr
+
+	eventRecorder := new(eventRecorderStub)
+
+	resourcePool := new(unhealthyResourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(failedVFPCIAddr, nil).Once()
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(vfPCIAddr, nil).Once()
+	resourcePool.mock.On("MarkUnhealthy", mock.Anything, time.Hour).
+		Return(nil)
+	resourcePool.mock.On("Free", mock.Anything).
+		Return(nil)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), &failingPCIPool{
+			Pool:       pciPool,
+			iommuGroup: 2,
+		}, resourcePool, conf,
+			resourcepool.WithUnhealthyVFTimeout(time.Hour),
+			resourcepool.WithEventRecorder(eventRecorder),
+		),
+	)
+
+	request := func(id string) (*networkservice.Connection, error) {
+		return server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Mechanism: &networkservice.Mechanism{
+					Type: vfio.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+	}
+
+	conn, err := request("id-1")
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+	resourcePool.mock.AssertCalled(t, "MarkUnhealthy", failedVFPCIAddr, time.Hour)
+	resourcePool.mock.AssertCalled(t, "Free", failedVFPCIAddr)
+
+	// the failed VF is selected again, but there are no more candidates
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(failedVFPCIAddr, nil).Once()
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return("", resource.ErrNoFreeVF).Once()
+
+	_, err = request("id-2")
+	require.ErrorIs(t, err, resource.ErrNoFreeVF)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 4)
+	resourcePool.mock.AssertNumberOfCalls(t, "MarkUnhealthy", 2)
+
+	require.Equal(t, []string{
+		resourcepool.BindFailedReason,
+		resourcepool.BindFailedReason,
+		resourcepool.PoolExhaustedReason,
+	}, eventRecorder.reasons)
+}
+
+func TestResourcePoolServer_Close_VFReset(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	vf := pfs[pf2PciAddr].Vfs[1]
+
+	eventRecorder := new(eventRecorderStub)
+
+	resourcePool := new(unhealthyResourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(vf.Addr, nil)
+	resourcePool.mock.On("MarkUnhealthy", vf.Addr, time.Hour).
+		Return(nil)
+	resourcePool.mock.On("Free", vf.Addr).
+		Return(nil)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithVFReset(),
+			resourcepool.WithUnhealthyVFTimeout(time.Hour),
+			resourcepool.WithEventRecorder(eventRecorder),
+		),
+	)
+
+	request := func(id string) *networkservice.Connection {
+		conn, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Mechanism: &networkservice.Mechanism{
+					Type: vfio.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		return conn
+	}
+
+	_, err = server.Close(context.TODO(), request("id-1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, vf.Resets)
+	resourcePool.mock.AssertNumberOfCalls(t, "Free", 1)
+	resourcePool.mock.AssertNotCalled(t, "MarkUnhealthy", vf.Addr, time.Hour)
+
+	// the VF doesn't respond after the reset, it is excluded from the selection
+	conn := request("id-2")
+	vf.ConfigSpace = sriovtest.UnresponsiveConfigSpace()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = server.Close(closeCtx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 2, vf.Resets)
+	resourcePool.mock.AssertNumberOfCalls(t, "Free", 2)
+	resourcePool.mock.AssertCalled(t, "MarkUnhealthy", vf.Addr, time.Hour)
+	require.Equal(t, []string{resourcepool.ResetFailedReason}, eventRecorder.reasons)
+}
+
+func TestResourcePoolServer_Close_WarmVFIO(t *testing.T) {
+	const pf1PciAddr = "0000:00:01.0"
+
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	vf := pfs[pf2PciAddr].Vfs[1]
+	warmVF := pfs[pf1PciAddr].Vfs[0]
+	warmVFIO := map[string]uint{"10G": 1}
+
+	resourcePool := new(warmResourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(vf.Addr, nil)
+	resourcePool.mock.On("Free", vf.Addr).
+		Return(nil)
+	resourcePool.mock.On("ReserveWarmGroups", warmVFIO).
+		Return([]uint{warmVF.IOMMUGroup})
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithWarmVFIO(warmVFIO)),
+	)
+
+	conn, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	resourcePool.mock.AssertNotCalled(t, "ReserveWarmGroups", warmVFIO)
+
+	_, err = server.Close(context.TODO(), conn)
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "ReserveWarmGroups", 1)
+	require.Equal(t, "vfio-pci", warmVF.Driver)
+}
+
+func TestResourcePoolServer_Request_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	vfPCIAddr := pfs[pf2PciAddr].Vfs[1].Addr
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(vfPCIAddr, nil)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), pciPool, resourcePool, conf),
+	)
+
+	_, err = server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Len(t, spans, 3)
+
+	assignSpan := spans["resourcepool/assignVF"]
+	require.Contains(t, assignSpan.Attributes(), tracing.TokenIDKey.String(tokenID))
+	require.Contains(t, assignSpan.Attributes(), tracing.DriverKey.String(string(sriov.VFIOPCIDriver)))
+
+	for _, name := range []string{"resourcepool/selectVF", "resourcepool/bindDriver"} {
+		require.Equal(t, assignSpan.SpanContext().SpanID(), spans[name].Parent().SpanID())
+		require.Contains(t, spans[name].Attributes(), tracing.PCIAddressKey.String(vfPCIAddr))
+	}
+	require.Contains(t, spans["resourcepool/bindDriver"].Attributes(), tracing.IOMMUGroupKey.Int64(2))
+}
+
+func TestResourcePoolServer_Request_MultipleTokens(t *testing.T) {
+	const pf1PciAddr, otherTokenID = "0000:00:01.0", "sriov-yyyyyyyy-yyyy-yyyy-yyyy-yyyyyyyyyyyy"
+
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+	resourcePool.mock.On("Select", otherTokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf1PciAddr].Vfs[0].Addr, nil)
+	resourcePool.mock.On("Free", mock.Anything).
+		Return(nil)
+
+	resourcePoolServer := resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), pciPool, resourcePool, conf)
+	request := func(conn *networkservice.Connection) (*networkservice.Connection, error) {
+		return chain.NewNetworkServiceServer(metadata.NewServer(), resourcePoolServer).
+			Request(context.TODO(), &networkservice.NetworkServiceRequest{Connection: conn})
+	}
+
+	// the device token ID should be the first one
+	_, err = request(&networkservice.Connection{
+		Id: "id",
+		Mechanism: &networkservice.Mechanism{
+			Type: vfio.MECHANISM,
+			Parameters: map[string]string{
+				resourcepool.TokenIDsKey: tokenID + "," + otherTokenID,
+				common.DeviceTokenIDKey:  otherTokenID,
+			},
+		},
+	})
+	require.ErrorContains(t, err, "differs from the first token ID")
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 0)
+
+	conn, err := request(&networkservice.Connection{
+		Id: "id",
+		Mechanism: &networkservice.Mechanism{
+			Type: vfio.MECHANISM,
+			Parameters: map[string]string{
+				resourcepool.TokenIDsKey: tokenID + "," + otherTokenID,
+			},
+		},
+	})
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 2)
+
+	require.Equal(t, tokenID, conn.GetMechanism().GetParameters()[common.DeviceTokenIDKey])
+	require.Equal(t, pfs[pf2PciAddr].Vfs[1].Addr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+	require.Equal(t, []string{pfs[pf2PciAddr].Vfs[1].Addr, pfs[pf1PciAddr].Vfs[0].Addr},
+		resourcepool.PCIAddresses(conn.GetMechanism()))
+	require.Equal(t, fmt.Sprintf("%d,%d", pfs[pf2PciAddr].Vfs[1].IOMMUGroup, pfs[pf1PciAddr].Vfs[0].IOMMUGroup),
+		conn.GetMechanism().GetParameters()[resourcepool.IOMMUGroupsKey])
+	require.Equal(t, string(sriov.VFIOPCIDriver), pfs[pf1PciAddr].Vfs[0].Driver)
+
+	// refresh with the lost metadata reuses all the VFs
+	conn, err = request(conn.Clone())
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 2)
+
+	_, err = chain.NewNetworkServiceServer(metadata.NewServer(), resourcePoolServer).Close(context.TODO(), conn)
+	require.NoError(t, err)
+	resourcePool.mock.AssertCalled(t, "Free", pfs[pf2PciAddr].Vfs[1].Addr)
+	resourcePool.mock.AssertCalled(t, "Free", pfs[pf1PciAddr].Vfs[0].Addr)
+}
+
+func TestResourcePoolServer_Close_Takeover(t *testing.T) {
+	const pf1PciAddr, otherTokenID = "0000:00:01.0", "sriov-yyyyyyyy-yyyy-yyyy-yyyy-yyyyyyyyyyyy"
+
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(statefulResourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+	resourcePool.mock.On("Select", otherTokenID, sriov.VFIOPCIDriver).
+		Return(pfs[pf1PciAddr].Vfs[0].Addr, nil)
+	resourcePool.mock.On("Free", mock.Anything).
+		Return(nil)
+
+	conn, err := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), pciPool, resourcePool, conf),
+	).Request(context.TODO(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					resourcepool.TokenIDsKey: tokenID + "," + otherTokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// the standby takes over the state of the primary with a fresh chain element
+	resourcePool.state = &resource.State{
+		Assignments: []*resource.Assignment{
+			{VFPCIAddress: pfs[pf2PciAddr].Vfs[1].Addr, TokenID: tokenID, DriverType: sriov.VFIOPCIDriver},
+			{VFPCIAddress: pfs[pf1PciAddr].Vfs[0].Addr, TokenID: otherTokenID, DriverType: sriov.VFIOPCIDriver},
+		},
+	}
+	standbyServer := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.VFIOPCIDriver, new(sync.Mutex), pciPool, resourcePool, conf),
+	)
+
+	// the connection not matching the state is not freed
+	otherConn := conn.Clone()
+	otherConn.Id = "other-id"
+	otherConn.GetMechanism().GetParameters()[resourcepool.PCIAddressesKey] = pfs[pf2PciAddr].Vfs[0].Addr + "," +
+		pfs[pf1PciAddr].Vfs[0].Addr
+
+	_, err = standbyServer.Close(context.TODO(), otherConn)
+	require.NoError(t, err)
+	resourcePool.mock.AssertNotCalled(t, "Free", mock.Anything)
+
+	_, err = standbyServer.Close(context.TODO(), conn)
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Free", 2)
+	resourcePool.mock.AssertCalled(t, "Free", pfs[pf2PciAddr].Vfs[1].Addr)
+	resourcePool.mock.AssertCalled(t, "Free", pfs[pf1PciAddr].Vfs[0].Addr)
+}
+
+func TestResourcePoolServer_Request_Migration(t *testing.T) {
+	for _, nextFailure := range []bool{false, true} {
+		nextFailure := nextFailure
+		t.Run(fmt.Sprintf("nextFailure=%v", nextFailure), func(t *testing.T) {
+			var pfs map[string]*sriovtest.PCIPhysicalFunction
+			_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+			conf, err := config.ReadConfig(context.TODO(), configFileName)
+			require.NoError(t, err)
+
+			pciPool, err := pci.NewTestPool(pfs, conf)
+			require.NoError(t, err)
+
+			vfPCIAddr := pfs[pf2PciAddr].Vfs[1].Addr
+			resourcePool := new(rebindingResourcePoolMock)
+			resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+				Return(vfPCIAddr, nil)
+			resourcePool.mock.On("Rebind", vfPCIAddr, tokenID, sriov.VFIOPCIDriver).
+				Return(sriov.KernelDriver, nil)
+			resourcePool.mock.On("Rebind", vfPCIAddr, tokenID, sriov.KernelDriver).
+				Return(sriov.VFIOPCIDriver, nil)
+			resourcePool.mock.On("Free", vfPCIAddr).
+				Return(nil)
+
+			resourceLock := new(sync.Mutex)
+			kernelResourceElem, vfioResourceElem := newVFResourceServer(), newVFResourceServer()
+			vfioServers := []networkservice.NetworkServiceServer{
+				resourcepool.NewServer(sriov.VFIOPCIDriver, resourceLock, pciPool, resourcePool, conf),
+				vfioResourceElem,
+			}
+			if nextFailure {
+				vfioServers = append(vfioServers, injecterror.NewServer(injecterror.WithRequestErrorTimes(0)))
+			}
+			server := chain.NewNetworkServiceServer(
+				metadata.NewServer(),
+				resetmechanism.NewServer(
+					mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+						kernel.MECHANISM: chain.NewNetworkServiceServer(
+							resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, conf),
+							kernelResourceElem,
+						),
+						vfio.MECHANISM: chain.NewNetworkServiceServer(vfioServers...),
+					}),
+					resetmechanism.WithMigration(resourcepool.CanMigrate),
+				),
+			)
+
+			// 1. Request kernel mechanism
+
+			conn, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+				Connection: &networkservice.Connection{
+					Id: "id",
+					Mechanism: &networkservice.Mechanism{
+						Type: kernel.MECHANISM,
+						Parameters: map[string]string{
+							common.DeviceTokenIDKey: tokenID,
+						},
+					},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, vfPCIAddr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+			require.Equal(t, vf2KernelDriver, pfs[pf2PciAddr].Vfs[1].Driver)
+
+			// 2. Migrate to VFIO mechanism
+
+			request := &networkservice.NetworkServiceRequest{
+				Connection: conn.Clone(),
+			}
+			request.Connection.Mechanism = &networkservice.Mechanism{
+				Type: vfio.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			}
+
+			migratedConn, err := server.Request(context.TODO(), request)
+			resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+			resourcePool.mock.AssertNotCalled(t, "Free", mock.Anything)
+			resourcePool.mock.AssertCalled(t, "Rebind", vfPCIAddr, tokenID, sriov.VFIOPCIDriver)
+			if nextFailure {
+				require.Error(t, err)
+				resourcePool.mock.AssertCalled(t, "Rebind", vfPCIAddr, tokenID, sriov.KernelDriver)
+				require.Equal(t, vf2KernelDriver, pfs[pf2PciAddr].Vfs[1].Driver)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, vfPCIAddr, migratedConn.GetMechanism().GetParameters()[common.PCIAddressKey])
+				require.Equal(t, string(sriov.VFIOPCIDriver), pfs[pf2PciAddr].Vfs[1].Driver)
+				require.Equal(t, &vfconfig.VFConfig{
+					PFInterfaceName: pfs[pf2PciAddr].IfName,
+					VFNum:           1,
+				}, vfioResourceElem.getVFConfig())
+				conn = migratedConn
+			}
+
+			// 3. Close
+
+			_, err = server.Close(context.TODO(), conn)
+			require.NoError(t, err)
+			resourcePool.mock.AssertNumberOfCalls(t, "Free", 1)
+		})
+	}
+}
+
+func TestResourcePoolServer_Request_ClientQuota(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+	resourcePool.mock.On("Free", pfs[pf2PciAddr].Vfs[1].Addr).
+		Return(nil)
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithClientQuota(1)),
+	)
+
+	request := func(id, spiffeID string) (*networkservice.Connection, error) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+			SignedString([]byte("key"))
+		require.NoError(t, err)
+
+		return server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Path: &networkservice.Path{
+					PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: token}},
+				},
+				Mechanism: &networkservice.Mechanism{
+					Type: kernel.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+	}
+
+	conn, err := request("id-1", "spiffe://example.org/nsc-1")
+	require.NoError(t, err)
+
+	_, err = request("id-2", "spiffe://example.org/nsc-1")
+	require.ErrorIs(t, err, resourcepool.ErrQuotaExceeded)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+
+	_, err = request("id-3", "spiffe://example.org/nsc-2")
+	require.NoError(t, err)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 2)
+
+	_, err = server.Close(context.TODO(), conn)
+	require.NoError(t, err)
+
+	_, err = request("id-2", "spiffe://example.org/nsc-1")
+	require.NoError(t, err)
+}
+
+func TestResourcePoolServer_Request_ClientQuotaShared(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+
+	resourceLock := new(sync.Mutex)
+	quotaOption := resourcepool.WithClientQuota(1)
+	newServer := func() networkservice.NetworkServiceServer {
+		return chain.NewNetworkServiceServer(
+			metadata.NewServer(),
+			resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, conf, quotaOption),
+		)
+	}
+	servers := []networkservice.NetworkServiceServer{newServer(), newServer()}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "spiffe://example.org/nsc"}).
+		SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	request := func(server networkservice.NetworkServiceServer, id string) error {
+		_, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Path: &networkservice.Path{
+					PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: token}},
+				},
+				Mechanism: &networkservice.Mechanism{
+					Type: kernel.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+		return err
+	}
+
+	require.NoError(t, request(servers[0], "id-1"))
+
+	// The quota should be shared by the chain elements created with the same option.
+	require.ErrorIs(t, request(servers[1], "id-2"), resourcepool.ErrQuotaExceeded)
+	resourcePool.mock.AssertNumberOfCalls(t, "Select", 1)
+}
+
+func TestResourcePoolServer_Request_ExhaustionTracker(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	vfPCIAddr := pfs[pf2PciAddr].Vfs[1].Addr
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(vfPCIAddr, nil).Once()
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return("", resource.ErrNoFreeVF).Once()
+	resourcePool.mock.On("Free", vfPCIAddr).
+		Return(nil)
+
+	tracker := exhaustion.NewTracker(10)
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithExhaustionTracker(tracker)),
+	)
+
+	request := func(id, spiffeID string) (*networkservice.Connection, error) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+			SignedString([]byte("key"))
+		require.NoError(t, err)
+
+		return server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Path: &networkservice.Path{
+					PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: token}},
+				},
+				Mechanism: &networkservice.Mechanism{
+					Type: kernel.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+	}
+
+	conn, err := request("id-1", "spiffe://example.org/nsc-1")
+	require.NoError(t, err)
+
+	_, err = request("id-2", "spiffe://example.org/nsc-2")
+	require.ErrorIs(t, err, resource.ErrNoFreeVF)
+
+	incident := tracker.LatestIncident()
+	require.NotNil(t, incident)
+	require.Equal(t, sriov.KernelDriver, incident.DriverType)
+	require.Equal(t, &exhaustion.Consumer{
+		ConnectionID: "id-2",
+		Identity:     "spiffe://example.org/nsc-2",
+		TokenIDs:     []string{tokenID},
+	}, incident.Requester)
+	require.Equal(t, []*exhaustion.Consumer{{
+		ConnectionID:   "id-1",
+		Identity:       "spiffe://example.org/nsc-1",
+		TokenIDs:       []string{tokenID},
+		VFPCIAddresses: []string{vfPCIAddr},
+	}}, incident.Consumers)
+
+	_, err = server.Close(context.TODO(), conn)
+	require.NoError(t, err)
+	require.Equal(t, map[sriov.DriverType]int{sriov.KernelDriver: 1}, tracker.Exhaustions())
+}
+
+type auditorStub struct {
+	records []*audit.Record
+}
+
+func (a *auditorStub) Record(_ context.Context, conn *networkservice.Connection, record *audit.Record) {
+	record.ConnectionID = conn.GetId()
+	a.records = append(a.records, record)
+}
+
+func TestResourcePoolServer_Request_Auditor(t *testing.T) {
+	var pfs map[string]*sriovtest.PCIPhysicalFunction
+	_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+	conf, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(pfs, conf)
+	require.NoError(t, err)
+
+	vfPCIAddr := pfs[pf2PciAddr].Vfs[1].Addr
+
+	resourcePool := new(resourcePoolMock)
+	resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+		Return(vfPCIAddr, nil)
+	resourcePool.mock.On("Free", vfPCIAddr).
+		Return(nil)
+
+	auditor := new(auditorStub)
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf,
+			resourcepool.WithAuditor(auditor)),
+	)
+
+	conn, err := server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// refresh doesn't change the hardware state
+	_, err = server.Request(context.TODO(), &networkservice.NetworkServiceRequest{Connection: conn.Clone()})
+	require.NoError(t, err)
+
+	_, err = server.Close(context.TODO(), conn)
+	require.NoError(t, err)
+
+	require.Equal(t, []*audit.Record{
+		{ConnectionID: "id", Action: audit.DriverBound, TokenID: tokenID, PCIAddress: vfPCIAddr, Driver: sriov.KernelDriver},
+		{ConnectionID: "id", Action: audit.VFAssigned, TokenID: tokenID, PCIAddress: vfPCIAddr, Driver: sriov.KernelDriver},
+		{ConnectionID: "id", Action: audit.VFFreed, TokenID: tokenID, PCIAddress: vfPCIAddr},
+	}, auditor.records)
+}
+
+func TestResourcePoolServer_Request_NextFailure(t *testing.T) {
+	for _, withCleanup := range []bool{false, true} {
+		withCleanup := withCleanup
+		t.Run(fmt.Sprintf("cleanup=%v", withCleanup), func(t *testing.T) {
+			var pfs map[string]*sriovtest.PCIPhysicalFunction
+			_ = yamlhelper.UnmarshalFile(physicalFunctionsFilename, &pfs)
+
+			conf, err := config.ReadConfig(context.TODO(), configFileName)
+			require.NoError(t, err)
+
+			pciPool, err := pci.NewTestPool(pfs, conf)
+			require.NoError(t, err)
+
+			resourcePool := new(resourcePoolMock)
+			resourcePool.mock.On("Select", tokenID, sriov.KernelDriver).
+				Return(pfs[pf2PciAddr].Vfs[1].Addr, nil)
+			resourcePool.mock.On("Free", pfs[pf2PciAddr].Vfs[1].Addr).
+				Return(nil)
+
+			servers := []networkservice.NetworkServiceServer{metadata.NewServer()}
+			if withCleanup {
+				servers = append(servers, cleanup.NewServer())
+			}
+			server := chain.NewNetworkServiceServer(append(servers,
+				resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), pciPool, resourcePool, conf),
+				injecterror.NewServer(injecterror.WithRequestErrorTimes(0)),
+			)...)
+
+			_, err = server.Request(context.TODO(), &networkservice.NetworkServiceRequest{
+				Connection: &networkservice.Connection{
+					Id: "id",
+					Mechanism: &networkservice.Mechanism{
+						Type: kernel.MECHANISM,
+						Parameters: map[string]string{
+							common.DeviceTokenIDKey: tokenID,
+						},
+					},
+				},
+			})
+			require.Error(t, err)
+			resourcePool.mock.AssertNumberOfCalls(t, "Free", 1)
+		})
+	}
+}
+
+// cancellingPCIPool cancels the Request context in the middle of the VFIO driver binding
+type cancellingPCIPool struct {
+	*pci.Pool
+	cancel context.CancelFunc
+}
+
+func (p *cancellingPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	if driverType != sriov.VFIOPCIDriver {
+		return p.Pool.BindDriver(ctx, iommuGroup, driverType)
+	}
+	_ = p.Pool.BindDriver(ctx, iommuGroup, driverType)
+	p.cancel()
+	return ctx.Err()
+}
+
+// failingPCIPool fails the VFIO driver binding for the iommuGroup
+type failingPCIPool struct {
+	*pci.Pool
+	iommuGroup uint
+}
+
+func (p *failingPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	if driverType == sriov.VFIOPCIDriver && iommuGroup == p.iommuGroup {
+		return errors.Errorf("failed to bind IOMMU group: %v", iommuGroup)
+	}
+	return p.Pool.BindDriver(ctx, iommuGroup, driverType)
+}
+
 type resourcePoolMock struct {
 	mock mock.Mock
 
@@ -190,3 +1300,162 @@ func (rp *resourcePoolMock) Free(vfPCIAddr string) error {
 	rv := rp.mock.Called(vfPCIAddr)
 	return rv.Error(0)
 }
+
+type statefulResourcePoolMock struct {
+	resourcePoolMock
+
+	state *resource.State
+}
+
+func (rp *statefulResourcePoolMock) State() *resource.State {
+	return rp.state
+}
+
+type rebindingResourcePoolMock struct {
+	resourcePoolMock
+}
+
+func (rp *rebindingResourcePoolMock) Rebind(vfPCIAddr, tokenID string, driverType sriov.DriverType) (sriov.DriverType, error) {
+	rv := rp.mock.Called(vfPCIAddr, tokenID, driverType)
+	return rv.Get(0).(sriov.DriverType), rv.Error(1)
+}
+
+type warmResourcePoolMock struct {
+	resourcePoolMock
+}
+
+func (rp *warmResourcePoolMock) ReserveWarmGroups(warmVFIO map[string]uint) []uint {
+	rv := rp.mock.Called(warmVFIO)
+	return rv.Get(0).([]uint)
+}
+
+type unhealthyResourcePoolMock struct {
+	resourcePoolMock
+}
+
+func (rp *unhealthyResourcePoolMock) MarkUnhealthy(vfPCIAddr string, duration time.Duration) error {
+	rv := rp.mock.Called(vfPCIAddr, duration)
+	return rv.Error(0)
+}
+
+func BenchmarkResourcePoolServer_Request(b *testing.B) {
+	const pfCount, vfCount = 8, 8
+
+	conf := &config.Config{PhysicalFunctions: map[string]*config.PhysicalFunction{}}
+	for i := 0; i < pfCount; i++ {
+		pfCfg := &config.PhysicalFunction{
+			PFKernelDriver: "pf-driver",
+			VFKernelDriver: "vf-driver",
+		}
+		for k := 0; k < vfCount; k++ {
+			pfCfg.VirtualFunctions = append(pfCfg.VirtualFunctions, &config.VirtualFunction{
+				Address:    fmt.Sprintf("0000:%02x:00.%d", i+1, k+1),
+				IOMMUGroup: uint(i*vfCount + k + 1),
+			})
+		}
+		conf.PhysicalFunctions[fmt.Sprintf("0000:%02x:00.0", i+1)] = pfCfg
+	}
+
+	for _, sharded := range []bool{false, true} {
+		name := "ResourceLock"
+		var options []resourcepool.Option
+		if sharded {
+			name = "ShardedLock"
+			options = append(options,
+				resourcepool.WithShardedLock(resourcepool.NewShardedLock()),
+				resourcepool.WithTokenLock(resourcepool.NewShardedLock()))
+		}
+
+		b.Run(name, func(b *testing.B) {
+			pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(conf), conf)
+			require.NoError(b, err)
+
+			server := chain.NewNetworkServiceServer(
+				metadata.NewServer(),
+				resourcepool.NewServer(sriov.KernelDriver, new(sync.Mutex), &slowPCIPool{Pool: pciPool},
+					newRoundRobinResourcePool(conf), conf, options...),
+			)
+
+			var connID int64
+			b.SetParallelism(pfCount)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					id := atomic.AddInt64(&connID, 1)
+					conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+						Connection: &networkservice.Connection{
+							Id: strconv.FormatInt(id, 10),
+							Mechanism: &networkservice.Mechanism{
+								Type: kernel.MECHANISM,
+								Parameters: map[string]string{
+									common.DeviceTokenIDKey: fmt.Sprintf("sriov-%08x-xxxx-xxxx-xxxx-xxxxxxxxxxxx", id),
+								},
+							},
+						},
+					})
+					require.NoError(b, err)
+
+					_, err = server.Close(context.Background(), conn)
+					require.NoError(b, err)
+				}
+			})
+		})
+	}
+}
+
+// slowPCIPool emulates the driver binding time of the real hardware
+type slowPCIPool struct {
+	*pci.Pool
+}
+
+func (p *slowPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	time.Sleep(time.Millisecond)
+	return p.Pool.BindDriver(ctx, iommuGroup, driverType)
+}
+
+// roundRobinResourcePool selects the free VFs from the PFs in turn, it is called under the resource lock
+type roundRobinResourcePool struct {
+	vfs  [][]string
+	free map[string]bool
+	next int
+}
+
+func newRoundRobinResourcePool(conf *config.Config) *roundRobinResourcePool {
+	rp := &roundRobinResourcePool{free: map[string]bool{}}
+	for _, pfCfg := range conf.PhysicalFunctions {
+		var vfs []string
+		for _, vfCfg := range pfCfg.VirtualFunctions {
+			vfs = append(vfs, vfCfg.Address)
+			rp.free[vfCfg.Address] = true
+		}
+		rp.vfs = append(rp.vfs, vfs)
+	}
+	return rp
+}
+
+func (rp *roundRobinResourcePool) Select(_ string, driverType sriov.DriverType) (string, error) {
+	for i := range rp.vfs {
+		pfVFs := rp.vfs[(rp.next+i)%len(rp.vfs)]
+		for _, vf := range pfVFs {
+			if rp.free[vf] {
+				rp.free[vf] = false
+				rp.next = (rp.next + i + 1) % len(rp.vfs)
+				return vf, nil
+			}
+		}
+	}
+	return "", errors.Errorf("no free VF for the driver type: %v", driverType)
+}
+
+func (rp *roundRobinResourcePool) Free(vfPCIAddr string) error {
+	rp.free[vfPCIAddr] = true
+	return nil
+}
+
+type eventRecorderStub struct {
+	reasons []string
+}
+
+func (r *eventRecorderStub) Warningf(reason, _ string, _ ...interface{}) {
+	r.reasons = append(r.reasons, reason)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepool
+
+import (
+	"sync"
+)
+
+// ShardedLock is a set of locks keyed by the string, e.g. by the PF PCI address or by the token ID. The lock for the
+// key exists only while it is held or waited for, so the keys don't accumulate.
+type ShardedLock struct {
+	mu    sync.Mutex
+	locks map[string]*shardedLockEntry
+}
+
+type shardedLockEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewShardedLock returns a new ShardedLock
+func NewShardedLock() *ShardedLock {
+	return &ShardedLock{
+		locks: map[string]*shardedLockEntry{},
+	}
+}
+
+// Lock locks the lock for the key
+func (l *ShardedLock) Lock(key string) {
+	l.mu.Lock()
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = new(shardedLockEntry)
+		l.locks[key] = entry
+	}
+	entry.refs++
+	l.mu.Unlock()
+
+	entry.mu.Lock()
+}
+
+// Unlock unlocks the lock for the key, it panics if the lock for the key is not locked
+func (l *ShardedLock) Unlock(key string) {
+	l.mu.Lock()
+	entry, ok := l.locks[key]
+	if !ok {
+		l.mu.Unlock()
+		panic("resourcepool: unlock of unlocked ShardedLock key: " + key)
+	}
+	if entry.refs--; entry.refs == 0 {
+		delete(l.locks, key)
+	}
+	l.mu.Unlock()
+
+	entry.mu.Unlock()
+}
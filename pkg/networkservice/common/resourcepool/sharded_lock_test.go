@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcepool_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+)
+
+func TestShardedLock(t *testing.T) {
+	const key, count = "key", 100
+
+	l := resourcepool.NewShardedLock()
+
+	var wg sync.WaitGroup
+	var counter int
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Lock(key)
+			defer l.Unlock(key)
+			counter++
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, count, counter)
+
+	// the lock for the key is removed on the last Unlock
+	require.Panics(t, func() { l.Unlock(key) })
+	require.Panics(t, func() { l.Unlock("unknown") })
+
+	l.Lock(key)
+	l.Unlock(key)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcepool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// WarmUp reserves the warm IOMMU groups in the resource pool for the capability -> count warmVFIO config (see
+// config.Config.WarmVFIO) and binds them to the vfio-pci driver, so the following VFIO requests are served without the
+// driver rebinding. It should be called under the resource lock, on startup it should be called before the chain
+// elements start serving the requests.
+func WarmUp(ctx context.Context, resourcePool WarmResourcePool, pciPool PCIPool, warmVFIO map[string]uint) error {
+	if len(warmVFIO) == 0 {
+		return nil
+	}
+	for _, iommuGroup := range resourcePool.ReserveWarmGroups(warmVFIO) {
+		if err := pciPool.BindDriver(ctx, iommuGroup, sriov.VFIOPCIDriver); err != nil {
+			return errors.Wrapf(err, "failed to pre-bind IOMMU group to the vfio-pci driver: %d", iommuGroup)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectionhints
+
+import (
+	"context"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/numa"
+)
+
+// PodDevices returns the other devices allocated for the pod the deviceID device is allocated for:
+// devices[resourceName] -> []deviceIDs, e.g. podresources.PodDevices with the bound options
+type PodDevices func(ctx context.Context, deviceID string) (map[string][]string, error)
+
+// Option is an option for NewServer
+type Option func(s *selectionHintsServer)
+
+// WithNUMALabel sets label with the preferred PF NUMA node, default is "numa"
+func WithNUMALabel(numaLabel string) Option {
+	return func(s *selectionHintsServer) {
+		s.numaLabel = numaLabel
+	}
+}
+
+// WithCapabilityLabels sets labels with the preferred PF capabilities, default is "bandwidth"
+func WithCapabilityLabels(capabilityLabels ...string) Option {
+	return func(s *selectionHintsServer) {
+		s.capabilityLabels = capabilityLabels
+	}
+}
+
+// WithPhysicalNetworkLabel sets label with the required PF physical network, default is "physicalNetwork"
+func WithPhysicalNetworkLabel(networkLabel string) Option {
+	return func(s *selectionHintsServer) {
+		s.networkLabel = networkLabel
+	}
+}
+
+// WithClientCPUSet makes the server prefer the NUMA node local to the client cpuset if there is no NUMA node label. The
+// client cgroup directory is taken from CgroupDirKey mechanism parameter relative to cpusetBaseDir, e.g.
+// numa.DefaultCPUSetBaseDir. If there are no VFs on the preferred NUMA node, the topology nodes nearest to it are
+// preferred.
+func WithClientCPUSet(topology *numa.Topology, cpusetBaseDir string) Option {
+	return func(s *selectionHintsServer) {
+		s.topology = topology
+		s.cpusetBaseDir = cpusetBaseDir
+	}
+}
+
+// WithPeerDeviceAffinity makes the server prefer the cfg PFs nearest in the PCI hierarchy (e.g. sharing a PCIe switch)
+// to the other PCI devices allocated for the client pod, e.g. GPUs for the GPUDirect RDMA workloads. The client pod is
+// found by the DeviceTokenIDKey mechanism parameter, the PCI hierarchy is read from devicesPath, e.g.
+// pcitopology.DefaultDevicesPath.
+func WithPeerDeviceAffinity(cfg *config.Config, podDevices PodDevices, devicesPath string) Option {
+	return func(s *selectionHintsServer) {
+		s.cfg = cfg
+		s.podDevices = podDevices
+		s.devicesPath = devicesPath
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package selectionhints provides chain element mapping NSE registry labels and request labels to the VF selection hints
+package selectionhints
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/discover"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/numa"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/pcitopology"
+)
+
+const (
+	// DefaultNUMALabel is a default label with the preferred PF NUMA node
+	DefaultNUMALabel = "numa"
+	// DefaultCapabilityLabel is a default label with the preferred PF capability
+	DefaultCapabilityLabel = "bandwidth"
+	// DefaultPhysicalNetworkLabel is a default label with the required PF physical network
+	DefaultPhysicalNetworkLabel = "physicalNetwork"
+)
+
+// CgroupDirKey is a client on host cgroup directory mechanism parameter key, same as for the VFIO mechanism
+const CgroupDirKey = vfio.CgroupDirKey
+
+var pciAddrRegexp = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+
+type selectionHintsServer struct {
+	numaLabel        string
+	capabilityLabels []string
+	networkLabel     string
+	topology         *numa.Topology
+	cpusetBaseDir    string
+	cfg              *config.Config
+	podDevices       PodDevices
+	devicesPath      string
+}
+
+// NewServer returns a new selection hints server chain element. It maps the selected NSE registry labels for the
+// requested network service and the request labels (request labels take precedence) to the VF selection hints used by
+// the following resourcepool chain elements. If there is no NUMA node label and WithClientCPUSet is set, the NUMA node
+// most of the client cpuset CPUs are on is preferred, then the other NUMA nodes in the order of the distance from it. If WithPeerDeviceAffinity is set, the PFs nearest in the PCI
+// hierarchy to the other client pod PCI devices are preferred. Unlike the other hints, the physical network label is a
+// requirement: only VFs of the PFs cabled to the physical network are selected for the connection. Should be placed after discover.
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &selectionHintsServer{
+		numaLabel:        DefaultNUMALabel,
+		capabilityLabels: []string{DefaultCapabilityLabel},
+		networkLabel:     DefaultPhysicalNetworkLabel,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *selectionHintsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("selectionHintsServer", "Request")
+
+	labels := nseLabels(ctx, request.GetConnection())
+	for key, value := range request.GetConnection().GetLabels() {
+		labels[key] = value
+	}
+
+	hints := sriov.NewSelectionHints()
+	if value, ok := labels[s.numaLabel]; ok {
+		if numaNode, err := strconv.Atoi(value); err == nil && numaNode >= 0 {
+			hints.NUMANode = numaNode
+		} else {
+			logger.Warnf("invalid NUMA node label value: %s=%s", s.numaLabel, value)
+		}
+	}
+	if hints.NUMANode < 0 && s.topology != nil {
+		hints.NUMANode = s.clientNUMANode(ctx, request)
+	}
+	if hints.NUMANode >= 0 && s.topology != nil {
+		hints.FallbackNUMANodes = s.fallbackNUMANodes(hints.NUMANode)
+	}
+	for _, capabilityLabel := range s.capabilityLabels {
+		if value := labels[capabilityLabel]; value != "" {
+			hints.Capabilities = append(hints.Capabilities, value)
+		}
+	}
+
+	hints.PhysicalNetwork = labels[s.networkLabel]
+
+	if s.podDevices != nil {
+		hints.PreferredPFs = s.peerNearestPFs(ctx, request)
+	}
+
+	if !hints.IsEmpty() {
+		logger.Debugf("VF selection hints: %+v", hints)
+		resourcepool.StoreSelectionHints(ctx, false, hints)
+	}
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *selectionHintsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// clientNUMANode returns the NUMA node most of the client cpuset CPUs are on, -1 if it is unknown
+func (s *selectionHintsServer) clientNUMANode(ctx context.Context, request *networkservice.NetworkServiceRequest) int {
+	cgroupDir := mechanismParameter(request, CgroupDirKey)
+	if cgroupDir == "" {
+		return -1
+	}
+
+	cpus, err := numa.ReadCPUSet(s.cpusetBaseDir, cgroupDir)
+	if err != nil {
+		log.FromContext(ctx).WithField("selectionHintsServer", "clientNUMANode").
+			Warnf("failed to read client cpuset: %s", err.Error())
+		return -1
+	}
+	return s.topology.PreferredNode(cpus)
+}
+
+// fallbackNUMANodes returns the other NUMA nodes sorted by the distance from the numaNode
+func (s *selectionHintsServer) fallbackNUMANodes(numaNode int) []int {
+	var fallbackNodes []int
+	for _, id := range s.topology.NodesByDistance(numaNode) {
+		if id != numaNode {
+			fallbackNodes = append(fallbackNodes, id)
+		}
+	}
+	return fallbackNodes
+}
+
+// peerNearestPFs returns the PFs nearest in the PCI hierarchy to the other client pod PCI devices, nil if there are no
+// such devices or all the PFs are equally near
+func (s *selectionHintsServer) peerNearestPFs(ctx context.Context, request *networkservice.NetworkServiceRequest) []string {
+	logger := log.FromContext(ctx).WithField("selectionHintsServer", "peerNearestPFs")
+
+	tokenID := mechanismParameter(request, common.DeviceTokenIDKey)
+	if tokenID == "" {
+		return nil
+	}
+
+	devices, err := s.podDevices(ctx, tokenID)
+	if err != nil {
+		logger.Warnf("failed to get client pod devices: %s", err.Error())
+		return nil
+	}
+
+	var peers []string
+	for _, deviceIDs := range devices {
+		for _, deviceID := range deviceIDs {
+			if pciAddrRegexp.MatchString(deviceID) {
+				peers = append(peers, deviceID)
+			}
+		}
+	}
+	if len(peers) == 0 {
+		return nil
+	}
+
+	var pfPCIAddrs []string
+	for pfPCIAddr := range s.cfg.PhysicalFunctions {
+		pfPCIAddrs = append(pfPCIAddrs, pfPCIAddr)
+	}
+	sort.Strings(pfPCIAddrs)
+
+	var nearestPFs []string
+	nearestDistance, known := -1, 0
+	for _, pfPCIAddr := range pfPCIAddrs {
+		distance := -1
+		for _, peer := range peers {
+			peerDistance, err := pcitopology.Distance(s.devicesPath, pfPCIAddr, peer)
+			if err != nil {
+				logger.Warnf("failed to get PCI distance: %s", err.Error())
+				continue
+			}
+			if distance < 0 || peerDistance < distance {
+				distance = peerDistance
+			}
+		}
+		switch {
+		case distance < 0:
+			continue
+		case nearestDistance < 0 || distance < nearestDistance:
+			nearestPFs, nearestDistance = []string{pfPCIAddr}, distance
+		case distance == nearestDistance:
+			nearestPFs = append(nearestPFs, pfPCIAddr)
+		}
+		known++
+	}
+	if len(nearestPFs) == known {
+		return nil
+	}
+	return nearestPFs
+}
+
+func mechanismParameter(request *networkservice.NetworkServiceRequest, key string) string {
+	if value := request.GetConnection().GetMechanism().GetParameters()[key]; value != "" {
+		return value
+	}
+	for _, preference := range request.GetMechanismPreferences() {
+		if value := preference.GetParameters()[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func nseLabels(ctx context.Context, conn *networkservice.Connection) map[string]string {
+	labels := map[string]string{}
+	candidates := discover.Candidates(ctx)
+	if candidates == nil {
+		return labels
+	}
+	for _, nse := range candidates.Endpoints {
+		if nse.GetName() != conn.GetNetworkServiceEndpointName() {
+			continue
+		}
+		for key, value := range nse.GetNetworkServiceLabels()[conn.GetNetworkService()].GetLabels() {
+			labels[key] = value
+		}
+	}
+	return labels
+}
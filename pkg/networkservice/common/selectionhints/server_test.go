@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package selectionhints_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/discover"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/selectionhints"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/numa"
+)
+
+const (
+	nsName  = "ns"
+	nseName = "nse"
+)
+
+type candidatesServer struct {
+	nses []*registry.NetworkServiceEndpoint
+}
+
+func (s *candidatesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	ctx = discover.WithCandidates(ctx, s.nses, &registry.NetworkService{Name: nsName})
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *candidatesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestSelectionHintsServer_Request(t *testing.T) {
+	var hints *sriov.SelectionHints
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		&candidatesServer{
+			nses: []*registry.NetworkServiceEndpoint{
+				{
+					Name: nseName,
+					NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+						nsName: {
+							Labels: map[string]string{
+								selectionhints.DefaultNUMALabel:            "1",
+								selectionhints.DefaultCapabilityLabel:      "10G",
+								selectionhints.DefaultPhysicalNetworkLabel: "physnet-a",
+							},
+						},
+					},
+				},
+			},
+		},
+		selectionhints.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			hints = resourcepool.LoadSelectionHints(ctx, false)
+		}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         "id",
+			NetworkService:             nsName,
+			NetworkServiceEndpointName: nseName,
+			Labels: map[string]string{
+				selectionhints.DefaultCapabilityLabel: "25G",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &sriov.SelectionHints{
+		NUMANode:        1,
+		Capabilities:    []string{"25G"},
+		PhysicalNetwork: "physnet-a",
+	}, hints)
+}
+
+func TestSelectionHintsServer_NoHints(t *testing.T) {
+	var hints *sriov.SelectionHints
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		selectionhints.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			hints = resourcepool.LoadSelectionHints(ctx, false)
+		}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, hints)
+}
+
+func TestSelectionHintsServer_ClientCPUSet(t *testing.T) {
+	cpusetBaseDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(cpusetBaseDir, "pod-1"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(cpusetBaseDir, "pod-1", "cpuset.cpus.effective"), []byte("4-5"), 0o600))
+
+	topology := &numa.Topology{
+		Nodes: map[int]*numa.Node{
+			0: {ID: 0, CPUs: []int{0, 1, 2, 3}, Distances: []int{10, 21, 12}},
+			1: {ID: 1, CPUs: []int{4, 5, 6, 7}, Distances: []int{21, 10, 12}},
+			2: {ID: 2, CPUs: []int{8, 9, 10, 11}, Distances: []int{12, 12, 10}},
+		},
+	}
+
+	var hints *sriov.SelectionHints
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		selectionhints.NewServer(selectionhints.WithClientCPUSet(topology, cpusetBaseDir)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			hints = resourcepool.LoadSelectionHints(ctx, false)
+		}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Parameters: map[string]string{selectionhints.CgroupDirKey: "pod-1"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, hints.NUMANode)
+	require.Equal(t, []int{2, 0}, hints.FallbackNUMANodes)
+
+	// NUMA label takes precedence
+	_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id-2",
+			Labels: map[string]string{selectionhints.DefaultNUMALabel: "0"},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Parameters: map[string]string{selectionhints.CgroupDirKey: "pod-1"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, hints.NUMANode)
+	require.Equal(t, []int{2, 1}, hints.FallbackNUMANodes)
+}
+
+func TestSelectionHintsServer_PeerDeviceAffinity(t *testing.T) {
+	sysfsPath := t.TempDir()
+	devicesPath := filepath.Join(sysfsPath, "bus", "pci", "devices")
+	require.NoError(t, os.MkdirAll(devicesPath, 0o750))
+	for pciAddr, path := range map[string]string{
+		"0000:03:00.0": "pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:08.0/0000:03:00.0",
+		"0000:04:00.0": "pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:10.0/0000:04:00.0",
+		"0000:05:00.0": "pci0000:00/0000:00:02.0/0000:05:00.0",
+	} {
+		devicePath := filepath.Join(sysfsPath, "devices", path)
+		require.NoError(t, os.MkdirAll(devicePath, 0o750))
+		require.NoError(t, os.Symlink(devicePath, filepath.Join(devicesPath, pciAddr)))
+	}
+
+	cfg := &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			"0000:03:00.0": {},
+			"0000:05:00.0": {},
+		},
+	}
+	podDevices := func(_ context.Context, deviceID string) (map[string][]string, error) {
+		require.Equal(t, "token-1", deviceID)
+		return map[string][]string{
+			"nvidia.com/gpu":     {"0000:04:00.0"},
+			"service.domain/10G": {"token-2"},
+		}, nil
+	}
+
+	var hints *sriov.SelectionHints
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		selectionhints.NewServer(selectionhints.WithPeerDeviceAffinity(cfg, podDevices, devicesPath)),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			hints = resourcepool.LoadSelectionHints(ctx, false)
+		}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Parameters: map[string]string{common.DeviceTokenIDKey: "token-1"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"0000:03:00.0"}, hints.PreferredPFs)
+
+	// No token, no preference
+	hints = nil
+	_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id-2",
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, hints)
+}
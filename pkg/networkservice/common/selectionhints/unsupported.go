@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+// Package selectionhints provides chain element mapping NSE registry labels and request labels to the VF selection hints
+package selectionhints
+
+import (
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/numa"
+)
+
+type selectionHintsServer struct {
+	numaLabel        string
+	capabilityLabels []string
+	networkLabel     string
+	topology         *numa.Topology
+	cpusetBaseDir    string
+	cfg              *config.Config
+	podDevices       PodDevices
+	devicesPath      string
+}
+
+// NewServer returns a server chain element failing all the requests with sriov.UnsupportedError, the selection hints
+// are supported on Linux only
+func NewServer(_ ...Option) networkservice.NetworkServiceServer {
+	return injecterror.NewServer(
+		injecterror.WithError(sriov.NewUnsupportedError("selection hints server")),
+		injecterror.WithCloseErrorTimes())
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stagetiming provides chain elements recording the Request stages durations
+package stagetiming
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+)
+
+type stageTimingServer struct {
+	recorder stages.Recorder
+}
+
+// NewServer returns a new stage timing server chain element, it sets the recorder into the Request context so the next
+// chain elements record their stages durations into it
+func NewServer(recorder stages.Recorder) networkservice.NetworkServiceServer {
+	return &stageTimingServer{
+		recorder: recorder,
+	}
+}
+
+func (s *stageTimingServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	ctx = stages.WithRecorder(ctx, s.recorder)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *stageTimingServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// NewStageServer returns the server chain element recording its Request duration as the stage, excluding the time
+// spent in the next chain elements. It is meant for the chain elements not recording the stages on their own.
+func NewStageServer(stage stages.Stage, server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+	return chain.NewNetworkServiceServer(
+		&stageStartServer{stage: stage},
+		server,
+		&stageEndServer{stage: stage},
+	)
+}
+
+type stageStartKey struct {
+	stage stages.Stage
+}
+
+type stageStart struct {
+	time     time.Time
+	observed bool
+}
+
+type stageStartServer struct {
+	stage stages.Stage
+}
+
+func (s *stageStartServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	start := &stageStart{time: time.Now()}
+	ctx = context.WithValue(ctx, stageStartKey{stage: s.stage}, start)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if !start.observed {
+		// the stage server has failed without calling the next chain elements
+		stages.Observe(ctx, s.stage, start.time)
+	}
+	return conn, err
+}
+
+func (s *stageStartServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type stageEndServer struct {
+	stage stages.Stage
+}
+
+func (s *stageEndServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if start, ok := ctx.Value(stageStartKey{stage: s.stage}).(*stageStart); ok && !start.observed {
+		start.observed = true
+		stages.Observe(ctx, s.stage, start.time)
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *stageEndServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
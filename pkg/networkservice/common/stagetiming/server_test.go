@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stagetiming_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/stagetiming"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+)
+
+const (
+	stageDelay = 10 * time.Millisecond
+	nextDelay  = 200 * time.Millisecond
+)
+
+type recorderStub struct {
+	lock      sync.Mutex
+	durations map[stages.Stage][]time.Duration
+}
+
+func (r *recorderStub) Observe(stage stages.Stage, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.durations == nil {
+		r.durations = map[stages.Stage][]time.Duration{}
+	}
+	r.durations[stage] = append(r.durations[stage], duration)
+}
+
+type delayServer struct {
+	delay time.Duration
+	err   error
+}
+
+func (s *delayServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *delayServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestStageTimingServer_Request(t *testing.T) {
+	recorder := new(recorderStub)
+	server := chain.NewNetworkServiceServer(
+		stagetiming.NewServer(recorder),
+		stagetiming.NewStageServer(stages.NamespaceInjection, &delayServer{delay: stageDelay}),
+		&delayServer{delay: nextDelay},
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+
+	durations := recorder.durations[stages.NamespaceInjection]
+	require.Len(t, durations, 1)
+	require.GreaterOrEqual(t, durations[0], stageDelay)
+	require.Less(t, durations[0], nextDelay)
+}
+
+func TestStageTimingServer_Request_StageFailed(t *testing.T) {
+	recorder := new(recorderStub)
+	server := chain.NewNetworkServiceServer(
+		stagetiming.NewServer(recorder),
+		stagetiming.NewStageServer(stages.NamespaceInjection, &delayServer{delay: stageDelay, err: errors.New("error")}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.Error(t, err)
+
+	durations := recorder.durations[stages.NamespaceInjection]
+	require.Len(t, durations, 1)
+	require.GreaterOrEqual(t, durations[0], stageDelay)
+}
+
+func TestStageTimingServer_Request_NoRecorder(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		stagetiming.NewStageServer(stages.NamespaceInjection, &delayServer{}),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+import "time"
+
+// Option is an option for the standby server chain element
+type Option func(s *standbyServer)
+
+// WithRenewInterval sets how often the lease is tried to be acquired or renewed, it should be less than the lease
+// duration
+func WithRenewInterval(renewInterval time.Duration) Option {
+	return func(s *standbyServer) {
+		s.renewInterval = renewInterval
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standby provides chain element implementing the warm-standby mode for the forwarder instances sharing the
+// same SR-IOV resources
+package standby
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+const defaultRenewInterval = time.Second
+
+// Lease is a lease.Lease interface
+type Lease interface {
+	TryAcquire() (bool, error)
+	Release() error
+}
+
+// StatefulPool is a resource.Pool interface
+type StatefulPool interface {
+	State() *resource.State
+	Restore(state *resource.State) error
+}
+
+type standbyServer struct {
+	lease         Lease
+	statePath     string
+	resourceLock  sync.Locker
+	resourcePool  StatefulPool
+	renewInterval time.Duration
+	isActive      atomic.Bool
+	lock          sync.RWMutex
+	state         *resource.State
+}
+
+// NewServer returns a new standby server chain element. It rejects all Requests and Closes while the lease is held by
+// another instance, periodically loading the persisted pool state read-only. On the lease acquisition it restores the
+// last persisted state into the resourcePool and starts serving, persisting the resourcePool state into the statePath
+// file after each Request and Close. On ctx cancellation the lease is released.
+func NewServer(
+	ctx context.Context,
+	lease Lease,
+	statePath string,
+	resourceLock sync.Locker,
+	resourcePool StatefulPool,
+	options ...Option,
+) networkservice.NetworkServiceServer {
+	s := &standbyServer{
+		lease:         lease,
+		statePath:     statePath,
+		resourceLock:  resourceLock,
+		resourcePool:  resourcePool,
+		renewInterval: defaultRenewInterval,
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	logger := log.FromContext(ctx).WithField("standbyServer", "renew")
+	s.renew(logger)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				if err := s.lease.Release(); err != nil {
+					logger.Warnf("failed to release lease: %s", err.Error())
+				}
+				return
+			case <-time.After(s.renewInterval):
+				s.renew(logger)
+			}
+		}
+	}()
+
+	return s
+}
+
+func (s *standbyServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if !s.isActive.Load() {
+		return nil, errors.New("forwarder is in the warm-standby mode, no requests are accepted")
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	s.persist(ctx)
+
+	return conn, err
+}
+
+func (s *standbyServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if !s.isActive.Load() {
+		return nil, errors.New("forwarder is in the warm-standby mode, no closes are accepted")
+	}
+
+	rv, err := next.Server(ctx).Close(ctx, conn)
+	s.persist(ctx)
+
+	return rv, err
+}
+
+func (s *standbyServer) renew(logger log.Logger) {
+	acquired, err := s.lease.TryAcquire()
+	if err != nil {
+		logger.Warnf("failed to acquire lease: %s", err.Error())
+	}
+
+	switch isActive := s.isActive.Load(); {
+	case acquired && !isActive:
+		s.takeOver(logger)
+	case !acquired && isActive:
+		// Wait for the Requests in progress
+		s.lock.Lock()
+		s.isActive.Store(false)
+		s.lock.Unlock()
+		logger.Warn("lease is lost, switched to the warm-standby mode")
+	case !acquired:
+		s.load(logger)
+	}
+}
+
+func (s *standbyServer) takeOver(logger log.Logger) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.load(logger)
+	if s.state != nil {
+		s.resourceLock.Lock()
+		err := s.resourcePool.Restore(s.state)
+		s.resourceLock.Unlock()
+		if err != nil {
+			logger.Warnf("failed to restore pool state: %s", err.Error())
+		}
+	}
+
+	s.isActive.Store(true)
+	logger.Infof("lease is acquired, restored %d VF assignments", len(s.state.GetAssignments()))
+}
+
+// load reads the persisted state read-only, missing state file means there are no assignments yet
+func (s *standbyServer) load(logger log.Logger) {
+	state, err := resource.ReadStateFile(s.statePath)
+	switch {
+	case err == nil:
+		s.state = state
+	case !errors.Is(err, os.ErrNotExist):
+		logger.Warnf("failed to load pool state: %s", err.Error())
+	}
+}
+
+func (s *standbyServer) persist(ctx context.Context) {
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	if err := resource.WriteStateFile(s.statePath, s.resourcePool.State()); err != nil {
+		log.FromContext(ctx).WithField("standbyServer", "persist").Warnf("failed to persist pool state: %s", err.Error())
+	}
+}
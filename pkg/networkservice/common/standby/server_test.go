@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/standby"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+type testLease struct {
+	acquirable atomic.Bool
+}
+
+func (l *testLease) TryAcquire() (bool, error) {
+	return l.acquirable.Load(), nil
+}
+
+func (l *testLease) Release() error {
+	return nil
+}
+
+type testPool struct {
+	state *resource.State
+}
+
+func (p *testPool) State() *resource.State {
+	return p.state
+}
+
+func (p *testPool) Restore(state *resource.State) error {
+	p.state = state
+	return nil
+}
+
+func TestStandbyServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	persistedState := &resource.State{
+		Assignments: []*resource.Assignment{
+			{VFPCIAddress: "0000:01:00.1", TokenID: "1", DriverType: sriov.KernelDriver},
+		},
+	}
+	require.NoError(t, resource.WriteStateFile(statePath, persistedState))
+
+	lease := new(testLease)
+	resourceLock := new(sync.Mutex)
+	pool := new(testPool)
+
+	server := standby.NewServer(ctx, lease, statePath, resourceLock, pool,
+		standby.WithRenewInterval(10*time.Millisecond))
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	}
+
+	_, err := server.Request(ctx, request)
+	require.Error(t, err)
+
+	lease.acquirable.Store(true)
+	require.Eventually(t, func() bool {
+		_, err = server.Request(ctx, request)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	resourceLock.Lock()
+	require.Equal(t, persistedState, pool.state)
+	pool.state = &resource.State{Assignments: []*resource.Assignment{}}
+	resourceLock.Unlock()
+
+	_, err = server.Close(ctx, request.GetConnection())
+	require.NoError(t, err)
+
+	state, err := resource.ReadStateFile(statePath)
+	require.NoError(t, err)
+	require.Empty(t, state.Assignments)
+
+	lease.acquirable.Store(false)
+	require.Eventually(t, func() bool {
+		_, err = server.Request(ctx, request)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package stats
+
+import sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+
+// Option is an option for NewServer
+type Option func(s *statsServer)
+
+// WithVFConfigurator sets the VF configurator the VF traffic counters are read with, netlink by default
+func WithVFConfigurator(configurator sriovvfconfig.Configurator) Option {
+	return func(s *statsServer) {
+		s.configurator = configurator
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package stats provides chain element writing the selected VF traffic counters into the connection path metrics
+package stats
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// Metrics keys written into the path segment metrics
+const (
+	RxBytesKey   = "rx_bytes"
+	TxBytesKey   = "tx_bytes"
+	RxPacketsKey = "rx_packets"
+	TxPacketsKey = "tx_packets"
+	RxDropsKey   = "rx_drops"
+	TxDropsKey   = "tx_drops"
+)
+
+type statsServer struct {
+	configurator sriovvfconfig.Configurator
+}
+
+// NewServer returns a new stats server chain element. On each Request and Close it samples the traffic counters of the
+// VF selected by the previous chain elements from the PF VF info and writes them into the Forwarder path segment
+// metrics, so the periodic connection refreshes keep the metrics up to date for the NSM monitoring.
+func NewServer(options ...Option) networkservice.NetworkServiceServer {
+	s := &statsServer{
+		configurator: sriovvfconfig.NewNetlink(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *statsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	index := request.GetConnection().GetPath().GetIndex()
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if vfConfig, ok := vfconfig.Load(ctx, false); ok {
+		s.writeMetrics(ctx, conn, index, vfConfig)
+	}
+
+	return conn, nil
+}
+
+func (s *statsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if vfConfig, ok := vfconfig.Load(ctx, false); ok {
+		s.writeMetrics(ctx, conn, conn.GetPath().GetIndex(), vfConfig)
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *statsServer) writeMetrics(ctx context.Context, conn *networkservice.Connection, index uint32, vfConfig *vfconfig.VFConfig) {
+	logger := log.FromContext(ctx).WithField("statsServer", "writeMetrics")
+
+	segments := conn.GetPath().GetPathSegments()
+	if int(index) >= len(segments) {
+		return
+	}
+
+	vf, err := s.configurator.VF(vfConfig.PFInterfaceName, vfConfig.VFNum)
+	if err != nil {
+		logger.Warnf("failed to get VF %s stats: %s", vfConfig.VFPCIAddress, err.Error())
+		return
+	}
+
+	segment := segments[index]
+	if segment.Metrics == nil {
+		segment.Metrics = map[string]string{}
+	}
+	segment.Metrics[RxBytesKey] = strconv.FormatUint(vf.Stats.RxBytes, 10)
+	segment.Metrics[TxBytesKey] = strconv.FormatUint(vf.Stats.TxBytes, 10)
+	segment.Metrics[RxPacketsKey] = strconv.FormatUint(vf.Stats.RxPackets, 10)
+	segment.Metrics[TxPacketsKey] = strconv.FormatUint(vf.Stats.TxPackets, 10)
+	segment.Metrics[RxDropsKey] = strconv.FormatUint(vf.Stats.RxDropped, 10)
+	segment.Metrics[TxDropsKey] = strconv.FormatUint(vf.Stats.TxDropped, 10)
+}
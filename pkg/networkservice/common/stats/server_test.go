@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package stats_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/stats"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+func testRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Path: &networkservice.Path{
+				Index: 0,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "forwarder"},
+				},
+			},
+		},
+	}
+}
+
+func TestStatsServer_NoVF(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		stats.NewServer(),
+	)
+
+	conn, err := server.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+	require.Empty(t, conn.GetPath().GetPathSegments()[0].GetMetrics())
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
+
+func TestStatsServer_NoPF(t *testing.T) {
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			vfconfig.Store(ctx, false, &vfconfig.VFConfig{
+				PFInterfaceName: "not-existing-pf",
+				VFInterfaceName: "not-existing-vf",
+			})
+		}),
+		stats.NewServer(),
+	)
+
+	conn, err := server.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+	require.Empty(t, conn.GetPath().GetPathSegments()[0].GetMetrics())
+}
+
+func TestStatsServer_Metrics(t *testing.T) {
+	fake := sriovvfconfig.NewFake(2, "eth0")
+	fake.PFs["eth0"][1].Stats = sriovvfconfig.Stats{
+		RxPackets: 10,
+		TxPackets: 20,
+		RxBytes:   1000,
+		TxBytes:   2000,
+		RxDropped: 1,
+		TxDropped: 2,
+	}
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			vfconfig.Store(ctx, false, &vfconfig.VFConfig{
+				PFInterfaceName: "eth0",
+				VFNum:           1,
+			})
+		}),
+		stats.NewServer(stats.WithVFConfigurator(fake)),
+	)
+
+	conn, err := server.Request(context.Background(), testRequest())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		stats.RxBytesKey:   "1000",
+		stats.TxBytesKey:   "2000",
+		stats.RxPacketsKey: "10",
+		stats.TxPacketsKey: "20",
+		stats.RxDropsKey:   "1",
+		stats.TxDropsKey:   "2",
+	}, conn.GetPath().GetPathSegments()[0].GetMetrics())
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package tcoffload provides chain elements offloading the cross-connects between the VFs of the same switchdev mode
+// PF to the NIC eswitch with the TC flower rules on the VF representors
+package tcoffload
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/postpone"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+)
+
+// Manager is a tcflower.Manager interface
+type Manager interface {
+	Install(key string, rules ...*tcflower.Rule) error
+	Remove(key string) error
+}
+
+type tcOffloadClient struct {
+	manager Manager
+	netDir  string
+}
+
+// NewClient returns a new TC offload client chain element. If the VF selected for the client by the server chain and
+// the VF selected for the endpoint by the following client chain elements belong to the same switchdev mode PF, it
+// installs the TC flower rules redirecting the traffic between their representors, the rules are removed on Close.
+func NewClient(manager Manager, options ...Option) networkservice.NetworkServiceClient {
+	c := &tcOffloadClient{
+		manager: manager,
+		netDir:  tcflower.DefaultNetDir,
+	}
+	for _, opt := range options {
+		opt(&c.netDir)
+	}
+	return c
+}
+
+func (c *tcOffloadClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	clientVF, ok := vfconfig.Load(ctx, false)
+	if !ok {
+		return conn, nil
+	}
+	endpointVF, ok := vfconfig.Load(ctx, true)
+	if !ok || endpointVF.PFInterfaceName != clientVF.PFInterfaceName {
+		return conn, nil
+	}
+
+	if err := c.install(conn.GetId(), clientVF, endpointVF); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *tcOffloadClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.manager.Remove(conn.GetId()); err != nil {
+		log.FromContext(ctx).WithField("tcOffloadClient", "Close").
+			Warnf("failed to remove TC flower rules: %s", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *tcOffloadClient) install(connID string, clientVF, endpointVF *vfconfig.VFConfig) error {
+	clientRep, err := tcflower.VFRepresentor(c.netDir, clientVF.PFInterfaceName, clientVF.VFNum)
+	if err != nil {
+		return err
+	}
+	endpointRep, err := tcflower.VFRepresentor(c.netDir, endpointVF.PFInterfaceName, endpointVF.VFNum)
+	if err != nil {
+		return err
+	}
+	return c.manager.Install(connID,
+		&tcflower.Rule{Ingress: clientRep, Egress: endpointRep},
+		&tcflower.Rule{Ingress: endpointRep, Egress: clientRep},
+	)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tcoffload_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tcoffload"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+type fakeManager struct {
+	rules map[string][]*tcflower.Rule
+}
+
+func (m *fakeManager) Install(key string, rules ...*tcflower.Rule) error {
+	m.rules[key] = rules
+	return nil
+}
+
+func (m *fakeManager) Remove(key string) error {
+	delete(m.rules, key)
+	return nil
+}
+
+func testNetDir(t *testing.T) string {
+	netDir := t.TempDir()
+	for ifName, portName := range map[string]string{
+		"eth0":   "p0",
+		"eth0_1": "pf0vf1",
+		"eth0_2": "pf0vf2",
+	} {
+		dir := filepath.Join(netDir, ifName)
+		require.NoError(t, os.MkdirAll(dir, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "phys_switch_id"), []byte("0011"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "phys_port_name"), []byte(portName), 0o600))
+	}
+	return netDir
+}
+
+func TestTCOffloadClient_Request(t *testing.T) {
+	manager := &fakeManager{rules: map[string][]*tcflower.Rule{}}
+
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		tcoffload.NewClient(manager, tcoffload.WithNetDir(testNetDir(t))),
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			vfconfig.Store(ctx, false, &vfconfig.VFConfig{PFInterfaceName: "eth0", VFNum: 1})
+			vfconfig.Store(ctx, true, &vfconfig.VFConfig{PFInterfaceName: "eth0", VFNum: 2})
+		}),
+	)
+
+	conn, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*tcflower.Rule{
+		{Ingress: "eth0_1", Egress: "eth0_2"},
+		{Ingress: "eth0_2", Egress: "eth0_1"},
+	}, manager.rules["id"])
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Empty(t, manager.rules)
+}
+
+func TestTCOffloadClient_Request_DifferentPFs(t *testing.T) {
+	manager := &fakeManager{rules: map[string][]*tcflower.Rule{}}
+
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		tcoffload.NewClient(manager, tcoffload.WithNetDir(testNetDir(t))),
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			vfconfig.Store(ctx, false, &vfconfig.VFConfig{PFInterfaceName: "eth0", VFNum: 1})
+			vfconfig.Store(ctx, true, &vfconfig.VFConfig{PFInterfaceName: "eth1", VFNum: 2})
+		}),
+	)
+
+	_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, manager.rules)
+}
+
+func TestVLANTagger_TagVF(t *testing.T) {
+	manager := &fakeManager{rules: map[string][]*tcflower.Rule{}}
+	configurator := sriovvfconfig.NewFake(2, "eth0")
+	tagger := tcoffload.NewVLANTagger(manager, configurator, tcoffload.WithNetDir(testNetDir(t)))
+
+	vfConfig := &vfconfig.VFConfig{PFInterfaceName: "eth0", VFNum: 1}
+	require.NoError(t, tagger.TagVF(vfConfig, 100))
+	require.Equal(t, []*tcflower.Rule{
+		{Ingress: "eth0_1", Egress: "eth0", PushVLAN: 100},
+		{Ingress: "eth0", Egress: "eth0_1", MatchVLAN: 100, PopVLAN: true},
+	}, manager.rules["vlan/eth0/1"])
+
+	require.NoError(t, tagger.TagVF(vfConfig, 0))
+	require.Empty(t, manager.rules)
+
+	vf, err := configurator.VF("eth0", 1)
+	require.NoError(t, err)
+	require.Zero(t, vf.VLAN)
+}
+
+func TestVLANTagger_TagVF_LegacyPF(t *testing.T) {
+	netDir := testNetDir(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(netDir, "eth1"), 0o750))
+
+	manager := &fakeManager{rules: map[string][]*tcflower.Rule{}}
+	configurator := sriovvfconfig.NewFake(2, "eth1")
+	tagger := tcoffload.NewVLANTagger(manager, configurator, tcoffload.WithNetDir(netDir))
+
+	// the legacy mode PF has no VF representors, so the VF VLAN is set instead
+	vfConfig := &vfconfig.VFConfig{PFInterfaceName: "eth1", VFNum: 1}
+	require.NoError(t, tagger.TagVF(vfConfig, 100))
+	require.Empty(t, manager.rules)
+
+	vf, err := configurator.VF("eth1", 1)
+	require.NoError(t, err)
+	require.Equal(t, 100, vf.VLAN)
+
+	require.NoError(t, tagger.TagVF(vfConfig, 0))
+	vf, err = configurator.VF("eth1", 1)
+	require.NoError(t, err)
+	require.Zero(t, vf.VLAN)
+}
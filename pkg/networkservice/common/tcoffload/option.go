@@ -0,0 +1,31 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tcoffload
+
+// Option is an option for the TC offload chain elements, it sets the sysfs net interfaces directory used for the VF
+// representors lookup
+type Option func(netDir *string)
+
+// WithNetDir sets the sysfs net interfaces directory, tcflower.DefaultNetDir is used by default
+func WithNetDir(netDir string) Option {
+	return func(d *string) {
+		*d = netDir
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tcoffload
+
+import (
+	"fmt"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// VLANTagger is a vlan.VFTagger tagging the VF traffic with the TC flower rules between the VF representor and the PF
+// uplink representor instead of the VF VLAN. The VFs of the legacy mode PFs have no representors, so they are tagged
+// with the VF VLAN set by the configurator.
+type VLANTagger struct {
+	manager      Manager
+	configurator sriovvfconfig.Configurator
+	netDir       string
+}
+
+// NewVLANTagger returns a new VLANTagger setting the VF VLAN of the legacy mode PFs with the configurator
+func NewVLANTagger(manager Manager, configurator sriovvfconfig.Configurator, options ...Option) *VLANTagger {
+	t := &VLANTagger{
+		manager:      manager,
+		configurator: configurator,
+		netDir:       tcflower.DefaultNetDir,
+	}
+	for _, opt := range options {
+		opt(&t.netDir)
+	}
+	return t
+}
+
+// TagVF pushes the vlanID VLAN tag to the traffic sent by the VF to the PF uplink and pops it from the traffic
+// received on the PF uplink for the VF, vlanID == 0 removes the rules
+func (t *VLANTagger) TagVF(vfConfig *vfconfig.VFConfig, vlanID uint32) error {
+	if !tcflower.IsSwitchdev(t.netDir, vfConfig.PFInterfaceName) {
+		return t.configurator.SetVLAN(vfConfig.PFInterfaceName, vfConfig.VFNum, int(vlanID), 0)
+	}
+
+	key := fmt.Sprintf("vlan/%s/%d", vfConfig.PFInterfaceName, vfConfig.VFNum)
+	if vlanID == 0 {
+		return t.manager.Remove(key)
+	}
+
+	rep, err := tcflower.VFRepresentor(t.netDir, vfConfig.PFInterfaceName, vfConfig.VFNum)
+	if err != nil {
+		return err
+	}
+	return t.manager.Install(key,
+		&tcflower.Rule{Ingress: rep, Egress: vfConfig.PFInterfaceName, PushVLAN: uint16(vlanID)},
+		&tcflower.Rule{Ingress: vfConfig.PFInterfaceName, Egress: rep, MatchVLAN: uint16(vlanID), PopVLAN: true},
+	)
+}
@@ -29,6 +29,6 @@ import (
 )
 
 // NewClient returns a new token client chain element
-func NewClient() networkservice.NetworkServiceClient {
-	return multitoken.NewClient()
+func NewClient(options ...multitoken.Option) networkservice.NetworkServiceClient {
+	return multitoken.NewClient(options...)
 }
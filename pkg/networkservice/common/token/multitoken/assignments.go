@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multitoken
+
+import (
+	"sync"
+)
+
+// Assignment is a connection tokens assignment
+type Assignment struct {
+	TokenNames []string
+	TokenIDs   []string
+}
+
+// Assignments provides current connection tokens assignments of the token chain elements for debugging and metrics
+type Assignments struct {
+	assignments map[string]*Assignment // assignments[connectionID] -> *Assignment
+	lock        sync.RWMutex
+}
+
+// NewAssignments returns a new Assignments
+func NewAssignments() *Assignments {
+	return &Assignments{
+		assignments: map[string]*Assignment{},
+	}
+}
+
+// Get returns current assignments[connectionID] -> *Assignment
+func (a *Assignments) Get() map[string]*Assignment {
+	if a == nil {
+		return nil
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	assignments := make(map[string]*Assignment, len(a.assignments))
+	for connID, assignment := range a.assignments {
+		assignments[connID] = &Assignment{
+			TokenNames: append([]string(nil), assignment.TokenNames...),
+			TokenIDs:   append([]string(nil), assignment.TokenIDs...),
+		}
+	}
+	return assignments
+}
+
+func (a *Assignments) store(connID string, assignment *Assignment) {
+	if a == nil {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.assignments[connID] = assignment
+}
+
+func (a *Assignments) delete(connID string) {
+	if a == nil {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.assignments, connID)
+}
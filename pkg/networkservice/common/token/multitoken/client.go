@@ -18,79 +18,55 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build linux
-// +build linux
-
 // Package multitoken provides chain elements for inserting SRIOV tokens into request and response
 package multitoken
 
 import (
 	"context"
-	"os"
-	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
-const (
-	sriovTokenLabel    = "sriovToken"
-	serviceDomainLabel = "serviceDomain"
-)
-
 type tokenClient struct {
 	config tokenConfig
 }
 
 // NewClient returns a new token client chain element
-func NewClient() networkservice.NetworkServiceClient {
+func NewClient(options ...Option) networkservice.NetworkServiceClient {
+	o := &tokenOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.source == nil {
+		o.source = tokens.NewEnvSource()
+	}
+
 	return &tokenClient{
-		createTokenElement(tokens.FromEnv(os.Environ())),
+		config: createTokenElement(o),
 	}
 }
 
 func (c *tokenClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
-	isEstablished := c.config.get(request.GetConnection()) != ""
-
-	var tokenID string
-	var tokenName string
-	if labels := request.GetConnection().GetLabels(); labels != nil {
-		var ok bool
-		if tokenName, ok = labels[sriovTokenLabel]; ok {
-			tokenID = c.config.assign(tokenName, request.GetConnection())
-			if tokenID == "" {
-				return nil, errors.Errorf("no free token for the name: %v", tokenName)
-			}
-
-			request = request.Clone()
-			delete(request.GetConnection().GetLabels(), sriovTokenLabel)
-			request.GetConnection().GetLabels()[serviceDomainLabel] = strings.Split(tokenName, "/")[0]
+	isEstablished := len(c.config.get(request.GetConnection())) != 0
 
-			for _, mech := range request.GetMechanismPreferences() {
-				if mech.Parameters == nil {
-					mech.Parameters = map[string]string{}
-				}
-				mech.Parameters[common.DeviceTokenIDKey] = tokenID
-			}
-		}
+	request, labels, err := assignTokens(c.config, request)
+	if err != nil {
+		return nil, err
 	}
 
 	conn, err := next.Client(ctx).Request(ctx, request, opts...)
-	if err != nil && tokenID != "" && !isEstablished {
+	if err != nil && labels != nil && !isEstablished {
 		c.config.release(request.GetConnection())
 	}
 
-	if err == nil && tokenName != "" {
-		// Set the previous values in the labels. We need them for healing
-		delete(conn.GetLabels(), serviceDomainLabel)
-		conn.GetLabels()[sriovTokenLabel] = tokenName
+	if err == nil {
+		labels.restore(conn)
 	}
 
 	return conn, err
@@ -18,9 +18,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build linux
-// +build linux
-
 package multitoken_test
 
 import (
@@ -32,10 +29,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 
-	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
 
 	token "github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/token/multitoken"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
@@ -106,3 +104,263 @@ func (c *validateClient) Request(
 func (c *validateClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
+
+func TestTokenClient_Request_TokenSource(t *testing.T) {
+	source := tokens.SourceFunc(func() map[string][]string {
+		return map[string][]string{
+			tokenName: {tokenID},
+		}
+	})
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Labels: map[string]string{
+				sriovTokenLabel: tokenName,
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Type: "a",
+			},
+		},
+	}
+
+	client := chain.NewNetworkServiceClient(
+		token.NewClient(token.WithTokenSource(source)),
+		&validateClient{t},
+	)
+	_, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	request.GetConnection().Id = "id-2"
+	_, err = client.Request(context.Background(), request)
+	require.Error(t, err)
+}
+
+func TestTokenClient_Request_MultipleTokens(t *testing.T) {
+	const (
+		tokenName2 = "service.domain.2/10G"
+		tokenID2   = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2"
+	)
+
+	source := tokens.SourceFunc(func() map[string][]string {
+		return map[string][]string{
+			tokenName:  {tokenID},
+			tokenName2: {tokenID2},
+		}
+	})
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Labels: map[string]string{
+				sriovTokenLabel: tokenName + ", " + tokenName2,
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Type: "a",
+			},
+		},
+	}
+
+	client := chain.NewNetworkServiceClient(
+		token.NewClient(token.WithTokenSource(source)),
+		checkrequest.NewClient(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.Equal(t, map[string]string{
+				serviceDomainLabel:        serviceDomain,
+				serviceDomainLabel + "-1": "service.domain.2",
+			}, request.GetConnection().GetLabels())
+			require.Equal(t, map[string]string{
+				common.DeviceTokenIDKey: tokenID,
+				token.TokenIDKey(1):     tokenID2,
+			}, request.GetMechanismPreferences()[0].GetParameters())
+		}),
+	)
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		sriovTokenLabel: tokenName + ", " + tokenName2,
+	}, conn.GetLabels())
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	// all tokens are assigned or none
+	request.GetConnection().Id = "id-2"
+	request.GetConnection().GetLabels()[sriovTokenLabel] = tokenName2 + ",service.domain.3/10G"
+	_, err = client.Request(context.Background(), request)
+	require.Error(t, err)
+
+	client = chain.NewNetworkServiceClient(
+		token.NewClient(token.WithTokenSource(source)),
+	)
+	request.GetConnection().Id = "id-3"
+	request.GetConnection().GetLabels()[sriovTokenLabel] = tokenName2
+	_, err = client.Request(context.Background(), request)
+	require.NoError(t, err)
+}
+
+func TestTokenClient_Request_Fallback(t *testing.T) {
+	const (
+		tokenName2 = "service.domain.2/10G"
+		tokenID2   = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2"
+		fallback   = "service.domain.3/10G; " + tokenName2
+	)
+
+	source := tokens.SourceFunc(func() map[string][]string {
+		return map[string][]string{
+			tokenName:  {tokenID},
+			tokenName2: {tokenID2},
+		}
+	})
+
+	newRequest := func(id string) *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Labels: map[string]string{
+					sriovTokenLabel:      tokenName,
+					"sriovTokenFallback": fallback,
+				},
+			},
+			MechanismPreferences: []*networkservice.Mechanism{
+				{
+					Type: "a",
+				},
+			},
+		}
+	}
+
+	var tokenIDs []string
+	client := chain.NewNetworkServiceClient(
+		token.NewClient(token.WithTokenSource(source)),
+		checkrequest.NewClient(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.NotContains(t, request.GetConnection().GetLabels(), "sriovTokenFallback")
+			tokenIDs = append(tokenIDs, request.GetMechanismPreferences()[0].GetParameters()[common.DeviceTokenIDKey])
+		}),
+	)
+
+	conn, err := client.Request(context.Background(), newRequest("id-1"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		sriovTokenLabel:      tokenName,
+		"sriovTokenFallback": fallback,
+	}, conn.GetLabels())
+
+	// refresh keeps the same token
+	_, err = client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection:           conn,
+		MechanismPreferences: newRequest("id-1").GetMechanismPreferences(),
+	})
+	require.NoError(t, err)
+
+	_, err = client.Request(context.Background(), newRequest("id-2"))
+	require.NoError(t, err)
+
+	_, err = client.Request(context.Background(), newRequest("id-3"))
+	require.Error(t, err)
+
+	require.Equal(t, []string{tokenID, tokenID, tokenID2}, tokenIDs)
+}
+
+func TestTokenClient_Assignments(t *testing.T) {
+	source := tokens.SourceFunc(func() map[string][]string {
+		return map[string][]string{
+			tokenName: {tokenID},
+		}
+	})
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Labels: map[string]string{
+				sriovTokenLabel: tokenName,
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Type: "a",
+			},
+		},
+	}
+
+	assignments := token.NewAssignments()
+	client := chain.NewNetworkServiceClient(
+		token.NewClient(token.WithTokenSource(source), token.WithAssignments(assignments)),
+		&validateClient{t},
+	)
+	require.Empty(t, assignments.Get())
+
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]*token.Assignment{
+		"id": {
+			TokenNames: []string{tokenName},
+			TokenIDs:   []string{tokenID},
+		},
+	}, assignments.Get())
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Empty(t, assignments.Get())
+}
+
+func TestAssignments_Nil(t *testing.T) {
+	var assignments *token.Assignments
+	require.Nil(t, assignments.Get())
+}
+
+func TestTokenClient_Request_Refresh(t *testing.T) {
+	const tokenID2 = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2"
+
+	allocatableIDs := []string{tokenID}
+	source := tokens.SourceFunc(func() map[string][]string {
+		return map[string][]string{
+			tokenName: allocatableIDs,
+		}
+	})
+
+	var expectedID string
+	client := chain.NewNetworkServiceClient(
+		token.NewClient(token.WithTokenSource(source)),
+		checkrequest.NewClient(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.Equal(t, expectedID, request.GetMechanismPreferences()[0].GetParameters()[common.DeviceTokenIDKey])
+		}),
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Labels: map[string]string{
+				sriovTokenLabel: tokenName,
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Type: "a",
+			},
+		},
+	}
+
+	expectedID = tokenID
+	conn, err := client.Request(context.Background(), request.Clone())
+	require.NoError(t, err)
+
+	// Token is still present in the source, so it is kept
+	request.Connection = conn.Clone()
+	_, err = client.Request(context.Background(), request.Clone())
+	require.NoError(t, err)
+
+	// Token has been reallocated, so a fresh one is assigned
+	allocatableIDs = []string{tokenID2}
+	expectedID = tokenID2
+	_, err = client.Request(context.Background(), request.Clone())
+	require.NoError(t, err)
+
+	// No tokens left
+	allocatableIDs = nil
+	_, err = client.Request(context.Background(), request.Clone())
+	require.Error(t, err)
+}
@@ -14,69 +14,153 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build linux
-// +build linux
-
 package multitoken
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
+// TokenIDKey returns mechanism parameter key for the i-th token ID of the connection
+func TokenIDKey(i int) string {
+	if i == 0 {
+		return common.DeviceTokenIDKey
+	}
+	return fmt.Sprintf("%s-%d", common.DeviceTokenIDKey, i)
+}
+
 type tokenElement struct {
 	lock                sync.Mutex
-	tokens              map[string][]string // tokens[tokenName] -> []tokenIDs
-	connectionsByTokens map[string]string   // connectionsByTokens[tokenID] -> connectionID
-	tokensByConnections map[string]string   // tokensByConnections[connectionID] -> tokenID
+	source              tokens.Source
+	connectionsByTokens map[string]string      // connectionsByTokens[tokenID] -> connectionID
+	tokensByConnections map[string]*Assignment // tokensByConnections[connectionID] -> *Assignment
+	assignments         *Assignments
 }
 
 type tokenConfig interface {
-	assign(tokenName string, conn *networkservice.Connection) (tokenID string)
-	get(conn *networkservice.Connection) (tokenID string)
+	assign(candidates [][]string, conn *networkservice.Connection) (tokenNames, tokenIDs []string)
+	get(conn *networkservice.Connection) (tokenIDs []string)
 	release(conn *networkservice.Connection)
 }
 
-func createTokenElement(allocatableTokens map[string][]string) tokenConfig {
-	return &tokenElement{tokens: allocatableTokens,
+func createTokenElement(o *tokenOptions) tokenConfig {
+	return &tokenElement{source: o.source,
 		connectionsByTokens: map[string]string{},
-		tokensByConnections: map[string]string{}}
+		tokensByConnections: map[string]*Assignment{},
+		assignments:         o.assignments}
 }
 
-func (c *tokenElement) assign(tokenName string, conn *networkservice.Connection) (tokenID string) {
+// assign assigns a free token for each of candidate token names to the connection, candidates are tried in order until
+// all tokens of some candidate can be assigned. Returns already assigned tokens for the established connection if they
+// are still present in the token source, otherwise reassigns fresh tokens.
+func (c *tokenElement) assign(candidates [][]string, conn *networkservice.Connection) (tokenNames, tokenIDs []string) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	var ok bool
-	if tokenID, ok = c.tokensByConnections[conn.GetId()]; ok {
-		return tokenID
+	allocatableTokens := c.source.Tokens()
+	a, isAssigned := c.tokensByConnections[conn.GetId()]
+	if isAssigned && isAllocatable(allocatableTokens, a) {
+		return a.TokenNames, a.TokenIDs
 	}
 
-	for _, tokenID = range c.tokens[tokenName] {
-		if _, ok := c.connectionsByTokens[tokenID]; !ok {
-			c.connectionsByTokens[tokenID] = conn.GetId()
-			c.tokensByConnections[conn.GetId()] = tokenID
+	for _, tokenNames = range candidates {
+		if tokenIDs = c.findFreeAll(allocatableTokens, tokenNames, conn.GetId()); tokenIDs != nil {
 			break
 		}
-		tokenID = ""
 	}
-	return
+	if tokenIDs == nil {
+		// The stale tokens are kept until they can be replaced, so the following requests retry the replacement
+		return nil, nil
+	}
+	if isAssigned {
+		// Tokens can be reallocated after the device plugin restart, so we shouldn't keep the stale ones
+		c.releaseAssignment(conn.GetId(), a)
+	}
+
+	for _, tokenID := range tokenIDs {
+		c.connectionsByTokens[tokenID] = conn.GetId()
+	}
+	a = &Assignment{
+		TokenNames: tokenNames,
+		TokenIDs:   tokenIDs,
+	}
+	c.tokensByConnections[conn.GetId()] = a
+	c.assignments.store(conn.GetId(), a)
+
+	return tokenNames, tokenIDs
+}
+
+func (c *tokenElement) findFreeAll(allocatableTokens map[string][]string, tokenNames []string, connID string) (tokenIDs []string) {
+	for _, tokenName := range tokenNames {
+		tokenID := c.findFree(allocatableTokens[tokenName], tokenIDs, connID)
+		if tokenID == "" {
+			return nil
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	return tokenIDs
+}
+
+// findFree returns a token ID not assigned to any other connection than connID
+func (c *tokenElement) findFree(tokenIDs, assignedIDs []string, connID string) string {
+	for _, tokenID := range tokenIDs {
+		if owner, ok := c.connectionsByTokens[tokenID]; ok && owner != connID {
+			continue
+		}
+		if contains(assignedIDs, tokenID) {
+			continue
+		}
+		return tokenID
+	}
+	return ""
 }
 
-func (c *tokenElement) get(conn *networkservice.Connection) (tokenID string) {
+func (c *tokenElement) get(conn *networkservice.Connection) (tokenIDs []string) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	return c.tokensByConnections[conn.GetId()]
+	if a, ok := c.tokensByConnections[conn.GetId()]; ok {
+		return a.TokenIDs
+	}
+	return nil
 }
 
 func (c *tokenElement) release(conn *networkservice.Connection) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if tokenID, ok := c.tokensByConnections[conn.GetId()]; ok {
+	if a, ok := c.tokensByConnections[conn.GetId()]; ok {
+		c.releaseAssignment(conn.GetId(), a)
+	}
+}
+
+func (c *tokenElement) releaseAssignment(connID string, a *Assignment) {
+	for _, tokenID := range a.TokenIDs {
 		delete(c.connectionsByTokens, tokenID)
-		delete(c.tokensByConnections, conn.GetId())
 	}
+	delete(c.tokensByConnections, connID)
+	c.assignments.delete(connID)
+}
+
+func isAllocatable(allocatableTokens map[string][]string, a *Assignment) bool {
+	for i, tokenID := range a.TokenIDs {
+		if !contains(allocatableTokens[a.TokenNames[i]], tokenID) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multitoken
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+type tokenEndpointServer struct {
+	config tokenConfig
+}
+
+// NewEndpointServer returns a new token server chain element assigning tokens for the request token labels on the
+// endpoint side, it should be used when NSE (not NSC) owns the SR-IOV resource
+func NewEndpointServer(options ...Option) networkservice.NetworkServiceServer {
+	o := &tokenOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.source == nil {
+		o.source = tokens.NewEnvSource()
+	}
+
+	return &tokenEndpointServer{
+		config: createTokenElement(o),
+	}
+}
+
+func (s *tokenEndpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	isEstablished := len(s.config.get(request.GetConnection())) != 0
+
+	request, labels, err := assignTokens(s.config, request)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && labels != nil && !isEstablished {
+		s.config.release(request.GetConnection())
+	}
+
+	if err == nil {
+		labels.restore(conn)
+	}
+
+	return conn, err
+}
+
+func (s *tokenEndpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.config.release(conn)
+	return next.Server(ctx).Close(ctx, conn)
+}
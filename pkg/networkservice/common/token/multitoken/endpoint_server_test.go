@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multitoken_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	token "github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/token/multitoken"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+func TestTokenEndpointServer_Request(t *testing.T) {
+	source := tokens.SourceFunc(func() map[string][]string {
+		return map[string][]string{
+			tokenName: {tokenID},
+		}
+	})
+
+	newRequest := func(id string) *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Labels: map[string]string{
+					sriovTokenLabel: tokenName,
+				},
+				Mechanism: &networkservice.Mechanism{
+					Type: "a",
+				},
+			},
+		}
+	}
+
+	// release on failure
+	server := token.NewEndpointServer(token.WithTokenSource(source))
+	_, err := chain.NewNetworkServiceServer(
+		server,
+		injecterror.NewServer(injecterror.WithError(errors.New("error"))),
+	).Request(context.Background(), newRequest("id-1"))
+	require.Error(t, err)
+
+	conn, err := chain.NewNetworkServiceServer(
+		server,
+		checkrequest.NewServer(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.Equal(t, map[string]string{
+				serviceDomainLabel: serviceDomain,
+			}, request.GetConnection().GetLabels())
+			require.Equal(t, tokenID, request.GetConnection().GetMechanism().GetParameters()[common.DeviceTokenIDKey])
+		}),
+	).Request(context.Background(), newRequest("id-2"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		sriovTokenLabel: tokenName,
+	}, conn.GetLabels())
+
+	_, err = chain.NewNetworkServiceServer(server).Request(context.Background(), newRequest("id-3"))
+	require.Error(t, err)
+
+	_, err = chain.NewNetworkServiceServer(server).Close(context.Background(), conn)
+	require.NoError(t, err)
+
+	_, err = chain.NewNetworkServiceServer(server).Request(context.Background(), newRequest("id-3"))
+	require.NoError(t, err)
+}
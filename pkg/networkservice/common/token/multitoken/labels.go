@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multitoken
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const (
+	sriovTokenLabel         = "sriovToken"
+	sriovTokenFallbackLabel = "sriovTokenFallback"
+	serviceDomainLabel      = "serviceDomain"
+)
+
+// tokenLabels are the request token labels, they are replaced with service domain labels in the request and restored
+// in the response
+type tokenLabels struct {
+	tokenLabel    string
+	fallbackLabel string
+	tokensCount   int
+}
+
+// assignTokens assigns tokens for the request token labels and returns the request with token labels replaced by service
+// domain labels and token IDs inserted into mechanism parameters. Returns nil tokenLabels if request has no token
+// labels.
+func assignTokens(config tokenConfig, request *networkservice.NetworkServiceRequest) (*networkservice.NetworkServiceRequest, *tokenLabels, error) {
+	tokenLabel, ok := request.GetConnection().GetLabels()[sriovTokenLabel]
+	if !ok {
+		return request, nil, nil
+	}
+	fallbackLabel := request.GetConnection().GetLabels()[sriovTokenFallbackLabel]
+
+	candidates := append([][]string{parseTokenNames(tokenLabel)}, parseFallbackTokenNames(fallbackLabel)...)
+	tokenNames, tokenIDs := config.assign(candidates, request.GetConnection())
+	if len(tokenIDs) == 0 {
+		return nil, nil, errors.Errorf("no free token for the names: %v", candidates)
+	}
+
+	request = request.Clone()
+	delete(request.GetConnection().GetLabels(), sriovTokenLabel)
+	delete(request.GetConnection().GetLabels(), sriovTokenFallbackLabel)
+	for i, tokenName := range tokenNames {
+		request.GetConnection().GetLabels()[serviceDomainKey(i)] = strings.Split(tokenName, "/")[0]
+	}
+
+	mechs := request.GetMechanismPreferences()
+	if mech := request.GetConnection().GetMechanism(); mech != nil {
+		mechs = append(mechs, mech)
+	}
+	for _, mech := range mechs {
+		if mech.Parameters == nil {
+			mech.Parameters = map[string]string{}
+		}
+		for i, tokenID := range tokenIDs {
+			mech.Parameters[TokenIDKey(i)] = tokenID
+		}
+	}
+
+	return request, &tokenLabels{
+		tokenLabel:    tokenLabel,
+		fallbackLabel: fallbackLabel,
+		tokensCount:   len(tokenIDs),
+	}, nil
+}
+
+// restore sets the previous values in the connection labels. We need them for healing
+func (l *tokenLabels) restore(conn *networkservice.Connection) {
+	if l == nil {
+		return
+	}
+
+	for i := 0; i < l.tokensCount; i++ {
+		delete(conn.GetLabels(), serviceDomainKey(i))
+	}
+	if conn.GetLabels() == nil {
+		conn.Labels = map[string]string{}
+	}
+	conn.GetLabels()[sriovTokenLabel] = l.tokenLabel
+	if l.fallbackLabel != "" {
+		conn.GetLabels()[sriovTokenFallbackLabel] = l.fallbackLabel
+	}
+}
+
+// parseTokenNames parses comma separated token names label value
+func parseTokenNames(tokenLabel string) (tokenNames []string) {
+	for _, tokenName := range strings.Split(tokenLabel, ",") {
+		if tokenName = strings.TrimSpace(tokenName); tokenName != "" {
+			tokenNames = append(tokenNames, tokenName)
+		}
+	}
+	return tokenNames
+}
+
+// parseFallbackTokenNames parses semicolon separated fallback token names label value, each fallback has the same format
+// as sriovToken label value: "service.domain.2/10G; service.domain.3/10G,service.domain.4/10G"
+func parseFallbackTokenNames(fallbackLabel string) (candidates [][]string) {
+	for _, fallback := range strings.Split(fallbackLabel, ";") {
+		if tokenNames := parseTokenNames(fallback); len(tokenNames) != 0 {
+			candidates = append(candidates, tokenNames)
+		}
+	}
+	return candidates
+}
+
+// serviceDomainKey returns label key for the i-th token service domain
+func serviceDomainKey(i int) string {
+	if i == 0 {
+		return serviceDomainLabel
+	}
+	return fmt.Sprintf("%s-%d", serviceDomainLabel, i)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multitoken
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+type tokenOptions struct {
+	source      tokens.Source
+	assignments *Assignments
+}
+
+// Option is an option for the token chain elements
+type Option func(o *tokenOptions)
+
+// WithTokenSource sets source of the allocatable tokens, by default tokens are taken from the environment variables
+func WithTokenSource(source tokens.Source) Option {
+	return func(o *tokenOptions) {
+		o.source = source
+	}
+}
+
+// WithAssignments sets Assignments updated with the chain element connection tokens assignments
+func WithAssignments(assignments *Assignments) Option {
+	return func(o *tokenOptions) {
+		o.assignments = assignments
+	}
+}
@@ -16,22 +16,20 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build linux
-// +build linux
-
 package multitoken
 
 import (
 	"context"
-	"os"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
-	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
 )
 
 type tokenServer struct {
@@ -40,30 +38,49 @@ type tokenServer struct {
 }
 
 // NewServer returns a new multi token server chain element for the given tokenKey
-func NewServer(tokenKey string) networkservice.NetworkServiceServer {
+func NewServer(tokenKey string, options ...Option) networkservice.NetworkServiceServer {
+	o := &tokenOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.source == nil {
+		o.source = tokens.NewEnvSource()
+	}
+
 	return &tokenServer{
 		tokenName: tokenKey,
-		config: createTokenElement(map[string][]string{
-			tokenKey: tokens.FromEnv(os.Environ())[tokenKey],
-		}),
+		config:    createTokenElement(o),
 	}
 }
 
 func (s *tokenServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	isEstablished := s.config.get(request.GetConnection()) != ""
+	assignedIDs := s.config.get(request.GetConnection())
+	isEstablished := len(assignedIDs) != 0
 
-	var tokenID string
+	var tokenIDs []string
 	mechanism := kernel.ToMechanism(request.GetConnection().GetMechanism())
-	if mechanism != nil && mechanism.GetDeviceTokenID() == "" {
-		if tokenID = s.config.assign(s.tokenName, request.GetConnection()); tokenID != "" {
-			mechanism.SetDeviceTokenID(tokenID)
+	switch {
+	case mechanism == nil:
+	case mechanism.GetDeviceTokenID() == "", isEstablished && mechanism.GetDeviceTokenID() == assignedIDs[0]:
+		// Refresh requests are re-validated, so the stale token is replaced with a fresh one
+		_, span := tracing.Start(ctx, "token/assign")
+		var tokenID string
+		if _, tokenIDs = s.config.assign([][]string{{s.tokenName}}, request.GetConnection()); len(tokenIDs) != 0 {
+			tokenID = tokenIDs[0]
+		}
+		span.SetAttributes(tracing.TokenIDKey.String(tokenID))
+		span.End()
+		if tokenID == "" && isEstablished {
+			// Wiping the token would make the following chain elements tear the established datapath down
+			return nil, errors.Errorf("no free token to replace the stale token %s: %s", assignedIDs[0], s.tokenName)
 		}
-	} else if mechanism != nil && mechanism.GetDeviceTokenID() != "" {
+		mechanism.SetDeviceTokenID(tokenID)
+	default:
 		isEstablished = true
 	}
 
 	conn, err := next.Server(ctx).Request(ctx, request)
-	if err != nil && tokenID != "" && !isEstablished {
+	if err != nil && len(tokenIDs) != 0 && !isEstablished {
 		s.config.release(request.GetConnection())
 	}
 
@@ -32,10 +32,11 @@ import (
 )
 
 // NewServer returns a new token server chain element for the given tokenKey
-func NewServer(tokenKey string) networkservice.NetworkServiceServer {
+// NOTE: options are applied only for the multi token server
+func NewServer(tokenKey string, options ...multitoken.Option) networkservice.NetworkServiceServer {
 	sriovTokens := tokens.FromEnv(os.Environ())[tokenKey]
 	if len(sriovTokens) == 1 {
 		return sharedtoken.NewServer(sriovTokens[0])
 	}
-	return multitoken.NewServer(tokenKey)
+	return multitoken.NewServer(tokenKey, options...)
 }
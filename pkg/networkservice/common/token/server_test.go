@@ -31,6 +31,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/token/multitoken"
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
@@ -142,3 +143,39 @@ func TestMultiTokenServer_Request(t *testing.T) {
 	require.NotNil(t, mech3)
 	require.Equal(t, "", mech3.GetDeviceTokenID())
 }
+
+func TestMultiTokenServer_Request_RefreshStaleToken(t *testing.T) {
+	allocatableIDs := []string{tokenID1}
+	server := chain.NewNetworkServiceServer(
+		multitoken.NewServer(tokenName, multitoken.WithTokenSource(tokens.SourceFunc(func() map[string][]string {
+			return map[string][]string{
+				tokenName: allocatableIDs,
+			}
+		}))),
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type:       kernel.MECHANISM,
+				Parameters: map[string]string{},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, tokenID1, kernel.ToMechanism(conn.GetMechanism()).GetDeviceTokenID())
+
+	// No replacement for the stale token, the established connection token is kept
+	allocatableIDs = nil
+	request := &networkservice.NetworkServiceRequest{Connection: conn.Clone()}
+	refreshed, err := server.Request(context.Background(), request)
+	require.Error(t, err)
+	require.Nil(t, refreshed)
+	require.Equal(t, tokenID1, kernel.ToMechanism(request.GetConnection().GetMechanism()).GetDeviceTokenID())
+
+	allocatableIDs = []string{tokenID2}
+	refreshed, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: request.GetConnection()})
+	require.NoError(t, err)
+	require.Equal(t, tokenID2, kernel.ToMechanism(refreshed.GetMechanism()).GetDeviceTokenID())
+}
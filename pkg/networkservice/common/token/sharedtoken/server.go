@@ -28,6 +28,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tracing"
 )
 
 type tokenServer struct {
@@ -44,7 +46,9 @@ func NewServer(token string) networkservice.NetworkServiceServer {
 func (s *tokenServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	mechanism := kernel.ToMechanism(request.GetConnection().GetMechanism())
 	if mechanism != nil && mechanism.GetDeviceTokenID() == "" {
+		_, span := tracing.Start(ctx, "token/assign", tracing.TokenIDKey.String(s.sharedToken))
 		mechanism.SetDeviceTokenID(s.sharedToken)
+		span.End()
 	}
 	return next.Server(ctx).Request(ctx, request)
 }
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenaccess
+
+import (
+	"github.com/edwarnicke/genericsync"
+)
+
+// Allocations is a TokenOwners storing the token ID -> owner identity (e.g. client spiffe ID) records
+type Allocations struct {
+	owners genericsync.Map[string, string]
+}
+
+// NewAllocations returns a new Allocations
+func NewAllocations() *Allocations {
+	return &Allocations{}
+}
+
+// Record records the owner identity for the token ID
+func (a *Allocations) Record(tokenID, owner string) {
+	a.owners.Store(tokenID, owner)
+}
+
+// Forget removes the record for the token ID
+func (a *Allocations) Forget(tokenID string) {
+	a.owners.Delete(tokenID)
+}
+
+// Owner returns the owner identity recorded for the token ID
+func (a *Allocations) Owner(tokenID string) (string, bool) {
+	return a.owners.Load(tokenID)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokenaccess provides chain element rejecting requests presenting device tokens allocated to other clients
+package tokenaccess
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/token/multitoken"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/identity"
+)
+
+// TokenOwners provides the owner identity (e.g. client spiffe ID) for the allocated token IDs
+type TokenOwners interface {
+	Owner(tokenID string) (string, bool)
+}
+
+type tokenAccessServer struct {
+	tokenOwners TokenOwners
+}
+
+// NewServer returns a new token access server chain element. It validates all the token ID parameters of the
+// requested mechanisms against the client identity taken from the first path segment token subject: requests
+// presenting tokens owned by another identity are rejected, tokens without recorded owner are allowed.
+func NewServer(tokenOwners TokenOwners) networkservice.NetworkServiceServer {
+	return &tokenAccessServer{
+		tokenOwners: tokenOwners,
+	}
+}
+
+func (s *tokenAccessServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanisms := append([]*networkservice.Mechanism{request.GetConnection().GetMechanism()}, request.GetMechanismPreferences()...)
+	for _, mechanism := range mechanisms {
+		for _, tokenID := range tokenIDs(mechanism) {
+			if tokenID == "" {
+				continue
+			}
+			owner, ok := s.tokenOwners.Owner(tokenID)
+			if !ok {
+				continue
+			}
+			if clientID := identity.FromPath(ctx, request.GetConnection().GetPath()); clientID != owner {
+				return nil, errors.Errorf("token %s is not allocated to the client: %s", tokenID, clientID)
+			}
+		}
+	}
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+// tokenIDs returns all the token IDs presented in the mechanism: the common.DeviceTokenIDKey one, the
+// resourcepool.TokenIDs ones and the multitoken.TokenIDKey ones
+func tokenIDs(mechanism *networkservice.Mechanism) []string {
+	ids := append([]string{mechanism.GetParameters()[common.DeviceTokenIDKey]}, resourcepool.TokenIDs(mechanism)...)
+	for i := 1; ; i++ {
+		tokenID, ok := mechanism.GetParameters()[multitoken.TokenIDKey(i)]
+		if !ok {
+			return ids
+		}
+		ids = append(ids, tokenID)
+	}
+}
+
+func (s *tokenAccessServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
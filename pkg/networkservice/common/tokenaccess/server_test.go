@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenaccess_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/token/multitoken"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tokenaccess"
+)
+
+const (
+	ownerID = "spiffe://example.org/ns/default/pod/nsc-1"
+	otherID = "spiffe://example.org/ns/default/pod/nsc-2"
+	tokenID = "token-1"
+)
+
+func request(t *testing.T, spiffeID, tokenID string) *networkservice.NetworkServiceRequest {
+	return requestWithParameters(t, spiffeID, map[string]string{
+		common.DeviceTokenIDKey: tokenID,
+	})
+}
+
+func requestWithParameters(t *testing.T, spiffeID string, params map[string]string) *networkservice.NetworkServiceRequest {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+		SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Token: token},
+				},
+			},
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			{
+				Parameters: params,
+			},
+		},
+	}
+}
+
+func TestTokenAccessServer(t *testing.T) {
+	allocations := tokenaccess.NewAllocations()
+	allocations.Record(tokenID, ownerID)
+
+	server := tokenaccess.NewServer(allocations)
+
+	_, err := server.Request(context.Background(), request(t, ownerID, tokenID))
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), request(t, otherID, tokenID))
+	require.Error(t, err)
+
+	_, err = server.Request(context.Background(), request(t, otherID, "not-recorded-token"))
+	require.NoError(t, err)
+
+	allocations.Forget(tokenID)
+	_, err = server.Request(context.Background(), request(t, otherID, tokenID))
+	require.NoError(t, err)
+}
+
+func TestTokenAccessServer_MultipleTokens(t *testing.T) {
+	const ownTokenID = "token-2"
+
+	allocations := tokenaccess.NewAllocations()
+	allocations.Record(tokenID, ownerID)
+	allocations.Record(ownTokenID, otherID)
+
+	server := tokenaccess.NewServer(allocations)
+
+	_, err := server.Request(context.Background(), requestWithParameters(t, otherID, map[string]string{
+		resourcepool.TokenIDsKey: ownTokenID + "," + ownTokenID,
+	}))
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), requestWithParameters(t, otherID, map[string]string{
+		resourcepool.TokenIDsKey: ownTokenID + "," + tokenID,
+	}))
+	require.Error(t, err)
+
+	_, err = server.Request(context.Background(), requestWithParameters(t, otherID, map[string]string{
+		resourcepool.TokenIDsKey: ownTokenID,
+		common.DeviceTokenIDKey:  tokenID,
+	}))
+	require.Error(t, err)
+
+	_, err = server.Request(context.Background(), requestWithParameters(t, otherID, map[string]string{
+		multitoken.TokenIDKey(0): ownTokenID,
+		multitoken.TokenIDKey(1): tokenID,
+	}))
+	require.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vfhealth provides chain element closing the connections using the unhealthy VFs
+package vfhealth
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+)
+
+// HealthMonitor is a PF/VF health monitor
+type HealthMonitor interface {
+	AddListener(listener func(event *hotplug.Event))
+}
+
+type connectionInfo struct {
+	pfPCIAddr    string
+	vfPCIAddr    string
+	eventFactory begin.EventFactory
+}
+
+type vfHealthServer struct {
+	ctx         context.Context
+	pfPCIAddrs  map[string]string // pfPCIAddrs[vfPCIAddr] -> pfPCIAddr
+	connections genericsync.Map[string, *connectionInfo]
+}
+
+// NewServer returns a new VF health server chain element. It closes the connections using VFs of the PF with the link
+// down or using VFs disappeared from the host, so the clients can heal instead of keeping the broken connections. It
+// should be placed after begin and after the chain elements selecting the VF.
+func NewServer(ctx context.Context, healthMonitor HealthMonitor, cfg *config.Config) networkservice.NetworkServiceServer {
+	s := &vfHealthServer{
+		ctx:        ctx,
+		pfPCIAddrs: map[string]string{},
+	}
+	for pfPCIAddr, pf := range cfg.PhysicalFunctions {
+		for _, vf := range pf.VirtualFunctions {
+			s.pfPCIAddrs[vf.Address] = pfPCIAddr
+		}
+	}
+
+	healthMonitor.AddListener(s.onEvent)
+
+	return s
+}
+
+func (s *vfHealthServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	vfPCIAddr := conn.GetMechanism().GetParameters()[common.PCIAddressKey]
+	if pfPCIAddr, ok := s.pfPCIAddrs[vfPCIAddr]; ok {
+		s.connections.Store(conn.GetId(), &connectionInfo{
+			pfPCIAddr:    pfPCIAddr,
+			vfPCIAddr:    vfPCIAddr,
+			eventFactory: begin.FromContext(ctx),
+		})
+	} else {
+		s.connections.Delete(conn.GetId())
+	}
+
+	return conn, nil
+}
+
+func (s *vfHealthServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.connections.Delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *vfHealthServer) onEvent(event *hotplug.Event) {
+	logger := log.FromContext(s.ctx).WithField("vfHealthServer", "onEvent")
+
+	missingVFs := map[string]struct{}{}
+	for _, vfPCIAddr := range event.MissingVFs {
+		missingVFs[vfPCIAddr] = struct{}{}
+	}
+
+	s.connections.Range(func(connID string, info *connectionInfo) bool {
+		if info.pfPCIAddr != event.PFPCIAddr {
+			return true
+		}
+		if _, isMissing := missingVFs[info.vfPCIAddr]; event.LinkUp && !isMissing {
+			return true
+		}
+
+		logger.Warnf("VF %s is unhealthy, closing the connection: %s", info.vfPCIAddr, connID)
+		s.connections.Delete(connID)
+		info.eventFactory.Close(begin.CancelContext(s.ctx))
+
+		return true
+	})
+}
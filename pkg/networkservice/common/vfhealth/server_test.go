@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfhealth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/vfhealth"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+)
+
+const (
+	pf1PCIAddr  = "0000:01:00.0"
+	pf2PCIAddr  = "0000:02:00.0"
+	vf11PCIAddr = "0000:01:00.1"
+	vf12PCIAddr = "0000:01:00.2"
+	vf21PCIAddr = "0000:02:00.1"
+
+	timeout = time.Second
+	tick    = 10 * time.Millisecond
+)
+
+type testMonitor struct {
+	listeners []func(event *hotplug.Event)
+}
+
+func (m *testMonitor) AddListener(listener func(event *hotplug.Event)) {
+	m.listeners = append(m.listeners, listener)
+}
+
+func (m *testMonitor) notify(event *hotplug.Event) {
+	for _, listener := range m.listeners {
+		listener(event)
+	}
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			pf1PCIAddr: {
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: vf11PCIAddr},
+					{Address: vf12PCIAddr},
+				},
+			},
+			pf2PCIAddr: {
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: vf21PCIAddr},
+				},
+			},
+		},
+	}
+}
+
+func request(connID, vfPCIAddr string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: connID,
+			Mechanism: &networkservice.Mechanism{
+				Parameters: map[string]string{
+					common.PCIAddressKey: vfPCIAddr,
+				},
+			},
+		},
+	}
+}
+
+func TestVFHealthServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor := new(testMonitor)
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		vfhealth.NewServer(ctx, monitor, testConfig()),
+		counter,
+	)
+
+	for connID, vfPCIAddr := range map[string]string{
+		"id-11": vf11PCIAddr,
+		"id-12": vf12PCIAddr,
+		"id-21": vf21PCIAddr,
+	} {
+		_, err := server.Request(ctx, request(connID, vfPCIAddr))
+		require.NoError(t, err)
+	}
+
+	monitor.notify(&hotplug.Event{
+		PFPCIAddr:  pf1PCIAddr,
+		LinkUp:     true,
+		MissingVFs: []string{vf12PCIAddr},
+		HealthyVFs: 1,
+	})
+	require.Eventually(t, func() bool {
+		return counter.UniqueCloses() == 1
+	}, timeout, tick)
+
+	monitor.notify(&hotplug.Event{
+		PFPCIAddr: pf2PCIAddr,
+		LinkUp:    false,
+	})
+	require.Eventually(t, func() bool {
+		return counter.UniqueCloses() == 2
+	}, timeout, tick)
+
+	monitor.notify(&hotplug.Event{
+		PFPCIAddr:  pf2PCIAddr,
+		LinkUp:     true,
+		HealthyVFs: 1,
+	})
+	require.Never(t, func() bool {
+		return counter.UniqueCloses() != 2
+	}, 10*tick, tick)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vfmtu provides chain element setting the selected VF MTU according to the connection context MTU
+package vfmtu
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/next"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// ClampedMTUKey is a connection extra context key reporting the requested MTU, if it has been clamped to the PF MTU
+const ClampedMTUKey = "sriovClampedMTU"
+
+type originalMTUKey struct{}
+
+type vfMTUServer struct{}
+
+// NewServer returns a new VF MTU server chain element. It sets the VF selected by the previous chain elements MTU to the
+// connection context MTU. If PF MTU is less than the requested one, MTU is clamped to the PF MTU and the connection
+// context is updated accordingly.
+func NewServer() networkservice.NetworkServiceServer {
+	return &vfMTUServer{}
+}
+
+func (s *vfMTUServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	vfConfig, ok := vfconfig.Load(ctx, false)
+	mtu := request.GetConnection().GetContext().GetMTU()
+	if !ok || mtu == 0 || vfConfig.VFInterfaceName == "" {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	_, isEstablished := metadata.Map(ctx, false).Load(originalMTUKey{})
+	if !isEstablished {
+		if err := s.setMTU(ctx, request.GetConnection(), vfConfig, mtu); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !isEstablished {
+		s.restoreMTU(ctx, vfConfig)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *vfMTUServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	// VF should be moved back into the forwarder netns at this point
+	if vfConfig, ok := vfconfig.Load(ctx, false); ok {
+		s.restoreMTU(ctx, vfConfig)
+	}
+
+	return rv, err
+}
+
+func (s *vfMTUServer) setMTU(ctx context.Context, conn *networkservice.Connection, vfConfig *vfconfig.VFConfig, mtu uint32) error {
+	logger := log.FromContext(ctx).WithField("vfMTUServer", "setMTU")
+
+	pfLink, err := netlink.LinkByName(vfConfig.PFInterfaceName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find PF net interface: %s", vfConfig.PFInterfaceName)
+	}
+	if pfMTU := uint32(pfLink.Attrs().MTU); pfMTU < mtu {
+		logger.Warnf("requested MTU %d is greater than PF %s MTU %d, clamping", mtu, vfConfig.PFInterfaceName, pfMTU)
+
+		if conn.GetContext().GetExtraContext() == nil {
+			conn.GetContext().ExtraContext = map[string]string{}
+		}
+		conn.GetContext().GetExtraContext()[ClampedMTUKey] = strconv.FormatUint(uint64(mtu), 10)
+		conn.GetContext().MTU = pfMTU
+		mtu = pfMTU
+	}
+
+	vfLink, err := netlink.LinkByName(vfConfig.VFInterfaceName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find VF net interface: %s", vfConfig.VFInterfaceName)
+	}
+	originalMTU := vfLink.Attrs().MTU
+	if err := netlink.LinkSetMTU(vfLink, int(mtu)); err != nil {
+		return errors.Wrapf(err, "failed to set MTU %d for the VF net interface: %s", mtu, vfConfig.VFInterfaceName)
+	}
+	metadata.Map(ctx, false).Store(originalMTUKey{}, originalMTU)
+
+	return nil
+}
+
+func (s *vfMTUServer) restoreMTU(ctx context.Context, vfConfig *vfconfig.VFConfig) {
+	logger := log.FromContext(ctx).WithField("vfMTUServer", "restoreMTU")
+
+	rawValue, ok := metadata.Map(ctx, false).LoadAndDelete(originalMTUKey{})
+	if !ok {
+		return
+	}
+
+	vfLink, err := netlink.LinkByName(vfConfig.VFInterfaceName)
+	if err == nil {
+		err = netlink.LinkSetMTU(vfLink, rawValue.(int))
+	}
+	if err != nil {
+		logger.Warnf("failed to restore VF net interface %s MTU: %s", vfConfig.VFInterfaceName, err.Error())
+	}
+}
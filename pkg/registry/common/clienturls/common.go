@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clienturls provides registry chain elements setting the client URL with the failover between several URLs
+package clienturls
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+type clientURLs struct {
+	urls    []*url.URL
+	current atomic.Int32
+}
+
+// try calls f with the client URLs starting from the last successful one until the first success
+func try[T any](ctx context.Context, c *clientURLs, f func(context.Context) (T, error)) (rv T, err error) {
+	if len(c.urls) == 0 {
+		return f(ctx)
+	}
+
+	start := int(c.current.Load())
+	for i := range c.urls {
+		index := (start + i) % len(c.urls)
+		if rv, err = f(clienturlctx.WithClientURL(ctx, c.urls[index])); err == nil {
+			c.current.Store(int32(index))
+			return rv, nil
+		}
+		log.FromContext(ctx).WithField("clientURLs", "try").
+			Warnf("failed to call %s, trying the next URL: %s", c.urls[index].String(), err.Error())
+	}
+	return rv, err
+}
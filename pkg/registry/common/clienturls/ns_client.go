@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienturls
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+)
+
+type clientURLsNSClient struct {
+	urls *clientURLs
+}
+
+// NewNetworkServiceRegistryClient returns a new client URLs NS registry client chain element. It sets the client URL
+// from the urls list, starting from the last successful one and failing over to the next one on error.
+func NewNetworkServiceRegistryClient(urls ...*url.URL) registry.NetworkServiceRegistryClient {
+	return &clientURLsNSClient{
+		urls: &clientURLs{urls: urls},
+	}
+}
+
+func (c *clientURLsNSClient) Register(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	return try(ctx, c.urls, func(ctx context.Context) (*registry.NetworkService, error) {
+		return next.NetworkServiceRegistryClient(ctx).Register(ctx, in.Clone(), opts...)
+	})
+}
+
+func (c *clientURLsNSClient) Find(ctx context.Context, in *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	return try(ctx, c.urls, func(ctx context.Context) (registry.NetworkServiceRegistry_FindClient, error) {
+		return next.NetworkServiceRegistryClient(ctx).Find(ctx, in, opts...)
+	})
+}
+
+func (c *clientURLsNSClient) Unregister(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return try(ctx, c.urls, func(ctx context.Context) (*empty.Empty, error) {
+		return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, in, opts...)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienturls
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/next"
+)
+
+type clientURLsNSEClient struct {
+	urls *clientURLs
+}
+
+// NewNetworkServiceEndpointRegistryClient returns a new client URLs NSE registry client chain element. It sets the
+// client URL from the urls list, starting from the last successful one and failing over to the next one on error.
+func NewNetworkServiceEndpointRegistryClient(urls ...*url.URL) registry.NetworkServiceEndpointRegistryClient {
+	return &clientURLsNSEClient{
+		urls: &clientURLs{urls: urls},
+	}
+}
+
+func (c *clientURLsNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	return try(ctx, c.urls, func(ctx context.Context) (*registry.NetworkServiceEndpoint, error) {
+		return next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, in.Clone(), opts...)
+	})
+}
+
+func (c *clientURLsNSEClient) Find(ctx context.Context, in *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return try(ctx, c.urls, func(ctx context.Context) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+		return next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, in, opts...)
+	})
+}
+
+func (c *clientURLsNSEClient) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return try(ctx, c.urls, func(ctx context.Context) (*empty.Empty, error) {
+		return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, in, opts...)
+	})
+}
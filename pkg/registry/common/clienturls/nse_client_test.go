@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienturls_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk/pkg/registry/core/chain"
+	"github.com/ljkiraly/sdk/pkg/tools/clienturlctx"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/registry/common/clienturls"
+)
+
+type failingNSEClient struct {
+	failingURLs map[string]bool
+	calledURLs  []string
+}
+
+func (c *failingNSEClient) call(ctx context.Context) error {
+	u := clienturlctx.ClientURL(ctx).String()
+	c.calledURLs = append(c.calledURLs, u)
+	if c.failingURLs[u] {
+		return errors.Errorf("%s is unavailable", u)
+	}
+	return nil
+}
+
+func (c *failingNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, _ ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	return in, c.call(ctx)
+}
+
+func (c *failingNSEClient) Find(ctx context.Context, _ *registry.NetworkServiceEndpointQuery, _ ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return nil, c.call(ctx)
+}
+
+func (c *failingNSEClient) Unregister(ctx context.Context, _ *registry.NetworkServiceEndpoint, _ ...grpc.CallOption) (*empty.Empty, error) {
+	return new(empty.Empty), c.call(ctx)
+}
+
+func TestClientURLsNSEClient_Failover(t *testing.T) {
+	u1 := &url.URL{Scheme: "unix", Path: "/nsmgr-1.sock"}
+	u2 := &url.URL{Scheme: "unix", Path: "/nsmgr-2.sock"}
+
+	failing := &failingNSEClient{
+		failingURLs: map[string]bool{u1.String(): true},
+	}
+	client := chain.NewNetworkServiceEndpointRegistryClient(
+		clienturls.NewNetworkServiceEndpointRegistryClient(u1, u2),
+		failing,
+	)
+
+	_, err := client.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+	require.Equal(t, []string{u1.String(), u2.String()}, failing.calledURLs)
+
+	// The last successful URL is used first
+	failing.calledURLs = nil
+	_, err = client.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+	require.Equal(t, []string{u2.String()}, failing.calledURLs)
+
+	// All the URLs are unavailable
+	failing.failingURLs[u2.String()] = true
+	failing.calledURLs = nil
+	_, err = client.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.Error(t, err)
+	require.Equal(t, []string{u2.String(), u1.String()}, failing.calledURLs)
+}
@@ -20,6 +20,8 @@ package config
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ljkiraly/sdk/pkg/tools/log/logruslogger"
@@ -28,9 +30,16 @@ import (
 	"github.com/ljkiraly/sdk-sriov/pkg/tools/yamlhelper"
 )
 
+// PTPCapability is a PF capability marking the PF PTP hardware clock (PHC) can be granted to the clients
+const PTPCapability = "ptp"
+
 // Config contains list of available physical functions
 type Config struct {
 	PhysicalFunctions map[string]*PhysicalFunction `yaml:"physicalFunctions"`
+	// MaxTokens limits the number of tokens created for the "serviceDomain/capability" token name
+	MaxTokens map[string]uint `yaml:"maxTokens"`
+	// WarmVFIO is the number of the VFs kept bound to the vfio-pci driver while free for the capability
+	WarmVFIO map[string]uint `yaml:"warmVFIO"`
 }
 
 func (c *Config) String() string {
@@ -45,10 +54,26 @@ func (c *Config) String() string {
 	_, _ = sb.WriteString(strings.Join(strs, " "))
 	_, _ = sb.WriteString("]")
 
+	if len(c.MaxTokens) > 0 {
+		_, _ = sb.WriteString(" MaxTokens:")
+		_, _ = sb.WriteString(fmt.Sprintf("%v", c.MaxTokens))
+	}
+
+	if len(c.WarmVFIO) > 0 {
+		_, _ = sb.WriteString(" WarmVFIO:")
+		_, _ = sb.WriteString(fmt.Sprintf("%v", c.WarmVFIO))
+	}
+
 	_, _ = sb.WriteString("}")
 	return sb.String()
 }
 
+// TokenLimit returns the max number of tokens for the given token name, if there is no limit returns (0, false)
+func (c *Config) TokenLimit(tokenName string) (limit uint, ok bool) {
+	limit, ok = c.MaxTokens[tokenName]
+	return limit, ok
+}
+
 // PhysicalFunction contains physical function capabilities, available services domains and virtual functions
 type PhysicalFunction struct {
 	PFKernelDriver   string             `yaml:"pfKernelDriver"`
@@ -56,6 +81,15 @@ type PhysicalFunction struct {
 	Capabilities     []string           `yaml:"capabilities"`
 	ServiceDomains   []string           `yaml:"serviceDomains"`
 	VirtualFunctions []*VirtualFunction `yaml:"virtualFunctions"`
+	Disabled         bool               `yaml:"disabled"`
+	ExcludeVFs       []string           `yaml:"excludeVFs"`
+	DPU              *DPU               `yaml:"dpu"`
+	NUMANode         *int               `yaml:"numaNode"`
+	// PhysicalNetwork is the physical network the PF is cabled to, PFs with the same physical network form a physical
+	// isolation group
+	PhysicalNetwork string `yaml:"physicalNetwork"`
+	// QoS is the PF QoS classes configuration, nil if the PF has no QoS classes
+	QoS *QoS `yaml:"qos"`
 }
 
 func (pf *PhysicalFunction) String() string {
@@ -84,25 +118,177 @@ func (pf *PhysicalFunction) String() string {
 	_, _ = sb.WriteString(strings.Join(strs, " "))
 	_, _ = sb.WriteString("]")
 
+	_, _ = sb.WriteString(" Disabled:")
+	_, _ = sb.WriteString(strconv.FormatBool(pf.Disabled))
+
+	_, _ = sb.WriteString(" ExcludeVFs:[")
+	_, _ = sb.WriteString(strings.Join(pf.ExcludeVFs, " "))
+	_, _ = sb.WriteString("]")
+
+	if pf.DPU != nil {
+		_, _ = sb.WriteString(fmt.Sprintf(" DPU:%+v", pf.DPU))
+	}
+
+	if pf.NUMANode != nil {
+		_, _ = sb.WriteString(" NUMANode:")
+		_, _ = sb.WriteString(strconv.Itoa(*pf.NUMANode))
+	}
+
+	if pf.PhysicalNetwork != "" {
+		_, _ = sb.WriteString(" PhysicalNetwork:")
+		_, _ = sb.WriteString(pf.PhysicalNetwork)
+	}
+
+	if pf.QoS != nil {
+		_, _ = sb.WriteString(fmt.Sprintf(" QoS:%+v", pf.QoS.Classes))
+	}
+
 	_, _ = sb.WriteString("}")
 	return sb.String()
 }
 
+// IsExcluded returns if the given VF is excluded from the pools
+func (pf *PhysicalFunction) IsExcluded(vfPCIAddr string) bool {
+	if pf.Disabled {
+		return true
+	}
+	for _, addr := range pf.ExcludeVFs {
+		if addr == vfPCIAddr {
+			return true
+		}
+	}
+	return false
+}
+
+// EnabledVirtualFunctions returns virtual functions that are not excluded from the pools, for the disabled physical
+// function returns nil
+func (pf *PhysicalFunction) EnabledVirtualFunctions() []*VirtualFunction {
+	if pf.Disabled {
+		return nil
+	}
+
+	var vfs []*VirtualFunction
+	for _, vf := range pf.VirtualFunctions {
+		if !pf.IsExcluded(vf.Address) {
+			vfs = append(vfs, vf)
+		}
+	}
+	return vfs
+}
+
+// GetNUMANode returns pf NUMA node, if NUMA node is unknown returns -1
+func (pf *PhysicalFunction) GetNUMANode() int {
+	if pf.NUMANode == nil {
+		return -1
+	}
+	return *pf.NUMANode
+}
+
+// IsDPUHosted returns if pf is hosted on DPU and managed through the DPU-side representors
+func (pf *PhysicalFunction) IsDPUHosted() bool {
+	return pf.DPU != nil
+}
+
+// HasPTP returns if pf has PTPCapability
+func (pf *PhysicalFunction) HasPTP() bool {
+	for _, capability := range pf.Capabilities {
+		if capability == PTPCapability {
+			return true
+		}
+	}
+	return false
+}
+
+// DPU describes DPU (SmartNIC) hosting the physical function
+type DPU struct {
+	// Name is the DPU identifier
+	Name string `yaml:"name"`
+	// PFRepresentor is the DPU-side representor net interface name for the host physical function
+	PFRepresentor string `yaml:"pfRepresentor"`
+}
+
 // VirtualFunction contains
 type VirtualFunction struct {
 	Address    string `yaml:"address"`
 	IOMMUGroup uint   `yaml:"iommuGroup"`
+	// Representor is the DPU-side representor net interface name for the virtual function, set only for the
+	// DPU-hosted physical functions
+	Representor string `yaml:"representor"`
+}
+
+// QoS contains the QoS classes the connections can request on the PF
+type QoS struct {
+	// Classes are the QoS classes by the class names
+	Classes map[string]*QoSClass `yaml:"classes"`
+}
+
+// QoSClass is a QoS class mapped to the VF traffic class by the 802.1p priority
+type QoSClass struct {
+	// Priority is the 802.1p priority set for the VF traffic, the PF DCB configuration maps it to the traffic class
+	Priority uint8 `yaml:"priority"`
+	// Lossless requires PFC enabled for the Priority on the PF
+	Lossless bool `yaml:"lossless"`
+}
+
+func (c *QoSClass) String() string {
+	return fmt.Sprintf("&{Priority:%d Lossless:%v}", c.Priority, c.Lossless)
+}
+
+// PhysicalNetworks returns the physical isolation groups: PCI addresses of the enabled PFs, sorted, for each physical
+// network
+func (c *Config) PhysicalNetworks() map[string][]string {
+	groups := map[string][]string{}
+	for pfPCIAddr, pfCfg := range c.PhysicalFunctions {
+		if pfCfg.Disabled || pfCfg.PhysicalNetwork == "" {
+			continue
+		}
+		groups[pfCfg.PhysicalNetwork] = append(groups[pfCfg.PhysicalNetwork], pfPCIAddr)
+	}
+	for _, pfPCIAddrs := range groups {
+		sort.Strings(pfPCIAddrs)
+	}
+	return groups
+}
+
+// Representor returns DPU name and DPU-side representor net interface name for the given host VF PCI address
+func (c *Config) Representor(vfPCIAddr string) (dpuName, representor string, err error) {
+	for pfPCIAddr, pfCfg := range c.PhysicalFunctions {
+		for _, vfCfg := range pfCfg.VirtualFunctions {
+			if vfCfg.Address != vfPCIAddr {
+				continue
+			}
+			if !pfCfg.IsDPUHosted() {
+				return "", "", errors.Errorf("VF PF is not DPU hosted: %s -> %s", vfPCIAddr, pfPCIAddr)
+			}
+			return pfCfg.DPU.Name, vfCfg.Representor, nil
+		}
+	}
+	return "", "", errors.Errorf("VF doesn't exist: %s", vfPCIAddr)
 }
 
 // ReadConfig reads configuration from file
 func ReadConfig(ctx context.Context, configFile string) (*Config, error) {
-	logger := logruslogger.New(ctx)
-
 	cfg := &Config{}
 	if err := yamlhelper.UnmarshalFile(configFile, cfg); err != nil {
 		return nil, err
 	}
 
+	return validateConfig(ctx, cfg)
+}
+
+// ParseConfig parses configuration from YAML bytes
+func ParseConfig(ctx context.Context, data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yamlhelper.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return validateConfig(ctx, cfg)
+}
+
+func validateConfig(ctx context.Context, cfg *Config) (*Config, error) {
+	logger := logruslogger.New(ctx)
+
 	for pciAddr, pfCfg := range cfg.PhysicalFunctions {
 		if pfCfg.PFKernelDriver == "" {
 			return nil, errors.Errorf("%s has no PFKernelDriver set", pciAddr)
@@ -116,9 +302,60 @@ func ReadConfig(ctx context.Context, configFile string) (*Config, error) {
 		if len(pfCfg.ServiceDomains) == 0 {
 			return nil, errors.Errorf("%s has no ServiceDomains set", pciAddr)
 		}
+		if err := validateDPU(pciAddr, pfCfg); err != nil {
+			return nil, err
+		}
+		if err := validateQoS(pciAddr, pfCfg); err != nil {
+			return nil, err
+		}
+	}
+
+	for tokenName := range cfg.MaxTokens {
+		if len(strings.Split(tokenName, "/")) != 2 {
+			return nil, errors.Errorf("invalid maxTokens name, expected serviceDomain/capability: %s", tokenName)
+		}
 	}
 
 	logger.WithField("Config", "ReadConfig").Infof("unmarshalled Config: %+v", cfg)
 
 	return cfg, nil
 }
+
+func validateDPU(pciAddr string, pfCfg *PhysicalFunction) error {
+	if !pfCfg.IsDPUHosted() {
+		for _, vfCfg := range pfCfg.VirtualFunctions {
+			if vfCfg.Representor != "" {
+				return errors.Errorf("%s is not DPU hosted, but has VF representor set: %s", pciAddr, vfCfg.Address)
+			}
+		}
+		return nil
+	}
+
+	if pfCfg.DPU.Name == "" {
+		return errors.Errorf("%s has no DPU name set", pciAddr)
+	}
+	for _, vfCfg := range pfCfg.VirtualFunctions {
+		if vfCfg.Representor == "" {
+			return errors.Errorf("%s is DPU hosted, but has no VF representor set: %s", pciAddr, vfCfg.Address)
+		}
+	}
+	return nil
+}
+
+// maxPriority is the max 802.1p priority
+const maxPriority = 7
+
+func validateQoS(pciAddr string, pfCfg *PhysicalFunction) error {
+	if pfCfg.QoS == nil {
+		return nil
+	}
+	for name, class := range pfCfg.QoS.Classes {
+		if class == nil {
+			return errors.Errorf("%s has no QoS class configuration set: %s", pciAddr, name)
+		}
+		if class.Priority > maxPriority {
+			return errors.Errorf("%s has invalid QoS class priority %d: %s", pciAddr, class.Priority, name)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package config_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,21 +27,22 @@ import (
 )
 
 const (
-	configFileName  = "config.yml"
-	pf1PciAddr      = "0000:01:00.0"
-	pf2PciAddr      = "0000:02:00.0"
-	pfKernelDriver  = "pf-driver"
-	vfKernelDriver  = "vf-driver"
-	capabilityIntel = "intel"
-	capability10G   = "10G"
-	capability20G   = "20G"
-	serviceDomain1  = "service.domain.1"
-	serviceDomain2  = "service.domain.2"
-	vf11PciAddr     = "0000:01:00.1"
-	vf12PciAddr     = "0000:01:00.2"
-	vf21PciAddr     = "0000:02:00.1"
-	vf22PciAddr     = "0000:02:00.2"
-	vf23PciAddr     = "0000:02:00.3"
+	configFileName    = "config.yml"
+	dpuConfigFileName = "dpu_config.yml"
+	pf1PciAddr        = "0000:01:00.0"
+	pf2PciAddr        = "0000:02:00.0"
+	pfKernelDriver    = "pf-driver"
+	vfKernelDriver    = "vf-driver"
+	capabilityIntel   = "intel"
+	capability10G     = "10G"
+	capability20G     = "20G"
+	serviceDomain1    = "service.domain.1"
+	serviceDomain2    = "service.domain.2"
+	vf11PciAddr       = "0000:01:00.1"
+	vf12PciAddr       = "0000:01:00.2"
+	vf21PciAddr       = "0000:02:00.1"
+	vf22PciAddr       = "0000:02:00.2"
+	vf23PciAddr       = "0000:02:00.3"
 )
 
 func TestReadConfigFile(t *testing.T) {
@@ -96,5 +98,96 @@ func TestReadConfigFile(t *testing.T) {
 				},
 			},
 		},
+		MaxTokens: map[string]uint{
+			serviceDomain2 + "/" + capability20G: 2,
+		},
+		WarmVFIO: map[string]uint{
+			capability20G: 1,
+		},
 	}, cfg)
 }
+
+func TestPhysicalFunction_EnabledVirtualFunctions(t *testing.T) {
+	pf := &config.PhysicalFunction{
+		VirtualFunctions: []*config.VirtualFunction{
+			{Address: vf21PciAddr},
+			{Address: vf22PciAddr},
+			{Address: vf23PciAddr},
+		},
+		ExcludeVFs: []string{vf22PciAddr},
+	}
+	require.Equal(t, []*config.VirtualFunction{
+		{Address: vf21PciAddr},
+		{Address: vf23PciAddr},
+	}, pf.EnabledVirtualFunctions())
+	require.True(t, pf.IsExcluded(vf22PciAddr))
+	require.False(t, pf.IsExcluded(vf21PciAddr))
+
+	pf.Disabled = true
+	require.Empty(t, pf.EnabledVirtualFunctions())
+	require.True(t, pf.IsExcluded(vf21PciAddr))
+}
+
+func TestPhysicalFunction_HasPTP(t *testing.T) {
+	require.True(t, (&config.PhysicalFunction{Capabilities: []string{"10G", config.PTPCapability}}).HasPTP())
+	require.False(t, (&config.PhysicalFunction{Capabilities: []string{"10G"}}).HasPTP())
+}
+
+func TestConfig_PhysicalNetworks(t *testing.T) {
+	cfg := &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			"0000:01:00.0": {PhysicalNetwork: "physnet-a"},
+			"0000:02:00.0": {PhysicalNetwork: "physnet-b"},
+			"0000:03:00.0": {PhysicalNetwork: "physnet-a"},
+			"0000:04:00.0": {PhysicalNetwork: "physnet-a", Disabled: true},
+			"0000:05:00.0": {},
+		},
+	}
+	require.Equal(t, map[string][]string{
+		"physnet-a": {"0000:01:00.0", "0000:03:00.0"},
+		"physnet-b": {"0000:02:00.0"},
+	}, cfg.PhysicalNetworks())
+}
+
+func TestParseConfig_QoS(t *testing.T) {
+	const pfConfig = `
+physicalFunctions:
+  0000:01:00.0:
+    pfKernelDriver: pf-driver
+    vfKernelDriver: vf-driver
+    capabilities: [10G]
+    serviceDomains: [service.domain.1]
+    qos:
+      classes:
+        gold:
+          priority: %d
+          lossless: true
+`
+	cfg, err := config.ParseConfig(context.Background(), []byte(fmt.Sprintf(pfConfig, 3)))
+	require.NoError(t, err)
+	require.Equal(t, &config.QoSClass{Priority: 3, Lossless: true},
+		cfg.PhysicalFunctions[pf1PciAddr].QoS.Classes["gold"])
+
+	_, err = config.ParseConfig(context.Background(), []byte(fmt.Sprintf(pfConfig, 8)))
+	require.ErrorContains(t, err, "invalid QoS class priority 8")
+}
+
+func TestReadConfigFile_DPU(t *testing.T) {
+	cfg, err := config.ReadConfig(context.Background(), dpuConfigFileName)
+	require.NoError(t, err)
+
+	pfCfg := cfg.PhysicalFunctions[pf1PciAddr]
+	require.True(t, pfCfg.IsDPUHosted())
+	require.Equal(t, &config.DPU{
+		Name:          "dpu-1",
+		PFRepresentor: "pf0hpf",
+	}, pfCfg.DPU)
+
+	dpuName, representor, err := cfg.Representor(vf12PciAddr)
+	require.NoError(t, err)
+	require.Equal(t, "dpu-1", dpuName)
+	require.Equal(t, "pf0vf1", representor)
+
+	_, _, err = cfg.Representor(vf21PciAddr)
+	require.Error(t, err)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ChangeSet is a set of changes between two configs
+type ChangeSet struct {
+	AddedPFs          []string             // PCI addresses of the added physical functions
+	RemovedPFs        []string             // PCI addresses of the removed physical functions
+	ChangedPFs        map[string]*PFChange // ChangedPFs[pfPCIAddr] -> *PFChange
+	ChangedTokenNames []string             // token names with the changed maxTokens limit
+}
+
+// PFChange is a set of changes for a physical function present in both configs
+// NOTE: virtual functions are compared after applying disabled, excludeVFs flags, so disabling physical function or
+// excluding virtual function is reported as virtual function removal
+type PFChange struct {
+	AddedVFs              []*VirtualFunction
+	RemovedVFs            []*VirtualFunction
+	AddedCapabilities     []string
+	RemovedCapabilities   []string
+	AddedServiceDomains   []string
+	RemovedServiceDomains []string
+	DriversChanged        bool
+	DPUChanged            bool
+}
+
+// IsEmpty returns if there are no changes in cs
+func (cs *ChangeSet) IsEmpty() bool {
+	return len(cs.AddedPFs) == 0 && len(cs.RemovedPFs) == 0 && len(cs.ChangedPFs) == 0 && len(cs.ChangedTokenNames) == 0
+}
+
+func (pfc *PFChange) isEmpty() bool {
+	return len(pfc.AddedVFs) == 0 && len(pfc.RemovedVFs) == 0 &&
+		len(pfc.AddedCapabilities) == 0 && len(pfc.RemovedCapabilities) == 0 &&
+		len(pfc.AddedServiceDomains) == 0 && len(pfc.RemovedServiceDomains) == 0 &&
+		!pfc.DriversChanged && !pfc.DPUChanged
+}
+
+// Diff returns a set of changes needed to get newCfg from oldCfg, nil configs are treated as empty
+func Diff(oldCfg, newCfg *Config) *ChangeSet {
+	if oldCfg == nil {
+		oldCfg = &Config{}
+	}
+	if newCfg == nil {
+		newCfg = &Config{}
+	}
+
+	cs := &ChangeSet{
+		ChangedPFs: map[string]*PFChange{},
+	}
+
+	for pfPCIAddr, newPF := range newCfg.PhysicalFunctions {
+		oldPF, ok := oldCfg.PhysicalFunctions[pfPCIAddr]
+		if !ok {
+			cs.AddedPFs = append(cs.AddedPFs, pfPCIAddr)
+			continue
+		}
+		if pfc := diffPF(oldPF, newPF); !pfc.isEmpty() {
+			cs.ChangedPFs[pfPCIAddr] = pfc
+		}
+	}
+	for pfPCIAddr := range oldCfg.PhysicalFunctions {
+		if _, ok := newCfg.PhysicalFunctions[pfPCIAddr]; !ok {
+			cs.RemovedPFs = append(cs.RemovedPFs, pfPCIAddr)
+		}
+	}
+
+	for tokenName, limit := range newCfg.MaxTokens {
+		if oldLimit, ok := oldCfg.MaxTokens[tokenName]; !ok || oldLimit != limit {
+			cs.ChangedTokenNames = append(cs.ChangedTokenNames, tokenName)
+		}
+	}
+	for tokenName := range oldCfg.MaxTokens {
+		if _, ok := newCfg.MaxTokens[tokenName]; !ok {
+			cs.ChangedTokenNames = append(cs.ChangedTokenNames, tokenName)
+		}
+	}
+
+	sort.Strings(cs.AddedPFs)
+	sort.Strings(cs.RemovedPFs)
+	sort.Strings(cs.ChangedTokenNames)
+
+	return cs
+}
+
+func diffPF(oldPF, newPF *PhysicalFunction) *PFChange {
+	pfc := &PFChange{
+		DriversChanged: oldPF.PFKernelDriver != newPF.PFKernelDriver || oldPF.VFKernelDriver != newPF.VFKernelDriver,
+		DPUChanged:     !reflect.DeepEqual(oldPF.DPU, newPF.DPU),
+	}
+
+	pfc.AddedCapabilities, pfc.RemovedCapabilities = diffStrings(oldPF.Capabilities, newPF.Capabilities)
+	pfc.AddedServiceDomains, pfc.RemovedServiceDomains = diffStrings(oldPF.ServiceDomains, newPF.ServiceDomains)
+
+	oldVFs := map[string]*VirtualFunction{}
+	for _, vf := range oldPF.EnabledVirtualFunctions() {
+		oldVFs[vf.Address] = vf
+	}
+	newVFs := map[string]*VirtualFunction{}
+	for _, vf := range newPF.EnabledVirtualFunctions() {
+		newVFs[vf.Address] = vf
+	}
+
+	for addr, newVF := range newVFs {
+		switch oldVF, ok := oldVFs[addr]; {
+		case !ok:
+			pfc.AddedVFs = append(pfc.AddedVFs, newVF)
+		case oldVF.IOMMUGroup != newVF.IOMMUGroup, oldVF.Representor != newVF.Representor:
+			pfc.RemovedVFs = append(pfc.RemovedVFs, oldVF)
+			pfc.AddedVFs = append(pfc.AddedVFs, newVF)
+		}
+	}
+	for addr, oldVF := range oldVFs {
+		if _, ok := newVFs[addr]; !ok {
+			pfc.RemovedVFs = append(pfc.RemovedVFs, oldVF)
+		}
+	}
+
+	sortVFs(pfc.AddedVFs)
+	sortVFs(pfc.RemovedVFs)
+
+	return pfc
+}
+
+func diffStrings(oldStrs, newStrs []string) (added, removed []string) {
+	oldSet := map[string]struct{}{}
+	for _, s := range oldStrs {
+		oldSet[s] = struct{}{}
+	}
+	newSet := map[string]struct{}{}
+	for _, s := range newStrs {
+		newSet[s] = struct{}{}
+		if _, ok := oldSet[s]; !ok {
+			added = append(added, s)
+		}
+	}
+	for _, s := range oldStrs {
+		if _, ok := newSet[s]; !ok {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func sortVFs(vfs []*VirtualFunction) {
+	sort.Slice(vfs, func(i, k int) bool {
+		return vfs[i].Address < vfs[k].Address
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+func TestDiff_Same(t *testing.T) {
+	oldCfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+	newCfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	require.True(t, config.Diff(oldCfg, newCfg).IsEmpty())
+}
+
+func TestDiff(t *testing.T) {
+	oldCfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+	newCfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	delete(newCfg.PhysicalFunctions, pf1PciAddr)
+	newCfg.PhysicalFunctions["0000:03:00.0"] = oldCfg.PhysicalFunctions[pf1PciAddr]
+
+	pf2 := newCfg.PhysicalFunctions[pf2PciAddr]
+	pf2.Capabilities = []string{capabilityIntel, capability10G}
+	pf2.ExcludeVFs = []string{vf21PciAddr}
+	pf2.VirtualFunctions[1].IOMMUGroup = 5
+	newCfg.MaxTokens = nil
+
+	cs := config.Diff(oldCfg, newCfg)
+	require.False(t, cs.IsEmpty())
+	require.Equal(t, []string{"0000:03:00.0"}, cs.AddedPFs)
+	require.Equal(t, []string{pf1PciAddr}, cs.RemovedPFs)
+	require.Equal(t, []string{serviceDomain2 + "/" + capability20G}, cs.ChangedTokenNames)
+
+	require.Equal(t, map[string]*config.PFChange{
+		pf2PciAddr: {
+			AddedVFs: []*config.VirtualFunction{
+				{Address: vf22PciAddr, IOMMUGroup: 5},
+			},
+			RemovedVFs: []*config.VirtualFunction{
+				{Address: vf21PciAddr, IOMMUGroup: 1},
+				{Address: vf22PciAddr, IOMMUGroup: 2},
+			},
+			AddedCapabilities:   []string{capability10G},
+			RemovedCapabilities: []string{capability20G},
+		},
+	}, cs.ChangedPFs)
+}
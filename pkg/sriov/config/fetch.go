@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const (
+	// GetConfigMethod is a gRPC method returning the config YAML document as google.protobuf.BytesValue
+	GetConfigMethod = "/sriov.config.ConfigService/GetConfig"
+
+	defaultFetchRetries       = 5
+	defaultFetchRetryInterval = time.Second
+)
+
+type fetchOptions struct {
+	tlsConfig     *tls.Config
+	retries       int
+	retryInterval time.Duration
+	dialOptions   []grpc.DialOption
+}
+
+// FetchOption is an option for FetchConfig
+type FetchOption func(o *fetchOptions)
+
+// WithTLSConfig sets TLS config used for both HTTPS and gRPC connections
+func WithTLSConfig(tlsConfig *tls.Config) FetchOption {
+	return func(o *fetchOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithRetry sets how many times and how often FetchConfig retries the failed fetch
+func WithRetry(retries int, retryInterval time.Duration) FetchOption {
+	return func(o *fetchOptions) {
+		o.retries = retries
+		o.retryInterval = retryInterval
+	}
+}
+
+// WithDialOptions sets additional gRPC dial options
+func WithDialOptions(dialOptions ...grpc.DialOption) FetchOption {
+	return func(o *fetchOptions) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// FetchConfig fetches configuration from the remote source:
+//   - http://, https:// - GET returning the config YAML document
+//   - tcp://, unix:// - gRPC GetConfigMethod call returning the config YAML document
+func FetchConfig(ctx context.Context, source *url.URL, opts ...FetchOption) (*Config, error) {
+	logger := log.FromContext(ctx).WithField("Config", "FetchConfig")
+
+	o := &fetchOptions{
+		retries:       defaultFetchRetries,
+		retryInterval: defaultFetchRetryInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var fetch func(context.Context, *url.URL, *fetchOptions) ([]byte, error)
+	switch source.Scheme {
+	case "http", "https":
+		fetch = fetchHTTP
+	case "tcp", "unix":
+		fetch = fetchGRPC
+	default:
+		return nil, errors.Errorf("unsupported config source scheme: %s", source.String())
+	}
+
+	for attempt := 0; ; attempt++ {
+		data, err := fetch(ctx, source, o)
+		if err == nil {
+			return ParseConfig(ctx, data)
+		}
+		if attempt >= o.retries {
+			return nil, errors.Wrapf(err, "failed to fetch config from %s", source.String())
+		}
+		logger.Warnf("failed to fetch config from %s, retrying: %s", source.String(), err.Error())
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "provided context is done")
+		case <-time.After(o.retryInterval):
+		}
+	}
+}
+
+func fetchHTTP(ctx context.Context, source *url.URL, o *fetchOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.String(), http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request: %s", source.String())
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: o.tlsConfig,
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get: %s", source.String())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+	return data, nil
+}
+
+func fetchGRPC(ctx context.Context, source *url.URL, o *fetchOptions) ([]byte, error) {
+	transportCreds := insecure.NewCredentials()
+	if o.tlsConfig != nil {
+		transportCreds = credentials.NewTLS(o.tlsConfig)
+	}
+
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(transportCreds)}, o.dialOptions...)
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(source), dialOptions...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial: %s", source.String())
+	}
+	defer func() { _ = cc.Close() }()
+
+	resp := new(wrapperspb.BytesValue)
+	if err := cc.Invoke(ctx, GetConfigMethod, new(emptypb.Empty), resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", GetConfigMethod)
+	}
+	return resp.GetValue(), nil
+}
+
+type configServer interface {
+	getConfig(ctx context.Context) ([]byte, error)
+}
+
+type configServerFunc func(ctx context.Context) ([]byte, error)
+
+func (f configServerFunc) getConfig(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+// RegisterConfigServer registers GetConfigMethod handler serving the config YAML document returned by getConfig
+func RegisterConfigServer(s grpc.ServiceRegistrar, getConfig func(ctx context.Context) ([]byte, error)) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "sriov.config.ConfigService",
+		HandlerType: (*configServer)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetConfig",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					if err := dec(new(emptypb.Empty)); err != nil {
+						return nil, err
+					}
+					data, err := srv.(configServer).getConfig(ctx)
+					if err != nil {
+						return nil, err
+					}
+					return wrapperspb.Bytes(data), nil
+				},
+			},
+		},
+	}, configServerFunc(getConfig))
+}
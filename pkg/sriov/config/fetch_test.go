@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+func TestFetchConfig_HTTP(t *testing.T) {
+	data, err := os.ReadFile(configFileName)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	cfg, err := config.FetchConfig(context.Background(), u, config.WithRetry(1, time.Millisecond))
+	require.NoError(t, err)
+	require.Len(t, cfg.PhysicalFunctions, 2)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestFetchConfig_GRPC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := os.ReadFile(configFileName)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	config.RegisterConfigServer(server, func(context.Context) ([]byte, error) {
+		return data, nil
+	})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	cfg, err := config.FetchConfig(ctx, &url.URL{Scheme: "tcp", Host: listener.Addr().String()})
+	require.NoError(t, err)
+	require.Len(t, cfg.PhysicalFunctions, 2)
+}
+
+func TestFetchConfig_Unsupported(t *testing.T) {
+	_, err := config.FetchConfig(context.Background(), &url.URL{Scheme: "ftp", Host: "localhost"})
+	require.Error(t, err)
+}
@@ -26,4 +26,15 @@ const (
 	KernelDriver DriverType = "kernel"
 	// VFIOPCIDriver is vfio-pci driver type
 	VFIOPCIDriver DriverType = "vfio-pci"
+	// VhostVDPADriver is vhost-vdpa driver type: the VF is bound to the kernel driver and serves the vDPA device bound
+	// to the vhost_vdpa bus driver
+	VhostVDPADriver DriverType = "vhost-vdpa"
+	// VirtioVDPADriver is virtio-vdpa driver type: the VF is bound to the kernel driver and serves the vDPA device
+	// bound to the virtio_vdpa bus driver
+	VirtioVDPADriver DriverType = "virtio-vdpa"
 )
+
+// IsVDPA returns true if the driver type is a vDPA driver type
+func (t DriverType) IsVDPA() bool {
+	return t == VhostVDPADriver || t == VirtioVDPADriver
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hotplug provides a monitor tracking SR-IOV PF link state and VF presence in sysfs
+package hotplug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+)
+
+const (
+	netDir        = "net"
+	operStateFile = "operstate"
+	operStateDown = "down"
+	operStateLLD  = "lowerlayerdown"
+
+	defaultPCIDevicesPath = "/sys/bus/pci/devices"
+	defaultInterval       = 5 * time.Second
+)
+
+// Event is a PF health change event
+type Event struct {
+	PFPCIAddr  string
+	LinkUp     bool
+	MissingVFs []string // PCI addresses of the enabled VFs disappeared from sysfs
+	HealthyVFs int      // number of the enabled VFs available for use, 0 if PF link is down
+}
+
+// EventTopic is the event bus topic of the PF health changes, the events payload is *Event
+const EventTopic eventbus.Topic = "pfHealth"
+
+func (e *Event) String() string {
+	return fmt.Sprintf("PF %s link up: %v, healthy VFs: %d, missing VFs: %v", e.PFPCIAddr, e.LinkUp, e.HealthyVFs, e.MissingVFs)
+}
+
+// Monitor periodically checks PF link state and VF presence for the configured PFs and publishes the PF health
+// changes to the event bus
+type Monitor struct {
+	pciDevicesPath string
+	interval       time.Duration
+	config         *config.Config
+	states         map[string]*Event // states[pfPCIAddr] -> *Event
+	bus            *eventbus.Bus
+	lock           sync.Mutex
+}
+
+// Option is an option for the Monitor
+type Option func(m *Monitor)
+
+// WithPCIDevicesPath sets sysfs PCI devices path
+func WithPCIDevicesPath(pciDevicesPath string) Option {
+	return func(m *Monitor) {
+		m.pciDevicesPath = pciDevicesPath
+	}
+}
+
+// WithEventBus sets the event bus the PF health changes are published to with EventTopic, so it can be shared with the
+// other publishers. By default Monitor uses its own event bus.
+func WithEventBus(bus *eventbus.Bus) Option {
+	return func(m *Monitor) {
+		m.bus = bus
+	}
+}
+
+// WithInterval sets health check interval
+func WithInterval(interval time.Duration) Option {
+	return func(m *Monitor) {
+		m.interval = interval
+	}
+}
+
+// NewMonitor returns a new Monitor for the cfg PFs, all PFs are assumed to be healthy until the first check
+func NewMonitor(cfg *config.Config, options ...Option) *Monitor {
+	m := &Monitor{
+		pciDevicesPath: defaultPCIDevicesPath,
+		interval:       defaultInterval,
+		config:         cfg,
+		states:         map[string]*Event{},
+		bus:            eventbus.New(),
+	}
+	for _, option := range options {
+		option(m)
+	}
+
+	for pfPCIAddr, pfCfg := range cfg.PhysicalFunctions {
+		if pfCfg.Disabled {
+			continue
+		}
+		m.states[pfPCIAddr] = &Event{
+			PFPCIAddr:  pfPCIAddr,
+			LinkUp:     true,
+			HealthyVFs: len(pfCfg.EnabledVirtualFunctions()),
+		}
+	}
+
+	return m
+}
+
+// AddListener adds a new listener that fires on PF health change, it is subscribed to the Monitor event bus EventTopic
+func (m *Monitor) AddListener(listener func(event *Event)) {
+	m.bus.Subscribe(func(event *eventbus.Event) {
+		listener(event.Payload.(*Event))
+	}, EventTopic)
+}
+
+// IsHealthy returns if PCI function selected by the given PCI address is healthy: PF link is up and VF is present.
+// Unknown PCI functions are treated as healthy.
+func (m *Monitor) IsHealthy(pciAddr string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for pfPCIAddr, state := range m.states {
+		if pfPCIAddr == pciAddr {
+			return state.LinkUp
+		}
+		for _, vfPCIAddr := range state.MissingVFs {
+			if vfPCIAddr == pciAddr {
+				return false
+			}
+		}
+		for _, vfCfg := range m.config.PhysicalFunctions[pfPCIAddr].VirtualFunctions {
+			if vfCfg.Address == pciAddr {
+				return state.LinkUp
+			}
+		}
+	}
+	return true
+}
+
+// Start starts periodic health checks until the ctx is done
+func (m *Monitor) Start(ctx context.Context) {
+	go func() {
+		for {
+			m.Check(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(m.interval):
+			}
+		}
+	}()
+}
+
+// Check checks health of all PFs and publishes the changes
+func (m *Monitor) Check(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("Monitor", "Check")
+
+	var events []*Event
+	func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		var pfPCIAddrs []string
+		for pfPCIAddr := range m.states {
+			pfPCIAddrs = append(pfPCIAddrs, pfPCIAddr)
+		}
+		sort.Strings(pfPCIAddrs)
+
+		for _, pfPCIAddr := range pfPCIAddrs {
+			state := m.check(pfPCIAddr)
+			if isEqual(state, m.states[pfPCIAddr]) {
+				continue
+			}
+			logger.Infof("PF health changed: %s, link up: %v, missing VFs: %v", pfPCIAddr, state.LinkUp, state.MissingVFs)
+
+			m.states[pfPCIAddr] = state
+			events = append(events, state)
+		}
+	}()
+
+	for _, event := range events {
+		m.bus.Publish(EventTopic, event)
+	}
+}
+
+func (m *Monitor) check(pfPCIAddr string) *Event {
+	state := &Event{
+		PFPCIAddr: pfPCIAddr,
+		LinkUp:    m.isLinkUp(pfPCIAddr),
+	}
+	for _, vfCfg := range m.config.PhysicalFunctions[pfPCIAddr].EnabledVirtualFunctions() {
+		if _, err := os.Stat(filepath.Join(m.pciDevicesPath, vfCfg.Address)); err != nil {
+			state.MissingVFs = append(state.MissingVFs, vfCfg.Address)
+			continue
+		}
+		if state.LinkUp {
+			state.HealthyVFs++
+		}
+	}
+	return state
+}
+
+func (m *Monitor) isLinkUp(pfPCIAddr string) bool {
+	pfPath := filepath.Join(m.pciDevicesPath, pfPCIAddr)
+	if _, err := os.Stat(pfPath); err != nil {
+		return false
+	}
+
+	// PF can have no net interface, if it is bound to some non-kernel driver
+	ifNames, err := os.ReadDir(filepath.Join(pfPath, netDir))
+	if err != nil || len(ifNames) == 0 {
+		return true
+	}
+
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(pfPath, netDir, ifNames[0].Name(), operStateFile)))
+	if err != nil {
+		return true
+	}
+	switch strings.TrimSpace(string(data)) {
+	case operStateDown, operStateLLD:
+		return false
+	default:
+		return true
+	}
+}
+
+func isEqual(state, other *Event) bool {
+	if state.LinkUp != other.LinkUp || state.HealthyVFs != other.HealthyVFs || len(state.MissingVFs) != len(other.MissingVFs) {
+		return false
+	}
+	for i := range state.MissingVFs {
+		if state.MissingVFs[i] != other.MissingVFs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hotplug_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+)
+
+const (
+	pf1PciAddr  = "0000:01:00.0"
+	vf11PciAddr = "0000:01:00.1"
+	vf12PciAddr = "0000:01:00.2"
+	pf2PciAddr  = "0000:02:00.0"
+	vf21PciAddr = "0000:02:00.1"
+)
+
+func TestMonitor_Check(t *testing.T) {
+	devicesPath := t.TempDir()
+	for _, pciAddr := range []string{pf1PciAddr, vf11PciAddr, vf12PciAddr, pf2PciAddr, vf21PciAddr} {
+		require.NoError(t, os.MkdirAll(filepath.Join(devicesPath, pciAddr), 0o750))
+	}
+	operStatePath := filepath.Join(devicesPath, pf2PciAddr, "net", "eth0", "operstate")
+	require.NoError(t, os.MkdirAll(filepath.Dir(operStatePath), 0o750))
+	require.NoError(t, os.WriteFile(operStatePath, []byte("up\n"), 0o600))
+
+	cfg := &config.Config{
+		PhysicalFunctions: map[string]*config.PhysicalFunction{
+			pf1PciAddr: {
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: vf11PciAddr, IOMMUGroup: 1},
+					{Address: vf12PciAddr, IOMMUGroup: 2},
+				},
+			},
+			pf2PciAddr: {
+				VirtualFunctions: []*config.VirtualFunction{
+					{Address: vf21PciAddr, IOMMUGroup: 3},
+				},
+			},
+		},
+	}
+
+	m := hotplug.NewMonitor(cfg, hotplug.WithPCIDevicesPath(devicesPath))
+
+	var events []*hotplug.Event
+	m.AddListener(func(event *hotplug.Event) {
+		events = append(events, event)
+	})
+
+	m.Check(context.Background())
+	require.Empty(t, events)
+
+	// VF disappears
+	require.NoError(t, os.RemoveAll(filepath.Join(devicesPath, vf12PciAddr)))
+	// PF link goes down
+	require.NoError(t, os.WriteFile(operStatePath, []byte("down\n"), 0o600))
+
+	m.Check(context.Background())
+	require.Equal(t, []*hotplug.Event{
+		{PFPCIAddr: pf1PciAddr, LinkUp: true, MissingVFs: []string{vf12PciAddr}, HealthyVFs: 1},
+		{PFPCIAddr: pf2PciAddr, LinkUp: false, HealthyVFs: 0},
+	}, events)
+
+	require.True(t, m.IsHealthy(vf11PciAddr))
+	require.False(t, m.IsHealthy(vf12PciAddr))
+	require.False(t, m.IsHealthy(pf2PciAddr))
+	require.False(t, m.IsHealthy(vf21PciAddr))
+
+	events = nil
+	m.Check(context.Background())
+	require.Empty(t, events)
+}
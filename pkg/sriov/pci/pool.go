@@ -34,17 +34,22 @@ import (
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pcifunction"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
 )
 
 const (
 	vfioDriver        = "vfio-pci"
 	driverBindTimeout = time.Second
 	driverBindCheck   = driverBindTimeout / 10
+	resetTimeout      = 2 * time.Second
+	resetCheck        = resetTimeout / 20
 )
 
 type pciFunction interface {
 	GetBoundDriver() (string, error)
 	BindDriver(driver string) error
+	Reset() error
+	CheckResponsive() error
 
 	sriov.PCIFunction
 }
@@ -77,6 +82,10 @@ func NewPCIPool(pciDevicesPath, pciDriversPath, vfioDir string, cfg *config.Conf
 	}
 
 	for pfPCIAddr, pfCfg := range cfg.PhysicalFunctions {
+		if pfCfg.Disabled {
+			continue
+		}
+
 		pf, err := pcifunction.NewPhysicalFunction(pfPCIAddr, pciDevicesPath, pciDriversPath)
 		if err != nil {
 			return nil, err
@@ -105,6 +114,10 @@ func NewTestPool(physicalFunctions map[string]*sriovtest.PCIPhysicalFunction, cf
 	}
 
 	for pfPCIAddr, pfCfg := range cfg.PhysicalFunctions {
+		if pfCfg.Disabled {
+			continue
+		}
+
 		pf, ok := physicalFunctions[pfPCIAddr]
 		if !ok {
 			return nil, errors.Errorf("PF doesn't exist: %v", pfPCIAddr)
@@ -148,7 +161,12 @@ func (p *Pool) GetPCIFunction(pciAddr string) (sriov.PCIFunction, error) {
 
 // BindDriver binds selected IOMMU group to the given driver type
 func (p *Pool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	logger := hwlog.FromContext(ctx, hwlog.PCISubsystem).WithField("iommuGroup", iommuGroup)
 	for _, f := range p.functionsByIOMMUGroup[iommuGroup] {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrapf(err, "provided context is done before binding: %s", f.function.GetPCIAddress())
+		}
+		logger.Debugf("binding %v driver to PCI function: %s", driverType, f.function.GetPCIAddress())
 		switch driverType {
 		case sriov.KernelDriver:
 			if err := f.function.BindDriver(f.kernelDriver); err != nil {
@@ -172,6 +190,44 @@ func (p *Pool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov
 	return nil
 }
 
+// ResetFunction triggers the function level reset of the PCI function bound to the given driver type, e.g. to recover
+// the VF left in a broken state by the user space driver, and waits until the function responds again with the driver
+// bound. Returns error if the function is not sane after the reset.
+func (p *Pool) ResetFunction(ctx context.Context, pciAddr string, driverType sriov.DriverType) error {
+	f, ok := p.functions[pciAddr]
+	if !ok {
+		return errors.Errorf("PCI function doesn't exist: %v", pciAddr)
+	}
+
+	hwlog.FromContext(ctx, hwlog.PCISubsystem).Debugf("resetting PCI function: %s", pciAddr)
+	if err := f.function.Reset(); err != nil {
+		return err
+	}
+
+	if err := p.waitFunctionResponsive(ctx, f.function); err != nil {
+		return err
+	}
+	return p.waitDriverGettingBound(ctx, f.function, driverType)
+}
+
+func (p *Pool) waitFunctionResponsive(ctx context.Context, pcif pciFunction) error {
+	timeoutCh := time.After(resetTimeout)
+	for {
+		err := pcif.CheckResponsive()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "provided context is done")
+		case <-timeoutCh:
+			return errors.Errorf("time for the function reset exceeded: %s, cause: %v", pcif.GetPCIAddress(), err)
+		case <-time.After(resetCheck):
+		}
+	}
+}
+
 func (p *Pool) waitDriverGettingBound(ctx context.Context, pcif pciFunction, driverType sriov.DriverType) error {
 	timeoutCh := time.After(driverBindTimeout)
 	for {
@@ -21,14 +21,24 @@ import (
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pcifunction"
 )
 
-// UpdateConfig updates config with virtual functions
+// UpdateConfig updates config with virtual functions and NUMA nodes, disabled physical functions are not touched
 func UpdateConfig(pciDevicesPath, pciDriversPath string, cfg *config.Config) error {
 	for pfPCIAddr, pfCfg := range cfg.PhysicalFunctions {
+		if pfCfg.Disabled {
+			continue
+		}
+
 		pf, err := pcifunction.NewPhysicalFunction(pfPCIAddr, pciDevicesPath, pciDriversPath)
 		if err != nil {
 			return err
 		}
 
+		if pfCfg.NUMANode == nil {
+			if numaNode, err := pf.GetNUMANode(); err == nil {
+				pfCfg.NUMANode = &numaNode
+			}
+		}
+
 		for _, vf := range pf.GetVirtualFunctions() {
 			iommuGroup, err := vf.GetIOMMUGroup()
 			if err != nil {
@@ -19,6 +19,8 @@
 package pcifunction
 
 import (
+	"encoding/binary"
+	"io"
 	"os"
 
 	"path"
@@ -31,11 +33,17 @@ import (
 const (
 	netInterfacesPath = "net"
 	iommuGroup        = "iommu_group"
+	numaNodeFile      = "numa_node"
 	boundDriverPath   = "driver"
 	bindDriverPath    = "bind"
 	unbindDriverPath  = "unbind"
+	resetPath         = "reset"
+	configSpacePath   = "config"
 )
 
+// UnresponsiveVendorID is read as the vendor ID from the config space of the device not responding on the bus
+const UnresponsiveVendorID uint16 = 0xffff
+
 // Function describes Linux PCI function
 type Function struct {
 	address        string
@@ -82,6 +90,11 @@ func (f *Function) GetIOMMUGroup() (uint, error) {
 	return uint(iommuGroup), nil
 }
 
+// GetNUMANode returns f NUMA node, if NUMA node is unknown returns -1
+func (f *Function) GetNUMANode() (int, error) {
+	return readIntFromFile(f.withDevicePath(numaNodeFile))
+}
+
 // GetBoundDriver returns driver name that is bound to f, if no driver bound, returns ""
 func (f *Function) GetBoundDriver() (string, error) {
 	if !isFileExists(f.withDevicePath(boundDriverPath)) {
@@ -121,6 +134,39 @@ func (f *Function) BindDriver(driver string) error {
 	return nil
 }
 
+// Reset triggers f function level reset
+func (f *Function) Reset() error {
+	if err := os.WriteFile(f.withDevicePath(resetPath), []byte("1"), 0); err != nil {
+		return errors.Wrapf(err, "failed to reset the device: %v", f.address)
+	}
+	return nil
+}
+
+// CheckResponsive returns error if f doesn't respond to the config space reads, e.g. after the failed reset. The config
+// space is read directly, the sysfs vendor file is cached by the kernel and doesn't reflect the device state.
+func (f *Function) CheckResponsive() error {
+	configSpace, err := os.Open(f.withDevicePath(configSpacePath))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open config space for the device: %v", f.address)
+	}
+	defer func() { _ = configSpace.Close() }()
+
+	return CheckConfigSpace(f.address, configSpace)
+}
+
+// CheckConfigSpace returns error if the vendor ID read from the first two bytes of the device config space is
+// UnresponsiveVendorID
+func CheckConfigSpace(pciAddress string, configSpace io.Reader) error {
+	var vendorID uint16
+	if err := binary.Read(configSpace, binary.LittleEndian, &vendorID); err != nil {
+		return errors.Wrapf(err, "failed to read vendor ID from the config space for the device: %v", pciAddress)
+	}
+	if vendorID == UnresponsiveVendorID {
+		return errors.Errorf("device doesn't respond to the config space reads: %v", pciAddress)
+	}
+	return nil
+}
+
 func (f *Function) withDevicePath(elem ...string) string {
 	return path.Join(append([]string{f.pciDevicesPath, f.address}, elem...)...)
 }
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcifunction_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pcifunction"
+)
+
+const pfPCIAddr = "0000:01:00.0"
+
+func TestFunction_CheckResponsive(t *testing.T) {
+	pciDevicesPath := t.TempDir()
+	pfPath := filepath.Join(pciDevicesPath, pfPCIAddr)
+	require.NoError(t, os.MkdirAll(pfPath, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(pfPath, "sriov_totalvfs"), []byte("0"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(pfPath, "sriov_numvfs"), []byte("0"), 0o600))
+	// the vendor file is cached by the kernel, it still reports the vendor ID for the unresponsive device
+	require.NoError(t, os.WriteFile(filepath.Join(pfPath, "vendor"), []byte("0x8086\n"), 0o600))
+
+	pf, err := pcifunction.NewPhysicalFunction(pfPCIAddr, pciDevicesPath, t.TempDir())
+	require.NoError(t, err)
+
+	require.Error(t, pf.CheckResponsive())
+
+	require.NoError(t, os.WriteFile(filepath.Join(pfPath, "config"), []byte{0x86, 0x80, 0x54, 0x15}, 0o600))
+	require.NoError(t, pf.CheckResponsive())
+
+	require.NoError(t, os.WriteFile(filepath.Join(pfPath, "config"), []byte{0xff, 0xff, 0xff, 0xff}, 0o600))
+	require.Error(t, pf.CheckResponsive())
+
+	require.NoError(t, os.WriteFile(filepath.Join(pfPath, "config"), []byte{0x86}, 0o600))
+	require.Error(t, pf.CheckResponsive())
+}
@@ -44,6 +44,20 @@ func readUintFromFile(path string) (uint, error) {
 	return uint(value), nil
 }
 
+func readIntFromFile(path string) (int, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to locate file: %v", path)
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to convert string to int: %v", string(data))
+	}
+
+	return value, nil
+}
+
 func evalSymlinkAndGetBaseName(path string) (string, error) {
 	fileInfo, err := os.Lstat(path)
 	if err != nil {
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+)
+
+// Option is an option for the Pool
+type Option func(p *Pool)
+
+// WithEventBus sets the event bus the VF selections, frees and health exclusions are published to with EventTopic.
+// Events are published synchronously under the lock the Pool is used under, so the handlers shouldn't use the Pool.
+func WithEventBus(bus *eventbus.Bus) Option {
+	return func(p *Pool) {
+		p.bus = bus
+	}
+}
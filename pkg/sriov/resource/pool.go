@@ -18,16 +18,46 @@
 package resource
 
 import (
+	"fmt"
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
 )
 
+// ErrNoFreeVF is returned by the VF selection if there is no free VF matching the token and the driver type
+var ErrNoFreeVF = errors.New("no free VF")
+
+// EventTopic is the event bus topic of the VF state changes, the events payload is *VFEvent
+const EventTopic eventbus.Topic = "vfs"
+
+// VF state changes
+const (
+	VFSelected  = "selected"
+	VFFreed     = "freed"
+	VFUnhealthy = "unhealthy"
+)
+
+// VFEvent is a VF state change event
+type VFEvent struct {
+	VFPCIAddress string
+	TokenID      string
+	Change       string
+}
+
+func (e *VFEvent) String() string {
+	if e.TokenID == "" {
+		return fmt.Sprintf("VF %s %s", e.VFPCIAddress, e.Change)
+	}
+	return fmt.Sprintf("VF %s %s for the token %s", e.VFPCIAddress, e.Change, e.TokenID)
+}
+
 // TokenPool is a token.Pool interface
 type TokenPool interface {
 	Find(id string) (string, error)
@@ -42,15 +72,29 @@ type Pool struct {
 	virtualFunctions  map[string]*virtualFunction
 	tokens            map[string]*virtualFunction
 	iommuGroups       map[uint]sriov.DriverType
+	warmGroups        map[uint]struct{}
+	unhealthyVFs      map[string]time.Time
+	drainedVFs        map[string]*drainMark
+	selectFailures    uint64
 	tokenPool         TokenPool
+	bus               *eventbus.Bus
 }
 
 type physicalFunction struct {
 	tokenNames       map[string]struct{}
+	capabilities     []string
+	numaNode         int
+	physicalNetwork  string
 	virtualFunctions map[uint][]*virtualFunction
 	freeVFsCount     int
 }
 
+// drainMark is the DrainPF unhealthy mark of the virtual function and the unhealthy mark it overrides
+type drainMark struct {
+	drainedUntil   time.Time
+	unhealthyUntil time.Time
+}
+
 type virtualFunction struct {
 	pciAddr    string
 	pfPCIAddr  string
@@ -59,20 +103,34 @@ type virtualFunction struct {
 }
 
 // NewPool returns a new Pool
-func NewPool(tokenPool TokenPool, cfg *config.Config) *Pool {
+func NewPool(tokenPool TokenPool, cfg *config.Config, options ...Option) *Pool {
 	p := &Pool{
 		physicalFunctions: map[string]*physicalFunction{},
 		virtualFunctions:  map[string]*virtualFunction{},
 		tokens:            map[string]*virtualFunction{},
 		iommuGroups:       map[uint]sriov.DriverType{},
+		warmGroups:        map[uint]struct{}{},
+		unhealthyVFs:      map[string]time.Time{},
+		drainedVFs:        map[string]*drainMark{},
 		tokenPool:         tokenPool,
 	}
+	for _, option := range options {
+		option(p)
+	}
 
 	for pfPCIAddr, pFun := range cfg.PhysicalFunctions {
+		if pFun.Disabled {
+			continue
+		}
+
+		enabledVFs := pFun.EnabledVirtualFunctions()
 		pf := &physicalFunction{
 			tokenNames:       map[string]struct{}{},
+			capabilities:     pFun.Capabilities,
+			numaNode:         pFun.GetNUMANode(),
+			physicalNetwork:  pFun.PhysicalNetwork,
 			virtualFunctions: map[uint][]*virtualFunction{},
-			freeVFsCount:     len(pFun.VirtualFunctions),
+			freeVFsCount:     len(enabledVFs),
 		}
 		p.physicalFunctions[pfPCIAddr] = pf
 
@@ -82,7 +140,7 @@ func NewPool(tokenPool TokenPool, cfg *config.Config) *Pool {
 			}
 		}
 
-		for _, vFun := range pFun.VirtualFunctions {
+		for _, vFun := range enabledVFs {
 			vf := &virtualFunction{
 				pciAddr:    vFun.Address,
 				pfPCIAddr:  pfPCIAddr,
@@ -100,6 +158,30 @@ func NewPool(tokenPool TokenPool, cfg *config.Config) *Pool {
 
 // Select selects a virtual function for the given driver type and marks it as "in-use"
 func (p *Pool) Select(tokenID string, driverType sriov.DriverType) (string, error) {
+	return p.SelectWithHints(tokenID, driverType, nil)
+}
+
+// SelectWithHints selects a virtual function for the given driver type preferring the ones matching the hints and
+// marks it as "in-use"
+func (p *Pool) SelectWithHints(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints) (string, error) {
+	return p.selectFiltered(tokenID, driverType, hints, nil)
+}
+
+// SelectExcludingPFs selects a virtual function for the given driver type on the physical function not listed in
+// excludedPFs and marks it as "in-use", e.g. to get the VFs of a bonded pair from the different PFs
+func (p *Pool) SelectExcludingPFs(tokenID string, driverType sriov.DriverType, excludedPFs []string) (string, error) {
+	return p.selectFiltered(tokenID, driverType, nil, excludedPFs)
+}
+
+func (p *Pool) selectFiltered(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints, excludedPFs []string) (string, error) {
+	vfPCIAddr, err := p.trySelect(tokenID, driverType, hints, excludedPFs)
+	if err != nil {
+		p.selectFailures++
+	}
+	return vfPCIAddr, err
+}
+
+func (p *Pool) trySelect(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints, excludedPFs []string) (string, error) {
 	switch vf, err := p.trySelected(tokenID, driverType); {
 	case err != nil:
 		return "", err
@@ -112,9 +194,18 @@ func (p *Pool) Select(tokenID string, driverType sriov.DriverType) (string, erro
 		return "", err
 	}
 
-	vfs := p.find(driverType, tokenName)
+	vfs := excludePFs(p.find(driverType, tokenName), excludedPFs)
 	if len(vfs) == 0 {
-		return "", errors.Errorf("no free VF for the driver type: %v", driverType)
+		return "", errors.Wrapf(ErrNoFreeVF, "failed to select VF for the driver type: %v", driverType)
+	}
+	if hints != nil && hints.PhysicalNetwork != "" {
+		if vfs = p.filterByPhysicalNetwork(vfs, hints.PhysicalNetwork); len(vfs) == 0 {
+			return "", errors.Wrapf(ErrNoFreeVF, "failed to select VF on the physical network %s for the driver type: %v",
+				hints.PhysicalNetwork, driverType)
+		}
+	}
+	if matchingVFs := p.filterByHints(vfs, hints); len(matchingVFs) != 0 {
+		vfs = matchingVFs
 	}
 
 	sort.Slice(vfs, func(i, k int) bool {
@@ -122,11 +213,16 @@ func (p *Pool) Select(tokenID string, driverType sriov.DriverType) (string, erro
 		rightIG := p.iommuGroups[vfs[k].iommuGroup]
 		leftPF := p.physicalFunctions[vfs[i].pfPCIAddr]
 		rightPF := p.physicalFunctions[vfs[k].pfPCIAddr]
+		_, leftWarm := p.warmGroups[vfs[i].iommuGroup]
+		_, rightWarm := p.warmGroups[vfs[k].iommuGroup]
 		switch {
 		case leftIG == driverType && rightIG == sriov.NoDriver:
 			return true
 		case leftIG == sriov.NoDriver && rightIG == driverType:
 			return false
+		case leftWarm != rightWarm:
+			// warm VFs are already bound to the vfio-pci driver, other drivers shouldn't take them
+			return leftWarm == (driverType == sriov.VFIOPCIDriver)
 		case leftPF.freeVFsCount > rightPF.freeVFsCount:
 			return true
 		case leftPF.freeVFsCount < rightPF.freeVFsCount:
@@ -161,7 +257,7 @@ func (p *Pool) find(driverType sriov.DriverType, tokenName string) []*virtualFun
 			for iommuGroup, vfs := range pf.virtualFunctions {
 				if ig := p.iommuGroups[iommuGroup]; ig == sriov.NoDriver || ig == driverType {
 					for _, vf := range vfs {
-						if vf.tokenID == "" {
+						if vf.tokenID == "" && !p.isUnhealthy(vf.pciAddr) {
 							virtualFunctions = append(virtualFunctions, vf)
 						}
 					}
@@ -172,6 +268,107 @@ func (p *Pool) find(driverType sriov.DriverType, tokenName string) []*virtualFun
 	return virtualFunctions
 }
 
+func (p *Pool) isUnhealthy(vfPCIAddr string) bool {
+	unhealthyUntil, ok := p.unhealthyVFs[vfPCIAddr]
+	if ok && !time.Now().Before(unhealthyUntil) {
+		delete(p.unhealthyVFs, vfPCIAddr)
+		delete(p.drainedVFs, vfPCIAddr)
+		return false
+	}
+	return ok
+}
+
+// filterByHints returns the VFs matching the hints. If no VF matches all of them, the hints are relaxed one at a time:
+// the preferred PFs are dropped first, then the NUMA node is replaced with the fallback NUMA nodes in order and dropped,
+// then the capabilities.
+func (p *Pool) filterByHints(vfs []*virtualFunction, hints *sriov.SelectionHints) []*virtualFunction {
+	if hints.IsEmpty() {
+		return nil
+	}
+
+	relaxations := []func(h *sriov.SelectionHints){
+		func(h *sriov.SelectionHints) { h.PreferredPFs = nil },
+	}
+	if hints.NUMANode >= 0 {
+		for _, numaNode := range hints.FallbackNUMANodes {
+			numaNode := numaNode
+			relaxations = append(relaxations, func(h *sriov.SelectionHints) { h.NUMANode = numaNode })
+		}
+	}
+	relaxations = append(relaxations,
+		func(h *sriov.SelectionHints) { h.NUMANode = -1 },
+		func(h *sriov.SelectionHints) { h.Capabilities = nil },
+	)
+
+	relaxed := *hints
+	relaxed.PhysicalNetwork = ""
+	for _, relax := range relaxations {
+		if relaxed.IsEmpty() {
+			break
+		}
+		if matchingVFs := p.matchHints(vfs, &relaxed); len(matchingVFs) != 0 {
+			return matchingVFs
+		}
+		relax(&relaxed)
+	}
+	return nil
+}
+
+func (p *Pool) matchHints(vfs []*virtualFunction, hints *sriov.SelectionHints) (matchingVFs []*virtualFunction) {
+	for _, vf := range vfs {
+		pf := p.physicalFunctions[vf.pfPCIAddr]
+		if hints.NUMANode >= 0 && pf.numaNode != hints.NUMANode {
+			continue
+		}
+		if !containsAll(pf.capabilities, hints.Capabilities) {
+			continue
+		}
+		if len(hints.PreferredPFs) > 0 && !containsAll(hints.PreferredPFs, []string{vf.pfPCIAddr}) {
+			continue
+		}
+		matchingVFs = append(matchingVFs, vf)
+	}
+	return matchingVFs
+}
+
+func (p *Pool) filterByPhysicalNetwork(vfs []*virtualFunction, physicalNetwork string) (matchingVFs []*virtualFunction) {
+	for _, vf := range vfs {
+		if p.physicalFunctions[vf.pfPCIAddr].physicalNetwork == physicalNetwork {
+			matchingVFs = append(matchingVFs, vf)
+		}
+	}
+	return matchingVFs
+}
+
+func excludePFs(vfs []*virtualFunction, excludedPFs []string) []*virtualFunction {
+	if len(excludedPFs) == 0 {
+		return vfs
+	}
+	var filteredVFs []*virtualFunction
+	for _, vf := range vfs {
+		if !containsAll(excludedPFs, []string{vf.pfPCIAddr}) {
+			filteredVFs = append(filteredVFs, vf)
+		}
+	}
+	return filteredVFs
+}
+
+func containsAll(strs, subStrs []string) bool {
+	for _, subStr := range subStrs {
+		found := false
+		for _, str := range strs {
+			if str == subStr {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *Pool) selectVF(vf *virtualFunction, tokenID string, driverType sriov.DriverType) error {
 	var tokenNames []string
 	for tokenName := range p.physicalFunctions[vf.pfPCIAddr].tokenNames {
@@ -186,6 +383,11 @@ func (p *Pool) selectVF(vf *virtualFunction, tokenID string, driverType sriov.Dr
 
 	p.physicalFunctions[vf.pfPCIAddr].freeVFsCount--
 	p.iommuGroups[vf.iommuGroup] = driverType
+	if driverType != sriov.VFIOPCIDriver {
+		delete(p.warmGroups, vf.iommuGroup)
+	}
+
+	p.publish(vf.pciAddr, tokenID, VFSelected)
 
 	return nil
 }
@@ -203,6 +405,7 @@ func (p *Pool) Free(vfPCIAddr string) error {
 	if err := p.tokenPool.StopUsing(vf.tokenID); err != nil {
 		return err
 	}
+	p.publish(vf.pciAddr, vf.tokenID, VFFreed)
 	delete(p.tokens, vf.tokenID)
 	vf.tokenID = ""
 
@@ -221,3 +424,230 @@ func (p *Pool) Free(vfPCIAddr string) error {
 
 	return nil
 }
+
+// Rebind changes the driver type of the virtual function selected for the tokenID in place, so it stays selected while
+// its IOMMU group is rebound to the other driver (e.g. on the connection mechanism migration). Returns the previous
+// driver type, error if the virtual function is not selected for the tokenID or its IOMMU group is shared with the
+// other selected virtual functions.
+func (p *Pool) Rebind(vfPCIAddr, tokenID string, driverType sriov.DriverType) (sriov.DriverType, error) {
+	vf, ok := p.virtualFunctions[vfPCIAddr]
+	if !ok {
+		return sriov.NoDriver, errors.Errorf("VF doesn't exist: %v", vfPCIAddr)
+	}
+	if vf.tokenID == "" || vf.tokenID != tokenID {
+		return sriov.NoDriver, errors.Errorf("VF %v is not selected for the token: %v", vfPCIAddr, tokenID)
+	}
+
+	for _, pf := range p.physicalFunctions {
+		for _, vff := range pf.virtualFunctions[vf.iommuGroup] {
+			if vff != vf && vff.tokenID != "" {
+				return sriov.NoDriver, errors.Errorf("VF %v IOMMU group %v is used by the other VF: %v",
+					vfPCIAddr, vf.iommuGroup, vff.pciAddr)
+			}
+		}
+	}
+
+	prevDriverType := p.iommuGroups[vf.iommuGroup]
+	p.iommuGroups[vf.iommuGroup] = driverType
+	if driverType != sriov.VFIOPCIDriver {
+		delete(p.warmGroups, vf.iommuGroup)
+	}
+
+	return prevDriverType, nil
+}
+
+// MarkUnhealthy excludes given virtual function from the selection for the duration, e.g. after its driver binding
+// failure. It doesn't free the virtual function if it is selected.
+func (p *Pool) MarkUnhealthy(vfPCIAddr string, duration time.Duration) error {
+	if _, ok := p.virtualFunctions[vfPCIAddr]; !ok {
+		return errors.Errorf("VF doesn't exist: %v", vfPCIAddr)
+	}
+	p.unhealthyVFs[vfPCIAddr] = time.Now().Add(duration)
+	p.publish(vfPCIAddr, "", VFUnhealthy)
+	return nil
+}
+
+// DrainPF excludes all the virtual functions of the given physical function from the selection for the duration, 0
+// duration ends the draining. Ending the draining keeps the virtual functions marked unhealthy by MarkUnhealthy. Returns the assignments still holding the physical function virtual functions.
+func (p *Pool) DrainPF(pfPCIAddr string, duration time.Duration) ([]*Assignment, error) {
+	pf, ok := p.physicalFunctions[pfPCIAddr]
+	if !ok {
+		return nil, errors.Errorf("PF doesn't exist: %v", pfPCIAddr)
+	}
+
+	var assignments []*Assignment
+	for _, vfs := range pf.virtualFunctions {
+		for _, vf := range vfs {
+			if duration == 0 {
+				p.undrainVF(vf.pciAddr)
+			} else {
+				p.drainVF(vf.pciAddr, duration)
+			}
+			if vf.tokenID != "" {
+				assignments = append(assignments, &Assignment{
+					VFPCIAddress: vf.pciAddr,
+					TokenID:      vf.tokenID,
+					DriverType:   p.iommuGroups[vf.iommuGroup],
+				})
+			}
+		}
+	}
+	sort.Slice(assignments, func(i, k int) bool {
+		return assignments[i].VFPCIAddress < assignments[k].VFPCIAddress
+	})
+	return assignments, nil
+}
+
+// drainVF marks the virtual function unhealthy for the duration unless it is already marked for longer, and remembers the
+// mark so undrainVF clears only it
+func (p *Pool) drainVF(vfPCIAddr string, duration time.Duration) {
+	mark := &drainMark{
+		drainedUntil: time.Now().Add(duration),
+	}
+	if unhealthyUntil, ok := p.unhealthyVFs[vfPCIAddr]; ok {
+		if unhealthyUntil.After(mark.drainedUntil) {
+			return
+		}
+		mark.unhealthyUntil = unhealthyUntil
+		if prevMark, ok := p.drainedVFs[vfPCIAddr]; ok && prevMark.drainedUntil.Equal(unhealthyUntil) {
+			mark.unhealthyUntil = prevMark.unhealthyUntil
+		}
+	}
+	p.unhealthyVFs[vfPCIAddr] = mark.drainedUntil
+	p.drainedVFs[vfPCIAddr] = mark
+	p.publish(vfPCIAddr, "", VFUnhealthy)
+}
+
+// undrainVF clears the virtual function unhealthy mark set by drainVF, restoring the unhealthy mark it has overridden. The
+// marks set by MarkUnhealthy after the draining are kept.
+func (p *Pool) undrainVF(vfPCIAddr string) {
+	mark, ok := p.drainedVFs[vfPCIAddr]
+	if !ok {
+		return
+	}
+	delete(p.drainedVFs, vfPCIAddr)
+	if !p.unhealthyVFs[vfPCIAddr].Equal(mark.drainedUntil) {
+		return
+	}
+	if time.Now().Before(mark.unhealthyUntil) {
+		p.unhealthyVFs[vfPCIAddr] = mark.unhealthyUntil
+	} else {
+		delete(p.unhealthyVFs, vfPCIAddr)
+	}
+}
+
+func (p *Pool) publish(vfPCIAddr, tokenID, change string) {
+	if p.bus == nil {
+		return
+	}
+	p.bus.Publish(EventTopic, &VFEvent{
+		VFPCIAddress: vfPCIAddr,
+		TokenID:      tokenID,
+		Change:       change,
+	})
+}
+
+// Stats are the Pool VF counts
+type Stats struct {
+	PhysicalFunctions map[string]*PFStats `json:"physicalFunctions"` // PhysicalFunctions[pfPCIAddr] -> *PFStats
+	SelectFailures    uint64              `json:"selectFailures"`
+}
+
+// PFStats are the physical function VF counts
+type PFStats struct {
+	VFs          int `json:"vfs"`
+	FreeVFs      int `json:"freeVFs"`
+	UnhealthyVFs int `json:"unhealthyVFs"`
+}
+
+// Stats returns the current VF counts by the PF PCI addresses and the total number of the failed VF selections
+func (p *Pool) Stats() *Stats {
+	stats := &Stats{
+		PhysicalFunctions: map[string]*PFStats{},
+		SelectFailures:    p.selectFailures,
+	}
+	for pfPCIAddr, pf := range p.physicalFunctions {
+		pfStats := &PFStats{
+			FreeVFs: pf.freeVFsCount,
+		}
+		for _, vfs := range pf.virtualFunctions {
+			for _, vf := range vfs {
+				pfStats.VFs++
+				if p.isUnhealthy(vf.pciAddr) {
+					pfStats.UnhealthyVFs++
+				}
+			}
+		}
+		stats.PhysicalFunctions[pfPCIAddr] = pfStats
+	}
+	return stats
+}
+
+// ReserveWarmGroups reserves the free IOMMU groups to be kept bound to the vfio-pci driver, so the VFIO selections
+// prefer them and don't need the driver rebinding: for each capability -> count item up to count groups of the PFs
+// having the capability are reserved. Returns the newly reserved groups, the caller should bind them to the vfio-pci
+// driver.
+func (p *Pool) ReserveWarmGroups(warmVFIO map[string]uint) []uint {
+	var capabilities []string
+	for capability := range warmVFIO {
+		capabilities = append(capabilities, capability)
+	}
+	sort.Strings(capabilities)
+
+	var reserved []uint
+	for _, capability := range capabilities {
+		groups := p.capabilityGroups(capability)
+
+		var count uint
+		for _, iommuGroup := range groups {
+			if _, ok := p.warmGroups[iommuGroup]; ok {
+				count++
+			}
+		}
+		for _, iommuGroup := range groups {
+			if count >= warmVFIO[capability] {
+				break
+			}
+			if _, ok := p.warmGroups[iommuGroup]; ok || !p.isFreeGroup(iommuGroup) {
+				continue
+			}
+			p.warmGroups[iommuGroup] = struct{}{}
+			reserved = append(reserved, iommuGroup)
+			count++
+		}
+	}
+	return reserved
+}
+
+func (p *Pool) capabilityGroups(capability string) []uint {
+	groups := map[uint]struct{}{}
+	for _, pf := range p.physicalFunctions {
+		if !containsAll(pf.capabilities, []string{capability}) {
+			continue
+		}
+		for iommuGroup := range pf.virtualFunctions {
+			groups[iommuGroup] = struct{}{}
+		}
+	}
+
+	var sorted []uint
+	for iommuGroup := range groups {
+		sorted = append(sorted, iommuGroup)
+	}
+	sort.Slice(sorted, func(i, k int) bool { return sorted[i] < sorted[k] })
+	return sorted
+}
+
+func (p *Pool) isFreeGroup(iommuGroup uint) bool {
+	if p.iommuGroups[iommuGroup] != sriov.NoDriver {
+		return false
+	}
+	for _, pf := range p.physicalFunctions {
+		for _, vf := range pf.virtualFunctions[iommuGroup] {
+			if vf.tokenID != "" {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,7 @@ import (
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
 )
 
 const (
@@ -36,10 +38,12 @@ const (
 	serviceDomain2  = "service.domain.2"
 	capabilityIntel = "intel"
 	capability10G   = "10G"
+	capability20G   = "20G"
 	vf11PciAddr     = "0000:01:00.1"
 	vf21PciAddr     = "0000:02:00.1"
 	vf22PciAddr     = "0000:02:00.2"
 	vf31PciAddr     = "0000:03:00.1"
+	vf32PciAddr     = "0000:03:00.2"
 )
 
 func TestPool_Select_Selected(t *testing.T) {
@@ -132,6 +136,185 @@ func TestPool_Select_FreeVFsCount(t *testing.T) {
 	assert.Equal(t, vf31PciAddr, vfPCIAddr)
 }
 
+func TestPool_SelectWithHints(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain2, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	hints := sriov.NewSelectionHints()
+	hints.Capabilities = []string{capability10G}
+
+	vfPCIAddr, err := p.SelectWithHints("1", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf21PciAddr, vfPCIAddr)
+
+	// No matching VFs, hints should be ignored.
+
+	hints.Capabilities = []string{"100G"}
+
+	vfPCIAddr, err = p.SelectWithHints("2", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf31PciAddr, vfPCIAddr)
+}
+
+func TestPool_SelectWithHints_PhysicalNetwork(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain2, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+			"3": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+	cfg.PhysicalFunctions["0000:02:00.0"].PhysicalNetwork = "physnet-a"
+	cfg.PhysicalFunctions["0000:03:00.0"].PhysicalNetwork = "physnet-b"
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	hints := sriov.NewSelectionHints()
+	hints.PhysicalNetwork = "physnet-a"
+
+	vfPCIAddr, err := p.SelectWithHints("1", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf21PciAddr, vfPCIAddr)
+
+	// Unlike the other hints, physical network is never relaxed.
+
+	hints.PhysicalNetwork = "physnet-c"
+
+	_, err = p.SelectWithHints("2", sriov.VFIOPCIDriver, hints)
+	require.ErrorIs(t, err, resource.ErrNoFreeVF)
+
+	hints.PhysicalNetwork = "physnet-b"
+	hints.Capabilities = []string{capability10G}
+
+	vfPCIAddr, err = p.SelectWithHints("3", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf31PciAddr, vfPCIAddr)
+}
+
+func TestPool_SelectWithHints_PreferredPFs(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain2, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	hints := sriov.NewSelectionHints()
+	hints.PreferredPFs = []string{"0000:03:00.0"}
+	hints.Capabilities = []string{capability10G}
+
+	// No VFs on the preferred PFs match the capabilities, preferred PFs are relaxed first.
+
+	vfPCIAddr, err := p.SelectWithHints("1", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf21PciAddr, vfPCIAddr)
+
+	hints.Capabilities = nil
+
+	vfPCIAddr, err = p.SelectWithHints("2", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf31PciAddr, vfPCIAddr)
+}
+
+func TestPool_SelectWithHints_FallbackNUMANodes(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain2, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+	numaNode0, numaNode2 := 0, 2
+	cfg.PhysicalFunctions["0000:02:00.0"].NUMANode = &numaNode0
+	cfg.PhysicalFunctions["0000:03:00.0"].NUMANode = &numaNode2
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	hints := sriov.NewSelectionHints()
+	hints.NUMANode = 1
+	hints.FallbackNUMANodes = []int{2, 0}
+
+	// No VFs on the NUMA node, the fallback NUMA nodes are tried in order.
+
+	vfPCIAddr, err := p.SelectWithHints("1", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf31PciAddr, vfPCIAddr)
+
+	hints.FallbackNUMANodes = []int{0, 2}
+
+	vfPCIAddr, err = p.SelectWithHints("2", sriov.VFIOPCIDriver, hints)
+	assert.Nil(t, err)
+	assert.Equal(t, vf21PciAddr, vfPCIAddr)
+}
+
+func TestPool_SelectExcludingPFs(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain2, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	vfPCIAddr, err := p.Select("1", sriov.VFIOPCIDriver)
+	assert.Nil(t, err)
+	assert.Equal(t, vf31PciAddr, vfPCIAddr)
+
+	vfPCIAddr, err = p.SelectExcludingPFs("2", sriov.VFIOPCIDriver, []string{"0000:03:00.0"})
+	assert.Nil(t, err)
+	assert.Equal(t, vf21PciAddr, vfPCIAddr)
+}
+
+func TestPool_ReserveWarmGroups(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain2, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	warmVFIO := map[string]uint{capability20G: 1}
+	require.Equal(t, []uint{1}, p.ReserveWarmGroups(warmVFIO))
+	require.Empty(t, p.ReserveWarmGroups(warmVFIO))
+
+	// kernel driver should leave the warm IOMMU group to the VFIO
+	vfPCIAddr, err := p.Select("1", sriov.KernelDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf22PciAddr, vfPCIAddr)
+
+	vfPCIAddr, err = p.Select("2", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf31PciAddr, vfPCIAddr)
+}
+
 func TestPool_Free(t *testing.T) {
 	tokenPool := &tokenPoolStub{
 		tokens: map[string]string{
@@ -156,6 +339,164 @@ func TestPool_Free(t *testing.T) {
 	assert.Equal(t, vf11PciAddr, vfPCIAddr)
 }
 
+func TestPool_Rebind(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain1, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	vfPCIAddr, err := p.Select("1", sriov.KernelDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf11PciAddr, vfPCIAddr)
+
+	_, err = p.Rebind(vf11PciAddr, "2", sriov.VFIOPCIDriver)
+	require.Error(t, err)
+
+	prevDriverType, err := p.Rebind(vf11PciAddr, "1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, sriov.KernelDriver, prevDriverType)
+
+	// Should be the same VF for the new driver.
+
+	vfPCIAddr, err = p.Select("1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf11PciAddr, vfPCIAddr)
+
+	// Should fail for the IOMMU group shared with the other selected VF.
+
+	vfPCIAddr, err = p.Select("2", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf31PciAddr, vfPCIAddr)
+
+	_, err = p.Rebind(vf11PciAddr, "1", sriov.KernelDriver)
+	require.Error(t, err)
+}
+
+func TestPool_MarkUnhealthy(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain1, capabilityIntel),
+			"2": path.Join(serviceDomain2, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	require.Error(t, p.MarkUnhealthy("0000:00:00.0", time.Hour))
+
+	// the only VF for the token is unhealthy
+	require.NoError(t, p.MarkUnhealthy(vf11PciAddr, time.Hour))
+	_, err = p.Select("1", sriov.VFIOPCIDriver)
+	require.ErrorIs(t, err, resource.ErrNoFreeVF)
+
+	const unhealthyDuration = 10 * time.Millisecond
+	require.NoError(t, p.MarkUnhealthy(vf31PciAddr, unhealthyDuration))
+
+	vfPCIAddr, err := p.Select("2", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf32PciAddr, vfPCIAddr)
+	require.NoError(t, p.Free(vfPCIAddr))
+
+	time.Sleep(unhealthyDuration)
+
+	vfPCIAddr, err = p.Select("2", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf31PciAddr, vfPCIAddr)
+}
+
+func TestPool_DrainPF(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain1, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	_, err = p.DrainPF("0000:00:00.0", time.Hour)
+	require.Error(t, err)
+
+	vfPCIAddr, err := p.Select("1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+
+	assignments, err := p.DrainPF("0000:01:00.0", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []*resource.Assignment{
+		{VFPCIAddress: vf11PciAddr, TokenID: "1", DriverType: sriov.VFIOPCIDriver},
+	}, assignments)
+
+	require.NoError(t, p.Free(vfPCIAddr))
+	_, err = p.Select("1", sriov.VFIOPCIDriver)
+	require.ErrorIs(t, err, resource.ErrNoFreeVF)
+
+	assignments, err = p.DrainPF("0000:01:00.0", 0)
+	require.NoError(t, err)
+	require.Empty(t, assignments)
+
+	vfPCIAddr, err = p.Select("1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vf11PciAddr, vfPCIAddr)
+	require.NoError(t, p.Free(vfPCIAddr))
+
+	// ending the draining keeps the VFs marked unhealthy outside of it
+
+	require.NoError(t, p.MarkUnhealthy(vf11PciAddr, time.Hour))
+
+	for _, duration := range []time.Duration{time.Minute, 2 * time.Hour} {
+		_, err = p.DrainPF("0000:01:00.0", duration)
+		require.NoError(t, err)
+		_, err = p.DrainPF("0000:01:00.0", 0)
+		require.NoError(t, err)
+
+		_, err = p.Select("1", sriov.VFIOPCIDriver)
+		require.ErrorIs(t, err, resource.ErrNoFreeVF)
+	}
+}
+
+func TestPool_EventBus(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain1, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	bus := eventbus.New()
+	var events []*resource.VFEvent
+	bus.Subscribe(func(event *eventbus.Event) {
+		events = append(events, event.Payload.(*resource.VFEvent))
+	}, resource.EventTopic)
+
+	p := resource.NewPool(tokenPool, cfg, resource.WithEventBus(bus))
+
+	vfPCIAddr, err := p.Select("1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.NoError(t, p.Free(vfPCIAddr))
+	require.NoError(t, p.MarkUnhealthy(vfPCIAddr, time.Hour))
+
+	require.Equal(t, []*resource.VFEvent{
+		{VFPCIAddress: vf11PciAddr, TokenID: "1", Change: resource.VFSelected},
+		{VFPCIAddress: vf11PciAddr, TokenID: "1", Change: resource.VFFreed},
+		{VFPCIAddress: vf11PciAddr, Change: resource.VFUnhealthy},
+	}, events)
+	require.Equal(t, "VF 0000:01:00.1 selected for the token 1", events[0].String())
+}
+
 type tokenPoolStub struct {
 	tokens map[string]string
 }
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// State is a persistable state of the Pool VF assignments
+type State struct {
+	Assignments []*Assignment `json:"assignments"`
+}
+
+// Assignment is a VF selected for the token ID
+type Assignment struct {
+	VFPCIAddress string           `json:"vfPCIAddress"`
+	TokenID      string           `json:"tokenID"`
+	DriverType   sriov.DriverType `json:"driverType"`
+}
+
+// GetAssignments returns the state assignments, nil state has no assignments
+func (s *State) GetAssignments() []*Assignment {
+	if s == nil {
+		return nil
+	}
+	return s.Assignments
+}
+
+// State returns the current VF assignments sorted by the VF PCI address
+func (p *Pool) State() *State {
+	state := &State{
+		Assignments: []*Assignment{},
+	}
+	for tokenID, vf := range p.tokens {
+		state.Assignments = append(state.Assignments, &Assignment{
+			VFPCIAddress: vf.pciAddr,
+			TokenID:      tokenID,
+			DriverType:   p.iommuGroups[vf.iommuGroup],
+		})
+	}
+	sort.Slice(state.Assignments, func(i, k int) bool {
+		return state.Assignments[i].VFPCIAddress < state.Assignments[k].VFPCIAddress
+	})
+	return state
+}
+
+// Restore marks the state assigned VFs as "in-use", already restored assignments are skipped. VFs selected in the pool
+// but not assigned to the same token by the state are freed first. All the VFs and assignments are tried, the first
+// failure is returned.
+func (p *Pool) Restore(state *State) (err error) {
+	assigned := map[string]string{}
+	for _, a := range state.GetAssignments() {
+		assigned[a.TokenID] = a.VFPCIAddress
+	}
+
+	var stale []string
+	for tokenID, vf := range p.tokens {
+		if vfPCIAddr, ok := assigned[tokenID]; !ok || vfPCIAddr != vf.pciAddr {
+			stale = append(stale, vf.pciAddr)
+		}
+	}
+	sort.Strings(stale)
+	for _, vfPCIAddr := range stale {
+		if freeErr := p.Free(vfPCIAddr); freeErr != nil && err == nil {
+			err = errors.Wrapf(freeErr, "failed to free VF missing from the state: %v", vfPCIAddr)
+		}
+	}
+
+	for _, a := range state.GetAssignments() {
+		if restoreErr := p.restore(a); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}
+	return err
+}
+
+func (p *Pool) restore(a *Assignment) error {
+	vf, ok := p.virtualFunctions[a.VFPCIAddress]
+	if !ok {
+		return errors.Errorf("VF doesn't exist: %v", a.VFPCIAddress)
+	}
+
+	switch {
+	case vf.tokenID == a.TokenID:
+		return nil
+	case vf.tokenID != "":
+		return errors.Errorf("VF is already selected for another token: %v", a.VFPCIAddress)
+	}
+	if _, ok := p.tokens[a.TokenID]; ok {
+		return errors.Errorf("token is already used for another VF: %v", a.TokenID)
+	}
+	if ig := p.iommuGroups[vf.iommuGroup]; ig != sriov.NoDriver && ig != a.DriverType {
+		return errors.Errorf("VF IOMMU group is already bound to another driver: %v", ig)
+	}
+
+	if err := p.selectVF(vf, a.TokenID, a.DriverType); err != nil {
+		return errors.Wrapf(err, "failed to restore VF: %v", a.VFPCIAddress)
+	}
+	return nil
+}
+
+// ReadStateFile reads the state from the JSON file
+func ReadStateFile(path string) (*State, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state file: %s", path)
+	}
+
+	state := new(State)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal state file: %s", path)
+	}
+	return state, nil
+}
+
+// WriteStateFile atomically writes the state into the JSON file
+func WriteStateFile(path string, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal state")
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary state file for: %s", path)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "failed to write temporary state file: %s", tmpFile.Name())
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close temporary state file: %s", tmpFile.Name())
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to replace state file: %s", path)
+	}
+	return nil
+}
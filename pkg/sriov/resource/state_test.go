@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+func TestPool_State_Restore(t *testing.T) {
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]string{
+			"1": path.Join(serviceDomain1, capabilityIntel),
+			"2": path.Join(serviceDomain1, capabilityIntel),
+		},
+	}
+
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := resource.NewPool(tokenPool, cfg)
+
+	vfPCIAddr, err := p.Select("1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, resource.WriteStateFile(statePath, p.State()))
+
+	state, err := resource.ReadStateFile(statePath)
+	require.NoError(t, err)
+	require.Equal(t, []*resource.Assignment{
+		{VFPCIAddress: vfPCIAddr, TokenID: "1", DriverType: sriov.VFIOPCIDriver},
+	}, state.Assignments)
+
+	restored := resource.NewPool(tokenPool, cfg)
+	require.NoError(t, restored.Restore(state))
+	require.NoError(t, restored.Restore(state))
+	require.Equal(t, p.State(), restored.State())
+
+	// The restored VF should be selected again for the same token.
+	restoredVFPCIAddr, err := restored.Select("1", sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, restoredVFPCIAddr)
+
+	// The VF selected for the token missing from the state should be freed and restored for the new token.
+	require.NoError(t, restored.Restore(&resource.State{
+		Assignments: []*resource.Assignment{
+			{VFPCIAddress: vfPCIAddr, TokenID: "2", DriverType: sriov.VFIOPCIDriver},
+		},
+	}))
+	require.Equal(t, []*resource.Assignment{
+		{VFPCIAddress: vfPCIAddr, TokenID: "2", DriverType: sriov.VFIOPCIDriver},
+	}, restored.State().Assignments)
+
+	// The empty state should free all the selected VFs.
+	require.NoError(t, restored.Restore(new(resource.State)))
+	require.Empty(t, restored.State().Assignments)
+
+	require.Error(t, restored.Restore(&resource.State{
+		Assignments: []*resource.Assignment{
+			{VFPCIAddress: vfPCIAddr, TokenID: "3", DriverType: sriov.VFIOPCIDriver},
+		},
+	}))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriov
+
+// SelectionHints are VF selection preferences, VFs matching the hints are preferred, but if there are no such free
+// VFs, the hints are relaxed one at a time in the PreferredPFs, NUMANode, Capabilities order, and if there are still
+// no such free VFs, any suitable VF is selected
+type SelectionHints struct {
+	// NUMANode is a preferred PF NUMA node, -1 means any
+	NUMANode int
+	// FallbackNUMANodes are PF NUMA nodes tried in order if there are no matching VFs on the NUMANode, e.g. the nodes
+	// sorted by the distance from it
+	FallbackNUMANodes []int
+	// Capabilities are PF capabilities the VF is preferred to have all of
+	Capabilities []string
+	// PhysicalNetwork is a required PF physical network, unlike the other hints it is never relaxed: if there are no
+	// free VFs on the PFs of the physical network, no VF is selected
+	PhysicalNetwork string
+	// PreferredPFs are PCI addresses of the PFs the VF is preferred to be on, e.g. the PFs nearest in the PCI hierarchy
+	// to the other client devices
+	PreferredPFs []string
+}
+
+// NewSelectionHints returns empty SelectionHints
+func NewSelectionHints() *SelectionHints {
+	return &SelectionHints{
+		NUMANode: -1,
+	}
+}
+
+// IsEmpty returns if there are no preferences in h
+func (h *SelectionHints) IsEmpty() bool {
+	return h == nil || (h.NUMANode < 0 && len(h.Capabilities) == 0 && h.PhysicalNetwork == "" && len(h.PreferredPFs) == 0)
+}
@@ -17,6 +17,12 @@
 // Package sriovtest provides utils for SR-IOV testing
 package sriovtest
 
+import (
+	"bytes"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pcifunction"
+)
+
 // PCIPhysicalFunction is a test data class for pcifunction.PhysicalFunction
 type PCIPhysicalFunction struct {
 	Vfs []*PCIFunction `yaml:"vfs"`
@@ -30,6 +36,18 @@ type PCIFunction struct {
 	IfName     string `yaml:"ifName"`
 	IOMMUGroup uint   `yaml:"iommuGroup"`
 	Driver     string `yaml:"driver"`
+	// Resets is the number of the function level resets triggered
+	Resets int `yaml:"-"`
+	// ConfigSpace is the function config space read by the responsiveness check, nil config space reads as a valid one
+	ConfigSpace []byte `yaml:"-"`
+}
+
+// testConfigSpace is a config space starting with the Intel vendor ID
+var testConfigSpace = []byte{0x86, 0x80}
+
+// UnresponsiveConfigSpace returns a config space read from the device not responding on the bus
+func UnresponsiveConfigSpace() []byte {
+	return []byte{0xff, 0xff, 0xff, 0xff}
 }
 
 // GetPCIAddress returns f.Addr
@@ -57,3 +75,18 @@ func (f *PCIFunction) BindDriver(driver string) error {
 	f.Driver = driver
 	return nil
 }
+
+// Reset increments f.Resets
+func (f *PCIFunction) Reset() error {
+	f.Resets++
+	return nil
+}
+
+// CheckResponsive checks f.ConfigSpace the same way as pcifunction.Function checks the device config space
+func (f *PCIFunction) CheckResponsive() error {
+	configSpace := f.ConfigSpace
+	if configSpace == nil {
+		configSpace = testConfigSpace
+	}
+	return pcifunction.CheckConfigSpace(f.Addr, bytes.NewReader(configSpace))
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriovtest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+)
+
+// NewPhysicalFunctions returns fake physical functions with virtual functions for all the PFs in cfg, so the SR-IOV
+// chain can be exercised without the SR-IOV hardware
+func NewPhysicalFunctions(cfg *config.Config) map[string]*PCIPhysicalFunction {
+	var pfPCIAddrs []string
+	for pfPCIAddr := range cfg.PhysicalFunctions {
+		pfPCIAddrs = append(pfPCIAddrs, pfPCIAddr)
+	}
+	sort.Strings(pfPCIAddrs)
+
+	physicalFunctions := map[string]*PCIPhysicalFunction{}
+	for i, pfPCIAddr := range pfPCIAddrs {
+		pfCfg := cfg.PhysicalFunctions[pfPCIAddr]
+
+		pf := &PCIPhysicalFunction{
+			PCIFunction: PCIFunction{
+				Addr:   pfPCIAddr,
+				IfName: fmt.Sprintf("fake-pf%d", i),
+				Driver: pfCfg.PFKernelDriver,
+			},
+		}
+		for k, vfCfg := range pfCfg.VirtualFunctions {
+			pf.Vfs = append(pf.Vfs, &PCIFunction{
+				Addr:       vfCfg.Address,
+				IfName:     fmt.Sprintf("fake-pf%d-vf%d", i, k),
+				IOMMUGroup: vfCfg.IOMMUGroup,
+				Driver:     pfCfg.VFKernelDriver,
+			})
+		}
+		physicalFunctions[pfPCIAddr] = pf
+	}
+	return physicalFunctions
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	sriovtokens "github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+// Option is an option for the Pool
+type Option func(p *Pool)
+
+// WithSigner sets signer for the token IDs, Pool generates signed token IDs and restores only the verified ones
+func WithSigner(signer *sriovtokens.Signer) Option {
+	return func(p *Pool) {
+		p.signer = signer
+	}
+}
+
+// WithAuditLog sets audit log recording all token allocations, uses and frees
+// NOTE: audit log write errors don't fail Pool actions
+func WithAuditLog(auditLog sriovtokens.AuditLog) Option {
+	return func(p *Pool) {
+		p.auditLog = auditLog
+	}
+}
+
+// WithEventRecorder sets recorder for the token state corrections made by Sync
+func WithEventRecorder(eventRecorder EventRecorder) Option {
+	return func(p *Pool) {
+		p.eventRecorder = eventRecorder
+	}
+}
+
+// WithEventBus sets the event bus the tokens changes are published to with EventTopic, so it can be shared with the
+// other publishers. By default Pool uses its own event bus.
+func WithEventBus(bus *eventbus.Bus) Option {
+	return func(p *Pool) {
+		p.bus = bus
+	}
+}
@@ -21,11 +21,14 @@ package token
 
 import (
 	"path"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
 	sriovtokens "github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
@@ -36,12 +39,27 @@ const (
 	closed
 )
 
+// SyncCorrectionReason is the reason of the event recorded for the token state corrected by Sync
+const SyncCorrectionReason = "TokenStateCorrected"
+
+// EventTopic is the event bus topic of the tokens state changes to/from "closed" and the tokens health changes, the
+// events have no payload
+const EventTopic eventbus.Topic = "tokens"
+
+// EventRecorder records the token state corrections, e.g. as the forwarder pod Kubernetes Events
+type EventRecorder interface {
+	Normalf(reason, format string, args ...interface{})
+}
+
 // Pool manages forwarder SR-IOV resource tokens
 type Pool struct {
 	tokens        map[string]*token   // tokens[id] -> *token
 	tokensByNames map[string][]*token // tokensByNames[name] -> []*token
 	closedTokens  map[string][]*token // closedTokens[id] -> []*token
-	listeners     []func()
+	bus           *eventbus.Bus
+	signer        *sriovtokens.Signer
+	auditLog      sriovtokens.AuditLog
+	eventRecorder EventRecorder
 	lock          sync.Mutex
 	dirty         bool
 }
@@ -61,28 +79,42 @@ func (ts state) String() string {
 }
 
 type token struct {
-	id    string
-	name  string
-	state state
+	id            string
+	name          string
+	state         state
+	numaNode      int
+	pfPCIAddr     string
+	unhealthy     bool
+	correlationID string
 }
 
 // NewPool returns a new Pool
-func NewPool(cfg *config.Config) *Pool {
+func NewPool(cfg *config.Config, options ...Option) *Pool {
 	p := &Pool{
 		tokens:        map[string]*token{},
 		tokensByNames: map[string][]*token{},
 		closedTokens:  map[string][]*token{},
+		bus:           eventbus.New(),
+	}
+	for _, option := range options {
+		option(p)
 	}
 
-	for _, pfCfg := range cfg.PhysicalFunctions {
+	for pfPCIAddr, pfCfg := range cfg.PhysicalFunctions {
+		vfsCount := len(pfCfg.EnabledVirtualFunctions())
 		for _, serviceDomain := range pfCfg.ServiceDomains {
 			for _, capability := range pfCfg.Capabilities {
 				name := path.Join(serviceDomain, capability)
-				for i := 0; i < len(pfCfg.VirtualFunctions); i++ {
+				for i := 0; i < vfsCount; i++ {
+					if limit, ok := cfg.TokenLimit(name); ok && uint(len(p.tokensByNames[name])) >= limit {
+						break
+					}
 					tok := &token{
-						id:    sriovtokens.NewTokenID(),
-						name:  name,
-						state: free,
+						id:        p.newTokenID(),
+						name:      name,
+						state:     free,
+						numaNode:  pfCfg.GetNUMANode(),
+						pfPCIAddr: pfPCIAddr,
 					}
 					p.tokens[tok.id] = tok
 					p.tokensByNames[tok.name] = append(p.tokensByNames[tok.name], tok)
@@ -94,6 +126,13 @@ func NewPool(cfg *config.Config) *Pool {
 	return p
 }
 
+func (p *Pool) newTokenID() string {
+	if p.signer != nil {
+		return p.signer.NewTokenID()
+	}
+	return sriovtokens.NewTokenID()
+}
+
 // Restore replaces part of existing tokens with given tokens and set them into the allocated state
 // NOTE: it can be called only on untouched Pool, any actions will disable Restore
 func (p *Pool) Restore(tokens map[string][]string) error {
@@ -110,6 +149,9 @@ func (p *Pool) Restore(tokens map[string][]string) error {
 		if !ok {
 			continue
 		}
+		if p.signer != nil {
+			ids = p.verifiedIDs(ids)
+		}
 
 		for i := 0; i < len(ids) && i < len(toks); i++ {
 			tok := toks[i]
@@ -125,15 +167,69 @@ func (p *Pool) Restore(tokens map[string][]string) error {
 	return nil
 }
 
-// AddListener adds a new listener that fires on tokens state change to/from "closed"
-func (p *Pool) AddListener(listener func()) {
+// Sync sets tokens state according to the given actually allocated tokens, can be called at any moment:
+// * `free` -> `allocated` (token is allocated, but we have missed Allocate)
+// * `allocated` -> `free` (token is not allocated anymore, but we have missed Free)
+// * `inUse`, `closed` -> (nothing to do here, tokens are managed by the Use, StopUsing)
+func (p *Pool) Sync(tokens map[string][]string) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	p.listeners = append(p.listeners, listener)
+	p.dirty = true
+
+	allocatedIDs := map[string]struct{}{}
+	for _, ids := range tokens {
+		for _, id := range ids {
+			allocatedIDs[id] = struct{}{}
+		}
+	}
+
+	for id, tok := range p.tokens {
+		_, isAllocated := allocatedIDs[id]
+		var corrected state
+		switch {
+		case tok.state == free && isAllocated:
+			corrected = allocated
+		case tok.state == allocated && !isAllocated:
+			corrected = free
+		default:
+			continue
+		}
+		if p.eventRecorder != nil {
+			p.eventRecorder.Normalf(SyncCorrectionReason, "token %s:%s state corrected: %v -> %v", tok.name, tok.id, tok.state, corrected)
+		}
+		tok.state = corrected
+	}
+
+	return nil
+}
+
+func (p *Pool) verifiedIDs(ids []string) []string {
+	var verifiedIDs []string
+	for _, id := range ids {
+		if p.signer.Verify(id) == nil {
+			verifiedIDs = append(verifiedIDs, id)
+		}
+	}
+	return verifiedIDs
+}
+
+// AddListener adds a new listener that fires on tokens state change to/from "closed" and on tokens health change, it
+// is subscribed to the Pool event bus EventTopic
+func (p *Pool) AddListener(listener func()) {
+	p.bus.Subscribe(func(*eventbus.Event) {
+		listener()
+	}, EventTopic)
+}
+
+// publish publishes the tokens change, it is called under the lock, so the event is published asynchronously for the
+// handlers to be able to call the Pool
+func (p *Pool) publish() {
+	go p.bus.Publish(EventTopic, nil)
 }
 
-// Tokens returns a map of tokens by names marked as available/not available
+// Tokens returns a map of tokens by names marked as available/not available, closed and unhealthy tokens are not
+// available
 func (p *Pool) Tokens() map[string]map[string]bool {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -144,12 +240,90 @@ func (p *Pool) Tokens() map[string]map[string]bool {
 	for name, toks := range p.tokensByNames {
 		tokens[name] = map[string]bool{}
 		for _, tok := range toks {
-			tokens[name][tok.id] = tok.state != closed
+			tokens[name][tok.id] = tok.state != closed && !tok.unhealthy
 		}
 	}
 	return tokens
 }
 
+// Stats returns the numbers of tokens by names and states ("free", "allocated", "inUse", "closed"), unhealthy tokens
+// are counted under the "unhealthy" state
+func (p *Pool) Stats() map[string]map[string]int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	stats := map[string]map[string]int{}
+	for name, toks := range p.tokensByNames {
+		stats[name] = map[string]int{}
+		for _, tok := range toks {
+			stats[name][tok.state.String()]++
+			if tok.unhealthy {
+				stats[name]["unhealthy"]++
+			}
+		}
+	}
+	return stats
+}
+
+// FreeCounts returns the numbers of the free healthy tokens by names
+func (p *Pool) FreeCounts() map[string]int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	counts := map[string]int{}
+	for name, toks := range p.tokensByNames {
+		counts[name] = 0
+		for _, tok := range toks {
+			if tok.state == free && !tok.unhealthy {
+				counts[name]++
+			}
+		}
+	}
+	return counts
+}
+
+// SetHealth marks healthyVFs tokens of every name created for the PF selected by the given PCI address as healthy
+// and the rest of them as unhealthy. The "inUse" tokens are kept healthy first, then the "allocated" ones, so the
+// free tokens are marked unhealthy before the tokens used by the pods.
+func (p *Pool) SetHealth(pfPCIAddr string, healthyVFs int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var isChanged bool
+	for _, toks := range p.tokensByNames {
+		var pfToks []*token
+		for _, tok := range toks {
+			if tok.pfPCIAddr == pfPCIAddr {
+				pfToks = append(pfToks, tok)
+			}
+		}
+		sort.SliceStable(pfToks, func(i, k int) bool {
+			return healthPriority(pfToks[i].state) > healthPriority(pfToks[k].state)
+		})
+		for i, tok := range pfToks {
+			unhealthy := i >= healthyVFs
+			isChanged = isChanged || tok.unhealthy != unhealthy
+			tok.unhealthy = unhealthy
+		}
+	}
+
+	if isChanged {
+		p.publish()
+	}
+}
+
+// healthPriority returns the priority of the token in the given state to be kept healthy
+func healthPriority(ts state) int {
+	switch ts {
+	case inUse:
+		return 2
+	case allocated:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // Find returns a token name selected by the given ID
 func (p *Pool) Find(id string) (string, error) {
 	p.lock.Lock()
@@ -164,6 +338,21 @@ func (p *Pool) Find(id string) (string, error) {
 	return tok.name, nil
 }
 
+// NUMANode returns NUMA node of the physical function the token selected by the given ID has been created for, if
+// NUMA node is unknown returns -1
+func (p *Pool) NUMANode(id string) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.dirty = true
+
+	tok, err := p.find(id)
+	if err != nil {
+		return -1, err
+	}
+	return tok.numaNode, nil
+}
+
 func (p *Pool) find(id string) (*token, error) {
 	if token, ok := p.tokens[id]; ok {
 		return token, nil
@@ -177,6 +366,13 @@ func (p *Pool) find(id string) (*token, error) {
 // * `inUse` -stopUsing-> `allocated` (we have not called StopUsing, Free, but Device Plugin is already using the token)
 // * `closed` -XXX-> `error`
 func (p *Pool) Allocate(id string) error {
+	_, err := p.AllocateOwned(id)
+	return err
+}
+
+// AllocateOwned marks a token selected by the given ID as "allocated" the same way as Allocate and returns true if the
+// token has been "free", so the caller owns the allocation and can roll it back with Free
+func (p *Pool) AllocateOwned(id string) (owned bool, err error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -184,18 +380,42 @@ func (p *Pool) Allocate(id string) error {
 
 	tok, err := p.find(id)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	switch tok.state {
 	case inUse:
-		return p.stopUsing(id)
+		if err := p.stopUsing(id); err != nil {
+			return false, err
+		}
 	case closed:
-		return errors.Errorf("token is closed: %s:%s", tok.name, tok.id)
+		return false, errors.Errorf("token is closed: %s:%s", tok.name, tok.id)
 	}
+	owned = tok.state == free
 	tok.state = allocated
+	p.audit(sriovtokens.AuditAllocate, tok)
 
-	return nil
+	return owned, nil
+}
+
+// AllocateFree marks any healthy "free" token with the given name as "allocated" and returns its ID, it is used instead
+// of Allocate if there is no Device Plugin allocating the tokens
+func (p *Pool) AllocateFree(name string) (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.dirty = true
+
+	for _, tok := range p.tokensByNames[name] {
+		if tok.state != free || tok.unhealthy {
+			continue
+		}
+		tok.state = allocated
+		p.audit(sriovtokens.AuditAllocate, tok)
+
+		return tok.id, nil
+	}
+	return "", errors.Errorf("no free token: %s", name)
 }
 
 // Free marks a token selected by the given ID as "free":
@@ -221,6 +441,8 @@ func (p *Pool) Free(id string) error {
 		return nil
 	}
 	tok.state = free
+	p.audit(sriovtokens.AuditFree, tok)
+	tok.correlationID = ""
 
 	return nil
 }
@@ -245,6 +467,7 @@ func (p *Pool) Use(id string, names []string) error {
 		return errors.Errorf("token is %v: %s:%s", tok.state, tok.name, tok.id)
 	}
 	tok.state = inUse
+	p.audit(sriovtokens.AuditUse, tok)
 
 	for i := range names {
 		if names[i] == tok.name {
@@ -256,13 +479,12 @@ func (p *Pool) Use(id string, names []string) error {
 			continue
 		}
 		tokToClose.state = closed
+		p.audit(sriovtokens.AuditClose, tokToClose)
 
 		p.closedTokens[tok.id] = append(p.closedTokens[tok.id], tokToClose)
 	}
 
-	for _, listener := range p.listeners {
-		go listener()
-	}
+	p.publish()
 
 	return nil
 }
@@ -305,19 +527,47 @@ func (p *Pool) stopUsing(id string) error {
 		return errors.Errorf("token is not in use: %s:%s - %v", tok.name, tok.id, tok.state)
 	}
 	tok.state = allocated
+	p.audit(sriovtokens.AuditStopUsing, tok)
 
 	for _, t := range p.closedTokens[tok.id] {
 		t.state = free
+		p.audit(sriovtokens.AuditFree, t)
 	}
 	delete(p.closedTokens, tok.id)
 
-	for _, listener := range p.listeners {
-		go listener()
+	p.publish()
+
+	return nil
+}
+
+// SetCorrelationID sets owner ID (pod UID, connection ID, ...) for the token selected by the given ID, it is recorded
+// into the audit log for all following token actions until the token is freed
+func (p *Pool) SetCorrelationID(id, correlationID string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	tok, err := p.find(id)
+	if err != nil {
+		return err
 	}
+	tok.correlationID = correlationID
 
 	return nil
 }
 
+func (p *Pool) audit(action sriovtokens.AuditAction, tok *token) {
+	if p.auditLog == nil {
+		return
+	}
+	_ = p.auditLog.Record(&sriovtokens.AuditRecord{
+		Time:          time.Now(),
+		Action:        action,
+		TokenName:     tok.name,
+		TokenID:       tok.id,
+		CorrelationID: tok.correlationID,
+	})
+}
+
 // ToEnv returns a (name, value) pair to store given tokens into the environment variable
 func (p *Pool) ToEnv(tokenName string, tokenIDs []string) (name, value string) {
 	return sriovtokens.ToEnv(tokenName, tokenIDs)
@@ -20,13 +20,16 @@ package token_test
 
 import (
 	"context"
+	"fmt"
 	"path"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
 	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
 )
 
 const (
@@ -36,6 +39,9 @@ const (
 	capabilityIntel = "intel"
 	capability10G   = "10G"
 	capability20G   = "20G"
+	pf1PciAddr      = "0000:01:00.0"
+	pf2PciAddr      = "0000:02:00.0"
+	vf21PciAddr     = "0000:02:00.1"
 )
 
 func TestPool_Tokens(t *testing.T) {
@@ -53,6 +59,64 @@ func TestPool_Tokens(t *testing.T) {
 	require.Equal(t, 3, countTrue(tokens[path.Join(serviceDomain2, capability20G)]))
 }
 
+func TestPool_Tokens_Excluded(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	cfg.PhysicalFunctions[pf1PciAddr].Disabled = true
+	cfg.PhysicalFunctions[pf2PciAddr].ExcludeVFs = []string{vf21PciAddr}
+
+	p := token.NewPool(cfg)
+
+	tokens := p.Tokens()
+	require.Equal(t, 4, len(tokens))
+	require.Equal(t, 2, countTrue(tokens[path.Join(serviceDomain1, capabilityIntel)]))
+	require.Equal(t, 2, countTrue(tokens[path.Join(serviceDomain1, capability20G)]))
+	require.Equal(t, 2, countTrue(tokens[path.Join(serviceDomain2, capabilityIntel)]))
+	require.Equal(t, 2, countTrue(tokens[path.Join(serviceDomain2, capability20G)]))
+}
+
+func TestPool_Tokens_MaxTokens(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	cfg.MaxTokens = map[string]uint{
+		path.Join(serviceDomain1, capabilityIntel): 2,
+		path.Join(serviceDomain2, capability20G):   0,
+	}
+
+	p := token.NewPool(cfg)
+
+	tokens := p.Tokens()
+	require.Equal(t, 4, len(tokens))
+	require.Equal(t, 2, countTrue(tokens[path.Join(serviceDomain1, capabilityIntel)]))
+	require.Equal(t, 1, countTrue(tokens[path.Join(serviceDomain1, capability10G)]))
+	require.Equal(t, 3, countTrue(tokens[path.Join(serviceDomain1, capability20G)]))
+	require.Equal(t, 3, countTrue(tokens[path.Join(serviceDomain2, capabilityIntel)]))
+	require.Equal(t, 0, countTrue(tokens[path.Join(serviceDomain2, capability20G)]))
+}
+
+func TestPool_NUMANode(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	numaNode := 1
+	cfg.PhysicalFunctions[pf2PciAddr].NUMANode = &numaNode
+
+	p := token.NewPool(cfg)
+
+	for id := range p.Tokens()[path.Join(serviceDomain1, capability10G)] {
+		node, err := p.NUMANode(id)
+		require.NoError(t, err)
+		require.Equal(t, -1, node)
+	}
+	for id := range p.Tokens()[path.Join(serviceDomain2, capability20G)] {
+		node, err := p.NUMANode(id)
+		require.NoError(t, err)
+		require.Equal(t, numaNode, node)
+	}
+}
+
 func TestPool_Use(t *testing.T) {
 	cfg, err := config.ReadConfig(context.TODO(), configFileName)
 	require.NoError(t, err)
@@ -91,6 +155,29 @@ func TestPool_Use(t *testing.T) {
 	require.Equal(t, 3, countTrue(tokens[path.Join(serviceDomain2, capability20G)]))
 }
 
+func TestPool_AllocateFree(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := token.NewPool(cfg)
+
+	name := path.Join(serviceDomain1, capability10G)
+
+	id, err := p.AllocateFree(name)
+	require.NoError(t, err)
+	require.NoError(t, p.Use(id, []string{name}))
+
+	_, err = p.AllocateFree(name)
+	require.Error(t, err)
+
+	require.NoError(t, p.StopUsing(id))
+	require.NoError(t, p.Free(id))
+
+	freeID, err := p.AllocateFree(name)
+	require.NoError(t, err)
+	require.Equal(t, id, freeID)
+}
+
 func TestPool_Restore(t *testing.T) {
 	cfg, err := config.ReadConfig(context.TODO(), configFileName)
 	require.NoError(t, err)
@@ -110,6 +197,205 @@ func TestPool_Restore(t *testing.T) {
 	require.Equal(t, tokens, p.Tokens())
 }
 
+func TestPool_Signer(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	signer := tokens.NewSigner([]byte("key"))
+
+	p := token.NewPool(cfg, token.WithSigner(signer))
+	toks := p.Tokens()
+
+	name := path.Join(serviceDomain2, capability20G)
+	var ids []string
+	for id := range toks[name] {
+		require.True(t, tokens.IsTokenID(id))
+		require.NoError(t, signer.Verify(id))
+		ids = append(ids, id)
+	}
+
+	unsignedID := tokens.NewTokenID()
+	foreignID := tokens.NewSigner([]byte("other")).NewTokenID()
+
+	p = token.NewPool(cfg, token.WithSigner(signer))
+	require.NoError(t, p.Restore(map[string][]string{
+		name: {unsignedID, ids[0], foreignID},
+	}))
+
+	_, err = p.Find(ids[0])
+	require.NoError(t, err)
+	_, err = p.Find(unsignedID)
+	require.Error(t, err)
+	_, err = p.Find(foreignID)
+	require.Error(t, err)
+}
+
+func TestPool_Sync(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	eventRecorder := new(eventRecorderStub)
+	p := token.NewPool(cfg, token.WithEventRecorder(eventRecorder))
+
+	name := path.Join(serviceDomain2, capability20G)
+	var ids []string
+	for id := range p.Tokens()[name] {
+		ids = append(ids, id)
+	}
+	require.Len(t, ids, 3)
+
+	require.NoError(t, p.Allocate(ids[0]))
+	require.NoError(t, p.Allocate(ids[1]))
+	require.NoError(t, p.Use(ids[1], nil))
+
+	require.NoError(t, p.Sync(map[string][]string{
+		name: {ids[2]},
+	}))
+	require.ElementsMatch(t, []string{
+		"token " + name + ":" + ids[0] + " state corrected: allocated -> free",
+		"token " + name + ":" + ids[2] + " state corrected: free -> allocated",
+	}, eventRecorder.events)
+
+	// ids[1] is still in use
+	require.Error(t, p.Use(ids[1], nil))
+
+	// ids[0] is freed, ids[2] is allocated, so free ids[0] should be closed first
+	intelName := path.Join(serviceDomain2, capabilityIntel)
+	for id := range p.Tokens()[intelName] {
+		require.NoError(t, p.Use(id, []string{intelName, name}))
+		break
+	}
+	require.Equal(t, map[string]bool{
+		ids[0]: false,
+		ids[1]: true,
+		ids[2]: true,
+	}, p.Tokens()[name])
+}
+
+func TestPool_SetHealth(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := token.NewPool(cfg)
+
+	name := path.Join(serviceDomain2, capability20G)
+	require.Equal(t, 3, countTrue(p.Tokens()[name]))
+
+	p.SetHealth(pf2PciAddr, 1)
+	require.Equal(t, 1, countTrue(p.Tokens()[name]))
+	require.Equal(t, 2, countTrue(p.Tokens()[path.Join(serviceDomain1, capabilityIntel)]))
+
+	p.SetHealth(pf2PciAddr, 3)
+	require.Equal(t, 3, countTrue(p.Tokens()[name]))
+}
+
+func TestPool_SetHealth_MixedStates(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	name := path.Join(serviceDomain2, capability20G)
+	for i := 0; i < 3; i++ {
+		p := token.NewPool(cfg)
+
+		var ids []string
+		for id := range p.Tokens()[name] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		require.Len(t, ids, 3)
+
+		inUseID, allocatedID, freeID := ids[i], ids[(i+1)%3], ids[(i+2)%3]
+		require.NoError(t, p.Allocate(inUseID))
+		require.NoError(t, p.Use(inUseID, nil))
+		require.NoError(t, p.Allocate(allocatedID))
+
+		p.SetHealth(pf2PciAddr, 2)
+		tokens := p.Tokens()[name]
+		require.True(t, tokens[inUseID])
+		require.True(t, tokens[allocatedID])
+		require.False(t, tokens[freeID])
+
+		p.SetHealth(pf2PciAddr, 1)
+		tokens = p.Tokens()[name]
+		require.True(t, tokens[inUseID])
+		require.False(t, tokens[allocatedID])
+		require.False(t, tokens[freeID])
+	}
+}
+
+func TestPool_AllocateOwned(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := token.NewPool(cfg)
+
+	var id string
+	for id = range p.Tokens()[path.Join(serviceDomain1, capability10G)] {
+		break
+	}
+
+	owned, err := p.AllocateOwned(id)
+	require.NoError(t, err)
+	require.True(t, owned)
+
+	owned, err = p.AllocateOwned(id)
+	require.NoError(t, err)
+	require.False(t, owned)
+
+	require.NoError(t, p.Use(id, nil))
+	owned, err = p.AllocateOwned(id)
+	require.NoError(t, err)
+	require.False(t, owned)
+}
+
+func TestPool_FreeCounts(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	p := token.NewPool(cfg)
+
+	name := path.Join(serviceDomain2, capability20G)
+	require.Equal(t, 3, p.FreeCounts()[name])
+
+	id, err := p.AllocateFree(name)
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+	require.Equal(t, 2, p.FreeCounts()[name])
+
+	p.SetHealth(pf2PciAddr, 1)
+	require.Equal(t, 0, p.FreeCounts()[name])
+	require.Equal(t, 1, p.FreeCounts()[path.Join(serviceDomain1, capability10G)])
+}
+
+func TestPool_AuditLog(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	auditLog := new(auditLogStub)
+	p := token.NewPool(cfg, token.WithAuditLog(auditLog))
+
+	name := path.Join(serviceDomain1, capability10G)
+	var id string
+	for id = range p.Tokens()[name] {
+		break
+	}
+
+	require.NoError(t, p.Allocate(id))
+	require.NoError(t, p.SetCorrelationID(id, "conn-1"))
+	require.NoError(t, p.Use(id, nil))
+	require.NoError(t, p.StopUsing(id))
+	require.NoError(t, p.Free(id))
+	require.NoError(t, p.Allocate(id))
+
+	require.Equal(t, []string{
+		"allocate " + id + " ",
+		"use " + id + " conn-1",
+		"stopUsing " + id + " conn-1",
+		"free " + id + " conn-1",
+		"allocate " + id + " ",
+	}, auditLog.records)
+}
+
 func TestPool_ToEnv(t *testing.T) {
 	cfg, err := config.ReadConfig(context.TODO(), configFileName)
 	require.NoError(t, err)
@@ -128,3 +414,20 @@ func countTrue(m map[string]bool) (count int) {
 	}
 	return count
 }
+
+type auditLogStub struct {
+	records []string
+}
+
+func (l *auditLogStub) Record(record *tokens.AuditRecord) error {
+	l.records = append(l.records, string(record.Action)+" "+record.TokenID+" "+record.CorrelationID)
+	return nil
+}
+
+type eventRecorderStub struct {
+	events []string
+}
+
+func (r *eventRecorderStub) Normalf(_, format string, args ...interface{}) {
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriov
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupported is matched with errors.Is by the errors returned by the SDK features on the platforms they are not
+// supported on
+var ErrUnsupported = errors.New("not supported on the platform")
+
+// UnsupportedError is an error returned by the SDK feature stubs built for the platforms other than Linux
+type UnsupportedError struct {
+	// Feature is the unsupported feature, e.g. "VFIO server"
+	Feature string
+	// OS is the running platform operating system, runtime.GOOS
+	OS string
+}
+
+// NewUnsupportedError returns a new UnsupportedError for the feature on the running platform
+func NewUnsupportedError(feature string) *UnsupportedError {
+	return &UnsupportedError{
+		Feature: feature,
+		OS:      runtime.GOOS,
+	}
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s is not supported on %s", e.Feature, e.OS)
+}
+
+// Is returns if target is ErrUnsupported
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vdpa
+
+import "github.com/ljkiraly/sdk-sriov/pkg/sriov"
+
+// Device is a vDPA device
+type Device struct {
+	Name         string           `json:"name"`
+	VFPCIAddress string           `json:"vfPCIAddress"`
+	Driver       sriov.DriverType `json:"driver"`
+	// DevicePath is the vhost-vdpa char device path, set only for the VhostVDPADriver
+	DevicePath string `json:"devicePath,omitempty"`
+	// NetInterfaceName is the virtio net interface name, set only for the VirtioVDPADriver
+	NetInterfaceName string `json:"netInterfaceName,omitempty"`
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vdpa manages the vDPA devices served by the SR-IOV VFs: the devices are created and deleted over the vdpa
+// netlink (as `vdpa dev add/del`) and bound to the vhost_vdpa or virtio_vdpa bus drivers over sysfs
+package vdpa
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+const (
+	// DefaultSysfsPath is the default vdpa bus sysfs path
+	DefaultSysfsPath = "/sys/bus/vdpa"
+
+	pciMgmtBus          = "pci"
+	vhostVDPABusDriver  = "vhost_vdpa"
+	virtioVDPABusDriver = "virtio_vdpa"
+	vhostVDPADevPrefix  = "vhost-vdpa-"
+	virtioDevPrefix     = "virtio"
+	devicesPath         = "devices"
+	driversPath         = "drivers"
+	boundDriverPath     = "driver"
+	bindDriverPath      = "bind"
+	unbindDriverPath    = "unbind"
+	netInterfacesPath   = "net"
+	devPath             = "/dev"
+)
+
+// Netlink is a netlink.Handle interface
+type Netlink interface {
+	VDPANewDev(name, mgmtBus, mgmtName string, params netlink.VDPANewDevParams) error
+	VDPADelDev(name string) error
+}
+
+// Manager manages the vDPA devices lifecycle
+type Manager struct {
+	netlink   Netlink
+	sysfsPath string
+	params    netlink.VDPANewDevParams
+}
+
+// Option is an option for the Manager
+type Option func(m *Manager)
+
+// WithNetlink sets the netlink handle, netlink default handle is used by default
+func WithNetlink(nl Netlink) Option {
+	return func(m *Manager) {
+		m.netlink = nl
+	}
+}
+
+// WithSysfsPath sets the vdpa bus sysfs path, DefaultSysfsPath by default
+func WithSysfsPath(sysfsPath string) Option {
+	return func(m *Manager) {
+		m.sysfsPath = sysfsPath
+	}
+}
+
+// WithDeviceParams sets the created devices params, e.g. the MTU, the max virtqueue pairs or the virtio features
+func WithDeviceParams(params netlink.VDPANewDevParams) Option {
+	return func(m *Manager) {
+		m.params = params
+	}
+}
+
+// NewManager returns a new Manager
+func NewManager(options ...Option) *Manager {
+	m := &Manager{
+		netlink:   new(netlink.Handle),
+		sysfsPath: DefaultSysfsPath,
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// DeviceName returns the vDPA device name for the VF
+func DeviceName(vfPCIAddr string) string {
+	return "vdpa-" + strings.NewReplacer(":", "-", ".", "-").Replace(vfPCIAddr)
+}
+
+// Create creates the vDPA device on the VF and binds it to the driverType bus driver, the device is deleted if the
+// binding fails
+func (m *Manager) Create(vfPCIAddr string, driverType sriov.DriverType) (*Device, error) {
+	name := DeviceName(vfPCIAddr)
+	if err := m.netlink.VDPANewDev(name, pciMgmtBus, vfPCIAddr, m.params); err != nil {
+		return nil, errors.Wrapf(err, "failed to create vDPA device %s on the VF: %s", name, vfPCIAddr)
+	}
+
+	device, err := m.Bind(name, driverType)
+	if err != nil {
+		_ = m.Delete(name)
+		return nil, err
+	}
+	device.VFPCIAddress = vfPCIAddr
+	return device, nil
+}
+
+// Delete deletes the vDPA device
+func (m *Manager) Delete(name string) error {
+	if err := m.netlink.VDPADelDev(name); err != nil {
+		return errors.Wrapf(err, "failed to delete vDPA device: %s", name)
+	}
+	return nil
+}
+
+// Bind unbinds currently bound bus driver and binds the driverType bus driver to the vDPA device
+func (m *Manager) Bind(name string, driverType sriov.DriverType) (*Device, error) {
+	var driver string
+	switch driverType {
+	case sriov.VhostVDPADriver:
+		driver = vhostVDPABusDriver
+	case sriov.VirtioVDPADriver:
+		driver = virtioVDPABusDriver
+	default:
+		return nil, errors.Errorf("driver type is not supported: %v", driverType)
+	}
+
+	switch boundDriver, err := m.boundDriver(name); {
+	case err != nil:
+		return nil, err
+	case boundDriver == driver:
+	default:
+		if boundDriver != "" {
+			unbindPath := m.withDevicePath(name, boundDriverPath, unbindDriverPath)
+			if err := os.WriteFile(unbindPath, []byte(name), 0); err != nil {
+				return nil, errors.Wrapf(err, "failed to unbind driver from the vDPA device: %v", name)
+			}
+		}
+		bindPath := filepath.Join(m.sysfsPath, driversPath, driver, bindDriverPath)
+		if err := os.WriteFile(bindPath, []byte(name), 0); err != nil {
+			return nil, errors.Wrapf(err, "failed to bind the driver to the vDPA device: %v %v", name, driver)
+		}
+		if boundDriver, _ := m.boundDriver(name); boundDriver != driver {
+			return nil, errors.Errorf("failed to bind the driver to the vDPA device: %v %v", name, driver)
+		}
+	}
+
+	device := &Device{
+		Name:   name,
+		Driver: driverType,
+	}
+	var err error
+	if driverType == sriov.VhostVDPADriver {
+		device.DevicePath, err = m.vhostDevicePath(name)
+	} else {
+		device.NetInterfaceName, err = m.virtioNetInterfaceName(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+func (m *Manager) boundDriver(name string) (string, error) {
+	driverPath := m.withDevicePath(name, boundDriverPath)
+	if _, err := os.Lstat(driverPath); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get the vDPA device driver: %s", name)
+	}
+
+	realPath, err := filepath.EvalSymlinks(driverPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the vDPA device driver: %s", name)
+	}
+	return filepath.Base(realPath), nil
+}
+
+func (m *Manager) vhostDevicePath(name string) (string, error) {
+	charDev, err := m.findEntry(m.withDevicePath(name), vhostVDPADevPrefix)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the vhost-vdpa char device for the vDPA device: %s", name)
+	}
+	return filepath.Join(devPath, charDev), nil
+}
+
+func (m *Manager) virtioNetInterfaceName(name string) (string, error) {
+	virtioDev, err := m.findEntry(m.withDevicePath(name), virtioDevPrefix)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the virtio device for the vDPA device: %s", name)
+	}
+	ifName, err := m.findEntry(m.withDevicePath(name, virtioDev, netInterfacesPath), "")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the net interface for the vDPA device: %s", name)
+	}
+	return ifName, nil
+}
+
+func (m *Manager) findEntry(dir, prefix string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read directory: %s", dir)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix) {
+			return entry.Name(), nil
+		}
+	}
+	return "", errors.Errorf("no %s* entry found in: %s", prefix, dir)
+}
+
+func (m *Manager) withDevicePath(name string, elem ...string) string {
+	return filepath.Join(append([]string{m.sysfsPath, devicesPath, name}, elem...)...)
+}
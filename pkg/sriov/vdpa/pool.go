@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vdpa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+// ResourcePool is a resource.Pool interface
+type ResourcePool interface {
+	Select(tokenID string, driverType sriov.DriverType) (string, error)
+	Free(vfPCIAddr string) error
+}
+
+type hintedPool interface {
+	SelectWithHints(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints) (string, error)
+}
+
+type excludingPool interface {
+	SelectExcludingPFs(tokenID string, driverType sriov.DriverType, excludedPFs []string) (string, error)
+}
+
+type unhealthyPool interface {
+	MarkUnhealthy(vfPCIAddr string, duration time.Duration) error
+}
+
+type statefulPool interface {
+	State() *resource.State
+}
+
+// Pool is a resource pool serving the tokens by the vDPA devices: for the vDPA driver types it selects the VF bound to
+// the kernel driver from the wrapped pool, the vDPA device is created on it with CreateDevice once the VF is bound to
+// the kernel driver and deleted with DeleteDevice or when the VF is freed. Other driver types are served by the
+// wrapped pool as is. The selection hints, the PF exclusion, the unhealthy VFs and the state are served by the wrapped
+// pool if it supports them.
+// WARNING: Select, Free and the other resource pool methods are thread unsafe - if you want to use them concurrently,
+// use some synchronization outside. CreateDevice and DeleteDevice are safe to call without it.
+type Pool struct {
+	ResourcePool
+	manager *Manager
+	drivers map[string]sriov.DriverType // drivers[vfPCIAddr] -> vDPA driver type
+	devices map[string]*Device          // devices[vfPCIAddr] -> *Device
+	lock    sync.Mutex
+}
+
+// NewPool returns a new Pool wrapping the resourcePool
+func NewPool(resourcePool ResourcePool, manager *Manager) *Pool {
+	return &Pool{
+		ResourcePool: resourcePool,
+		manager:      manager,
+		drivers:      map[string]sriov.DriverType{},
+		devices:      map[string]*Device{},
+	}
+}
+
+// Select selects a VF for the given driver type, for the vDPA driver types selects the VF for the kernel driver
+func (p *Pool) Select(tokenID string, driverType sriov.DriverType) (string, error) {
+	vfPCIAddr, err := p.ResourcePool.Select(tokenID, pciDriverType(driverType))
+	return p.selected(vfPCIAddr, driverType, err)
+}
+
+// SelectWithHints selects a VF for the given driver type with the wrapped pool selection hints
+func (p *Pool) SelectWithHints(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints) (string, error) {
+	hinted, ok := p.ResourcePool.(hintedPool)
+	if !ok {
+		if hints != nil && hints.PhysicalNetwork != "" {
+			return "", errors.Errorf("resource pool doesn't support physical network isolation: %s", hints.PhysicalNetwork)
+		}
+		return p.Select(tokenID, driverType)
+	}
+	vfPCIAddr, err := hinted.SelectWithHints(tokenID, pciDriverType(driverType), hints)
+	return p.selected(vfPCIAddr, driverType, err)
+}
+
+// SelectExcludingPFs selects a VF for the given driver type on the PFs other than the excluded ones
+func (p *Pool) SelectExcludingPFs(tokenID string, driverType sriov.DriverType, excludedPFs []string) (string, error) {
+	excluding, ok := p.ResourcePool.(excludingPool)
+	if !ok {
+		return "", errors.New("resource pool doesn't support PF exclusion")
+	}
+	vfPCIAddr, err := excluding.SelectExcludingPFs(tokenID, pciDriverType(driverType), excludedPFs)
+	return p.selected(vfPCIAddr, driverType, err)
+}
+
+// MarkUnhealthy excludes the VF from the wrapped pool selection for the duration
+func (p *Pool) MarkUnhealthy(vfPCIAddr string, duration time.Duration) error {
+	unhealthy, ok := p.ResourcePool.(unhealthyPool)
+	if !ok {
+		return errors.New("resource pool doesn't support unhealthy VFs")
+	}
+	return unhealthy.MarkUnhealthy(vfPCIAddr, duration)
+}
+
+// State returns the wrapped pool VF assignments, nil if it doesn't provide them
+func (p *Pool) State() *resource.State {
+	if stateful, ok := p.ResourcePool.(statefulPool); ok {
+		return stateful.State()
+	}
+	return nil
+}
+
+func (p *Pool) selected(vfPCIAddr string, driverType sriov.DriverType, err error) (string, error) {
+	if err != nil || !driverType.IsVDPA() {
+		return vfPCIAddr, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.drivers[vfPCIAddr] = driverType
+	return vfPCIAddr, nil
+}
+
+// CreateDevice creates the vDPA device for the driver type the VF has been selected for, the VF should be already
+// bound to the kernel driver. Returns the already created device if it is bound to the same driver.
+func (p *Pool) CreateDevice(vfPCIAddr string) (*Device, error) {
+	p.lock.Lock()
+	driverType, ok := p.drivers[vfPCIAddr]
+	device := p.devices[vfPCIAddr]
+	p.lock.Unlock()
+
+	if !ok {
+		return nil, errors.Errorf("VF is not selected for the vDPA driver: %s", vfPCIAddr)
+	}
+	if device != nil && device.Driver == driverType {
+		return device, nil
+	}
+
+	var err error
+	if device != nil {
+		if device, err = p.manager.Bind(device.Name, driverType); err != nil {
+			return nil, err
+		}
+		device.VFPCIAddress = vfPCIAddr
+	} else if device, err = p.manager.Create(vfPCIAddr, driverType); err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.devices[vfPCIAddr] = device
+	return device, nil
+}
+
+// DeleteDevice deletes the vDPA device created on the VF if any
+func (p *Pool) DeleteDevice(vfPCIAddr string) error {
+	p.lock.Lock()
+	device, ok := p.devices[vfPCIAddr]
+	p.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+	if err := p.manager.Delete(device.Name); err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.devices, vfPCIAddr)
+	return nil
+}
+
+// Free deletes the vDPA device created on the VF if any and frees the VF
+func (p *Pool) Free(vfPCIAddr string) error {
+	if err := p.DeleteDevice(vfPCIAddr); err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	delete(p.drivers, vfPCIAddr)
+	p.lock.Unlock()
+
+	return p.ResourcePool.Free(vfPCIAddr)
+}
+
+// Device returns the vDPA device created on the VF
+func (p *Pool) Device(vfPCIAddr string) (*Device, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	device, ok := p.devices[vfPCIAddr]
+	return device, ok
+}
+
+func pciDriverType(driverType sriov.DriverType) sriov.DriverType {
+	if driverType.IsVDPA() {
+		return sriov.KernelDriver
+	}
+	return driverType
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package vdpa
+
+import "github.com/ljkiraly/sdk-sriov/pkg/sriov"
+
+// Manager fails all the vDPA devices management with sriov.UnsupportedError, vDPA is supported on Linux only
+type Manager struct{}
+
+// Option is an option for the Manager
+type Option func(m *Manager)
+
+// NewManager returns a new Manager
+func NewManager(_ ...Option) *Manager {
+	return &Manager{}
+}
+
+// Create returns sriov.UnsupportedError
+func (m *Manager) Create(string, sriov.DriverType) (*Device, error) {
+	return nil, sriov.NewUnsupportedError("vDPA")
+}
+
+// Delete returns sriov.UnsupportedError
+func (m *Manager) Delete(string) error {
+	return sriov.NewUnsupportedError("vDPA")
+}
+
+// Bind returns sriov.UnsupportedError
+func (m *Manager) Bind(string, sriov.DriverType) (*Device, error) {
+	return nil, sriov.NewUnsupportedError("vDPA")
+}
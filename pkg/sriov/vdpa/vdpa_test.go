@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vdpa_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/vdpa"
+)
+
+const (
+	vfPCIAddr  = "0000:01:00.1"
+	deviceName = "vdpa-0000-01-00-1"
+	tokenID    = "1"
+)
+
+func TestManager_Create(t *testing.T) {
+	sysfsPath := t.TempDir()
+	nl := &netlinkStub{sysfsPath: sysfsPath, t: t}
+	manager := vdpa.NewManager(vdpa.WithNetlink(nl), vdpa.WithSysfsPath(sysfsPath))
+
+	device, err := manager.Create(vfPCIAddr, sriov.VhostVDPADriver)
+	require.NoError(t, err)
+	require.Equal(t, &vdpa.Device{
+		Name:         deviceName,
+		VFPCIAddress: vfPCIAddr,
+		Driver:       sriov.VhostVDPADriver,
+		DevicePath:   "/dev/vhost-vdpa-0",
+	}, device)
+	require.Equal(t, vfPCIAddr, nl.devices[deviceName])
+
+	require.NoError(t, manager.Delete(deviceName))
+	require.Empty(t, nl.devices)
+
+	// the driver binding doesn't take effect
+	_, err = manager.Create(vfPCIAddr, sriov.VirtioVDPADriver)
+	require.ErrorContains(t, err, "failed to bind the driver to the vDPA device")
+	require.Empty(t, nl.devices)
+	data, err := os.ReadFile(filepath.Join(sysfsPath, "drivers", "virtio_vdpa", "bind"))
+	require.NoError(t, err)
+	require.Equal(t, deviceName, string(data))
+
+	_, err = manager.Create(vfPCIAddr, sriov.KernelDriver)
+	require.ErrorContains(t, err, "driver type is not supported")
+}
+
+func TestPool(t *testing.T) {
+	sysfsPath := t.TempDir()
+	nl := &netlinkStub{sysfsPath: sysfsPath, t: t}
+	resourcePool := &resourcePoolStub{}
+	p := vdpa.NewPool(resourcePool, vdpa.NewManager(vdpa.WithNetlink(nl), vdpa.WithSysfsPath(sysfsPath)))
+
+	selected, err := p.Select(tokenID, sriov.VFIOPCIDriver)
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, selected)
+	require.Equal(t, sriov.VFIOPCIDriver, resourcePool.driverType)
+	require.NoError(t, p.Free(selected))
+	require.Empty(t, nl.devices)
+
+	selected, err = p.Select(tokenID, sriov.VhostVDPADriver)
+	require.NoError(t, err)
+	require.Equal(t, vfPCIAddr, selected)
+	require.Equal(t, sriov.KernelDriver, resourcePool.driverType)
+
+	// the device is created after the kernel driver binding, not on the selection
+	require.Empty(t, nl.devices)
+	_, ok := p.Device(selected)
+	require.False(t, ok)
+
+	device, err := p.CreateDevice(selected)
+	require.NoError(t, err)
+	require.Equal(t, "/dev/vhost-vdpa-0", device.DevicePath)
+	require.Equal(t, vfPCIAddr, nl.devices[deviceName])
+
+	// the refresh keeps the device
+	_, err = p.CreateDevice(selected)
+	require.NoError(t, err)
+	require.Len(t, nl.devices, 1)
+
+	require.NoError(t, p.Free(selected))
+	require.Empty(t, nl.devices)
+	require.False(t, resourcePool.selected)
+	_, ok = p.Device(selected)
+	require.False(t, ok)
+
+	// the device can't be created on the VF not selected for the vDPA driver
+	_, err = p.CreateDevice(selected)
+	require.Error(t, err)
+
+	// the device creation failure keeps the VF selected until it is freed
+	selected, err = p.SelectWithHints(tokenID, sriov.VhostVDPADriver, &sriov.SelectionHints{NUMANode: 1})
+	require.NoError(t, err)
+	require.Equal(t, &sriov.SelectionHints{NUMANode: 1}, resourcePool.hints)
+
+	nl.err = errors.New("not supported")
+	_, err = p.CreateDevice(selected)
+	require.ErrorContains(t, err, "not supported")
+	require.True(t, resourcePool.selected)
+	require.NoError(t, p.Free(selected))
+	require.False(t, resourcePool.selected)
+}
+
+type netlinkStub struct {
+	sysfsPath string
+	devices   map[string]string
+	err       error
+	t         *testing.T
+}
+
+// VDPANewDev creates the device bound to the vhost_vdpa bus driver as the kernel does by default
+func (nl *netlinkStub) VDPANewDev(name, mgmtBus, mgmtName string, _ netlink.VDPANewDevParams) error {
+	if nl.err != nil {
+		return nl.err
+	}
+	require.Equal(nl.t, "pci", mgmtBus)
+
+	driverPath := filepath.Join(nl.sysfsPath, "drivers", "vhost_vdpa")
+	require.NoError(nl.t, os.MkdirAll(driverPath, 0o750))
+	require.NoError(nl.t, os.MkdirAll(filepath.Join(nl.sysfsPath, "drivers", "virtio_vdpa"), 0o750))
+	require.NoError(nl.t, os.WriteFile(filepath.Join(nl.sysfsPath, "drivers", "virtio_vdpa", "bind"), nil, 0o600))
+	devicePath := filepath.Join(nl.sysfsPath, "devices", name)
+	require.NoError(nl.t, os.MkdirAll(filepath.Join(devicePath, "vhost-vdpa-0"), 0o750))
+	require.NoError(nl.t, os.Symlink(driverPath, filepath.Join(devicePath, "driver")))
+
+	if nl.devices == nil {
+		nl.devices = map[string]string{}
+	}
+	nl.devices[name] = mgmtName
+	return nil
+}
+
+func (nl *netlinkStub) VDPADelDev(name string) error {
+	if _, ok := nl.devices[name]; !ok {
+		return errors.Errorf("no device: %s", name)
+	}
+	delete(nl.devices, name)
+	return os.RemoveAll(filepath.Join(nl.sysfsPath, "devices", name))
+}
+
+type resourcePoolStub struct {
+	driverType sriov.DriverType
+	hints      *sriov.SelectionHints
+	selected   bool
+}
+
+func (p *resourcePoolStub) Select(_ string, driverType sriov.DriverType) (string, error) {
+	p.driverType = driverType
+	p.selected = true
+	return vfPCIAddr, nil
+}
+
+func (p *resourcePoolStub) SelectWithHints(tokenID string, driverType sriov.DriverType, hints *sriov.SelectionHints) (string, error) {
+	p.hints = hints
+	return p.Select(tokenID, driverType)
+}
+
+func (p *resourcePoolStub) Free(_ string) error {
+	p.selected = false
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vlan provides a VLAN ID pool for the remote SR-IOV connections
+package vlan
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinID is the minimal usable VLAN ID
+	MinID = 1
+	// MaxID is the maximal usable VLAN ID
+	MaxID = 4094
+)
+
+// Pool allocates VLAN IDs for the connections
+type Pool struct {
+	minID, maxID uint32
+	ids          map[uint32]string // ids[vlanID] -> connectionID
+	connections  map[string]uint32 // connections[connectionID] -> vlanID
+	lock         sync.Mutex
+}
+
+// NewPool returns a new VLAN ID pool allocating IDs from the [minID, maxID] range
+func NewPool(minID, maxID uint32) (*Pool, error) {
+	if minID < MinID || maxID > MaxID || minID > maxID {
+		return nil, errors.Errorf("invalid VLAN ID range: [%d, %d]", minID, maxID)
+	}
+	return &Pool{
+		minID:       minID,
+		maxID:       maxID,
+		ids:         map[uint32]string{},
+		connections: map[string]uint32{},
+	}, nil
+}
+
+// Allocate allocates a free VLAN ID for the connection, returns already allocated VLAN ID if there is some
+func (p *Pool) Allocate(connID string) (uint32, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if vlanID, ok := p.connections[connID]; ok {
+		return vlanID, nil
+	}
+
+	for vlanID := p.minID; vlanID <= p.maxID; vlanID++ {
+		if _, ok := p.ids[vlanID]; ok {
+			continue
+		}
+		p.ids[vlanID] = connID
+		p.connections[connID] = vlanID
+		return vlanID, nil
+	}
+	return 0, errors.Errorf("no free VLAN ID in the range: [%d, %d]", p.minID, p.maxID)
+}
+
+// Free frees the VLAN ID allocated for the connection
+func (p *Pool) Free(connID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if vlanID, ok := p.connections[connID]; ok {
+		delete(p.ids, vlanID)
+		delete(p.connections, connID)
+	}
+}
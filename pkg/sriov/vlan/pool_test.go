@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vlan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/vlan"
+)
+
+func TestNewPool_InvalidRange(t *testing.T) {
+	_, err := vlan.NewPool(0, 10)
+	require.Error(t, err)
+
+	_, err = vlan.NewPool(1, vlan.MaxID+1)
+	require.Error(t, err)
+
+	_, err = vlan.NewPool(10, 1)
+	require.Error(t, err)
+}
+
+func TestPool_Allocate(t *testing.T) {
+	p, err := vlan.NewPool(100, 101)
+	require.NoError(t, err)
+
+	vlanID, err := p.Allocate("id-1")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	vlanID, err = p.Allocate("id-1")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	vlanID, err = p.Allocate("id-2")
+	require.NoError(t, err)
+	require.Equal(t, uint32(101), vlanID)
+
+	_, err = p.Allocate("id-3")
+	require.Error(t, err)
+
+	p.Free("id-1")
+
+	vlanID, err = p.Allocate("id-3")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alerting provides the in-process thresholds evaluator: it raises the alerts when the free tokens ratio for a
+// capability drops below the bound or the driver bind failures rate exceeds the limit, the alerts are published as
+// events and can fail the forwarder readiness, so the simple alerting doesn't need an external rule engine
+package alerting
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/yamlhelper"
+)
+
+const defaultBindFailuresWindow = time.Minute
+
+// Config contains the alerting thresholds
+type Config struct {
+	// MinFreeTokenRatio is the min ratio of the free tokens to the not closed tokens by capability
+	MinFreeTokenRatio map[string]float64 `yaml:"minFreeTokenRatio"`
+	// MaxBindFailures is the max number of the driver bind failures within the BindFailuresWindow, 0 means no limit
+	MaxBindFailures uint `yaml:"maxBindFailures"`
+	// BindFailuresWindow is the driver bind failures rate window, e.g. "5m", 1 minute by default
+	BindFailuresWindow string `yaml:"bindFailuresWindow"`
+	// FailReadiness makes the forwarder not ready while any alert is raised
+	FailReadiness bool `yaml:"failReadiness"`
+
+	bindFailuresWindow time.Duration
+}
+
+// ReadConfig reads the alerting config from file
+func ReadConfig(configFile string) (*Config, error) {
+	cfg := &Config{}
+	if err := yamlhelper.UnmarshalFile(configFile, cfg); err != nil {
+		return nil, err
+	}
+
+	return validateConfig(cfg)
+}
+
+// ParseConfig parses the alerting config from YAML bytes
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yamlhelper.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return validateConfig(cfg)
+}
+
+func validateConfig(cfg *Config) (*Config, error) {
+	for capability, ratio := range cfg.MinFreeTokenRatio {
+		if ratio < 0 || ratio > 1 {
+			return nil, errors.Errorf("%s free token ratio should be in [0, 1]: %v", capability, ratio)
+		}
+	}
+
+	cfg.bindFailuresWindow = defaultBindFailuresWindow
+	if cfg.BindFailuresWindow != "" {
+		window, err := time.ParseDuration(cfg.BindFailuresWindow)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bind failures window: %s", cfg.BindFailuresWindow)
+		}
+		if window <= 0 {
+			return nil, errors.Errorf("bind failures window should be positive: %s", cfg.BindFailuresWindow)
+		}
+		cfg.bindFailuresWindow = window
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+)
+
+// EventTopic is the event bus topic the alert changes are published on with *AlertEvent payload
+const EventTopic eventbus.Topic = "alerts"
+
+// Alert kinds
+const (
+	// FreeTokenRatioAlert is raised when the free tokens ratio for the capability drops below the bound
+	FreeTokenRatioAlert = "FreeTokenRatio"
+	// BindFailuresAlert is raised when the driver bind failures within the window exceed the limit
+	BindFailuresAlert = "BindFailures"
+
+	freeState   = "free"
+	closedState = "closed"
+)
+
+// Alert is a crossed threshold
+type Alert struct {
+	Kind string `json:"kind"`
+	// Subject is the capability for FreeTokenRatioAlert, empty for BindFailuresAlert
+	Subject   string  `json:"subject,omitempty"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+}
+
+func (a *Alert) key() string {
+	return a.Kind + "/" + a.Subject
+}
+
+func (a *Alert) String() string {
+	if a.Kind == FreeTokenRatioAlert {
+		return fmt.Sprintf("free token ratio for the capability %s is %.2f, below %.2f", a.Subject, a.Value, a.Threshold)
+	}
+	return fmt.Sprintf("%v driver bind failures exceed %v", a.Value, a.Threshold)
+}
+
+// AlertEvent is an alert raised or resolved
+type AlertEvent struct {
+	Alert    *Alert `json:"alert"`
+	Resolved bool   `json:"resolved"`
+}
+
+func (e *AlertEvent) String() string {
+	if e.Resolved {
+		return "resolved: " + e.Alert.String()
+	}
+	return "raised: " + e.Alert.String()
+}
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	Stats() map[string]map[string]int
+}
+
+// PCIPool is a pci.Pool interface
+type PCIPool interface {
+	GetPCIFunction(pciAddr string) (sriov.PCIFunction, error)
+	BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error
+}
+
+// VFResetter is a pci.Pool interface
+type VFResetter interface {
+	ResetFunction(ctx context.Context, pciAddr string, driverType sriov.DriverType) error
+}
+
+// Evaluator evaluates the Config thresholds: the free tokens ratios are evaluated on the token pool stats, the driver
+// bind failures are recorded by the pool wrapped with PCIPool
+type Evaluator struct {
+	cfg          *Config
+	tokenPool    TokenPool
+	bus          *eventbus.Bus
+	bindFailures []time.Time
+	alerts       map[string]*Alert
+	lock         sync.Mutex
+}
+
+// Option is an option for the Evaluator
+type Option func(e *Evaluator)
+
+// WithTokenPool sets the token pool to evaluate the free tokens ratios
+func WithTokenPool(tokenPool TokenPool) Option {
+	return func(e *Evaluator) {
+		e.tokenPool = tokenPool
+	}
+}
+
+// WithEventBus sets the event bus to publish the raised and resolved alerts on EventTopic
+func WithEventBus(bus *eventbus.Bus) Option {
+	return func(e *Evaluator) {
+		e.bus = bus
+	}
+}
+
+// NewEvaluator returns a new Evaluator for the cfg thresholds
+func NewEvaluator(cfg *Config, options ...Option) *Evaluator {
+	e := &Evaluator{
+		cfg:    cfg,
+		alerts: map[string]*Alert{},
+	}
+	for _, option := range options {
+		option(e)
+	}
+	return e
+}
+
+// Start evaluates the thresholds every interval until the ctx is done
+func (e *Evaluator) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.Evaluate()
+			}
+		}
+	}()
+}
+
+// Evaluate evaluates the thresholds, publishes the alerts changes and returns the raised alerts sorted by kind and
+// subject
+func (e *Evaluator) Evaluate() []*Alert {
+	var alerts []*Alert
+	if e.tokenPool != nil && len(e.cfg.MinFreeTokenRatio) != 0 {
+		alerts = append(alerts, e.freeTokenRatioAlerts()...)
+	}
+
+	e.lock.Lock()
+	if alert := e.bindFailuresAlert(time.Now()); alert != nil {
+		alerts = append(alerts, alert)
+	}
+	events := e.update(alerts)
+	e.lock.Unlock()
+
+	if e.bus != nil {
+		for _, event := range events {
+			e.bus.Publish(EventTopic, event)
+		}
+	}
+
+	sort.Slice(alerts, func(i, k int) bool {
+		return alerts[i].key() < alerts[k].key()
+	})
+	return alerts
+}
+
+// Ready returns an error describing the raised alerts if the Config fails readiness, nil otherwise
+func (e *Evaluator) Ready() error {
+	if !e.cfg.FailReadiness {
+		return nil
+	}
+
+	alerts := e.Evaluate()
+	if len(alerts) == 0 {
+		return nil
+	}
+	var descriptions []string
+	for _, alert := range alerts {
+		descriptions = append(descriptions, alert.String())
+	}
+	return errors.Errorf("alerts raised: %s", strings.Join(descriptions, "; "))
+}
+
+func (e *Evaluator) freeTokenRatioAlerts() []*Alert {
+	free, total := map[string]int{}, map[string]int{}
+	for name, states := range e.tokenPool.Stats() {
+		capability := path.Base(name)
+		for state, count := range states {
+			if state == closedState {
+				continue
+			}
+			total[capability] += count
+			if state == freeState {
+				free[capability] += count
+			}
+		}
+	}
+
+	var alerts []*Alert
+	for capability, minRatio := range e.cfg.MinFreeTokenRatio {
+		if total[capability] == 0 {
+			continue
+		}
+		if ratio := float64(free[capability]) / float64(total[capability]); ratio < minRatio {
+			alerts = append(alerts, &Alert{
+				Kind:      FreeTokenRatioAlert,
+				Subject:   capability,
+				Value:     ratio,
+				Threshold: minRatio,
+			})
+		}
+	}
+	return alerts
+}
+
+func (e *Evaluator) bindFailuresAlert(now time.Time) *Alert {
+	since := now.Add(-e.cfg.bindFailuresWindow)
+	for len(e.bindFailures) != 0 && e.bindFailures[0].Before(since) {
+		e.bindFailures = e.bindFailures[1:]
+	}
+
+	if e.cfg.MaxBindFailures == 0 || uint(len(e.bindFailures)) <= e.cfg.MaxBindFailures {
+		return nil
+	}
+	return &Alert{
+		Kind:      BindFailuresAlert,
+		Value:     float64(len(e.bindFailures)),
+		Threshold: float64(e.cfg.MaxBindFailures),
+	}
+}
+
+func (e *Evaluator) update(alerts []*Alert) (events []*AlertEvent) {
+	raised := map[string]*Alert{}
+	for _, alert := range alerts {
+		raised[alert.key()] = alert
+		if _, ok := e.alerts[alert.key()]; !ok {
+			events = append(events, &AlertEvent{Alert: alert})
+		}
+	}
+	for key, alert := range e.alerts {
+		if _, ok := raised[key]; !ok {
+			events = append(events, &AlertEvent{Alert: alert, Resolved: true})
+		}
+	}
+	e.alerts = raised
+
+	sort.Slice(events, func(i, k int) bool {
+		return events[i].Alert.key() < events[k].Alert.key()
+	})
+	return events
+}
+
+// PCIPool returns the pciPool recording the driver bind failures into the Evaluator, the returned pool is a VFResetter
+// if the pciPool is
+func (e *Evaluator) PCIPool(pciPool PCIPool) PCIPool {
+	alertingPool := &alertingPCIPool{
+		PCIPool:   pciPool,
+		evaluator: e,
+	}
+	if resetter, ok := pciPool.(VFResetter); ok {
+		return &resettingAlertingPCIPool{
+			alertingPCIPool: alertingPool,
+			VFResetter:      resetter,
+		}
+	}
+	return alertingPool
+}
+
+type alertingPCIPool struct {
+	PCIPool
+	evaluator *Evaluator
+}
+
+type resettingAlertingPCIPool struct {
+	*alertingPCIPool
+	VFResetter
+}
+
+func (p *alertingPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	err := p.PCIPool.BindDriver(ctx, iommuGroup, driverType)
+	if err != nil {
+		p.evaluator.lock.Lock()
+		p.evaluator.bindFailures = append(p.evaluator.bindFailures, time.Now())
+		p.evaluator.lock.Unlock()
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+)
+
+const capabilityIntel = "intel"
+
+func TestParseConfig(t *testing.T) {
+	_, err := alerting.ParseConfig([]byte(`
+minFreeTokenRatio:
+  intel: 1.5
+`))
+	require.Error(t, err)
+
+	_, err = alerting.ParseConfig([]byte(`bindFailuresWindow: forever`))
+	require.Error(t, err)
+
+	cfg, err := alerting.ParseConfig([]byte(`
+minFreeTokenRatio:
+  intel: 0.5
+maxBindFailures: 2
+bindFailuresWindow: 5m
+failReadiness: true
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{capabilityIntel: 0.5}, cfg.MinFreeTokenRatio)
+	require.EqualValues(t, 2, cfg.MaxBindFailures)
+	require.True(t, cfg.FailReadiness)
+}
+
+func TestEvaluator_FreeTokenRatio(t *testing.T) {
+	cfg, err := alerting.ParseConfig([]byte(`
+minFreeTokenRatio:
+  intel: 0.5
+  10G: 0.5
+failReadiness: true
+`))
+	require.NoError(t, err)
+
+	tokenPool := &tokenPoolStub{
+		stats: map[string]map[string]int{
+			"service.domain.1/intel": {"free": 1, "inUse": 1},
+			"service.domain.2/intel": {"free": 1, "closed": 5},
+			"service.domain.1/10G":   {"free": 1, "allocated": 1},
+		},
+	}
+
+	bus := eventbus.New()
+	var events []string
+	bus.Subscribe(func(event *eventbus.Event) {
+		events = append(events, event.Payload.(*alerting.AlertEvent).String())
+	}, alerting.EventTopic)
+
+	evaluator := alerting.NewEvaluator(cfg, alerting.WithTokenPool(tokenPool), alerting.WithEventBus(bus))
+	require.Empty(t, evaluator.Evaluate())
+	require.NoError(t, evaluator.Ready())
+
+	tokenPool.stats["service.domain.1/intel"] = map[string]int{"inUse": 2}
+	require.Equal(t, []*alerting.Alert{
+		{Kind: alerting.FreeTokenRatioAlert, Subject: capabilityIntel, Value: 1. / 3, Threshold: 0.5},
+	}, evaluator.Evaluate())
+	require.ErrorContains(t, evaluator.Ready(), "free token ratio for the capability intel is 0.33, below 0.50")
+
+	tokenPool.stats["service.domain.1/intel"] = map[string]int{"free": 2}
+	require.Empty(t, evaluator.Evaluate())
+
+	require.Equal(t, []string{
+		"raised: free token ratio for the capability intel is 0.33, below 0.50",
+		"resolved: free token ratio for the capability intel is 0.33, below 0.50",
+	}, events)
+}
+
+func TestEvaluator_BindFailures(t *testing.T) {
+	cfg, err := alerting.ParseConfig([]byte(`
+maxBindFailures: 1
+bindFailuresWindow: 100ms
+`))
+	require.NoError(t, err)
+
+	evaluator := alerting.NewEvaluator(cfg)
+	pciPool := evaluator.PCIPool(&pciPoolStub{err: errors.New("failed to bind")})
+
+	require.Error(t, pciPool.BindDriver(context.Background(), 1, sriov.VFIOPCIDriver))
+	require.Empty(t, evaluator.Evaluate())
+
+	require.Error(t, pciPool.BindDriver(context.Background(), 1, sriov.VFIOPCIDriver))
+	require.Equal(t, []*alerting.Alert{
+		{Kind: alerting.BindFailuresAlert, Value: 2, Threshold: 1},
+	}, evaluator.Evaluate())
+
+	// not failing readiness by default
+	require.NoError(t, evaluator.Ready())
+
+	require.Eventually(t, func() bool {
+		return len(evaluator.Evaluate()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+type tokenPoolStub struct {
+	stats map[string]map[string]int
+}
+
+func (tp *tokenPoolStub) Stats() map[string]map[string]int {
+	return tp.stats
+}
+
+type pciPoolStub struct {
+	err error
+}
+
+func (p *pciPoolStub) GetPCIFunction(_ string) (sriov.PCIFunction, error) {
+	return nil, p.err
+}
+
+func (p *pciPoolStub) BindDriver(_ context.Context, _ uint, _ sriov.DriverType) error {
+	return p.err
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit provides the audit trail of the hardware state changes caused by the connections: each Record tells
+// which change has been made for which client path identity, and is written to all the configured sinks
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/identity"
+)
+
+// Action is a hardware state change
+type Action string
+
+// Actions
+const (
+	// VFAssigned is the VF assignment to the connection
+	VFAssigned Action = "VFAssigned"
+	// VFFreed is the VF release by the connection
+	VFFreed Action = "VFFreed"
+	// DriverBound is the VF IOMMU group driver binding
+	DriverBound Action = "DriverBound"
+	// VLANSet is the VF VLAN tagging
+	VLANSet Action = "VLANSet"
+	// VLANCleared is the VF VLAN untagging
+	VLANCleared Action = "VLANCleared"
+)
+
+// Record is an audit record. Device is set instead of PCIAddress for the changes made on the PF uplink, as
+// "<PF net interface name> vf <VF num>".
+type Record struct {
+	Time         time.Time        `json:"time"`
+	Identity     string           `json:"identity,omitempty"`
+	ConnectionID string           `json:"connectionId"`
+	Action       Action           `json:"action"`
+	TokenID      string           `json:"tokenId,omitempty"`
+	PCIAddress   string           `json:"pciAddress,omitempty"`
+	Device       string           `json:"device,omitempty"`
+	Driver       sriov.DriverType `json:"driver,omitempty"`
+	VLANID       uint32           `json:"vlanId,omitempty"`
+}
+
+// Sink writes the audit records, e.g. to a file, syslog or a remote collector
+type Sink interface {
+	Write(record *Record) error
+}
+
+// Auditor writes the audit records to the sinks
+type Auditor struct {
+	sinks []Sink
+}
+
+// NewAuditor returns a new Auditor writing to the sinks
+func NewAuditor(sinks ...Sink) *Auditor {
+	return &Auditor{
+		sinks: sinks,
+	}
+}
+
+// Record completes the record with the time, the connection ID and the client identity from the connection path and
+// writes it to all the sinks. Sink failures are logged and don't fail the hardware state change.
+func (a *Auditor) Record(ctx context.Context, conn *networkservice.Connection, record *Record) {
+	record.Time = time.Now()
+	record.ConnectionID = conn.GetId()
+	record.Identity = identity.FromPath(ctx, conn.GetPath())
+
+	for _, sink := range a.sinks {
+		if err := sink.Write(record); err != nil {
+			log.FromContext(ctx).WithField("auditor", "Record").
+				Errorf("failed to write audit record %s for %s: %s", record.Action, record.ConnectionID, err.Error())
+		}
+	}
+}
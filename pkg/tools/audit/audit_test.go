@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/audit"
+)
+
+const (
+	connID      = "conn-1"
+	spiffeID    = "spiffe://example.org/ns/tenant/pod/nsc-1"
+	tokenID     = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	vfPCIAddr   = "0000:01:00.1"
+	sinkTimeout = time.Second
+)
+
+type memorySink struct {
+	lock    sync.Mutex
+	records []*audit.Record
+}
+
+func (s *memorySink) Write(record *audit.Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.records = append(s.records, record)
+	return nil
+}
+
+type failingSink struct{}
+
+func (s *failingSink) Write(*audit.Record) error {
+	return errors.New("failure")
+}
+
+func testConnection(t *testing.T) *networkservice.Connection {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+		SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	return &networkservice.Connection{
+		Id: connID,
+		Path: &networkservice.Path{
+			PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: token}},
+		},
+	}
+}
+
+func TestAuditor_Record(t *testing.T) {
+	memory := new(memorySink)
+	auditor := audit.NewAuditor(new(failingSink), memory)
+
+	auditor.Record(context.Background(), testConnection(t), &audit.Record{
+		Action:     audit.VFAssigned,
+		TokenID:    tokenID,
+		PCIAddress: vfPCIAddr,
+		Driver:     sriov.VFIOPCIDriver,
+	})
+
+	require.Len(t, memory.records, 1)
+	record := memory.records[0]
+	require.False(t, record.Time.IsZero())
+	require.Equal(t, &audit.Record{
+		Time:         record.Time,
+		Identity:     spiffeID,
+		ConnectionID: connID,
+		Action:       audit.VFAssigned,
+		TokenID:      tokenID,
+		PCIAddress:   vfPCIAddr,
+		Driver:       sriov.VFIOPCIDriver,
+	}, record)
+}
+
+func TestFileSink(t *testing.T) {
+	auditFile := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := audit.NewFileSink(auditFile)
+	require.NoError(t, err)
+
+	auditor := audit.NewAuditor(sink)
+	auditor.Record(context.Background(), testConnection(t), &audit.Record{Action: audit.VFAssigned, PCIAddress: vfPCIAddr})
+	auditor.Record(context.Background(), testConnection(t), &audit.Record{Action: audit.VFFreed, PCIAddress: vfPCIAddr})
+	require.NoError(t, sink.Close())
+
+	// records are appended to the existing file
+	sink, err = audit.NewFileSink(auditFile)
+	require.NoError(t, err)
+	auditor = audit.NewAuditor(sink)
+	auditor.Record(context.Background(), testConnection(t), &audit.Record{Action: audit.VLANSet, VLANID: 100})
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(filepath.Clean(auditFile))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var actions []audit.Action
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := new(audit.Record)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), record))
+		require.Equal(t, spiffeID, record.Identity)
+		actions = append(actions, record.Action)
+	}
+	require.Equal(t, []audit.Action{audit.VFAssigned, audit.VFFreed, audit.VLANSet}, actions)
+}
+
+func TestSyslogSink(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	sink, err := audit.NewSyslogSink("udp", listener.LocalAddr().String(), "sriov-audit")
+	require.NoError(t, err)
+	defer func() { _ = sink.Close() }()
+
+	audit.NewAuditor(sink).Record(context.Background(), testConnection(t), &audit.Record{
+		Action: audit.VLANSet,
+		Device: "pf-1 vf 0",
+		VLANID: 100,
+	})
+
+	require.NoError(t, listener.SetReadDeadline(time.Now().Add(sinkTimeout)))
+	buf := make([]byte, 4096)
+	n, _, err := listener.ReadFrom(buf)
+	require.NoError(t, err)
+
+	message := string(buf[:n])
+	require.Contains(t, message, "sriov-audit")
+	require.Contains(t, message, `"action":"VLANSet"`)
+	require.Contains(t, message, `"device":"pf-1 vf 0"`)
+	require.Contains(t, message, `"vlanId":100`)
+}
+
+func TestGRPCSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "audit.sock"))
+	require.NoError(t, err)
+
+	memory := new(memorySink)
+	grpcServer := grpc.NewServer()
+	audit.RegisterAuditServer(grpcServer, memory)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	cc, err := grpc.DialContext(ctx, "unix://"+listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	sink := audit.NewGRPCSink(cc, sinkTimeout)
+	audit.NewAuditor(sink).Record(ctx, testConnection(t), &audit.Record{
+		Action:     audit.DriverBound,
+		PCIAddress: vfPCIAddr,
+		Driver:     sriov.KernelDriver,
+	})
+
+	require.Len(t, memory.records, 1)
+	require.Equal(t, spiffeID, memory.records[0].Identity)
+	require.Equal(t, audit.DriverBound, memory.records[0].Action)
+	require.Equal(t, sriov.KernelDriver, memory.records[0].Driver)
+
+	require.NoError(t, cc.Close())
+	require.ErrorContains(t, sink.Write(&audit.Record{Action: audit.VFFreed}), audit.RecordMethod)
+}
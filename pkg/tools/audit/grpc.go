@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// RecordMethod is the full gRPC method name of the audit Record call
+const RecordMethod = "/sriov.audit.AuditService/Record"
+
+const defaultGRPCSinkTimeout = 5 * time.Second
+
+// GRPCSink is a Sink sending the records to the remote audit collector
+type GRPCSink struct {
+	cc      grpc.ClientConnInterface
+	timeout time.Duration
+}
+
+// NewGRPCSink returns a new GRPCSink sending the records over the cc, each call is limited with the timeout, with the
+// 5s one if timeout is zero
+func NewGRPCSink(cc grpc.ClientConnInterface, timeout time.Duration) *GRPCSink {
+	if timeout == 0 {
+		timeout = defaultGRPCSinkTimeout
+	}
+	return &GRPCSink{
+		cc:      cc,
+		timeout: timeout,
+	}
+}
+
+// Write implements Sink
+func (s *GRPCSink) Write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit record")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	if err := s.cc.Invoke(ctx, RecordMethod, wrapperspb.Bytes(data), new(emptypb.Empty)); err != nil {
+		return errors.Wrapf(err, "failed to call %s", RecordMethod)
+	}
+	return nil
+}
+
+type auditService interface {
+	Write(record *Record) error
+}
+
+// RegisterAuditServer registers the audit gRPC service writing the received records to the sink, e.g. on the remote
+// audit collector. The service is registered without the generated proto code: Record takes
+// google.protobuf.BytesValue with the JSON encoded Record and returns google.protobuf.Empty.
+func RegisterAuditServer(s grpc.ServiceRegistrar, sink Sink) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "sriov.audit.AuditService",
+		HandlerType: (*auditService)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Record",
+				Handler: func(srv interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					req := new(wrapperspb.BytesValue)
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+					record := new(Record)
+					if err := json.Unmarshal(req.GetValue(), record); err != nil {
+						return nil, errors.Wrap(err, "failed to unmarshal audit record")
+					}
+					if err := srv.(auditService).Write(record); err != nil {
+						return nil, err
+					}
+					return new(emptypb.Empty), nil
+				},
+			},
+		},
+	}, sink)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package audit
+
+import (
+	"encoding/json"
+	"log/syslog"
+
+	"github.com/pkg/errors"
+)
+
+// SyslogSink is a Sink writing the records to the syslog as JSON messages with the notice severity
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink returns a new SyslogSink connected to the syslog daemon at the raddr over the network, to the local
+// one if network is empty. Messages are tagged with the tag and sent with the auth facility.
+func NewSyslogSink(network, raddr, tag string) (*SyslogSink, error) {
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_NOTICE|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to syslog: %s %s", network, raddr)
+	}
+	return &SyslogSink{
+		writer: writer,
+	}, nil
+}
+
+// Write implements Sink
+func (s *SyslogSink) Write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit record")
+	}
+	if err := s.writer.Notice(string(data)); err != nil {
+		return errors.Wrap(err, "failed to write audit record to syslog")
+	}
+	return nil
+}
+
+// Close closes the syslog connection
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// SyslogSink is a Sink writing the records to the syslog, syslog is not supported on Windows
+type SyslogSink struct{}
+
+// NewSyslogSink returns sriov.UnsupportedError
+func NewSyslogSink(_, _, _ string) (*SyslogSink, error) {
+	return nil, sriov.NewUnsupportedError("syslog audit sink")
+}
+
+// Write implements Sink
+func (s *SyslogSink) Write(*Record) error {
+	return sriov.NewUnsupportedError("syslog audit sink")
+}
+
+// Close does nothing
+func (s *SyslogSink) Close() error {
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdi
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+const (
+	vfioDevice     = "vfio"
+	specExt        = ".json"
+	specPerm       = 0o644
+	specDirPerm    = 0o755
+	deviceType     = "c"
+	devicePerms    = "rw"
+	defaultVFIODir = "/dev/vfio"
+)
+
+// Generator generates CDI specs for the allocated VFIO and vhost-vdpa devices
+type Generator struct {
+	kind             string
+	specDir          string
+	hostVFIODir      string
+	containerVFIODir string
+	mounts           []*Mount
+}
+
+// Option is an option for the Generator
+type Option func(g *Generator)
+
+// WithKind sets CDI device kind
+func WithKind(kind string) Option {
+	return func(g *Generator) {
+		g.kind = kind
+	}
+}
+
+// WithSpecDir sets directory where CDI specs are written
+func WithSpecDir(specDir string) Option {
+	return func(g *Generator) {
+		g.specDir = specDir
+	}
+}
+
+// WithVFIODir sets host and container VFIO device directories
+func WithVFIODir(hostVFIODir, containerVFIODir string) Option {
+	return func(g *Generator) {
+		g.hostVFIODir = hostVFIODir
+		g.containerVFIODir = containerVFIODir
+	}
+}
+
+// WithMounts sets additional mounts added to every generated device
+func WithMounts(mounts ...*Mount) Option {
+	return func(g *Generator) {
+		g.mounts = append(g.mounts, mounts...)
+	}
+}
+
+// NewGenerator returns a new CDI spec Generator
+func NewGenerator(options ...Option) *Generator {
+	g := &Generator{
+		kind:             DefaultKind,
+		specDir:          DefaultSpecDir,
+		hostVFIODir:      defaultVFIODir,
+		containerVFIODir: defaultVFIODir,
+	}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// Kind returns CDI device kind
+func (g *Generator) Kind() string {
+	return g.kind
+}
+
+// Device returns a CDI device for the tokenIDs allocated for a container with the VFs from the iommuGroups. Device
+// has VFIO container and IOMMU group device nodes, additional mounts and tokens env.
+func (g *Generator) Device(name, tokenName string, tokenIDs []string, iommuGroups []uint) *Device {
+	edits := &ContainerEdits{
+		DeviceNodes: []*DeviceNode{g.deviceNode(vfioDevice)},
+		Mounts:      g.mounts,
+	}
+	for _, igid := range iommuGroups {
+		edits.DeviceNodes = append(edits.DeviceNodes, g.deviceNode(strconv.FormatUint(uint64(igid), 10)))
+	}
+	if len(tokenIDs) > 0 {
+		envName, envValue := tokens.ToEnv(tokenName, tokenIDs)
+		edits.Env = append(edits.Env, envName+"="+envValue)
+	}
+
+	return &Device{
+		Name:           name,
+		ContainerEdits: edits,
+	}
+}
+
+// CharDevice returns a CDI device with the host char device nodes mounted on the same paths into the container, e.g.
+// the vhost-vdpa char devices
+func (g *Generator) CharDevice(name string, devicePaths ...string) *Device {
+	edits := &ContainerEdits{
+		Mounts: g.mounts,
+	}
+	for _, devicePath := range devicePaths {
+		edits.DeviceNodes = append(edits.DeviceNodes, &DeviceNode{
+			Path:        devicePath,
+			HostPath:    devicePath,
+			Type:        deviceType,
+			Permissions: devicePerms,
+		})
+	}
+
+	return &Device{
+		Name:           name,
+		ContainerEdits: edits,
+	}
+}
+
+func (g *Generator) deviceNode(fileName string) *DeviceNode {
+	return &DeviceNode{
+		Path:        filepath.Join(g.containerVFIODir, fileName),
+		HostPath:    filepath.Join(g.hostVFIODir, fileName),
+		Type:        deviceType,
+		Permissions: devicePerms,
+	}
+}
+
+// Spec returns a CDI spec for the devices
+func (g *Generator) Spec(devices ...*Device) *Spec {
+	return &Spec{
+		CDIVersion: Version,
+		Kind:       g.kind,
+		Devices:    devices,
+	}
+}
+
+// WriteSpec writes CDI spec for the devices into the spec directory as specName file, returns qualified names of the
+// written devices
+func (g *Generator) WriteSpec(ctx context.Context, specName string, devices ...*Device) ([]string, error) {
+	logger := log.FromContext(ctx).WithField("Generator", "WriteSpec")
+
+	data, err := json.MarshalIndent(g.Spec(devices...), "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal CDI spec")
+	}
+
+	if err := os.MkdirAll(g.specDir, specDirPerm); err != nil {
+		return nil, errors.Wrapf(err, "failed to create CDI spec directory: %s", g.specDir)
+	}
+
+	// CDI-aware runtimes may read spec directory at any moment, so spec should be written atomically
+	specPath := g.specPath(specName)
+	tmpPath := filepath.Join(g.specDir, "."+specName+specExt)
+	if err := os.WriteFile(tmpPath, data, specPerm); err != nil {
+		return nil, errors.Wrapf(err, "failed to write CDI spec: %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, specPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, errors.Wrapf(err, "failed to rename CDI spec: %s -> %s", tmpPath, specPath)
+	}
+	logger.Infof("CDI spec written: %s", specPath)
+
+	names := make([]string, 0, len(devices))
+	for _, device := range devices {
+		names = append(names, QualifiedName(g.kind, device.Name))
+	}
+	return names, nil
+}
+
+// RemoveSpec removes specName CDI spec from the spec directory
+func (g *Generator) RemoveSpec(specName string) error {
+	specPath := g.specPath(specName)
+	if err := os.Remove(specPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove CDI spec: %s", specPath)
+	}
+	return nil
+}
+
+func (g *Generator) specPath(specName string) string {
+	return filepath.Join(g.specDir, strings.ReplaceAll(g.kind, "/", "_")+"-"+specName+specExt)
+}
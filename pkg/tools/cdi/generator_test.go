@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdi_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cdi"
+)
+
+const (
+	specName  = "pod-1"
+	tokenName = "service.domain/capability"
+	tokenID1  = "1"
+	tokenID2  = "2"
+)
+
+func TestGenerator_WriteSpec(t *testing.T) {
+	specDir := filepath.Join(t.TempDir(), "cdi")
+
+	g := cdi.NewGenerator(
+		cdi.WithSpecDir(specDir),
+		cdi.WithVFIODir("/host/dev/vfio", "/dev/vfio"),
+		cdi.WithMounts(&cdi.Mount{
+			HostPath:      "/sys/bus/pci",
+			ContainerPath: "/sys/bus/pci",
+			Options:       []string{"ro", "bind"},
+		}),
+	)
+
+	names, err := g.WriteSpec(context.Background(), specName,
+		g.Device("container-1", tokenName, []string{tokenID1, tokenID2}, []uint{1, 2}))
+	require.NoError(t, err)
+	require.Equal(t, []string{cdi.DefaultKind + "=container-1"}, names)
+
+	specPath := filepath.Join(specDir, "networkservicemesh.io_sriov-"+specName+".json")
+	data, err := os.ReadFile(filepath.Clean(specPath))
+	require.NoError(t, err)
+
+	spec := new(cdi.Spec)
+	require.NoError(t, json.Unmarshal(data, spec))
+	require.Equal(t, &cdi.Spec{
+		CDIVersion: cdi.Version,
+		Kind:       cdi.DefaultKind,
+		Devices: []*cdi.Device{
+			{
+				Name: "container-1",
+				ContainerEdits: &cdi.ContainerEdits{
+					Env: []string{"NSM_SRIOV_TOKENS_service.domain/capability=1,2"},
+					DeviceNodes: []*cdi.DeviceNode{
+						{Path: "/dev/vfio/vfio", HostPath: "/host/dev/vfio/vfio", Type: "c", Permissions: "rw"},
+						{Path: "/dev/vfio/1", HostPath: "/host/dev/vfio/1", Type: "c", Permissions: "rw"},
+						{Path: "/dev/vfio/2", HostPath: "/host/dev/vfio/2", Type: "c", Permissions: "rw"},
+					},
+					Mounts: []*cdi.Mount{
+						{HostPath: "/sys/bus/pci", ContainerPath: "/sys/bus/pci", Options: []string{"ro", "bind"}},
+					},
+				},
+			},
+		},
+	}, spec)
+
+	require.NoError(t, g.RemoveSpec(specName))
+	_, err = os.Stat(specPath)
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, g.RemoveSpec(specName))
+}
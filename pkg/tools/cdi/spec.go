@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cdi provides Container Device Interface (CDI) spec generation for the allocated VFIO devices
+package cdi
+
+import (
+	"fmt"
+)
+
+const (
+	// Version is a CDI spec version generated by the package
+	Version = "0.6.0"
+	// DefaultKind is a default CDI device kind
+	DefaultKind = "networkservicemesh.io/sriov"
+	// DefaultSpecDir is a default CDI spec directory
+	DefaultSpecDir = "/var/run/cdi"
+)
+
+// Spec is a CDI spec
+type Spec struct {
+	CDIVersion     string          `json:"cdiVersion"`
+	Kind           string          `json:"kind"`
+	Devices        []*Device       `json:"devices"`
+	ContainerEdits *ContainerEdits `json:"containerEdits,omitempty"`
+}
+
+// Device is a CDI device
+type Device struct {
+	Name           string          `json:"name"`
+	ContainerEdits *ContainerEdits `json:"containerEdits"`
+}
+
+// ContainerEdits is a set of edits applied to the container by the CDI-aware runtime
+type ContainerEdits struct {
+	Env         []string      `json:"env,omitempty"`
+	DeviceNodes []*DeviceNode `json:"deviceNodes,omitempty"`
+	Mounts      []*Mount      `json:"mounts,omitempty"`
+}
+
+// DeviceNode is a CDI device node
+// NOTE: device major, minor numbers are not set, CDI-aware runtime resolves them from the HostPath
+type DeviceNode struct {
+	Path        string `json:"path"`
+	HostPath    string `json:"hostPath,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// Mount is a CDI mount
+type Mount struct {
+	HostPath      string   `json:"hostPath"`
+	ContainerPath string   `json:"containerPath"`
+	Type          string   `json:"type,omitempty"`
+	Options       []string `json:"options,omitempty"`
+}
+
+// QualifiedName returns a fully qualified CDI device name: "vendor.com/class=name"
+func QualifiedName(kind, name string) string {
+	return fmt.Sprintf("%s=%s", kind, name)
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+type bpfInsn struct {
+	code uint8
+	regs uint8 // dst:4 | src:4
+	off  int16
+	imm  int32
+}
+
+type bpfProgLoadAttr struct {
+	progType    uint32
+	insnCnt     uint32
+	insns       uint64
+	license     uint64
+	logLevel    uint32
+	logSize     uint32
+	logBuf      uint64
+	kernVersion uint32
+	progFlags   uint32
+}
+
+type bpfProgQueryAttr struct {
+	targetFD    uint32
+	attachType  uint32
+	queryFlags  uint32
+	attachFlags uint32
+	progIDs     uint64
+	progCnt     uint32
+	_           uint32
+}
+
+type bpfProgGetFDByIDAttr struct {
+	progID uint32
+}
+
+type bpfObjInfoAttr struct {
+	bpfFD   uint32
+	infoLen uint32
+	info    uint64
+}
+
+// bpfProgInfo is the struct bpf_prog_info head
+type bpfProgInfo struct {
+	progType        uint32
+	id              uint32
+	tag             [8]byte
+	jitedProgLen    uint32
+	xlatedProgLen   uint32
+	jitedProgInsns  uint64
+	xlatedProgInsns uint64
+}
+
+type bpfProgAttachAttr struct {
+	targetFD     uint32
+	attachBPFFD  uint32
+	attachType   uint32
+	attachFlags  uint32
+	replaceBPFFD uint32
+}
+
+const (
+	// bpf_insn opcodes used by the device filter program
+	opLdxMemW  = 0x61 // BPF_LDX | BPF_MEM | BPF_W
+	opLdImm64  = 0x18 // BPF_LD | BPF_IMM | BPF_DW
+	opMov64Imm = 0xb7 // BPF_ALU64 | BPF_MOV | BPF_K
+	opMov64Reg = 0xbf // BPF_ALU64 | BPF_MOV | BPF_X
+	opAnd64Imm = 0x57 // BPF_ALU64 | BPF_AND | BPF_K
+	opRsh64Imm = 0x77 // BPF_ALU64 | BPF_RSH | BPF_K
+	opJneImm   = 0x55 // BPF_JMP | BPF_JNE | BPF_K
+	opCall     = 0x85 // BPF_JMP | BPF_CALL
+	opExit     = 0x95 // BPF_JMP | BPF_EXIT
+
+	// struct bpf_cgroup_dev_ctx field offsets
+	devCtxAccessType = 0
+	devCtxMajor      = 4
+	devCtxMinor      = 8
+
+	devAccessAll = unix.BPF_DEVCG_ACC_READ | unix.BPF_DEVCG_ACC_WRITE | unix.BPF_DEVCG_ACC_MKNOD
+
+	maxAttachedProgs = 64
+
+	bpfLicense = "Apache\x00"
+)
+
+type syscallBPF struct{}
+
+func bpfSyscall(cmd int, attr unsafe.Pointer, size uintptr) (int, error) {
+	fd, _, errno := unix.Syscall(unix.SYS_BPF, uintptr(cmd), uintptr(attr), size)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+func (syscallBPF) DeviceFilter(cgroupDir string) (*os.File, uint32, error) {
+	dir, err := os.Open(filepath.Clean(cgroupDir))
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to open cgroup dir: %s", cgroupDir)
+	}
+	defer func() { _ = dir.Close() }()
+
+	progIDs := make([]uint32, maxAttachedProgs)
+	queryAttr := &bpfProgQueryAttr{
+		targetFD:   uint32(dir.Fd()),
+		attachType: unix.BPF_CGROUP_DEVICE,
+		progIDs:    uint64(uintptr(unsafe.Pointer(&progIDs[0]))),
+		progCnt:    uint32(len(progIDs)),
+	}
+	_, err = bpfSyscall(unix.BPF_PROG_QUERY, unsafe.Pointer(queryAttr), unsafe.Sizeof(*queryAttr))
+	runtime.KeepAlive(progIDs)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to query device filter programs: %s", cgroupDir)
+	}
+
+	switch queryAttr.progCnt {
+	case 0:
+		return nil, queryAttr.attachFlags, nil
+	case 1:
+	default:
+		return nil, 0, errors.Errorf("%d device filter programs are attached, expected at most 1: %s",
+			queryAttr.progCnt, cgroupDir)
+	}
+
+	getAttr := &bpfProgGetFDByIDAttr{
+		progID: progIDs[0],
+	}
+	fd, err := bpfSyscall(unix.BPF_PROG_GET_FD_BY_ID, unsafe.Pointer(getAttr), unsafe.Sizeof(*getAttr))
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get device filter program %d: %s", progIDs[0], cgroupDir)
+	}
+	return os.NewFile(uintptr(fd), "device_filter_prog"), queryAttr.attachFlags, nil
+}
+
+func (syscallBPF) LoadDeviceFilter(rules []*Rule, fallback *os.File) (*os.File, error) {
+	fallbackInsns, err := progInsns(fallback)
+	if err != nil {
+		return nil, err
+	}
+	insns, err := deviceFilterInsns(rules, fallbackInsns)
+	if err != nil {
+		return nil, err
+	}
+	license := []byte(bpfLicense)
+
+	attr := &bpfProgLoadAttr{
+		progType: unix.BPF_PROG_TYPE_CGROUP_DEVICE,
+		insnCnt:  uint32(len(insns)),
+		insns:    uint64(uintptr(unsafe.Pointer(&insns[0]))),
+		license:  uint64(uintptr(unsafe.Pointer(&license[0]))),
+	}
+	fd, err := bpfSyscall(unix.BPF_PROG_LOAD, unsafe.Pointer(attr), unsafe.Sizeof(*attr))
+	runtime.KeepAlive(insns)
+	runtime.KeepAlive(license)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load device filter program")
+	}
+	return os.NewFile(uintptr(fd), "device_filter_prog"), nil
+}
+
+func (syscallBPF) ReplaceDeviceFilter(cgroupDir string, prog, old *os.File, attachFlags uint32) error {
+	dir, err := os.Open(filepath.Clean(cgroupDir))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open cgroup dir: %s", cgroupDir)
+	}
+	defer func() { _ = dir.Close() }()
+
+	attr := &bpfProgAttachAttr{
+		targetFD:    uint32(dir.Fd()),
+		attachBPFFD: uint32(prog.Fd()),
+		attachType:  unix.BPF_CGROUP_DEVICE,
+		attachFlags: attachFlags,
+	}
+	// without BPF_F_ALLOW_MULTI the program attached with the same flags replaces the attached one
+	if attachFlags&unix.BPF_F_ALLOW_MULTI != 0 {
+		attr.attachFlags |= unix.BPF_F_REPLACE
+		attr.replaceBPFFD = uint32(old.Fd())
+	}
+	if _, err := bpfSyscall(unix.BPF_PROG_ATTACH, unsafe.Pointer(attr), unsafe.Sizeof(*attr)); err != nil {
+		return errors.Wrapf(err, "failed to replace device filter program: %s", cgroupDir)
+	}
+	return nil
+}
+
+// progInsns returns the translated instructions of the loaded program, the program can't use the maps or helpers:
+// the runtimes device filter programs don't use them
+func progInsns(prog *os.File) ([]bpfInsn, error) {
+	info := new(bpfProgInfo)
+	attr := &bpfObjInfoAttr{
+		bpfFD:   uint32(prog.Fd()),
+		infoLen: uint32(unsafe.Sizeof(*info)),
+		info:    uint64(uintptr(unsafe.Pointer(info))),
+	}
+	if _, err := bpfSyscall(unix.BPF_OBJ_GET_INFO_BY_FD, unsafe.Pointer(attr), unsafe.Sizeof(*attr)); err != nil {
+		return nil, errors.Wrap(err, "failed to get device filter program info")
+	}
+
+	insns := make([]bpfInsn, info.xlatedProgLen/uint32(unsafe.Sizeof(bpfInsn{})))
+	if len(insns) == 0 {
+		return nil, errors.New("device filter program instructions are not available")
+	}
+	*info = bpfProgInfo{
+		xlatedProgLen:   uint32(len(insns)) * uint32(unsafe.Sizeof(bpfInsn{})),
+		xlatedProgInsns: uint64(uintptr(unsafe.Pointer(&insns[0]))),
+	}
+	_, err := bpfSyscall(unix.BPF_OBJ_GET_INFO_BY_FD, unsafe.Pointer(attr), unsafe.Sizeof(*attr))
+	runtime.KeepAlive(insns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get device filter program instructions")
+	}
+
+	for i := range insns {
+		if insns[i].code == opLdImm64 && insns[i].regs>>4 != 0 || insns[i].code == opCall {
+			return nil, errors.Errorf("device filter program using the maps or helpers can't be extended: %d", info.id)
+		}
+	}
+	return insns, nil
+}
+
+// deviceFilterInsns returns the BPF_CGROUP_DEVICE program instructions returning 1 for the device accesses matching
+// the rules, the other device accesses are checked by the fallback program instructions
+func deviceFilterInsns(rules []*Rule, fallbackInsns []bpfInsn) ([]bpfInsn, error) {
+	insns := []bpfInsn{
+		// r6 = ctx
+		{code: opMov64Reg, regs: 6 | 1<<4},
+		// r2 = ctx->access_type & 0xffff (device type)
+		{code: opLdxMemW, regs: 2 | 6<<4, off: devCtxAccessType},
+		// r3 = ctx->access_type >> 16 (access)
+		{code: opMov64Reg, regs: 3 | 2<<4},
+		{code: opAnd64Imm, regs: 2, imm: 0xffff},
+		{code: opRsh64Imm, regs: 3, imm: 16},
+		// r4 = ctx->major
+		{code: opLdxMemW, regs: 4 | 6<<4, off: devCtxMajor},
+		// r5 = ctx->minor
+		{code: opLdxMemW, regs: 5 | 6<<4, off: devCtxMinor},
+	}
+
+	for _, rule := range rules {
+		block, err := ruleInsns(rule)
+		if err != nil {
+			return nil, err
+		}
+		insns = append(insns, block...)
+	}
+
+	// the fallback program expects ctx in r1 and doesn't read the other registers before writing them
+	insns = append(insns, bpfInsn{code: opMov64Reg, regs: 1 | 6<<4})
+	return append(insns, fallbackInsns...), nil
+}
+
+// ruleInsns returns the instructions returning 1 if the device access matches the rule, or continuing to the next
+// instructions
+func ruleInsns(rule *Rule) ([]bpfInsn, error) {
+	var checks []bpfInsn
+	switch rule.Type {
+	case AllDevices:
+	case CharDevice:
+		checks = append(checks, bpfInsn{code: opJneImm, regs: 2, imm: unix.BPF_DEVCG_DEV_CHAR})
+	case BlockDevice:
+		checks = append(checks, bpfInsn{code: opJneImm, regs: 2, imm: unix.BPF_DEVCG_DEV_BLOCK})
+	default:
+		return nil, errors.Errorf("invalid device type: %s", rule.Type)
+	}
+	for _, check := range []struct {
+		reg uint8
+		num string
+	}{{reg: 4, num: rule.Major}, {reg: 5, num: rule.Minor}} {
+		if check.num == AnyDeviceNum {
+			continue
+		}
+		n, err := strconv.ParseUint(check.num, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid device number: %s", check.num)
+		}
+		checks = append(checks, bpfInsn{code: opJneImm, regs: check.reg, imm: int32(uint32(n))})
+	}
+
+	var access int32
+	for mode := range rule.Modes {
+		switch mode {
+		case 'r':
+			access |= unix.BPF_DEVCG_ACC_READ
+		case 'w':
+			access |= unix.BPF_DEVCG_ACC_WRITE
+		case 'm':
+			access |= unix.BPF_DEVCG_ACC_MKNOD
+		}
+	}
+	checks = append(checks,
+		// r7 = access & ^ruleAccess
+		bpfInsn{code: opMov64Reg, regs: 7 | 3<<4},
+		bpfInsn{code: opAnd64Imm, regs: 7, imm: ^access & devAccessAll},
+		bpfInsn{code: opJneImm, regs: 7},
+	)
+
+	insns := append(checks,
+		// return 1
+		bpfInsn{code: opMov64Imm, imm: 1},
+		bpfInsn{code: opExit},
+	)
+	// the failed checks jump to the next rule
+	for i := range checks {
+		if checks[i].code == opJneImm {
+			insns[i].off = int16(len(insns) - i - 1)
+		}
+	}
+	return insns, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package cgroup
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+type syscallBPF struct{}
+
+func (syscallBPF) DeviceFilter(cgroupDir string) (*os.File, uint32, error) {
+	return nil, 0, errors.Wrapf(ErrUnsupported, "failed to query device filter programs: %s", cgroupDir)
+}
+
+func (syscallBPF) LoadDeviceFilter([]*Rule, *os.File) (*os.File, error) {
+	return nil, errors.Wrap(ErrUnsupported, "failed to load device filter program")
+}
+
+func (syscallBPF) ReplaceDeviceFilter(cgroupDir string, _, _ *os.File, _ uint32) error {
+	return errors.Wrapf(ErrUnsupported, "failed to replace device filter program: %s", cgroupDir)
+}
@@ -57,33 +57,65 @@ func NewCgroups(pathPattern string) (cgroups []*Cgroup, err error) {
 	return cgroups, nil
 }
 
+// Dir returns the cgroup directory
+func (c *Cgroup) Dir() string {
+	return c.Path
+}
+
 // Allow allows "c major:minor rwm" for cgroup
 func (c *Cgroup) Allow(major, minor uint32) error {
-	dev := newDevice(major, minor, 'r', 'w', 'm')
+	return c.AllowRule(newDevice(major, minor, 'r', 'w', 'm'))
+}
 
+// Deny denies "c major:minor rw" for cgroup
+func (c *Cgroup) Deny(major, minor uint32) error {
+	return c.DenyRule(newDevice(major, minor, 'r', 'w'))
+}
+
+// AllowRule writes the rule into the cgroup devices.allow
+func (c *Cgroup) AllowRule(rule *Rule) error {
 	filePath := filepath.Join(c.Path, deviceAllowFileName)
-	if err := os.WriteFile(filePath, []byte(dev.String()), 0); err != nil {
+	if err := os.WriteFile(filePath, []byte(rule.String()), 0); err != nil {
 		return errors.Wrapf(err, "failed to write to a %s", filePath)
 	}
 
 	return nil
 }
 
-// Deny denies "c major:minor rw" for cgroup
-func (c *Cgroup) Deny(major, minor uint32) error {
-	dev := newDevice(major, minor, 'r', 'w')
-
-	filePath := filepath.Join(c.Path, deviceAllowFileName)
-	if err := os.WriteFile(filePath, []byte(dev.String()), 0); err != nil {
+// DenyRule writes the rule into the cgroup devices.deny
+func (c *Cgroup) DenyRule(rule *Rule) error {
+	filePath := filepath.Join(c.Path, deviceDenyFileName)
+	if err := os.WriteFile(filePath, []byte(rule.String()), 0); err != nil {
 		return errors.Wrapf(err, "failed to write to a %s", filePath)
 	}
 
 	return nil
 }
 
+// Rules returns the rules from the cgroup devices.list
+func (c *Cgroup) Rules() ([]*Rule, error) {
+	filePath := filepath.Clean(filepath.Join(c.Path, deviceListFileName))
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open file %s", filePath)
+	}
+	defer func() { _ = file.Close() }()
+
+	var rules []*Rule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		rule, err := ParseRule(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
 // IsAllowed returns if "c major:minor rwm" is allowed for cgroup
 func (c *Cgroup) IsAllowed(major, minor uint32) (bool, error) {
-	isAllowed, _, err := c.compareTo(newDevice(major, minor, 'r', 'w', 'm'))
+	isAllowed, _, err := c.CompareRule(newDevice(major, minor, 'r', 'w', 'm'))
 	return isAllowed, err
 }
 
@@ -95,30 +127,28 @@ func (c *Cgroup) IsAllowed(major, minor uint32) (bool, error) {
 //   - "c major:* rwm"
 //   - "c *:* rwm"
 func (c *Cgroup) IsWiderThan(major, minor uint32) (bool, error) {
-	_, isWider, err := c.compareTo(newDevice(major, minor, 'r', 'w', 'm'))
+	_, isWider, err := c.CompareRule(newDevice(major, minor, 'r', 'w', 'm'))
 	return isWider, err
 }
 
-func (c *Cgroup) compareTo(dev *device) (isAllowed, isWider bool, err error) {
-	filePath := filepath.Clean(filepath.Join(c.Path, deviceListFileName))
-	file, err := os.Open(filePath)
+// CompareRule returns if the rule is allowed for cgroup and if cgroup allows wider device group than the rule
+func (c *Cgroup) CompareRule(rule *Rule) (isAllowed, isWider bool, err error) {
+	rules, err := c.Rules()
 	if err != nil {
-		return false, false, errors.Wrapf(err, "failed to open file %s", filePath)
+		return false, false, err
 	}
-	defer func() { _ = file.Close() }()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		d, err := parseDevice(scanner.Text())
-		if err != nil {
-			return false, false, err
-		}
+	isAllowed, isWider = compareRules(rules, rule)
+	return isAllowed, isWider, nil
+}
 
-		if reflect.DeepEqual(d, dev) {
+// compareRules returns if the rule is allowed by the rules and if the rules allow wider device group than the rule
+func compareRules(rules []*Rule, rule *Rule) (isAllowed, isWider bool) {
+	for _, r := range rules {
+		if reflect.DeepEqual(r, rule) {
 			isAllowed = true
-		} else if d.isWiderThan(dev) {
-			return true, true, nil
+		} else if r.IsWiderThan(rule) {
+			return true, true
 		}
 	}
-	return isAllowed, false, nil
+	return isAllowed, false
 }
@@ -124,3 +124,14 @@ func TestCgroup_IsWiderThan(t *testing.T) {
 		})
 	}
 }
+
+func TestRule_String(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		require.Equal(t, "c 1:3 rwm\n", cgroup.NewRule(cgroup.CharDevice, 1, 3, 'm', 'w', 'r').String())
+		require.Equal(t, "c 1:3 rw\n", cgroup.NewRule(cgroup.CharDevice, 1, 3, 'w', 'r').String())
+	}
+
+	rule, err := cgroup.ParseRule("b 8:* mr")
+	require.NoError(t, err)
+	require.Equal(t, "b 8:* rm\n", rule.String())
+}
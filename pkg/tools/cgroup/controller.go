@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const cgroupV2ControllersFileName = "cgroup.controllers"
+
+// ErrUnsupported is an error for the device access operations not supported by the cgroup version or the platform
+var ErrUnsupported = errors.New("not supported by the cgroup version or the platform")
+
+// Version is a cgroup hierarchy version
+type Version int
+
+// Cgroup hierarchy versions
+const (
+	V1 Version = 1
+	V2 Version = 2
+)
+
+// DeviceController is a cgroup device access API
+type DeviceController interface {
+	// Dir returns the cgroup directory
+	Dir() string
+	// AllowRule allows the devices matching the rule for the cgroup
+	AllowRule(rule *Rule) error
+	// DenyRule denies the devices matching the rule for the cgroup
+	DenyRule(rule *Rule) error
+	// Rules returns the current cgroup device rules
+	Rules() ([]*Rule, error)
+	// CompareRule returns if the rule is allowed for cgroup and if cgroup allows wider device group than the rule
+	CompareRule(rule *Rule) (isAllowed, isWider bool, err error)
+}
+
+// BPF is a bpf(2) syscall interface for the cgroup v2 device filter programs
+type BPF interface {
+	// DeviceFilter returns the BPF_CGROUP_DEVICE program attached to the cgroup directory and its attach flags, the
+	// returned program is nil if there is no program attached
+	DeviceFilter(cgroupDir string) (prog *os.File, attachFlags uint32, err error)
+	// LoadDeviceFilter loads the BPF_CGROUP_DEVICE program allowing the device accesses matching the rules and
+	// checking the other device accesses with the fallback program
+	LoadDeviceFilter(rules []*Rule, fallback *os.File) (*os.File, error)
+	// ReplaceDeviceFilter attaches the program to the cgroup directory replacing the old program attached with
+	// attachFlags
+	ReplaceDeviceFilter(cgroupDir string, prog, old *os.File, attachFlags uint32) error
+}
+
+// Option is an option for NewDeviceControllers
+type Option func(o *controllerOptions)
+
+type controllerOptions struct {
+	bpf BPF
+}
+
+// WithBPF sets the bpf(2) syscall implementation used by the cgroup v2 device controllers, used for testing
+func WithBPF(bpf BPF) Option {
+	return func(o *controllerOptions) {
+		o.bpf = bpf
+	}
+}
+
+// DetectVersion returns the cgroup hierarchy version of the cgroup directory
+func DetectVersion(dir string) (Version, error) {
+	if _, err := os.Stat(filepath.Join(dir, deviceListFileName)); err == nil {
+		return V1, nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, cgroupV2ControllersFileName)); err == nil {
+		return V2, nil
+	}
+	return 0, errors.Errorf("not a devices cgroup directory: %s", dir)
+}
+
+// NewDeviceControllers returns device controllers for all cgroups matching pathPattern, both v1 and v2 cgroups are
+// matched
+func NewDeviceControllers(pathPattern string, opts ...Option) ([]DeviceController, error) {
+	o := &controllerOptions{
+		bpf: syscallBPF{},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cgroups, err := NewCgroups(pathPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var controllers []DeviceController
+	for _, cg := range cgroups {
+		controllers = append(controllers, cg)
+	}
+
+	pattern := filepath.Join(pathPattern, cgroupV2ControllersFileName)
+	filePaths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get filepaths %s", pattern)
+	}
+	for _, filePath := range filePaths {
+		controllers = append(controllers, &cgroupV2{path: filepath.Dir(filePath), bpf: o.bpf})
+	}
+
+	return controllers, nil
+}
+
+// cgroupV2 is a cgroup v2 device controller. The cgroup v2 device access is controlled with the BPF_CGROUP_DEVICE
+// program attached by the container runtime. The attached program can't be extended, so the same way as the runtimes
+// update it, it is replaced with the program allowing the device accesses matching the allowed rules and checking the
+// other device accesses with the runtime program. DenyRule revokes only the device accesses allowed by AllowRule and
+// Rules lists only them, the runtime program rules are not known.
+type cgroupV2 struct {
+	path string
+	bpf  BPF
+}
+
+// deviceFilter is the device filter program attached to the cgroup v2 directory by the device controllers
+type deviceFilter struct {
+	runtimeProg *os.File
+	prog        *os.File
+	attachFlags uint32
+	rules       *deviceRules
+}
+
+// deviceFilters are the device filters of the cgroup v2 directories: the device controllers are created for each
+// device access change, but the runtime program and the allowed rules should be kept for the cgroup lifetime
+var deviceFilters = struct {
+	sync.Mutex
+	filters map[string]*deviceFilter // filters[cgroupDir] -> *deviceFilter
+}{
+	filters: map[string]*deviceFilter{},
+}
+
+func (c *cgroupV2) Dir() string {
+	return c.path
+}
+
+func (c *cgroupV2) AllowRule(rule *Rule) error {
+	deviceFilters.Lock()
+	defer deviceFilters.Unlock()
+
+	filter, err := c.filter()
+	if err != nil {
+		return errors.Wrapf(err, "failed to allow %s for the cgroup v2 %s", strings.TrimSpace(rule.String()), c.path)
+	}
+	if filter.runtimeProg == nil {
+		return nil
+	}
+
+	rules := filter.rules.clone()
+	rules.allow(rule)
+	if err := c.replace(filter, rules); err != nil {
+		return errors.Wrapf(err, "failed to allow %s for the cgroup v2 %s", strings.TrimSpace(rule.String()), c.path)
+	}
+	return nil
+}
+
+func (c *cgroupV2) DenyRule(rule *Rule) error {
+	deviceFilters.Lock()
+	defer deviceFilters.Unlock()
+
+	filter, err := c.filter()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deny %s for the cgroup v2 %s", strings.TrimSpace(rule.String()), c.path)
+	}
+	if filter.runtimeProg == nil {
+		return nil
+	}
+
+	rules := filter.rules.clone()
+	rules.deny(rule)
+	if err := c.replace(filter, rules); err != nil {
+		return errors.Wrapf(err, "failed to deny %s for the cgroup v2 %s", strings.TrimSpace(rule.String()), c.path)
+	}
+	return nil
+}
+
+func (c *cgroupV2) Rules() ([]*Rule, error) {
+	deviceFilters.Lock()
+	defer deviceFilters.Unlock()
+
+	filter, err := c.filter()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list rules for the cgroup v2 %s", c.path)
+	}
+	if filter.runtimeProg == nil {
+		// no device filter, all devices are allowed
+		return []*Rule{{
+			Type:  AllDevices,
+			Major: AnyDeviceNum,
+			Minor: AnyDeviceNum,
+			Modes: map[rune]struct{}{'r': {}, 'w': {}, 'm': {}},
+		}}, nil
+	}
+	return filter.rules.clone().devices, nil
+}
+
+func (c *cgroupV2) CompareRule(rule *Rule) (isAllowed, isWider bool, err error) {
+	rules, err := c.Rules()
+	if err != nil {
+		return false, false, err
+	}
+	isAllowed, isWider = compareRules(rules, rule)
+	return isAllowed, isWider, nil
+}
+
+// filter returns the cgroup device filter, it should be called under the deviceFilters lock
+func (c *cgroupV2) filter() (*deviceFilter, error) {
+	for cgroupDir, filter := range deviceFilters.filters {
+		if _, err := os.Stat(cgroupDir); os.IsNotExist(err) {
+			filter.close()
+			delete(deviceFilters.filters, cgroupDir)
+		}
+	}
+
+	if filter, ok := deviceFilters.filters[c.path]; ok {
+		return filter, nil
+	}
+
+	prog, attachFlags, err := c.bpf.DeviceFilter(c.path)
+	if err != nil {
+		return nil, err
+	}
+	filter := &deviceFilter{
+		runtimeProg: prog,
+		prog:        prog,
+		attachFlags: attachFlags,
+		rules:       new(deviceRules),
+	}
+	deviceFilters.filters[c.path] = filter
+	return filter, nil
+}
+
+// replace replaces the attached device filter program with the program allowing the rules
+func (c *cgroupV2) replace(filter *deviceFilter, rules *deviceRules) error {
+	prog, err := c.bpf.LoadDeviceFilter(rules.devices, filter.runtimeProg)
+	if err != nil {
+		return err
+	}
+	if err := c.bpf.ReplaceDeviceFilter(c.path, prog, filter.prog, filter.attachFlags); err != nil {
+		_ = prog.Close()
+		return err
+	}
+
+	if filter.prog != filter.runtimeProg {
+		_ = filter.prog.Close()
+	}
+	filter.prog, filter.rules = prog, rules
+	return nil
+}
+
+func (f *deviceFilter) close() {
+	if f.prog != f.runtimeProg {
+		_ = f.prog.Close()
+	}
+	if f.runtimeProg != nil {
+		_ = f.runtimeProg.Close()
+	}
+}
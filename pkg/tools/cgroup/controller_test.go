@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package cgroup_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
+func TestNewDeviceControllers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createCgroup(t, filepath.Join(tmpDir, "v1"))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "v2"), mkdirPerm))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "v2", "cgroup.controllers"), nil, 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "none"), mkdirPerm))
+
+	version, err := cgroup.DetectVersion(filepath.Join(tmpDir, "v1"))
+	require.NoError(t, err)
+	require.Equal(t, cgroup.V1, version)
+
+	version, err = cgroup.DetectVersion(filepath.Join(tmpDir, "v2"))
+	require.NoError(t, err)
+	require.Equal(t, cgroup.V2, version)
+
+	_, err = cgroup.DetectVersion(filepath.Join(tmpDir, "none"))
+	require.Error(t, err)
+
+	controllers, err := cgroup.NewDeviceControllers(filepath.Join(tmpDir, "*"))
+	require.NoError(t, err)
+	require.Len(t, controllers, 2)
+	require.Equal(t, filepath.Join(tmpDir, "v1"), controllers[0].Dir())
+	require.Equal(t, filepath.Join(tmpDir, "v2"), controllers[1].Dir())
+
+}
+
+type bpfStub struct {
+	dir         string
+	runtimeProg string
+	attachFlags uint32
+	loaded      map[string][]*cgroup.Rule // loaded[prog] -> rules
+	fallbacks   map[string]string         // fallbacks[prog] -> fallback prog
+	attached    string
+}
+
+func (b *bpfStub) file(name string) (*os.File, error) {
+	return os.Create(filepath.Join(b.dir, name))
+}
+
+func (b *bpfStub) DeviceFilter(string) (*os.File, uint32, error) {
+	if b.runtimeProg == "" {
+		return nil, 0, nil
+	}
+	b.attached = b.runtimeProg
+	prog, err := b.file(b.runtimeProg)
+	return prog, b.attachFlags, err
+}
+
+func (b *bpfStub) LoadDeviceFilter(rules []*cgroup.Rule, fallback *os.File) (*os.File, error) {
+	name := fmt.Sprintf("prog-%d", len(b.loaded))
+	b.loaded[name] = rules
+	b.fallbacks[name] = filepath.Base(fallback.Name())
+	return b.file(name)
+}
+
+func (b *bpfStub) ReplaceDeviceFilter(_ string, prog, old *os.File, attachFlags uint32) error {
+	if filepath.Base(old.Name()) != b.attached || attachFlags != b.attachFlags {
+		return errors.Errorf("unexpected replaced program: %s", old.Name())
+	}
+	b.attached = filepath.Base(prog.Name())
+	return nil
+}
+
+func TestCgroupV2_Rules(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "cgroup.controllers"), nil, 0o600))
+
+	bpf := &bpfStub{
+		dir:         t.TempDir(),
+		runtimeProg: "runtime-prog",
+		attachFlags: 2, // BPF_F_ALLOW_MULTI
+		loaded:      map[string][]*cgroup.Rule{},
+		fallbacks:   map[string]string{},
+	}
+	controller := func() cgroup.DeviceController {
+		controllers, err := cgroup.NewDeviceControllers(tmpDir, cgroup.WithBPF(bpf))
+		require.NoError(t, err)
+		require.Len(t, controllers, 1)
+		return controllers[0]
+	}
+
+	rule := cgroup.NewRule(cgroup.CharDevice, 10, 200, 'r', 'w', 'm')
+
+	isAllowed, _, err := controller().CompareRule(rule)
+	require.NoError(t, err)
+	require.False(t, isAllowed)
+
+	// the runtime program is replaced by the program allowing the rule and falling back to the runtime program
+	require.NoError(t, controller().AllowRule(rule))
+	require.Equal(t, "prog-0", bpf.attached)
+	require.Equal(t, []*cgroup.Rule{rule}, bpf.loaded["prog-0"])
+	require.Equal(t, "runtime-prog", bpf.fallbacks["prog-0"])
+
+	isAllowed, isWider, err := controller().CompareRule(rule)
+	require.NoError(t, err)
+	require.True(t, isAllowed)
+	require.False(t, isWider)
+
+	// the denied modes are revoked, the runtime program is still the fallback
+	require.NoError(t, controller().DenyRule(cgroup.NewRule(cgroup.CharDevice, 10, 200, 'r', 'w')))
+	require.Equal(t, "prog-1", bpf.attached)
+	require.Equal(t, []*cgroup.Rule{cgroup.NewRule(cgroup.CharDevice, 10, 200, 'm')}, bpf.loaded["prog-1"])
+	require.Equal(t, "runtime-prog", bpf.fallbacks["prog-1"])
+
+	require.NoError(t, controller().DenyRule(cgroup.NewRule(cgroup.CharDevice, 10, 200, 'm')))
+	rules, err := controller().Rules()
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	// the device filters of the removed cgroups are dropped on the next device filter access
+	require.NoError(t, os.RemoveAll(tmpDir))
+	otherDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "cgroup.controllers"), nil, 0o600))
+	controllers, err := cgroup.NewDeviceControllers(otherDir, cgroup.WithBPF(bpf))
+	require.NoError(t, err)
+	_, err = controllers[0].Rules()
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(tmpDir, mkdirPerm))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "cgroup.controllers"), nil, 0o600))
+	require.NoError(t, controller().AllowRule(rule))
+	require.Equal(t, []*cgroup.Rule{rule}, bpf.loaded["prog-3"])
+	require.Equal(t, "runtime-prog", bpf.fallbacks["prog-3"])
+}
+
+func TestCgroupV2_Rules_NoDeviceFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "cgroup.controllers"), nil, 0o600))
+
+	bpf := &bpfStub{
+		dir:       t.TempDir(),
+		loaded:    map[string][]*cgroup.Rule{},
+		fallbacks: map[string]string{},
+	}
+	controllers, err := cgroup.NewDeviceControllers(tmpDir, cgroup.WithBPF(bpf))
+	require.NoError(t, err)
+	require.Len(t, controllers, 1)
+
+	// all devices are allowed without the device filter program
+	isAllowed, isWider, err := controllers[0].CompareRule(cgroup.NewRule(cgroup.CharDevice, 10, 200, 'r', 'w', 'm'))
+	require.NoError(t, err)
+	require.True(t, isAllowed)
+	require.True(t, isWider)
+
+	require.NoError(t, controllers[0].AllowRule(cgroup.NewRule(cgroup.CharDevice, 10, 200, 'r', 'w', 'm')))
+	require.Empty(t, bpf.loaded)
+}
+
+func TestCgroup_Rules(t *testing.T) {
+	tmpDir := t.TempDir()
+	createCgroup(t, tmpDir)
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, deviceListFileName), []byte("c 1:3 rwm\nb *:* m\n"), 0o600))
+
+	cgroups, err := cgroup.NewCgroups(tmpDir)
+	require.NoError(t, err)
+
+	rules, err := cgroups[0].Rules()
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, cgroup.NewRule(cgroup.CharDevice, 1, 3, 'r', 'w', 'm'), rules[0])
+	require.Equal(t, cgroup.BlockDevice, rules[1].Type)
+	require.Equal(t, cgroup.AnyDeviceNum, rules[1].Major)
+
+	isAllowed, isWider, err := cgroups[0].CompareRule(cgroup.NewRule(cgroup.BlockDevice, 8, 0, 'm'))
+	require.NoError(t, err)
+	require.True(t, isAllowed)
+	require.True(t, isWider)
+
+	isAllowed, isWider, err = cgroups[0].CompareRule(cgroup.NewRule(cgroup.BlockDevice, 8, 0, 'r', 'w'))
+	require.NoError(t, err)
+	require.False(t, isAllowed)
+	require.False(t, isWider)
+}
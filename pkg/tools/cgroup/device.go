@@ -20,6 +20,7 @@
 package cgroup
 
 import (
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -27,16 +28,26 @@ import (
 	"github.com/pkg/errors"
 )
 
-type device struct {
+// Device types
+const (
+	AllDevices   = "a"
+	CharDevice   = "c"
+	BlockDevice  = "b"
+	AnyDeviceNum = "*"
+)
+
+// Rule is a device cgroup rule: "${type} ${major}:${minor} ${modes}", e.g. "c 1:3 rwm", "b *:* m"
+type Rule struct {
 	Type  string
 	Major string
 	Minor string
 	Modes map[rune]struct{}
 }
 
-func newDevice(major, minor uint32, modes ...rune) *device {
-	d := &device{
-		Type:  "c",
+// NewRule returns a new rule for the device type and numbers
+func NewRule(deviceType string, major, minor uint32, modes ...rune) *Rule {
+	d := &Rule{
+		Type:  deviceType,
 		Major: strconv.FormatUint(uint64(major), 10),
 		Minor: strconv.FormatUint(uint64(minor), 10),
 		Modes: make(map[rune]struct{}),
@@ -49,16 +60,20 @@ func newDevice(major, minor uint32, modes ...rune) *device {
 	return d
 }
 
+// deviceModes are the device access modes in the order they are written in the rule string
+var deviceModes = []rune{'r', 'w', 'm'}
+
 var devicePattern = regexp.MustCompile("(?P<type>[abc]) (?P<major>[*0-9]+):(?P<minor>[*0-9]+) (?P<mode>[rwm]+)")
 
-func parseDevice(s string) (*device, error) {
+// ParseRule parses the device cgroup rule string
+func ParseRule(s string) (*Rule, error) {
 	if !devicePattern.MatchString(s) {
 		return nil, errors.Errorf("invalid device string: %s", s)
 	}
 
 	split := devicePattern.FindAllStringSubmatch(s, -1)[0]
 
-	d := &device{
+	d := &Rule{
 		Type:  split[devicePattern.SubexpIndex("type")],
 		Major: split[devicePattern.SubexpIndex("major")],
 		Minor: split[devicePattern.SubexpIndex("minor")],
@@ -72,7 +87,12 @@ func parseDevice(s string) (*device, error) {
 	return d, nil
 }
 
-func (d *device) String() string {
+func newDevice(major, minor uint32, modes ...rune) *Rule {
+	return NewRule(CharDevice, major, minor, modes...)
+}
+
+// String returns the rule string in the devices.allow, devices.deny format
+func (d *Rule) String() string {
 	sb := new(strings.Builder)
 
 	sb.WriteString(d.Type)
@@ -83,18 +103,21 @@ func (d *device) String() string {
 	sb.WriteString(d.Minor)
 	sb.WriteString(" ")
 
-	for mode := range d.Modes {
-		sb.WriteRune(mode)
+	for _, mode := range deviceModes {
+		if _, ok := d.Modes[mode]; ok {
+			sb.WriteRune(mode)
+		}
 	}
 	sb.WriteRune('\n')
 
 	return sb.String()
 }
 
-func (d *device) isWiderThan(other *device) (isWider bool) {
+// IsWiderThan returns if the rule allows a wider device group than the other rule
+func (d *Rule) IsWiderThan(other *Rule) (isWider bool) {
 	switch d.Type {
 	case other.Type:
-	case "a":
+	case AllDevices:
 		isWider = true
 	default:
 		return false
@@ -102,7 +125,7 @@ func (d *device) isWiderThan(other *device) (isWider bool) {
 
 	switch {
 	case d.Major == other.Major:
-	case d.Major == "*":
+	case d.Major == AnyDeviceNum:
 		isWider = true
 	default:
 		return false
@@ -110,7 +133,7 @@ func (d *device) isWiderThan(other *device) (isWider bool) {
 
 	switch {
 	case d.Minor == other.Minor:
-	case d.Minor == "*":
+	case d.Minor == AnyDeviceNum:
 		isWider = true
 	default:
 		return false
@@ -127,3 +150,69 @@ func (d *device) isWiderThan(other *device) (isWider bool) {
 
 	return isWider
 }
+
+// deviceRules is an allowed device rules list with the devices.allow, devices.deny semantics
+type deviceRules struct {
+	devices []*Rule
+}
+
+func (s *deviceRules) String() string {
+	sb := new(strings.Builder)
+	for _, dev := range s.devices {
+		sb.WriteString(dev.String())
+	}
+	return sb.String()
+}
+
+func (s *deviceRules) allow(dev *Rule) {
+	for i := 0; i < len(s.devices); {
+		d := s.devices[i]
+
+		if reflect.DeepEqual(d, dev) || d.IsWiderThan(dev) {
+			return
+		}
+
+		if dev.IsWiderThan(d) {
+			s.devices = append(s.devices[:i], s.devices[i+1:]...)
+			continue
+		}
+
+		i++
+	}
+	s.devices = append(s.devices, dev)
+}
+
+func (s *deviceRules) deny(dev *Rule) {
+	for i := 0; i < len(s.devices); {
+		d := s.devices[i]
+
+		if reflect.DeepEqual(d, dev) || dev.IsWiderThan(d) {
+			s.devices = append(s.devices[:i], s.devices[i+1:]...)
+			continue
+		}
+
+		if d.IsWiderThan(dev) {
+			for mode := range dev.Modes {
+				delete(d.Modes, mode)
+			}
+			if len(d.Modes) == 0 {
+				s.devices = append(s.devices[:i], s.devices[i+1:]...)
+				continue
+			}
+		}
+
+		i++
+	}
+}
+
+func (s *deviceRules) clone() *deviceRules {
+	clone := &deviceRules{}
+	for _, dev := range s.devices {
+		modes := make(map[rune]struct{}, len(dev.Modes))
+		for mode := range dev.Modes {
+			modes[mode] = struct{}{}
+		}
+		clone.devices = append(clone.devices, &Rule{Type: dev.Type, Major: dev.Major, Minor: dev.Minor, Modes: modes})
+	}
+	return clone
+}
@@ -22,17 +22,20 @@ package cgroup
 import (
 	"bufio"
 	"os"
-	"path/filepath"
 	"regexp"
-	"strings"
 
 	"github.com/pkg/errors"
 )
 
 var devicesCgroup = regexp.MustCompile("^[1-9][0-9]*?:devices:(.*)$")
 
-// DirPath returns cgroup dir path pattern matching all pod containers
-func DirPath() (string, error) {
+// DirPath returns cgroup dir path pattern matching all pod containers, the pod cgroup dir is resolved from the current
+// container cgroup dir by the first resolver knowing its layout, DefaultPathResolvers are used if no resolvers are given
+func DirPath(resolvers ...PathResolver) (string, error) {
+	if len(resolvers) == 0 {
+		resolvers = DefaultPathResolvers()
+	}
+
 	cgroupInfo, err := os.Open("/proc/self/cgroup")
 	if err != nil {
 		return "", errors.Wrap(err, "error opening cgroup info file")
@@ -42,15 +45,13 @@ func DirPath() (string, error) {
 	for scanner := bufio.NewScanner(cgroupInfo); scanner.Scan(); {
 		line := scanner.Text()
 		if devicesCgroup.MatchString(line) {
-			return podDirPath(devicesCgroup.FindStringSubmatch(line)[1]), nil
+			containerDirPath := devicesCgroup.FindStringSubmatch(line)[1]
+			if podDirPath, ok := ResolveDirPath(containerDirPath, resolvers...); ok {
+				return podDirPath, nil
+			}
+			return "", errors.Errorf("unknown %s cgroup directory layout: %s", DetectDriver(containerDirPath), containerDirPath)
 		}
 	}
 
 	return "", errors.New("can't find out cgroup directory")
 }
-
-func podDirPath(containerCgroupDirPath string) string {
-	split := strings.Split(containerCgroupDirPath, string(filepath.Separator))
-	split[len(split)-1] = "*" // any container match
-	return filepath.Join(split...)
-}
@@ -25,8 +25,6 @@ import (
 	"context"
 	"os"
 	"path/filepath"
-	"reflect"
-	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -80,7 +78,7 @@ func newFakeCgroup(ctx context.Context, path string) (*Cgroup, supplierFunc, err
 	}()
 
 	var lock sync.Mutex
-	cgStub := new(fakeCgroupDevices)
+	cgStub := new(deviceRules)
 
 	deviceSupplier := outputFileAPI(filepath.Join(path, deviceListFileName))
 	if err := deviceSupplier(""); err != nil {
@@ -91,7 +89,7 @@ func newFakeCgroup(ctx context.Context, path string) (*Cgroup, supplierFunc, err
 		lock.Lock()
 		defer lock.Unlock()
 
-		dev, _ := parseDevice(s)
+		dev, _ := ParseRule(s)
 		cgStub.allow(dev)
 
 		_ = deviceSupplier(cgStub.String())
@@ -103,7 +101,7 @@ func newFakeCgroup(ctx context.Context, path string) (*Cgroup, supplierFunc, err
 		lock.Lock()
 		defer lock.Unlock()
 
-		dev, _ := parseDevice(s)
+		dev, _ := ParseRule(s)
 		cgStub.deny(dev)
 
 		_ = deviceSupplier(cgStub.String())
@@ -121,56 +119,3 @@ func newFakeCgroup(ctx context.Context, path string) (*Cgroup, supplierFunc, err
 
 	return cgroups[0], deviceSupplier, nil
 }
-
-type fakeCgroupDevices struct {
-	devices []*device
-}
-
-func (s *fakeCgroupDevices) String() string {
-	sb := new(strings.Builder)
-	for _, dev := range s.devices {
-		sb.WriteString(dev.String())
-	}
-	return sb.String()
-}
-
-func (s *fakeCgroupDevices) allow(dev *device) {
-	for i := 0; i < len(s.devices); {
-		d := s.devices[i]
-
-		if reflect.DeepEqual(d, dev) || d.isWiderThan(dev) {
-			return
-		}
-
-		if dev.isWiderThan(d) {
-			s.devices = append(s.devices[:i], s.devices[i+1:]...)
-			continue
-		}
-
-		i++
-	}
-	s.devices = append(s.devices, dev)
-}
-
-func (s *fakeCgroupDevices) deny(dev *device) {
-	for i := 0; i < len(s.devices); {
-		d := s.devices[i]
-
-		if reflect.DeepEqual(d, dev) || dev.isWiderThan(d) {
-			s.devices = append(s.devices[:i], s.devices[i+1:]...)
-			continue
-		}
-
-		if d.isWiderThan(dev) {
-			for mode := range dev.Modes {
-				delete(d.Modes, mode)
-			}
-			if len(d.Modes) == 0 {
-				s.devices = append(s.devices[:i], s.devices[i+1:]...)
-				continue
-			}
-		}
-
-		i++
-	}
-}
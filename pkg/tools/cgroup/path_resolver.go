@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroup
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Driver is a cgroup driver used by the container runtime to lay out the container cgroups
+type Driver string
+
+const (
+	// DriverCgroupfs is a cgroupfs driver: /kubepods/burstable/pod${UID}/${CONTAINER_ID}
+	DriverCgroupfs Driver = "cgroupfs"
+	// DriverSystemd is a systemd driver: /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod${UID}.slice/
+	// cri-containerd-${CONTAINER_ID}.scope
+	DriverSystemd Driver = "systemd"
+)
+
+var (
+	systemdPodSlice = regexp.MustCompile(`^(.*-)?kubepods(-[a-z]+)?-pod[0-9a-f_]+\.slice$`)
+	cgroupfsPodDir  = regexp.MustCompile(`^pod[0-9a-f-]+$`)
+)
+
+// DetectDriver returns the cgroup driver used for the container cgroup dir path
+func DetectDriver(containerDirPath string) Driver {
+	for _, dir := range splitDirPath(containerDirPath) {
+		if strings.HasSuffix(dir, ".slice") || strings.HasSuffix(dir, ".scope") {
+			return DriverSystemd
+		}
+	}
+	return DriverCgroupfs
+}
+
+// PathResolver resolves the pod cgroup dir path pattern matching all pod containers from the container cgroup dir path
+type PathResolver interface {
+	// PodDirPath returns the pod cgroup dir path pattern, false if the container cgroup dir path layout is unknown
+	PodDirPath(containerDirPath string) (string, bool)
+}
+
+// PathResolverFunc is a func adapter for the PathResolver
+type PathResolverFunc func(containerDirPath string) (string, bool)
+
+// PodDirPath calls f(containerDirPath)
+func (f PathResolverFunc) PodDirPath(containerDirPath string) (string, bool) {
+	return f(containerDirPath)
+}
+
+var (
+	// SystemdPathResolver resolves the systemd driver paths, the pod slice is looked up by name so the container
+	// runtime scope naming (cri-containerd-*.scope, crio-*.scope, docker-*.scope) and the kind-style parent slices
+	// (/kubelet.slice/kubelet-kubepods.slice/...) don't matter
+	SystemdPathResolver PathResolver = PathResolverFunc(func(containerDirPath string) (string, bool) {
+		return podDirPathByPattern(containerDirPath, systemdPodSlice)
+	})
+	// CgroupfsPathResolver resolves the cgroupfs driver paths, the pod dir is looked up by name so the kind-style
+	// parent dirs (/docker/${NODE_ID}/kubepods/..., /kubelet/kubepods/...) and the nested container dirs don't matter
+	CgroupfsPathResolver PathResolver = PathResolverFunc(func(containerDirPath string) (string, bool) {
+		return podDirPathByPattern(containerDirPath, cgroupfsPodDir)
+	})
+	// ParentPathResolver is a fallback resolver matching all the container parent dir children
+	ParentPathResolver PathResolver = PathResolverFunc(func(containerDirPath string) (string, bool) {
+		split := splitDirPath(containerDirPath)
+		if len(split) == 0 {
+			return "", false
+		}
+		split[len(split)-1] = "*" // any container match
+		return filepath.Join(split...), true
+	})
+)
+
+// DefaultPathResolvers returns the resolvers used by DirPath if no resolvers are given
+func DefaultPathResolvers() []PathResolver {
+	return []PathResolver{SystemdPathResolver, CgroupfsPathResolver, ParentPathResolver}
+}
+
+// ResolveDirPath returns pod cgroup dir path pattern resolved from the container cgroup dir path by the first resolver
+// knowing its layout
+func ResolveDirPath(containerDirPath string, resolvers ...PathResolver) (string, bool) {
+	for _, resolver := range resolvers {
+		if podDirPath, ok := resolver.PodDirPath(containerDirPath); ok {
+			return podDirPath, true
+		}
+	}
+	return "", false
+}
+
+func podDirPathByPattern(containerDirPath string, podDirPattern *regexp.Regexp) (string, bool) {
+	split := splitDirPath(containerDirPath)
+	for i := len(split) - 1; i >= 0; i-- {
+		if podDirPattern.MatchString(split[i]) {
+			return filepath.Join(append(split[:i+1], "*")...), true // any container match
+		}
+	}
+	return "", false
+}
+
+func splitDirPath(dirPath string) []string {
+	var split []string
+	for _, dir := range strings.Split(dirPath, string(filepath.Separator)) {
+		if dir != "" {
+			split = append(split, dir)
+		}
+	}
+	return split
+}
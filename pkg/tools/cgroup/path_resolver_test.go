@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package cgroup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/cgroup"
+)
+
+const podUID = "8dd0f4a3-4a5c-4c5e-a3ee-9a8ba2b2c0c1"
+
+func TestResolveDirPath(t *testing.T) {
+	samples := []struct {
+		name             string
+		containerDirPath string
+		driver           cgroup.Driver
+		podDirPath       string
+	}{
+		{
+			name:             "cgroupfs",
+			containerDirPath: "/kubepods/burstable/pod" + podUID + "/a1b2c3",
+			driver:           cgroup.DriverCgroupfs,
+			podDirPath:       "kubepods/burstable/pod" + podUID + "/*",
+		},
+		{
+			name:             "cgroupfs nested container",
+			containerDirPath: "/kubepods/besteffort/pod" + podUID + "/a1b2c3/nested",
+			driver:           cgroup.DriverCgroupfs,
+			podDirPath:       "kubepods/besteffort/pod" + podUID + "/*",
+		},
+		{
+			name:             "systemd containerd",
+			containerDirPath: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/cri-containerd-a1b2c3.scope",
+			driver:           cgroup.DriverSystemd,
+			podDirPath:       "kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/*",
+		},
+		{
+			name:             "systemd CRI-O",
+			containerDirPath: "/kubepods.slice/kubepods-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/crio-a1b2c3.scope",
+			driver:           cgroup.DriverSystemd,
+			podDirPath:       "kubepods.slice/kubepods-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/*",
+		},
+		{
+			name:             "systemd CRI-O nested container",
+			containerDirPath: "/kubepods.slice/kubepods-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/crio-a1b2c3.scope/container",
+			driver:           cgroup.DriverSystemd,
+			podDirPath:       "kubepods.slice/kubepods-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/*",
+		},
+		{
+			name:             "kind cgroupfs",
+			containerDirPath: "/docker/0f1e2d3c4b5a/kubepods/besteffort/pod" + podUID + "/a1b2c3",
+			driver:           cgroup.DriverCgroupfs,
+			podDirPath:       "docker/0f1e2d3c4b5a/kubepods/besteffort/pod" + podUID + "/*",
+		},
+		{
+			name:             "kind systemd",
+			containerDirPath: "/kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-besteffort.slice/kubelet-kubepods-besteffort-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/cri-containerd-a1b2c3.scope",
+			driver:           cgroup.DriverSystemd,
+			podDirPath:       "kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-besteffort.slice/kubelet-kubepods-besteffort-pod8dd0f4a3_4a5c_4c5e_a3ee_9a8ba2b2c0c1.slice/*",
+		},
+		{
+			name:             "unknown",
+			containerDirPath: "/docker/a1b2c3",
+			driver:           cgroup.DriverCgroupfs,
+			podDirPath:       "docker/*",
+		},
+	}
+
+	for i := range samples {
+		sample := samples[i]
+		t.Run(sample.name, func(t *testing.T) {
+			require.Equal(t, sample.driver, cgroup.DetectDriver(sample.containerDirPath))
+
+			podDirPath, ok := cgroup.ResolveDirPath(sample.containerDirPath, cgroup.DefaultPathResolvers()...)
+			require.True(t, ok)
+			require.Equal(t, sample.podDirPath, podDirPath)
+		})
+	}
+}
+
+func TestResolveDirPath_Resolvers(t *testing.T) {
+	containerDirPath := "/docker/a1b2c3"
+
+	_, ok := cgroup.ResolveDirPath(containerDirPath, cgroup.SystemdPathResolver, cgroup.CgroupfsPathResolver)
+	require.False(t, ok)
+
+	podDirPath, ok := cgroup.ResolveDirPath(containerDirPath, cgroup.SystemdPathResolver,
+		cgroup.PathResolverFunc(func(string) (string, bool) {
+			return "custom/*", true
+		}))
+	require.True(t, ok)
+	require.Equal(t, "custom/*", podDirPath)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkpoint provides kubelet device plugin checkpoint file parser mapping token IDs to the owner pods
+package checkpoint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultPath is a default kubelet device plugin checkpoint file path
+	DefaultPath = "/var/lib/kubelet/device-plugins/kubelet_internal_checkpoint"
+)
+
+// Owner is a container owning the token
+type Owner struct {
+	PodUID        string
+	ContainerName string
+	ResourceName  string
+}
+
+type checkpoint struct {
+	Data struct {
+		PodDeviceEntries []*podDevicesEntry
+	}
+}
+
+type podDevicesEntry struct {
+	PodUID        string
+	ContainerName string
+	ResourceName  string
+	DeviceIDs     json.RawMessage
+}
+
+// Read reads kubelet device plugin checkpoint file and returns owners[tokenID] -> *Owner
+// NOTE: checkpoint checksum is not verified
+func Read(path string) (map[string]*Owner, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read kubelet checkpoint: %s", path)
+	}
+	return Parse(data)
+}
+
+// Parse parses kubelet device plugin checkpoint and returns owners[tokenID] -> *Owner
+func Parse(data []byte) (map[string]*Owner, error) {
+	cp := new(checkpoint)
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal kubelet checkpoint")
+	}
+
+	owners := map[string]*Owner{}
+	for _, entry := range cp.Data.PodDeviceEntries {
+		ids, err := parseDeviceIDs(entry.DeviceIDs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid device IDs for the pod: %s", entry.PodUID)
+		}
+
+		owner := &Owner{
+			PodUID:        entry.PodUID,
+			ContainerName: entry.ContainerName,
+			ResourceName:  entry.ResourceName,
+		}
+		for _, id := range ids {
+			owners[id] = owner
+		}
+	}
+	return owners, nil
+}
+
+// parseDeviceIDs parses both kubelet DeviceIDs formats: []ids (before k8s 1.20) and map[numaNode] -> []ids
+func parseDeviceIDs(data json.RawMessage) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err == nil {
+		return ids, nil
+	}
+
+	var idsByNUMANodes map[string][]string
+	if err := json.Unmarshal(data, &idsByNUMANodes); err != nil {
+		return nil, err
+	}
+	for _, numaIDs := range idsByNUMANodes {
+		ids = append(ids, numaIDs...)
+	}
+	return ids, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/checkpoint"
+)
+
+const (
+	checkpointData = `{
+  "Data": {
+    "PodDeviceEntries": [
+      {
+        "PodUID": "pod-1",
+        "ContainerName": "container-1",
+        "ResourceName": "service.domain/10G",
+        "DeviceIDs": {"0": ["sriov-1"], "1": ["sriov-2"]},
+        "AllocResp": "Cg=="
+      },
+      {
+        "PodUID": "pod-2",
+        "ContainerName": "container-1",
+        "ResourceName": "service.domain/20G",
+        "DeviceIDs": ["sriov-3"],
+        "AllocResp": "Cg=="
+      }
+    ],
+    "RegisteredDevices": {
+      "service.domain/10G": ["sriov-1", "sriov-2"],
+      "service.domain/20G": ["sriov-3"]
+    }
+  },
+  "Checksum": 1
+}`
+)
+
+func TestRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubelet_internal_checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte(checkpointData), 0o600))
+
+	owners, err := checkpoint.Read(path)
+	require.NoError(t, err)
+
+	owner1 := &checkpoint.Owner{PodUID: "pod-1", ContainerName: "container-1", ResourceName: "service.domain/10G"}
+	require.Equal(t, map[string]*checkpoint.Owner{
+		"sriov-1": owner1,
+		"sriov-2": owner1,
+		"sriov-3": {PodUID: "pod-2", ContainerName: "container-1", ResourceName: "service.domain/20G"},
+	}, owners)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := checkpoint.Parse([]byte(`{"Data": {"PodDeviceEntries": [{"DeviceIDs": 1}]}}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package dcb provides the PF DCB (ETS, PFC) configuration reading and the VF 802.1p priority setting used to map the
+// VF traffic to the PF traffic classes
+package dcb
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// NumTCs is the max number of the traffic classes and the number of the 802.1p priorities
+const NumTCs = 8
+
+// Transmission selection algorithms
+const (
+	TSAStrict uint8 = 0
+	TSAShaper uint8 = 1
+	TSAETS    uint8 = 2
+)
+
+// IEEE is the PF IEEE 802.1Qaz (ETS) and 802.1Qbb (PFC) configuration
+type IEEE struct {
+	// PrioTC maps the 802.1p priorities to the traffic classes
+	PrioTC [NumTCs]uint8
+	// TCBandwidth is the ETS bandwidth percentage of the traffic classes
+	TCBandwidth [NumTCs]uint8
+	// TSA is the transmission selection algorithm of the traffic classes
+	TSA [NumTCs]uint8
+	// PFCEnabled is the bitmap of the priorities with PFC enabled
+	PFCEnabled uint8
+}
+
+// TrafficClass returns the traffic class the priority is mapped to
+func (c *IEEE) TrafficClass(priority uint8) uint8 {
+	return c.PrioTC[priority%NumTCs]
+}
+
+// IsPFCEnabled returns if PFC is enabled for the priority
+func (c *IEEE) IsPFCEnabled(priority uint8) bool {
+	return c.PFCEnabled&(1<<(priority%NumTCs)) != 0
+}
+
+// Validate checks that the priority is mapped to the configured traffic class: the traffic class has some bandwidth
+// if it uses ETS, and PFC is enabled for the priority if lossless is required
+func (c *IEEE) Validate(priority uint8, lossless bool) error {
+	if priority >= NumTCs {
+		return errors.Errorf("invalid priority: %d", priority)
+	}
+	tc := c.TrafficClass(priority)
+	if tc >= NumTCs {
+		return errors.Errorf("priority %d is mapped to invalid traffic class: %d", priority, tc)
+	}
+	if c.TSA[tc] == TSAETS && c.TCBandwidth[tc] == 0 {
+		return errors.Errorf("priority %d is mapped to traffic class %d with no ETS bandwidth", priority, tc)
+	}
+	if lossless && !c.IsPFCEnabled(priority) {
+		return errors.Errorf("PFC is not enabled for priority %d", priority)
+	}
+	return nil
+}
+
+// SetVFPriority sets the 802.1p priority for the vfNum VF traffic of the pfName PF keeping the VF VLAN, like
+// `ip link set pfName vf vfNum vlan <VLAN> qos priority`
+func SetVFPriority(configurator vfconfig.Configurator, pfName string, vfNum int, priority uint8) error {
+	vf, err := configurator.VF(pfName, vfNum)
+	if err != nil {
+		return err
+	}
+	if vf.VLAN == 0 && priority != 0 {
+		return errors.Errorf("VF %d has no VLAN to set the priority for: %s", vfNum, pfName)
+	}
+	return configurator.SetVLAN(pfName, vfNum, vf.VLAN, int(priority))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dcb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/dcb"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+func TestIEEE_Validate(t *testing.T) {
+	ieee := &dcb.IEEE{
+		PrioTC:      [dcb.NumTCs]uint8{0, 0, 0, 1, 0, 2, 3, 0},
+		TCBandwidth: [dcb.NumTCs]uint8{50, 50, 0, 0},
+		TSA:         [dcb.NumTCs]uint8{dcb.TSAETS, dcb.TSAETS, dcb.TSAETS, dcb.TSAStrict},
+		PFCEnabled:  1 << 3,
+	}
+
+	require.Equal(t, uint8(1), ieee.TrafficClass(3))
+	require.True(t, ieee.IsPFCEnabled(3))
+	require.False(t, ieee.IsPFCEnabled(5))
+
+	require.NoError(t, ieee.Validate(3, true))
+	require.NoError(t, ieee.Validate(6, false))
+	require.ErrorContains(t, ieee.Validate(5, false), "no ETS bandwidth")
+	require.ErrorContains(t, ieee.Validate(0, true), "PFC is not enabled")
+	require.Error(t, ieee.Validate(8, false))
+}
+
+func TestSetVFPriority(t *testing.T) {
+	configurator := vfconfig.NewFake(2, "pf")
+
+	require.ErrorContains(t, dcb.SetVFPriority(configurator, "pf", 0, 3), "no VLAN")
+	require.NoError(t, dcb.SetVFPriority(configurator, "pf", 0, 0))
+
+	require.NoError(t, configurator.SetVLAN("pf", 1, 100, 0))
+	require.NoError(t, dcb.SetVFPriority(configurator, "pf", 1, 3))
+
+	vf, err := configurator.VF("pf", 1)
+	require.NoError(t, err)
+	require.Equal(t, 100, vf.VLAN)
+	require.Equal(t, 3, vf.QoS)
+
+	require.Error(t, dcb.SetVFPriority(configurator, "pf", 2, 0))
+}
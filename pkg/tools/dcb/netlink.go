@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package dcb
+
+import (
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// dcbnl commands and attributes missing in the netlink packages
+const (
+	rtmGetDCB = 78
+
+	cmdIEEEGet = 21
+
+	attrIfName = 1
+	attrIEEE   = 13
+
+	attrIEEEETS = 1
+	attrIEEEPFC = 2
+
+	sizeofDCBMsg = 4
+)
+
+// struct ieee_ets field offsets
+const (
+	etsTCTxBWOffset  = 3
+	etsTCTSAOffset   = 3 + 2*NumTCs
+	etsPrioTCOffset  = 3 + 3*NumTCs
+	sizeofIEEEETS    = 3 + 7*NumTCs
+	pfcEnabledOffset = 1
+)
+
+// dcbMsg is a struct dcbmsg
+type dcbMsg struct {
+	family uint8
+	cmd    uint8
+}
+
+func (m *dcbMsg) Len() int {
+	return sizeofDCBMsg
+}
+
+func (m *dcbMsg) Serialize() []byte {
+	return []byte{m.family, m.cmd, 0, 0}
+}
+
+// ReadIEEE returns the ifName net interface IEEE DCB configuration
+func ReadIEEE(ifName string) (*IEEE, error) {
+	req := nl.NewNetlinkRequest(rtmGetDCB, unix.NLM_F_REQUEST)
+	req.AddData(&dcbMsg{family: unix.AF_UNSPEC, cmd: cmdIEEEGet})
+	req.AddData(nl.NewRtAttr(attrIfName, nl.ZeroTerminated(ifName)))
+
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get DCB configuration: %s", ifName)
+	}
+	if len(msgs) == 0 || len(msgs[0]) < sizeofDCBMsg {
+		return nil, errors.Errorf("no DCB configuration: %s", ifName)
+	}
+
+	attrs, err := nl.ParseRouteAttr(msgs[0][sizeofDCBMsg:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse DCB configuration: %s", ifName)
+	}
+	for _, attr := range attrs {
+		if attr.Attr.Type&^unix.NLA_F_NESTED == attrIEEE {
+			return parseIEEE(attr)
+		}
+	}
+	return nil, errors.Errorf("no IEEE DCB configuration: %s", ifName)
+}
+
+func parseIEEE(attr syscall.NetlinkRouteAttr) (*IEEE, error) {
+	attrs, err := nl.ParseRouteAttr(attr.Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse IEEE DCB configuration")
+	}
+	ieee := new(IEEE)
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case attrIEEEETS:
+			if len(a.Value) < sizeofIEEEETS {
+				return nil, errors.New("invalid ETS configuration")
+			}
+			copy(ieee.TCBandwidth[:], a.Value[etsTCTxBWOffset:])
+			copy(ieee.TSA[:], a.Value[etsTCTSAOffset:])
+			copy(ieee.PrioTC[:], a.Value[etsPrioTCOffset:])
+		case attrIEEEPFC:
+			if len(a.Value) <= pfcEnabledOffset {
+				return nil, errors.New("invalid PFC configuration")
+			}
+			ieee.PFCEnabled = a.Value[pfcEnabledOffset]
+		}
+	}
+	return ieee, nil
+}
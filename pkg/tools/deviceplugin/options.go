@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package deviceplugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/podresources"
+)
+
+// TokenRecorder is a tokenaccess.Allocations interface
+type TokenRecorder interface {
+	Record(tokenID, owner string)
+	Forget(tokenID string)
+}
+
+// OwnerFunc returns the client identity the token IDs are allocated for
+type OwnerFunc func(ctx context.Context, tokenIDs []string) (string, error)
+
+// HealthMonitor is a hotplug.Monitor interface
+type HealthMonitor interface {
+	AddListener(listener func(event *hotplug.Event))
+}
+
+type serverOptions struct {
+	devicePluginPath    string
+	kubeletSocket       string
+	registrationTimeout time.Duration
+	healthMonitor       HealthMonitor
+	tokenRecorder       TokenRecorder
+	ownerFunc           OwnerFunc
+	syncInterval        time.Duration
+	podResourcesOptions []podresources.Option
+}
+
+// Option is an option for StartServers
+type Option func(o *serverOptions)
+
+// WithDevicePluginPath sets kubelet device plugins directory, device plugin sockets are created there
+func WithDevicePluginPath(devicePluginPath string) Option {
+	return func(o *serverOptions) {
+		o.devicePluginPath = devicePluginPath
+	}
+}
+
+// WithKubeletSocket sets kubelet registration socket path
+func WithKubeletSocket(kubeletSocket string) Option {
+	return func(o *serverOptions) {
+		o.kubeletSocket = kubeletSocket
+	}
+}
+
+// WithRegistrationTimeout sets timeout for the kubelet registration
+func WithRegistrationTimeout(registrationTimeout time.Duration) Option {
+	return func(o *serverOptions) {
+		o.registrationTimeout = registrationTimeout
+	}
+}
+
+// WithHealthMonitor sets PF health monitor, devices for the tokens created for the unhealthy PFs and VFs are reported
+// as unhealthy
+func WithHealthMonitor(healthMonitor HealthMonitor) Option {
+	return func(o *serverOptions) {
+		o.healthMonitor = healthMonitor
+	}
+}
+
+// WithTokenRecorder sets recorder storing the allocated token IDs with the client identity returned by ownerFunc, the
+// forwarder rejects requests presenting the recorded tokens from another client identity
+func WithTokenRecorder(tokenRecorder TokenRecorder, ownerFunc OwnerFunc) Option {
+	return func(o *serverOptions) {
+		o.tokenRecorder = tokenRecorder
+		o.ownerFunc = ownerFunc
+	}
+}
+
+// WithPodResourcesSync enables syncing the allocated tokens with the kubelet PodResources API on start and then every
+// syncInterval, so the tokens allocated or freed by kubelet while the device plugin missed it are corrected and the pods
+// owning the tokens are set as the tokens audit log correlation IDs. The token pool should be a token.Pool.
+func WithPodResourcesSync(syncInterval time.Duration, podResourcesOptions ...podresources.Option) Option {
+	return func(o *serverOptions) {
+		o.syncInterval = syncInterval
+		o.podResourcesOptions = podResourcesOptions
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package deviceplugin
+
+import (
+	"context"
+	"sort"
+
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+)
+
+// GetPreferredAllocation prefers tokens created for the physical functions sharing NUMA node with the devices that
+// must be included into the allocation, rest of the tokens are preferred to be taken from the same NUMA node
+func (s *devicePluginServer) GetPreferredAllocation(
+	_ context.Context,
+	request *pluginapi.PreferredAllocationRequest,
+) (*pluginapi.PreferredAllocationResponse, error) {
+	resp := &pluginapi.PreferredAllocationResponse{}
+	for _, containerRequest := range request.GetContainerRequests() {
+		resp.ContainerResponses = append(resp.ContainerResponses, &pluginapi.ContainerPreferredAllocationResponse{
+			DeviceIDs: s.preferredAllocation(containerRequest),
+		})
+	}
+	return resp, nil
+}
+
+func (s *devicePluginServer) preferredAllocation(request *pluginapi.ContainerPreferredAllocationRequest) []string {
+	size := int(request.GetAllocationSize())
+
+	selected := map[string]struct{}{}
+	includedNodes := map[int]int{} // includedNodes[numaNode] -> count of must include devices
+	var ids []string
+	for _, id := range request.GetMustIncludeDeviceIDs() {
+		selected[id] = struct{}{}
+		ids = append(ids, id)
+		if numaNode := s.numaNode(id); numaNode >= 0 {
+			includedNodes[numaNode]++
+		}
+	}
+
+	candidates := map[string]int{}  // candidates[id] -> numaNode
+	availableNodes := map[int]int{} // availableNodes[numaNode] -> count of available devices
+	var candidateIDs []string
+	for _, id := range request.GetAvailableDeviceIDs() {
+		if _, ok := selected[id]; ok {
+			continue
+		}
+		numaNode := s.numaNode(id)
+		candidates[id] = numaNode
+		candidateIDs = append(candidateIDs, id)
+		if numaNode >= 0 {
+			availableNodes[numaNode]++
+		}
+	}
+
+	sort.Slice(candidateIDs, func(i, k int) bool {
+		leftNode, rightNode := candidates[candidateIDs[i]], candidates[candidateIDs[k]]
+		switch {
+		case includedNodes[leftNode] != includedNodes[rightNode]:
+			return includedNodes[leftNode] > includedNodes[rightNode]
+		case availableNodes[leftNode] != availableNodes[rightNode]:
+			return availableNodes[leftNode] > availableNodes[rightNode]
+		default:
+			return candidateIDs[i] < candidateIDs[k]
+		}
+	})
+
+	for i := 0; len(ids) < size && i < len(candidateIDs); i++ {
+		ids = append(ids, candidateIDs[i])
+	}
+	return ids
+}
+
+func (s *devicePluginServer) numaNode(id string) int {
+	numaNode, err := s.tokenPool.NUMANode(id)
+	if err != nil {
+		return -1
+	}
+	return numaNode
+}
@@ -0,0 +1,370 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package deviceplugin provides kubelet device plugin servers exposing token.Pool tokens as k8s resources
+package deviceplugin
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/podresources"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+const (
+	socketPrefix               = "sriov-"
+	socketSuffix               = ".sock"
+	defaultRegistrationTimeout = 10 * time.Second
+)
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	AddListener(listener func())
+	Tokens() map[string]map[string]bool
+	AllocateOwned(id string) (bool, error)
+	Free(id string) error
+	NUMANode(id string) (int, error)
+	SetHealth(pfPCIAddr string, healthyVFs int)
+}
+
+type devicePluginServer struct {
+	ctx           context.Context
+	name          string
+	tokenPool     TokenPool
+	tokenRecorder TokenRecorder
+	ownerFunc     OwnerFunc
+	updateCh      chan struct{}
+}
+
+// StartServers starts device plugin servers for all tokenPool token names and registers them in kubelet. Servers are
+// stopped on ctx.Done().
+// NOTE: it should be called after token.Pool.Restore, because it accesses tokenPool
+func StartServers(ctx context.Context, tokenPool TokenPool, options ...Option) error {
+	o := &serverOptions{
+		devicePluginPath:    pluginapi.DevicePluginPath,
+		kubeletSocket:       pluginapi.KubeletSocket,
+		registrationTimeout: defaultRegistrationTimeout,
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	if o.syncInterval > 0 {
+		if err := startPodResourcesSync(ctx, tokenPool, o); err != nil {
+			return err
+		}
+	}
+
+	var names []string
+	for name := range tokenPool.Tokens() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var servers []*devicePluginServer
+	for _, name := range names {
+		s := &devicePluginServer{
+			ctx:           ctx,
+			name:          name,
+			tokenPool:     tokenPool,
+			tokenRecorder: o.tokenRecorder,
+			ownerFunc:     o.ownerFunc,
+			updateCh:      make(chan struct{}, 1),
+		}
+		if err := s.start(o); err != nil {
+			return err
+		}
+		servers = append(servers, s)
+	}
+
+	tokenPool.AddListener(func() {
+		for _, s := range servers {
+			s.update()
+		}
+	})
+
+	if o.healthMonitor != nil {
+		o.healthMonitor.AddListener(func(event *hotplug.Event) {
+			tokenPool.SetHealth(event.PFPCIAddr, event.HealthyVFs)
+		})
+	}
+
+	return nil
+}
+
+// startPodResourcesSync syncs tokenPool with the kubelet PodResources API and starts syncing it every o.syncInterval
+// until ctx.Done()
+func startPodResourcesSync(ctx context.Context, tokenPool TokenPool, o *serverOptions) error {
+	syncedPool, ok := tokenPool.(podresources.TokenPool)
+	if !ok {
+		return errors.New("token pool can't be synced with the kubelet PodResources API")
+	}
+	if err := podresources.Sync(ctx, syncedPool, o.podResourcesOptions...); err != nil {
+		return err
+	}
+
+	go func() {
+		logger := log.FromContext(ctx).WithField("devicePluginServer", "podresources")
+
+		ticker := time.NewTicker(o.syncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := podresources.Sync(ctx, syncedPool, o.podResourcesOptions...); err != nil {
+				logger.Warnf("failed to sync tokens: %s", err.Error())
+			}
+		}
+	}()
+
+	return nil
+}
+
+func socketName(tokenName string) string {
+	return socketPrefix + strings.ReplaceAll(tokenName, "/", "-") + socketSuffix
+}
+
+func (s *devicePluginServer) start(o *serverOptions) error {
+	logger := log.FromContext(s.ctx).WithField("devicePluginServer", s.name)
+
+	socket := socketName(s.name)
+	socketPath := filepath.Join(o.devicePluginPath, socket)
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove stale socket: %s", socketPath)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on: %s", socketPath)
+	}
+
+	server := grpc.NewServer()
+	pluginapi.RegisterDevicePluginServer(server, s)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := server.Serve(listener); err != nil {
+			logger.Errorf("device plugin server stopped: %s", err.Error())
+		}
+	}()
+	go func() {
+		<-s.ctx.Done()
+		server.Stop()
+		wg.Wait()
+		_ = os.Remove(socketPath)
+	}()
+
+	return s.register(socket, o)
+}
+
+func (s *devicePluginServer) register(socket string, o *serverOptions) error {
+	ctx, cancel := context.WithTimeout(s.ctx, o.registrationTimeout)
+	defer cancel()
+
+	cc, err := grpc.DialContext(ctx, "unix://"+o.kubeletSocket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dial kubelet: %s", o.kubeletSocket)
+	}
+	defer func() { _ = cc.Close() }()
+
+	if _, err := pluginapi.NewRegistrationClient(cc).Register(ctx, &pluginapi.RegisterRequest{
+		Version:      pluginapi.Version,
+		Endpoint:     socket,
+		ResourceName: s.name,
+		Options:      s.options(),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to register device plugin in kubelet: %s", s.name)
+	}
+
+	return nil
+}
+
+func (s *devicePluginServer) update() {
+	select {
+	case s.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *devicePluginServer) options() *pluginapi.DevicePluginOptions {
+	return &pluginapi.DevicePluginOptions{
+		GetPreferredAllocationAvailable: true,
+	}
+}
+
+func (s *devicePluginServer) GetDevicePluginOptions(_ context.Context, _ *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
+	return s.options(), nil
+}
+
+func (s *devicePluginServer) ListAndWatch(_ *pluginapi.Empty, stream pluginapi.DevicePlugin_ListAndWatchServer) error {
+	for {
+		if err := stream.Send(&pluginapi.ListAndWatchResponse{Devices: s.devices()}); err != nil {
+			return errors.Wrapf(err, "failed to send devices: %s", s.name)
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-stream.Context().Done():
+			return nil
+		case <-s.updateCh:
+		}
+	}
+}
+
+func (s *devicePluginServer) devices() []*pluginapi.Device {
+	var devices []*pluginapi.Device
+	for id, available := range s.tokenPool.Tokens()[s.name] {
+		health := pluginapi.Healthy
+		if !available {
+			health = pluginapi.Unhealthy
+		}
+		device := &pluginapi.Device{
+			ID:     id,
+			Health: health,
+		}
+		if numaNode := s.numaNode(id); numaNode >= 0 {
+			device.Topology = &pluginapi.TopologyInfo{
+				Nodes: []*pluginapi.NUMANode{
+					{ID: int64(numaNode)},
+				},
+			}
+		}
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, k int) bool {
+		return devices[i].ID < devices[k].ID
+	})
+	return devices
+}
+
+func (s *devicePluginServer) Allocate(ctx context.Context, request *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	logger := log.FromContext(ctx).WithField("devicePluginServer", "Allocate")
+
+	resp := &pluginapi.AllocateResponse{}
+	var allocatedIDs []string
+	for _, containerRequest := range request.GetContainerRequests() {
+		for _, id := range containerRequest.GetDevicesIDs() {
+			owned, err := s.tokenPool.AllocateOwned(id)
+			if err != nil {
+				s.free(ctx, allocatedIDs)
+				return nil, err
+			}
+			// the tokens already allocated before the call are not rolled back
+			if owned {
+				allocatedIDs = append(allocatedIDs, id)
+			}
+		}
+		if err := s.record(ctx, containerRequest.GetDevicesIDs()); err != nil {
+			s.free(ctx, allocatedIDs)
+			return nil, err
+		}
+		logger.Infof("allocated tokens: %s -> %v", s.name, containerRequest.GetDevicesIDs())
+
+		envs, err := s.envs(containerRequest.GetDevicesIDs())
+		if err != nil {
+			s.free(ctx, allocatedIDs)
+			return nil, err
+		}
+		resp.ContainerResponses = append(resp.ContainerResponses, &pluginapi.ContainerAllocateResponse{
+			Envs: envs,
+		})
+	}
+	return resp, nil
+}
+
+// envs returns the environment variables storing the tokens both v1 and v2 encoded, v2 encoded tokens carry the NUMA
+// node metadata if it is known
+func (s *devicePluginServer) envs(ids []string) (map[string]string, error) {
+	toks := make([]*tokens.Token, 0, len(ids))
+	for _, id := range ids {
+		tok := &tokens.Token{ID: id}
+		if numaNode := s.numaNode(id); numaNode >= 0 {
+			tok.Metadata = map[string]string{tokens.NUMANodeMetadataKey: strconv.Itoa(numaNode)}
+		}
+		toks = append(toks, tok)
+	}
+
+	nameV2, valueV2, err := tokens.ToEnvV2(s.name, toks)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode tokens: %s -> %v", s.name, ids)
+	}
+	name, value := tokens.ToEnv(s.name, ids)
+	return map[string]string{
+		name:   value,
+		nameV2: valueV2,
+	}, nil
+}
+
+func (s *devicePluginServer) record(ctx context.Context, ids []string) error {
+	if s.tokenRecorder == nil {
+		return nil
+	}
+
+	owner, err := s.ownerFunc(ctx, ids)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get tokens owner: %s -> %v", s.name, ids)
+	}
+	for _, id := range ids {
+		s.tokenRecorder.Record(id, owner)
+	}
+	return nil
+}
+
+// free frees the tokens allocated from the free ones by the failed Allocate, kubelet retries the allocation
+func (s *devicePluginServer) free(ctx context.Context, ids []string) {
+	for _, id := range ids {
+		if s.tokenRecorder != nil {
+			s.tokenRecorder.Forget(id)
+		}
+		if err := s.tokenPool.Free(id); err != nil {
+			log.FromContext(ctx).WithField("devicePluginServer", "Allocate").
+				Warnf("failed to free token: %s: %s", id, err.Error())
+		}
+	}
+}
+
+func (s *devicePluginServer) PreStartContainer(_ context.Context, _ *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
+	return &pluginapi.PreStartContainerResponse{}, nil
+}
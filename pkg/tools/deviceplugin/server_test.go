@@ -0,0 +1,570 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package deviceplugin_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/tokenaccess"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/deviceplugin"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/podresources"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+const (
+	tokenName = "service.domain/10G"
+	tokenID1  = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1"
+	tokenID2  = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2"
+	tokenID3  = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx3"
+)
+
+func TestStartServers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	kubelet := startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {
+				tokenID1: true,
+				tokenID2: true,
+			},
+		},
+		numaNodes: map[string]int{
+			tokenID1: 1,
+		},
+	}
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+	))
+
+	require.Len(t, kubelet.requests, 1)
+	require.Equal(t, tokenName, kubelet.requests[0].GetResourceName())
+
+	cc, err := grpc.DialContext(ctx, "unix://"+filepath.Join(tmpDir, kubelet.requests[0].GetEndpoint()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := pluginapi.NewDevicePluginClient(cc)
+
+	stream, err := client.ListAndWatch(ctx, new(pluginapi.Empty))
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	topology := &pluginapi.TopologyInfo{
+		Nodes: []*pluginapi.NUMANode{{ID: 1}},
+	}
+	require.Equal(t, []*pluginapi.Device{
+		{ID: tokenID1, Health: pluginapi.Healthy, Topology: topology},
+		{ID: tokenID2, Health: pluginapi.Healthy},
+	}, resp.GetDevices())
+
+	tokenPool.close(tokenID2)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []*pluginapi.Device{
+		{ID: tokenID1, Health: pluginapi.Healthy, Topology: topology},
+		{ID: tokenID2, Health: pluginapi.Unhealthy},
+	}, resp.GetDevices())
+
+	allocateResp, err := client.Allocate(ctx, &pluginapi.AllocateRequest{
+		ContainerRequests: []*pluginapi.ContainerAllocateRequest{
+			{DevicesIDs: []string{tokenID1}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"NSM_SRIOV_TOKENS_" + tokenName:    tokenID1,
+		"NSM_SRIOV_TOKENS_V2_" + tokenName: `[{"id":"` + tokenID1 + `","metadata":{"numaNode":"1"}}]`,
+	}, allocateResp.GetContainerResponses()[0].GetEnvs())
+
+	var envs []string
+	for name, value := range allocateResp.GetContainerResponses()[0].GetEnvs() {
+		envs = append(envs, name+"="+value)
+	}
+	require.Equal(t, map[string][]*tokens.Token{
+		tokenName: {{ID: tokenID1, Metadata: map[string]string{tokens.NUMANodeMetadataKey: "1"}}},
+	}, tokens.FromEnvV2(envs))
+	require.Equal(t, []string{tokenID1}, tokenPool.allocated)
+}
+
+func TestStartServers_HealthMonitor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	_ = startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {
+				tokenID1: true,
+			},
+		},
+	}
+	healthMonitor := new(healthMonitorStub)
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+		deviceplugin.WithHealthMonitor(healthMonitor),
+	))
+	require.Len(t, healthMonitor.listeners, 1)
+
+	healthMonitor.listeners[0](&hotplug.Event{
+		PFPCIAddr:  "0000:01:00.0",
+		LinkUp:     true,
+		MissingVFs: []string{"0000:01:00.1"},
+		HealthyVFs: 1,
+	})
+	require.Equal(t, map[string]int{"0000:01:00.0": 1}, tokenPool.healthyVFs)
+}
+
+func TestStartServers_PodResourcesSync(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	podResourcesSocket := filepath.Join(tmpDir, "pod-resources.sock")
+	listener, err := net.Listen("unix", podResourcesSocket)
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	podresourcesapi.RegisterPodResourcesListerServer(server, new(podResourcesStub))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {
+				tokenID1: true,
+			},
+		},
+	}
+
+	require.Error(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+		deviceplugin.WithPodResourcesSync(time.Hour,
+			podresources.WithSocket(filepath.Join(tmpDir, "missing.sock")),
+			podresources.WithConnectTimeout(10*time.Millisecond)),
+	))
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+		deviceplugin.WithPodResourcesSync(10*time.Millisecond, podresources.WithSocket(podResourcesSocket)),
+	))
+	require.Equal(t, map[string][]string{tokenName: {tokenID1}}, tokenPool.syncedTokens())
+
+	require.Eventually(t, func() bool {
+		return tokenPool.syncCount() > 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGetPreferredAllocation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	kubelet := startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {"a": true, "b": true, "c": true, "d": true, "e": true},
+		},
+		numaNodes: map[string]int{"a": 0, "b": 1, "c": 0, "d": 1, "e": 1},
+	}
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+	))
+	require.True(t, kubelet.requests[0].GetOptions().GetGetPreferredAllocationAvailable())
+
+	cc, err := grpc.DialContext(ctx, "unix://"+filepath.Join(tmpDir, kubelet.requests[0].GetEndpoint()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	resp, err := pluginapi.NewDevicePluginClient(cc).GetPreferredAllocation(ctx, &pluginapi.PreferredAllocationRequest{
+		ContainerRequests: []*pluginapi.ContainerPreferredAllocationRequest{
+			{
+				AvailableDeviceIDs:   []string{"a", "b", "c", "d", "e"},
+				MustIncludeDeviceIDs: []string{"a"},
+				AllocationSize:       2,
+			},
+			{
+				AvailableDeviceIDs: []string{"a", "b", "c", "d", "e"},
+				AllocationSize:     2,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, resp.GetContainerResponses()[0].GetDeviceIDs())
+	require.Equal(t, []string{"b", "d"}, resp.GetContainerResponses()[1].GetDeviceIDs())
+}
+
+func TestAllocate_Rollback(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	kubelet := startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {
+				tokenID1: true,
+				tokenID2: true,
+				tokenID3: true,
+			},
+		},
+		// the token allocated before the failed call
+		allocated: []string{tokenID3},
+	}
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+	))
+
+	cc, err := grpc.DialContext(ctx, "unix://"+filepath.Join(tmpDir, kubelet.requests[0].GetEndpoint()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = pluginapi.NewDevicePluginClient(cc).Allocate(ctx, &pluginapi.AllocateRequest{
+		ContainerRequests: []*pluginapi.ContainerAllocateRequest{
+			{DevicesIDs: []string{tokenID1}},
+			{DevicesIDs: []string{tokenID2, tokenID3, "unknown"}},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{tokenID3}, tokenPool.allocated)
+}
+
+func TestAllocate_TokenRecorder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const (
+		ownerID = "spiffe://example.org/ns/default/pod/nsc-1"
+		otherID = "spiffe://example.org/ns/default/pod/nsc-2"
+	)
+
+	tmpDir := t.TempDir()
+	kubelet := startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {
+				tokenID1: true,
+			},
+		},
+	}
+	allocations := tokenaccess.NewAllocations()
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+		deviceplugin.WithTokenRecorder(allocations, func(_ context.Context, tokenIDs []string) (string, error) {
+			require.Equal(t, []string{tokenID1}, tokenIDs)
+			return ownerID, nil
+		}),
+	))
+
+	cc, err := grpc.DialContext(ctx, "unix://"+filepath.Join(tmpDir, kubelet.requests[0].GetEndpoint()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = pluginapi.NewDevicePluginClient(cc).Allocate(ctx, &pluginapi.AllocateRequest{
+		ContainerRequests: []*pluginapi.ContainerAllocateRequest{
+			{DevicesIDs: []string{tokenID1}},
+		},
+	})
+	require.NoError(t, err)
+
+	server := tokenaccess.NewServer(allocations)
+
+	_, err = server.Request(ctx, tokenRequest(t, ownerID, tokenID1))
+	require.NoError(t, err)
+
+	_, err = server.Request(ctx, tokenRequest(t, otherID, tokenID1))
+	require.Error(t, err)
+}
+
+func TestAllocate_TokenRecorder_Error(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	kubelet := startKubelet(t, filepath.Join(tmpDir, "kubelet.sock"))
+
+	tokenPool := &tokenPoolStub{
+		tokens: map[string]map[string]bool{
+			tokenName: {
+				tokenID1: true,
+			},
+		},
+	}
+	allocations := tokenaccess.NewAllocations()
+
+	require.NoError(t, deviceplugin.StartServers(ctx, tokenPool,
+		deviceplugin.WithDevicePluginPath(tmpDir),
+		deviceplugin.WithKubeletSocket(filepath.Join(tmpDir, "kubelet.sock")),
+		deviceplugin.WithTokenRecorder(allocations, func(context.Context, []string) (string, error) {
+			return "", errors.New("failed to get owner")
+		}),
+	))
+
+	cc, err := grpc.DialContext(ctx, "unix://"+filepath.Join(tmpDir, kubelet.requests[0].GetEndpoint()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = pluginapi.NewDevicePluginClient(cc).Allocate(ctx, &pluginapi.AllocateRequest{
+		ContainerRequests: []*pluginapi.ContainerAllocateRequest{
+			{DevicesIDs: []string{tokenID1}},
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, tokenPool.allocated)
+
+	_, ok := allocations.Owner(tokenID1)
+	require.False(t, ok)
+}
+
+func tokenRequest(t *testing.T, spiffeID, tokenID string) *networkservice.NetworkServiceRequest {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+		SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Token: token},
+				},
+			},
+			Mechanism: &networkservice.Mechanism{
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+				},
+			},
+		},
+	}
+}
+
+type kubeletStub struct {
+	requests []*pluginapi.RegisterRequest
+}
+
+func startKubelet(t *testing.T, socketPath string) *kubeletStub {
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	kubelet := new(kubeletStub)
+
+	server := grpc.NewServer()
+	pluginapi.RegisterRegistrationServer(server, kubelet)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return kubelet
+}
+
+func (k *kubeletStub) Register(_ context.Context, request *pluginapi.RegisterRequest) (*pluginapi.Empty, error) {
+	k.requests = append(k.requests, request)
+	return new(pluginapi.Empty), nil
+}
+
+type podResourcesStub struct {
+	podresourcesapi.UnimplementedPodResourcesListerServer
+}
+
+func (s *podResourcesStub) List(_ context.Context, _ *podresourcesapi.ListPodResourcesRequest) (*podresourcesapi.ListPodResourcesResponse, error) {
+	return &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{{
+			Name: "pod-1",
+			Containers: []*podresourcesapi.ContainerResources{{
+				Name: "container-1",
+				Devices: []*podresourcesapi.ContainerDevices{
+					{ResourceName: tokenName, DeviceIds: []string{tokenID1}},
+				},
+			}},
+		}},
+	}, nil
+}
+
+type tokenPoolStub struct {
+	tokens     map[string]map[string]bool
+	numaNodes  map[string]int
+	allocated  []string
+	healthyVFs map[string]int
+	synced     map[string][]string
+	syncs      int
+	listeners  []func()
+	lock       sync.Mutex
+}
+
+func (tp *tokenPoolStub) AddListener(listener func()) {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	tp.listeners = append(tp.listeners, listener)
+}
+
+func (tp *tokenPoolStub) Tokens() map[string]map[string]bool {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	tokens := map[string]map[string]bool{}
+	for name, ids := range tp.tokens {
+		tokens[name] = map[string]bool{}
+		for id, available := range ids {
+			tokens[name][id] = available
+		}
+	}
+	return tokens
+}
+
+func (tp *tokenPoolStub) AllocateOwned(id string) (bool, error) {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	for _, ids := range tp.tokens {
+		if _, ok := ids[id]; ok {
+			for _, allocatedID := range tp.allocated {
+				if allocatedID == id {
+					return false, nil
+				}
+			}
+			tp.allocated = append(tp.allocated, id)
+			return true, nil
+		}
+	}
+	return false, errors.Errorf("token doesn't exist: %s", id)
+}
+
+func (tp *tokenPoolStub) Free(id string) error {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	for i := range tp.allocated {
+		if tp.allocated[i] == id {
+			tp.allocated = append(tp.allocated[:i], tp.allocated[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("token is not allocated: %s", id)
+}
+
+func (tp *tokenPoolStub) NUMANode(id string) (int, error) {
+	if numaNode, ok := tp.numaNodes[id]; ok {
+		return numaNode, nil
+	}
+	return -1, nil
+}
+
+func (tp *tokenPoolStub) SetHealth(pfPCIAddr string, healthyVFs int) {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	if tp.healthyVFs == nil {
+		tp.healthyVFs = map[string]int{}
+	}
+	tp.healthyVFs[pfPCIAddr] = healthyVFs
+}
+
+func (tp *tokenPoolStub) Restore(map[string][]string) error {
+	return errors.New("token pool has already been accessed")
+}
+
+func (tp *tokenPoolStub) Sync(tokens map[string][]string) error {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	tp.synced = tokens
+	tp.syncs++
+	return nil
+}
+
+func (tp *tokenPoolStub) syncedTokens() map[string][]string {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	return tp.synced
+}
+
+func (tp *tokenPoolStub) syncCount() int {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	return tp.syncs
+}
+
+func (tp *tokenPoolStub) close(id string) {
+	tp.lock.Lock()
+	defer tp.lock.Unlock()
+
+	for _, ids := range tp.tokens {
+		if _, ok := ids[id]; ok {
+			ids[id] = false
+		}
+	}
+	for _, listener := range tp.listeners {
+		go listener()
+	}
+}
+
+type healthMonitorStub struct {
+	listeners []func(event *hotplug.Event)
+}
+
+func (m *healthMonitorStub) AddListener(listener func(event *hotplug.Event)) {
+	m.listeners = append(m.listeners, listener)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package devlink provides the devlink operations on the PCI devices used by the switchdev support and the health
+// monitoring: eswitch mode, device parameters, port functions and health reporters
+package devlink
+
+import (
+	"net"
+
+	"github.com/vishvananda/netlink/nl"
+)
+
+// Eswitch modes
+const (
+	EswitchModeLegacy    = "legacy"
+	EswitchModeSwitchdev = "switchdev"
+)
+
+// Device parameter configuration modes
+const (
+	CModeRuntime    uint8 = nl.DEVLINK_PARAM_CMODE_RUNTIME
+	CModeDriverInit uint8 = nl.DEVLINK_PARAM_CMODE_DRIVERINIT
+	CModePermanent  uint8 = nl.DEVLINK_PARAM_CMODE_PERMANENT
+)
+
+// Port flavours
+const (
+	PortFlavourPhysical uint16 = nl.DEVLINK_PORT_FLAVOUR_PHYSICAL
+	PortFlavourPCIPF    uint16 = nl.DEVLINK_PORT_FLAVOUR_PCI_PF
+	PortFlavourPCIVF    uint16 = nl.DEVLINK_PORT_FLAVOUR_PCI_VF
+)
+
+// Port is a devlink port of the PCI device
+type Port struct {
+	Index      uint32
+	Flavour    uint16
+	NetdevName string
+	Function   *PortFunction
+}
+
+// PortFunction is a function of the devlink port, e.g. VF behind the VF representor port
+type PortFunction struct {
+	HwAddr   net.HardwareAddr
+	Active   bool
+	Attached bool
+}
+
+// HealthReporter is a devlink health reporter of the PCI device
+type HealthReporter struct {
+	Name         string
+	Healthy      bool
+	ErrorCount   uint64
+	RecoverCount uint64
+}
+
+// Devlink provides the devlink operations on the PCI devices, pciAddr is the PCI address of the device
+type Devlink interface {
+	// EswitchMode returns the eswitch mode of the device
+	EswitchMode(pciAddr string) (string, error)
+	// SetEswitchMode sets the eswitch mode of the device
+	SetEswitchMode(pciAddr, mode string) error
+	// Param returns the device parameter value in the cmode configuration mode
+	Param(pciAddr, name string, cmode uint8) (interface{}, error)
+	// SetParam sets the device parameter value in the cmode configuration mode
+	SetParam(pciAddr, name string, cmode uint8, value interface{}) error
+	// Ports returns the device ports
+	Ports(pciAddr string) ([]*Port, error)
+	// SetPortFunctionHwAddr sets the hardware address of the port function
+	SetPortFunctionHwAddr(pciAddr string, portIndex uint32, hwAddr net.HardwareAddr) error
+	// SetPortFunctionState activates or deactivates the port function
+	SetPortFunctionState(pciAddr string, portIndex uint32, active bool) error
+	// HealthReporters returns the device health reporters
+	HealthReporters(pciAddr string) ([]*HealthReporter, error)
+	// RecoverHealthReporter starts the recovery of the device health reporter
+	RecoverHealthReporter(pciAddr, name string) error
+}
+
+// Unhealthy returns the names of the device health reporters in the error state
+func Unhealthy(dl Devlink, pciAddr string) ([]string, error) {
+	reporters, err := dl.HealthReporters(pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, r := range reporters {
+		if !r.Healthy {
+			names = append(names, r.Name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package devlink_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/devlink"
+)
+
+const pfPCIAddr = "0000:01:00.0"
+
+func TestFake(t *testing.T) {
+	fake := devlink.NewFake(pfPCIAddr)
+	fake.Devices[pfPCIAddr].Ports = []*devlink.Port{
+		{Index: 1, Flavour: devlink.PortFlavourPCIVF, NetdevName: "eth0_0"},
+	}
+	fake.Devices[pfPCIAddr].HealthReporters = []*devlink.HealthReporter{
+		{Name: "tx", Healthy: true},
+		{Name: "fw", Healthy: false, ErrorCount: 1},
+	}
+
+	var dl devlink.Devlink = fake
+
+	mode, err := dl.EswitchMode(pfPCIAddr)
+	require.NoError(t, err)
+	require.Equal(t, devlink.EswitchModeLegacy, mode)
+
+	require.NoError(t, dl.SetEswitchMode(pfPCIAddr, devlink.EswitchModeSwitchdev))
+	require.Error(t, dl.SetEswitchMode(pfPCIAddr, "invalid"))
+	mode, err = dl.EswitchMode(pfPCIAddr)
+	require.NoError(t, err)
+	require.Equal(t, devlink.EswitchModeSwitchdev, mode)
+
+	require.NoError(t, dl.SetParam(pfPCIAddr, "enable_roce", devlink.CModeDriverInit, true))
+	value, err := dl.Param(pfPCIAddr, "enable_roce", devlink.CModeDriverInit)
+	require.NoError(t, err)
+	require.Equal(t, true, value)
+	_, err = dl.Param(pfPCIAddr, "enable_roce", devlink.CModeRuntime)
+	require.Error(t, err)
+
+	hwAddr, _ := net.ParseMAC("02:00:00:00:00:01")
+	require.NoError(t, dl.SetPortFunctionHwAddr(pfPCIAddr, 1, hwAddr))
+	require.NoError(t, dl.SetPortFunctionState(pfPCIAddr, 1, true))
+	require.Error(t, dl.SetPortFunctionState(pfPCIAddr, 2, true))
+	ports, err := dl.Ports(pfPCIAddr)
+	require.NoError(t, err)
+	require.Equal(t, &devlink.PortFunction{HwAddr: hwAddr, Active: true}, ports[0].Function)
+
+	unhealthy, err := devlink.Unhealthy(dl, pfPCIAddr)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fw"}, unhealthy)
+
+	require.NoError(t, dl.RecoverHealthReporter(pfPCIAddr, "fw"))
+	unhealthy, err = devlink.Unhealthy(dl, pfPCIAddr)
+	require.NoError(t, err)
+	require.Empty(t, unhealthy)
+
+	_, err = dl.EswitchMode("0000:02:00.0")
+	require.Error(t, err)
+}
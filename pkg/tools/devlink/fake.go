@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package devlink
+
+import (
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FakeDevice is a PCI device state of the Fake
+type FakeDevice struct {
+	EswitchMode     string
+	Params          map[string]map[uint8]interface{} // Params[name][cmode] -> value
+	Ports           []*Port
+	HealthReporters []*HealthReporter
+}
+
+// Fake is a Devlink implementation for testing storing the PCI devices state in memory
+type Fake struct {
+	Devices map[string]*FakeDevice // Devices[pciAddr] -> device
+
+	lock sync.Mutex
+}
+
+// NewFake returns a new Fake with the devices in the legacy eswitch mode
+func NewFake(pciAddrs ...string) *Fake {
+	f := &Fake{
+		Devices: map[string]*FakeDevice{},
+	}
+	for _, pciAddr := range pciAddrs {
+		f.Devices[pciAddr] = &FakeDevice{
+			EswitchMode: EswitchModeLegacy,
+			Params:      map[string]map[uint8]interface{}{},
+		}
+	}
+	return f
+}
+
+// EswitchMode returns the device eswitch mode
+func (f *Fake) EswitchMode(pciAddr string) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return "", err
+	}
+	return dev.EswitchMode, nil
+}
+
+// SetEswitchMode sets the device eswitch mode
+func (f *Fake) SetEswitchMode(pciAddr, mode string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return err
+	}
+	if mode != EswitchModeLegacy && mode != EswitchModeSwitchdev {
+		return errors.Errorf("invalid eswitch mode: %s", mode)
+	}
+	dev.EswitchMode = mode
+	return nil
+}
+
+// Param returns the device parameter value
+func (f *Fake) Param(pciAddr, name string, cmode uint8) (interface{}, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := dev.Params[name][cmode]
+	if !ok {
+		return nil, errors.Errorf("devlink param %s has no value for the cmode %d: %s", name, cmode, pciAddr)
+	}
+	return value, nil
+}
+
+// SetParam sets the device parameter value
+func (f *Fake) SetParam(pciAddr, name string, cmode uint8, value interface{}) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return err
+	}
+	if dev.Params[name] == nil {
+		dev.Params[name] = map[uint8]interface{}{}
+	}
+	dev.Params[name][cmode] = value
+	return nil
+}
+
+// Ports returns the device ports
+func (f *Fake) Ports(pciAddr string) ([]*Port, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	return dev.Ports, nil
+}
+
+// SetPortFunctionHwAddr sets the port function hardware address
+func (f *Fake) SetPortFunctionHwAddr(pciAddr string, portIndex uint32, hwAddr net.HardwareAddr) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fn, err := f.portFunction(pciAddr, portIndex)
+	if err != nil {
+		return err
+	}
+	fn.HwAddr = hwAddr
+	return nil
+}
+
+// SetPortFunctionState sets the port function state
+func (f *Fake) SetPortFunctionState(pciAddr string, portIndex uint32, active bool) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fn, err := f.portFunction(pciAddr, portIndex)
+	if err != nil {
+		return err
+	}
+	fn.Active = active
+	return nil
+}
+
+// HealthReporters returns the device health reporters
+func (f *Fake) HealthReporters(pciAddr string) ([]*HealthReporter, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	return dev.HealthReporters, nil
+}
+
+// RecoverHealthReporter recovers the device health reporter
+func (f *Fake) RecoverHealthReporter(pciAddr, name string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return err
+	}
+	for _, r := range dev.HealthReporters {
+		if r.Name == name {
+			r.Healthy = true
+			r.RecoverCount++
+			return nil
+		}
+	}
+	return errors.Errorf("no devlink health reporter %s: %s", name, pciAddr)
+}
+
+func (f *Fake) device(pciAddr string) (*FakeDevice, error) {
+	dev, ok := f.Devices[pciAddr]
+	if !ok {
+		return nil, errors.Errorf("no devlink device: %s", pciAddr)
+	}
+	return dev, nil
+}
+
+func (f *Fake) portFunction(pciAddr string, portIndex uint32) (*PortFunction, error) {
+	dev, err := f.device(pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range dev.Ports {
+		if p.Index == portIndex {
+			if p.Function == nil {
+				p.Function = new(PortFunction)
+			}
+			return p.Function, nil
+		}
+	}
+	return nil, errors.Errorf("no devlink port %d: %s", portIndex, pciAddr)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package devlink
+
+import (
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+const pciBus = "pci"
+
+// devlink health reporter commands and attributes missing in the netlink package
+const (
+	cmdHealthReporterGet     = 52
+	cmdHealthReporterRecover = 54
+
+	attrHealthReporter             = 114
+	attrHealthReporterName         = 115
+	attrHealthReporterState        = 116
+	attrHealthReporterErrCount     = 117
+	attrHealthReporterRecoverCount = 118
+
+	healthReporterStateHealthy = 0
+)
+
+type netlinkDevlink struct{}
+
+// NewNetlink returns a new Devlink implemented with the devlink generic netlink family
+func NewNetlink() Devlink {
+	return netlinkDevlink{}
+}
+
+func (netlinkDevlink) EswitchMode(pciAddr string) (string, error) {
+	dev, err := netlink.DevLinkGetDeviceByName(pciBus, pciAddr)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get devlink device: %s", pciAddr)
+	}
+	return dev.Attrs.Eswitch.Mode, nil
+}
+
+func (netlinkDevlink) SetEswitchMode(pciAddr, mode string) error {
+	dev, err := netlink.DevLinkGetDeviceByName(pciBus, pciAddr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get devlink device: %s", pciAddr)
+	}
+	if err := netlink.DevLinkSetEswitchMode(dev, mode); err != nil {
+		return errors.Wrapf(err, "failed to set eswitch mode %s: %s", mode, pciAddr)
+	}
+	return nil
+}
+
+func (netlinkDevlink) Param(pciAddr, name string, cmode uint8) (interface{}, error) {
+	param, err := netlink.DevlinkGetDeviceParamByName(pciBus, pciAddr, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get devlink param %s: %s", name, pciAddr)
+	}
+	for _, value := range param.Values {
+		if value.CMODE == cmode {
+			return value.Data, nil
+		}
+	}
+	return nil, errors.Errorf("devlink param %s has no value for the cmode %d: %s", name, cmode, pciAddr)
+}
+
+func (netlinkDevlink) SetParam(pciAddr, name string, cmode uint8, value interface{}) error {
+	if err := netlink.DevlinkSetDeviceParam(pciBus, pciAddr, name, cmode, value); err != nil {
+		return errors.Wrapf(err, "failed to set devlink param %s: %s", name, pciAddr)
+	}
+	return nil
+}
+
+func (netlinkDevlink) Ports(pciAddr string) ([]*Port, error) {
+	devlinkPorts, err := netlink.DevLinkGetAllPortList()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get devlink ports")
+	}
+	var ports []*Port
+	for _, p := range devlinkPorts {
+		if p.BusName != pciBus || p.DeviceName != pciAddr {
+			continue
+		}
+		port := &Port{
+			Index:      p.PortIndex,
+			Flavour:    p.PortFlavour,
+			NetdevName: p.NetdeviceName,
+		}
+		if p.Fn != nil {
+			port.Function = &PortFunction{
+				HwAddr:   p.Fn.HwAddr,
+				Active:   p.Fn.State == nl.DEVLINK_PORT_FN_STATE_ACTIVE,
+				Attached: p.Fn.OpState == nl.DEVLINK_PORT_FN_OPSTATE_ATTACHED,
+			}
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+func (netlinkDevlink) SetPortFunctionHwAddr(pciAddr string, portIndex uint32, hwAddr net.HardwareAddr) error {
+	if err := netlink.DevlinkPortFnSet(pciBus, pciAddr, portIndex, netlink.DevlinkPortFnSetAttrs{
+		FnAttrs:     netlink.DevlinkPortFn{HwAddr: hwAddr},
+		HwAddrValid: true,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set port %d function hardware address: %s", portIndex, pciAddr)
+	}
+	return nil
+}
+
+func (netlinkDevlink) SetPortFunctionState(pciAddr string, portIndex uint32, active bool) error {
+	state := uint8(nl.DEVLINK_PORT_FN_STATE_INACTIVE)
+	if active {
+		state = nl.DEVLINK_PORT_FN_STATE_ACTIVE
+	}
+	if err := netlink.DevlinkPortFnSet(pciBus, pciAddr, portIndex, netlink.DevlinkPortFnSetAttrs{
+		FnAttrs:    netlink.DevlinkPortFn{State: state},
+		StateValid: true,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set port %d function state: %s", portIndex, pciAddr)
+	}
+	return nil
+}
+
+func (netlinkDevlink) HealthReporters(pciAddr string) ([]*HealthReporter, error) {
+	req, err := newRequest(cmdHealthReporterGet, unix.NLM_F_REQUEST|unix.NLM_F_DUMP, pciAddr)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get devlink health reporters: %s", pciAddr)
+	}
+
+	// The dump request is not filtered by the device on the older kernels, so the reporters of all the devices are
+	// returned and the ones of the other devices are skipped
+	var reporters []*HealthReporter
+	for _, msg := range msgs {
+		attrs, err := nl.ParseRouteAttr(msg[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse devlink health reporters: %s", pciAddr)
+		}
+		var busName, devName string
+		var msgReporters []*HealthReporter
+		for _, attr := range attrs {
+			switch attr.Attr.Type &^ unix.NLA_F_NESTED {
+			case nl.DEVLINK_ATTR_BUS_NAME:
+				busName = parseString(attr.Value)
+			case nl.DEVLINK_ATTR_DEV_NAME:
+				devName = parseString(attr.Value)
+			case attrHealthReporter:
+				reporter, err := parseHealthReporter(attr)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to parse devlink health reporter: %s", pciAddr)
+				}
+				msgReporters = append(msgReporters, reporter)
+			}
+		}
+		if busName == pciBus && devName == pciAddr {
+			reporters = append(reporters, msgReporters...)
+		}
+	}
+	return reporters, nil
+}
+
+func (netlinkDevlink) RecoverHealthReporter(pciAddr, name string) error {
+	req, err := newRequest(cmdHealthReporterRecover, unix.NLM_F_REQUEST|unix.NLM_F_ACK, pciAddr)
+	if err != nil {
+		return err
+	}
+	req.AddData(nl.NewRtAttr(attrHealthReporterName, nl.ZeroTerminated(name)))
+	if _, err := req.Execute(unix.NETLINK_GENERIC, 0); err != nil {
+		return errors.Wrapf(err, "failed to recover devlink health reporter %s: %s", name, pciAddr)
+	}
+	return nil
+}
+
+func newRequest(cmd uint8, flags int, pciAddr string) (*nl.NetlinkRequest, error) {
+	family, err := netlink.GenlFamilyGet(nl.GENL_DEVLINK_NAME)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get devlink generic netlink family")
+	}
+	req := nl.NewNetlinkRequest(int(family.ID), flags)
+	req.AddData(&nl.Genlmsg{
+		Command: cmd,
+		Version: nl.GENL_DEVLINK_VERSION,
+	})
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_BUS_NAME, nl.ZeroTerminated(pciBus)))
+	req.AddData(nl.NewRtAttr(nl.DEVLINK_ATTR_DEV_NAME, nl.ZeroTerminated(pciAddr)))
+	return req, nil
+}
+
+func parseHealthReporter(attr syscall.NetlinkRouteAttr) (*HealthReporter, error) {
+	attrs, err := nl.ParseRouteAttr(attr.Value)
+	if err != nil {
+		return nil, err
+	}
+	reporter := new(HealthReporter)
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case attrHealthReporterName:
+			reporter.Name = parseString(a.Value)
+		case attrHealthReporterState:
+			reporter.Healthy = a.Value[0] == healthReporterStateHealthy
+		case attrHealthReporterErrCount:
+			reporter.ErrorCount = nl.NativeEndian().Uint64(a.Value)
+		case attrHealthReporterRecoverCount:
+			reporter.RecoverCount = nl.NativeEndian().Uint64(a.Value)
+		}
+	}
+	return reporter, nil
+}
+
+// parseString parses the zero terminated string attribute value
+func parseString(value []byte) string {
+	return strings.TrimRight(string(value), "\x00")
+}
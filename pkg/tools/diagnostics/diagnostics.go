@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics provides the forwarder SR-IOV diagnostics bundle and pprof HTTP endpoints for the field issues
+// support
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	runtimepprof "runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+)
+
+// HTTP handler paths
+const (
+	// BundlePath is the HTTP path serving the diagnostics bundle
+	BundlePath = "/debug/sriov/bundle"
+	// PprofPath is the HTTP path prefix serving the pprof profiles
+	PprofPath = "/debug/pprof/"
+)
+
+// Bundle files names
+const (
+	ConfigFile     = "config.yaml"
+	StateFile      = "state.json"
+	DriversFile    = "drivers.json"
+	GoroutinesFile = "goroutines.txt"
+)
+
+// StateSource is a sriovadmin.Server interface
+type StateSource interface {
+	State() *sriovadmin.State
+}
+
+// PCIPool is a pci.Pool interface
+type PCIPool interface {
+	GetPCIFunction(pciAddr string) (sriov.PCIFunction, error)
+}
+
+type boundDriverFunction interface {
+	GetBoundDriver() (string, error)
+}
+
+// VFDriverState is the configured VF driver binding state read from sysfs
+type VFDriverState struct {
+	PFPCIAddress string `json:"pfPciAddress"`
+	VFPCIAddress string `json:"vfPciAddress"`
+	IOMMUGroup   uint   `json:"iommuGroup"`
+	Driver       string `json:"driver"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Diagnostics produces the diagnostics bundle: tar.gz archive with the SR-IOV config, the forwarder state with the pools
+// snapshots and the recent events, the configured VFs driver binding state and the goroutines dump. Sections for the
+// not configured sources are omitted.
+type Diagnostics struct {
+	cfg     *config.Config
+	state   StateSource
+	pciPool PCIPool
+	lock    sync.Mutex
+}
+
+// Option is an option for the Diagnostics
+type Option func(d *Diagnostics)
+
+// WithConfig sets the SR-IOV config to bundle and to read the configured VFs driver binding state for
+func WithConfig(cfg *config.Config) Option {
+	return func(d *Diagnostics) {
+		d.cfg = cfg
+	}
+}
+
+// WithState sets the forwarder state source
+func WithState(state StateSource) Option {
+	return func(d *Diagnostics) {
+		d.state = state
+	}
+}
+
+// WithPCIPool sets the PCI pool to read the configured VFs driver binding state
+func WithPCIPool(pciPool PCIPool) Option {
+	return func(d *Diagnostics) {
+		d.pciPool = pciPool
+	}
+}
+
+// New returns a new Diagnostics
+func New(options ...Option) *Diagnostics {
+	d := new(Diagnostics)
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// SetState sets the forwarder state source once it is created
+func (d *Diagnostics) SetState(state StateSource) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.state = state
+}
+
+// WriteBundle writes the diagnostics bundle into the w
+func (d *Diagnostics) WriteBundle(w io.Writer) error {
+	d.lock.Lock()
+	state := d.state
+	d.lock.Unlock()
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+
+	addFile := func(name string, data []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to write bundle file header: %s", name)
+		}
+		if _, err := tarWriter.Write(data); err != nil {
+			return errors.Wrapf(err, "failed to write bundle file: %s", name)
+		}
+		return nil
+	}
+
+	if d.cfg != nil {
+		data, err := yaml.Marshal(d.cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal config")
+		}
+		if err := addFile(ConfigFile, data); err != nil {
+			return err
+		}
+	}
+	if state != nil {
+		if err := addJSONFile(addFile, StateFile, state.State()); err != nil {
+			return err
+		}
+	}
+	if d.cfg != nil && d.pciPool != nil {
+		if err := addJSONFile(addFile, DriversFile, d.DriverStates()); err != nil {
+			return err
+		}
+	}
+
+	goroutines := new(bytes.Buffer)
+	if err := runtimepprof.Lookup("goroutine").WriteTo(goroutines, 2); err != nil {
+		return errors.Wrap(err, "failed to dump goroutines")
+	}
+	if err := addFile(GoroutinesFile, goroutines.Bytes()); err != nil {
+		return err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to close bundle archive")
+	}
+	return errors.Wrap(gzipWriter.Close(), "failed to close bundle archive")
+}
+
+// DriverStates returns the driver binding state of the configured VFs ordered by PCI addresses
+func (d *Diagnostics) DriverStates() []*VFDriverState {
+	var states []*VFDriverState
+	for pfPCIAddr, pfCfg := range d.cfg.PhysicalFunctions {
+		for _, vfCfg := range pfCfg.VirtualFunctions {
+			state := &VFDriverState{
+				PFPCIAddress: pfPCIAddr,
+				VFPCIAddress: vfCfg.Address,
+				IOMMUGroup:   vfCfg.IOMMUGroup,
+			}
+			states = append(states, state)
+
+			vf, err := d.pciPool.GetPCIFunction(vfCfg.Address)
+			if err != nil {
+				state.Error = err.Error()
+				continue
+			}
+			if boundDriverVF, ok := vf.(boundDriverFunction); ok {
+				if state.Driver, err = boundDriverVF.GetBoundDriver(); err != nil {
+					state.Error = err.Error()
+				}
+			}
+		}
+	}
+	sort.Slice(states, func(i, k int) bool {
+		return states[i].VFPCIAddress < states[k].VFPCIAddress
+	})
+	return states
+}
+
+// Handler returns HTTP handler serving the diagnostics bundle on BundlePath and the pprof profiles under PprofPath
+func (d *Diagnostics) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(BundlePath, func(w http.ResponseWriter, _ *http.Request) {
+		bundle := new(bytes.Buffer)
+		if err := d.WriteBundle(bundle); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", `attachment; filename="sriov-diagnostics.tar.gz"`)
+		_, _ = w.Write(bundle.Bytes())
+	})
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	return mux
+}
+
+func addJSONFile(addFile func(name string, data []byte) error, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", name)
+	}
+	return addFile(name, data)
+}
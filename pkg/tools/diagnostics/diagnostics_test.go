@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/diagnostics"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+)
+
+const (
+	configFileName = "config.yml"
+	vf11PciAddr    = "0000:01:00.1"
+	vfKernelDriver = "vf-driver"
+)
+
+func TestDiagnostics_Handler(t *testing.T) {
+	cfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+	require.NoError(t, err)
+
+	d := diagnostics.New(
+		diagnostics.WithConfig(cfg),
+		diagnostics.WithPCIPool(pciPool),
+	)
+	d.SetState(sriovadmin.NewServer(sriovadmin.WithTokenPool(token.NewPool(cfg))))
+
+	server := httptest.NewServer(d.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + diagnostics.BundlePath)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	files := readBundle(t, resp.Body)
+	require.Contains(t, files, diagnostics.ConfigFile)
+	require.Contains(t, string(files[diagnostics.GoroutinesFile]), "goroutine")
+
+	state := new(sriovadmin.State)
+	require.NoError(t, json.Unmarshal(files[diagnostics.StateFile], state))
+	require.Equal(t, 5, state.Tokens["service.domain.2/intel"]["free"])
+
+	var drivers []*diagnostics.VFDriverState
+	require.NoError(t, json.Unmarshal(files[diagnostics.DriversFile], &drivers))
+	require.Len(t, drivers, 6)
+	require.Equal(t, &diagnostics.VFDriverState{
+		PFPCIAddress: "0000:01:00.0",
+		VFPCIAddress: vf11PciAddr,
+		IOMMUGroup:   1,
+		Driver:       vfKernelDriver,
+	}, drivers[0])
+
+	pprofResp, err := http.Get(server.URL + diagnostics.PprofPath)
+	require.NoError(t, err)
+	_ = pprofResp.Body.Close()
+	require.Equal(t, http.StatusOK, pprofResp.StatusCode)
+}
+
+func readBundle(t *testing.T, r io.Reader) map[string][]byte {
+	gzipReader, err := gzip.NewReader(r)
+	require.NoError(t, err)
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		files[header.Name], err = io.ReadAll(tarReader)
+		require.NoError(t, err)
+	}
+	return files
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventbus provides the event bus the SR-IOV pools and monitors publish their state changes to, so the
+// metrics, admin API and healing hooks can subscribe to all of them the same way
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// watchBufferSize is the number of the events buffered for a watcher before the new ones are dropped
+const watchBufferSize = 64
+
+// Topic is an event topic, topics are defined by the publishers along with their payload types
+type Topic string
+
+// Event is a published event
+type Event struct {
+	Topic   Topic
+	Time    time.Time
+	Payload interface{}
+}
+
+// Handler handles the events
+type Handler func(event *Event)
+
+type subscription struct {
+	handler Handler
+	topics  map[Topic]struct{}
+}
+
+func (s *subscription) matches(topic Topic) bool {
+	if len(s.topics) == 0 {
+		return true
+	}
+	_, ok := s.topics[topic]
+	return ok
+}
+
+// Bus delivers the published events to the subscribers in the subscription order
+type Bus struct {
+	subscriptions []*subscription
+	lock          sync.RWMutex
+}
+
+// New returns a new Bus
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds the handler for the events of the topics, of all the topics if there are none. Returned func removes
+// the handler.
+func (b *Bus) Subscribe(handler Handler, topics ...Topic) (unsubscribe func()) {
+	s := &subscription{
+		handler: handler,
+		topics:  map[Topic]struct{}{},
+	}
+	for _, topic := range topics {
+		s.topics[topic] = struct{}{}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.subscriptions = append(b.subscriptions, s)
+
+	return func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		for i := range b.subscriptions {
+			if b.subscriptions[i] == s {
+				b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish publishes a new event of the topic with the payload. Handlers are called synchronously in the Publish
+// caller goroutine, so publishers shouldn't hold the locks the handlers can wait for.
+func (b *Bus) Publish(topic Topic, payload interface{}) {
+	event := &Event{
+		Topic:   topic,
+		Time:    time.Now(),
+		Payload: payload,
+	}
+
+	b.lock.RLock()
+	var handlers []Handler
+	for _, s := range b.subscriptions {
+		if s.matches(topic) {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	b.lock.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// Watch returns a channel receiving the events of the topics, of all the topics if there are none, until the ctx is
+// done. Events are dropped if the watcher doesn't keep up with them.
+func (b *Bus) Watch(ctx context.Context, topics ...Topic) <-chan *Event {
+	ch := make(chan *Event, watchBufferSize)
+
+	var lock sync.Mutex
+	var closed bool
+	unsubscribe := b.Subscribe(func(event *Event) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if closed {
+			return
+		}
+		select {
+		case ch <- event:
+		default:
+		}
+	}, topics...)
+
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		closed = true
+		close(ch)
+	}()
+
+	return ch
+}
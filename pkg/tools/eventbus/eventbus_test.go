@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+)
+
+const (
+	topic1 eventbus.Topic = "topic-1"
+	topic2 eventbus.Topic = "topic-2"
+)
+
+func TestBus_Subscribe(t *testing.T) {
+	bus := eventbus.New()
+
+	var all, filtered []interface{}
+	unsubscribe := bus.Subscribe(func(event *eventbus.Event) {
+		all = append(all, event.Payload)
+	})
+	bus.Subscribe(func(event *eventbus.Event) {
+		require.Equal(t, topic2, event.Topic)
+		require.False(t, event.Time.IsZero())
+		filtered = append(filtered, event.Payload)
+	}, topic2)
+
+	bus.Publish(topic1, 1)
+	bus.Publish(topic2, 2)
+	unsubscribe()
+	bus.Publish(topic2, 3)
+
+	require.Equal(t, []interface{}{1, 2}, all)
+	require.Equal(t, []interface{}{2, 3}, filtered)
+}
+
+func TestBus_Watch(t *testing.T) {
+	bus := eventbus.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := bus.Watch(ctx, topic1)
+
+	bus.Publish(topic2, 0)
+	for i := 1; i <= 100; i++ {
+		bus.Publish(topic1, i)
+	}
+
+	// events exceeding the watcher buffer are dropped
+	var payloads []interface{}
+	for len(payloads) < 64 {
+		select {
+		case event := <-ch:
+			payloads = append(payloads, event.Payload)
+		case <-time.After(time.Second):
+			require.FailNow(t, "no event received")
+		}
+	}
+	require.Equal(t, 1, payloads[0])
+	require.Equal(t, 64, payloads[63])
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+	bus.Publish(topic1, 101)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exhaustion provides a tracker attributing the SR-IOV resource pool exhaustion to the connections and client
+// identities consuming the VFs at the moment the selection has failed
+package exhaustion
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+)
+
+// Consumer is a connection consuming the VFs
+type Consumer struct {
+	ConnectionID   string   `json:"connectionId"`
+	Identity       string   `json:"identity,omitempty"`
+	TokenIDs       []string `json:"tokenIds,omitempty"`
+	VFPCIAddresses []string `json:"vfPciAddresses,omitempty"`
+}
+
+// Incident is a failed VF selection with the VF consumers at that moment
+type Incident struct {
+	Time       time.Time        `json:"time"`
+	DriverType sriov.DriverType `json:"driverType"`
+	Requester  *Consumer        `json:"requester"`
+	Consumers  []*Consumer      `json:"consumers"`
+}
+
+// Tracker tracks the VF consumers and keeps the last exhaustion incidents up to the size
+type Tracker struct {
+	consumers   map[string]*Consumer // consumers[connID] -> *Consumer
+	incidents   []*Incident
+	next        int
+	size        int
+	exhaustions map[sriov.DriverType]int
+	lock        sync.Mutex
+}
+
+// NewTracker returns a new Tracker keeping the last size incidents
+func NewTracker(size int) *Tracker {
+	return &Tracker{
+		consumers:   map[string]*Consumer{},
+		size:        size,
+		exhaustions: map[sriov.DriverType]int{},
+	}
+}
+
+// Assigned records the VFs assigned to the connection, replacing the connection previous record
+func (t *Tracker) Assigned(consumer *Consumer) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.consumers[consumer.ConnectionID] = consumer
+}
+
+// Released removes the connection record
+func (t *Tracker) Released(connID string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.consumers, connID)
+}
+
+// Exhausted records the incident of the failed VF selection for the requester
+func (t *Tracker) Exhausted(requester *Consumer, driverType sriov.DriverType) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.exhaustions[driverType]++
+
+	if t.size <= 0 {
+		return
+	}
+
+	consumers := make([]*Consumer, 0, len(t.consumers))
+	for _, consumer := range t.consumers {
+		consumers = append(consumers, consumer)
+	}
+	sort.Slice(consumers, func(i, k int) bool {
+		return consumers[i].ConnectionID < consumers[k].ConnectionID
+	})
+
+	incident := &Incident{
+		Time:       time.Now(),
+		DriverType: driverType,
+		Requester:  requester,
+		Consumers:  consumers,
+	}
+	if len(t.incidents) < t.size {
+		t.incidents = append(t.incidents, incident)
+		return
+	}
+	t.incidents[t.next] = incident
+	t.next = (t.next + 1) % t.size
+}
+
+// Exhaustions returns the numbers of the recorded incidents by driver types
+func (t *Tracker) Exhaustions() map[sriov.DriverType]int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	exhaustions := make(map[sriov.DriverType]int, len(t.exhaustions))
+	for driverType, count := range t.exhaustions {
+		exhaustions[driverType] = count
+	}
+	return exhaustions
+}
+
+// Incidents returns the incidents from the oldest to the newest
+func (t *Tracker) Incidents() []*Incident {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	incidents := make([]*Incident, 0, len(t.incidents))
+	incidents = append(incidents, t.incidents[t.next:]...)
+	return append(incidents, t.incidents[:t.next]...)
+}
+
+// LatestIncident returns the newest incident, nil if there are no incidents
+func (t *Tracker) LatestIncident() *Incident {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.incidents) == 0 {
+		return nil
+	}
+	return t.incidents[(t.next+len(t.incidents)-1)%len(t.incidents)]
+}
+
+// VFsByIdentity returns the numbers of the VFs held by the client identities at the incident
+func (i *Incident) VFsByIdentity() map[string]int {
+	vfs := map[string]int{}
+	for _, consumer := range i.Consumers {
+		vfs[consumer.Identity] += len(consumer.VFPCIAddresses)
+	}
+	return vfs
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exhaustion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := exhaustion.NewTracker(2)
+	require.Nil(t, tracker.LatestIncident())
+
+	consumer1 := &exhaustion.Consumer{ConnectionID: "id-1", Identity: "nsc-1", VFPCIAddresses: []string{"0000:01:00.1"}}
+	consumer2 := &exhaustion.Consumer{ConnectionID: "id-2", Identity: "nsc-2", VFPCIAddresses: []string{"0000:01:00.2"}}
+	tracker.Assigned(consumer2)
+	tracker.Assigned(consumer1)
+
+	for i, requester := range []string{"id-3", "id-4", "id-5"} {
+		if i == 2 {
+			tracker.Released(consumer2.ConnectionID)
+		}
+		tracker.Exhausted(&exhaustion.Consumer{ConnectionID: requester}, sriov.VFIOPCIDriver)
+	}
+
+	incidents := tracker.Incidents()
+	require.Len(t, incidents, 2)
+	require.Equal(t, "id-4", incidents[0].Requester.ConnectionID)
+	require.Equal(t, []*exhaustion.Consumer{consumer1, consumer2}, incidents[0].Consumers)
+	require.Equal(t, "id-5", incidents[1].Requester.ConnectionID)
+	require.Equal(t, []*exhaustion.Consumer{consumer1}, incidents[1].Consumers)
+
+	require.Equal(t, incidents[1], tracker.LatestIncident())
+	require.Equal(t, map[string]int{"nsc-1": 1}, tracker.LatestIncident().VFsByIdentity())
+	require.Equal(t, map[sriov.DriverType]int{sriov.VFIOPCIDriver: 3}, tracker.Exhaustions())
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC health service names
+const (
+	// ReadinessService is the service name checking the readiness
+	ReadinessService = "readiness"
+	// LivenessService is the service name checking the liveness
+	LivenessService = "liveness"
+)
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	checker *Checker
+}
+
+// RegisterHealthServer registers the grpc.health.v1.Health service checking the readiness for ReadinessService, the
+// liveness for LivenessService and both of them for the empty service name. Watch is not supported.
+func RegisterHealthServer(s grpc.ServiceRegistrar, checker *Checker) {
+	grpc_health_v1.RegisterHealthServer(s, &healthServer{
+		checker: checker,
+	})
+}
+
+func (s *healthServer) Check(_ context.Context, request *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	var err error
+	switch request.GetService() {
+	case "":
+		if err = s.checker.Live(); err == nil {
+			err = s.checker.Ready()
+		}
+	case ReadinessService:
+		err = s.checker.Ready()
+	case LivenessService:
+		err = s.checker.Live()
+	default:
+		return nil, status.Errorf(codes.NotFound, "unknown service: %s", request.GetService())
+	}
+
+	if err != nil {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
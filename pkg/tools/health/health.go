@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health provides the forwarder SR-IOV readiness and liveness checks exposed via gRPC health service and HTTP
+// handler for the Kubernetes probes
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+// ForwarderReconciler is the reconciler name the forwarder sends its heartbeat as while its resource lock is not stuck
+const ForwarderReconciler = "forwarder"
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	FreeCounts() map[string]int
+}
+
+// ResourcePool is a resource.Pool interface
+type ResourcePool interface {
+	Stats() *resource.Stats
+}
+
+type reconciler struct {
+	timeout   time.Duration
+	heartbeat time.Time
+}
+
+// Checker checks the forwarder SR-IOV readiness and liveness:
+//   - ready - SR-IOV config is loaded, pools are built, the token pool advertises at least one token name (service
+//     domain and capability pair) and every additional readiness check passes, token names with all the tokens
+//     allocated don't fail the readiness as the forwarder is still serving them;
+//   - live - every registered reconciler has sent its heartbeat within its timeout.
+type Checker struct {
+	cfg             *config.Config
+	tokenPool       TokenPool
+	resourcePool    ResourcePool
+	resourceLock    sync.Locker
+	reconcilers     map[string]*reconciler
+	readinessChecks []func() error
+	lock            sync.Mutex
+}
+
+// Option is an option for the Checker
+type Option func(c *Checker)
+
+// WithConfig sets the loaded SR-IOV config
+func WithConfig(cfg *config.Config) Option {
+	return func(c *Checker) {
+		c.cfg = cfg
+	}
+}
+
+// WithTokenPool sets the token pool to check the advertised tokens
+func WithTokenPool(tokenPool TokenPool) Option {
+	return func(c *Checker) {
+		c.tokenPool = tokenPool
+	}
+}
+
+// WithResourcePool sets the resource pool, resourceLock is the lock the resource pool is used under
+func WithResourcePool(resourcePool ResourcePool, resourceLock sync.Locker) Option {
+	return func(c *Checker) {
+		c.resourcePool = resourcePool
+		c.resourceLock = resourceLock
+	}
+}
+
+// WithReconciler registers the reconciler expected to send its heartbeat at least once per timeout, the timeout
+// starts with the Checker creation
+func WithReconciler(name string, timeout time.Duration) Option {
+	return func(c *Checker) {
+		c.reconcilers[name] = &reconciler{
+			timeout: timeout,
+		}
+	}
+}
+
+// WithReadinessCheck adds the readiness check, e.g. alerting.Evaluator Ready, evaluated after the pools checks
+func WithReadinessCheck(check func() error) Option {
+	return func(c *Checker) {
+		c.readinessChecks = append(c.readinessChecks, check)
+	}
+}
+
+// NewChecker returns a new Checker
+func NewChecker(options ...Option) *Checker {
+	c := &Checker{
+		reconcilers: map[string]*reconciler{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	now := time.Now()
+	for _, r := range c.reconcilers {
+		r.heartbeat = now
+	}
+
+	return c
+}
+
+// SetResourcePool sets the resource pool once it is built, resourceLock is the lock the resource pool is used under
+func (c *Checker) SetResourcePool(resourcePool ResourcePool, resourceLock sync.Locker) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.resourcePool = resourcePool
+	c.resourceLock = resourceLock
+}
+
+// Heartbeat records the reconciler heartbeat, heartbeats of the not registered reconcilers are ignored
+func (c *Checker) Heartbeat(name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if r, ok := c.reconcilers[name]; ok {
+		r.heartbeat = time.Now()
+	}
+}
+
+// Ready returns an error describing the first failed readiness check, or nil if the forwarder is ready
+func (c *Checker) Ready() error {
+	c.lock.Lock()
+	resourcePool, resourceLock := c.resourcePool, c.resourceLock
+	c.lock.Unlock()
+
+	if c.cfg == nil || len(c.cfg.PhysicalFunctions) == 0 {
+		return errors.New("SR-IOV config is not loaded")
+	}
+	if c.tokenPool == nil || resourcePool == nil {
+		return errors.New("SR-IOV pools are not built")
+	}
+
+	resourceLock.Lock()
+	stats := resourcePool.Stats()
+	resourceLock.Unlock()
+
+	if len(stats.PhysicalFunctions) == 0 {
+		return errors.New("resource pool has no physical functions")
+	}
+
+	if len(c.tokenPool.FreeCounts()) == 0 {
+		return errors.New("token pool advertises no tokens")
+	}
+
+	for _, check := range c.readinessChecks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Live returns an error describing the first reconciler missing its heartbeat, or nil if the forwarder is live
+func (c *Checker) Live() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	for _, name := range sortedKeys(c.reconcilers) {
+		r := c.reconcilers[name]
+		if since := now.Sub(r.heartbeat); since > r.timeout {
+			return errors.Errorf("no reconciler heartbeat for %s: %s", since.Round(time.Millisecond), name)
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
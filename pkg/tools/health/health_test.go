@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/health"
+)
+
+const (
+	configFileName = "config.yml"
+	tokenName      = "service.domain.1/10G"
+	reconcilerName = "podresources"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	require.Error(t, health.NewChecker().Ready())
+
+	cfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	checker := health.NewChecker(
+		health.WithConfig(cfg),
+		health.WithTokenPool(tokenPool),
+	)
+	require.ErrorContains(t, checker.Ready(), "pools are not built")
+
+	checker.SetResourcePool(resource.NewPool(tokenPool, cfg), new(sync.Mutex))
+	require.NoError(t, checker.Ready())
+
+	// all the tokens allocated doesn't mean the forwarder isn't ready
+	_, err = tokenPool.AllocateFree(tokenName)
+	require.NoError(t, err)
+	require.Zero(t, tokenPool.FreeCounts()[tokenName])
+	require.NoError(t, checker.Ready())
+}
+
+func TestChecker_Ready_ReadinessCheck(t *testing.T) {
+	cfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	var checkErr error
+	tokenPool := token.NewPool(cfg)
+	checker := health.NewChecker(
+		health.WithConfig(cfg),
+		health.WithTokenPool(tokenPool),
+		health.WithResourcePool(resource.NewPool(tokenPool, cfg), new(sync.Mutex)),
+		health.WithReadinessCheck(func() error {
+			return checkErr
+		}),
+	)
+	require.NoError(t, checker.Ready())
+
+	checkErr = errors.New("alerts raised")
+	require.ErrorIs(t, checker.Ready(), checkErr)
+}
+
+func TestChecker_Live(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+
+	checker := health.NewChecker(health.WithReconciler(reconcilerName, timeout))
+	require.NoError(t, checker.Live())
+
+	require.Eventually(t, func() bool {
+		return checker.Live() != nil
+	}, time.Second, timeout/5)
+	require.ErrorContains(t, checker.Live(), reconcilerName)
+
+	checker.Heartbeat(reconcilerName)
+	require.NoError(t, checker.Live())
+}
+
+func TestRegisterHealthServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := config.ReadConfig(ctx, configFileName)
+	require.NoError(t, err)
+
+	var checkErr error
+	tokenPool := token.NewPool(cfg)
+	checker := health.NewChecker(
+		health.WithConfig(cfg),
+		health.WithTokenPool(tokenPool),
+		health.WithResourcePool(resource.NewPool(tokenPool, cfg), new(sync.Mutex)),
+		health.WithReconciler(reconcilerName, time.Hour),
+		health.WithReadinessCheck(func() error {
+			return checkErr
+		}),
+	)
+
+	grpcServer := grpc.NewServer()
+	health.RegisterHealthServer(grpcServer, checker)
+
+	socketPath := filepath.Join(t.TempDir(), "health.sock")
+	errCh := grpcutils.ListenAndServe(ctx, &url.URL{Scheme: "unix", Path: socketPath}, grpcServer)
+
+	cc, err := grpc.DialContext(ctx, "unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := grpc_health_v1.NewHealthClient(cc)
+	for _, service := range []string{"", health.ReadinessService, health.LivenessService} {
+		resp, checkErr := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, checkErr)
+		require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+
+	checkErr = errors.New("alerts raised")
+
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: health.ReadinessService})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	resp, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: health.LivenessService})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "unknown"})
+	require.Error(t, err)
+
+	_ = cc.Close()
+	cancel()
+	<-errCh
+}
+
+func TestChecker_Handler(t *testing.T) {
+	checker := health.NewChecker(health.WithReconciler(reconcilerName, time.Hour))
+	server := httptest.NewServer(checker.Handler())
+	defer server.Close()
+
+	for path, code := range map[string]int{
+		health.ReadinessPath: http.StatusServiceUnavailable,
+		health.LivenessPath:  http.StatusOK,
+	} {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, code, resp.StatusCode, path)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"net/http"
+)
+
+// HTTP handler paths
+const (
+	// ReadinessPath is the HTTP path checking the readiness
+	ReadinessPath = "/readyz"
+	// LivenessPath is the HTTP path checking the liveness
+	LivenessPath = "/livez"
+)
+
+// Handler returns HTTP handler checking the readiness on ReadinessPath and the liveness on LivenessPath: it responds
+// with 200 if the check passes and with 503 and the failure description otherwise
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReadinessPath, checkHandlerFunc(c.Ready))
+	mux.HandleFunc(LivenessPath, checkHandlerFunc(c.Live))
+	return mux
+}
+
+func checkHandlerFunc(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := check(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(err.Error() + "\n"))
+			return
+		}
+		_, _ = w.Write([]byte("ok\n"))
+	}
+}
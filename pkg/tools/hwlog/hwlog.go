@@ -0,0 +1,268 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hwlog provides loggers for the SR-IOV hardware operations: log entries are tagged with the operation
+// correlation fields (connection ID, token ID, PCI address) and filtered by the per subsystem log level
+package hwlog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+// Subsystems
+const (
+	PCISubsystem          = "pci"
+	ResourcePoolSubsystem = "resourcepool"
+)
+
+// Log fields
+const (
+	ConnectionIDField = "connID"
+	TokenIDField      = "tokenID"
+	PCIAddressField   = "pciAddr"
+	SubsystemField    = "subsystem"
+)
+
+var levels sync.Map // levels[subsystem] -> logrus.Level
+
+type operationFieldsKey struct{}
+
+// SetLevel sets the subsystem log level, entries more verbose than the level are dropped. Entries more verbose than the
+// global logrus level but allowed by the subsystem level are written directly to the logrus standard logger output.
+// Subsystems without the level set pass all the entries to the context logger.
+func SetLevel(subsystem string, level logrus.Level) {
+	levels.Store(subsystem, level)
+}
+
+// SetLevels sets the subsystems log levels from the comma separated subsystem=level list, e.g. "pci=debug,resourcepool=warn"
+func SetLevels(spec string) error {
+	parsed := map[string]logrus.Level{}
+	for _, item := range strings.Split(spec, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		subsystem, levelName, ok := strings.Cut(item, "=")
+		if !ok {
+			return errors.Errorf("expected subsystem=level, got: %s", item)
+		}
+		level, err := logrus.ParseLevel(levelName)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s subsystem log level", subsystem)
+		}
+		parsed[subsystem] = level
+	}
+	for subsystem, level := range parsed {
+		SetLevel(subsystem, level)
+	}
+	return nil
+}
+
+// WithOperation returns the ctx with the logger tagged with the hardware operation correlation fields, empty fields are
+// skipped
+func WithOperation(ctx context.Context, connID, tokenID, pciAddr string) context.Context {
+	logger := log.FromContext(ctx)
+	fields := logrus.Fields{}
+	if parentFields, ok := ctx.Value(operationFieldsKey{}).(logrus.Fields); ok {
+		for key, value := range parentFields {
+			fields[key] = value
+		}
+	}
+	for _, field := range []struct{ key, value string }{
+		{ConnectionIDField, connID},
+		{TokenIDField, tokenID},
+		{PCIAddressField, pciAddr},
+	} {
+		if field.value != "" {
+			logger = logger.WithField(field.key, field.value)
+			fields[field.key] = field.value
+		}
+	}
+	return log.WithLog(context.WithValue(ctx, operationFieldsKey{}, fields), logger)
+}
+
+// FromContext returns the ctx logger for the subsystem
+func FromContext(ctx context.Context, subsystem string) log.Logger {
+	fields := logrus.Fields{SubsystemField: subsystem}
+	if operationFields, ok := ctx.Value(operationFieldsKey{}).(logrus.Fields); ok {
+		for key, value := range operationFields {
+			fields[key] = value
+		}
+	}
+	return &subsystemLogger{
+		logger:    log.FromContext(ctx).WithField(SubsystemField, subsystem),
+		subsystem: subsystem,
+		fields:    fields,
+	}
+}
+
+// Operation runs the hardware operation logging its start and success with the debug level and its failure with the
+// error level
+func Operation(logger log.Logger, name string, op func() error) error {
+	logger.Debugf("%s: started", name)
+	start := time.Now()
+	if err := op(); err != nil {
+		logger.Errorf("%s: failed in %v: %s", name, time.Since(start), err.Error())
+		return err
+	}
+	logger.Debugf("%s: done in %v", name, time.Since(start))
+	return nil
+}
+
+type subsystemLogger struct {
+	logger    log.Logger
+	subsystem string
+	fields    logrus.Fields
+}
+
+func (l *subsystemLogger) enabled(level logrus.Level) bool {
+	subsystemLevel, ok := levels.Load(l.subsystem)
+	return !ok || level <= subsystemLevel.(logrus.Level)
+}
+
+// raised returns the logrus entry writing to the standard logger output with the subsystem level if the level is allowed
+// by the subsystem level but is dropped by the global logrus level, nil otherwise
+func (l *subsystemLogger) raised(level logrus.Level) *logrus.Entry {
+	subsystemLevel, ok := levels.Load(l.subsystem)
+	if !ok || level > subsystemLevel.(logrus.Level) || logrus.IsLevelEnabled(level) {
+		return nil
+	}
+
+	std := logrus.StandardLogger()
+	return logrus.NewEntry(&logrus.Logger{
+		Out:          std.Out,
+		Hooks:        std.Hooks,
+		Formatter:    std.Formatter,
+		ReportCaller: std.ReportCaller,
+		Level:        subsystemLevel.(logrus.Level),
+		ExitFunc:     std.ExitFunc,
+	}).WithFields(l.fields)
+}
+
+func (l *subsystemLogger) Info(v ...interface{}) {
+	if entry := l.raised(logrus.InfoLevel); entry != nil {
+		entry.Info(v...)
+	} else if l.enabled(logrus.InfoLevel) {
+		l.logger.Info(v...)
+	}
+}
+
+func (l *subsystemLogger) Infof(format string, v ...interface{}) {
+	if entry := l.raised(logrus.InfoLevel); entry != nil {
+		entry.Infof(format, v...)
+	} else if l.enabled(logrus.InfoLevel) {
+		l.logger.Infof(format, v...)
+	}
+}
+
+func (l *subsystemLogger) Warn(v ...interface{}) {
+	if entry := l.raised(logrus.WarnLevel); entry != nil {
+		entry.Warn(v...)
+	} else if l.enabled(logrus.WarnLevel) {
+		l.logger.Warn(v...)
+	}
+}
+
+func (l *subsystemLogger) Warnf(format string, v ...interface{}) {
+	if entry := l.raised(logrus.WarnLevel); entry != nil {
+		entry.Warnf(format, v...)
+	} else if l.enabled(logrus.WarnLevel) {
+		l.logger.Warnf(format, v...)
+	}
+}
+
+func (l *subsystemLogger) Error(v ...interface{}) {
+	if entry := l.raised(logrus.ErrorLevel); entry != nil {
+		entry.Error(v...)
+	} else if l.enabled(logrus.ErrorLevel) {
+		l.logger.Error(v...)
+	}
+}
+
+func (l *subsystemLogger) Errorf(format string, v ...interface{}) {
+	if entry := l.raised(logrus.ErrorLevel); entry != nil {
+		entry.Errorf(format, v...)
+	} else if l.enabled(logrus.ErrorLevel) {
+		l.logger.Errorf(format, v...)
+	}
+}
+
+func (l *subsystemLogger) Fatal(v ...interface{}) {
+	l.logger.Fatal(v...)
+}
+
+func (l *subsystemLogger) Fatalf(format string, v ...interface{}) {
+	l.logger.Fatalf(format, v...)
+}
+
+func (l *subsystemLogger) Debug(v ...interface{}) {
+	if entry := l.raised(logrus.DebugLevel); entry != nil {
+		entry.Debug(v...)
+	} else if l.enabled(logrus.DebugLevel) {
+		l.logger.Debug(v...)
+	}
+}
+
+func (l *subsystemLogger) Debugf(format string, v ...interface{}) {
+	if entry := l.raised(logrus.DebugLevel); entry != nil {
+		entry.Debugf(format, v...)
+	} else if l.enabled(logrus.DebugLevel) {
+		l.logger.Debugf(format, v...)
+	}
+}
+
+func (l *subsystemLogger) Trace(v ...interface{}) {
+	if entry := l.raised(logrus.TraceLevel); entry != nil {
+		entry.Trace(v...)
+	} else if l.enabled(logrus.TraceLevel) {
+		l.logger.Trace(v...)
+	}
+}
+
+func (l *subsystemLogger) Tracef(format string, v ...interface{}) {
+	if entry := l.raised(logrus.TraceLevel); entry != nil {
+		entry.Tracef(format, v...)
+	} else if l.enabled(logrus.TraceLevel) {
+		l.logger.Tracef(format, v...)
+	}
+}
+
+func (l *subsystemLogger) Object(k, v interface{}) {
+	if l.enabled(logrus.TraceLevel) {
+		l.logger.Object(k, v)
+	}
+}
+
+func (l *subsystemLogger) WithField(key, value interface{}) log.Logger {
+	fields := logrus.Fields{}
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	fields[fmt.Sprint(key)] = value
+	return &subsystemLogger{
+		logger:    l.logger.WithField(key, value),
+		subsystem: l.subsystem,
+		fields:    fields,
+	}
+}
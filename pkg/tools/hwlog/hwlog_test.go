@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hwlog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/ljkiraly/sdk/pkg/tools/log/logruslogger"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/hwlog"
+)
+
+const (
+	connID  = "conn-1"
+	tokenID = "token-1"
+	pciAddr = "0000:01:00.1"
+)
+
+func testContext(t *testing.T) (context.Context, *test.Hook) {
+	hook := test.NewGlobal()
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.TraceLevel)
+	t.Cleanup(func() {
+		logrus.SetLevel(level)
+		logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+		hwlog.SetLevel(hwlog.PCISubsystem, logrus.TraceLevel)
+	})
+
+	ctx := log.WithLog(context.Background(), logruslogger.New(context.Background()))
+	return hwlog.WithOperation(ctx, connID, tokenID, ""), hook
+}
+
+func TestOperation(t *testing.T) {
+	ctx, hook := testContext(t)
+
+	logger := hwlog.FromContext(ctx, hwlog.PCISubsystem).WithField(hwlog.PCIAddressField, pciAddr)
+	err := hwlog.Operation(logger, "bind driver", func() error {
+		return errors.New("failure")
+	})
+	require.Error(t, err)
+
+	require.Len(t, hook.AllEntries(), 2)
+	require.Equal(t, logrus.DebugLevel, hook.AllEntries()[0].Level)
+
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.ErrorLevel, entry.Level)
+	require.Contains(t, entry.Message, "bind driver: failed")
+	require.Equal(t, connID, entry.Data[hwlog.ConnectionIDField])
+	require.Equal(t, tokenID, entry.Data[hwlog.TokenIDField])
+	require.Equal(t, pciAddr, entry.Data[hwlog.PCIAddressField])
+	require.Equal(t, hwlog.PCISubsystem, entry.Data[hwlog.SubsystemField])
+}
+
+func TestSetLevels(t *testing.T) {
+	ctx, hook := testContext(t)
+
+	require.Error(t, hwlog.SetLevels("pci"))
+	require.Error(t, hwlog.SetLevels("pci=unknown"))
+	require.NoError(t, hwlog.SetLevels("pci=error, resourcepool=trace"))
+
+	require.NoError(t, hwlog.Operation(hwlog.FromContext(ctx, hwlog.PCISubsystem), "bind driver", func() error {
+		return nil
+	}))
+	require.Empty(t, hook.AllEntries())
+
+	hwlog.FromContext(ctx, hwlog.ResourcePoolSubsystem).Debug("free VF")
+	require.Len(t, hook.AllEntries(), 1)
+	require.Equal(t, hwlog.ResourcePoolSubsystem, hook.LastEntry().Data[hwlog.SubsystemField])
+}
+
+func TestSetLevel_RaiseVerbosity(t *testing.T) {
+	ctx, hook := testContext(t)
+	logrus.SetLevel(logrus.InfoLevel)
+
+	hwlog.SetLevel(hwlog.PCISubsystem, logrus.DebugLevel)
+
+	logger := hwlog.FromContext(ctx, hwlog.PCISubsystem).WithField(hwlog.PCIAddressField, pciAddr)
+	require.NoError(t, hwlog.Operation(logger, "bind driver", func() error {
+		return nil
+	}))
+	logger.Trace("dropped by the subsystem level")
+
+	require.Len(t, hook.AllEntries(), 2)
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.DebugLevel, entry.Level)
+	require.Contains(t, entry.Message, "bind driver: done")
+	require.Equal(t, connID, entry.Data[hwlog.ConnectionIDField])
+	require.Equal(t, tokenID, entry.Data[hwlog.TokenIDField])
+	require.Equal(t, pciAddr, entry.Data[hwlog.PCIAddressField])
+	require.Equal(t, hwlog.PCISubsystem, entry.Data[hwlog.SubsystemField])
+}
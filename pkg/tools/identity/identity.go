@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identity provides the client identity of the connections
+package identity
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// FromPath returns the client SPIFFE ID from the first path segment token subject, or "" if it can't be parsed. Path
+// tokens are expected to be already verified by the authorize chain element.
+func FromPath(ctx context.Context, path *networkservice.Path) string {
+	if len(path.GetPathSegments()) == 0 {
+		return ""
+	}
+
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(path.GetPathSegments()[0].GetToken(), &claims); err != nil {
+		log.FromContext(ctx).WithField("identity", "FromPath").
+			Warnf("failed to parse path token: %s", err.Error())
+		return ""
+	}
+	return claims.Subject
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/identity"
+)
+
+func TestFromPath(t *testing.T) {
+	const spiffeID = "spiffe://example.org/nsc"
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: spiffeID}).
+		SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	require.Equal(t, spiffeID, identity.FromPath(context.TODO(), &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{{Token: token}, {Token: "invalid"}},
+	}))
+	require.Empty(t, identity.FromPath(context.TODO(), &networkservice.Path{
+		PathSegments: []*networkservice.PathSegment{{Token: "invalid"}},
+	}))
+	require.Empty(t, identity.FromPath(context.TODO(), nil))
+}
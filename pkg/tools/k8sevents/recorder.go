@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8sevents provides a sink emitting Kubernetes Events for the significant forwarder SR-IOV occurrences, so
+// they are visible in the forwarder pod `kubectl describe`
+package k8sevents
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+)
+
+// Hot-plug events reasons
+const (
+	// NICHotUnplugReason is the reason of the PF link down or VFs disappeared event
+	NICHotUnplugReason = "NICHotUnplug"
+	// NICRecoveredReason is the reason of the PF recovered event
+	NICRecoveredReason = "NICRecovered"
+)
+
+// Alerts events reasons
+const (
+	// AlertRaisedReason is the reason of the alerting threshold crossed event
+	AlertRaisedReason = "SRIOVAlertRaised"
+	// AlertResolvedReason is the reason of the alerting threshold recovered event
+	AlertResolvedReason = "SRIOVAlertResolved"
+
+	defaultComponent = "sriov-forwarder"
+)
+
+type options struct {
+	component string
+}
+
+// Option is an option for the Recorder
+type Option func(o *options)
+
+// WithComponent sets the events source component, "sriov-forwarder" by default
+func WithComponent(component string) Option {
+	return func(o *options) {
+		o.component = component
+	}
+}
+
+// Recorder emits Kubernetes Events attached to the forwarder pod
+type Recorder struct {
+	recorder record.EventRecorder
+	pod      *corev1.ObjectReference
+}
+
+// NewRecorder returns a new Recorder emitting the events attached to the namespace/podName pod until the ctx is done
+func NewRecorder(ctx context.Context, client kubernetes.Interface, namespace, podName string, opts ...Option) (*Recorder, error) {
+	o := &options{
+		component: defaultComponent,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the forwarder pod: %s/%s", namespace, podName)
+	}
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(namespace)})
+	go func() {
+		<-ctx.Done()
+		broadcaster.Shutdown()
+	}()
+
+	return &Recorder{
+		recorder: broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: o.component}),
+		pod: &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			UID:        pod.UID,
+		},
+	}, nil
+}
+
+// Normalf emits the Normal event with the reason
+func (r *Recorder) Normalf(reason, format string, args ...interface{}) {
+	r.recorder.Eventf(r.pod, corev1.EventTypeNormal, reason, format, args...)
+}
+
+// Warningf emits the Warning event with the reason
+func (r *Recorder) Warningf(reason, format string, args ...interface{}) {
+	r.recorder.Eventf(r.pod, corev1.EventTypeWarning, reason, format, args...)
+}
+
+// HotplugListener returns hotplug.Monitor listener emitting the PF health change events
+func (r *Recorder) HotplugListener() func(event *hotplug.Event) {
+	return func(event *hotplug.Event) {
+		switch {
+		case !event.LinkUp:
+			r.Warningf(NICHotUnplugReason, "PF %s link is down", event.PFPCIAddr)
+		case len(event.MissingVFs) != 0:
+			r.Warningf(NICHotUnplugReason, "PF %s VFs disappeared: %s", event.PFPCIAddr, strings.Join(event.MissingVFs, ", "))
+		default:
+			r.Normalf(NICRecoveredReason, "PF %s is healthy, %d VFs available", event.PFPCIAddr, event.HealthyVFs)
+		}
+	}
+}
+
+// AlertsHandler returns the event bus handler emitting the alerting.EventTopic alerts raised and resolved events
+func (r *Recorder) AlertsHandler() eventbus.Handler {
+	return func(event *eventbus.Event) {
+		alertEvent, ok := event.Payload.(*alerting.AlertEvent)
+		if !ok {
+			return
+		}
+		if alertEvent.Resolved {
+			r.Normalf(AlertResolvedReason, "%s", alertEvent.Alert)
+			return
+		}
+		r.Warningf(AlertRaisedReason, "%s", alertEvent.Alert)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sevents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/hotplug"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/alerting"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/k8sevents"
+)
+
+const (
+	namespace  = "nsm-system"
+	podName    = "forwarder-sriov-1"
+	podUID     = "pod-uid"
+	pfPCIAddr  = "0000:01:00.0"
+	vfPCIAddr  = "0000:01:00.1"
+	bindReason = "VFBindFailed"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      podName,
+			UID:       podUID,
+		},
+	})
+
+	_, err := k8sevents.NewRecorder(ctx, client, namespace, "missing")
+	require.Error(t, err)
+
+	recorder, err := k8sevents.NewRecorder(ctx, client, namespace, podName)
+	require.NoError(t, err)
+
+	recorder.Warningf(bindReason, "failed to bind VF %s", vfPCIAddr)
+	recorder.HotplugListener()(&hotplug.Event{
+		PFPCIAddr:  pfPCIAddr,
+		LinkUp:     true,
+		MissingVFs: []string{vfPCIAddr},
+	})
+	recorder.AlertsHandler()(&eventbus.Event{
+		Topic: alerting.EventTopic,
+		Payload: &alerting.AlertEvent{
+			Alert: &alerting.Alert{Kind: alerting.BindFailuresAlert, Value: 3, Threshold: 2},
+		},
+	})
+
+	var events []corev1.Event
+	require.Eventually(t, func() bool {
+		list, listErr := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, listErr)
+		events = list.Items
+		return len(events) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	reasons := map[string]string{}
+	for i := range events {
+		require.Equal(t, corev1.EventTypeWarning, events[i].Type)
+		require.Equal(t, podName, events[i].InvolvedObject.Name)
+		require.EqualValues(t, podUID, events[i].InvolvedObject.UID)
+		reasons[events[i].Reason] = events[i].Message
+	}
+	require.Equal(t, map[string]string{
+		bindReason:                   "failed to bind VF " + vfPCIAddr,
+		k8sevents.NICHotUnplugReason: "PF " + pfPCIAddr + " VFs disappeared: " + vfPCIAddr,
+		k8sevents.AlertRaisedReason:  "3 driver bind failures exceed 2",
+	}, reasons)
+}
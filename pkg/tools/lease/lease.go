@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package lease provides a file based leader lease for the processes sharing the same host
+package lease
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Lease is a file based leader lease, the holder should renew it more often than the lease duration to keep it
+type Lease struct {
+	path     string
+	holder   string
+	duration time.Duration
+}
+
+type record struct {
+	Holder    string    `json:"holder"`
+	RenewTime time.Time `json:"renewTime"`
+}
+
+// New returns a new Lease stored in the path file for the holder
+func New(path, holder string, duration time.Duration) *Lease {
+	return &Lease{
+		path:     path,
+		holder:   holder,
+		duration: duration,
+	}
+}
+
+// TryAcquire acquires or renews the lease, returns false if the lease is held by another holder and not expired yet
+func (l *Lease) TryAcquire() (bool, error) {
+	acquired := false
+	err := l.update(func(r *record) bool {
+		now := time.Now()
+		if r.Holder != "" && r.Holder != l.holder && now.Sub(r.RenewTime) < l.duration {
+			return false
+		}
+		r.Holder, r.RenewTime = l.holder, now
+		acquired = true
+		return true
+	})
+	return acquired, err
+}
+
+// Release releases the lease if it is held, so another holder can acquire it without waiting for the expiration
+func (l *Lease) Release() error {
+	return l.update(func(r *record) bool {
+		if r.Holder != l.holder {
+			return false
+		}
+		*r = record{}
+		return true
+	})
+}
+
+// update calls modify for the lease record under the exclusive file lock, writes the record back if modify returns true
+func (l *Lease) update(modify func(r *record) bool) error {
+	file, err := os.OpenFile(filepath.Clean(l.path), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open lease file: %s", l.path)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return errors.Wrapf(err, "failed to lock lease file: %s", l.path)
+	}
+	defer func() { _ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN) }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read lease file: %s", l.path)
+	}
+
+	r := new(record)
+	if len(data) != 0 {
+		if err = json.Unmarshal(data, r); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal lease file: %s", l.path)
+		}
+	}
+
+	if !modify(r) {
+		return nil
+	}
+
+	if data, err = json.Marshal(r); err != nil {
+		return errors.Wrap(err, "failed to marshal lease")
+	}
+	if err = file.Truncate(0); err != nil {
+		return errors.Wrapf(err, "failed to truncate lease file: %s", l.path)
+	}
+	if _, err = file.WriteAt(data, 0); err != nil {
+		return errors.Wrapf(err, "failed to write lease file: %s", l.path)
+	}
+	return nil
+}
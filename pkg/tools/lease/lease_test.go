@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package lease_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/lease"
+)
+
+func TestLease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+
+	primary := lease.New(path, "primary", 100*time.Millisecond)
+	standby := lease.New(path, "standby", 100*time.Millisecond)
+
+	acquired, err := primary.TryAcquire()
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = standby.TryAcquire()
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.Eventually(t, func() bool {
+		acquired, err = standby.TryAcquire()
+		return err == nil && acquired
+	}, time.Second, 10*time.Millisecond)
+
+	acquired, err = primary.TryAcquire()
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.NoError(t, standby.Release())
+
+	acquired, err = primary.TryAcquire()
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
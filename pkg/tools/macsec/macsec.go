@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package macsec provides the MACsec net interfaces management with the hardware offload, so the encrypted links can
+// terminate in the NIC
+package macsec
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Offload modes
+const (
+	OffloadOff uint8 = 0
+	OffloadPHY uint8 = 1
+	OffloadMAC uint8 = 2
+)
+
+// Cipher suites
+const (
+	CipherGCMAES128 uint64 = 0x0080020001000001
+	CipherGCMAES256 uint64 = 0x0080C20001000002
+)
+
+const (
+	// KeyIDLen is the SA key ID length
+	KeyIDLen = 16
+	// SCILen is the secure channel identifier length
+	SCILen = 8
+)
+
+// SA is a MACsec secure association
+type SA struct {
+	// AN is the association number
+	AN uint8
+	// PN is the initial packet number, 1 is used if it is 0
+	PN uint32
+	// Key is the SA key, its length should match the cipher suite
+	Key []byte
+	// KeyID is the SA key ID
+	KeyID []byte
+}
+
+// Config is a MACsec net interface configuration
+type Config struct {
+	// Name is the MACsec net interface name
+	Name string
+	// Parent is the net interface the MACsec net interface is created on
+	Parent string
+	// Offload is the offload mode
+	Offload uint8
+	// Cipher is the cipher suite, CipherGCMAES128 is used if it is 0
+	Cipher uint64
+	// TxSA is the transmit SA
+	TxSA *SA
+	// RxSCI is the peer secure channel identifier: the peer MAC address followed by the port in the network byte order
+	RxSCI []byte
+	// RxSA is the receive SA of the peer secure channel
+	RxSA *SA
+}
+
+// Validate checks the configuration
+func (c *Config) Validate() error {
+	if c.Name == "" || c.Parent == "" {
+		return errors.New("MACsec net interface and its parent names should be set")
+	}
+	keyLen := 16
+	switch c.Cipher {
+	case 0, CipherGCMAES128:
+	case CipherGCMAES256:
+		keyLen = 32
+	default:
+		return errors.Errorf("unsupported cipher suite: %#x", c.Cipher)
+	}
+	if len(c.RxSCI) != SCILen {
+		return errors.Errorf("invalid receive SCI length: %d", len(c.RxSCI))
+	}
+	if err := validateSA("transmit", c.TxSA, keyLen); err != nil {
+		return err
+	}
+	return validateSA("receive", c.RxSA, keyLen)
+}
+
+func validateSA(name string, sa *SA, keyLen int) error {
+	if sa == nil {
+		return errors.Errorf("no %s SA set", name)
+	}
+	if len(sa.Key) != keyLen {
+		return errors.Errorf("invalid %s SA key length: %d", name, len(sa.Key))
+	}
+	if len(sa.KeyID) != KeyIDLen {
+		return errors.Errorf("invalid %s SA key ID length: %d", name, len(sa.KeyID))
+	}
+	if sa.AN > 3 {
+		return errors.Errorf("invalid %s SA association number: %d", name, sa.AN)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/macsec"
+)
+
+func testConfig() *macsec.Config {
+	return &macsec.Config{
+		Name:    "ms-eth0",
+		Parent:  "eth0",
+		Offload: macsec.OffloadMAC,
+		TxSA: &macsec.SA{
+			Key:   bytes.Repeat([]byte{1}, 16),
+			KeyID: bytes.Repeat([]byte{2}, macsec.KeyIDLen),
+		},
+		RxSCI: []byte{0x02, 0, 0, 0, 0, 0x01, 0, 0x01},
+		RxSA: &macsec.SA{
+			Key:   bytes.Repeat([]byte{3}, 16),
+			KeyID: bytes.Repeat([]byte{4}, macsec.KeyIDLen),
+		},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := testConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Cipher = macsec.CipherGCMAES256
+	require.ErrorContains(t, cfg.Validate(), "invalid transmit SA key length")
+
+	cfg = testConfig()
+	cfg.RxSA.KeyID = []byte{4}
+	require.ErrorContains(t, cfg.Validate(), "invalid receive SA key ID length")
+
+	cfg = testConfig()
+	cfg.RxSCI = nil
+	require.ErrorContains(t, cfg.Validate(), "invalid receive SCI length")
+
+	cfg = testConfig()
+	cfg.TxSA.AN = 4
+	require.ErrorContains(t, cfg.Validate(), "invalid transmit SA association number")
+
+	cfg = testConfig()
+	cfg.Parent = ""
+	require.Error(t, cfg.Validate())
+}
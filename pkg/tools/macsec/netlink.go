@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package macsec
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// MACsec rtnetlink and generic netlink attributes and commands missing in the netlink packages
+const (
+	iflaMacsecICVLen      = 3
+	iflaMacsecCipherSuite = 4
+	iflaMacsecEncrypt     = 7
+	iflaMacsecOffload     = 15
+
+	genlName    = "macsec"
+	genlVersion = 1
+
+	cmdAddRxSC = 1
+	cmdAddTxSA = 4
+	cmdAddRxSA = 7
+
+	attrIfIndex    = 1
+	attrRxSCConfig = 2
+	attrSAConfig   = 3
+
+	rxSCAttrSCI    = 1
+	rxSCAttrActive = 2
+
+	saAttrAN     = 1
+	saAttrActive = 2
+	saAttrPN     = 3
+	saAttrKey    = 4
+	saAttrKeyID  = 5
+
+	icvLen = 16
+)
+
+// Create creates the MACsec net interface on the parent net interface with the transmit SA and the peer receive SC and
+// SA, and sets it up. If any step fails, the created net interface is deleted.
+func Create(cfg *Config) (err error) {
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+
+	parent, err := netlink.LinkByName(cfg.Parent)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find parent net interface: %s", cfg.Parent)
+	}
+	if err = addLink(cfg, parent.Attrs().Index); err != nil {
+		return errors.Wrapf(err, "failed to add MACsec net interface: %s", cfg.Name)
+	}
+	defer func() {
+		if err != nil {
+			_ = Delete(cfg.Name)
+		}
+	}()
+
+	link, err := netlink.LinkByName(cfg.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find MACsec net interface: %s", cfg.Name)
+	}
+	ifIndex := link.Attrs().Index
+
+	if err = execute(cmdAddTxSA, ifIndex, saConfig(cfg.TxSA)); err != nil {
+		return errors.Wrapf(err, "failed to add transmit SA: %s", cfg.Name)
+	}
+	if err = execute(cmdAddRxSC, ifIndex, rxSCConfig(cfg.RxSCI, true)); err != nil {
+		return errors.Wrapf(err, "failed to add receive SC: %s", cfg.Name)
+	}
+	if err = execute(cmdAddRxSA, ifIndex, rxSCConfig(cfg.RxSCI, false), saConfig(cfg.RxSA)); err != nil {
+		return errors.Wrapf(err, "failed to add receive SA: %s", cfg.Name)
+	}
+
+	if err = netlink.LinkSetUp(link); err != nil {
+		return errors.Wrapf(err, "failed to set up MACsec net interface: %s", cfg.Name)
+	}
+	return nil
+}
+
+// Delete deletes the MACsec net interface, does nothing if there is no such net interface
+func Delete(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to find MACsec net interface: %s", name)
+	}
+	if err := netlink.LinkDel(link); err != nil {
+		return errors.Wrapf(err, "failed to delete MACsec net interface: %s", name)
+	}
+	return nil
+}
+
+func addLink(cfg *Config, parentIndex int) error {
+	cipher := cfg.Cipher
+	if cipher == 0 {
+		cipher = CipherGCMAES128
+	}
+
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(nl.NewIfInfomsg(unix.AF_UNSPEC))
+	req.AddData(nl.NewRtAttr(unix.IFLA_IFNAME, nl.ZeroTerminated(cfg.Name)))
+	req.AddData(nl.NewRtAttr(unix.IFLA_LINK, nl.Uint32Attr(uint32(parentIndex))))
+
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated(genlName))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	data.AddRtAttr(iflaMacsecCipherSuite, nl.Uint64Attr(cipher))
+	data.AddRtAttr(iflaMacsecICVLen, nl.Uint8Attr(icvLen))
+	data.AddRtAttr(iflaMacsecEncrypt, nl.Uint8Attr(1))
+	data.AddRtAttr(iflaMacsecOffload, nl.Uint8Attr(cfg.Offload))
+	req.AddData(linkInfo)
+
+	_, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+func execute(cmd uint8, ifIndex int, attrs ...*nl.RtAttr) error {
+	family, err := netlink.GenlFamilyGet(genlName)
+	if err != nil {
+		return errors.Wrap(err, "failed to get MACsec generic netlink family")
+	}
+
+	req := nl.NewNetlinkRequest(int(family.ID), unix.NLM_F_ACK)
+	req.AddData(&nl.Genlmsg{
+		Command: cmd,
+		Version: genlVersion,
+	})
+	req.AddData(nl.NewRtAttr(attrIfIndex, nl.Uint32Attr(uint32(ifIndex))))
+	for _, attr := range attrs {
+		req.AddData(attr)
+	}
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+func rxSCConfig(sci []byte, active bool) *nl.RtAttr {
+	attr := nl.NewRtAttr(attrRxSCConfig, nil)
+	attr.AddRtAttr(rxSCAttrSCI, sci)
+	if active {
+		attr.AddRtAttr(rxSCAttrActive, nl.Uint8Attr(1))
+	}
+	return attr
+}
+
+func saConfig(sa *SA) *nl.RtAttr {
+	pn := sa.PN
+	if pn == 0 {
+		pn = 1
+	}
+	attr := nl.NewRtAttr(attrSAConfig, nil)
+	attr.AddRtAttr(saAttrAN, nl.Uint8Attr(sa.AN))
+	attr.AddRtAttr(saAttrPN, nl.Uint32Attr(pn))
+	attr.AddRtAttr(saAttrKeyID, sa.KeyID)
+	attr.AddRtAttr(saAttrKey, sa.Key)
+	attr.AddRtAttr(saAttrActive, nl.Uint8Attr(1))
+	return attr
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metricsmeta"
+)
+
+var (
+	pfLabelMeta     = &metricsmeta.Label{Name: pfLabel, Help: "PF PCI address"}
+	driverLabelMeta = &metricsmeta.Label{Name: driverLabel, Help: "VF driver type: kernel, vfio-pci, ..."}
+
+	tokensMeta = &metricsmeta.Metric{
+		Name: TokensMetric,
+		Type: metricsmeta.Gauge,
+		Help: "Number of the SR-IOV resource tokens by name and state",
+		Labels: []*metricsmeta.Label{
+			{Name: nameLabel, Help: "token name: <service domain>/<capability>"},
+			{Name: stateLabel, Help: "token state: free, allocated, inUse or closed"},
+		},
+	}
+	vfsMeta = &metricsmeta.Metric{
+		Name:   VFsMetric,
+		Type:   metricsmeta.Gauge,
+		Help:   "Number of the enabled VFs by PF",
+		Labels: []*metricsmeta.Label{pfLabelMeta},
+	}
+	freeVFsMeta = &metricsmeta.Metric{
+		Name:   FreeVFsMetric,
+		Type:   metricsmeta.Gauge,
+		Help:   "Number of the VFs not assigned to the connections by PF",
+		Labels: []*metricsmeta.Label{pfLabelMeta},
+	}
+	unhealthyVFsMeta = &metricsmeta.Metric{
+		Name:   UnhealthyVFsMetric,
+		Type:   metricsmeta.Gauge,
+		Help:   "Number of the VFs temporarily excluded from the selection by PF",
+		Labels: []*metricsmeta.Label{pfLabelMeta},
+	}
+	selectFailuresMeta = &metricsmeta.Metric{
+		Name: SelectFailuresMetric,
+		Type: metricsmeta.Counter,
+		Help: "Number of the failed VF selections",
+	}
+	exhaustionsMeta = &metricsmeta.Metric{
+		Name:   ExhaustionsMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the VF selections failed for the lack of free VFs by driver type",
+		Labels: []*metricsmeta.Label{driverLabelMeta},
+	}
+	consumerVFsMeta = &metricsmeta.Metric{
+		Name: ConsumerVFsMetric,
+		Type: metricsmeta.Gauge,
+		Help: "Number of the VFs held by client identity at the latest exhaustion",
+		Labels: []*metricsmeta.Label{
+			{Name: identityLabel, Help: "client SPIFFE ID"},
+		},
+	}
+	bindDurationMeta = &metricsmeta.Metric{
+		Name:   BindDurationMetric,
+		Type:   metricsmeta.Histogram,
+		Help:   "Duration of the IOMMU group driver binding by driver type",
+		Unit:   "seconds",
+		Labels: []*metricsmeta.Label{driverLabelMeta},
+	}
+	bindFailuresMeta = &metricsmeta.Metric{
+		Name:   BindFailuresMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the failed IOMMU group driver bindings by driver type",
+		Labels: []*metricsmeta.Label{driverLabelMeta},
+	}
+	eventsMeta = &metricsmeta.Metric{
+		Name: EventsMetric,
+		Type: metricsmeta.Counter,
+		Help: "Number of the SR-IOV state change events by topic",
+		Labels: []*metricsmeta.Label{
+			{Name: topicLabel, Help: "event bus topic: tokens, vfs, pfHealth, ..."},
+		},
+	}
+)
+
+// Metadata returns the descriptions of the Metrics metrics
+func Metadata() []*metricsmeta.Metric {
+	return []*metricsmeta.Metric{
+		tokensMeta,
+		vfsMeta,
+		freeVFsMeta,
+		unhealthyVFsMeta,
+		selectFailuresMeta,
+		exhaustionsMeta,
+		consumerVFsMeta,
+		bindDurationMeta,
+		bindFailuresMeta,
+		eventsMeta,
+	}
+}
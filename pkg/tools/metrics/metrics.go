@@ -0,0 +1,257 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides Prometheus collectors for the SR-IOV pools
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+)
+
+// Metrics names
+const (
+	TokensMetric         = "sriov_token_pool_tokens"
+	VFsMetric            = "sriov_resource_pool_vfs"
+	FreeVFsMetric        = "sriov_resource_pool_free_vfs"
+	UnhealthyVFsMetric   = "sriov_resource_pool_unhealthy_vfs"
+	SelectFailuresMetric = "sriov_resource_pool_select_failures_total"
+	BindDurationMetric   = "sriov_pci_pool_bind_duration_seconds"
+	BindFailuresMetric   = "sriov_pci_pool_bind_failures_total"
+	ExhaustionsMetric    = "sriov_resource_pool_exhaustions_total"
+	ConsumerVFsMetric    = "sriov_resource_pool_exhaustion_consumer_vfs"
+	EventsMetric         = "sriov_events_total"
+
+	nameLabel     = "name"
+	stateLabel    = "state"
+	pfLabel       = "pf"
+	driverLabel   = "driver"
+	identityLabel = "identity"
+	topicLabel    = "topic"
+
+	bindDurationBucketsStart = 0.01
+)
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	Stats() map[string]map[string]int
+}
+
+// ResourcePool is a resource.Pool interface
+type ResourcePool interface {
+	Stats() *resource.Stats
+}
+
+// ExhaustionTracker is an exhaustion.Tracker interface
+type ExhaustionTracker interface {
+	Exhaustions() map[sriov.DriverType]int
+	LatestIncident() *exhaustion.Incident
+}
+
+// PCIPool is a pci.Pool interface
+type PCIPool interface {
+	GetPCIFunction(pciAddr string) (sriov.PCIFunction, error)
+	BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error
+}
+
+// VFResetter is a pci.Pool interface
+type VFResetter interface {
+	ResetFunction(ctx context.Context, pciAddr string, driverType sriov.DriverType) error
+}
+
+// Metrics is a Prometheus collector for the SR-IOV pools: token and resource pools capacity and allocation are
+// collected on scrape, PCI pool driver binding latency and failures are recorded by the pool wrapped with PCIPool
+type Metrics struct {
+	tokenPool    TokenPool
+	resourcePool ResourcePool
+	resourceLock sync.Locker
+	exhaustion   ExhaustionTracker
+	eventBus     *eventbus.Bus
+
+	tokens         *prometheus.Desc
+	vfs            *prometheus.Desc
+	freeVFs        *prometheus.Desc
+	unhealthyVFs   *prometheus.Desc
+	selectFailures *prometheus.Desc
+	exhaustions    *prometheus.Desc
+	consumerVFs    *prometheus.Desc
+	bindDuration   *prometheus.HistogramVec
+	bindFailures   *prometheus.CounterVec
+	events         *prometheus.CounterVec
+}
+
+// Option is an option for the Metrics
+type Option func(m *Metrics)
+
+// WithTokenPool sets the token pool to collect the tokens counts by names and states
+func WithTokenPool(tokenPool TokenPool) Option {
+	return func(m *Metrics) {
+		m.tokenPool = tokenPool
+	}
+}
+
+// WithResourcePool sets the resource pool to collect the VF counts by PFs, resourceLock is the lock the resource pool
+// is used under
+func WithResourcePool(resourcePool ResourcePool, resourceLock sync.Locker) Option {
+	return func(m *Metrics) {
+		m.resourcePool = resourcePool
+		m.resourceLock = resourceLock
+	}
+}
+
+// WithExhaustionTracker sets the exhaustion tracker to collect the exhaustions counts by driver types and the VFs held
+// by the client identities at the latest exhaustion
+func WithExhaustionTracker(exhaustionTracker ExhaustionTracker) Option {
+	return func(m *Metrics) {
+		m.exhaustion = exhaustionTracker
+	}
+}
+
+// WithEventBus subscribes the Metrics to the event bus to count the published events by topics
+func WithEventBus(bus *eventbus.Bus) Option {
+	return func(m *Metrics) {
+		m.eventBus = bus
+	}
+}
+
+// New returns a new Metrics
+func New(options ...Option) *Metrics {
+	m := &Metrics{
+		tokens:         tokensMeta.Desc(),
+		vfs:            vfsMeta.Desc(),
+		freeVFs:        freeVFsMeta.Desc(),
+		unhealthyVFs:   unhealthyVFsMeta.Desc(),
+		selectFailures: selectFailuresMeta.Desc(),
+		exhaustions:    exhaustionsMeta.Desc(),
+		consumerVFs:    consumerVFsMeta.Desc(),
+		bindDuration:   bindDurationMeta.HistogramVec(prometheus.ExponentialBuckets(bindDurationBucketsStart, 2, 10)),
+		bindFailures:   bindFailuresMeta.CounterVec(),
+		events:         eventsMeta.CounterVec(),
+	}
+	for _, option := range options {
+		option(m)
+	}
+	if m.eventBus != nil {
+		m.eventBus.Subscribe(func(event *eventbus.Event) {
+			m.events.WithLabelValues(string(event.Topic)).Inc()
+		})
+	}
+	return m
+}
+
+// Register registers the Metrics on the registerer, e.g. on the registry already serving the forwarder metrics
+func (m *Metrics) Register(registerer prometheus.Registerer) error {
+	if err := registerer.Register(m); err != nil {
+		return errors.Wrap(err, "failed to register SR-IOV pools metrics")
+	}
+	return nil
+}
+
+// Describe implements prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.tokens
+	ch <- m.vfs
+	ch <- m.freeVFs
+	ch <- m.unhealthyVFs
+	ch <- m.selectFailures
+	ch <- m.exhaustions
+	ch <- m.consumerVFs
+	m.bindDuration.Describe(ch)
+	m.bindFailures.Describe(ch)
+	m.events.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	if m.tokenPool != nil {
+		for name, states := range m.tokenPool.Stats() {
+			for state, count := range states {
+				ch <- prometheus.MustNewConstMetric(m.tokens, prometheus.GaugeValue, float64(count), name, state)
+			}
+		}
+	}
+	if m.resourcePool != nil {
+		m.resourceLock.Lock()
+		stats := m.resourcePool.Stats()
+		m.resourceLock.Unlock()
+
+		for pfPCIAddr, pfStats := range stats.PhysicalFunctions {
+			ch <- prometheus.MustNewConstMetric(m.vfs, prometheus.GaugeValue, float64(pfStats.VFs), pfPCIAddr)
+			ch <- prometheus.MustNewConstMetric(m.freeVFs, prometheus.GaugeValue, float64(pfStats.FreeVFs), pfPCIAddr)
+			ch <- prometheus.MustNewConstMetric(m.unhealthyVFs, prometheus.GaugeValue, float64(pfStats.UnhealthyVFs), pfPCIAddr)
+		}
+		ch <- prometheus.MustNewConstMetric(m.selectFailures, prometheus.CounterValue, float64(stats.SelectFailures))
+	}
+	if m.exhaustion != nil {
+		for driverType, count := range m.exhaustion.Exhaustions() {
+			ch <- prometheus.MustNewConstMetric(m.exhaustions, prometheus.CounterValue, float64(count), string(driverType))
+		}
+		if incident := m.exhaustion.LatestIncident(); incident != nil {
+			for identity, count := range incident.VFsByIdentity() {
+				ch <- prometheus.MustNewConstMetric(m.consumerVFs, prometheus.GaugeValue, float64(count), identity)
+			}
+		}
+	}
+	m.bindDuration.Collect(ch)
+	m.bindFailures.Collect(ch)
+	m.events.Collect(ch)
+}
+
+// PCIPool returns the pciPool recording the driver binding latency and failures into the Metrics, the returned pool is
+// a VFResetter if the pciPool is
+func (m *Metrics) PCIPool(pciPool PCIPool) PCIPool {
+	instrumented := &instrumentedPCIPool{
+		PCIPool: pciPool,
+		metrics: m,
+	}
+	if resetter, ok := pciPool.(VFResetter); ok {
+		return &resettingInstrumentedPCIPool{
+			instrumentedPCIPool: instrumented,
+			VFResetter:          resetter,
+		}
+	}
+	return instrumented
+}
+
+type instrumentedPCIPool struct {
+	PCIPool
+	metrics *Metrics
+}
+
+type resettingInstrumentedPCIPool struct {
+	*instrumentedPCIPool
+	VFResetter
+}
+
+func (p *instrumentedPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	start := time.Now()
+	err := p.PCIPool.BindDriver(ctx, iommuGroup, driverType)
+	if err != nil {
+		p.metrics.bindFailures.WithLabelValues(string(driverType)).Inc()
+		return err
+	}
+	p.metrics.bindDuration.WithLabelValues(string(driverType)).Observe(time.Since(start).Seconds())
+	return nil
+}
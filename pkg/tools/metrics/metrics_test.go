@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metrics"
+)
+
+const (
+	configFileName = "config.yml"
+	tokenName      = "service.domain.1/10G"
+)
+
+func TestMetrics_Collect(t *testing.T) {
+	cfg, err := config.ReadConfig(context.TODO(), configFileName)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	resourcePool := resource.NewPool(tokenPool, cfg)
+
+	tokenID, err := tokenPool.AllocateFree(tokenName)
+	require.NoError(t, err)
+	_, err = resourcePool.Select(tokenID, sriov.KernelDriver)
+	require.NoError(t, err)
+	_, err = resourcePool.Select("unknown", sriov.KernelDriver)
+	require.Error(t, err)
+
+	m := metrics.New(
+		metrics.WithTokenPool(tokenPool),
+		metrics.WithResourcePool(resourcePool, new(sync.Mutex)),
+	)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, m.Register(registry))
+	require.Error(t, m.Register(registry))
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP sriov_resource_pool_free_vfs Number of the VFs not assigned to the connections by PF
+# TYPE sriov_resource_pool_free_vfs gauge
+sriov_resource_pool_free_vfs{pf="0000:01:00.0"} 0
+sriov_resource_pool_free_vfs{pf="0000:02:00.0"} 2
+sriov_resource_pool_free_vfs{pf="0000:03:00.0"} 3
+# HELP sriov_resource_pool_select_failures_total Number of the failed VF selections
+# TYPE sriov_resource_pool_select_failures_total counter
+sriov_resource_pool_select_failures_total 1
+# HELP sriov_token_pool_tokens Number of the SR-IOV resource tokens by name and state
+# TYPE sriov_token_pool_tokens gauge
+sriov_token_pool_tokens{name="service.domain.1/10G",state="inUse"} 1
+sriov_token_pool_tokens{name="service.domain.1/intel",state="closed"} 1
+sriov_token_pool_tokens{name="service.domain.2/10G",state="free"} 2
+sriov_token_pool_tokens{name="service.domain.2/20G",state="free"} 3
+sriov_token_pool_tokens{name="service.domain.2/intel",state="free"} 5
+`), metrics.FreeVFsMetric, metrics.SelectFailuresMetric, metrics.TokensMetric))
+}
+
+func TestMetrics_Exhaustion(t *testing.T) {
+	tracker := exhaustion.NewTracker(1)
+	tracker.Assigned(&exhaustion.Consumer{ConnectionID: "id-1", Identity: "nsc-1", VFPCIAddresses: []string{"0000:01:00.1"}})
+	tracker.Assigned(&exhaustion.Consumer{ConnectionID: "id-2", Identity: "nsc-1", VFPCIAddresses: []string{"0000:01:00.2"}})
+	tracker.Assigned(&exhaustion.Consumer{ConnectionID: "id-3", Identity: "nsc-2", VFPCIAddresses: []string{"0000:01:00.3"}})
+	tracker.Exhausted(&exhaustion.Consumer{ConnectionID: "id-4", Identity: "nsc-3"}, sriov.KernelDriver)
+
+	m := metrics.New(metrics.WithExhaustionTracker(tracker))
+	registry := prometheus.NewRegistry()
+	require.NoError(t, m.Register(registry))
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP sriov_resource_pool_exhaustion_consumer_vfs Number of the VFs held by client identity at the latest exhaustion
+# TYPE sriov_resource_pool_exhaustion_consumer_vfs gauge
+sriov_resource_pool_exhaustion_consumer_vfs{identity="nsc-1"} 2
+sriov_resource_pool_exhaustion_consumer_vfs{identity="nsc-2"} 1
+# HELP sriov_resource_pool_exhaustions_total Number of the VF selections failed for the lack of free VFs by driver type
+# TYPE sriov_resource_pool_exhaustions_total counter
+sriov_resource_pool_exhaustions_total{driver="kernel"} 1
+`), metrics.ConsumerVFsMetric, metrics.ExhaustionsMetric))
+}
+
+func TestMetrics_EventBus(t *testing.T) {
+	bus := eventbus.New()
+
+	m := metrics.New(metrics.WithEventBus(bus))
+	registry := prometheus.NewRegistry()
+	require.NoError(t, m.Register(registry))
+
+	bus.Publish(token.EventTopic, nil)
+	bus.Publish(resource.EventTopic, &resource.VFEvent{VFPCIAddress: "0000:01:00.1", Change: resource.VFFreed})
+	bus.Publish(resource.EventTopic, &resource.VFEvent{VFPCIAddress: "0000:01:00.1", Change: resource.VFUnhealthy})
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP sriov_events_total Number of the SR-IOV state change events by topic
+# TYPE sriov_events_total counter
+sriov_events_total{topic="tokens"} 1
+sriov_events_total{topic="vfs"} 2
+`), metrics.EventsMetric))
+}
+
+func TestMetrics_PCIPool(t *testing.T) {
+	m := metrics.New()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, m.Register(registry))
+
+	pciPool := m.PCIPool(&pciPoolStub{
+		failedDriverType: sriov.VFIOPCIDriver,
+	})
+	require.NoError(t, pciPool.BindDriver(context.TODO(), 1, sriov.KernelDriver))
+	require.NoError(t, pciPool.BindDriver(context.TODO(), 2, sriov.KernelDriver))
+	require.Error(t, pciPool.BindDriver(context.TODO(), 1, sriov.VFIOPCIDriver))
+
+	count, err := testutil.GatherAndCount(registry, metrics.BindDurationMetric)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP sriov_pci_pool_bind_failures_total Number of the failed IOMMU group driver bindings by driver type
+# TYPE sriov_pci_pool_bind_failures_total counter
+sriov_pci_pool_bind_failures_total{driver="vfio-pci"} 1
+`), metrics.BindFailuresMetric))
+}
+
+type pciPoolStub struct {
+	failedDriverType sriov.DriverType
+}
+
+func (p *pciPoolStub) GetPCIFunction(pciAddr string) (sriov.PCIFunction, error) {
+	return nil, errors.Errorf("PCI function doesn't exist: %v", pciAddr)
+}
+
+func (p *pciPoolStub) BindDriver(_ context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	if driverType == p.failedDriverType {
+		return errors.Errorf("failed to bind IOMMU group: %v", iommuGroup)
+	}
+	return nil
+}
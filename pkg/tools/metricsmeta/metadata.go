@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metricsmeta describes the exposed SR-IOV metrics. Collectors build their Prometheus descriptors from the
+// descriptions, so the metadata served to the dashboard generators always matches the exposed metrics.
+package metricsmeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetadataPath is the metrics metadata HTTP endpoint path
+const MetadataPath = "/metrics/metadata"
+
+// Type is a metric type
+type Type string
+
+// Metric types
+const (
+	Counter   Type = "counter"
+	Gauge     Type = "gauge"
+	Histogram Type = "histogram"
+)
+
+// Label describes a metric label
+type Label struct {
+	Name string `json:"name"`
+	Help string `json:"help"`
+}
+
+// Metric describes a metric
+type Metric struct {
+	Name   string   `json:"name"`
+	Type   Type     `json:"type"`
+	Help   string   `json:"help"`
+	Unit   string   `json:"unit,omitempty"`
+	Labels []*Label `json:"labels,omitempty"`
+}
+
+// LabelNames returns the metric label names
+func (m *Metric) LabelNames() []string {
+	var names []string
+	for _, label := range m.Labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// InstrumentName returns the OpenTelemetry instrument name the Prometheus exporter exports with the metric name: the
+// exporter appends the "_total" suffix to the counters and the unit suffix to the instruments with the unit set
+func (m *Metric) InstrumentName() string {
+	name := m.Name
+	if m.Type == Counter {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	if m.Unit != "" {
+		name = strings.TrimSuffix(name, "_"+m.Unit)
+	}
+	return name
+}
+
+// Desc returns the metric Prometheus descriptor
+func (m *Metric) Desc() *prometheus.Desc {
+	return prometheus.NewDesc(m.Name, m.Help, m.LabelNames(), nil)
+}
+
+// CounterVec returns a new Prometheus counter for the metric
+func (m *Metric) CounterVec() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: m.Name,
+		Help: m.Help,
+	}, m.LabelNames())
+}
+
+// HistogramVec returns a new Prometheus histogram with the buckets for the metric
+func (m *Metric) HistogramVec(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    m.Name,
+		Help:    m.Help,
+		Buckets: buckets,
+	}, m.LabelNames())
+}
+
+// Query returns the PromQL query suitable for the metric dashboard panel: rate for the counters, 99th percentile for
+// the histograms and the value for the gauges, aggregated by the metric labels
+func (m *Metric) Query() string {
+	by := ""
+	if len(m.Labels) != 0 {
+		by = fmt.Sprintf(" by (%s)", strings.Join(m.LabelNames(), ", "))
+	}
+	switch m.Type {
+	case Counter:
+		return fmt.Sprintf("sum%s (rate(%s[5m]))", by, m.Name)
+	case Histogram:
+		return fmt.Sprintf("histogram_quantile(0.99, sum by (%s) (rate(%s_bucket[5m])))",
+			strings.Join(append(m.LabelNames(), "le"), ", "), m.Name)
+	default:
+		return fmt.Sprintf("sum%s (%s)", by, m.Name)
+	}
+}
+
+type metricJSON struct {
+	*Metric
+	Query string `json:"query"`
+}
+
+// Handler returns the HTTP handler serving the metrics descriptions sorted by name as JSON, each one with the dashboard
+// panel query
+func Handler(metrics ...*Metric) http.Handler {
+	sorted := make([]*metricJSON, 0, len(metrics))
+	for _, m := range metrics {
+		sorted = append(sorted, &metricJSON{Metric: m, Query: m.Query()})
+	}
+	sort.Slice(sorted, func(i, k int) bool {
+		return sorted[i].Name < sorted[k].Name
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(sorted)
+	})
+}
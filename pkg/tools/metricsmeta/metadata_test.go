@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsmeta_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metricsmeta"
+)
+
+var (
+	vfs = &metricsmeta.Metric{
+		Name: "sriov_vfs",
+		Type: metricsmeta.Gauge,
+		Help: "Number of the VFs",
+		Labels: []*metricsmeta.Label{
+			{Name: "pf", Help: "PF PCI address"},
+		},
+	}
+	bindFailures = &metricsmeta.Metric{
+		Name: "sriov_bind_failures_total",
+		Type: metricsmeta.Counter,
+		Help: "Number of the driver bind failures",
+		Labels: []*metricsmeta.Label{
+			{Name: "driver", Help: "driver"},
+		},
+	}
+	bindDuration = &metricsmeta.Metric{
+		Name: "sriov_bind_duration_seconds",
+		Type: metricsmeta.Histogram,
+		Help: "Duration of the driver bind",
+		Unit: "seconds",
+		Labels: []*metricsmeta.Label{
+			{Name: "driver", Help: "driver"},
+		},
+	}
+)
+
+func TestMetric_Query(t *testing.T) {
+	require.Equal(t, "sum by (pf) (sriov_vfs)", vfs.Query())
+	require.Equal(t, "sum by (driver) (rate(sriov_bind_failures_total[5m]))", bindFailures.Query())
+	require.Equal(t, "histogram_quantile(0.99, sum by (driver, le) (rate(sriov_bind_duration_seconds_bucket[5m])))",
+		bindDuration.Query())
+	require.Equal(t, "sum (up)", (&metricsmeta.Metric{Name: "up", Type: metricsmeta.Gauge}).Query())
+}
+
+func TestMetric_InstrumentName(t *testing.T) {
+	require.Equal(t, "sriov_vfs", vfs.InstrumentName())
+	require.Equal(t, "sriov_bind_failures", bindFailures.InstrumentName())
+	require.Equal(t, "sriov_bind_duration", bindDuration.InstrumentName())
+}
+
+func TestMetric_CounterVec(t *testing.T) {
+	counter := bindFailures.CounterVec()
+	counter.WithLabelValues("vfio-pci").Inc()
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(counter))
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP sriov_bind_failures_total Number of the driver bind failures
+# TYPE sriov_bind_failures_total counter
+sriov_bind_failures_total{driver="vfio-pci"} 1
+`)))
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	metricsmeta.Handler(vfs, bindFailures, bindDuration).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metricsmeta.MetadataPath, http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var metadata []struct {
+		Name   string `json:"name"`
+		Type   string `json:"type"`
+		Unit   string `json:"unit"`
+		Query  string `json:"query"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metadata))
+	require.Len(t, metadata, 3)
+
+	require.Equal(t, bindDuration.Name, metadata[0].Name)
+	require.Equal(t, "histogram", metadata[0].Type)
+	require.Equal(t, "seconds", metadata[0].Unit)
+	require.Equal(t, bindDuration.Query(), metadata[0].Query)
+	require.Equal(t, bindFailures.Name, metadata[1].Name)
+	require.Equal(t, vfs.Name, metadata[2].Name)
+	require.Equal(t, "pf", metadata[2].Labels[0].Name)
+}
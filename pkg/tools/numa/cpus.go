@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numa
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseCPUList parses CPU list in the Linux list format (e.g. "0-3,8,10-11") into the sorted CPU IDs
+func ParseCPUList(cpuList string) ([]int, error) {
+	cpuSet := map[int]struct{}{}
+	for _, part := range strings.Split(strings.TrimSpace(cpuList), ",") {
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CPU list: %s", cpuList)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, errors.Wrapf(err, "invalid CPU list: %s", cpuList)
+			}
+		}
+		if first < 0 || last < first {
+			return nil, errors.Errorf("invalid CPU list: %s", cpuList)
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			cpuSet[cpu] = struct{}{}
+		}
+	}
+
+	cpus := make([]int, 0, len(cpuSet))
+	for cpu := range cpuSet {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+
+	return cpus, nil
+}
+
+// FormatCPUList formats CPU IDs into the Linux list format
+func FormatCPUList(cpus []int) string {
+	parts := make([]string, 0, len(cpus))
+	for _, cpu := range cpus {
+		parts = append(parts, strconv.Itoa(cpu))
+	}
+	return strings.Join(parts, ",")
+}
+
+// IntersectCPUs returns CPU IDs present in both sorted lists
+func IntersectCPUs(left, right []int) []int {
+	var cpus []int
+	for i, k := 0, 0; i < len(left) && k < len(right); {
+		switch {
+		case left[i] < right[k]:
+			i++
+		case left[i] > right[k]:
+			k++
+		default:
+			cpus = append(cpus, left[i])
+			i++
+			k++
+		}
+	}
+	return cpus
+}
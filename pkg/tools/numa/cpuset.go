@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numa
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultCPUSetBaseDir is the default host cpuset cgroup directory mount location
+const DefaultCPUSetBaseDir = "/sys/fs/cgroup/cpuset"
+
+// cpusetFiles are the effective cpuset files for the cgroup v1 and v2
+var cpusetFiles = []string{"cpuset.effective_cpus", "cpuset.cpus.effective"}
+
+// ReadCPUSet returns the effective CPUs of the client cgroupDir relative to the cpusetBaseDir, cgroupDir can be a glob
+// pattern and the first match is used
+func ReadCPUSet(cpusetBaseDir, cgroupDir string) ([]int, error) {
+	for _, cpusetFile := range cpusetFiles {
+		matches, err := filepath.Glob(filepath.Join(cpusetBaseDir, cgroupDir, cpusetFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cgroup directory pattern: %s", cgroupDir)
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		cpuList, err := readString(matches[0])
+		if err != nil {
+			return nil, err
+		}
+		return ParseCPUList(cpuList)
+	}
+	return nil, errors.Errorf("no cpuset found for the cgroup directory: %s", cgroupDir)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package numa provides the node NUMA topology and the client cpuset discovery, so the hardware local to the CPUs the
+// client actually runs on can be preferred
+package numa
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultNodesPath is the default NUMA nodes sysfs path
+	DefaultNodesPath = "/sys/devices/system/node"
+
+	nodeDirPrefix = "node"
+	cpuListFile   = "cpulist"
+	distanceFile  = "distance"
+)
+
+// Node is a NUMA node
+type Node struct {
+	// ID is the NUMA node ID
+	ID int
+	// CPUs are the sorted node CPU IDs
+	CPUs []int
+	// Distances are the distances to the nodes, Distances[i] is the distance to the node i
+	Distances []int
+}
+
+// Topology is the node NUMA topology
+type Topology struct {
+	Nodes map[int]*Node
+}
+
+// ReadTopology reads the NUMA topology from the nodesPath sysfs directory, e.g. DefaultNodesPath
+func ReadTopology(nodesPath string) (*Topology, error) {
+	entries, err := os.ReadDir(nodesPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read NUMA nodes: %s", nodesPath)
+	}
+
+	t := &Topology{
+		Nodes: map[int]*Node{},
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), nodeDirPrefix) {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), nodeDirPrefix))
+		if err != nil {
+			continue
+		}
+
+		node := &Node{ID: id}
+		cpuList, err := readString(filepath.Join(nodesPath, entry.Name(), cpuListFile))
+		if err != nil {
+			return nil, err
+		}
+		if node.CPUs, err = ParseCPUList(cpuList); err != nil {
+			return nil, err
+		}
+		if distances, err := readString(filepath.Join(nodesPath, entry.Name(), distanceFile)); err == nil {
+			for _, field := range strings.Fields(distances) {
+				distance, err := strconv.Atoi(field)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid NUMA node %d distances: %s", id, distances)
+				}
+				node.Distances = append(node.Distances, distance)
+			}
+		}
+		t.Nodes[id] = node
+	}
+
+	return t, nil
+}
+
+// NodeCPUs returns the node CPUs, nil if there is no such node
+func (t *Topology) NodeCPUs(id int) []int {
+	if node, ok := t.Nodes[id]; ok {
+		return node.CPUs
+	}
+	return nil
+}
+
+// Distance returns the distance between the nodes, -1 if it is unknown
+func (t *Topology) Distance(from, to int) int {
+	node, ok := t.Nodes[from]
+	if !ok || to < 0 || to >= len(node.Distances) {
+		return -1
+	}
+	return node.Distances[to]
+}
+
+// NodeOf returns the NUMA node of the cpu, -1 if it is unknown
+func (t *Topology) NodeOf(cpu int) int {
+	for id, node := range t.Nodes {
+		if i := sort.SearchInts(node.CPUs, cpu); i < len(node.CPUs) && node.CPUs[i] == cpu {
+			return id
+		}
+	}
+	return -1
+}
+
+// PreferredNode returns the NUMA node most of the cpus are on, the lower node ID wins the tie. Returns -1 if none of
+// the cpus is on the known node.
+func (t *Topology) PreferredNode(cpus []int) int {
+	counts := map[int]int{}
+	for _, cpu := range cpus {
+		if id := t.NodeOf(cpu); id >= 0 {
+			counts[id]++
+		}
+	}
+
+	preferred := -1
+	for id, count := range counts {
+		if preferred < 0 || count > counts[preferred] || (count == counts[preferred] && id < preferred) {
+			preferred = id
+		}
+	}
+	return preferred
+}
+
+// NodesByDistance returns the node IDs sorted by the distance from the node, the node itself goes first. The nodes with
+// unknown distance go last.
+func (t *Topology) NodesByDistance(from int) []int {
+	ids := make([]int, 0, len(t.Nodes))
+	for id := range t.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, k int) bool {
+		left, right := t.Distance(from, ids[i]), t.Distance(from, ids[k])
+		switch {
+		case left == right:
+			return ids[i] < ids[k]
+		case left < 0:
+			return false
+		case right < 0:
+			return true
+		default:
+			return left < right
+		}
+	})
+	return ids
+}
+
+func readString(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read file: %s", path)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
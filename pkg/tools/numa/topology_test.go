@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numa_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/numa"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+}
+
+func testNodesPath(t *testing.T) string {
+	nodesPath := t.TempDir()
+	writeFile(t, filepath.Join(nodesPath, "node0", "cpulist"), "0-3,8-11\n")
+	writeFile(t, filepath.Join(nodesPath, "node0", "distance"), "10 21\n")
+	writeFile(t, filepath.Join(nodesPath, "node1", "cpulist"), "4-7,12-15\n")
+	writeFile(t, filepath.Join(nodesPath, "node1", "distance"), "21 10\n")
+	writeFile(t, filepath.Join(nodesPath, "possible"), "0-1\n")
+	return nodesPath
+}
+
+func TestReadTopology(t *testing.T) {
+	topology, err := numa.ReadTopology(testNodesPath(t))
+	require.NoError(t, err)
+
+	require.Len(t, topology.Nodes, 2)
+	require.Equal(t, []int{0, 1, 2, 3, 8, 9, 10, 11}, topology.NodeCPUs(0))
+	require.Nil(t, topology.NodeCPUs(2))
+
+	require.Equal(t, 21, topology.Distance(0, 1))
+	require.Equal(t, 10, topology.Distance(1, 1))
+	require.Equal(t, -1, topology.Distance(0, 2))
+
+	require.Equal(t, 1, topology.NodeOf(12))
+	require.Equal(t, -1, topology.NodeOf(16))
+
+	require.Equal(t, []int{1, 0}, topology.NodesByDistance(1))
+}
+
+func TestTopology_PreferredNode(t *testing.T) {
+	topology, err := numa.ReadTopology(testNodesPath(t))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, topology.PreferredNode([]int{2, 4, 5}))
+	require.Equal(t, 0, topology.PreferredNode([]int{2, 4}))
+	require.Equal(t, -1, topology.PreferredNode([]int{16}))
+	require.Equal(t, -1, topology.PreferredNode(nil))
+}
+
+func TestReadCPUSet(t *testing.T) {
+	cpusetBaseDir := t.TempDir()
+	writeFile(t, filepath.Join(cpusetBaseDir, "kubepods", "pod-1", "cpuset.cpus.effective"), "4-5\n")
+
+	cpus, err := numa.ReadCPUSet(cpusetBaseDir, "kubepods/*")
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 5}, cpus)
+
+	_, err = numa.ReadCPUSet(cpusetBaseDir, "kubepods/pod-2")
+	require.ErrorContains(t, err, "no cpuset found")
+}
+
+func TestCPUList(t *testing.T) {
+	cpus, err := numa.ParseCPUList("8,0-2,1")
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 8}, cpus)
+	require.Equal(t, "0,1,2,8", numa.FormatCPUList(cpus))
+	require.Equal(t, []int{1, 8}, numa.IntersectCPUs(cpus, []int{1, 3, 8}))
+
+	_, err = numa.ParseCPUList("3-1")
+	require.Error(t, err)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pcitopology provides the PCI hierarchy distance between the devices, so the hardware sharing a PCIe switch
+// with the other pod devices (e.g. GPUs) can be preferred
+package pcitopology
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultDevicesPath is the default PCI devices sysfs path
+const DefaultDevicesPath = "/sys/bus/pci/devices"
+
+// switchDepth is the common hierarchy depth (root bus, root port, switch upstream port) of the devices sharing a PCIe
+// switch
+const switchDepth = 3
+
+// Hierarchy returns the PCI hierarchy of the pciAddr device from the root bus down to the device itself, e.g.
+// [pci0000:00 0000:00:01.0 0000:01:00.0 0000:02:08.0 0000:03:00.0]
+func Hierarchy(devicesPath, pciAddr string) ([]string, error) {
+	path, err := filepath.EvalSymlinks(filepath.Join(devicesPath, pciAddr))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve PCI device path: %s", pciAddr)
+	}
+
+	var hierarchy []string
+	for _, element := range strings.Split(path, string(filepath.Separator)) {
+		if strings.HasPrefix(element, "pci") || len(hierarchy) > 0 {
+			hierarchy = append(hierarchy, element)
+		}
+	}
+	if len(hierarchy) == 0 || hierarchy[len(hierarchy)-1] != pciAddr {
+		return nil, errors.Errorf("invalid PCI device path: %s", path)
+	}
+	return hierarchy, nil
+}
+
+// Distance returns the number of the PCI hierarchy hops between the first and the second devices
+func Distance(devicesPath, first, second string) (int, error) {
+	firstHierarchy, err := Hierarchy(devicesPath, first)
+	if err != nil {
+		return 0, err
+	}
+	secondHierarchy, err := Hierarchy(devicesPath, second)
+	if err != nil {
+		return 0, err
+	}
+	common := commonDepth(firstHierarchy, secondHierarchy)
+	return len(firstHierarchy) + len(secondHierarchy) - 2*common, nil
+}
+
+// SharesSwitch returns if the first and the second devices are under the same PCIe switch
+func SharesSwitch(devicesPath, first, second string) (bool, error) {
+	firstHierarchy, err := Hierarchy(devicesPath, first)
+	if err != nil {
+		return false, err
+	}
+	secondHierarchy, err := Hierarchy(devicesPath, second)
+	if err != nil {
+		return false, err
+	}
+	return commonDepth(firstHierarchy, secondHierarchy) >= switchDepth, nil
+}
+
+func commonDepth(first, second []string) (depth int) {
+	for depth < len(first) && depth < len(second) && first[depth] == second[depth] {
+		depth++
+	}
+	return depth
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcitopology_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/pcitopology"
+)
+
+const (
+	nicAddr   = "0000:03:00.0"
+	gpuAddr   = "0000:04:00.0"
+	otherAddr = "0000:05:00.0"
+)
+
+func testDevicesPath(t *testing.T) string {
+	sysfsPath := t.TempDir()
+	devicesPath := filepath.Join(sysfsPath, "bus", "pci", "devices")
+	require.NoError(t, os.MkdirAll(devicesPath, 0o750))
+
+	for pciAddr, path := range map[string]string{
+		nicAddr:   "pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:08.0/0000:03:00.0",
+		gpuAddr:   "pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:10.0/0000:04:00.0",
+		otherAddr: "pci0000:00/0000:00:02.0/0000:05:00.0",
+	} {
+		devicePath := filepath.Join(sysfsPath, "devices", path)
+		require.NoError(t, os.MkdirAll(devicePath, 0o750))
+		require.NoError(t, os.Symlink(devicePath, filepath.Join(devicesPath, pciAddr)))
+	}
+	return devicesPath
+}
+
+func TestHierarchy(t *testing.T) {
+	devicesPath := testDevicesPath(t)
+
+	hierarchy, err := pcitopology.Hierarchy(devicesPath, otherAddr)
+	require.NoError(t, err)
+	require.Equal(t, []string{"pci0000:00", "0000:00:02.0", otherAddr}, hierarchy)
+
+	_, err = pcitopology.Hierarchy(devicesPath, "0000:06:00.0")
+	require.Error(t, err)
+}
+
+func TestDistance(t *testing.T) {
+	devicesPath := testDevicesPath(t)
+
+	distance, err := pcitopology.Distance(devicesPath, nicAddr, gpuAddr)
+	require.NoError(t, err)
+	require.Equal(t, 4, distance)
+
+	distance, err = pcitopology.Distance(devicesPath, nicAddr, otherAddr)
+	require.NoError(t, err)
+	require.Equal(t, 6, distance)
+
+	distance, err = pcitopology.Distance(devicesPath, nicAddr, nicAddr)
+	require.NoError(t, err)
+	require.Equal(t, 0, distance)
+}
+
+func TestSharesSwitch(t *testing.T) {
+	devicesPath := testDevicesPath(t)
+
+	sharesSwitch, err := pcitopology.SharesSwitch(devicesPath, nicAddr, gpuAddr)
+	require.NoError(t, err)
+	require.True(t, sharesSwitch)
+
+	sharesSwitch, err = pcitopology.SharesSwitch(devicesPath, nicAddr, otherAddr)
+	require.NoError(t, err)
+	require.False(t, sharesSwitch)
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package podresources provides kubelet PodResources API helpers discovering tokens allocated for the running pods
+package podresources
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const (
+	// DefaultSocket is a default kubelet PodResources API socket path
+	DefaultSocket         = "/var/lib/kubelet/pod-resources/kubelet.sock"
+	defaultConnectTimeout = 10 * time.Second
+)
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	Restore(tokens map[string][]string) error
+	Sync(tokens map[string][]string) error
+}
+
+// correlatedTokenPool is a token.Pool interface recording the tokens owners into the audit log
+type correlatedTokenPool interface {
+	SetCorrelationID(id, correlationID string) error
+}
+
+type options struct {
+	socket         string
+	connectTimeout time.Duration
+}
+
+// Option is an option for the PodResources API helpers
+type Option func(o *options)
+
+// WithSocket sets kubelet PodResources API socket path
+func WithSocket(socket string) Option {
+	return func(o *options) {
+		o.socket = socket
+	}
+}
+
+// WithConnectTimeout sets timeout for the kubelet PodResources API connection
+func WithConnectTimeout(connectTimeout time.Duration) Option {
+	return func(o *options) {
+		o.connectTimeout = connectTimeout
+	}
+}
+
+// GetTokens returns tokens allocated for the running pods: tokens[tokenName] -> []tokenIDs
+func GetTokens(ctx context.Context, opts ...Option) (map[string][]string, error) {
+	podResources, err := list(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return tokensOf(podResources), nil
+}
+
+// PodDevices returns the other devices allocated for the pod the deviceID device is allocated for:
+// devices[resourceName] -> []deviceIDs. Returns empty map if there is no such pod.
+func PodDevices(ctx context.Context, deviceID string, opts ...Option) (map[string][]string, error) {
+	podResources, err := list(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := map[string][]string{}
+	for _, pod := range podResources {
+		if !hasDevice(pod, deviceID) {
+			continue
+		}
+		for _, container := range pod.GetContainers() {
+			for _, containerDevices := range container.GetDevices() {
+				for _, id := range containerDevices.GetDeviceIds() {
+					if id != deviceID {
+						devices[containerDevices.GetResourceName()] = append(devices[containerDevices.GetResourceName()], id)
+					}
+				}
+			}
+		}
+		break
+	}
+	return devices, nil
+}
+
+// Restore restores tokenPool with the tokens allocated for the running pods
+func Restore(ctx context.Context, tokenPool TokenPool, opts ...Option) error {
+	tokens, err := GetTokens(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	log.FromContext(ctx).WithField("podresources", "Restore").Infof("restoring tokens: %v", tokens)
+
+	return tokenPool.Restore(tokens)
+}
+
+// Sync syncs tokenPool with the tokens allocated for the running pods. If tokenPool is a token.Pool, the pods
+// ("namespace/name") are set as the tokens correlation IDs recorded into the audit log.
+func Sync(ctx context.Context, tokenPool TokenPool, opts ...Option) error {
+	podResources, err := list(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	tokens := tokensOf(podResources)
+	log.FromContext(ctx).WithField("podresources", "Sync").Debugf("syncing tokens: %v", tokens)
+
+	if err := tokenPool.Sync(tokens); err != nil {
+		return err
+	}
+
+	if correlatedPool, ok := tokenPool.(correlatedTokenPool); ok {
+		for _, pod := range podResources {
+			for _, container := range pod.GetContainers() {
+				for _, devices := range container.GetDevices() {
+					for _, id := range devices.GetDeviceIds() {
+						// the other resources devices are not in the pool
+						_ = correlatedPool.SetCorrelationID(id, pod.GetNamespace()+"/"+pod.GetName())
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func list(ctx context.Context, opts ...Option) ([]*podresourcesapi.PodResources, error) {
+	o := &options{
+		socket:         DefaultSocket,
+		connectTimeout: defaultConnectTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, o.connectTimeout)
+	defer cancel()
+
+	cc, err := grpc.DialContext(dialCtx, "unix://"+o.socket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial kubelet PodResources API: %s", o.socket)
+	}
+	defer func() { _ = cc.Close() }()
+
+	resp, err := podresourcesapi.NewPodResourcesListerClient(cc).List(ctx, new(podresourcesapi.ListPodResourcesRequest))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pod resources")
+	}
+	return resp.GetPodResources(), nil
+}
+
+func tokensOf(podResources []*podresourcesapi.PodResources) map[string][]string {
+	tokens := map[string][]string{}
+	for _, pod := range podResources {
+		for _, container := range pod.GetContainers() {
+			for _, devices := range container.GetDevices() {
+				tokens[devices.GetResourceName()] = append(tokens[devices.GetResourceName()], devices.GetDeviceIds()...)
+			}
+		}
+	}
+	return tokens
+}
+
+func hasDevice(pod *podresourcesapi.PodResources, deviceID string) bool {
+	for _, container := range pod.GetContainers() {
+		for _, devices := range container.GetDevices() {
+			for _, id := range devices.GetDeviceIds() {
+				if id == deviceID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
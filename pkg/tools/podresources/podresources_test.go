@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package podresources_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/podresources"
+)
+
+const (
+	tokenName1 = "service.domain/10G"
+	tokenName2 = "service.domain/20G"
+	tokenID1   = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1"
+	tokenID2   = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2"
+	tokenID3   = "sriov-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx3"
+)
+
+func TestSync(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	socket := filepath.Join(t.TempDir(), "kubelet.sock")
+	startPodResources(t, socket, &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{
+				Name: "pod-1",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName1, DeviceIds: []string{tokenID1}},
+							{ResourceName: "other.domain/resource", DeviceIds: []string{"other"}},
+						},
+					},
+					{
+						Name: "container-2",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName1, DeviceIds: []string{tokenID2}},
+						},
+					},
+				},
+			},
+			{
+				Name: "pod-2",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName2, DeviceIds: []string{tokenID3}},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	tokenPool := new(tokenPoolStub)
+	require.NoError(t, podresources.Sync(ctx, tokenPool, podresources.WithSocket(socket)))
+	require.Equal(t, map[string][]string{
+		tokenName1:              {tokenID1, tokenID2},
+		tokenName2:              {tokenID3},
+		"other.domain/resource": {"other"},
+	}, tokenPool.synced)
+}
+
+func TestSync_CorrelationIDs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	socket := filepath.Join(t.TempDir(), "kubelet.sock")
+	startPodResources(t, socket, &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{
+				Name:      "pod-1",
+				Namespace: "ns-1",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName1, DeviceIds: []string{tokenID1, tokenID2}},
+						},
+					},
+				},
+			},
+			{
+				Name:      "pod-2",
+				Namespace: "ns-2",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName2, DeviceIds: []string{tokenID3}},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	tokenPool := new(correlatedTokenPoolStub)
+	require.NoError(t, podresources.Sync(ctx, tokenPool, podresources.WithSocket(socket)))
+	require.Equal(t, map[string]string{
+		tokenID1: "ns-1/pod-1",
+		tokenID2: "ns-1/pod-1",
+		tokenID3: "ns-2/pod-2",
+	}, tokenPool.correlationIDs)
+}
+
+func TestPodDevices(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	socket := filepath.Join(t.TempDir(), "kubelet.sock")
+	startPodResources(t, socket, &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{
+				Name: "pod-1",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName2, DeviceIds: []string{tokenID3}},
+						},
+					},
+				},
+			},
+			{
+				Name: "pod-2",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: tokenName1, DeviceIds: []string{tokenID1, tokenID2}},
+						},
+					},
+					{
+						Name: "container-2",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: "nvidia.com/gpu", DeviceIds: []string{"0000:04:00.0"}},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	devices, err := podresources.PodDevices(ctx, tokenID1, podresources.WithSocket(socket))
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		tokenName1:       {tokenID2},
+		"nvidia.com/gpu": {"0000:04:00.0"},
+	}, devices)
+
+	devices, err = podresources.PodDevices(ctx, "unknown", podresources.WithSocket(socket))
+	require.NoError(t, err)
+	require.Empty(t, devices)
+}
+
+func TestGetTokens_NoKubelet(t *testing.T) {
+	_, err := podresources.GetTokens(context.Background(),
+		podresources.WithSocket(filepath.Join(t.TempDir(), "kubelet.sock")),
+		podresources.WithConnectTimeout(100*time.Millisecond),
+	)
+	require.Error(t, err)
+}
+
+type podResourcesStub struct {
+	podresourcesapi.UnimplementedPodResourcesListerServer
+
+	resp *podresourcesapi.ListPodResourcesResponse
+}
+
+func startPodResources(t *testing.T, socket string, resp *podresourcesapi.ListPodResourcesResponse) {
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	podresourcesapi.RegisterPodResourcesListerServer(server, &podResourcesStub{resp: resp})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+}
+
+func (s *podResourcesStub) List(_ context.Context, _ *podresourcesapi.ListPodResourcesRequest) (*podresourcesapi.ListPodResourcesResponse, error) {
+	return s.resp, nil
+}
+
+type tokenPoolStub struct {
+	restored map[string][]string
+	synced   map[string][]string
+}
+
+func (tp *tokenPoolStub) Restore(tokens map[string][]string) error {
+	tp.restored = tokens
+	return nil
+}
+
+func (tp *tokenPoolStub) Sync(tokens map[string][]string) error {
+	tp.synced = tokens
+	return nil
+}
+
+type correlatedTokenPoolStub struct {
+	tokenPoolStub
+	correlationIDs map[string]string
+}
+
+func (tp *correlatedTokenPoolStub) SetCorrelationID(id, correlationID string) error {
+	if tp.correlationIDs == nil {
+		tp.correlationIDs = map[string]string{}
+	}
+	tp.correlationIDs[id] = correlationID
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ptp provides the PTP hardware clock (PHC) discovery for the PFs
+package ptp
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultDevicesPath is the default PCI devices sysfs path
+	DefaultDevicesPath = "/sys/bus/pci/devices"
+	// DefaultDevDir is the default directory the PHC char devices are in
+	DefaultDevDir = "/dev"
+
+	ptpDir = "ptp"
+)
+
+// ErrNoPHC is returned if the PF has no PHC
+var ErrNoPHC = errors.New("no PTP hardware clock")
+
+// PHC returns the PHC char device name of the pfPCIAddr PF, e.g. ptp0
+func PHC(devicesPath, pfPCIAddr string) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(devicesPath, pfPCIAddr, ptpDir))
+	if os.IsNotExist(err) || (err == nil && len(entries) == 0) {
+		return "", errors.Wrapf(ErrNoPHC, "PF: %s", pfPCIAddr)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read PF PHC devices: %s", pfPCIAddr)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names[0], nil
+}
+
+// Discover returns the PHC char device names of the pfPCIAddrs PFs: phcs[pfPCIAddr] -> PHC name, PFs with no PHC are
+// skipped
+func Discover(devicesPath string, pfPCIAddrs ...string) (map[string]string, error) {
+	phcs := map[string]string{}
+	for _, pfPCIAddr := range pfPCIAddrs {
+		name, err := PHC(devicesPath, pfPCIAddr)
+		switch {
+		case errors.Is(err, ErrNoPHC):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		phcs[pfPCIAddr] = name
+	}
+	return phcs, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/ptp"
+)
+
+const (
+	pf1PCIAddr = "0000:01:00.0"
+	pf2PCIAddr = "0000:02:00.0"
+)
+
+func TestDiscover(t *testing.T) {
+	devicesPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(devicesPath, pf1PCIAddr, "ptp", "ptp1"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(devicesPath, pf2PCIAddr), 0o750))
+
+	name, err := ptp.PHC(devicesPath, pf1PCIAddr)
+	require.NoError(t, err)
+	require.Equal(t, "ptp1", name)
+
+	_, err = ptp.PHC(devicesPath, pf2PCIAddr)
+	require.ErrorIs(t, err, ptp.ErrNoPHC)
+
+	phcs, err := ptp.Discover(devicesPath, pf1PCIAddr, pf2PCIAddr)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{pf1PCIAddr: "ptp1"}, phcs)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriovadmin
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+// Client is the admin gRPC service client
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient returns a new Client using the cc
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{
+		cc: cc,
+	}
+}
+
+// Dial returns a new Client connected to the admin service served on the unix socket, the returned connection should
+// be closed by the caller
+func Dial(ctx context.Context, socketPath string) (*Client, *grpc.ClientConn, error) {
+	cc, err := grpc.DialContext(ctx, "unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to dial admin socket: %s", socketPath)
+	}
+	return NewClient(cc), cc, nil
+}
+
+// GetState returns the forwarder SR-IOV state
+func (c *Client) GetState(ctx context.Context) (*State, error) {
+	resp := new(wrapperspb.BytesValue)
+	if err := c.cc.Invoke(ctx, GetStateMethod, new(emptypb.Empty), resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", GetStateMethod)
+	}
+
+	state := new(State)
+	if err := json.Unmarshal(resp.GetValue(), state); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal state")
+	}
+	return state, nil
+}
+
+// FreeToken force-frees the token and the VF selected for it
+func (c *Client) FreeToken(ctx context.Context, tokenID string) error {
+	if err := c.cc.Invoke(ctx, FreeTokenMethod, wrapperspb.String(tokenID), new(emptypb.Empty)); err != nil {
+		return errors.Wrapf(err, "failed to call %s", FreeTokenMethod)
+	}
+	return nil
+}
+
+// DrainPF excludes the PF VFs from the selection for the duration, 0 duration ends the draining. Returns the
+// assignments still holding the PF VFs.
+func (c *Client) DrainPF(ctx context.Context, pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error) {
+	data, err := json.Marshal(&DrainPFRequest{
+		PFPCIAddress: pfPCIAddr,
+		Duration:     duration,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal drain PF request")
+	}
+
+	resp := new(wrapperspb.BytesValue)
+	if err := c.cc.Invoke(ctx, DrainPFMethod, wrapperspb.Bytes(data), resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", DrainPFMethod)
+	}
+
+	var assignments []*resource.Assignment
+	if err := json.Unmarshal(resp.GetValue(), &assignments); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal assignments")
+	}
+	return assignments, nil
+}
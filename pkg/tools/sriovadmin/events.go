@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriovadmin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tokens"
+)
+
+// TokenEvent is the kind of the events recorded from the token pool audit records
+const TokenEvent = "token"
+
+// Event is a forwarder SR-IOV event
+type Event struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Message string    `json:"message"`
+}
+
+// EventLog keeps the last events up to the size. It is a tokens.AuditLog, so it can be set as the token pool audit
+// log to record the token actions.
+type EventLog struct {
+	events []*Event
+	next   int
+	size   int
+	lock   sync.Mutex
+}
+
+// NewEventLog returns a new EventLog keeping the last size events
+func NewEventLog(size int) *EventLog {
+	return &EventLog{
+		size: size,
+	}
+}
+
+// Add adds a new event of the kind
+func (l *EventLog) Add(kind, format string, v ...interface{}) {
+	l.add(&Event{
+		Time:    time.Now(),
+		Kind:    kind,
+		Message: fmt.Sprintf(format, v...),
+	})
+}
+
+// Record implements tokens.AuditLog
+func (l *EventLog) Record(record *tokens.AuditRecord) error {
+	message := fmt.Sprintf("%s %s: %s", record.Action, record.TokenName, record.TokenID)
+	if record.CorrelationID != "" {
+		message += fmt.Sprintf(" (%s)", record.CorrelationID)
+	}
+	l.add(&Event{
+		Time:    record.Time,
+		Kind:    TokenEvent,
+		Message: message,
+	})
+	return nil
+}
+
+// Subscribe subscribes the EventLog to the bus events of the topics, of all the topics if there are none. Events are
+// recorded with the topic kind and the payload message, returned func unsubscribes the EventLog.
+func (l *EventLog) Subscribe(bus *eventbus.Bus, topics ...eventbus.Topic) (unsubscribe func()) {
+	return bus.Subscribe(func(event *eventbus.Event) {
+		message := "changed"
+		if event.Payload != nil {
+			message = fmt.Sprint(event.Payload)
+		}
+		l.add(&Event{
+			Time:    event.Time,
+			Kind:    string(event.Topic),
+			Message: message,
+		})
+	}, topics...)
+}
+
+func (l *EventLog) add(event *Event) {
+	if l.size <= 0 {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(l.events) < l.size {
+		l.events = append(l.events, event)
+		return
+	}
+	l.events[l.next] = event
+	l.next = (l.next + 1) % l.size
+}
+
+// List returns the events from the oldest to the newest
+func (l *EventLog) List() []*Event {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	events := make([]*Event, 0, len(l.events))
+	events = append(events, l.events[l.next:]...)
+	return append(events, l.events[:l.next]...)
+}
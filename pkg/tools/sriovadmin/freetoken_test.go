@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package sriovadmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/ljkiraly/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+)
+
+func TestAdminServer_FreeToken_Reselect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := config.ReadConfig(ctx, configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	resourcePool := resource.NewPool(tokenPool, cfg)
+	resourceLock := new(sync.Mutex)
+	store := introspect.NewStore()
+
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		introspect.NewServer(store),
+		resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, cfg),
+	)
+	admin := sriovadmin.NewServer(
+		sriovadmin.WithTokenPool(tokenPool),
+		sriovadmin.WithResourcePool(resourcePool, resourceLock),
+		sriovadmin.WithConnections(store),
+	)
+
+	request := func(connID string) *networkservice.Connection {
+		tokenID, allocErr := tokenPool.AllocateFree(tokenName)
+		require.NoError(t, allocErr)
+
+		conn, requestErr := server.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: connID,
+				Mechanism: &networkservice.Mechanism{
+					Type: kernel.MECHANISM,
+					Parameters: map[string]string{
+						common.DeviceTokenIDKey: tokenID,
+					},
+				},
+			},
+		})
+		require.NoError(t, requestErr)
+		require.Equal(t, vf11PciAddr, conn.GetMechanism().GetParameters()[common.PCIAddressKey])
+		return conn
+	}
+
+	conn1 := request("id-1")
+
+	require.NoError(t, admin.FreeToken(conn1.GetMechanism().GetParameters()[common.DeviceTokenIDKey]))
+	require.Empty(t, store.List())
+	require.Empty(t, resourcePool.State().Assignments)
+
+	conn2 := request("id-2")
+
+	_, err = server.Close(ctx, conn1)
+	require.NoError(t, err)
+
+	require.Equal(t, []*resource.Assignment{{
+		VFPCIAddress: vf11PciAddr,
+		TokenID:      conn2.GetMechanism().GetParameters()[common.DeviceTokenIDKey],
+		DriverType:   sriov.KernelDriver,
+	}}, resourcePool.State().Assignments)
+	require.Len(t, store.List(), 1)
+
+	_, err = server.Close(ctx, conn2)
+	require.NoError(t, err)
+	require.Empty(t, resourcePool.State().Assignments)
+}
+
+func TestAdminServer_FreeToken_Checkpoint(t *testing.T) {
+	cfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	tokenID, err := tokenPool.AllocateFree(tokenName)
+	require.NoError(t, err)
+
+	checkpointPath := filepath.Join(t.TempDir(), "kubelet_internal_checkpoint")
+	admin := sriovadmin.NewServer(
+		sriovadmin.WithTokenPool(tokenPool),
+		sriovadmin.WithCheckpoint(checkpointPath),
+	)
+	require.Empty(t, admin.State().Owners)
+
+	writeCheckpoint := func(deviceIDs ...string) {
+		data, marshalErr := json.Marshal(map[string]interface{}{
+			"Data": map[string]interface{}{
+				"PodDeviceEntries": []map[string]interface{}{{
+					"PodUID":        "pod-1",
+					"ContainerName": "container-1",
+					"ResourceName":  tokenName,
+					"DeviceIDs":     deviceIDs,
+				}},
+			},
+		})
+		require.NoError(t, marshalErr)
+		require.NoError(t, os.WriteFile(checkpointPath, data, 0o600))
+	}
+
+	writeCheckpoint(tokenID)
+	require.Equal(t, "pod-1", admin.State().Owners[tokenID].PodUID)
+	require.ErrorContains(t, admin.FreeToken(tokenID), "pod-1")
+	require.Equal(t, 1, tokenPool.Stats()[tokenName]["allocated"])
+
+	writeCheckpoint()
+	require.Empty(t, admin.State().Owners)
+	require.NoError(t, admin.FreeToken(tokenID))
+	require.Zero(t, tokenPool.Stats()[tokenName]["allocated"])
+}
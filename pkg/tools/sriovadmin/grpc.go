@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriovadmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ljkiraly/sdk/pkg/tools/grpcutils"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+)
+
+// socketFileMode restricts the admin socket to the forwarder user, the admin calls are not authenticated
+const socketFileMode os.FileMode = 0o600
+
+// Full gRPC method names of the admin calls
+const (
+	GetStateMethod  = "/sriov.admin.AdminService/GetState"
+	FreeTokenMethod = "/sriov.admin.AdminService/FreeToken"
+	DrainPFMethod   = "/sriov.admin.AdminService/DrainPF"
+)
+
+// DrainPFRequest is the admin DrainPF call request
+type DrainPFRequest struct {
+	PFPCIAddress string        `json:"pfPCIAddress"`
+	Duration     time.Duration `json:"duration"`
+}
+
+type adminService interface {
+	State() *State
+	FreeToken(tokenID string) error
+	DrainPF(pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error)
+}
+
+// RegisterAdminServer registers the admin gRPC service serving the server state and actions. The service is registered
+// without the generated proto code:
+//   - GetState takes google.protobuf.Empty and returns google.protobuf.BytesValue with the JSON encoded State;
+//   - FreeToken takes google.protobuf.StringValue with the token ID and returns google.protobuf.Empty;
+//   - DrainPF takes google.protobuf.BytesValue with the JSON encoded DrainPFRequest and returns
+//     google.protobuf.BytesValue with the JSON encoded assignments still holding the PF VFs.
+func RegisterAdminServer(s grpc.ServiceRegistrar, server *Server) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "sriov.admin.AdminService",
+		HandlerType: (*adminService)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetState",
+				Handler: func(srv interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					if err := dec(new(emptypb.Empty)); err != nil {
+						return nil, err
+					}
+					data, err := json.Marshal(srv.(adminService).State())
+					if err != nil {
+						return nil, errors.Wrap(err, "failed to marshal state")
+					}
+					return wrapperspb.Bytes(data), nil
+				},
+			},
+			{
+				MethodName: "FreeToken",
+				Handler: func(srv interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					tokenID := new(wrapperspb.StringValue)
+					if err := dec(tokenID); err != nil {
+						return nil, err
+					}
+					if err := srv.(adminService).FreeToken(tokenID.GetValue()); err != nil {
+						return nil, err
+					}
+					return new(emptypb.Empty), nil
+				},
+			},
+			{
+				MethodName: "DrainPF",
+				Handler: func(srv interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					data := new(wrapperspb.BytesValue)
+					if err := dec(data); err != nil {
+						return nil, err
+					}
+					request := new(DrainPFRequest)
+					if err := json.Unmarshal(data.GetValue(), request); err != nil {
+						return nil, errors.Wrap(err, "failed to unmarshal drain PF request")
+					}
+					assignments, err := srv.(adminService).DrainPF(request.PFPCIAddress, request.Duration)
+					if err != nil {
+						return nil, err
+					}
+					if data.Value, err = json.Marshal(assignments); err != nil {
+						return nil, errors.Wrap(err, "failed to marshal assignments")
+					}
+					return data, nil
+				},
+			},
+		},
+	}, server)
+}
+
+// ListenAndServe serves the admin gRPC service on the unix socket accessible only by the socket owner until the ctx is
+// done
+func ListenAndServe(ctx context.Context, socketPath string, server *Server) <-chan error {
+	grpcServer := grpc.NewServer()
+	RegisterAdminServer(grpcServer, server)
+
+	errCh := grpcutils.ListenAndServe(ctx, &url.URL{Scheme: "unix", Path: socketPath}, grpcServer)
+	if err := os.Chmod(socketPath, socketFileMode); err != nil && !errors.Is(err, os.ErrNotExist) {
+		grpcServer.Stop()
+
+		chmodErrCh := make(chan error, 1)
+		chmodErrCh <- errors.Wrapf(err, "failed to change the admin socket mode: %s", socketPath)
+		close(chmodErrCh)
+		return chmodErrCh
+	}
+	return errCh
+}
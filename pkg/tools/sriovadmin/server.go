@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sriovadmin provides a gRPC admin service aggregating the forwarder SR-IOV state: token pool state, resource
+// pool status, active connections, recent events, resource pool exhaustions and SR-IOV config, and the node operator
+// actions: force-freeing a token and draining a PF
+package sriovadmin
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/checkpoint"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+)
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	Stats() map[string]map[string]int
+	Free(id string) error
+}
+
+// ResourcePool is a resource.Pool interface
+type ResourcePool interface {
+	Stats() *resource.Stats
+	State() *resource.State
+	Free(vfPCIAddr string) error
+	DrainPF(pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error)
+}
+
+// ExhaustionTracker is an exhaustion.Tracker interface
+type ExhaustionTracker interface {
+	Incidents() []*exhaustion.Incident
+}
+
+// State is the forwarder SR-IOV state, sections for the not configured sources are empty
+type State struct {
+	Tokens      map[string]map[string]int    `json:"tokens,omitempty"` // Tokens[name][state] -> count
+	Resources   *resource.Stats              `json:"resources,omitempty"`
+	Assignments []*resource.Assignment       `json:"assignments,omitempty"`
+	Connections []*introspect.ConnectionInfo `json:"connections,omitempty"`
+	Owners      map[string]*checkpoint.Owner `json:"owners,omitempty"` // Owners[tokenID] -> pod container
+	Events      []*Event                     `json:"events,omitempty"`
+	Exhaustions []*exhaustion.Incident       `json:"exhaustions,omitempty"`
+	Config      *config.Config               `json:"config,omitempty"`
+}
+
+// Server aggregates the forwarder SR-IOV state from the configured sources
+type Server struct {
+	tokenPool    TokenPool
+	resourcePool ResourcePool
+	resourceLock sync.Locker
+	connections  *introspect.Store
+	eventLog     *EventLog
+	exhaustion   ExhaustionTracker
+	cfg          *config.Config
+	checkpoint   string
+}
+
+// Option is an option for the Server
+type Option func(s *Server)
+
+// WithTokenPool sets the token pool to report the tokens counts by names and states
+func WithTokenPool(tokenPool TokenPool) Option {
+	return func(s *Server) {
+		s.tokenPool = tokenPool
+	}
+}
+
+// WithResourcePool sets the resource pool to report the VF counts and assignments, resourceLock is the lock the
+// resource pool is used under
+func WithResourcePool(resourcePool ResourcePool, resourceLock sync.Locker) Option {
+	return func(s *Server) {
+		s.resourcePool = resourcePool
+		s.resourceLock = resourceLock
+	}
+}
+
+// WithConnections sets the introspect store to report the active connections
+func WithConnections(store *introspect.Store) Option {
+	return func(s *Server) {
+		s.connections = store
+	}
+}
+
+// WithEventLog sets the event log to report the recent events
+func WithEventLog(eventLog *EventLog) Option {
+	return func(s *Server) {
+		s.eventLog = eventLog
+	}
+}
+
+// WithExhaustionTracker sets the exhaustion tracker to report the recent resource pool exhaustions with the VF consumers
+func WithExhaustionTracker(exhaustionTracker ExhaustionTracker) Option {
+	return func(s *Server) {
+		s.exhaustion = exhaustionTracker
+	}
+}
+
+// WithConfig sets the SR-IOV config to report
+func WithConfig(cfg *config.Config) Option {
+	return func(s *Server) {
+		s.cfg = cfg
+	}
+}
+
+// WithCheckpoint sets the kubelet device plugin checkpoint file path, e.g. checkpoint.DefaultPath, to report the pod
+// containers the tokens are assigned to and to refuse force-freeing the tokens still assigned to the pod containers
+func WithCheckpoint(path string) Option {
+	return func(s *Server) {
+		s.checkpoint = path
+	}
+}
+
+// NewServer returns a new Server
+func NewServer(options ...Option) *Server {
+	s := new(Server)
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// State returns the current forwarder SR-IOV state
+func (s *Server) State() *State {
+	state := new(State)
+	if s.tokenPool != nil {
+		state.Tokens = s.tokenPool.Stats()
+	}
+	if s.resourcePool != nil {
+		s.resourceLock.Lock()
+		state.Resources = s.resourcePool.Stats()
+		state.Assignments = s.resourcePool.State().Assignments
+		s.resourceLock.Unlock()
+	}
+	if s.connections != nil {
+		state.Connections = s.connections.List()
+	}
+	if s.checkpoint != "" {
+		// the owners are not reported if the checkpoint can't be read, e.g. before the first kubelet allocation
+		state.Owners, _ = checkpoint.Read(s.checkpoint)
+	}
+	if s.eventLog != nil {
+		state.Events = s.eventLog.List()
+	}
+	if s.exhaustion != nil {
+		state.Exhaustions = s.exhaustion.Incidents()
+	}
+	state.Config = s.cfg
+	return state
+}
+
+// FreeToken force-frees the token: the connections holding the token are closed through their chains first, so the
+// chain elements drop their own bookkeeping, then the VFs still selected for the token are freed, so the token and the
+// resource pools stay consistent. Tokens still assigned to the pod containers by kubelet are not freed.
+func (s *Server) FreeToken(tokenID string) error {
+	if s.tokenPool == nil {
+		return errors.New("token pool is not configured")
+	}
+	if s.checkpoint != "" {
+		owners, err := checkpoint.Read(s.checkpoint)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if owner, ok := owners[tokenID]; ok {
+			return errors.Errorf("token %s is assigned to the pod %s container %s", tokenID, owner.PodUID, owner.ContainerName)
+		}
+	}
+	if s.connections != nil {
+		if err := s.connections.CloseTokenConnections(tokenID); err != nil {
+			return err
+		}
+	}
+	if s.resourcePool != nil {
+		if err := s.freeAssignments(tokenID); err != nil {
+			return err
+		}
+	}
+	return s.tokenPool.Free(tokenID)
+}
+
+func (s *Server) freeAssignments(tokenID string) error {
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	for _, assignment := range s.resourcePool.State().Assignments {
+		if assignment.TokenID != tokenID {
+			continue
+		}
+		if err := s.resourcePool.Free(assignment.VFPCIAddress); err != nil {
+			return errors.Wrapf(err, "failed to free VF %s selected for the token %s", assignment.VFPCIAddress, tokenID)
+		}
+	}
+	return nil
+}
+
+// DrainPF excludes the PF VFs from the selection for the duration, 0 duration ends the draining. Returns the
+// assignments still holding the PF VFs.
+func (s *Server) DrainPF(pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error) {
+	if s.resourcePool == nil {
+		return nil, errors.New("resource pool is not configured")
+	}
+
+	s.resourceLock.Lock()
+	defer s.resourceLock.Unlock()
+
+	return s.resourcePool.DrainPF(pfPCIAddr, duration)
+}
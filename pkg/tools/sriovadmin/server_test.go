@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriovadmin_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/eventbus"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/exhaustion"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+)
+
+const (
+	configFileName = "config.yml"
+	tokenName      = "service.domain.1/10G"
+	pf1PciAddr     = "0000:01:00.0"
+	vf11PciAddr    = "0000:01:00.1"
+)
+
+func TestAdminServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := config.ReadConfig(ctx, configFileName)
+	require.NoError(t, err)
+
+	eventLog := sriovadmin.NewEventLog(10)
+	tokenPool := token.NewPool(cfg, token.WithAuditLog(eventLog))
+	resourcePool := resource.NewPool(tokenPool, cfg)
+	store := introspect.NewStore()
+	tracker := exhaustion.NewTracker(10)
+
+	server := sriovadmin.NewServer(
+		sriovadmin.WithTokenPool(tokenPool),
+		sriovadmin.WithResourcePool(resourcePool, new(sync.Mutex)),
+		sriovadmin.WithConnections(store),
+		sriovadmin.WithEventLog(eventLog),
+		sriovadmin.WithExhaustionTracker(tracker),
+		sriovadmin.WithConfig(cfg),
+	)
+
+	socketPath := filepath.Join(t.TempDir(), "admin.sock")
+	errCh := sriovadmin.ListenAndServe(ctx, socketPath, server)
+
+	socketInfo, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), socketInfo.Mode().Perm())
+
+	client, cc, err := sriovadmin.Dial(ctx, socketPath)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	tokenID, err := tokenPool.AllocateFree(tokenName)
+	require.NoError(t, err)
+	vfPCIAddr, err := resourcePool.Select(tokenID, sriov.KernelDriver)
+	require.NoError(t, err)
+
+	_, err = chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		introspect.NewServer(store),
+	).Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+					common.PCIAddressKey:    vfPCIAddr,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tracker.Assigned(&exhaustion.Consumer{ConnectionID: "id", VFPCIAddresses: []string{vfPCIAddr}})
+	tracker.Exhausted(&exhaustion.Consumer{ConnectionID: "id-2"}, sriov.KernelDriver)
+
+	state, err := client.GetState(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, state.Tokens[tokenName]["inUse"])
+	require.Equal(t, 0, state.Resources.PhysicalFunctions[pf1PciAddr].FreeVFs)
+	require.Equal(t, []*resource.Assignment{{
+		VFPCIAddress: vf11PciAddr,
+		TokenID:      tokenID,
+		DriverType:   sriov.KernelDriver,
+	}}, state.Assignments)
+	require.Len(t, state.Connections, 1)
+	require.Equal(t, vf11PciAddr, state.Connections[0].VFPCIAddress)
+
+	require.NotEmpty(t, state.Events)
+	require.Equal(t, sriovadmin.TokenEvent, state.Events[0].Kind)
+	require.Contains(t, state.Events[0].Message, tokenID)
+
+	require.Len(t, state.Exhaustions, 1)
+	require.Equal(t, "id-2", state.Exhaustions[0].Requester.ConnectionID)
+	require.Equal(t, []string{vf11PciAddr}, state.Exhaustions[0].Consumers[0].VFPCIAddresses)
+
+	require.Contains(t, state.Config.PhysicalFunctions, pf1PciAddr)
+
+	_, err = client.DrainPF(ctx, "0000:00:00.0", time.Hour)
+	require.Error(t, err)
+
+	assignments, err := client.DrainPF(ctx, pf1PciAddr, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, state.Assignments, assignments)
+
+	require.NoError(t, client.FreeToken(ctx, tokenID))
+	require.Error(t, client.FreeToken(ctx, "missing"))
+
+	state, err = client.GetState(ctx)
+	require.NoError(t, err)
+	require.Empty(t, state.Assignments)
+	require.Empty(t, state.Connections)
+	require.Zero(t, state.Tokens[tokenName]["inUse"])
+	require.Equal(t, 1, state.Resources.PhysicalFunctions[pf1PciAddr].FreeVFs)
+	require.Equal(t, 1, state.Resources.PhysicalFunctions[pf1PciAddr].UnhealthyVFs)
+
+	_ = cc.Close()
+	cancel()
+	<-errCh
+}
+
+func TestEventLog(t *testing.T) {
+	eventLog := sriovadmin.NewEventLog(2)
+	require.Empty(t, eventLog.List())
+
+	for i := 0; i < 3; i++ {
+		eventLog.Add("test", "event-%d", i)
+	}
+
+	events := eventLog.List()
+	require.Len(t, events, 2)
+	require.Equal(t, "event-1", events[0].Message)
+	require.Equal(t, "event-2", events[1].Message)
+}
+
+func TestEventLog_Subscribe(t *testing.T) {
+	bus := eventbus.New()
+	eventLog := sriovadmin.NewEventLog(10)
+	unsubscribe := eventLog.Subscribe(bus, token.EventTopic, resource.EventTopic)
+
+	bus.Publish(token.EventTopic, nil)
+	bus.Publish(resource.EventTopic, &resource.VFEvent{VFPCIAddress: "0000:01:00.1", Change: resource.VFUnhealthy})
+	bus.Publish("other", nil)
+	unsubscribe()
+	bus.Publish(token.EventTopic, nil)
+
+	events := eventLog.List()
+	require.Len(t, events, 2)
+	require.Equal(t, "tokens", events[0].Kind)
+	require.Equal(t, "changed", events[0].Message)
+	require.Equal(t, "vfs", events[1].Kind)
+	require.Equal(t, "VF 0000:01:00.1 unhealthy", events[1].Message)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sriovctl provides the node operator debug commands talking to the forwarder SR-IOV admin service, so the
+// node state can be inspected and fixed without touching sysfs by hand
+package sriovctl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+)
+
+// Commands
+const (
+	TokensCommand      = "tokens"
+	AssignmentsCommand = "assignments"
+	DrainPFCommand     = "drain-pf"
+	FreeTokenCommand   = "free-token"
+	ConfigCommand      = "config"
+
+	defaultDrainDuration = time.Hour
+)
+
+// AdminClient is a sriovadmin.Client interface
+type AdminClient interface {
+	GetState(ctx context.Context) (*sriovadmin.State, error)
+	FreeToken(ctx context.Context, tokenID string) error
+	DrainPF(ctx context.Context, pfPCIAddr string, duration time.Duration) ([]*resource.Assignment, error)
+}
+
+// Usage writes the commands description to the out
+func Usage(out io.Writer) {
+	_, _ = fmt.Fprintf(out, `Commands:
+  %s                  list the tokens counts by names and states
+  %s             show the VF assignments with the connections holding them
+  %s [-duration d] <pf>  exclude the PF VFs from the selection for the duration (1h by default, 0 ends draining)
+  %s <token-id>     force-free the token and the VF selected for it
+  %s                  dump the SR-IOV config
+`, TokensCommand, AssignmentsCommand, DrainPFCommand, FreeTokenCommand, ConfigCommand)
+}
+
+// Run runs the command given by the args with the client, writing the command output to the out
+func Run(ctx context.Context, client AdminClient, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		return errors.New("no command")
+	}
+
+	switch command, args := args[0], args[1:]; command {
+	case TokensCommand:
+		return tokens(ctx, client, out)
+	case AssignmentsCommand:
+		return assignments(ctx, client, out)
+	case DrainPFCommand:
+		return drainPF(ctx, client, out, args)
+	case FreeTokenCommand:
+		if len(args) != 1 {
+			return errors.Errorf("%s expects exactly one token ID", FreeTokenCommand)
+		}
+		if err := client.FreeToken(ctx, args[0]); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "token %s freed\n", args[0])
+		return nil
+	case ConfigCommand:
+		return dumpConfig(ctx, client, out)
+	default:
+		return errors.Errorf("unknown command: %s", command)
+	}
+}
+
+func tokens(ctx context.Context, client AdminClient, out io.Writer) error {
+	state, err := client.GetState(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSTATE\tCOUNT")
+	for _, name := range sortedKeys(state.Tokens) {
+		for _, tokenState := range sortedKeys(state.Tokens[name]) {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\n", name, tokenState, state.Tokens[name][tokenState])
+		}
+	}
+	return w.Flush()
+}
+
+func assignments(ctx context.Context, client AdminClient, out io.Writer) error {
+	state, err := client.GetState(ctx)
+	if err != nil {
+		return err
+	}
+
+	connections := map[string]string{}
+	for _, conn := range state.Connections {
+		connections[conn.VFPCIAddress] = conn.ID
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VF\tTOKEN\tDRIVER\tCONNECTION")
+	for _, a := range state.Assignments {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.VFPCIAddress, a.TokenID, a.DriverType, connections[a.VFPCIAddress])
+	}
+	return w.Flush()
+}
+
+func drainPF(ctx context.Context, client AdminClient, out io.Writer, args []string) error {
+	flags := flag.NewFlagSet(DrainPFCommand, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	duration := flags.Duration("duration", defaultDrainDuration, "draining duration")
+	if err := flags.Parse(args); err != nil {
+		return errors.Wrapf(err, "invalid %s arguments", DrainPFCommand)
+	}
+	if flags.NArg() != 1 {
+		return errors.Errorf("%s expects exactly one PF PCI address", DrainPFCommand)
+	}
+	pfPCIAddr := flags.Arg(0)
+
+	held, err := client.DrainPF(ctx, pfPCIAddr, *duration)
+	if err != nil {
+		return err
+	}
+
+	if *duration == 0 {
+		_, _ = fmt.Fprintf(out, "PF %s draining ended\n", pfPCIAddr)
+	} else {
+		_, _ = fmt.Fprintf(out, "PF %s drained for %s\n", pfPCIAddr, *duration)
+	}
+	for _, a := range held {
+		_, _ = fmt.Fprintf(out, "VF %s is still held by the token %s\n", a.VFPCIAddress, a.TokenID)
+	}
+	return nil
+}
+
+func dumpConfig(ctx context.Context, client AdminClient, out io.Writer) error {
+	state, err := client.GetState(ctx)
+	if err != nil {
+		return err
+	}
+	if state.Config == nil {
+		return errors.New("SR-IOV config is not reported by the admin service")
+	}
+
+	data, err := yaml.Marshal(state.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal config")
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sriovctl_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovadmin"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/sriovctl"
+)
+
+const (
+	pfPCIAddr = "0000:01:00.0"
+	vfPCIAddr = "0000:01:00.1"
+	tokenID   = "token-1"
+)
+
+func TestRun(t *testing.T) {
+	client := &adminClientStub{
+		state: &sriovadmin.State{
+			Tokens: map[string]map[string]int{
+				"service.domain.1/10G": {"free": 1, "inUse": 1},
+			},
+			Assignments: []*resource.Assignment{
+				{VFPCIAddress: vfPCIAddr, TokenID: tokenID, DriverType: sriov.VFIOPCIDriver},
+			},
+			Connections: []*introspect.ConnectionInfo{
+				{ID: "conn-1", VFPCIAddress: vfPCIAddr},
+			},
+			Config: &config.Config{
+				PhysicalFunctions: map[string]*config.PhysicalFunction{
+					pfPCIAddr: {PFKernelDriver: "ice", VFKernelDriver: "iavf"},
+				},
+			},
+		},
+	}
+
+	for _, sample := range []struct {
+		args   []string
+		output string
+	}{
+		{
+			args: []string{sriovctl.TokensCommand},
+			output: "NAME                  STATE  COUNT\n" +
+				"service.domain.1/10G  free   1\n" +
+				"service.domain.1/10G  inUse  1\n",
+		},
+		{
+			args: []string{sriovctl.AssignmentsCommand},
+			output: "VF            TOKEN    DRIVER    CONNECTION\n" +
+				"0000:01:00.1  token-1  vfio-pci  conn-1\n",
+		},
+		{
+			args: []string{sriovctl.DrainPFCommand, "-duration", "30m", pfPCIAddr},
+			output: "PF 0000:01:00.0 drained for 30m0s\n" +
+				"VF 0000:01:00.1 is still held by the token token-1\n",
+		},
+		{
+			args:   []string{sriovctl.FreeTokenCommand, tokenID},
+			output: "token token-1 freed\n",
+		},
+	} {
+		out := new(bytes.Buffer)
+		require.NoError(t, sriovctl.Run(context.Background(), client, out, sample.args), sample.args)
+		require.Equal(t, sample.output, out.String(), sample.args)
+	}
+	require.Equal(t, 30*time.Minute, client.drainDuration)
+	require.Equal(t, []string{tokenID}, client.freed)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, sriovctl.Run(context.Background(), client, out, []string{sriovctl.ConfigCommand}))
+	require.Contains(t, out.String(), "pfKernelDriver: ice")
+
+	require.Error(t, sriovctl.Run(context.Background(), client, out, nil))
+	require.Error(t, sriovctl.Run(context.Background(), client, out, []string{"unknown"}))
+	require.Error(t, sriovctl.Run(context.Background(), client, out, []string{sriovctl.FreeTokenCommand}))
+	require.Error(t, sriovctl.Run(context.Background(), client, out, []string{sriovctl.DrainPFCommand, "-duration", "x", pfPCIAddr}))
+	require.ErrorContains(t, sriovctl.Run(context.Background(), client, out, []string{sriovctl.FreeTokenCommand, "missing"}), "missing")
+}
+
+type adminClientStub struct {
+	state         *sriovadmin.State
+	freed         []string
+	drainDuration time.Duration
+}
+
+func (c *adminClientStub) GetState(_ context.Context) (*sriovadmin.State, error) {
+	return c.state, nil
+}
+
+func (c *adminClientStub) FreeToken(_ context.Context, id string) error {
+	if id != tokenID {
+		return errors.Errorf("token doesn't exist: %s", id)
+	}
+	c.freed = append(c.freed, id)
+	return nil
+}
+
+func (c *adminClientStub) DrainPF(_ context.Context, _ string, duration time.Duration) ([]*resource.Assignment, error) {
+	c.drainDuration = duration
+	return c.state.Assignments, nil
+}
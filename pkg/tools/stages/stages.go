@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stages records the durations of the Request stages touching the hardware. Stages are observed into the
+// Recorder stored in the context, so the chain elements record them only if the Recorder is set by the stagetiming
+// chain element.
+package stages
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/metricsmeta"
+)
+
+// Stage is a Request stage
+type Stage string
+
+// Request stages
+const (
+	// TokenLookup is the SR-IOV token ID resolution and verification
+	TokenLookup Stage = "token_lookup"
+	// VFSelection is the VF selection from the resource pool
+	VFSelection Stage = "vf_selection"
+	// DriverBind is the VF IOMMU group driver binding
+	DriverBind Stage = "driver_bind"
+	// CgroupGrant is the VFIO devices access grant to the client
+	CgroupGrant Stage = "cgroup_grant"
+	// NamespaceInjection is the VF net interface injection into the client network namespace
+	NamespaceInjection Stage = "namespace_injection"
+)
+
+// StageDurationMetric is the Histograms metric name
+const StageDurationMetric = "sriov_request_stage_duration_seconds"
+
+const (
+	stageLabel = "stage"
+
+	stageDurationBucketsStart = 0.001
+	stageDurationBucketsCount = 14
+)
+
+var stageDurationMeta = &metricsmeta.Metric{
+	Name: StageDurationMetric,
+	Type: metricsmeta.Histogram,
+	Help: "Duration of the forwarder Request stages by stage",
+	Unit: "seconds",
+	Labels: []*metricsmeta.Label{
+		{Name: stageLabel, Help: "Request stage: token_lookup, vf_selection, driver_bind, cgroup_grant or namespace_injection"},
+	},
+}
+
+// Metadata returns the descriptions of the Histograms metrics
+func Metadata() []*metricsmeta.Metric {
+	return []*metricsmeta.Metric{stageDurationMeta}
+}
+
+// Recorder records the stage durations
+type Recorder interface {
+	Observe(stage Stage, duration time.Duration)
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a new context with the recorder
+func WithRecorder(ctx context.Context, recorder Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// Observe records the stage duration since start into the context Recorder, does nothing if there is no Recorder,
+// is meant to be deferred as: defer stages.Observe(ctx, stage, time.Now())
+func Observe(ctx context.Context, stage Stage, start time.Time) {
+	if recorder, ok := ctx.Value(recorderKey{}).(Recorder); ok {
+		recorder.Observe(stage, time.Since(start))
+	}
+}
+
+// Histograms is a Prometheus collector recording the stage durations as histograms by stage
+type Histograms struct {
+	stageDuration *prometheus.HistogramVec
+}
+
+// NewHistograms returns a new Histograms
+func NewHistograms() *Histograms {
+	return &Histograms{
+		stageDuration: stageDurationMeta.HistogramVec(
+			prometheus.ExponentialBuckets(stageDurationBucketsStart, 2, stageDurationBucketsCount)),
+	}
+}
+
+// Register registers the Histograms on the registerer, e.g. on the registry already serving the forwarder metrics
+func (h *Histograms) Register(registerer prometheus.Registerer) error {
+	if err := registerer.Register(h); err != nil {
+		return errors.Wrap(err, "failed to register Request stages metrics")
+	}
+	return nil
+}
+
+// Observe implements Recorder
+func (h *Histograms) Observe(stage Stage, duration time.Duration) {
+	h.stageDuration.WithLabelValues(string(stage)).Observe(duration.Seconds())
+}
+
+// Describe implements prometheus.Collector
+func (h *Histograms) Describe(ch chan<- *prometheus.Desc) {
+	h.stageDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (h *Histograms) Collect(ch chan<- prometheus.Metric) {
+	h.stageDuration.Collect(ch)
+}
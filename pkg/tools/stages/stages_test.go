@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stages_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/stages"
+)
+
+func TestObserve(t *testing.T) {
+	histograms := stages.NewHistograms()
+
+	// no recorder in the context
+	stages.Observe(context.Background(), stages.TokenLookup, time.Now())
+	require.Zero(t, testutil.CollectAndCount(histograms))
+
+	ctx := stages.WithRecorder(context.Background(), histograms)
+	stages.Observe(ctx, stages.VFSelection, time.Now())
+	stages.Observe(ctx, stages.DriverBind, time.Now().Add(-time.Second))
+	stages.Observe(ctx, stages.DriverBind, time.Now())
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, histograms.Register(registry))
+	require.Error(t, histograms.Register(registry))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, stages.StageDurationMetric, families[0].GetName())
+
+	counts := map[string]uint64{}
+	sums := map[string]float64{}
+	for _, m := range families[0].GetMetric() {
+		stage := m.GetLabel()[0].GetValue()
+		counts[stage] = m.GetHistogram().GetSampleCount()
+		sums[stage] = m.GetHistogram().GetSampleSum()
+	}
+	require.Equal(t, map[string]uint64{
+		string(stages.VFSelection): 1,
+		string(stages.DriverBind):  2,
+	}, counts)
+	require.GreaterOrEqual(t, sums[string(stages.DriverBind)], 1.)
+}
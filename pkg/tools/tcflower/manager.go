@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tcflower provides the TC flower rules management on the switchdev VF representors, so the simple
+// cross-connects are offloaded entirely to the NIC eswitch
+package tcflower
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Rule is a TC flower rule redirecting the traffic received on the Ingress representor to the Egress representor
+type Rule struct {
+	// Ingress is the net interface the rule is installed on
+	Ingress string
+	// Egress is the net interface the traffic is redirected to
+	Egress string
+	// MatchVLAN is the VLAN ID the traffic is matched by, 0 matches all the traffic
+	MatchVLAN uint16
+	// PushVLAN is the VLAN ID pushed to the traffic before the redirect, 0 pushes nothing
+	PushVLAN uint16
+	// PopVLAN pops the VLAN tag from the traffic before the redirect
+	PopVLAN bool
+}
+
+// TC programs the TC flower rules in hardware
+type TC interface {
+	// EnsureIngressQdisc adds the ingress qdisc to the ifName net interface if there is no one
+	EnsureIngressQdisc(ifName string) error
+	// AddFlower adds the hardware only flower rule on the rule.Ingress net interface with the priority
+	AddFlower(priority uint16, rule *Rule) error
+	// DelFlower deletes the flower rule with the priority from the ifName net interface
+	DelFlower(ifName string, priority uint16) error
+	// HardwareFlowers returns the priorities of the hardware only flower rules on the ifName net interface
+	HardwareFlowers(ifName string) ([]uint16, error)
+}
+
+type installedRule struct {
+	*Rule
+	priority uint16
+}
+
+// Manager installs the rule sets by keys, allocating the rule priorities per ingress net interface. The priorities are
+// known only to the Manager instance, so the hardware only flower rules left on the ingress net interface by the
+// previous instance (e.g. before the forwarder restart) are deleted on its first use.
+type Manager struct {
+	tc         TC
+	installed  map[string][]*installedRule // installed[key] -> rules
+	priorities map[string]map[uint16]bool  // priorities[ingress][priority] -> in use
+	flushed    map[string]bool             // flushed[ingress] -> stale rules are deleted
+	lock       sync.Mutex
+}
+
+// NewManager returns a new Manager
+func NewManager(options ...Option) *Manager {
+	m := &Manager{
+		tc:         netlinkTC{},
+		installed:  map[string][]*installedRule{},
+		priorities: map[string]map[uint16]bool{},
+		flushed:    map[string]bool{},
+	}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+// Install installs the rules for the key. If the key has the same rules installed, does nothing, other rules are
+// replaced. If any of the rules fails, the installed ones are deleted.
+func (m *Manager) Install(key string, rules ...*Rule) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if installed, ok := m.installed[key]; ok {
+		if sameRules(installed, rules) {
+			return nil
+		}
+		if err := m.remove(key); err != nil {
+			return err
+		}
+	}
+
+	var installed []*installedRule
+	for _, rule := range rules {
+		r, err := m.add(rule)
+		if err != nil {
+			for _, r := range installed {
+				_ = m.del(r)
+			}
+			return err
+		}
+		installed = append(installed, r)
+	}
+	m.installed[key] = installed
+
+	return nil
+}
+
+// Remove deletes the rules installed for the key
+func (m *Manager) Remove(key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.remove(key)
+}
+
+// Installed returns the rules installed for the key
+func (m *Manager) Installed(key string) []*Rule {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var rules []*Rule
+	for _, r := range m.installed[key] {
+		rules = append(rules, r.Rule)
+	}
+	return rules
+}
+
+func (m *Manager) remove(key string) error {
+	var err error
+	for _, r := range m.installed[key] {
+		if delErr := m.del(r); delErr != nil && err == nil {
+			err = delErr
+		}
+	}
+	delete(m.installed, key)
+	return err
+}
+
+func (m *Manager) add(rule *Rule) (*installedRule, error) {
+	if err := m.tc.EnsureIngressQdisc(rule.Ingress); err != nil {
+		return nil, errors.Wrapf(err, "failed to add ingress qdisc: %s", rule.Ingress)
+	}
+	if err := m.flush(rule.Ingress); err != nil {
+		return nil, err
+	}
+
+	priorities, ok := m.priorities[rule.Ingress]
+	if !ok {
+		priorities = map[uint16]bool{}
+		m.priorities[rule.Ingress] = priorities
+	}
+	var priority uint16 = 1
+	for priorities[priority] {
+		priority++
+	}
+
+	if err := m.tc.AddFlower(priority, rule); err != nil {
+		return nil, errors.Wrapf(err, "failed to add flower rule: %s -> %s", rule.Ingress, rule.Egress)
+	}
+	priorities[priority] = true
+
+	return &installedRule{Rule: rule, priority: priority}, nil
+}
+
+// flush deletes the stale hardware only flower rules from the ingress net interface on its first use
+func (m *Manager) flush(ingress string) error {
+	if m.flushed[ingress] {
+		return nil
+	}
+
+	priorities, err := m.tc.HardwareFlowers(ingress)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list flower rules: %s", ingress)
+	}
+	for _, priority := range priorities {
+		if err := m.tc.DelFlower(ingress, priority); err != nil {
+			return errors.Wrapf(err, "failed to delete stale flower rule: %s %d", ingress, priority)
+		}
+	}
+	m.flushed[ingress] = true
+
+	return nil
+}
+
+func (m *Manager) del(r *installedRule) error {
+	delete(m.priorities[r.Ingress], r.priority)
+	if len(m.priorities[r.Ingress]) == 0 {
+		delete(m.priorities, r.Ingress)
+	}
+	if err := m.tc.DelFlower(r.Ingress, r.priority); err != nil {
+		return errors.Wrapf(err, "failed to delete flower rule: %s -> %s", r.Ingress, r.Egress)
+	}
+	return nil
+}
+
+func sameRules(installed []*installedRule, rules []*Rule) bool {
+	if len(installed) != len(rules) {
+		return false
+	}
+	for i, r := range installed {
+		if *r.Rule != *rules[i] {
+			return false
+		}
+	}
+	return true
+}
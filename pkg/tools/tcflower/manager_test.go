@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tcflower_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/tcflower"
+)
+
+type fakeRule struct {
+	priority uint16
+	rule     tcflower.Rule
+}
+
+type fakeTC struct {
+	qdiscs map[string]bool
+	rules  map[string][]*fakeRule
+	failOn string
+}
+
+func newFakeTC() *fakeTC {
+	return &fakeTC{
+		qdiscs: map[string]bool{},
+		rules:  map[string][]*fakeRule{},
+	}
+}
+
+func (tc *fakeTC) EnsureIngressQdisc(ifName string) error {
+	tc.qdiscs[ifName] = true
+	return nil
+}
+
+func (tc *fakeTC) AddFlower(priority uint16, rule *tcflower.Rule) error {
+	if rule.Ingress == tc.failOn {
+		return errors.New("not supported")
+	}
+	for _, r := range tc.rules[rule.Ingress] {
+		if r.priority == priority {
+			return errors.Errorf("file exists: %s %d", rule.Ingress, priority)
+		}
+	}
+	tc.rules[rule.Ingress] = append(tc.rules[rule.Ingress], &fakeRule{priority: priority, rule: *rule})
+	return nil
+}
+
+func (tc *fakeTC) DelFlower(ifName string, priority uint16) error {
+	for i, r := range tc.rules[ifName] {
+		if r.priority == priority {
+			tc.rules[ifName] = append(tc.rules[ifName][:i], tc.rules[ifName][i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("no rule: %s %d", ifName, priority)
+}
+
+func (tc *fakeTC) HardwareFlowers(ifName string) ([]uint16, error) {
+	var priorities []uint16
+	for _, r := range tc.rules[ifName] {
+		priorities = append(priorities, r.priority)
+	}
+	return priorities, nil
+}
+
+func TestManager_InstallRemove(t *testing.T) {
+	tc := newFakeTC()
+	m := tcflower.NewManager(tcflower.WithTC(tc))
+
+	require.NoError(t, m.Install("conn-1",
+		&tcflower.Rule{Ingress: "rep0", Egress: "rep1"},
+		&tcflower.Rule{Ingress: "rep1", Egress: "rep0"},
+	))
+	require.NoError(t, m.Install("conn-2",
+		&tcflower.Rule{Ingress: "rep0", Egress: "eth0", PushVLAN: 100},
+	))
+	require.True(t, tc.qdiscs["rep0"])
+	require.Len(t, tc.rules["rep0"], 2)
+	require.Equal(t, uint16(1), tc.rules["rep0"][0].priority)
+	require.Equal(t, uint16(2), tc.rules["rep0"][1].priority)
+
+	// Same rules are not reinstalled
+	require.NoError(t, m.Install("conn-1",
+		&tcflower.Rule{Ingress: "rep0", Egress: "rep1"},
+		&tcflower.Rule{Ingress: "rep1", Egress: "rep0"},
+	))
+	require.Len(t, tc.rules["rep0"], 2)
+
+	require.NoError(t, m.Remove("conn-1"))
+	require.Len(t, tc.rules["rep0"], 1)
+	require.Empty(t, tc.rules["rep1"])
+	require.Empty(t, m.Installed("conn-1"))
+
+	// Freed priority is reused
+	require.NoError(t, m.Install("conn-3", &tcflower.Rule{Ingress: "rep0", Egress: "rep2"}))
+	require.Equal(t, uint16(1), tc.rules["rep0"][1].priority)
+}
+
+func TestManager_Install_StaleRules(t *testing.T) {
+	tc := newFakeTC()
+	require.NoError(t, tcflower.NewManager(tcflower.WithTC(tc)).Install("conn-1",
+		&tcflower.Rule{Ingress: "rep0", Egress: "rep1"},
+		&tcflower.Rule{Ingress: "rep1", Egress: "rep0"},
+	))
+
+	// the restarted forwarder deletes the rules left by the previous Manager instead of colliding with them
+	m := tcflower.NewManager(tcflower.WithTC(tc))
+	require.NoError(t, m.Install("conn-2", &tcflower.Rule{Ingress: "rep0", Egress: "eth0", PushVLAN: 100}))
+	require.Equal(t, []*fakeRule{
+		{priority: 1, rule: tcflower.Rule{Ingress: "rep0", Egress: "eth0", PushVLAN: 100}},
+	}, tc.rules["rep0"])
+	require.Len(t, tc.rules["rep1"], 1)
+
+	// the own rules are kept
+	require.NoError(t, m.Install("conn-3", &tcflower.Rule{Ingress: "rep0", Egress: "rep2"}))
+	require.Len(t, tc.rules["rep0"], 2)
+}
+
+func TestManager_Install_Rollback(t *testing.T) {
+	tc := newFakeTC()
+	tc.failOn = "rep1"
+	m := tcflower.NewManager(tcflower.WithTC(tc))
+
+	err := m.Install("conn-1",
+		&tcflower.Rule{Ingress: "rep0", Egress: "rep1"},
+		&tcflower.Rule{Ingress: "rep1", Egress: "rep0"},
+	)
+	require.Error(t, err)
+	require.Empty(t, tc.rules["rep0"])
+	require.Empty(t, m.Installed("conn-1"))
+}
+
+func TestVFRepresentor(t *testing.T) {
+	netDir := t.TempDir()
+	writeNetAttrs(t, netDir, "eth0", "0011", "p0")
+	writeNetAttrs(t, netDir, "eth0_0", "0011", "pf0vf0")
+	writeNetAttrs(t, netDir, "eth0_1", "0011", "pf0vf1")
+	writeNetAttrs(t, netDir, "eth1_1", "0022", "pf0vf1")
+	writeNetAttrs(t, netDir, "eth1", "", "")
+
+	rep, err := tcflower.VFRepresentor(netDir, "eth0", 1)
+	require.NoError(t, err)
+	require.Equal(t, "eth0_1", rep)
+
+	_, err = tcflower.VFRepresentor(netDir, "eth0", 2)
+	require.Error(t, err)
+
+	_, err = tcflower.VFRepresentor(netDir, "eth1", 0)
+	require.ErrorContains(t, err, "not in switchdev mode")
+}
+
+func writeNetAttrs(t *testing.T, netDir, ifName, switchID, portName string) {
+	dir := filepath.Join(netDir, ifName)
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "phys_switch_id"), []byte(switchID+"\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "phys_port_name"), []byte(portName+"\n"), 0o600))
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tcflower
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// act_vlan attributes and actions missing in the netlink package
+const (
+	tcaVLANParms                  = 2
+	tcaVLANPushVLANID             = 3
+	tcaVLANPushVLANProtocol       = 4
+	tcaVLANActPop           int32 = 1
+	tcaVLANActPush          int32 = 2
+)
+
+// tcVLAN is a struct tc_vlan
+type tcVLAN struct {
+	nl.TcGen
+	VAction int32
+}
+
+func (v *tcVLAN) serialize() []byte {
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, nl.NativeEndian(), v)
+	return buf.Bytes()
+}
+
+// netlinkTC is a TC implemented with the raw netlink requests, because netlink.Flower doesn't support VLAN matching
+// and actions
+type netlinkTC struct{}
+
+func (netlinkTC) EnsureIngressQdisc(ifName string) error {
+	link, err := netlink.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find net interface: %s", ifName)
+	}
+	return netlink.QdiscReplace(&netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	})
+}
+
+func (netlinkTC) AddFlower(priority uint16, rule *Rule) error {
+	ingress, err := netlink.LinkByName(rule.Ingress)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find net interface: %s", rule.Ingress)
+	}
+	egress, err := netlink.LinkByName(rule.Egress)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find net interface: %s", rule.Egress)
+	}
+
+	protocol := uint16(unix.ETH_P_ALL)
+	if rule.MatchVLAN != 0 {
+		protocol = unix.ETH_P_8021Q
+	}
+
+	req := nl.NewNetlinkRequest(unix.RTM_NEWTFILTER, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(&nl.TcMsg{
+		Family:  nl.FAMILY_ALL,
+		Ifindex: int32(ingress.Attrs().Index),
+		Parent:  netlink.HANDLE_MIN_INGRESS,
+		Info:    netlink.MakeHandle(priority, nl.Swap16(protocol)),
+	})
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated("flower")))
+
+	options := nl.NewRtAttr(nl.TCA_OPTIONS, nil)
+	if rule.MatchVLAN != 0 {
+		options.AddRtAttr(nl.TCA_FLOWER_KEY_VLAN_ID, nl.Uint16Attr(rule.MatchVLAN))
+	}
+	options.AddRtAttr(nl.TCA_FLOWER_FLAGS, nl.Uint32Attr(nl.TCA_CLS_FLAGS_SKIP_SW))
+
+	actions := options.AddRtAttr(nl.TCA_FLOWER_ACT, nil)
+	tab := nl.TCA_ACT_TAB
+	if rule.PopVLAN || rule.PushVLAN != 0 {
+		vlan := &tcVLAN{TcGen: nl.TcGen{Action: int32(netlink.TC_ACT_PIPE)}, VAction: tcaVLANActPop}
+		if rule.PushVLAN != 0 {
+			vlan.VAction = tcaVLANActPush
+		}
+		action := actions.AddRtAttr(tab, nil)
+		tab++
+		action.AddRtAttr(nl.TCA_ACT_KIND, nl.ZeroTerminated("vlan"))
+		actionOptions := action.AddRtAttr(nl.TCA_ACT_OPTIONS, nil)
+		actionOptions.AddRtAttr(tcaVLANParms, vlan.serialize())
+		if rule.PushVLAN != 0 {
+			actionOptions.AddRtAttr(tcaVLANPushVLANID, nl.Uint16Attr(rule.PushVLAN))
+			actionOptions.AddRtAttr(tcaVLANPushVLANProtocol, nl.Uint16Attr(nl.Swap16(unix.ETH_P_8021Q)))
+		}
+	}
+	action := actions.AddRtAttr(tab, nil)
+	action.AddRtAttr(nl.TCA_ACT_KIND, nl.ZeroTerminated("mirred"))
+	mirred := &nl.TcMirred{
+		TcGen:   nl.TcGen{Action: int32(netlink.TC_ACT_STOLEN)},
+		Eaction: int32(netlink.TCA_EGRESS_REDIR),
+		Ifindex: uint32(egress.Attrs().Index),
+	}
+	action.AddRtAttr(nl.TCA_ACT_OPTIONS, nil).AddRtAttr(nl.TCA_MIRRED_PARMS, mirred.Serialize())
+	req.AddData(options)
+
+	_, err = req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+func (netlinkTC) DelFlower(ifName string, priority uint16) error {
+	link, err := netlink.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find net interface: %s", ifName)
+	}
+
+	req := nl.NewNetlinkRequest(unix.RTM_DELTFILTER, unix.NLM_F_ACK)
+	req.AddData(&nl.TcMsg{
+		Family:  nl.FAMILY_ALL,
+		Ifindex: int32(link.Attrs().Index),
+		Parent:  netlink.HANDLE_MIN_INGRESS,
+		Info:    netlink.MakeHandle(priority, 0),
+	})
+
+	_, err = req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+func (netlinkTC) HardwareFlowers(ifName string) ([]uint16, error) {
+	link, err := netlink.LinkByName(ifName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find net interface: %s", ifName)
+	}
+	filters, err := netlink.FilterList(link, netlink.HANDLE_MIN_INGRESS)
+	if err != nil {
+		return nil, err
+	}
+
+	var priorities []uint16
+	listed := map[uint16]bool{}
+	for _, filter := range filters {
+		if flower, ok := filter.(*netlink.Flower); ok && flower.SkipSw && !listed[flower.Priority] {
+			listed[flower.Priority] = true
+			priorities = append(priorities, flower.Priority)
+		}
+	}
+	return priorities, nil
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020 Doc.ai and/or its affiliates.
+// Copyright (c) 2026 Nordix Foundation.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -14,16 +14,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package vfio
+package tcflower
 
-import "golang.org/x/sys/unix"
+// Option is an option pattern for NewManager
+type Option func(m *Manager)
 
-// Major is unix.Major typed to unix.Stat_t .Dev
-func Major(dev int32) uint32 {
-	return unix.Major(uint64(dev))
-}
-
-// Minor is unix.Minor typed to unix.Stat_t .Dev
-func Minor(dev int32) uint32 {
-	return unix.Minor(uint64(dev))
+// WithTC sets TC implementation, netlink is used by default
+func WithTC(tc TC) Option {
+	return func(m *Manager) {
+		m.tc = tc
+	}
 }
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tcflower
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultNetDir is a default sysfs net interfaces directory
+const DefaultNetDir = "/sys/class/net"
+
+// IsSwitchdev returns true if the pfName PF is in the switchdev mode, so its VFs have the representors
+func IsSwitchdev(netDir, pfName string) bool {
+	switchID, err := readNetAttr(netDir, pfName, "phys_switch_id")
+	return err == nil && switchID != ""
+}
+
+// VFRepresentor returns the switchdev representor net interface name for the vfNum VF of the pfName PF
+func VFRepresentor(netDir, pfName string, vfNum int) (string, error) {
+	switchID, err := readNetAttr(netDir, pfName, "phys_switch_id")
+	if err != nil || switchID == "" {
+		return "", errors.Errorf("PF is not in switchdev mode: %s", pfName)
+	}
+	pfPortName, err := readNetAttr(netDir, pfName, "phys_port_name")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read PF port name: %s", pfName)
+	}
+	var pfIdx int
+	if _, err := fmt.Sscanf(pfPortName, "p%d", &pfIdx); err != nil {
+		return "", errors.Wrapf(err, "invalid PF port name: %s", pfPortName)
+	}
+	portName := fmt.Sprintf("pf%dvf%d", pfIdx, vfNum)
+
+	entries, err := os.ReadDir(netDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read net interfaces: %s", netDir)
+	}
+	for _, entry := range entries {
+		if s, err := readNetAttr(netDir, entry.Name(), "phys_switch_id"); err != nil || s != switchID {
+			continue
+		}
+		if p, err := readNetAttr(netDir, entry.Name(), "phys_port_name"); err == nil && p == portName {
+			return entry.Name(), nil
+		}
+	}
+	return "", errors.Errorf("no representor found for PF %s VF %d", pfName, vfNum)
+}
+
+func readNetAttr(netDir, ifName, attr string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(netDir, ifName, attr))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
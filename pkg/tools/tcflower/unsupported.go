@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package tcflower
+
+import "github.com/ljkiraly/sdk-sriov/pkg/sriov"
+
+// netlinkTC is a TC failing all the calls with sriov.UnsupportedError, TC flower offload is supported on Linux only
+type netlinkTC struct{}
+
+func (netlinkTC) EnsureIngressQdisc(string) error {
+	return sriov.NewUnsupportedError("TC flower offload")
+}
+
+func (netlinkTC) AddFlower(uint16, *Rule) error {
+	return sriov.NewUnsupportedError("TC flower offload")
+}
+
+func (netlinkTC) DelFlower(string, uint16) error {
+	return sriov.NewUnsupportedError("TC flower offload")
+}
+
+func (netlinkTC) HardwareFlowers(string) ([]uint16, error) {
+	return nil, sriov.NewUnsupportedError("TC flower offload")
+}
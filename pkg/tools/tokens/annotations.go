@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AnnotationKey is a pod annotation key storing JSON encoded tokens[tokenName] -> []tokenIDs
+	AnnotationKey = "sriov.networkservicemesh.io/tokens"
+)
+
+// ToAnnotation returns a (key, value) pair to store given tokens into the pod annotation
+func ToAnnotation(tokens map[string][]string) (key, value string, err error) {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to marshal tokens")
+	}
+	return AnnotationKey, string(data), nil
+}
+
+// FromAnnotations returns all stored tokens from the Downward API annotations file, not existing file or missing
+// annotation are treated as empty
+func FromAnnotations(filePath string) (map[string][]string, error) {
+	tokens := map[string][]string{}
+
+	data, err := os.ReadFile(filepath.Clean(filePath))
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read annotations file: %s", filePath)
+	}
+
+	// Downward API annotations file has `key="quoted value"` line format
+	for scanner := bufio.NewScanner(bytes.NewReader(data)); scanner.Scan(); {
+		key, quotedValue, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || key != AnnotationKey {
+			continue
+		}
+
+		value, err := strconv.Unquote(quotedValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid annotation value: %s", quotedValue)
+		}
+		if err := json.Unmarshal([]byte(value), &tokens); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal tokens: %s", value)
+		}
+	}
+	return tokens, nil
+}
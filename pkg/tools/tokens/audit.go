@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AuditAction is a token action recorded into the AuditLog
+type AuditAction string
+
+const (
+	// AuditAllocate - token has been allocated by the Device Plugin
+	AuditAllocate AuditAction = "allocate"
+	// AuditUse - token has been taken into use by the connection
+	AuditUse AuditAction = "use"
+	// AuditStopUsing - token is not used by the connection anymore
+	AuditStopUsing AuditAction = "stopUsing"
+	// AuditFree - token has been freed
+	AuditFree AuditAction = "free"
+	// AuditClose - token has been closed, because some other token has taken its resource into use
+	AuditClose AuditAction = "close"
+)
+
+// AuditRecord is a token action record
+type AuditRecord struct {
+	Time          time.Time   `json:"time"`
+	Action        AuditAction `json:"action"`
+	TokenName     string      `json:"tokenName"`
+	TokenID       string      `json:"tokenID"`
+	CorrelationID string      `json:"correlationID,omitempty"` // pod UID, connection ID or any other owner ID
+}
+
+// AuditLog is an append-only token actions log
+type AuditLog interface {
+	Record(record *AuditRecord) error
+}
+
+type writerAuditLog struct {
+	encoder *json.Encoder
+	lock    sync.Mutex
+}
+
+// NewAuditLog returns a new AuditLog writing JSON encoded records into w, one record per line
+func NewAuditLog(w io.Writer) AuditLog {
+	return &writerAuditLog{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (l *writerAuditLog) Record(record *AuditRecord) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err := l.encoder.Encode(record); err != nil {
+		return errors.Wrapf(err, "failed to write audit record: %v", record)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	tmpFilePrefix = "."
+	dirPerm       = 0o755
+	filePerm      = 0o644
+)
+
+// WriteDir stores given tokens into the token name file in the dir, file is replaced atomically
+func WriteDir(dir, tokenName string, tokenIDs []string) error {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return errors.Wrapf(err, "failed to create tokens directory: %s", dir)
+	}
+
+	fileName := url.PathEscape(tokenName)
+	filePath := filepath.Join(dir, fileName)
+	tmpFilePath := filepath.Join(dir, tmpFilePrefix+fileName)
+
+	if err := os.WriteFile(tmpFilePath, []byte(strings.Join(tokenIDs, ",")), filePerm); err != nil {
+		return errors.Wrapf(err, "failed to write tokens file: %s", tmpFilePath)
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		_ = os.Remove(tmpFilePath)
+		return errors.Wrapf(err, "failed to rename tokens file: %s -> %s", tmpFilePath, filePath)
+	}
+	return nil
+}
+
+// FromDir returns all stored tokens from the dir, not existing dir is treated as empty
+func FromDir(dir string) (map[string][]string, error) {
+	tokens := map[string][]string{}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read tokens directory: %s", dir)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), tmpFilePrefix) {
+			continue
+		}
+		tokenName, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		filePath := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filepath.Clean(filePath))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read tokens file: %s", filePath)
+		}
+
+		if value := strings.TrimSpace(string(data)); value != "" {
+			tokens[tokenName] = strings.Split(value, ",")
+		}
+	}
+	return tokens, nil
+}
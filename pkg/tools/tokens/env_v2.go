@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvPrefixV2 sriov token env name prefix for the v2 encoding
+	EnvPrefixV2 = EnvPrefix + "V2_"
+	// NUMANodeMetadataKey is a Token metadata key for the NUMA node of the token VFs
+	NUMANodeMetadataKey = "numaNode"
+)
+
+// Token is a SR-IOV token with metadata
+type Token struct {
+	ID       string            `json:"id"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Expires  *time.Time        `json:"expires,omitempty"`
+}
+
+// IsExpired returns if the token is expired at the given moment
+func (t *Token) IsExpired(now time.Time) bool {
+	return t.Expires != nil && !now.Before(*t.Expires)
+}
+
+// ToEnvV2 returns a (name, value) pair to store given tokens with metadata into the environment variable
+func ToEnvV2(tokenName string, toks []*Token) (name, value string, err error) {
+	data, err := json.Marshal(toks)
+	if err != nil {
+		return "", "", err
+	}
+	return fmt.Sprintf("%s%s", EnvPrefixV2, tokenName), string(data), nil
+}
+
+// FromEnvV2 returns all stored tokens with metadata from the list of environment variables, both v1 and v2 encoded
+// environment variables are accepted. If the same token name is encoded in both ways, v2 wins.
+func FromEnvV2(envs []string) map[string][]*Token {
+	toks := map[string][]*Token{}
+	for name, ids := range FromEnv(envs) {
+		for _, id := range ids {
+			toks[name] = append(toks[name], &Token{ID: id})
+		}
+	}
+	for _, env := range envs {
+		if !strings.HasPrefix(env, EnvPrefixV2) {
+			continue
+		}
+		name, value, _ := strings.Cut(strings.TrimPrefix(env, EnvPrefixV2), "=")
+
+		var v2Toks []*Token
+		if err := json.Unmarshal([]byte(value), &v2Toks); err != nil {
+			continue
+		}
+		toks[name] = v2Toks
+	}
+	return toks
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const signatureSeparator = "."
+
+// Signer signs and verifies SR-IOV token IDs with HMAC-SHA256, signed token ID has "sriov-<uuid>.<signature>" format
+type Signer struct {
+	key []byte
+}
+
+// NewSigner returns a new Signer with the given HMAC key
+func NewSigner(key []byte) *Signer {
+	return &Signer{
+		key: append([]byte(nil), key...),
+	}
+}
+
+// NewTokenID returns a new signed SR-IOV token ID
+func (s *Signer) NewTokenID() string {
+	return s.Sign(NewTokenID())
+}
+
+// Sign returns signed tokenID
+func (s *Signer) Sign(tokenID string) string {
+	return tokenID + signatureSeparator + s.signature(tokenID)
+}
+
+// Verify returns an error if tokenID is not signed with the Signer key
+func (s *Signer) Verify(tokenID string) error {
+	unsignedID, signature, ok := strings.Cut(tokenID, signatureSeparator)
+	if !ok {
+		return errors.Errorf("SR-IOV token ID is not signed: %s", tokenID)
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(unsignedID))) {
+		return errors.Errorf("invalid SR-IOV token ID signature: %s", tokenID)
+	}
+	return nil
+}
+
+func (s *Signer) signature(tokenID string) string {
+	mac := hmac.New(sha256.New, s.key)
+	_, _ = mac.Write([]byte(tokenID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
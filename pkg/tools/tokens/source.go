@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+// Source is a source of the allocated tokens
+type Source interface {
+	// Tokens returns tokens[tokenName] -> []tokenIDs
+	Tokens() map[string][]string
+}
+
+// SourceFunc is a function adapter for the Source
+type SourceFunc func() map[string][]string
+
+// Tokens calls f()
+func (f SourceFunc) Tokens() map[string][]string {
+	return f()
+}
+
+// NewEnvSource returns a new Source with the tokens stored in the process environment variables, expired tokens are
+// skipped
+func NewEnvSource() Source {
+	toks := FromEnvV2(os.Environ())
+	return SourceFunc(func() map[string][]string {
+		now := time.Now()
+
+		tokens := map[string][]string{}
+		for name, nameToks := range toks {
+			for _, tok := range nameToks {
+				if !tok.IsExpired(now) {
+					tokens[name] = append(tokens[name], tok.ID)
+				}
+			}
+		}
+		return tokens
+	})
+}
+
+type watchSource struct {
+	load   func() (map[string][]string, error)
+	tokens map[string][]string
+	lock   sync.RWMutex
+}
+
+// NewDirSource returns a new Source with the tokens stored in the dir, tokens are refreshed on the dir changes until
+// the ctx is done
+func NewDirSource(ctx context.Context, dir string) (Source, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, errors.Wrapf(err, "failed to create tokens directory: %s", dir)
+	}
+	return newWatchSource(ctx, dir, func() (map[string][]string, error) {
+		return FromDir(dir)
+	})
+}
+
+// NewAnnotationsSource returns a new Source with the tokens stored in the AnnotationKey annotation of the Downward API
+// annotations file, tokens are refreshed on the file changes until the ctx is done
+func NewAnnotationsSource(ctx context.Context, filePath string) (Source, error) {
+	// Downward API volume is updated by replacing symlinks in the file directory, so we need to watch the directory
+	return newWatchSource(ctx, filepath.Dir(filePath), func() (map[string][]string, error) {
+		return FromAnnotations(filePath)
+	})
+}
+
+func newWatchSource(ctx context.Context, dir string, load func() (map[string][]string, error)) (Source, error) {
+	logger := log.FromContext(ctx).WithField("tokens", "watchSource")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tokens directory watcher")
+	}
+	if err = watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, errors.Wrapf(err, "failed to watch tokens directory: %s", dir)
+	}
+
+	s := &watchSource{
+		load: load,
+	}
+	if err = s.refresh(); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if err := s.refresh(); err != nil {
+					logger.Warnf("failed to refresh tokens: %s", err.Error())
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warnf("tokens directory watch error: %s", err.Error())
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+func (s *watchSource) refresh() error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tokens = tokens
+	return nil
+}
+
+func (s *watchSource) Tokens() map[string][]string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.tokens
+}
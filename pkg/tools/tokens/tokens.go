@@ -16,7 +16,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package tokens provides utility methods to store and load tokens to/from environment variables
+// Package tokens provides utility methods to store and load tokens to/from environment variables, files and pod
+// annotations
 package tokens
 
 import (
@@ -41,7 +42,7 @@ func ToEnv(tokenName string, tokenIDs []string) (name, value string) {
 func FromEnv(envs []string) map[string][]string {
 	tokens := map[string][]string{}
 	for _, env := range envs {
-		if !strings.HasPrefix(env, EnvPrefix) {
+		if !strings.HasPrefix(env, EnvPrefix) || strings.HasPrefix(env, EnvPrefixV2) {
 			continue
 		}
 		nameIDs := strings.Split(strings.TrimPrefix(env, EnvPrefix), "=")
@@ -57,7 +58,8 @@ func NewTokenID() string {
 
 var tokenIDLen = len(NewTokenID())
 
-// IsTokenID returns if given string is a SR-IOV token ID
+// IsTokenID returns if given string is a SR-IOV token ID, signed token IDs are also accepted
 func IsTokenID(s string) bool {
+	s, _, _ = strings.Cut(s, signatureSeparator)
 	return strings.HasPrefix(s, sriovPrevix) && len(s) == tokenIDLen
 }
@@ -17,7 +17,14 @@
 package tokens_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -44,3 +51,130 @@ func TestFromEnv(t *testing.T) {
 		"name-2": {"4"},
 	}, toks)
 }
+
+func TestWriteDir_FromDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tokens")
+
+	toks, err := tokens.FromDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, toks)
+
+	require.NoError(t, tokens.WriteDir(dir, "service.domain/name-1", []string{"1", "2", "3"}))
+	require.NoError(t, tokens.WriteDir(dir, "service.domain/name-2", []string{"4"}))
+	require.NoError(t, tokens.WriteDir(dir, "service.domain/name-2", []string{"5"}))
+
+	toks, err = tokens.FromDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"service.domain/name-1": {"1", "2", "3"},
+		"service.domain/name-2": {"5"},
+	}, toks)
+}
+
+func TestNewDirSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := filepath.Join(t.TempDir(), "tokens")
+
+	source, err := tokens.NewDirSource(ctx, dir)
+	require.NoError(t, err)
+	require.Empty(t, source.Tokens())
+
+	require.NoError(t, tokens.WriteDir(dir, "name", []string{"1", "2"}))
+	require.Eventually(t, func() bool {
+		return len(source.Tokens()["name"]) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSigner(t *testing.T) {
+	signer := tokens.NewSigner([]byte("key"))
+
+	tokenID := signer.NewTokenID()
+	require.True(t, tokens.IsTokenID(tokenID))
+	require.NoError(t, signer.Verify(tokenID))
+
+	require.Error(t, signer.Verify(tokens.NewTokenID()))
+	require.Error(t, signer.Verify(tokens.NewSigner([]byte("other")).NewTokenID()))
+	require.Error(t, signer.Verify(tokenID+"x"))
+}
+
+func TestFromEnvV2(t *testing.T) {
+	expires := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	name, value, err := tokens.ToEnvV2("name-2", []*tokens.Token{
+		{ID: "4", Metadata: map[string]string{"capability": "10G"}, Expires: &expires},
+		{ID: "5"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "NSM_SRIOV_TOKENS_V2_name-2", name)
+
+	envs := []string{
+		"A=aaa",
+		"NSM_SRIOV_TOKENS_name-1=1,2,3",
+		"NSM_SRIOV_TOKENS_name-2=6",
+		name + "=" + value,
+	}
+
+	require.Equal(t, map[string][]string{
+		"name-1": {"1", "2", "3"},
+		"name-2": {"6"},
+	}, tokens.FromEnv(envs))
+
+	toks := tokens.FromEnvV2(envs)
+	require.Equal(t, map[string][]*tokens.Token{
+		"name-1": {{ID: "1"}, {ID: "2"}, {ID: "3"}},
+		"name-2": {
+			{ID: "4", Metadata: map[string]string{"capability": "10G"}, Expires: &expires},
+			{ID: "5"},
+		},
+	}, toks)
+
+	require.False(t, toks["name-2"][0].IsExpired(expires.Add(-time.Second)))
+	require.True(t, toks["name-2"][0].IsExpired(expires))
+	require.False(t, toks["name-2"][1].IsExpired(expires))
+}
+
+func TestNewAnnotationsSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	filePath := filepath.Join(t.TempDir(), "annotations")
+
+	source, err := tokens.NewAnnotationsSource(ctx, filePath)
+	require.NoError(t, err)
+	require.Empty(t, source.Tokens())
+
+	key, value, err := tokens.ToAnnotation(map[string][]string{
+		"service.domain/name": {"1", "2"},
+	})
+	require.NoError(t, err)
+
+	data := "a=\"b\"\n" + key + "=" + strconv.Quote(value) + "\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(data), 0o600))
+
+	require.Eventually(t, func() bool {
+		return len(source.Tokens()["service.domain/name"]) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewAuditLog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	auditLog := tokens.NewAuditLog(buf)
+
+	records := []*tokens.AuditRecord{
+		{Time: time.Unix(1, 0).UTC(), Action: tokens.AuditAllocate, TokenName: "name", TokenID: "1"},
+		{Time: time.Unix(2, 0).UTC(), Action: tokens.AuditUse, TokenName: "name", TokenID: "1", CorrelationID: "conn-1"},
+	}
+	for _, record := range records {
+		require.NoError(t, auditLog.Record(record))
+	}
+
+	decoder := json.NewDecoder(buf)
+	for _, record := range records {
+		decoded := new(tokens.AuditRecord)
+		require.NoError(t, decoder.Decode(decoded))
+		require.Equal(t, record, decoded)
+	}
+	require.False(t, decoder.More())
+}
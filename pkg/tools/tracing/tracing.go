@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing provides OpenTelemetry spans for the SR-IOV chain elements operations. Spans are started with the
+// global tracer as children of the context span, so they are nested into the sdk chain elements spans if the
+// opentelemetry is enabled and are no-op otherwise.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attributes keys
+const (
+	TokenIDKey           = attribute.Key("sriov.token_id")
+	PCIAddressKey        = attribute.Key("sriov.pci_address")
+	DriverKey            = attribute.Key("sriov.driver")
+	IOMMUGroupKey        = attribute.Key("sriov.iommu_group")
+	MechanismKey         = attribute.Key("sriov.mechanism")
+	PreviousMechanismKey = attribute.Key("sriov.previous_mechanism")
+	VFIOModeKey          = attribute.Key("sriov.vfio_mode")
+)
+
+// Start starts a new span for the operation as a child of the ctx span
+func Start(ctx context.Context, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer("").Start(ctx, operation, trace.WithAttributes(attributes...))
+}
+
+// End records the operation error into the span if there is any and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfconfig
+
+import (
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	maxVLANID = 4095
+	maxQoS    = 7
+)
+
+// Fake is a Configurator implementation for testing storing the VF attributes in memory
+type Fake struct {
+	PFs map[string][]*VF // PFs[pfName] -> VFs, the VF traffic counters are set here directly
+
+	lock sync.Mutex
+}
+
+// NewFake returns a new Fake with the numVFs VFs on each of the PFs, the VFs have the kernel default attributes: spoof
+// check enabled and link state auto
+func NewFake(numVFs int, pfNames ...string) *Fake {
+	f := &Fake{
+		PFs: map[string][]*VF{},
+	}
+	for _, pfName := range pfNames {
+		for vfNum := 0; vfNum < numVFs; vfNum++ {
+			f.PFs[pfName] = append(f.PFs[pfName], &VF{
+				Num:       vfNum,
+				SpoofChk:  true,
+				LinkState: LinkStateAuto,
+			})
+		}
+	}
+	return f
+}
+
+// VFs returns copies of the PF VFs
+func (f *Fake) VFs(pfName string) ([]*VF, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	pfVFs, ok := f.PFs[pfName]
+	if !ok {
+		return nil, errors.Errorf("failed to find PF net interface: %s", pfName)
+	}
+	vfs := make([]*VF, 0, len(pfVFs))
+	for _, vf := range pfVFs {
+		vfs = append(vfs, vf.clone())
+	}
+	return vfs, nil
+}
+
+// VF returns a copy of the VF
+func (f *Fake) VF(pfName string, vfNum int) (*VF, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	vf, err := f.vf(pfName, vfNum)
+	if err != nil {
+		return nil, err
+	}
+	return vf.clone(), nil
+}
+
+// SetMAC sets the VF MAC address
+func (f *Fake) SetMAC(pfName string, vfNum int, mac net.HardwareAddr) error {
+	return f.set(pfName, vfNum, func(vf *VF) error {
+		if len(mac) != 6 {
+			return errors.Errorf("invalid MAC address: %s", mac)
+		}
+		vf.MAC = append(net.HardwareAddr(nil), mac...)
+		return nil
+	})
+}
+
+// SetVLAN sets the VF VLAN ID and 802.1p priority
+func (f *Fake) SetVLAN(pfName string, vfNum, vlanID, qos int) error {
+	return f.set(pfName, vfNum, func(vf *VF) error {
+		if vlanID < 0 || vlanID > maxVLANID || qos < 0 || qos > maxQoS {
+			return errors.Errorf("invalid VLAN %d qos %d", vlanID, qos)
+		}
+		vf.VLAN, vf.QoS = vlanID, qos
+		return nil
+	})
+}
+
+// SetRate sets the VF min and max TX rates
+func (f *Fake) SetRate(pfName string, vfNum, minTxRate, maxTxRate int) error {
+	return f.set(pfName, vfNum, func(vf *VF) error {
+		if minTxRate < 0 || maxTxRate < 0 || maxTxRate != 0 && minTxRate > maxTxRate {
+			return errors.Errorf("invalid rate %d-%d Mbps", minTxRate, maxTxRate)
+		}
+		vf.MinTxRate, vf.MaxTxRate = minTxRate, maxTxRate
+		return nil
+	})
+}
+
+// SetTrust sets the VF trust
+func (f *Fake) SetTrust(pfName string, vfNum int, trust bool) error {
+	return f.set(pfName, vfNum, func(vf *VF) error {
+		vf.Trust = trust
+		return nil
+	})
+}
+
+// SetSpoofChk sets the VF spoof check
+func (f *Fake) SetSpoofChk(pfName string, vfNum int, spoofChk bool) error {
+	return f.set(pfName, vfNum, func(vf *VF) error {
+		vf.SpoofChk = spoofChk
+		return nil
+	})
+}
+
+// SetLinkState sets the VF link state
+func (f *Fake) SetLinkState(pfName string, vfNum int, state LinkState) error {
+	return f.set(pfName, vfNum, func(vf *VF) error {
+		if state > LinkStateDisable {
+			return errors.Errorf("invalid link state: %d", state)
+		}
+		vf.LinkState = state
+		return nil
+	})
+}
+
+func (f *Fake) set(pfName string, vfNum int, setFunc func(vf *VF) error) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	vf, err := f.vf(pfName, vfNum)
+	if err != nil {
+		return err
+	}
+	if err := setFunc(vf); err != nil {
+		return errors.Wrapf(err, "failed to set VF %d: %s", vfNum, pfName)
+	}
+	return nil
+}
+
+func (f *Fake) vf(pfName string, vfNum int) (*VF, error) {
+	for _, vf := range f.PFs[pfName] {
+		if vf.Num == vfNum {
+			return vf, nil
+		}
+	}
+	return nil, errors.Errorf("failed to find VF %d: %s", vfNum, pfName)
+}
+
+func (vf *VF) clone() *VF {
+	c := *vf
+	c.MAC = append(net.HardwareAddr(nil), vf.MAC...)
+	return &c
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfconfig
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+type netlinkConfigurator struct{}
+
+// NewNetlink returns a new Configurator implemented with the rtnetlink PF link VF attributes, like
+// `ip link show pfName` and `ip link set pfName vf vfNum ...`
+func NewNetlink() Configurator {
+	return netlinkConfigurator{}
+}
+
+func (netlinkConfigurator) VFs(pfName string) ([]*VF, error) {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return nil, err
+	}
+	vfs := make([]*VF, 0, len(pfLink.Attrs().Vfs))
+	for i := range pfLink.Attrs().Vfs {
+		vfs = append(vfs, newVF(&pfLink.Attrs().Vfs[i]))
+	}
+	return vfs, nil
+}
+
+func (c netlinkConfigurator) VF(pfName string, vfNum int) (*VF, error) {
+	vfs, err := c.VFs(pfName)
+	if err != nil {
+		return nil, err
+	}
+	for _, vf := range vfs {
+		if vf.Num == vfNum {
+			return vf, nil
+		}
+	}
+	return nil, errors.Errorf("failed to find VF %d: %s", vfNum, pfName)
+}
+
+func (netlinkConfigurator) SetMAC(pfName string, vfNum int, mac net.HardwareAddr) error {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetVfHardwareAddr(pfLink, vfNum, mac); err != nil {
+		return errors.Wrapf(err, "failed to set MAC address %s for the VF %d: %s", mac, vfNum, pfName)
+	}
+	return nil
+}
+
+func (netlinkConfigurator) SetVLAN(pfName string, vfNum, vlanID, qos int) error {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetVfVlanQos(pfLink, vfNum, vlanID, qos); err != nil {
+		return errors.Wrapf(err, "failed to set VLAN %d qos %d for the VF %d: %s", vlanID, qos, vfNum, pfName)
+	}
+	return nil
+}
+
+func (netlinkConfigurator) SetRate(pfName string, vfNum, minTxRate, maxTxRate int) error {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetVfRate(pfLink, vfNum, minTxRate, maxTxRate); err != nil {
+		return errors.Wrapf(err, "failed to set rate %d-%d Mbps for the VF %d: %s", minTxRate, maxTxRate, vfNum, pfName)
+	}
+	return nil
+}
+
+func (netlinkConfigurator) SetTrust(pfName string, vfNum int, trust bool) error {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetVfTrust(pfLink, vfNum, trust); err != nil {
+		return errors.Wrapf(err, "failed to set trust %t for the VF %d: %s", trust, vfNum, pfName)
+	}
+	return nil
+}
+
+func (netlinkConfigurator) SetSpoofChk(pfName string, vfNum int, spoofChk bool) error {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetVfSpoofchk(pfLink, vfNum, spoofChk); err != nil {
+		return errors.Wrapf(err, "failed to set spoof check %t for the VF %d: %s", spoofChk, vfNum, pfName)
+	}
+	return nil
+}
+
+func (netlinkConfigurator) SetLinkState(pfName string, vfNum int, state LinkState) error {
+	pfLink, err := pfLinkByName(pfName)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetVfState(pfLink, vfNum, uint32(state)); err != nil {
+		return errors.Wrapf(err, "failed to set link state %s for the VF %d: %s", state, vfNum, pfName)
+	}
+	return nil
+}
+
+func pfLinkByName(pfName string) (netlink.Link, error) {
+	pfLink, err := netlink.LinkByName(pfName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find PF net interface: %s", pfName)
+	}
+	return pfLink, nil
+}
+
+func newVF(vfInfo *netlink.VfInfo) *VF {
+	return &VF{
+		Num:       vfInfo.ID,
+		MAC:       vfInfo.Mac,
+		VLAN:      vfInfo.Vlan,
+		QoS:       vfInfo.Qos,
+		MinTxRate: int(vfInfo.MinTxRate),
+		MaxTxRate: int(vfInfo.MaxTxRate),
+		Trust:     vfInfo.Trust != 0,
+		SpoofChk:  vfInfo.Spoofchk,
+		LinkState: LinkState(vfInfo.LinkState),
+		Stats: Stats{
+			RxPackets: vfInfo.RxPackets,
+			TxPackets: vfInfo.TxPackets,
+			RxBytes:   vfInfo.RxBytes,
+			TxBytes:   vfInfo.TxBytes,
+			RxDropped: vfInfo.RxDropped,
+			TxDropped: vfInfo.TxDropped,
+		},
+	}
+}
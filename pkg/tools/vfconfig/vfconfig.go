@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vfconfig provides reading and setting the VF attributes of the PF net interfaces: MAC address, VLAN, rate,
+// trust, spoof check and link state
+package vfconfig
+
+import (
+	"net"
+
+	"github.com/vishvananda/netlink/nl"
+)
+
+// LinkState is a VF link state
+type LinkState uint32
+
+// VF link states
+const (
+	// LinkStateAuto makes the VF link follow the PF link state
+	LinkStateAuto LinkState = nl.IFLA_VF_LINK_STATE_AUTO
+	// LinkStateEnable makes the VF link always up
+	LinkStateEnable LinkState = nl.IFLA_VF_LINK_STATE_ENABLE
+	// LinkStateDisable makes the VF link always down
+	LinkStateDisable LinkState = nl.IFLA_VF_LINK_STATE_DISABLE
+)
+
+func (s LinkState) String() string {
+	switch s {
+	case LinkStateAuto:
+		return "auto"
+	case LinkStateEnable:
+		return "enable"
+	case LinkStateDisable:
+		return "disable"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats is the VF traffic counters
+type Stats struct {
+	RxPackets uint64
+	TxPackets uint64
+	RxBytes   uint64
+	TxBytes   uint64
+	RxDropped uint64
+	TxDropped uint64
+}
+
+// VF is the VF attributes set on the PF net interface and the VF traffic counters
+type VF struct {
+	// Num is the VF number
+	Num int
+	// MAC is the VF MAC address
+	MAC net.HardwareAddr
+	// VLAN is the VF VLAN ID, 0 if the VF traffic is not tagged
+	VLAN int
+	// QoS is the VF VLAN 802.1p priority
+	QoS int
+	// MinTxRate is the VF min TX rate in Mbps, 0 if not limited
+	MinTxRate int
+	// MaxTxRate is the VF max TX rate in Mbps, 0 if not limited
+	MaxTxRate int
+	// Trust is true if the VF is trusted, e.g. may set the promiscuous mode
+	Trust bool
+	// SpoofChk is true if the VF source MAC address spoof check is enabled
+	SpoofChk bool
+	// LinkState is the VF link state
+	LinkState LinkState
+	// Stats is the VF traffic counters, it is ignored by the setters
+	Stats Stats
+}
+
+// Configurator reads and sets the VF attributes, pfName is the PF net interface name and vfNum is the VF number
+type Configurator interface {
+	// VFs returns the attributes and the traffic counters of all the PF VFs
+	VFs(pfName string) ([]*VF, error)
+	// VF returns the VF attributes and traffic counters
+	VF(pfName string, vfNum int) (*VF, error)
+	// SetMAC sets the VF MAC address
+	SetMAC(pfName string, vfNum int, mac net.HardwareAddr) error
+	// SetVLAN sets the VF VLAN ID and 802.1p priority, vlanID == 0 removes the tagging
+	SetVLAN(pfName string, vfNum, vlanID, qos int) error
+	// SetRate sets the VF min and max TX rates in Mbps, 0 removes the limit
+	SetRate(pfName string, vfNum, minTxRate, maxTxRate int) error
+	// SetTrust sets the VF trust
+	SetTrust(pfName string, vfNum int, trust bool) error
+	// SetSpoofChk enables or disables the VF spoof check
+	SetSpoofChk(pfName string, vfNum int, spoofChk bool) error
+	// SetLinkState sets the VF link state
+	SetLinkState(pfName string, vfNum int, state LinkState) error
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfconfig_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+const pfName = "eth0"
+
+func TestFake(t *testing.T) {
+	var c vfconfig.Configurator = vfconfig.NewFake(2, pfName)
+
+	vf, err := c.VF(pfName, 1)
+	require.NoError(t, err)
+	require.Equal(t, &vfconfig.VF{Num: 1, SpoofChk: true, LinkState: vfconfig.LinkStateAuto}, vf)
+
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+	require.NoError(t, c.SetMAC(pfName, 1, mac))
+	require.NoError(t, c.SetVLAN(pfName, 1, 100, 3))
+	require.NoError(t, c.SetRate(pfName, 1, 100, 1000))
+	require.NoError(t, c.SetTrust(pfName, 1, true))
+	require.NoError(t, c.SetSpoofChk(pfName, 1, false))
+	require.NoError(t, c.SetLinkState(pfName, 1, vfconfig.LinkStateDisable))
+
+	vf, err = c.VF(pfName, 1)
+	require.NoError(t, err)
+	require.Equal(t, &vfconfig.VF{
+		Num:       1,
+		MAC:       mac,
+		VLAN:      100,
+		QoS:       3,
+		MinTxRate: 100,
+		MaxTxRate: 1000,
+		Trust:     true,
+		SpoofChk:  false,
+		LinkState: vfconfig.LinkStateDisable,
+	}, vf)
+	require.Equal(t, "disable", vf.LinkState.String())
+
+	vf.VLAN = 200
+	vfs, err := c.VFs(pfName)
+	require.NoError(t, err)
+	require.Len(t, vfs, 2)
+	require.Equal(t, 100, vfs[1].VLAN)
+
+	require.Error(t, c.SetVLAN(pfName, 1, 4096, 0))
+	require.Error(t, c.SetVLAN(pfName, 1, 100, 8))
+	require.Error(t, c.SetRate(pfName, 1, 1000, 100))
+	require.Error(t, c.SetLinkState(pfName, 1, vfconfig.LinkState(3)))
+	require.Error(t, c.SetMAC(pfName, 1, net.HardwareAddr{1}))
+
+	require.Error(t, c.SetTrust(pfName, 2, true))
+	_, err = c.VFs("eth1")
+	require.Error(t, err)
+	_, err = c.VF("eth1", 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfioinit
+
+import "context"
+
+const (
+	// VFIOModule is the VFIO core kernel module
+	VFIOModule = "vfio"
+	// VFIOIOMMUType1Module is the VFIO IOMMU type1 driver kernel module
+	VFIOIOMMUType1Module = "vfio_iommu_type1"
+	// VFIOPCIModule is the VFIO PCI driver kernel module
+	VFIOPCIModule = "vfio_pci"
+
+	// NoIOMMUModeParameter is the VFIOModule parameter enabling the unsafe no-IOMMU mode
+	NoIOMMUModeParameter = "enable_unsafe_noiommu_mode"
+)
+
+// ModuleLoader loads the kernel module with the parameters formatted as "name=value"
+type ModuleLoader func(ctx context.Context, module string, parameters ...string) error
+
+type parameter struct {
+	module, name, value string
+}
+
+type options struct {
+	sysModulePath string
+	moduleLoader  ModuleLoader
+	parameters    []*parameter
+}
+
+// Option is an option for Init
+type Option func(o *options)
+
+// WithSysModulePath sets the kernel modules sysfs directory, "/sys/module" by default
+func WithSysModulePath(sysModulePath string) Option {
+	return func(o *options) {
+		o.sysModulePath = sysModulePath
+	}
+}
+
+// WithModuleLoader sets the kernel module loader, "modprobe" by default
+func WithModuleLoader(moduleLoader ModuleLoader) Option {
+	return func(o *options) {
+		o.moduleLoader = moduleLoader
+	}
+}
+
+// WithModuleParameter requires the module parameter to be set to the value
+func WithModuleParameter(module, name, value string) Option {
+	return func(o *options) {
+		o.parameters = append(o.parameters, &parameter{
+			module: module,
+			name:   name,
+			value:  value,
+		})
+	}
+}
+
+// WithNoIOMMUMode requires the VFIO unsafe no-IOMMU mode to be enabled
+func WithNoIOMMUMode() Option {
+	return WithModuleParameter(VFIOModule, NoIOMMUModeParameter, "Y")
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vfioinit provides a setup helper ensuring the VFIO kernel modules are loaded with the required parameters
+package vfioinit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+)
+
+const (
+	defaultSysModulePath = "/sys/module"
+	parametersDir        = "parameters"
+)
+
+// Init ensures the VFIO kernel modules are loaded and the required module parameters are set:
+//   - not loaded modules are loaded with the required parameters
+//   - the required parameters of the already loaded modules are set with sysfs, if they are writable
+//
+// Returned error describes what should be done on the host if Init is not able to do it itself.
+func Init(ctx context.Context, opts ...Option) error {
+	logger := log.FromContext(ctx).WithField("vfioinit", "Init")
+
+	o := &options{
+		sysModulePath: defaultSysModulePath,
+		moduleLoader:  modprobe,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for _, module := range []string{VFIOModule, VFIOIOMMUType1Module, VFIOPCIModule} {
+		if isModuleLoaded(o.sysModulePath, module) {
+			continue
+		}
+
+		var parameters []string
+		for _, p := range o.parameters {
+			if p.module == module {
+				parameters = append(parameters, p.name+"="+p.value)
+			}
+		}
+
+		logger.Infof("loading kernel module: %s %s", module, strings.Join(parameters, " "))
+		if err := o.moduleLoader(ctx, module, parameters...); err != nil {
+			return errors.Wrapf(err, "kernel module %s is not loaded, load it on the host: modprobe %s %s",
+				module, module, strings.Join(parameters, " "))
+		}
+		if !isModuleLoaded(o.sysModulePath, module) {
+			return errors.Errorf("kernel module %s is not loaded after modprobe, check the host kernel provides it", module)
+		}
+	}
+
+	for _, p := range o.parameters {
+		if err := ensureParameter(o.sysModulePath, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func modprobe(ctx context.Context, module string, parameters ...string) error {
+	output, err := exec.CommandContext(ctx, "modprobe", append([]string{module}, parameters...)...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "modprobe %s failed: %s", module, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func isModuleLoaded(sysModulePath, module string) bool {
+	_, err := os.Stat(filepath.Join(sysModulePath, module))
+	return err == nil
+}
+
+func ensureParameter(sysModulePath string, p *parameter) error {
+	parameterPath := filepath.Join(sysModulePath, p.module, parametersDir, p.name)
+
+	if value, err := readParameter(parameterPath); err != nil || isEqual(value, p.value) {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Clean(parameterPath), []byte(p.value), 0o600); err != nil {
+		return errors.Wrapf(err, "failed to set kernel module parameter %s, reload the module on the host: "+
+			"modprobe -r %s && modprobe %s %s=%s", parameterPath, p.module, p.module, p.name, p.value)
+	}
+
+	switch value, err := readParameter(parameterPath); {
+	case err != nil:
+		return err
+	case !isEqual(value, p.value):
+		return errors.Errorf("kernel module parameter %s is %s after setting it to %s", parameterPath, value, p.value)
+	}
+	return nil
+}
+
+func readParameter(parameterPath string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(parameterPath))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read kernel module parameter: %s", parameterPath)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// isEqual compares the module parameter values, boolean parameters are read as Y/N and can be written as 1/0
+func isEqual(value, expected string) bool {
+	normalize := func(s string) string {
+		switch s {
+		case "1", "y":
+			return "Y"
+		case "0", "n":
+			return "N"
+		default:
+			return s
+		}
+	}
+	return normalize(value) == normalize(expected)
+}
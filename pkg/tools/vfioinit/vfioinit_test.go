@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfioinit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfioinit"
+)
+
+func TestInit(t *testing.T) {
+	sysModulePath := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(sysModulePath, vfioinit.VFIOModule, "parameters"), 0o750))
+	parameterPath := filepath.Join(sysModulePath, vfioinit.VFIOModule, "parameters", vfioinit.NoIOMMUModeParameter)
+	require.NoError(t, os.WriteFile(parameterPath, []byte("N\n"), 0o600))
+
+	var loaded []string
+	loader := func(_ context.Context, module string, parameters ...string) error {
+		require.Empty(t, parameters)
+		loaded = append(loaded, module)
+		return os.Mkdir(filepath.Join(sysModulePath, module), 0o750)
+	}
+
+	require.NoError(t, vfioinit.Init(context.Background(),
+		vfioinit.WithSysModulePath(sysModulePath),
+		vfioinit.WithModuleLoader(loader),
+		vfioinit.WithNoIOMMUMode()))
+
+	require.Equal(t, []string{vfioinit.VFIOIOMMUType1Module, vfioinit.VFIOPCIModule}, loaded)
+
+	data, err := os.ReadFile(parameterPath)
+	require.NoError(t, err)
+	require.Equal(t, "Y", string(data))
+
+	// everything is already loaded and set
+	require.NoError(t, vfioinit.Init(context.Background(),
+		vfioinit.WithSysModulePath(sysModulePath),
+		vfioinit.WithModuleLoader(func(context.Context, string, ...string) error {
+			return errors.New("unexpected load")
+		}),
+		vfioinit.WithNoIOMMUMode()))
+}
+
+func TestInit_LoadFailed(t *testing.T) {
+	sysModulePath := t.TempDir()
+
+	var parameters []string
+	err := vfioinit.Init(context.Background(),
+		vfioinit.WithSysModulePath(sysModulePath),
+		vfioinit.WithModuleLoader(func(_ context.Context, _ string, params ...string) error {
+			parameters = params
+			return errors.New("module not found")
+		}),
+		vfioinit.WithNoIOMMUMode())
+	require.ErrorContains(t, err, "modprobe vfio enable_unsafe_noiommu_mode=Y")
+	require.Equal(t, []string{"enable_unsafe_noiommu_mode=Y"}, parameters)
+}
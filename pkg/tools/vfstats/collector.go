@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vfstats provides a Prometheus collector exporting the assigned VFs traffic counters per connection
+package vfstats
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ljkiraly/sdk/pkg/tools/log"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	sriovvfconfig "github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+)
+
+// ConnectionSource is an introspect.Store interface
+type ConnectionSource interface {
+	List() []*introspect.ConnectionInfo
+}
+
+// PCIPool is a pci.Pool interface
+type PCIPool interface {
+	GetPCIFunction(pciAddr string) (sriov.PCIFunction, error)
+}
+
+// TokenPool is a token.Pool interface
+type TokenPool interface {
+	Find(id string) (string, error)
+}
+
+// Collector is a Prometheus collector reading the traffic counters of the VFs assigned to the active connections from
+// the PF VF configurator (same as `ip link show` does) on scrape
+type Collector struct {
+	config      *config.Config
+	pciPool     PCIPool
+	connections ConnectionSource
+	tokenPool   TokenPool
+	vfConfig    sriovvfconfig.Configurator
+
+	rxBytes   *prometheus.Desc
+	txBytes   *prometheus.Desc
+	rxPackets *prometheus.Desc
+	txPackets *prometheus.Desc
+	rxDrops   *prometheus.Desc
+	txDrops   *prometheus.Desc
+}
+
+// Option is an option for the Collector
+type Option func(c *Collector)
+
+// WithTokenPool sets the token pool to label the counters by the connection token service domain and capability
+func WithTokenPool(tokenPool TokenPool) Option {
+	return func(c *Collector) {
+		c.tokenPool = tokenPool
+	}
+}
+
+// WithVFConfigurator sets the VF configurator to read the VF traffic counters with, netlink by default
+func WithVFConfigurator(configurator sriovvfconfig.Configurator) Option {
+	return func(c *Collector) {
+		c.vfConfig = configurator
+	}
+}
+
+// NewCollector returns a new Collector for the VFs of the connections, the VFs are looked up in the SR-IOV config and
+// their PF net interfaces in the PCI pool
+func NewCollector(cfg *config.Config, pciPool PCIPool, connections ConnectionSource, options ...Option) *Collector {
+	c := &Collector{
+		config:      cfg,
+		pciPool:     pciPool,
+		connections: connections,
+		vfConfig:    sriovvfconfig.NewNetlink(),
+		rxBytes:     rxBytesMeta.Desc(),
+		txBytes:     txBytesMeta.Desc(),
+		rxPackets:   rxPacketsMeta.Desc(),
+		txPackets:   txPacketsMeta.Desc(),
+		rxDrops:     rxDropsMeta.Desc(),
+		txDrops:     txDropsMeta.Desc(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rxBytes
+	ch <- c.txBytes
+	ch <- c.rxPackets
+	ch <- c.txPackets
+	ch <- c.rxDrops
+	ch <- c.txDrops
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	logger := log.L().WithField("vfStatsCollector", "Collect")
+
+	for _, conn := range c.connections.List() {
+		if conn.VFPCIAddress == "" {
+			continue
+		}
+
+		vf, err := c.vf(conn.VFPCIAddress)
+		if err != nil {
+			logger.Warnf("failed to get VF %s stats: %s", conn.VFPCIAddress, err.Error())
+			continue
+		}
+
+		var serviceDomain, capability string
+		if c.tokenPool != nil && conn.TokenID != "" {
+			if tokenName, err := c.tokenPool.Find(conn.TokenID); err == nil {
+				serviceDomain, capability = path.Split(tokenName)
+				serviceDomain = path.Clean(serviceDomain)
+			}
+		}
+
+		labelValues := []string{conn.ID, conn.VFPCIAddress, serviceDomain, capability}
+		for desc, value := range map[*prometheus.Desc]uint64{
+			c.rxBytes:   vf.Stats.RxBytes,
+			c.txBytes:   vf.Stats.TxBytes,
+			c.rxPackets: vf.Stats.RxPackets,
+			c.txPackets: vf.Stats.TxPackets,
+			c.rxDrops:   vf.Stats.RxDropped,
+			c.txDrops:   vf.Stats.TxDropped,
+		} {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), labelValues...)
+		}
+	}
+}
+
+func (c *Collector) vf(vfPCIAddr string) (*sriovvfconfig.VF, error) {
+	for pfPCIAddr, pfCfg := range c.config.PhysicalFunctions {
+		for vfNum, vfCfg := range pfCfg.VirtualFunctions {
+			if vfCfg.Address != vfPCIAddr {
+				continue
+			}
+			pf, err := c.pciPool.GetPCIFunction(pfPCIAddr)
+			if err != nil {
+				return nil, err
+			}
+			pfIfName, err := pf.GetNetInterfaceName()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get PF net interface name: %s", pfPCIAddr)
+			}
+			return c.vfConfig.VF(pfIfName, vfNum)
+		}
+	}
+	return nil, errors.Errorf("VF is not configured: %s", vfPCIAddr)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vfstats_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ljkiraly/sdk/pkg/networkservice/common/begin"
+	"github.com/ljkiraly/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/networkservice/common/introspect"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/config"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/pci"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/resource"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/sriovtest"
+	"github.com/ljkiraly/sdk-sriov/pkg/sriov/token"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfconfig"
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/vfstats"
+)
+
+const (
+	configFileName = "config.yml"
+	tokenName      = "service.domain.2/20G"
+)
+
+func TestCollector(t *testing.T) {
+	cfg, err := config.ReadConfig(context.Background(), configFileName)
+	require.NoError(t, err)
+
+	pciPool, err := pci.NewTestPool(sriovtest.NewPhysicalFunctions(cfg), cfg)
+	require.NoError(t, err)
+
+	tokenPool := token.NewPool(cfg)
+	resourcePool := resource.NewPool(tokenPool, cfg)
+
+	tokenID, err := tokenPool.AllocateFree(tokenName)
+	require.NoError(t, err)
+	vfPCIAddr, err := resourcePool.Select(tokenID, sriov.KernelDriver)
+	require.NoError(t, err)
+
+	store := introspect.NewStore()
+	_, err = chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		introspect.NewServer(store),
+	).Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.DeviceTokenIDKey: tokenID,
+					common.PCIAddressKey:    vfPCIAddr,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	vfConfig := vfconfig.NewFake(len(cfg.PhysicalFunctions["0000:02:00.0"].VirtualFunctions), "fake-pf2")
+	for _, vf := range vfConfig.PFs["fake-pf2"] {
+		vf.Stats = vfconfig.Stats{
+			RxBytes:   1000,
+			TxBytes:   2000,
+			RxPackets: 10,
+			TxPackets: 20,
+			RxDropped: 1,
+			TxDropped: uint64(vf.Num),
+		}
+	}
+
+	collector := vfstats.NewCollector(cfg, pciPool, store,
+		vfstats.WithTokenPool(tokenPool),
+		vfstats.WithVFConfigurator(vfConfig),
+	)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP sriov_vf_rx_bytes_total Number of the bytes received by the connection VF
+# TYPE sriov_vf_rx_bytes_total counter
+sriov_vf_rx_bytes_total{capability="20G",connection="id",service_domain="service.domain.2",vf="`+vfPCIAddr+`"} 1000
+# HELP sriov_vf_tx_dropped_total Number of the transmitted packets dropped by the connection VF
+# TYPE sriov_vf_tx_dropped_total counter
+sriov_vf_tx_dropped_total{capability="20G",connection="id",service_domain="service.domain.2",vf="`+vfPCIAddr+`"} 0
+`), vfstats.RxBytesMetric, vfstats.TxDropsMetric))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfstats
+
+import "github.com/ljkiraly/sdk-sriov/pkg/tools/metricsmeta"
+
+// Metrics names
+const (
+	RxBytesMetric   = "sriov_vf_rx_bytes_total"
+	TxBytesMetric   = "sriov_vf_tx_bytes_total"
+	RxPacketsMetric = "sriov_vf_rx_packets_total"
+	TxPacketsMetric = "sriov_vf_tx_packets_total"
+	RxDropsMetric   = "sriov_vf_rx_dropped_total"
+	TxDropsMetric   = "sriov_vf_tx_dropped_total"
+)
+
+var (
+	labels = []*metricsmeta.Label{
+		{Name: "connection", Help: "NSM connection ID"},
+		{Name: "vf", Help: "VF PCI address"},
+		{Name: "service_domain", Help: "connection token service domain"},
+		{Name: "capability", Help: "connection token capability"},
+	}
+
+	rxBytesMeta = &metricsmeta.Metric{
+		Name:   RxBytesMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the bytes received by the connection VF",
+		Unit:   "bytes",
+		Labels: labels,
+	}
+	txBytesMeta = &metricsmeta.Metric{
+		Name:   TxBytesMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the bytes transmitted by the connection VF",
+		Unit:   "bytes",
+		Labels: labels,
+	}
+	rxPacketsMeta = &metricsmeta.Metric{
+		Name:   RxPacketsMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the packets received by the connection VF",
+		Labels: labels,
+	}
+	txPacketsMeta = &metricsmeta.Metric{
+		Name:   TxPacketsMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the packets transmitted by the connection VF",
+		Labels: labels,
+	}
+	rxDropsMeta = &metricsmeta.Metric{
+		Name:   RxDropsMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the received packets dropped by the connection VF",
+		Labels: labels,
+	}
+	txDropsMeta = &metricsmeta.Metric{
+		Name:   TxDropsMetric,
+		Type:   metricsmeta.Counter,
+		Help:   "Number of the transmitted packets dropped by the connection VF",
+		Labels: labels,
+	}
+)
+
+// Metadata returns the descriptions of the Collector metrics
+func Metadata() []*metricsmeta.Metric {
+	return []*metricsmeta.Metric{
+		rxBytesMeta,
+		txBytesMeta,
+		rxPacketsMeta,
+		txPacketsMeta,
+		rxDropsMeta,
+		txDropsMeta,
+	}
+}
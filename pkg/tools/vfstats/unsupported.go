@@ -0,0 +1,27 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+// Package vfstats provides a Prometheus collector exporting the assigned VFs traffic counters per connection
+package vfstats
+
+// Collector is not supported on the non-Linux platforms, the VF traffic counters are read from the PF netlink VF info
+type Collector struct{}
+
+// Option is an option for the Collector
+type Option func(c *Collector)
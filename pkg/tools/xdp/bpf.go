@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package xdp
+
+import (
+	"os"
+	"runtime"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// BPF is a bpf(2) syscall interface
+type BPF interface {
+	// CreateXSKMap creates a new XSKMAP with maxEntries RX queue slots
+	CreateXSKMap(maxEntries uint32) (*os.File, error)
+	// LoadRedirectProgram loads the XDP program redirecting the RX queue packets to the AF_XDP sockets from the xskMap,
+	// the packets of the queues with no socket are passed to the kernel stack
+	LoadRedirectProgram(xskMap *os.File) (*os.File, error)
+	// Get opens the BPF object pinned on the bpffs pinPath
+	Get(pinPath string) (*os.File, error)
+	// Pin pins the BPF object on the bpffs pinPath
+	Pin(file *os.File, pinPath string) error
+}
+
+type bpfInsn struct {
+	code uint8
+	regs uint8 // dst:4 | src:4
+	off  int16
+	imm  int32
+}
+
+type bpfMapCreateAttr struct {
+	mapType    uint32
+	keySize    uint32
+	valueSize  uint32
+	maxEntries uint32
+	mapFlags   uint32
+}
+
+type bpfProgLoadAttr struct {
+	progType    uint32
+	insnCnt     uint32
+	insns       uint64
+	license     uint64
+	logLevel    uint32
+	logSize     uint32
+	logBuf      uint64
+	kernVersion uint32
+	progFlags   uint32
+}
+
+type bpfObjAttr struct {
+	pathname  uint64
+	bpfFD     uint32
+	fileFlags uint32
+}
+
+const (
+	// bpf_insn opcodes used by the redirect program
+	opLdxMemW   = 0x61 // BPF_LDX | BPF_MEM | BPF_W
+	opLdImm64   = 0x18 // BPF_LD | BPF_IMM | BPF_DW
+	opMov64Imm  = 0xb7 // BPF_ALU64 | BPF_MOV | BPF_K
+	opCall      = 0x85 // BPF_JMP | BPF_CALL
+	opExit      = 0x95 // BPF_JMP | BPF_EXIT
+	xdpPass     = 2
+	funcRedMap  = 51 // bpf_redirect_map
+	xdpMDRxQIdx = 16 // offsetof(struct xdp_md, rx_queue_index)
+
+	bpfLicense = "GPL\x00"
+)
+
+type syscallBPF struct{}
+
+func bpfSyscall(cmd int, attr unsafe.Pointer, size uintptr) (int, error) {
+	fd, _, errno := unix.Syscall(unix.SYS_BPF, uintptr(cmd), uintptr(attr), size)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+func (syscallBPF) CreateXSKMap(maxEntries uint32) (*os.File, error) {
+	attr := &bpfMapCreateAttr{
+		mapType:    unix.BPF_MAP_TYPE_XSKMAP,
+		keySize:    4,
+		valueSize:  4,
+		maxEntries: maxEntries,
+	}
+	fd, err := bpfSyscall(unix.BPF_MAP_CREATE, unsafe.Pointer(attr), unsafe.Sizeof(*attr))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create XSKMAP")
+	}
+	return os.NewFile(uintptr(fd), "xsks_map"), nil
+}
+
+func (syscallBPF) LoadRedirectProgram(xskMap *os.File) (*os.File, error) {
+	insns := []bpfInsn{
+		// r2 = ctx->rx_queue_index
+		{code: opLdxMemW, regs: 2 | 1<<4, off: xdpMDRxQIdx},
+		// r1 = xskMap
+		{code: opLdImm64, regs: 1 | unix.BPF_PSEUDO_MAP_FD<<4, imm: int32(xskMap.Fd())},
+		{},
+		// r3 = XDP_PASS
+		{code: opMov64Imm, regs: 3, imm: xdpPass},
+		// return bpf_redirect_map(xskMap, rx_queue_index, XDP_PASS)
+		{code: opCall, imm: funcRedMap},
+		{code: opExit},
+	}
+	license := []byte(bpfLicense)
+
+	attr := &bpfProgLoadAttr{
+		progType: unix.BPF_PROG_TYPE_XDP,
+		insnCnt:  uint32(len(insns)),
+		insns:    uint64(uintptr(unsafe.Pointer(&insns[0]))),
+		license:  uint64(uintptr(unsafe.Pointer(&license[0]))),
+	}
+	fd, err := bpfSyscall(unix.BPF_PROG_LOAD, unsafe.Pointer(attr), unsafe.Sizeof(*attr))
+	runtime.KeepAlive(insns)
+	runtime.KeepAlive(license)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load XDP redirect program")
+	}
+	return os.NewFile(uintptr(fd), "xdp_redirect_prog"), nil
+}
+
+func (syscallBPF) Get(pinPath string) (*os.File, error) {
+	pathname, err := unix.BytePtrFromString(pinPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pin path: %s", pinPath)
+	}
+	attr := &bpfObjAttr{
+		pathname: uint64(uintptr(unsafe.Pointer(pathname))),
+	}
+	fd, err := bpfSyscall(unix.BPF_OBJ_GET, unsafe.Pointer(attr), unsafe.Sizeof(*attr))
+	runtime.KeepAlive(pathname)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pinned BPF object: %s", pinPath)
+	}
+	return os.NewFile(uintptr(fd), pinPath), nil
+}
+
+func (syscallBPF) Pin(file *os.File, pinPath string) error {
+	pathname, err := unix.BytePtrFromString(pinPath)
+	if err != nil {
+		return errors.Wrapf(err, "invalid pin path: %s", pinPath)
+	}
+	attr := &bpfObjAttr{
+		pathname: uint64(uintptr(unsafe.Pointer(pathname))),
+		bpfFD:    uint32(file.Fd()),
+	}
+	_, err = bpfSyscall(unix.BPF_OBJ_PIN, unsafe.Pointer(attr), unsafe.Sizeof(*attr))
+	runtime.KeepAlive(pathname)
+	if err != nil {
+		return errors.Wrapf(err, "failed to pin BPF object: %s", pinPath)
+	}
+	return nil
+}
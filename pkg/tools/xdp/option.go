@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package xdp
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// Option is an option for NewPreparer
+type Option func(p *Preparer)
+
+// WithProgram sets the provided XDP program and the XSKMAP it redirects to, both pinned on the bpffs, to be used
+// instead of the built-in redirect program
+func WithProgram(programPinPath, mapPinPath string) Option {
+	return func(p *Preparer) {
+		p.programPinPath = programPinPath
+		p.mapPinPath = mapPinPath
+	}
+}
+
+// WithPinDir sets the bpffs directory the per-interface XSKMAPs are pinned in, /sys/fs/bpf/nsm-sriov by default
+func WithPinDir(pinDir string) Option {
+	return func(p *Preparer) {
+		p.pinDir = pinDir
+	}
+}
+
+// WithGenericMode attaches the XDP program in the generic (SKB) mode, for the VF drivers without the native XDP
+// support. AF_XDP zero-copy is not available in this mode.
+func WithGenericMode() Option {
+	return func(p *Preparer) {
+		p.xdpFlags = unix.XDP_FLAGS_SKB_MODE
+	}
+}
+
+// WithBPF sets the bpf(2) syscall implementation, used for testing
+func WithBPF(bpf BPF) Option {
+	return func(p *Preparer) {
+		p.bpf = bpf
+	}
+}
+
+// WithNetlink sets the netlink implementation, netlink package functions are used by default
+func WithNetlink(nl Netlink) Option {
+	return func(p *Preparer) {
+		p.netlink = nl
+	}
+}
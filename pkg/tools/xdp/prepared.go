@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xdp
+
+import "os"
+
+// Prepared is an AF_XDP-ready VF net interface
+type Prepared struct {
+	// InterfaceName is the VF net interface name
+	InterfaceName string
+	// QueueCount is the VF RX queues count
+	QueueCount int
+	// XSKMap is the XSKMAP the AF_XDP sockets should be inserted into by the RX queue index
+	XSKMap *os.File
+
+	program *os.File
+	pinPath string
+}
+
+func (p *Prepared) close() {
+	for _, file := range []*os.File{p.XSKMap, p.program} {
+		if file != nil {
+			_ = file.Close()
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package xdp provides the AF_XDP-ready VF preparation: the forwarder attaches the XDP program redirecting the VF RX
+// queues to the XSKMAP and hands the map over to the unprivileged client, so the client only needs to create the AF_XDP
+// sockets and to insert them into the map
+package xdp
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	defaultPinDir = "/sys/fs/bpf/nsm-sriov"
+	pinDirPerm    = 0o700
+)
+
+// Netlink is a netlink package interface
+type Netlink interface {
+	LinkByName(name string) (netlink.Link, error)
+	LinkSetXdpFdWithFlags(link netlink.Link, fd, flags int) error
+}
+
+type netlinkFuncs struct{}
+
+func (netlinkFuncs) LinkByName(name string) (netlink.Link, error) {
+	return netlink.LinkByName(name)
+}
+
+func (netlinkFuncs) LinkSetXdpFdWithFlags(link netlink.Link, fd, flags int) error {
+	return netlink.LinkSetXdpFdWithFlags(link, fd, flags)
+}
+
+// Preparer prepares the kernel driver VFs for the AF_XDP clients
+type Preparer struct {
+	bpf            BPF
+	netlink        Netlink
+	pinDir         string
+	programPinPath string
+	mapPinPath     string
+	xdpFlags       int
+	prepared       map[string]*Prepared // prepared[interface name] -> *Prepared
+	lock           sync.Mutex
+}
+
+// NewPreparer returns a new Preparer
+func NewPreparer(options ...Option) *Preparer {
+	p := &Preparer{
+		bpf:      syscallBPF{},
+		netlink:  netlinkFuncs{},
+		pinDir:   defaultPinDir,
+		xdpFlags: unix.XDP_FLAGS_DRV_MODE,
+		prepared: map[string]*Prepared{},
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Prepare attaches the XDP program to the ifName net interface and returns the XSKMAP for the client. By default the
+// per-interface XSKMAP is created, pinned in the pin directory and the built-in redirect program is loaded for it, with
+// WithProgram the provided pinned program and map are used instead. Prepare is idempotent for the same ifName.
+func (p *Preparer) Prepare(ifName string) (*Prepared, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if prepared, ok := p.prepared[ifName]; ok {
+		return prepared, nil
+	}
+
+	link, err := p.netlink.LinkByName(ifName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find VF net interface: %s", ifName)
+	}
+
+	prepared := &Prepared{
+		InterfaceName: ifName,
+		QueueCount:    link.Attrs().NumRxQueues,
+	}
+	if prepared.QueueCount == 0 {
+		prepared.QueueCount = 1
+	}
+
+	if p.programPinPath != "" {
+		err = p.getProgram(prepared)
+	} else {
+		err = p.loadProgram(prepared)
+	}
+	if err != nil {
+		prepared.close()
+		return nil, err
+	}
+
+	if err := p.netlink.LinkSetXdpFdWithFlags(link, int(prepared.program.Fd()), p.xdpFlags); err != nil {
+		p.unpin(prepared)
+		prepared.close()
+		return nil, errors.Wrapf(err, "failed to attach XDP program to the VF net interface: %s", ifName)
+	}
+	p.prepared[ifName] = prepared
+
+	return prepared, nil
+}
+
+func (p *Preparer) getProgram(prepared *Prepared) (err error) {
+	if prepared.program, err = p.bpf.Get(p.programPinPath); err != nil {
+		return err
+	}
+	prepared.XSKMap, err = p.bpf.Get(p.mapPinPath)
+	return err
+}
+
+func (p *Preparer) loadProgram(prepared *Prepared) (err error) {
+	if prepared.XSKMap, err = p.bpf.CreateXSKMap(uint32(prepared.QueueCount)); err != nil {
+		return err
+	}
+	if prepared.program, err = p.bpf.LoadRedirectProgram(prepared.XSKMap); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(p.pinDir, pinDirPerm); err != nil {
+		return errors.Wrapf(err, "failed to create pin directory: %s", p.pinDir)
+	}
+	pinPath := filepath.Join(p.pinDir, prepared.InterfaceName+"_xsks_map")
+	_ = os.Remove(pinPath)
+	if err := p.bpf.Pin(prepared.XSKMap, pinPath); err != nil {
+		return err
+	}
+	prepared.pinPath = pinPath
+
+	return nil
+}
+
+func (p *Preparer) unpin(prepared *Prepared) {
+	if prepared.pinPath != "" {
+		_ = os.Remove(prepared.pinPath)
+	}
+}
+
+// Release detaches the XDP program from the ifName net interface and closes the XSKMAP, does nothing if the
+// interface is not prepared
+func (p *Preparer) Release(ifName string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	prepared, ok := p.prepared[ifName]
+	if !ok {
+		return nil
+	}
+	delete(p.prepared, ifName)
+	defer prepared.close()
+	defer p.unpin(prepared)
+
+	link, err := p.netlink.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find VF net interface: %s", ifName)
+	}
+	if err := p.netlink.LinkSetXdpFdWithFlags(link, -1, p.xdpFlags); err != nil {
+		return errors.Wrapf(err, "failed to detach XDP program from the VF net interface: %s", ifName)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package xdp_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ljkiraly/sdk-sriov/pkg/tools/xdp"
+)
+
+const ifName = "ens1f0v1"
+
+type bpfStub struct {
+	dir  string
+	pins map[string]string
+}
+
+func (b *bpfStub) file(name string) (*os.File, error) {
+	return os.Create(filepath.Join(b.dir, name))
+}
+
+func (b *bpfStub) CreateXSKMap(maxEntries uint32) (*os.File, error) {
+	if maxEntries == 0 {
+		return nil, errors.New("invalid max entries")
+	}
+	return b.file("xsks_map")
+}
+
+func (b *bpfStub) LoadRedirectProgram(_ *os.File) (*os.File, error) {
+	return b.file("prog")
+}
+
+func (b *bpfStub) Get(pinPath string) (*os.File, error) {
+	name, ok := b.pins[pinPath]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return b.file(name)
+}
+
+func (b *bpfStub) Pin(file *os.File, pinPath string) error {
+	b.pins[pinPath] = filepath.Base(file.Name())
+	return os.WriteFile(pinPath, nil, 0o600)
+}
+
+type netlinkStub struct {
+	attached map[string]int
+}
+
+func (n *netlinkStub) LinkByName(name string) (netlink.Link, error) {
+	if name != ifName {
+		return nil, errors.Errorf("link not found: %s", name)
+	}
+	return &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: name, NumRxQueues: 4}}, nil
+}
+
+func (n *netlinkStub) LinkSetXdpFdWithFlags(link netlink.Link, fd, _ int) error {
+	if fd < 0 {
+		delete(n.attached, link.Attrs().Name)
+		return nil
+	}
+	n.attached[link.Attrs().Name] = fd
+	return nil
+}
+
+func TestPreparer_BuiltInProgram(t *testing.T) {
+	pinDir := filepath.Join(t.TempDir(), "bpf")
+	bpf := &bpfStub{dir: t.TempDir(), pins: map[string]string{}}
+	nl := &netlinkStub{attached: map[string]int{}}
+
+	p := xdp.NewPreparer(xdp.WithBPF(bpf), xdp.WithNetlink(nl), xdp.WithPinDir(pinDir))
+
+	prepared, err := p.Prepare(ifName)
+	require.NoError(t, err)
+	require.Equal(t, ifName, prepared.InterfaceName)
+	require.Equal(t, 4, prepared.QueueCount)
+	require.Equal(t, "xsks_map", filepath.Base(prepared.XSKMap.Name()))
+	require.Contains(t, nl.attached, ifName)
+
+	pinPath := filepath.Join(pinDir, ifName+"_xsks_map")
+	require.FileExists(t, pinPath)
+
+	again, err := p.Prepare(ifName)
+	require.NoError(t, err)
+	require.Same(t, prepared, again)
+
+	require.NoError(t, p.Release(ifName))
+	require.Empty(t, nl.attached)
+	require.NoFileExists(t, pinPath)
+
+	require.NoError(t, p.Release(ifName))
+}
+
+func TestPreparer_ProvidedProgram(t *testing.T) {
+	bpf := &bpfStub{
+		dir: t.TempDir(),
+		pins: map[string]string{
+			"/sys/fs/bpf/afxdp/prog":     "provided_prog",
+			"/sys/fs/bpf/afxdp/xsks_map": "provided_map",
+		},
+	}
+	nl := &netlinkStub{attached: map[string]int{}}
+
+	p := xdp.NewPreparer(xdp.WithBPF(bpf), xdp.WithNetlink(nl),
+		xdp.WithProgram("/sys/fs/bpf/afxdp/prog", "/sys/fs/bpf/afxdp/xsks_map"))
+
+	prepared, err := p.Prepare(ifName)
+	require.NoError(t, err)
+	require.Equal(t, "provided_map", filepath.Base(prepared.XSKMap.Name()))
+	require.Contains(t, nl.attached, ifName)
+
+	require.NoError(t, p.Release(ifName))
+	require.Empty(t, nl.attached)
+}
+
+func TestPreparer_NoInterface(t *testing.T) {
+	bpf := &bpfStub{dir: t.TempDir(), pins: map[string]string{}}
+	nl := &netlinkStub{attached: map[string]int{}}
+
+	p := xdp.NewPreparer(xdp.WithBPF(bpf), xdp.WithNetlink(nl), xdp.WithPinDir(t.TempDir()))
+
+	_, err := p.Prepare("missing")
+	require.ErrorContains(t, err, "failed to find VF net interface: missing")
+}
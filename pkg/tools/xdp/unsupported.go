@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package xdp
+
+import "github.com/ljkiraly/sdk-sriov/pkg/sriov"
+
+// Preparer fails all the preparations with sriov.UnsupportedError, AF_XDP is supported on Linux only
+type Preparer struct{}
+
+// Option is an option for NewPreparer
+type Option func(p *Preparer)
+
+// NewPreparer returns a new Preparer
+func NewPreparer(_ ...Option) *Preparer {
+	return &Preparer{}
+}
+
+// Prepare returns sriov.UnsupportedError
+func (p *Preparer) Prepare(string) (*Prepared, error) {
+	return nil, sriov.NewUnsupportedError("AF_XDP")
+}
+
+// Release returns sriov.UnsupportedError
+func (p *Preparer) Release(string) error {
+	return sriov.NewUnsupportedError("AF_XDP")
+}
@@ -32,7 +32,12 @@ func UnmarshalFile(fileName string, o interface{}) error {
 		return errors.Wrapf(err, "error reading file: %v", fileName)
 	}
 
-	if err = yaml.Unmarshal(bytes, o); err != nil {
+	return Unmarshal(bytes, o)
+}
+
+// Unmarshal unmarshal YAML bytes into the object
+func Unmarshal(bytes []byte, o interface{}) error {
+	if err := yaml.Unmarshal(bytes, o); err != nil {
 		return errors.Wrapf(err, "error unmarshalling yaml: %s", bytes)
 	}
 